@@ -0,0 +1,12 @@
+package ecr
+
+// Name is the full name including the tag.
+func (i *Image) Name() string {
+	return i.Image + ":" + i.Tag
+}
+
+// Labels sets the "common/image" label to the full name of the image so
+// that it is shown when listing artifacts.
+func (i *Image) Labels() map[string]string {
+	return map[string]string{"common/image": i.Name()}
+}
@@ -4,3 +4,9 @@ package docker
 func (i *Image) Name() string {
 	return i.Image + ":" + i.Tag
 }
+
+// Labels sets the "common/image" label to the full name of the image so
+// that it is shown when listing builds and artifacts.
+func (i *Image) Labels() map[string]string {
+	return map[string]string{"common/image": i.Name()}
+}
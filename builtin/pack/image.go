@@ -1,5 +1,10 @@
 package pack
 
 func (i *DockerImage) Labels() map[string]string {
-	return i.BuildLabels
+	labels := map[string]string{"common/image": i.Image + ":" + i.Tag}
+	for k, v := range i.BuildLabels {
+		labels[k] = v
+	}
+
+	return labels
 }
@@ -3,10 +3,13 @@ package ceb
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
+	"sync"
+	"syscall"
 
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc/codes"
@@ -25,6 +28,14 @@ const (
 	envCEBDisable          = "WAYPOINT_CEB_DISABLE"
 	envCEBServerRequired   = "WAYPOINT_CEB_SERVER_REQUIRED"
 	envCEBToken            = "WAYPOINT_CEB_INVITE_TOKEN"
+	envCEBConfigFile       = "WAYPOINT_CEB_CONFIG_FILE"
+	envCEBAppDataDir       = "WAYPOINT_CEB_APP_DATA_DIR"
+
+	// envConfigFile is set for the child to the path of the file with the
+	// current config values and envAppDataDir to the directory that config
+	// files are written to.
+	envConfigFile = "WAYPOINT_CONFIG_FILE"
+	envAppDataDir = "WAYPOINT_APP_DATA_DIR"
 )
 
 const (
@@ -41,6 +52,29 @@ type CEB struct {
 	childCmd     *exec.Cmd
 	execIdx      int64
 
+	// cert is the client certificate issued by the server. It is rotated
+	// before it expires.
+	certMu sync.Mutex
+	cert   *tls.Certificate
+
+	// envVars are the config variables from the server and values is
+	// what they resolve to. env is the environment the child is started
+	// with and fileModes are the files written to appDataDir. If a value
+	// changes once the child started, it is restarted, or reloaded with
+	// SIGHUP if only no_restart variables changed. configFile is where
+	// the env values are written for the child to reload.
+	envMu          sync.Mutex
+	envResolveMu   sync.Mutex
+	envVars        []*pb.ConfigVar
+	values         map[string]string
+	env            map[string]string
+	fileModes      map[string]os.FileMode
+	configFile     string
+	appDataDir     string
+	childStarted   bool
+	childRestartCh chan struct{}
+	childReloadCh  chan struct{}
+
 	cleanupFunc func()
 }
 
@@ -59,9 +93,11 @@ func Run(ctx context.Context, os ...Option) error {
 
 	// Defaults, initialization
 	ceb := &CEB{
-		id:      id,
-		logger:  hclog.L(),
-		context: ctx,
+		id:             id,
+		logger:         hclog.L(),
+		context:        ctx,
+		childRestartCh: make(chan struct{}, 1),
+		childReloadCh:  make(chan struct{}, 1),
 	}
 	defer ceb.Close()
 
@@ -74,6 +110,9 @@ func Run(ctx context.Context, os ...Option) error {
 		}
 	}
 
+	ceb.configFile = configFilePath(&cfg)
+	ceb.appDataDir = appDataDirPath(&cfg)
+
 	ceb.logger.Info("entrypoint starting",
 		"deployment_id", ceb.deploymentId,
 		"instance_id", ceb.id,
@@ -100,21 +139,45 @@ func Run(ctx context.Context, os ...Option) error {
 		if err := ceb.init(ctx, &cfg, false); err != nil {
 			return err
 		}
+
+		go ceb.watchDynamicConfig(ctx)
 	}
 
 	// Run our subprocess
 	errCh := ceb.execChildCmd(ctx)
-	select {
-	case err := <-errCh:
-		return err
-
-	case <-ctx.Done():
-		ceb.logger.Info("received cancellation request, gracefully exiting")
-		ceb.childCmd.Process.Kill()
-		<-errCh
-	}
+	for {
+		select {
+		case err := <-errCh:
+			return err
 
-	return nil
+		case <-ceb.childRestartCh:
+			ceb.logger.Info("config changed, restarting child process")
+			ceb.stopChildCmd(errCh)
+			oldCmd := ceb.childCmd
+			if err := ceb.initChildCmd(ctx, &cfg); err != nil {
+				return err
+			}
+
+			// Keep the output going to the same place, such as the log
+			// stream to the server.
+			ceb.childCmd.Stdout = oldCmd.Stdout
+			ceb.childCmd.Stderr = oldCmd.Stderr
+
+			errCh = ceb.execChildCmd(ctx)
+
+		case <-ceb.childReloadCh:
+			ceb.logger.Info("config changed, sending SIGHUP to child process")
+			if err := ceb.childCmd.Process.Signal(syscall.SIGHUP); err != nil {
+				ceb.logger.Warn("error sending SIGHUP to child process", "err", err)
+			}
+
+		case <-ctx.Done():
+			ceb.logger.Info("received cancellation request, gracefully exiting")
+			ceb.childCmd.Process.Kill()
+			<-errCh
+			return nil
+		}
+	}
 }
 
 // Close cleans up any resources created by the CEB and should be called
@@ -152,6 +215,8 @@ type config struct {
 	ServerTls           bool
 	ServerTlsSkipVerify bool
 	InviteToken         string
+	ConfigFile          string
+	AppDataDir          string
 
 	URLServicePort int
 }
@@ -183,6 +248,8 @@ func WithEnvDefaults() Option {
 		cfg.ServerTls = os.Getenv(envServerTls) != ""
 		cfg.ServerTlsSkipVerify = os.Getenv(envServerTlsSkipVerify) != ""
 		cfg.InviteToken = os.Getenv(envCEBToken)
+		cfg.ConfigFile = os.Getenv(envCEBConfigFile)
+		cfg.AppDataDir = os.Getenv(envCEBAppDataDir)
 		cfg.disable = os.Getenv(envCEBDisable) != ""
 
 		ceb.deploymentId = os.Getenv(envDeploymentId)
@@ -0,0 +1,112 @@
+package ceb
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// certRetryInterval is how long we wait to try again if rotating the
+// client certificate fails.
+const certRetryInterval = time.Minute
+
+// clientCert implements tls.Config.GetClientCertificate. It returns the
+// current client certificate, or no certificate if we don't have one yet.
+// New connections always use the latest certificate.
+func (ceb *CEB) clientCert(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	ceb.certMu.Lock()
+	defer ceb.certMu.Unlock()
+
+	if ceb.cert == nil {
+		return &tls.Certificate{}, nil
+	}
+
+	return ceb.cert, nil
+}
+
+// issueCert requests a new client certificate from the server. The private
+// key never leaves the entrypoint. This returns when the certificate
+// expires.
+func (ceb *CEB) issueCert(ctx context.Context, client pb.WaypointClient) (time.Time, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	resp, err := client.EntrypointIssueCert(ctx, &pb.EntrypointIssueCertRequest{
+		DeploymentId: ceb.deploymentId,
+		InstanceId:   ceb.id,
+		Csr:          pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	cert, err := tls.X509KeyPair(resp.Cert,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expire, err := ptypes.Timestamp(resp.ExpireTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ceb.certMu.Lock()
+	defer ceb.certMu.Unlock()
+	ceb.cert = &cert
+
+	return expire, nil
+}
+
+// rotateCert gets a new client certificate when two thirds of the
+// lifetime of the current one have passed. This runs until ctx is done.
+func (ceb *CEB) rotateCert(ctx context.Context, client pb.WaypointClient, expire time.Time) {
+	log := ceb.logger.Named("cert")
+	for {
+		wait := time.Until(expire) * 2 / 3
+		if wait < 0 {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(wait):
+		}
+
+		next, err := ceb.issueCert(ctx, client)
+		if err != nil {
+			log.Warn("error rotating client certificate, will retry", "err", err)
+
+			// Retry sooner since the current certificate is running out.
+			// Connections that are already open keep working.
+			expire = time.Now().Add(certRetryInterval * 3 / 2)
+			continue
+		}
+
+		log.Debug("rotated client certificate", "expire", next)
+		expire = next
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// childStopTimeout is how long the child process has to exit when it is
+// restarted before it is killed.
+const childStopTimeout = 10 * time.Second
+
 // initChildCmd initializes the child command that we'll execute when
 // we run. This just sets the `childCmd` field on the CEB structure. This
 // does not have any side effecting behavior.
@@ -29,6 +35,17 @@ func (ceb *CEB) execChildCmd(ctx context.Context) <-chan error {
 	ch := make(chan error, 1)
 	cmd := ceb.childCmd
 
+	// Add the environment from config variables. Any later changes restart
+	// the child so that it gets them.
+	ceb.envMu.Lock()
+	cmd.Env = append(cmd.Env, envList(ceb.env)...)
+	cmd.Env = append(cmd.Env,
+		envConfigFile+"="+ceb.configFile,
+		envAppDataDir+"="+ceb.appDataDir,
+	)
+	ceb.childStarted = true
+	ceb.envMu.Unlock()
+
 	// Start our subprocess
 	log := ceb.logger.With(
 		"cmd", cmd.Path,
@@ -56,6 +73,23 @@ func (ceb *CEB) execChildCmd(ctx context.Context) <-chan error {
 	return ch
 }
 
+// stopChildCmd asks the child process to exit, killing it if it doesn't
+// within childStopTimeout. errCh is the channel from execChildCmd.
+func (ceb *CEB) stopChildCmd(errCh <-chan error) {
+	proc := ceb.childCmd.Process
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		proc.Kill()
+	}
+
+	select {
+	case <-errCh:
+	case <-time.After(childStopTimeout):
+		ceb.logger.Warn("child process didn't exit in time, killing it")
+		proc.Kill()
+		<-errCh
+	}
+}
+
 func (ceb *CEB) buildCmd(ctx context.Context, args []string) (*exec.Cmd, error) {
 	// Avoid a crash below by verifying we got some arguments.
 	if len(args) == 0 {
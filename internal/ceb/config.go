@@ -43,10 +43,8 @@ func (ceb *CEB) initConfigStream(ctx context.Context, cfg *config, isRetry bool)
 	}
 	log.Trace("first config received")
 
-	// Modify childCmd to contain any passed variables as environment variables
-	for _, cv := range resp.Config.EnvVars {
-		ceb.childCmd.Env = append(ceb.childCmd.Env, cv.Name+"="+cv.Value)
-	}
+	// Set the environment the child is started with from the variables
+	ceb.setEnvVars(ctx, resp.Config.EnvVars)
 
 	// If we have URL service configuration, start it. We start this in a goroutine
 	// since we don't need to block starting up our application on this.
@@ -62,7 +60,7 @@ func (ceb *CEB) initConfigStream(ctx context.Context, cfg *config, isRetry bool)
 
 	// Start the watcher
 	ch := make(chan *pb.EntrypointConfig)
-	go ceb.watchConfig(ch)
+	go ceb.watchConfig(ctx, ch)
 
 	// Send the first config which will trigger setup
 	ch <- resp.Config
@@ -77,8 +75,11 @@ func (ceb *CEB) initConfigStream(ctx context.Context, cfg *config, isRetry bool)
 
 // watchConfig sits in a goroutine receiving the new configurations from the
 // server.
-func (ceb *CEB) watchConfig(ch <-chan *pb.EntrypointConfig) {
+func (ceb *CEB) watchConfig(ctx context.Context, ch <-chan *pb.EntrypointConfig) {
 	for config := range ch {
+		// Restart the child if the variables changed
+		ceb.setEnvVars(ctx, config.EnvVars)
+
 		// Start the exec sessions if we have any
 		if len(config.Exec) > 0 {
 			ceb.startExecGroup(config.Exec)
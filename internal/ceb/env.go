@@ -0,0 +1,350 @@
+package ceb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hashicorp/waypoint/internal/configsource"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// configRefreshInterval is how often dynamic config values are read
+	// again from their source.
+	configRefreshInterval = 5 * time.Minute
+
+	// configReadTimeout is how long reading one dynamic value may take.
+	configReadTimeout = 30 * time.Second
+)
+
+// setEnvVars sets the config variables from the server and resolves the
+// environment for the child. This does nothing if the variables didn't
+// change.
+func (ceb *CEB) setEnvVars(ctx context.Context, vars []*pb.ConfigVar) {
+	ceb.envMu.Lock()
+	same := configVarsEqual(ceb.envVars, vars)
+	if !same {
+		ceb.envVars = vars
+	}
+	ceb.envMu.Unlock()
+
+	if !same {
+		ceb.resolveEnv(ctx)
+	}
+}
+
+// resolveEnv builds the child environment and files from the current
+// config variables, reading dynamic values from their source. If anything
+// changed after the child started, it is restarted. If only no_restart
+// variables changed, the child is sent SIGHUP to reload instead.
+func (ceb *CEB) resolveEnv(ctx context.Context) {
+	log := ceb.logger.Named("config")
+
+	// Only one resolve at a time so an older one can't overwrite a
+	// newer result.
+	ceb.envResolveMu.Lock()
+	defer ceb.envResolveMu.Unlock()
+
+	ceb.envMu.Lock()
+	vars := ceb.envVars
+	previous := ceb.values
+	previousModes := ceb.fileModes
+	ceb.envMu.Unlock()
+
+	values := map[string]string{}
+	noRestart := map[string]bool{}
+	for _, v := range vars {
+		if v.NoRestart {
+			noRestart[v.Name] = true
+		}
+
+		if v.Dynamic == nil {
+			values[v.Name] = v.Value
+			continue
+		}
+
+		readCtx, cancel := context.WithTimeout(ctx, configReadTimeout)
+		value, err := configsource.Read(readCtx, v.Dynamic)
+		cancel()
+		if err != nil {
+			// Keep the last value we read so a source being unavailable
+			// doesn't restart the app without it.
+			log.Warn("error reading dynamic config value",
+				"name", v.Name, "from", v.Dynamic.From, "err", err)
+			if old, ok := previous[v.Name]; ok {
+				values[v.Name] = old
+			}
+
+			continue
+		}
+
+		values[v.Name] = value
+	}
+
+	// Split the values into the environment and files.
+	env := map[string]string{}
+	modes := map[string]os.FileMode{}
+	for _, v := range vars {
+		value, ok := values[v.Name]
+		if !ok {
+			continue
+		}
+
+		if v.File != nil {
+			modes[v.Name] = os.FileMode(v.File.Mode)
+		} else {
+			env[v.Name] = value
+		}
+	}
+
+	changed := envChanged(previous, values)
+	for name, mode := range modes {
+		if old, ok := previousModes[name]; ok && old != mode {
+			changed = append(changed, name)
+		}
+	}
+
+	// Removed variables always restart since they can't be unset in the
+	// environment of a running process.
+	restart := false
+	for _, k := range changed {
+		if !noRestart[k] {
+			restart = true
+		}
+	}
+
+	if previous == nil || len(changed) > 0 {
+		if err := ceb.writeConfigFile(env); err != nil {
+			log.Warn("error writing config file", "path", ceb.configFile, "err", err)
+		}
+
+		for name, mode := range modes {
+			if err := ceb.writeAppFile(name, values[name], mode); err != nil {
+				log.Warn("error writing config file", "name", name, "err", err)
+			}
+		}
+		for name := range previousModes {
+			if _, ok := modes[name]; !ok {
+				if err := ceb.removeAppFile(name); err != nil {
+					log.Warn("error removing config file", "name", name, "err", err)
+				}
+			}
+		}
+	}
+
+	ceb.envMu.Lock()
+	ceb.values = values
+	ceb.env = env
+	ceb.fileModes = modes
+	started := ceb.childStarted
+	ceb.envMu.Unlock()
+
+	if !started || len(changed) == 0 {
+		return
+	}
+
+	ch := ceb.childReloadCh
+	if restart {
+		ch = ceb.childRestartCh
+	}
+
+	select {
+	case ch <- struct{}{}:
+	default:
+		// One is already pending and it will see the new values.
+	}
+}
+
+// writeConfigFile writes the config values as a JSON object to the config
+// file that the child can read them from. The file is replaced atomically
+// so the child never reads a partial file.
+func (ceb *CEB) writeConfigFile(env map[string]string) error {
+	if ceb.configFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(ceb.configFile), ".waypoint-config")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), ceb.configFile)
+}
+
+// appFilePath returns the path of a config file in the app data directory.
+// This errors if the name would be outside of the directory.
+func (ceb *CEB) appFilePath(name string) (string, error) {
+	path := filepath.Join(ceb.appDataDir, name)
+	rel, err := filepath.Rel(ceb.appDataDir, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file %q is outside of the app data directory", name)
+	}
+
+	return path, nil
+}
+
+// writeAppFile writes a config file to the app data directory. Like the
+// config file, it is replaced atomically.
+func (ceb *CEB) writeAppFile(name, contents string, mode os.FileMode) error {
+	path, err := ceb.appFilePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), ".waypoint-file")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(contents)
+	if err == nil {
+		err = f.Chmod(mode)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// removeAppFile removes a config file that is no longer set.
+func (ceb *CEB) removeAppFile(name string) error {
+	path, err := ceb.appFilePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+
+	return err
+}
+
+// appDataDirPath returns the app data directory that config files are
+// written to.
+func appDataDirPath(cfg *config) string {
+	if cfg.AppDataDir != "" {
+		return cfg.AppDataDir
+	}
+
+	return filepath.Join(os.TempDir(), "waypoint-app-data")
+}
+
+// configFilePath returns the path of the config file for the child.
+func configFilePath(cfg *config) string {
+	if cfg.ConfigFile != "" {
+		return cfg.ConfigFile
+	}
+
+	return filepath.Join(os.TempDir(), "waypoint-config.json")
+}
+
+// watchDynamicConfig reads dynamic config values again periodically so
+// that the child is restarted when they change at the source. This runs
+// until ctx is done.
+func (ceb *CEB) watchDynamicConfig(ctx context.Context) {
+	ticker := time.NewTicker(configRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		ceb.envMu.Lock()
+		dynamic := false
+		for _, v := range ceb.envVars {
+			if v.Dynamic != nil {
+				dynamic = true
+				break
+			}
+		}
+		ceb.envMu.Unlock()
+
+		if dynamic {
+			ceb.resolveEnv(ctx)
+		}
+	}
+}
+
+// envList returns the environment as sorted "key=value" pairs.
+func envList(env map[string]string) []string {
+	result := make([]string, 0, len(env))
+	for k, v := range env {
+		result = append(result, k+"="+v)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// envChanged returns the sorted names of the values that are different
+// between a and b, including ones that are only in one of them.
+func envChanged(a, b map[string]string) []string {
+	var result []string
+	for k, v := range a {
+		if other, ok := b[k]; !ok || other != v {
+			result = append(result, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			result = append(result, k)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func configVarsEqual(a, b []*pb.ConfigVar) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+package ceb
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestCEBSetEnvVars(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	td, err := ioutil.TempDir("", "ceb")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+
+	ceb := &CEB{
+		logger:         hclog.L(),
+		configFile:     filepath.Join(td, "config.json"),
+		appDataDir:     filepath.Join(td, "data"),
+		childRestartCh: make(chan struct{}, 1),
+		childReloadCh:  make(chan struct{}, 1),
+	}
+
+	// Before the child starts we never restart
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{{Name: "A", Value: "1"}})
+	require.Equal([]string{"A=1"}, envList(ceb.env))
+	require.Len(ceb.childRestartCh, 0)
+
+	// Same vars after the child starts
+	ceb.childStarted = true
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{{Name: "A", Value: "1"}})
+	require.Len(ceb.childRestartCh, 0)
+
+	// Changed vars
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{
+		{Name: "B", Value: "2"},
+		{Name: "A", Value: "3"},
+	})
+	require.Equal([]string{"A=3", "B=2"}, envList(ceb.env))
+	require.Len(ceb.childRestartCh, 1)
+
+	// A dynamic value that can't be read isn't set
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{
+		{Name: "A", Value: "3"},
+		{Name: "C", Dynamic: &pb.ConfigVar_DynamicVal{From: "nope"}},
+	})
+	require.Equal([]string{"A=3"}, envList(ceb.env))
+	<-ceb.childRestartCh
+
+	// Changing only a no_restart variable reloads instead
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{
+		{Name: "A", Value: "4", NoRestart: true},
+	})
+	require.Len(ceb.childRestartCh, 0)
+	require.Len(ceb.childReloadCh, 1)
+
+	data, err := ioutil.ReadFile(ceb.configFile)
+	require.NoError(err)
+	var values map[string]string
+	require.NoError(json.Unmarshal(data, &values))
+	require.Equal(map[string]string{"A": "4"}, values)
+	<-ceb.childReloadCh
+
+	// Files are written to the app data dir and not set in the env
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{
+		{Name: "A", Value: "4", NoRestart: true},
+		{Name: "tls/cert.pem", Value: "cert", File: &pb.ConfigVar_FileOptions{Mode: 0640}},
+	})
+	require.Equal([]string{"A=4"}, envList(ceb.env))
+	require.Len(ceb.childRestartCh, 1)
+
+	path := filepath.Join(ceb.appDataDir, "tls", "cert.pem")
+	data, err = ioutil.ReadFile(path)
+	require.NoError(err)
+	require.Equal("cert", string(data))
+	fi, err := os.Stat(path)
+	require.NoError(err)
+	require.Equal(os.FileMode(0640), fi.Mode().Perm())
+
+	// Removed files are deleted
+	ceb.setEnvVars(ctx, []*pb.ConfigVar{
+		{Name: "A", Value: "4", NoRestart: true},
+	})
+	_, err = os.Stat(path)
+	require.True(os.IsNotExist(err))
+
+	// Files can't be outside the app data dir
+	_, err = ceb.appFilePath("../escape")
+	require.Error(err)
+}
@@ -3,8 +3,10 @@ package ceb
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-hclog"
@@ -51,6 +53,7 @@ func (ceb *CEB) initLogStream(ctx context.Context, cfg *config) error {
 			entry := &pb.LogBatch_Entry{
 				Timestamp: ptypes.TimestampNow(),
 				Line:      line,
+				Fields:    logFields(line),
 			}
 
 			// Send the entry. We never block here because blocking the
@@ -117,3 +120,31 @@ func (ceb *CEB) initLogStreamSender(
 
 	return nil
 }
+
+// logFields returns the top-level fields of a line that is a JSON object so
+// that the server can filter on them. This returns nil for any other line.
+// String values are used as they are and other values are JSON encoded.
+func logFields(line string) map[string]string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(obj))
+	for k, raw := range obj {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			result[k] = s
+			continue
+		}
+
+		result[k] = string(raw)
+	}
+
+	return result
+}
@@ -140,3 +140,47 @@ func TestLogs_reconnect(t *testing.T) {
 		return len(batch.Lines) > 0
 	}, 1*time.Second, 50*time.Millisecond)
 }
+
+func TestLogFields(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Line     string
+		Expected map[string]string
+	}{
+		{
+			"plain text",
+			"hello world\n",
+			nil,
+		},
+
+		{
+			"invalid json",
+			"{not json\n",
+			nil,
+		},
+
+		{
+			"json array",
+			"[1, 2]\n",
+			nil,
+		},
+
+		{
+			"json object",
+			`{"level":"error","msg":"bad","code":42,"ok":false,"ctx":{"a":1}}` + "\n",
+			map[string]string{
+				"level": "error",
+				"msg":   "bad",
+				"code":  "42",
+				"ok":    "false",
+				"ctx":   `{"a":1}`,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, logFields(tt.Line))
+		})
+	}
+}
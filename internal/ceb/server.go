@@ -25,13 +25,18 @@ func (ceb *CEB) dialServer(ctx context.Context, cfg *config, isRetry bool) error
 		grpc.WithStreamInterceptor(protocolversion.StreamClientInterceptor(protocolversion.Current())),
 	}
 	if !cfg.ServerTls {
+		ceb.logger.Warn("connecting to the server without TLS is deprecated, " +
+			"the connection can't use client certificates")
 		grpcOpts = append(grpcOpts, grpc.WithInsecure())
 	} else {
-		if cfg.ServerTlsSkipVerify {
-			grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(
-				credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}),
-			))
-		}
+		// Once we have a client certificate from the server it's presented
+		// on every new connection, making it mutual TLS.
+		grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(
+			credentials.NewTLS(&tls.Config{
+				InsecureSkipVerify:   cfg.ServerTlsSkipVerify,
+				GetClientCertificate: ceb.clientCert,
+			}),
+		))
 	}
 
 	// Connect to this server
@@ -107,6 +112,26 @@ func (ceb *CEB) dialServer(ctx context.Context, cfg *config, isRetry bool) error
 		client = pb.NewWaypointClient(conn)
 	}
 
+	// With TLS and a token we can get a client certificate. We reconnect
+	// so that the connection uses it.
+	var certExpire time.Time
+	if cfg.ServerTls && cfg.InviteToken != "" {
+		certExpire, err = ceb.issueCert(ctx, client)
+		if status.Code(err) == codes.Unimplemented {
+			ceb.logger.Warn("server doesn't issue client certificates, using the token only")
+		} else if err != nil {
+			return err
+		} else {
+			conn.Close()
+			ceb.logger.Info("reconnecting to server with client certificate")
+			conn, err = grpc.DialContext(ctx, cfg.ServerAddr, grpcOpts...)
+			if err != nil {
+				return err
+			}
+			client = pb.NewWaypointClient(conn)
+		}
+	}
+
 	// Negotiate API version
 	ceb.logger.Trace("requesting version info from server")
 	vsnResp, err := client.GetVersionInfo(ctx, &empty.Empty{}, grpc.WaitForReady(isRetry))
@@ -134,6 +159,13 @@ func (ceb *CEB) dialServer(ctx context.Context, cfg *config, isRetry bool) error
 	conn = nil
 	ceb.cleanup(func() { connCopy.Close() })
 
+	// Keep the client certificate fresh for reconnects.
+	if !certExpire.IsZero() {
+		rotateCtx, cancel := context.WithCancel(ceb.context)
+		ceb.cleanup(cancel)
+		go ceb.rotateCert(rotateCtx, client, certExpire)
+	}
+
 	return nil
 }
 
@@ -15,7 +15,8 @@ import (
 type ArtifactBuildCommand struct {
 	*baseCommand
 
-	flagPush bool
+	flagPush    bool
+	flagNoCache bool
 }
 
 func (c *ArtifactBuildCommand) Run(args []string) int {
@@ -30,7 +31,8 @@ func (c *ArtifactBuildCommand) Run(args []string) int {
 
 	c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
 		_, err := app.Build(ctx, &pb.Job_BuildOp{
-			DisablePush: !c.flagPush,
+			DisablePush:  !c.flagPush,
+			DisableCache: c.flagNoCache,
 		})
 		if err != nil {
 			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
@@ -52,6 +54,13 @@ func (c *ArtifactBuildCommand) Flags() *flag.Sets {
 			Default: true,
 			Usage:   "Push the artifact to the configured registry.",
 		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "no-cache",
+			Target: &c.flagNoCache,
+			Usage: "Build even if a previous build of the same source exists. " +
+				"Files matching .waypointignore don't affect whether source is the same.",
+		})
 	})
 }
 
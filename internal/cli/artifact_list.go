@@ -51,10 +51,11 @@ func (c *ArtifactListCommand) Run(args []string) int {
 
 		// List builds
 		resp, err := client.ListPushedArtifacts(c.Ctx, &pb.ListPushedArtifactsRequest{
-			Application:  app.Ref(),
-			Workspace:    wsRef,
-			Order:        c.filterFlags.orderOp(),
-			IncludeBuild: true,
+			Application:        app.Ref(),
+			Workspace:          wsRef,
+			Order:              c.filterFlags.orderOp(),
+			IncludeBuild:       true,
+			IncludeDeployments: true,
 		})
 		if err != nil {
 			c.project.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
@@ -106,8 +107,27 @@ func (c *ArtifactListCommand) Run(args []string) int {
 			}
 
 			details = append(details, fmt.Sprintf("build:%s", c.flagId.FormatId(b.Build.Sequence, b.Build.Id)))
+			details = append(details, "builder:"+b.Build.Component.GetName())
+
+			if img := artifactImage(b); img != "" {
+				details = append(details, "image:"+img)
+			}
+
+			if vcs := formatVCS(b.Build.GetVcs()); vcs != "" {
+				details = append(details, "commit:"+vcs)
+			} else if commit := operationCommit(nil, b.Labels); commit != "" {
+				details = append(details, "commit:"+shortCommit(commit))
+			}
+
+			if len(b.DeploymentIds) > 0 {
+				details = append(details, fmt.Sprintf("deployments:%d", len(b.DeploymentIds)))
+			}
 
 			if c.flagVerbose {
+				for _, id := range b.DeploymentIds {
+					extraDetails = append(extraDetails, "deployment:"+id)
+				}
+
 				for k, val := range b.Labels {
 					if strings.HasPrefix(k, "waypoint/") {
 						continue
@@ -187,6 +207,9 @@ func (c *ArtifactListCommand) displayJson(artifacts []*pb.PushedArtifact) error
 		i["status"] = c.statusJson(art.Status)
 		i["workspace"] = art.Workspace.Workspace
 		i["build"] = c.buildJson(art.Build)
+		i["image"] = artifactImage(art)
+		i["commit"] = operationCommit(art.Build.GetVcs(), art.Labels)
+		i["deployment_ids"] = art.DeploymentIds
 
 		output = append(output, i)
 	}
@@ -217,11 +240,39 @@ func (c *ArtifactListCommand) buildJson(b *pb.Build) interface{} {
 	i["id"] = b.Id
 	i["sequence"] = b.Sequence
 	i["labels"] = b.Labels
+	i["component"] = b.Component.GetName()
 	i["status"] = c.statusJson(b.Status)
+	i["vcs"] = b.Vcs
 
 	return i
 }
 
+// artifactImage returns the image reference of the artifact, such as the
+// image name and tag in the registry, if the plugin recorded one. The
+// image of the build is used if the artifact wasn't pushed to a registry.
+func artifactImage(a *pb.PushedArtifact) string {
+	if img, ok := a.Labels["common/image"]; ok {
+		return img
+	}
+
+	return a.Build.GetLabels()["common/image"]
+}
+
+// operationCommit returns the source commit that the operation was run
+// for. Operations from before the VCS info was recorded only know this if
+// they were queued by the server for a commit, such as by polling or a
+// trigger.
+func operationCommit(vcs *pb.Operation_VCS, labels map[string]string) string {
+	if vcs != nil && vcs.Commit != "" {
+		return vcs.Commit
+	}
+	if commit, ok := labels["waypoint/trigger-commit"]; ok {
+		return commit
+	}
+
+	return labels["waypoint/poll-commit"]
+}
+
 func (c *ArtifactListCommand) Flags() *flag.Sets {
 	return c.flagSet(0, func(set *flag.Sets) {
 		f := set.NewSet("Command Options")
@@ -268,5 +319,10 @@ Usage: waypoint artifact list [options]
   Lists the artifacts that are pushed to a registry. This does not
   list the artifacts that are just part of local builds.
 
+  For each artifact this shows the build it came from and the builder
+  plugin, the image reference in the registry if the plugin records one,
+  the source commit it was built from if it is known, and the number of deployments that haven't been destroyed that use it.
+  Use -verbose to show the labels and the IDs of the deployments.
+
 ` + c.Flags().Help())
 }
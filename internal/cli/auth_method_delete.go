@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type AuthMethodDeleteCommand struct {
+	*baseCommand
+}
+
+func (c *AuthMethodDeleteCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single auth method name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if _, err := c.project.Client().DeleteAuthMethod(c.Ctx, &pb.DeleteAuthMethodRequest{
+		Name: c.args[0],
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Auth method %q deleted.", c.args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *AuthMethodDeleteCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *AuthMethodDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AuthMethodDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *AuthMethodDeleteCommand) Synopsis() string {
+	return "Delete an auth method"
+}
+
+func (c *AuthMethodDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint auth-method delete NAME
+
+  Delete an auth method. Users can no longer log in with it, but tokens
+  that were already issued keep working until they expire.
+
+` + c.Flags().Help())
+}
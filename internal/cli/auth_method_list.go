@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+)
+
+type AuthMethodListCommand struct {
+	*baseCommand
+}
+
+func (c *AuthMethodListCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	resp, err := c.project.Client().ListAuthMethods(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	if len(resp.AuthMethods) == 0 {
+		c.ui.Output("No auth methods found.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("Name", "Display Name", "Role", "Issuer", "Email Domains")
+	for _, m := range resp.AuthMethods {
+		oidc := m.GetOidc()
+		tbl.Rich([]string{
+			m.Name,
+			m.DisplayName,
+			strings.ToLower(m.Role.String()),
+			oidc.GetIssuer(),
+			strings.Join(oidc.GetAllowedEmailDomains(), ", "),
+		}, nil)
+	}
+
+	c.ui.Table(tbl)
+	return 0
+}
+
+func (c *AuthMethodListCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *AuthMethodListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AuthMethodListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *AuthMethodListCommand) Synopsis() string {
+	return "List auth methods"
+}
+
+func (c *AuthMethodListCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint auth-method list
+
+  List the auth methods that users can log in with. Client secrets are
+  never shown.
+
+` + c.Flags().Help())
+}
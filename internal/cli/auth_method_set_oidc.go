@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type AuthMethodSetOIDCCommand struct {
+	*baseCommand
+
+	flagDisplayName    string
+	flagRole           string
+	flagTokenTTL       time.Duration
+	flagIssuer         string
+	flagClientId       string
+	flagClientSecret   string
+	flagScopes         []string
+	flagRedirectURIs   []string
+	flagAllowedDomains []string
+	flagAllowAnyUser   bool
+}
+
+func (c *AuthMethodSetOIDCCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single auth method name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	// The CLI always listens on the loopback address for the redirect.
+	redirects := append([]string{"http://127.0.0.1/oidc/callback"}, c.flagRedirectURIs...)
+
+	// Allowing every user must be explicit since most providers, such as
+	// Google, let anyone with an account log in.
+	domains := c.flagAllowedDomains
+	if c.flagAllowAnyUser {
+		domains = append(domains, "*")
+	}
+	if len(domains) == 0 {
+		c.ui.Output(
+			"At least one -allowed-email-domain is required. To allow every user "+
+				"of the provider to log in, set -allow-any-user.\n\n"+c.Help(),
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := c.project.Client().UpsertAuthMethod(c.Ctx, &pb.UpsertAuthMethodRequest{
+		AuthMethod: &pb.AuthMethod{
+			Name:          c.args[0],
+			DisplayName:   c.flagDisplayName,
+			Role:          pb.Token_Role(pb.Token_Role_value[strings.ToUpper(c.flagRole)]),
+			TokenDuration: tokenDuration(c.flagTokenTTL),
+			Method: &pb.AuthMethod_Oidc{
+				Oidc: &pb.AuthMethod_OIDC{
+					Issuer:              c.flagIssuer,
+					ClientId:            c.flagClientId,
+					ClientSecret:        c.flagClientSecret,
+					Scopes:              c.flagScopes,
+					AllowedRedirectUris: redirects,
+					AllowedEmailDomains: domains,
+				},
+			},
+		},
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Auth method %q set.", resp.AuthMethod.Name, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *AuthMethodSetOIDCCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "display-name",
+			Target: &c.flagDisplayName,
+			Usage:  "Name shown to users choosing an auth method.",
+		})
+
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:    "role",
+			Target:  &c.flagRole,
+			Values:  []string{"operator", "reader"},
+			Default: "reader",
+			Usage:   "The role of the tokens issued to users who log in.",
+		})
+
+		f.DurationVar(&flag.DurationVar{
+			Name:   "token-ttl",
+			Target: &c.flagTokenTTL,
+			Usage:  "How long issued tokens are valid for. Defaults to 24 hours.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "issuer",
+			Target: &c.flagIssuer,
+			Usage:  "URL of the OIDC provider, such as https://accounts.google.com.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "client-id",
+			Target: &c.flagClientId,
+			Usage:  "OAuth client ID registered with the provider.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "client-secret",
+			Target: &c.flagClientSecret,
+			Usage: "OAuth client secret. If this isn't set when updating an auth " +
+				"method, the existing secret is kept.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "scope",
+			Target: &c.flagScopes,
+			Usage:  "Scope to request in addition to openid, such as email. This can be repeated.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "allowed-redirect-uri",
+			Target: &c.flagRedirectURIs,
+			Usage: "Additional redirect URI to allow. The loopback URI used by " +
+				"\"waypoint login\" is always allowed. This can be repeated.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "allowed-email-domain",
+			Target: &c.flagAllowedDomains,
+			Usage: "Only allow users with a verified email address in this domain. " +
+				"This can be repeated.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "allow-any-user",
+			Target: &c.flagAllowAnyUser,
+			Usage: "Allow every user of the provider to log in. Either this or " +
+				"-allowed-email-domain must be set.",
+		})
+	})
+}
+
+func (c *AuthMethodSetOIDCCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AuthMethodSetOIDCCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *AuthMethodSetOIDCCommand) Synopsis() string {
+	return "Create or update an OIDC auth method"
+}
+
+func (c *AuthMethodSetOIDCCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint auth-method set oidc [options] NAME
+
+  Create or update an OIDC auth method. Users log in with it by running
+  "waypoint login". Register "http://127.0.0.1/oidc/callback" as a
+  redirect URI of the OAuth client with the provider.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/posener/complete"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	clientpkg "github.com/hashicorp/waypoint/internal/client"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type AuthStatusCommand struct {
+	*baseCommand
+}
+
+func (c *AuthStatusCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	failed := false
+	table := terminal.NewTable("App", "Component", "Name", "Status", "Error")
+	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
+		result, err := app.Auth(ctx, &pb.Job_AuthOp{CheckOnly: true})
+		if err != nil {
+			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return ErrSentinel
+		}
+
+		for _, r := range result.Results {
+			statusText, color := "ok", terminal.Green
+			errText := ""
+			if !r.AuthSupported {
+				statusText, color = "n/a", ""
+			} else if !r.CheckResult {
+				failed = true
+				statusText, color = "missing", terminal.Red
+				if r.CheckError != nil {
+					errText = status.FromProto(r.CheckError).Message()
+				}
+			}
+
+			table.Rich([]string{
+				app.Ref().Application,
+				strings.ToLower(r.Component.Type.String()),
+				r.Component.Name,
+				statusText,
+				errText,
+			}, []string{
+				"",
+				"",
+				"",
+				color,
+				"",
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 1
+	}
+
+	c.ui.Table(table)
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+func (c *AuthStatusCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *AuthStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *AuthStatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *AuthStatusCommand) Synopsis() string {
+	return "Show whether plugins have valid credentials."
+}
+
+func (c *AuthStatusCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint auth status [options]
+
+  Show whether each plugin has valid credentials available.
+
+  This only checks credentials, it doesn't perform any operation or
+  attempt to authenticate. This can be used to fix authentication
+  issues before running a long build or deploy. The exit code is
+  non-zero if any plugin is missing credentials.
+
+` + c.Flags().Help())
+}
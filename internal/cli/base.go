@@ -3,14 +3,17 @@ package cli
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
@@ -19,6 +22,7 @@ import (
 	"github.com/hashicorp/waypoint/internal/clierrors"
 	"github.com/hashicorp/waypoint/internal/config"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	"github.com/hashicorp/waypoint/internal/pkg/jsonui"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
@@ -72,6 +76,9 @@ type baseCommand struct {
 	// flagPlain is whether the output should be in plain mode.
 	flagPlain bool
 
+	// flagLogLevel is the log level to use for this invocation only.
+	flagLogLevel string
+
 	// flagLabels are set via -label if flagSetOperation is set.
 	flagLabels map[string]string
 
@@ -82,12 +89,42 @@ type baseCommand struct {
 	// flagRemoteSource are the remote data source overrides for jobs.
 	flagRemoteSource map[string]string
 
+	// flagVars and flagVarFiles set input variables of the project via
+	// -var and -var-file if flagSetOperation is set.
+	flagVars     map[string]string
+	flagVarFiles []string
+
+	// flagParallel is the number of apps to operate on at once. If this
+	// is zero, the "parallel" setting in the configuration is used.
+	flagParallel int
+
+	// flagCallTrace, flagComponentPrefix and flagOutputFile configure
+	// diagnostics for local operations. outputFile is the opened
+	// flagOutputFile, closed by Close.
+	flagCallTrace       string
+	flagComponentPrefix bool
+	flagOutputFile      string
+	outputFile          *os.File
+
+	// flagRequireApproval is set by commands that queue deploys to hold
+	// them for approval. flagApprovalTimeout cancels them if they aren't
+	// approved in time.
+	flagRequireApproval bool
+	flagApprovalTimeout time.Duration
+
+	// flagPriority is set by commands that queue jobs with a priority.
+	// This is "low", "normal" or "high".
+	flagPriority string
+
 	// flagApp is the app to target.
 	flagApp string
 
 	// flagWorkspace is the workspace to work in.
 	flagWorkspace string
 
+	// flagOutput is the output format, "default" or "json".
+	flagOutput string
+
 	// flagConnection contains manual flag-based connection info.
 	flagConnection clicontext.Config
 
@@ -107,6 +144,10 @@ func (c *baseCommand) Close() error {
 		closer.Close()
 	}
 
+	if c.outputFile != nil {
+		c.outputFile.Close()
+	}
+
 	return nil
 }
 
@@ -145,11 +186,24 @@ func (c *baseCommand) Init(opts ...Option) error {
 	}
 	c.args = baseCfg.Flags.Args()
 
+	// Override the log level if that was set
+	if c.flagLogLevel != "" {
+		if err := c.initLogLevel(c.flagLogLevel); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return err
+		}
+	}
+
 	// Reset the UI to plain if that was set
 	if c.flagPlain {
 		c.ui = terminal.NonInteractiveUI(c.Ctx)
 	}
 
+	// Machine-readable output replaces the UI entirely.
+	if c.flagOutput == "json" {
+		c.ui = jsonui.New(os.Stdout)
+	}
+
 	// With the flags we now know what workspace we're targeting
 	c.refWorkspace = &pb.Ref_Workspace{Workspace: c.flagWorkspace}
 
@@ -271,6 +325,36 @@ func (c *baseCommand) Init(opts ...Option) error {
 	return nil
 }
 
+// initLogLevel sets the level of our logger for this invocation. Every
+// logger we create is derived from c.Log, including the app loggers, the
+// mapper loggers, and the loggers that plugin output is forwarded to, so
+// they all share this level.
+func (c *baseCommand) initLogLevel(v string) error {
+	level := hclog.LevelFromString(v)
+	if level == hclog.NoLevel {
+		return fmt.Errorf("-log-level value %q is not a valid log level", v)
+	}
+
+	c.Log.SetLevel(level)
+
+	// If logging was disabled then our output is discarded, so we need
+	// to point it somewhere visible.
+	if c.LogOutput == ioutil.Discard {
+		if r, ok := c.Log.(hclog.OutputResettable); ok {
+			if err := r.ResetOutput(&hclog.LoggerOptions{
+				Output: os.Stderr,
+				Color:  hclog.AutoColor,
+			}); err != nil {
+				return err
+			}
+
+			c.LogOutput = os.Stderr
+		}
+	}
+
+	return nil
+}
+
 // DoApp calls the callback for each app. This lets you execute logic
 // in an app-specific context safely. This automatically handles any
 // parallelization, waiting, and error handling. Your code should be
@@ -287,34 +371,39 @@ func (c *baseCommand) DoApp(ctx context.Context, f func(context.Context, *client
 	if c.refApp != nil {
 		appTargets = []string{c.refApp.Application}
 	} else if c.cfg != nil {
-		for _, appCfg := range c.cfg.Apps {
-			appTargets = append(appTargets, appCfg.Name)
+		// Operate on apps in dependency order. The config was validated
+		// when it was loaded so there shouldn't be a cycle here.
+		var err error
+		appTargets, err = c.cfg.AppOrder()
+		if err != nil {
+			return err
 		}
 	}
 
-	var apps []*clientpkg.App
 	for _, appName := range appTargets {
-		app := c.project.App(appName)
 		c.Log.Debug("will operate on app", "name", appName)
-		apps = append(apps, app)
 	}
 
-	// Just a serialize loop for now, one day we'll parallelize.
-	var finalErr error
-	for _, app := range apps {
-		// Support cancellation
-		if err := ctx.Err(); err != nil {
+	return c.project.DoApps(ctx, appTargets, func(ctx context.Context, app *clientpkg.App) error {
+		// The sentinel means the error was already output.
+		if err := f(ctx, app); err != ErrSentinel {
 			return err
 		}
 
-		if err := f(ctx, app); err != nil {
-			if err != ErrSentinel {
-				finalErr = multierror.Append(finalErr, err)
-			}
-		}
+		return nil
+	})
+}
+
+// parallel returns the number of apps to operate on at once.
+func (c *baseCommand) parallel() int {
+	if c.flagParallel > 0 {
+		return c.flagParallel
+	}
+	if c.cfg != nil {
+		return c.cfg.Parallel
 	}
 
-	return finalErr
+	return 1
 }
 
 // logError logs an error and outputs it to the UI.
@@ -345,6 +434,22 @@ func (c *baseCommand) flagSet(bit flagSetBit, f func(*flag.Sets)) *flag.Sets {
 			Usage:   "Plain output: no colors, no animation.",
 		})
 
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:    "output",
+			Target:  &c.flagOutput,
+			Values:  []string{"default", "json"},
+			Default: "default",
+			Usage: "Output format. \"json\" writes every message, table row, and " +
+				"step as a JSON object on its own line for machines to parse",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "log-level",
+			Target: &c.flagLogLevel,
+			Usage: "Log level for this invocation only: trace, debug, info, warn, or error. " +
+				"This overrides the WAYPOINT_LOG_LEVEL env var and -v flags.",
+		})
+
 		f.StringVar(&flag.StringVar{
 			Name:    "app",
 			Target:  &c.flagApp,
@@ -385,6 +490,48 @@ func (c *baseCommand) flagSet(bit flagSetBit, f func(*flag.Sets)) *flag.Sets {
 				"This is specified to the data source type being used in your configuration. " +
 				"This is used for example to set a specific Git ref to run against.",
 		})
+
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "var",
+			Target: &c.flagVars,
+			Usage: "Input variable value to set for this operation. These override " +
+				"the values from -var-file and WP_VAR_ env vars. Can be specified multiple times.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "var-file",
+			Target: &c.flagVarFiles,
+			Usage: "HCL file of input variable values to set for this operation. " +
+				"Later files override earlier files. Can be specified multiple times.",
+		})
+
+		f.IntVar(&flag.IntVar{
+			Name:   "parallel",
+			Target: &c.flagParallel,
+			Usage: "Maximum number of apps to operate on at once. This defaults to\n" +
+				"the 'parallel' setting in your configuration, or one at a time.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "call-trace",
+			Target: &c.flagCallTrace,
+			Usage: "Write a trace of every plugin function call to this file for\n" +
+				"debugging, with one JSON object per line. Values are redacted. This only applies to local operations.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "component-prefix",
+			Target: &c.flagComponentPrefix,
+			Usage: "Prefix the output of plugins with the name of the component.\n" +
+				"This only applies to local operations.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "output-file",
+			Target: &c.flagOutputFile,
+			Usage: "Also write the output of plugins to this file as plain text.\n" +
+				"This only applies to local operations.",
+		})
 	}
 
 	if bit&flagSetConnection != 0 {
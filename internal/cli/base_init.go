@@ -3,13 +3,18 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
-	"github.com/hashicorp/hcl/v2/hclsimple"
-
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/clicontext"
 	clientpkg "github.com/hashicorp/waypoint/internal/client"
 	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/runner"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/hashicorp/waypoint/internal/serverclient"
 )
 
@@ -51,9 +56,15 @@ func (c *baseCommand) initConfigPath() (string, error) {
 // initConfigLoad loads the configuration at the given path.
 func (c *baseCommand) initConfigLoad(path string) (*configpkg.Config, error) {
 	c.cfgCtx = configpkg.EvalContext(filepath.Dir(path))
+	configpkg.SetWorkspace(c.cfgCtx, c.flagWorkspace)
+
+	vars, err := c.initVariables()
+	if err != nil {
+		return nil, err
+	}
 
-	var cfg configpkg.Config
-	if err := hclsimple.DecodeFile(path, c.cfgCtx, &cfg); err != nil {
+	cfg, err := configpkg.LoadFile(path, c.cfgCtx, vars)
+	if err != nil {
 		return nil, err
 	}
 
@@ -62,29 +73,93 @@ func (c *baseCommand) initConfigLoad(path string) (*configpkg.Config, error) {
 		return nil, err
 	}
 
-	// Validate
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	// Validate. Warnings are shown but don't prevent us from continuing.
+	results := cfg.ValidateWithWarnings()
+	for _, w := range results.Warnings {
+		c.ui.Output("Warning: %s", w, terminal.WithWarningStyle())
+	}
+	if results.HasErrors() {
+		return nil, results.Errors
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
-// initClient initializes the client.
-func (c *baseCommand) initClient() (*clientpkg.Project, error) {
+// initVariables returns the input variable values set for this invocation
+// in order of precedence. WP_VAR_ env vars are overridden by -var-file
+// files, which are overridden by -var flags.
+func (c *baseCommand) initVariables() ([]*configpkg.VariableValue, error) {
+	result := configpkg.VariableValuesFromEnv(os.Environ())
+	for _, path := range c.flagVarFiles {
+		vars, err := configpkg.LoadVariableFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading variable file %q: %s", path, err)
+		}
+
+		result = append(result, vars...)
+	}
+
+	// Sort the flags so that the values are in a stable order.
+	names := make([]string, 0, len(c.flagVars))
+	for k := range c.flagVars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		result = append(result, &configpkg.VariableValue{
+			Name:   k,
+			Value:  c.flagVars[k],
+			Source: configpkg.VariableSourceCLI,
+		})
+	}
+
+	return result, nil
+}
+
+// initConnectOpts returns the options for connecting to the server. The
+// flags take precedence over the default context and the environment.
+func (c *baseCommand) initConnectOpts() []serverclient.ConnectOption {
 	// We use our flag-based connection info if the user set an addr.
 	var flagConnection *clicontext.Config
 	if v := c.flagConnection; v.Server.Address != "" {
 		flagConnection = &v
 	}
 
-	// Get the context we'll use.
-	var err error
-	connectOpts := []serverclient.ConnectOption{
+	return []serverclient.ConnectOption{
 		serverclient.FromContextConfig(flagConnection),
 		serverclient.FromContext(c.contextStorage, ""),
 		serverclient.FromEnv(),
 	}
+}
+
+// initRunnerOpts returns the options for the runner of local operations
+// from the diagnostics flags.
+func (c *baseCommand) initRunnerOpts() ([]runner.Option, error) {
+	var result []runner.Option
+	if c.flagCallTrace != "" {
+		result = append(result, runner.WithProjectOptions(core.WithCallTrace(c.flagCallTrace)))
+	}
+	if c.flagComponentPrefix {
+		result = append(result, runner.WithProjectOptions(core.WithComponentPrefix(true)))
+	}
+	if c.flagOutputFile != "" {
+		f, err := os.Create(c.flagOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating output file: %s", err)
+		}
+
+		c.outputFile = f
+		result = append(result, runner.WithOutputWriter(f))
+	}
+
+	return result, nil
+}
+
+// initClient initializes the client.
+func (c *baseCommand) initClient() (*clientpkg.Project, error) {
+	// Get the context we'll use.
+	var err error
+	connectOpts := c.initConnectOpts()
 	c.clientContext, err = serverclient.ContextConfig(connectOpts...)
 	if err != nil {
 		return nil, err
@@ -98,9 +173,48 @@ func (c *baseCommand) initClient() (*clientpkg.Project, error) {
 		clientpkg.WithWorkspaceRef(c.refWorkspace),
 		clientpkg.WithLabels(c.flagLabels),
 		clientpkg.WithSourceOverrides(c.flagRemoteSource),
+		clientpkg.WithParallel(c.parallel()),
+	}
+	if vars, err := c.initVariables(); err != nil {
+		return nil, err
+	} else if len(vars) > 0 {
+		jobVars := make([]*pb.Operation_Variable, len(vars))
+		for i, v := range vars {
+			jobVars[i] = &pb.Operation_Variable{
+				Name:   v.Name,
+				Value:  v.Value,
+				Source: pb.Operation_Variable_Source(v.Source),
+			}
+		}
+
+		opts = append(opts, clientpkg.WithVariables(jobVars))
 	}
 	if !c.flagRemote {
 		opts = append(opts, clientpkg.WithLocal())
+
+		runnerOpts, err := c.initRunnerOpts()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, clientpkg.WithLocalRunnerOptions(runnerOpts...))
+	} else if path, err := c.initConfigPath(); err == nil && path != "" {
+		opts = append(opts, clientpkg.WithSourceUpload(filepath.Dir(path)))
+	}
+	if c.cfg != nil {
+		opts = append(opts, clientpkg.WithAppDependencies(c.cfg.AppDependencies()))
+	}
+	if c.cfg != nil && len(c.cfg.JobTimeouts) > 0 {
+		opts = append(opts, clientpkg.WithJobTimeouts(c.cfg.JobTimeouts))
+	}
+	if c.cfg != nil && c.cfg.Runner != nil {
+		opts = append(opts, clientpkg.WithRunnerLabels(c.cfg.Runner.Labels))
+	}
+	if c.flagRequireApproval {
+		opts = append(opts, clientpkg.WithApproval(c.flagApprovalTimeout))
+	}
+	if c.flagPriority != "" {
+		opts = append(opts, clientpkg.WithPriority(
+			pb.Job_Priority(pb.Job_Priority_value[strings.ToUpper(c.flagPriority)])))
 	}
 
 	if c.ui != nil {
@@ -21,6 +21,7 @@ type BuildListCommand struct {
 
 	flagWorkspaceAll bool
 	flagId           idFormat
+	filterFlags      filterFlags
 }
 
 func (c *BuildListCommand) Run(args []string) int {
@@ -44,8 +45,9 @@ func (c *BuildListCommand) Run(args []string) int {
 
 		// List builds
 		resp, err := client.ListBuilds(c.Ctx, &pb.ListBuildsRequest{
-			Application: app.Ref(),
-			Workspace:   wsRef,
+			Application:   app.Ref(),
+			Workspace:     wsRef,
+			LabelSelector: c.filterFlags.labelSelector(),
 		})
 		if err != nil {
 			c.project.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
@@ -55,7 +57,7 @@ func (c *BuildListCommand) Run(args []string) int {
 
 		const bullet = "●"
 
-		table := terminal.NewTable("", "ID", "Workspace", "Builder", "Started", "Completed")
+		table := terminal.NewTable("", "ID", "Workspace", "Builder", "Commit", "Started", "Completed")
 		for _, b := range resp.Builds {
 			// Determine our bullet
 			status := ""
@@ -88,6 +90,7 @@ func (c *BuildListCommand) Run(args []string) int {
 				c.flagId.FormatId(b.Sequence, b.Id),
 				b.Workspace.Workspace,
 				b.Component.Name,
+				formatVCS(b.Vcs),
 				startTime,
 				completeTime,
 			}, []string{
@@ -116,6 +119,7 @@ func (c *BuildListCommand) Flags() *flag.Sets {
 		})
 
 		initIdFormat(f, &c.flagId)
+		initFilterFlags(set, &c.filterFlags, filterOptionLabel)
 	})
 }
 
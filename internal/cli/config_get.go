@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/clierrors"
@@ -74,7 +76,7 @@ func (c *ConfigGetCommand) Run(args []string) int {
 		vars := map[string]string{}
 
 		for _, cv := range resp.Variables {
-			vars[cv.Name] = cv.Value
+			vars[cv.Name] = configVarValue(cv)
 		}
 
 		json.NewEncoder(out).Encode(vars)
@@ -98,7 +100,7 @@ func (c *ConfigGetCommand) Run(args []string) int {
 			return 1
 		}
 
-		fmt.Fprintln(out, resp.Variables[0].Value)
+		fmt.Fprintln(out, configVarValue(resp.Variables[0]))
 		return 0
 	}
 
@@ -112,7 +114,7 @@ func (c *ConfigGetCommand) Run(args []string) int {
 		table.Rich([]string{
 			app,
 			v.Name,
-			v.Value,
+			configVarValue(v),
 		}, []string{
 			"",
 			terminal.Green,
@@ -125,6 +127,28 @@ func (c *ConfigGetCommand) Run(args []string) int {
 	return 0
 }
 
+// configVarValue returns the value of the variable for display. Dynamic
+// values are shown in the form they were set with since the value itself
+// is only read by the entrypoint.
+func configVarValue(v *pb.ConfigVar) string {
+	if v.Dynamic == nil {
+		return v.Value
+	}
+
+	keys := make([]string, 0, len(v.Dynamic.Config))
+	for k := range v.Dynamic.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s = %q", k, v.Dynamic.Config[k])
+	}
+
+	return fmt.Sprintf("configdynamic(%q, {%s})", v.Dynamic.From, strings.Join(pairs, ", "))
+}
+
 func (c *ConfigGetCommand) Flags() *flag.Sets {
 	return c.flagSet(0, func(set *flag.Sets) {
 		f := set.NewSet("Command Options")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/configsource"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/posener/complete"
@@ -14,6 +15,8 @@ import (
 
 type ConfigSetCommand struct {
 	*baseCommand
+
+	flagRestart bool
 }
 
 func (c *ConfigSetCommand) Run(args []string) int {
@@ -43,8 +46,21 @@ func (c *ConfigSetCommand) Run(args []string) int {
 		}
 
 		configVar := &pb.ConfigVar{
-			Name:  arg[:idx],
-			Value: arg[idx+1:],
+			Name:      arg[:idx],
+			Value:     arg[idx+1:],
+			NoRestart: !c.flagRestart,
+		}
+
+		// Values can read from a config source when the app starts instead.
+		dynamic, err := configsource.ParseDynamic(configVar.Value)
+		if err != nil {
+			c.ui.Output("Error parsing the value of %s: %s", configVar.Name,
+				clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		if dynamic != nil {
+			configVar.Value = ""
+			configVar.Dynamic = dynamic
 		}
 
 		if c.flagApp == "" {
@@ -73,7 +89,17 @@ func (c *ConfigSetCommand) Run(args []string) int {
 }
 
 func (c *ConfigSetCommand) Flags() *flag.Sets {
-	return c.flagSet(0, nil)
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:    "restart",
+			Target:  &c.flagRestart,
+			Default: true,
+			Usage: "Restart the app when the value changes. If false, the entrypoint " +
+				"writes the new values to the file in WAYPOINT_CONFIG_FILE and sends " +
+				"SIGHUP to the app instead.",
+		})
+	})
 }
 
 func (c *ConfigSetCommand) AutocompleteArgs() complete.Predictor {
@@ -98,5 +124,21 @@ Usage: waypoint config-set <name> <value>
   This will scope the variable to the entire project by default.
   Specify the "-app" flag to set a config variable for a specific app.
 
-`)
+  A value can be read from a config source by the entrypoint instead of
+  being stored on the server. The entrypoint refreshes it periodically
+  and restarts the app when it changes:
+
+    waypoint config-set 'DB_PASSWORD=configdynamic("vault", {path="secret/data/db", key="password"})'
+
+  The available config sources are "vault" and "aws-ssm". Vault reads use
+  VAULT_ADDR and VAULT_TOKEN from the app's environment, and AWS SSM uses
+  the app's AWS credentials with the "path" of the parameter.
+
+  Apps that can reload their configuration can use "-restart=false" to
+  get new values without a restart. The entrypoint writes all values as
+  a JSON object to the file named by WAYPOINT_CONFIG_FILE and sends the
+  app SIGHUP. The environment variable keeps the value the app started
+  with.
+
+` + c.Flags().Help())
 }
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ConfigSyncCommand struct {
+	*baseCommand
+
+	flagPrune  bool
+	flagDryRun bool
+}
+
+func (c *ConfigSyncCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	client := c.project.Client()
+
+	var changes []*configSyncChange
+	for _, app := range c.cfg.Apps {
+		if c.flagApp != "" && app.Name != c.flagApp {
+			continue
+		}
+
+		ref := &pb.Ref_Application{
+			Project:     c.project.Ref().Project,
+			Application: app.Name,
+		}
+
+		// Get the app-scoped variables on the server. The response also
+		// has the project-scoped variables which we don't manage.
+		resp, err := client.GetConfig(c.Ctx, &pb.ConfigGetRequest{
+			Scope: &pb.ConfigGetRequest_Application{Application: ref},
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		var current []*pb.ConfigVar
+		for _, v := range resp.Variables {
+			if _, ok := v.Scope.(*pb.ConfigVar_Application); ok {
+				current = append(current, v)
+			}
+		}
+
+		changes = append(changes, configSyncDiff(
+			app.Name, current, core.AppConfigVars(ref, app.Config), c.flagPrune)...)
+	}
+
+	if len(changes) == 0 {
+		c.ui.Output("The config on the server is up to date.", terminal.WithSuccessStyle())
+		return 0
+	}
+
+	tbl := terminal.NewTable("App", "Name", "Change")
+	var req pb.ConfigSetRequest
+	for _, change := range changes {
+		color := ""
+		switch change.Action {
+		case "create":
+			color = terminal.Green
+		case "update":
+			color = terminal.Yellow
+		case "delete":
+			color = terminal.Red
+		case "keep":
+			// Unmanaged variables are only shown
+			tbl.Rich([]string{change.App, change.Var.Name, "not in waypoint.hcl (kept)"}, nil)
+			continue
+		}
+
+		tbl.Rich([]string{change.App, change.Var.Name, change.Action},
+			[]string{"", "", color})
+		req.Variables = append(req.Variables, change.Var)
+	}
+	c.ui.Table(tbl)
+
+	if len(req.Variables) == 0 {
+		c.ui.Output("The config on the server is up to date.", terminal.WithSuccessStyle())
+		return 0
+	}
+	if c.flagDryRun {
+		return 0
+	}
+
+	// All the changes are set in a single request so that they're applied
+	// together or not at all.
+	if _, err := client.SetConfig(c.Ctx, &req); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Synced %d config variable(s).", len(req.Variables), terminal.WithSuccessStyle())
+	return 0
+}
+
+// configSyncChange is a change to a config variable of an app. Action is
+// "create", "update", "delete" or "keep". Var is the variable to set for
+// the change, which has no value for deletes.
+type configSyncChange struct {
+	App    string
+	Action string
+	Var    *pb.ConfigVar
+}
+
+// configSyncDiff returns the changes to make the current app-scoped config
+// variables match the desired variables. Variables that aren't desired are
+// deleted if prune is true and otherwise kept.
+func configSyncDiff(app string, current, desired []*pb.ConfigVar, prune bool) []*configSyncChange {
+	currentByName := map[string]*pb.ConfigVar{}
+	for _, v := range current {
+		currentByName[v.Name] = v
+	}
+
+	var result []*configSyncChange
+	seen := map[string]struct{}{}
+	for _, v := range desired {
+		seen[v.Name] = struct{}{}
+
+		cur, ok := currentByName[v.Name]
+		switch {
+		case !ok:
+			result = append(result, &configSyncChange{App: app, Action: "create", Var: v})
+
+		case configVarValue(cur) != configVarValue(v) ||
+			cur.File.GetMode() != v.File.GetMode() ||
+			(cur.File == nil) != (v.File == nil):
+			result = append(result, &configSyncChange{App: app, Action: "update", Var: v})
+		}
+	}
+
+	for _, v := range current {
+		if _, ok := seen[v.Name]; ok {
+			continue
+		}
+
+		if !prune {
+			result = append(result, &configSyncChange{App: app, Action: "keep", Var: v})
+			continue
+		}
+
+		// Setting a variable with no value deletes it
+		result = append(result, &configSyncChange{
+			App:    app,
+			Action: "delete",
+			Var: &pb.ConfigVar{
+				Scope: v.Scope,
+				Name:  v.Name,
+			},
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Var.Name < result[j].Var.Name
+	})
+
+	return result
+}
+
+func (c *ConfigSyncCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "prune",
+			Target: &c.flagPrune,
+			Usage: "Delete the app config variables on the server that aren't in\n" +
+				"the config stanza of waypoint.hcl.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "dry-run",
+			Target: &c.flagDryRun,
+			Usage:  "Only show the changes that would be made to the server.",
+		})
+	})
+}
+
+func (c *ConfigSyncCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ConfigSyncCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ConfigSyncCommand) Synopsis() string {
+	return "Sync the config stanza of waypoint.hcl to the server"
+}
+
+func (c *ConfigSyncCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint config sync [options]
+
+  Set the app config variables on the server from the "config" stanza of
+  each app in waypoint.hcl, and show the variables that were created or
+  updated. Use "-app" to sync a single app.
+
+  Deploys set these too, but they never delete variables. With "-prune",
+  app config variables on the server that were removed from the stanza
+  are deleted. Project config variables aren't changed.
+
+  All the changes are made at once, so either every change is made or
+  none are. Use "-dry-run" to preview the changes without making them.
+
+` + c.Flags().Help())
+}
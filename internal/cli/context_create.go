@@ -37,11 +37,22 @@ func (c *ContextCreateCommand) Run(args []string) int {
 
 	name := args[0]
 
+	// A token is only sent if auth is required, so giving one implies it.
+	if c.flagConfig.Server.AuthToken != "" {
+		c.flagConfig.Server.RequireAuth = true
+	}
+
 	// Get our contexts
 	if err := c.contextStorage.Set(name, &c.flagConfig); err != nil {
 		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
 		return 1
 	}
+	if c.flagSetDefault {
+		if err := c.contextStorage.SetDefault(name); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+	}
 
 	c.ui.Output("Context %q created.", name, terminal.WithSuccessStyle())
 	return 0
@@ -79,7 +90,8 @@ func (c *ContextCreateCommand) Flags() *flag.Sets {
 		f.BoolVar(&flag.BoolVar{
 			Name:   "server-require-auth",
 			Target: &c.flagConfig.Server.RequireAuth,
-			Usage:  "If true, will send authentication details.",
+			Usage: "If true, will send authentication details. This is implied " +
+				"by -server-auth-token.",
 		})
 	})
 }
@@ -0,0 +1,14 @@
+FROM golang:1.15-alpine AS build
+
+WORKDIR /src
+COPY . .
+
+# The template has no go.sum, so tidy adds it on the first build.
+RUN go mod tidy && CGO_ENABLED=0 go build -o /server .
+
+FROM alpine:3.12
+
+COPY --from=build /server /server
+
+EXPOSE {{.port}}
+ENTRYPOINT ["/server"]
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	// Waypoint sets PORT to the service_port of the deployment.
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "{{.port}}"
+	}
+
+	ln, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("failed to listen: %s", err)
+	}
+
+	s := grpc.NewServer()
+
+	// Register your services here. The health service lets load balancers
+	// and "grpc_health_probe" check that the server is up.
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	reflection.Register(s)
+
+	log.Printf("{{.app}} listening on :%s", port)
+	if err := s.Serve(ln); err != nil {
+		log.Fatalf("failed to serve: %s", err)
+	}
+}
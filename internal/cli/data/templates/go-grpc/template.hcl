@@ -0,0 +1,21 @@
+# Variables are prompted for when the template is used, or set with
+# -template-var. Defaults may refer to the variables before them.
+variable "project" {
+  description = "Project name"
+  default     = "go-grpc"
+}
+
+variable "app" {
+  description = "Application name"
+  default     = "api"
+}
+
+variable "module" {
+  description = "Go module path"
+  default     = "example.com/{{.project}}"
+}
+
+variable "port" {
+  description = "Port the gRPC server listens on"
+  default     = "9090"
+}
@@ -0,0 +1,19 @@
+project = "{{.project}}"
+
+app "{{.app}}" {
+  labels = {
+    "service" = "{{.app}}"
+  }
+
+  # Build the image with the Dockerfile in this directory.
+  build {
+    use "docker" {}
+  }
+
+  # Run the gRPC server in a Docker container.
+  deploy {
+    use "docker" {
+      service_port = {{.port}}
+    }
+  }
+}
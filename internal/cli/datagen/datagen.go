@@ -1,6 +1,11 @@
 // Code generated by go-bindata.
 // sources:
 // data/init.tpl.hcl
+// data/templates/go-grpc/Dockerfile.tpl
+// data/templates/go-grpc/go.mod.tpl
+// data/templates/go-grpc/main.go.tpl
+// data/templates/go-grpc/template.hcl
+// data/templates/go-grpc/waypoint.hcl.tpl
 // DO NOT EDIT!
 
 package datagen
@@ -88,6 +93,106 @@ func initTplHcl() (*asset, error) {
 	return a, nil
 }
 
+var _templatesGoGrpcDockerfileTpl = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x35\x8e\x5d\x4b\xc3\x30\x18\x85\xef\xdf\x5f\x71\x98\xb0\x2b\x1b\xad\xe2\xcd\x60\x17\xfb\xc8\x64\xa8\x4d\xc9\x2a\x3a\x86\x48\x34\xd9\x56\x68\x9b\x92\x64\x82\x8c\xfd\x77\xdb\x65\x5e\xbd\x17\xef\x73\xce\x79\x16\x52\xbc\x60\x67\x2b\xd5\xec\x46\x29\x4b\x1f\x12\x55\xb5\x65\x63\x30\x59\xe1\xeb\x50\x56\x9a\xe8\x4d\xc8\xa7\xf9\x52\xe2\xc6\xbb\x6f\x9a\x89\x7c\x0d\x06\x46\x74\x85\x62\x6f\x10\x4c\xdd\x56\x2a\x18\xec\x95\x47\x63\xbb\x26\xe6\x0f\xf5\x35\xbc\x45\x28\xf5\x2f\x94\xd6\x1e\x65\x80\x6d\x10\x3a\x7c\x5b\x3a\x1f\x62\x31\x23\xf9\x9a\x75\x3c\x6a\xab\x23\x3b\x1c\x62\xf6\x28\x3e\x79\x36\x99\x3e\xf3\xf9\xf8\xb6\x7f\x9e\x51\x24\xb6\x5b\x37\xee\xc7\xb8\x7e\x79\xd1\x2b\x47\xcd\xd1\x3d\x4b\xef\x28\x5a\x25\xc9\xd6\xd9\x7a\x1c\x13\xff\xf8\xe5\x12\xf1\xf7\x5c\xac\x38\x8e\x47\xd6\x5a\x17\x4e\x27\xe2\x59\x21\xd7\xb9\x58\x66\x05\x36\x83\x0b\x36\xf8\xa0\x3f\xe8\xf9\x54\xdc\x10\x01\x00\x00"
+
+func templatesGoGrpcDockerfileTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGoGrpcDockerfileTpl,
+		"templates/go-grpc/Dockerfile.tpl",
+	)
+}
+
+func templatesGoGrpcDockerfileTpl() (*asset, error) {
+	bytes, err := templatesGoGrpcDockerfileTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go-grpc/Dockerfile.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesGoGrpcGoModTpl = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcb\xcd\x4f\x29\xcd\x49\x55\xa8\xae\xd6\xcb\x05\xb3\x6a\x6b\xb9\xb8\xd2\xf3\x15\x0c\xf5\x0c\x4d\xb9\xb8\x8a\x52\x0b\x4b\x33\x8b\x52\x15\xd2\xf3\xf3\xd3\x73\x52\xf5\xd2\xf3\x73\x12\xf3\xd2\xf5\xf2\x8b\xd2\xf5\xd3\x8b\x0a\x92\x15\xca\x0c\xf5\x8c\x8d\xf5\x0c\xb9\x00\x81\xa4\xa4\x41\x44\x00\x00\x00"
+
+func templatesGoGrpcGoModTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGoGrpcGoModTpl,
+		"templates/go-grpc/go.mod.tpl",
+	)
+}
+
+func templatesGoGrpcGoModTpl() (*asset, error) {
+	bytes, err := templatesGoGrpcGoModTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go-grpc/go.mod.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesGoGrpcMainGoTpl = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x91\xc1\x8a\x1b\x31\x0c\x86\xcf\xf1\x53\xa8\x86\xc2\x0c\x0d\x0e\xbd\xa6\xe4\xda\xf6\x50\xda\x65\xbb\xd0\x63\x70\x1c\x8d\xc7\xac\x63\x19\xdb\x49\x09\x61\xde\xbd\xb2\x27\xd9\xdd\x16\x76\xe9\xc9\xb2\x25\xff\xd2\xff\x29\x6a\xf3\xa8\x2d\xc2\x41\xbb\x20\x84\x3b\x44\x4a\x05\x3a\xb1\x90\x9e\xac\xe4\x23\x60\xa9\x07\x65\x29\xf8\xb0\x44\xd6\xa3\xb2\xe4\x75\xb0\x8a\x92\x5d\xd9\x14\x8d\x7c\x35\xb3\x1a\x51\xfb\x32\x72\xc1\x1c\xc4\x1d\xbc\x5d\xd9\xe2\xed\x1c\x6f\x4f\x1f\xdf\x50\x4e\x38\x78\x34\xc5\x51\x90\xa2\x17\x62\x38\x06\xd3\x4c\x74\x3d\x5c\xc4\x62\xb5\x82\x5f\xfa\x1c\xc9\x85\x02\x19\x4b\x86\xbb\x1f\xf7\x0f\x50\x08\xca\x88\xfc\x90\x4e\xce\xe0\xb6\x79\xa5\xa1\xbd\xed\x31\x7a\x3a\x1f\x30\x14\x25\x16\x2d\xb1\xde\x00\x65\xf5\x05\x0b\x86\x53\x27\xeb\x7f\xd9\x8b\x85\x1b\xa0\x65\x37\x1b\x90\xb2\x76\x9a\x8b\xf9\x76\xb9\xa8\x1a\x4e\x13\x0f\x3d\x31\x2c\x1f\x96\x80\x29\x55\x1d\x86\xa8\xbe\xb9\xcc\x4a\x9d\x2c\x26\xca\x25\xc8\xb5\xfc\x50\xab\x67\xc5\x5a\xf6\x8e\xcb\x9c\x6f\x8a\x8c\x5e\x7d\xd6\x45\xfb\xa1\x93\x83\x76\x1e\xf7\x75\x72\xdf\x04\xd6\xf0\x3e\xcb\x26\xdc\xcf\x6d\x72\x6d\x50\x89\xa8\xef\xf8\xfb\x27\x3b\xc3\xd4\x31\x8f\x4a\xe0\x1e\x6d\xfd\x93\xe0\x4c\xc7\x74\x73\x9d\x61\xc4\x84\x0a\x1e\xd8\xf4\xcc\xf9\x96\x01\x5f\x41\x79\xd2\x7b\xd8\x69\x86\x6d\x30\xe5\xa6\xa3\xc3\x9e\xd7\xf6\x62\x31\x31\xd1\x0e\x25\x98\x11\xcd\x23\xd3\xd3\xe5\x09\x2b\x37\x73\x19\x8e\x51\x3d\x6f\x5c\xdd\xc6\xf8\xda\x1e\xae\x23\xe6\xe5\xb5\xfb\xcb\xb1\xd9\xd2\xf3\x5a\x9f\xfe\x75\xb9\xfa\xa9\x50\xee\x12\xef\x93\xa1\x30\x6a\x1d\xe3\x34\x5d\x99\xb8\x60\x81\x02\xac\x1b\x99\xbf\xa9\x32\x9b\xac\x9a\x7c\xe7\x43\xff\xe9\xff\x48\x37\x23\xff\x80\x9e\xc4\x1f\xb0\xee\x66\xd0\x2a\x03\x00\x00"
+
+func templatesGoGrpcMainGoTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGoGrpcMainGoTpl,
+		"templates/go-grpc/main.go.tpl",
+	)
+}
+
+func templatesGoGrpcMainGoTpl() (*asset, error) {
+	bytes, err := templatesGoGrpcMainGoTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go-grpc/main.go.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesGoGrpcTemplateHcl = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x90\x31\x6f\x83\x30\x10\x85\x77\x7e\xc5\x13\x59\x0b\xcd\x9a\xa1\x43\xd5\x4a\x5d\xa3\x0e\xdd\x2f\x70\x80\x2b\x1b\x9f\xec\x83\xb4\x42\xfc\xf7\x1a\xd2\x54\x55\x44\xbc\xf9\xde\xe9\xfb\x74\x6f\x87\x0f\x0a\x86\x4e\x96\x23\x28\x30\x24\x78\x27\xca\x35\x1a\x1f\x70\xee\xb8\x87\x76\x0c\x65\x27\x96\x94\x61\x22\x86\xc8\xf5\x03\x52\x1a\x59\x71\x36\xda\x65\x3b\x14\xd7\x85\x62\xa4\x50\xe2\x95\x1b\x1a\xac\x46\x38\xfa\x46\xe0\x86\x03\xd4\xaf\xa0\xf1\x4f\x76\xe2\x64\xe0\x65\xe8\xca\xec\x3a\x46\x9e\xfc\x9f\x5c\x69\x8e\x29\x03\x6a\x8e\x55\x30\xa2\xc6\xf7\x78\x42\x7e\xbc\x64\xe8\xc9\x71\xbe\xc6\xab\x06\xcb\x4b\x71\xeb\x8b\x36\x48\x95\x67\x73\xf6\x0f\x48\x22\x9b\xb0\x67\x11\x6b\x2a\x5a\xbf\x77\x80\x24\xe6\x06\xe6\x7c\x3d\x58\xde\xe4\xbd\x79\x5c\x52\x08\x69\xb7\x41\xe3\x2f\x4a\x1d\x71\x59\x79\xf7\x38\x4d\xe5\xef\xa1\xf3\x7c\xa3\x10\x1f\xee\x5c\x9f\x82\xb5\xc3\xf6\xfd\xf8\x92\xca\x0f\x63\xaa\xd5\x9a\xa8\xdc\x47\xf8\x7e\xc3\x78\xd8\x1f\xf6\x0b\xfd\x07\x63\x2b\xc8\x9d\xe4\x01\x00\x00"
+
+func templatesGoGrpcTemplateHclBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGoGrpcTemplateHcl,
+		"templates/go-grpc/template.hcl",
+	)
+}
+
+func templatesGoGrpcTemplateHcl() (*asset, error) {
+	bytes, err := templatesGoGrpcTemplateHclBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go-grpc/template.hcl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesGoGrpcWaypointHclTpl = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x50\x41\x0e\xc2\x20\x10\xbc\xf3\x8a\x09\xde\xfb\x03\x2f\xea\x03\x4c\x3f\x60\x68\x59\x5b\x14\x0b\x01\xaa\x69\x1a\xfe\xee\x42\x9b\x78\xf1\x02\xcc\xce\xce\xcc\xb2\x3e\xb8\x07\xf5\x09\x47\xc8\x75\x6d\xfc\x86\x72\x96\x42\x28\xef\x6b\x8d\x6f\xc6\x58\x05\x60\x55\x47\x36\x72\x6f\x01\x80\x8c\x14\xde\xa6\x27\xb9\xab\xb7\x4e\xa6\xb2\xe0\xe3\x80\xd3\x6c\xac\x46\x1a\x09\xe6\xa5\x06\xc2\xc7\xa4\xb1\xc2\x8b\xeb\x9f\x14\xee\xc6\x32\x33\x71\xc5\x44\x68\x13\x38\xd8\x85\xa5\x61\x69\x57\x85\x5b\xc8\x1c\x09\x52\x57\x01\x0f\x91\x7f\xee\xed\x3c\x55\xb3\xa1\xbd\x9e\x51\x26\xa1\x50\xdc\xd4\xee\x8e\xde\x4d\x49\x99\x89\x42\x71\xd4\xe4\xad\x5b\xfe\x5a\xd6\x12\xb0\xff\xe5\xe6\x5d\x28\xdb\x28\xcb\xe0\x57\xce\x95\xde\x62\xb3\xf8\x02\xc6\x71\xa3\xb8\x2e\x01\x00\x00"
+
+func templatesGoGrpcWaypointHclTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGoGrpcWaypointHclTpl,
+		"templates/go-grpc/waypoint.hcl.tpl",
+	)
+}
+
+func templatesGoGrpcWaypointHclTpl() (*asset, error) {
+	bytes, err := templatesGoGrpcWaypointHclTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go-grpc/waypoint.hcl.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -140,7 +245,12 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"init.tpl.hcl": initTplHcl,
+	"init.tpl.hcl":                       initTplHcl,
+	"templates/go-grpc/Dockerfile.tpl":   templatesGoGrpcDockerfileTpl,
+	"templates/go-grpc/go.mod.tpl":       templatesGoGrpcGoModTpl,
+	"templates/go-grpc/main.go.tpl":      templatesGoGrpcMainGoTpl,
+	"templates/go-grpc/template.hcl":     templatesGoGrpcTemplateHcl,
+	"templates/go-grpc/waypoint.hcl.tpl": templatesGoGrpcWaypointHclTpl,
 }
 
 // AssetDir returns the file names below a certain
@@ -185,6 +295,15 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"init.tpl.hcl": {initTplHcl, map[string]*bintree{}},
+	"templates": {nil, map[string]*bintree{
+		"go-grpc": {nil, map[string]*bintree{
+			"Dockerfile.tpl":   {templatesGoGrpcDockerfileTpl, map[string]*bintree{}},
+			"go.mod.tpl":       {templatesGoGrpcGoModTpl, map[string]*bintree{}},
+			"main.go.tpl":      {templatesGoGrpcMainGoTpl, map[string]*bintree{}},
+			"template.hcl":     {templatesGoGrpcTemplateHcl, map[string]*bintree{}},
+			"waypoint.hcl.tpl": {templatesGoGrpcWaypointHclTpl, map[string]*bintree{}},
+		}},
+	}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type DeploymentApproveCommand struct {
+	*baseCommand
+}
+
+func (c *DeploymentApproveCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single job ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	_, err := c.project.Client().ApproveJob(c.Ctx, &pb.ApproveJobRequest{JobId: args[0]})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Job %q approved and queued.", args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *DeploymentApproveCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *DeploymentApproveCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *DeploymentApproveCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *DeploymentApproveCommand) Synopsis() string {
+	return "Approve a deploy that is waiting for approval."
+}
+
+func (c *DeploymentApproveCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint deployment approve [options] JOB-ID
+
+  Approve a deploy that is waiting for approval so that it runs.
+
+  Deploys wait for approval if they were started with "-require-approval".
+  The job ID is shown when the deploy starts waiting, or use
+  "waypoint job list -state waiting_approval" to find it.
+
+` + c.Flags().Help())
+}
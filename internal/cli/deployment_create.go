@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/posener/complete"
 
@@ -17,6 +18,7 @@ type DeploymentCreateCommand struct {
 	*baseCommand
 
 	flagRelease bool
+	flagDryRun  bool
 }
 
 func (c *DeploymentCreateCommand) Run(args []string) int {
@@ -42,6 +44,27 @@ func (c *DeploymentCreateCommand) Run(args []string) int {
 			return ErrSentinel
 		}
 
+		if c.flagDryRun {
+			app.UI.Output("Planning deploy...", terminal.WithHeaderStyle())
+			result, err := app.Deploy(ctx, &pb.Job_DeployOp{
+				Artifact: push,
+				DryRun:   true,
+			})
+			if err != nil {
+				app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+				return ErrSentinel
+			}
+
+			outputPlannedChanges(app.UI, result.PlannedChanges)
+			if c.flagRelease {
+				app.UI.Output("The new deployment would then be released. Use "+
+					"\"waypoint release -dry-run\" after deploying to plan the release.",
+					terminal.WithInfoStyle())
+			}
+
+			return nil
+		}
+
 		// Push it
 		app.UI.Output("Deploying...", terminal.WithHeaderStyle())
 		result, err := app.Deploy(ctx, &pb.Job_DeployOp{
@@ -119,6 +142,35 @@ func (c *DeploymentCreateCommand) Flags() *flag.Sets {
 			Usage:   "Release this deployment immedately.",
 			Default: true,
 		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "dry-run",
+			Target: &c.flagDryRun,
+			Usage: "Only show the changes the deploy would make. Nothing is deployed\n" +
+				"and no deployment is recorded.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "require-approval",
+			Target: &c.flagRequireApproval,
+			Usage: "Wait for the deploy to be approved with \"waypoint deployment approve\"\n" +
+				"before it runs.",
+		})
+
+		f.DurationVar(&flag.DurationVar{
+			Name:    "approval-timeout",
+			Target:  &c.flagApprovalTimeout,
+			Default: time.Hour,
+			Usage:   "Cancel the deploy if it isn't approved in this time. Set to 0 to wait forever.",
+		})
+
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "priority",
+			Target: &c.flagPriority,
+			Values: []string{"low", "normal", "high"},
+			Usage: "Priority of the queued jobs. Queued jobs with a higher priority\n" +
+				"are assigned to runners first. Jobs are normal priority by default.",
+		})
 	})
 }
 
@@ -146,6 +198,19 @@ Alias: waypoint deploy
 ` + c.Flags().Help())
 }
 
+// outputPlannedChanges outputs the changes from a dry run.
+func outputPlannedChanges(ui terminal.UI, changes []string) {
+	if len(changes) == 0 {
+		ui.Output("No changes would be made.", terminal.WithSuccessStyle())
+		return
+	}
+
+	ui.Output("The following changes would be made:", terminal.WithInfoStyle())
+	for _, change := range changes {
+		ui.Output("  - %s", change, terminal.WithInfoStyle())
+	}
+}
+
 const (
 	deployURLService = `
 The deploy was successful! A Waypoint deployment URL is shown below. This
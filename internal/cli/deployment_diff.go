@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type DeploymentDiffCommand struct {
+	*baseCommand
+}
+
+func (c *DeploymentDiffCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 2 {
+		c.ui.Output("Two deployment IDs are required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	var ds []*pb.Deployment
+	for _, id := range args {
+		d, err := c.project.Client().GetDeployment(c.Ctx, &pb.GetDeploymentRequest{
+			Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Id{Id: id},
+			},
+			LoadDetails: pb.Deployment_ARTIFACT,
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		ds = append(ds, d)
+	}
+
+	diff := core.DiffDeployments(ds[0], ds[1])
+
+	c.ui.Output("Deployments", terminal.WithHeaderStyle())
+	tbl := terminal.NewTable("", "A", "B")
+	tbl.Rich([]string{"ID", diff.A.Id, diff.B.Id}, nil)
+	tbl.Rich([]string{
+		"Sequence",
+		fmt.Sprintf("v%d", diff.A.Sequence),
+		fmt.Sprintf("v%d", diff.B.Sequence),
+	}, nil)
+	tbl.Rich([]string{"Artifact", diff.A.ArtifactId, diff.B.ArtifactId}, diffColors(diff.ArtifactChanged()))
+	tbl.Rich([]string{
+		"Plugin",
+		diff.A.Component.GetName(),
+		diff.B.Component.GetName(),
+	}, diffColors(diff.PluginChanged()))
+	c.ui.Table(tbl)
+
+	changed := diff.ArtifactChanged() || diff.PluginChanged()
+	for _, section := range []struct {
+		Name  string
+		Diffs []*core.LabelDiff
+	}{
+		{"Artifact Labels", diff.ArtifactLabels},
+		{"Labels", diff.Labels},
+		{"Plugin Configuration", diff.PluginConfig},
+	} {
+		if len(section.Diffs) == 0 {
+			continue
+		}
+		changed = true
+
+		c.ui.Output("")
+		c.ui.Output(section.Name, terminal.WithHeaderStyle())
+		tbl := terminal.NewTable("Key", "A", "B")
+		for _, d := range section.Diffs {
+			tbl.Rich([]string{d.Key, d.A, d.B}, nil)
+		}
+		c.ui.Table(tbl)
+	}
+
+	// Config variables are recorded as hashes so we only show how they
+	// changed, not their values.
+	if len(diff.Config) > 0 {
+		changed = true
+
+		c.ui.Output("")
+		c.ui.Output("Config Variables", terminal.WithHeaderStyle())
+		tbl := terminal.NewTable("Name", "Change")
+		for _, d := range diff.Config {
+			change, color := "changed", terminal.Yellow
+			switch {
+			case d.A == "":
+				change, color = "added", terminal.Green
+			case d.B == "":
+				change, color = "removed", terminal.Red
+			}
+
+			tbl.Rich([]string{d.Key, change}, []string{"", color})
+		}
+		c.ui.Table(tbl)
+	}
+
+	if !changed {
+		c.ui.Output("")
+		c.ui.Output("No differences between the deployments.", terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+// diffColors returns the colors for a row of the deployments table,
+// highlighting the values if they changed.
+func diffColors(changed bool) []string {
+	if !changed {
+		return nil
+	}
+
+	return []string{"", terminal.Yellow, terminal.Yellow}
+}
+
+func (c *DeploymentDiffCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *DeploymentDiffCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *DeploymentDiffCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *DeploymentDiffCommand) Synopsis() string {
+	return "Show what changed between two deployments"
+}
+
+func (c *DeploymentDiffCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint deployment diff [options] DEPLOYMENT-ID DEPLOYMENT-ID
+
+  Show what changed between two deployments.
+
+  This compares the artifact, labels, config variables and platform plugin
+  configuration that were recorded when each deployment was created. Only
+  the differences are shown. Config variable values aren't stored on
+  deployments so only whether they were added, removed or changed is shown.
+
+  Deployments created before this information was recorded are shown as
+  having no config variables or plugin configuration.
+
+  Use "waypoint deployment list -long-ids" to find deployment IDs.
+
+` + c.Flags().Help())
+}
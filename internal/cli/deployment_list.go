@@ -101,6 +101,7 @@ func (c *DeploymentListCommand) Run(args []string) int {
 			Status:        c.filterFlags.statusFilters(),
 			Order:         c.filterFlags.orderOp(),
 			LoadDetails:   pb.Deployment_BUILD,
+			LabelSelector: c.filterFlags.labelSelector(),
 		})
 		if err != nil {
 			c.project.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
@@ -176,6 +177,10 @@ func (c *DeploymentListCommand) Run(args []string) int {
 				details = append(details, "image:"+img)
 			}
 
+			if vcs := formatVCS(b.Vcs); vcs != "" {
+				details = append(details, "commit:"+vcs)
+			}
+
 			artdetails := fmt.Sprintf("artifact:%s", c.flagId.FormatId(b.Preload.Artifact.Sequence, b.Preload.Artifact.Id))
 			if len(details) == 0 {
 				details = append(details, artdetails)
@@ -281,6 +286,7 @@ func (c *DeploymentListCommand) displayJson(deployments []*pb.Deployment) error
 		i["workspace"] = dep.Workspace.Workspace
 		i["artifact"] = c.artifactJson(dep.Preload.Artifact)
 		i["build"] = c.buildJson(dep.Preload.Build)
+		i["vcs"] = dep.Vcs
 
 		output = append(output, i)
 	}
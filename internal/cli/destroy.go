@@ -2,36 +2,56 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	clientpkg "github.com/hashicorp/waypoint/internal/client"
 	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/core"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 type DestroyCommand struct {
 	*baseCommand
+
+	flagAll         bool
+	flagAutoApprove bool
 }
 
 func (c *DestroyCommand) Run(args []string) int {
-	// Initialize. If we fail, we just exit since Init handles the UI.
-	if err := c.Init(
+	opts := []Option{
 		WithArgs(args),
 		WithFlags(c.Flags()),
-		WithSingleApp(),
-	); err != nil {
+	}
+
+	// Destroying the whole workspace targets every app, so we only
+	// require a single app if we aren't doing that.
+	all := false
+	for _, s := range args {
+		if s == "-all" || s == "-all=true" {
+			all = true
+		}
+	}
+	if !all {
+		opts = append(opts, WithSingleApp())
+	}
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(opts...); err != nil {
 		return 1
 	}
 
+	if c.flagAll {
+		return c.destroyAll()
+	}
+
 	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
-		if err := app.Destroy(ctx, &pb.Job_DestroyOp{
-			Target: &pb.Job_DestroyOp_Workspace{
-				Workspace: &empty.Empty{},
-			},
-		}); err != nil {
+		if err := app.Destroy(ctx, destroyWorkspaceOp()); err != nil {
 			c.ui.Output("Error destroying: %s", err.Error(), terminal.WithErrorStyle())
 			return ErrSentinel
 		}
@@ -50,8 +70,174 @@ func (c *DestroyCommand) Run(args []string) int {
 	return 0
 }
 
+// destroyAll destroys every app in the workspace. The apps are destroyed
+// one at a time in reverse dependency order, after the user confirms the
+// full list of deployments and releases.
+func (c *DestroyCommand) destroyAll() int {
+	ctx := c.Ctx
+	client := c.project.Client()
+	ws := c.project.WorkspaceRef()
+
+	order, err := destroyOrder(c.cfg)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	// Gather everything we're going to destroy so the user can confirm it.
+	type appResources struct {
+		name        string
+		deployments []*pb.Deployment
+		releases    []*pb.Release
+	}
+	var resources []appResources
+	total := 0
+	for _, name := range order {
+		app := c.project.App(name)
+
+		deploys, err := client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+			Application:   app.Ref(),
+			Workspace:     ws,
+			PhysicalState: pb.Operation_CREATED,
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		releases, err := client.ListReleases(ctx, &pb.ListReleasesRequest{
+			Application:   app.Ref(),
+			Workspace:     ws,
+			PhysicalState: pb.Operation_CREATED,
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		r := appResources{name: name, releases: releases.Releases}
+		for _, d := range deploys.Deployments {
+			// Pinned deployments are skipped by destroy so we don't list them.
+			if core.DeploymentPinned(d) {
+				continue
+			}
+
+			r.deployments = append(r.deployments, d)
+		}
+
+		total += len(r.deployments) + len(r.releases)
+		resources = append(resources, r)
+	}
+
+	if total == 0 {
+		c.ui.Output("Nothing to destroy in workspace %q.", ws.Workspace,
+			terminal.WithSuccessStyle())
+		return 0
+	}
+
+	c.ui.Output("The following will be destroyed in workspace %q:", ws.Workspace,
+		terminal.WithHeaderStyle())
+	for _, r := range resources {
+		if len(r.deployments) == 0 && len(r.releases) == 0 {
+			continue
+		}
+
+		c.ui.Output("App %q:", r.name)
+		for _, rel := range r.releases {
+			c.ui.Output("  release %s (deployment %s)", rel.Id, rel.DeploymentId)
+		}
+		for _, d := range r.deployments {
+			c.ui.Output("  deployment %s (%s)", d.Id, destroyComponentName(d.Component))
+		}
+	}
+
+	if !c.flagAutoApprove {
+		if !c.ui.Interactive() {
+			c.ui.Output(
+				"Destroying a workspace requires confirmation. Run this command in an\n"+
+					"interactive terminal or specify the -auto-approve flag.",
+				terminal.WithErrorStyle())
+			return 1
+		}
+
+		result, err := c.ui.Input(&terminal.Input{
+			Prompt: fmt.Sprintf(
+				"Do you really want to destroy %d resources? Only 'yes' will be accepted: ", total),
+			Style: terminal.WarningBoldStyle,
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		if strings.TrimSpace(result) != "yes" {
+			c.ui.Output("Destroy cancelled.", terminal.WithWarningStyle())
+			return 1
+		}
+	}
+
+	// Destroy one at a time so that apps are torn down before the apps
+	// they depend on.
+	for _, name := range order {
+		app := c.project.App(name)
+		if err := app.Destroy(ctx, destroyWorkspaceOp()); err != nil {
+			c.ui.Output("Error destroying %q: %s", name, clierrors.Humanize(err),
+				terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	c.ui.Output("Destroy successful!", terminal.WithSuccessStyle())
+	return 0
+}
+
+// destroyOrder returns the names of the apps in the order they should be
+// destroyed, which is the reverse of their dependency order so that apps
+// are destroyed before the apps they depend on.
+func destroyOrder(cfg *configpkg.Config) ([]string, error) {
+	order, err := cfg.AppOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		result = append(result, order[i])
+	}
+
+	return result, nil
+}
+
+func destroyComponentName(c *pb.Component) string {
+	if c == nil || c.Name == "" {
+		return "unknown"
+	}
+
+	return c.Name
+}
+
+func destroyWorkspaceOp() *pb.Job_DestroyOp {
+	return &pb.Job_DestroyOp{
+		Target: &pb.Job_DestroyOp_Workspace{
+			Workspace: &empty.Empty{},
+		},
+	}
+}
+
 func (c *DestroyCommand) Flags() *flag.Sets {
 	return c.flagSet(flagSetOperation, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "all",
+			Target: &c.flagAll,
+			Usage: "Destroy the deployments and releases of every app in the " +
+				"workspace. The full list is shown for confirmation first.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "auto-approve",
+			Target: &c.flagAutoApprove,
+			Usage:  "Don't ask for confirmation before destroying with -all.",
+		})
 	})
 }
 
@@ -73,8 +259,13 @@ Usage: waypoint destroy [options]
   as part of deploys and releases are destroyed. For example, any load balancers,
   VMs, containers, etc.
 
-  This targets one app in one workspace. You must call this for each workspace
-  you've used if you want to destroy everything.
+  This targets one app in one workspace. Specify "-all" to destroy every
+  app in the workspace. The apps are destroyed one at a time, in reverse
+  dependency order, after you confirm the list of deployments and
+  releases that will be destroyed.
+
+  You must call this for each workspace you've used if you want to
+  destroy everything.
 
 ` + c.Flags().Help())
 }
@@ -0,0 +1,493 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	"github.com/hashicorp/waypoint/internal/plugin"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/serverclient"
+)
+
+// doctorDialTimeout is how long we try to connect to each advertise
+// address before reporting it as unreachable.
+const doctorDialTimeout = 5 * time.Second
+
+type DoctorCommand struct {
+	*baseCommand
+
+	// results are the checks that have run so far.
+	results []*doctorResult
+}
+
+// doctorResult is the result of a single check.
+type doctorResult struct {
+	Check  string
+	Status doctorStatus
+	Detail string
+
+	// Fix is how to resolve the problem if the check didn't pass.
+	Fix string
+}
+
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warning"
+	doctorFail doctorStatus = "failed"
+	doctorSkip doctorStatus = "skipped"
+)
+
+func (c *DoctorCommand) Run(args []string) int {
+	ctx := c.Ctx
+
+	// Initialize. If we fail, we just exit since Init handles the UI. We
+	// load the config and connect ourselves so that we can report issues
+	// with them as checks.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithClient(false),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	cfg := c.checkConfig()
+	if cfg != nil {
+		c.checkPlugins(cfg)
+	}
+
+	if client := c.checkServer(ctx); client != nil {
+		if c.checkToken(ctx, client) {
+			c.checkRunners(ctx, client, cfg)
+			c.checkEntrypoint(ctx, client, cfg)
+		}
+	}
+
+	table := terminal.NewTable("Check", "Status", "Details")
+	failed := false
+	for _, r := range c.results {
+		color := ""
+		switch r.Status {
+		case doctorOK:
+			color = terminal.Green
+		case doctorWarn:
+			color = terminal.Yellow
+		case doctorFail:
+			color = terminal.Red
+			failed = true
+		}
+
+		table.Rich([]string{
+			r.Check,
+			string(r.Status),
+			r.Detail,
+		}, []string{
+			"",
+			color,
+			"",
+		})
+	}
+	c.ui.Table(table)
+
+	var fixes []string
+	for _, r := range c.results {
+		if r.Fix != "" {
+			fixes = append(fixes, fmt.Sprintf("%s: %s", r.Check, r.Fix))
+		}
+	}
+	if len(fixes) > 0 {
+		c.ui.Output("")
+		c.ui.Output("Suggested fixes:", terminal.WithHeaderStyle())
+		for _, fix := range fixes {
+			c.ui.Output(fix, terminal.WithInfoStyle())
+		}
+	}
+
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+// result records the result of a check.
+func (c *DoctorCommand) result(check string, s doctorStatus, detail, fix string) {
+	c.results = append(c.results, &doctorResult{
+		Check:  check,
+		Status: s,
+		Detail: detail,
+		Fix:    fix,
+	})
+}
+
+// checkConfig loads the waypoint.hcl for the current directory. This
+// returns nil if there isn't one or it is invalid.
+func (c *DoctorCommand) checkConfig() *configpkg.Config {
+	const check = "Configuration"
+
+	path, err := c.initConfigPath()
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+		return nil
+	}
+	if path == "" {
+		c.result(check, doctorSkip, "No waypoint.hcl found, project checks are skipped.",
+			"Run this command in the directory of a project to check it too.")
+		return nil
+	}
+
+	cfg, err := c.initConfigLoad(path)
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err),
+			"Fix the errors in waypoint.hcl. `waypoint init` validates it as well.")
+		return nil
+	}
+
+	c.result(check, doctorOK, fmt.Sprintf("Loaded %s for project %q.", path, cfg.Project), "")
+	return cfg
+}
+
+// checkPlugins verifies that every plugin used by the configuration is
+// either built in or an external binary in the plugin search path. This
+// is the same lookup that runners do.
+func (c *DoctorCommand) checkPlugins(cfg *configpkg.Config) {
+	const check = "Plugins"
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+		return
+	}
+	if path, err := c.initConfigPath(); err == nil && path != "" {
+		pwd = filepath.Dir(path)
+	}
+
+	paths, err := plugin.DefaultPaths(pwd)
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+		return
+	}
+
+	var missing, invalid []string
+	for _, p := range cfg.Plugins() {
+		cmd, err := plugin.Discover(p, paths)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", p.Name, err))
+			continue
+		}
+
+		if cmd == nil {
+			if _, ok := plugin.Builtins[p.Name]; !ok {
+				missing = append(missing, p.Name)
+			}
+		}
+	}
+
+	switch {
+	case len(invalid) > 0:
+		c.result(check, doctorFail,
+			"Invalid plugins: "+strings.Join(invalid, ", "),
+			"Replace the plugin binaries or update the checksums in waypoint.hcl.")
+
+	case len(missing) > 0:
+		c.result(check, doctorFail,
+			"Plugins not found: "+strings.Join(missing, ", "),
+			fmt.Sprintf("Install the binaries as \"waypoint-plugin-<name>\" into one of: %s. "+
+				"Otherwise check the plugin names in waypoint.hcl for typos.",
+				strings.Join(paths, ", ")))
+
+	default:
+		c.result(check, doctorOK, fmt.Sprintf("All %d plugins were found.", len(cfg.Plugins())), "")
+	}
+}
+
+// checkServer connects to the server. This returns nil if there is no
+// server configured or the connection failed.
+func (c *DoctorCommand) checkServer(ctx context.Context) pb.WaypointClient {
+	const check = "Server connection"
+
+	opts := c.initConnectOpts()
+	cctx, err := serverclient.ContextConfig(opts...)
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err),
+			"Fix or delete the CLI context with `waypoint context list` and `waypoint context delete`.")
+		return nil
+	}
+	if cctx.Server.Address == "" {
+		c.result(check, doctorSkip,
+			"No server is configured, operations use a local in-process server.",
+			"Run `waypoint install` or `waypoint context create` to use a remote server.")
+		return nil
+	}
+	addr := cctx.Server.Address
+
+	conn, err := serverclient.Connect(ctx, opts...)
+	if err != nil {
+		c.result(check, doctorFail,
+			fmt.Sprintf("Error connecting to %s: %s", addr, clierrors.Humanize(err)),
+			"Check that the server is running and that the address and TLS settings of "+
+				"the CLI context are correct. `waypoint context verify` tests a context.")
+		return nil
+	}
+	c.cleanupConn(conn)
+	client := pb.NewWaypointClient(conn)
+
+	resp, err := client.GetVersionInfo(ctx, &empty.Empty{})
+	if err != nil {
+		c.result(check, doctorFail,
+			fmt.Sprintf("Error calling %s: %s", addr, clierrors.Humanize(err)),
+			"Check that the address is of a Waypoint server and that the CLI and server "+
+				"versions are compatible.")
+		return nil
+	}
+
+	c.result(check, doctorOK,
+		fmt.Sprintf("Connected to %s (server version %s).", addr, resp.Info.Version), "")
+	return client
+}
+
+// checkToken checks that the token of the CLI context is accepted by the
+// server. The remaining server checks need a valid token so this returns
+// false if it isn't.
+func (c *DoctorCommand) checkToken(ctx context.Context, client pb.WaypointClient) bool {
+	const check = "Token"
+
+	_, err := client.ListWorkspaces(ctx, &empty.Empty{})
+	switch status.Code(err) {
+	case codes.OK:
+		c.result(check, doctorOK, "The token is valid.", "")
+		return true
+
+	case codes.Unauthenticated, codes.PermissionDenied:
+		c.result(check, doctorFail, clierrors.Humanize(err),
+			"Log in again with `waypoint login`, or create a context with a new token "+
+				"using `waypoint context create -server-auth-token`.")
+
+	default:
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+	}
+
+	return false
+}
+
+// checkRunners checks that a remote runner is available to run jobs for
+// the project.
+func (c *DoctorCommand) checkRunners(ctx context.Context, client pb.WaypointClient, cfg *configpkg.Config) {
+	const check = "Runners"
+
+	job := &pb.Job{
+		Application: &pb.Ref_Application{},
+		Workspace:   c.refWorkspace,
+		TargetRunner: &pb.Ref_Runner{
+			Target: &pb.Ref_Runner_Any{
+				Any: &pb.Ref_RunnerAny{},
+			},
+		},
+		Operation: &pb.Job_Noop_{
+			Noop: &pb.Job_Noop{},
+		},
+	}
+	if job.Workspace.Workspace == "" {
+		job.Workspace = &pb.Ref_Workspace{Workspace: "default"}
+	}
+
+	// Remote operations for the project target runners with its labels.
+	remote := cfg != nil && cfg.Runner != nil && cfg.Runner.Enabled
+	if cfg != nil {
+		job.Application.Project = cfg.Project
+		if remote && len(cfg.Runner.Labels) > 0 {
+			job.TargetRunner.Target = &pb.Ref_Runner_Labels{
+				Labels: &pb.Ref_RunnerLabels{Labels: cfg.Runner.Labels},
+			}
+		}
+	}
+
+	resp, err := client.ValidateJob(ctx, &pb.ValidateJobRequest{Job: job})
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+		return
+	}
+
+	if resp.Assignable {
+		c.result(check, doctorOK, "A runner is available for remote operations.", "")
+		return
+	}
+
+	s := doctorWarn
+	detail := "No runners are connected. Operations can only run locally."
+	fix := "Start a runner with `waypoint runner agent`."
+	if remote {
+		s = doctorFail
+		detail = "Remote operations are enabled for the project but no runners can run them."
+		if len(cfg.Runner.Labels) > 0 {
+			fix = "Start a runner with the labels set by the runner block in waypoint.hcl " +
+				"using `waypoint runner agent -label`."
+		}
+	}
+
+	c.result(check, s, detail, fix)
+}
+
+// checkEntrypoint checks that the server advertises an address that
+// entrypoints can connect to and that deployments of the project have
+// instances connected.
+func (c *DoctorCommand) checkEntrypoint(ctx context.Context, client pb.WaypointClient, cfg *configpkg.Config) {
+	const check = "Entrypoint"
+
+	resp, err := client.GetServerConfig(ctx, &empty.Empty{})
+	if status.Code(err) == codes.PermissionDenied {
+		c.result(check, doctorSkip, "Reading the server config requires an admin token.", "")
+		return
+	}
+	if err != nil {
+		c.result(check, doctorFail, clierrors.Humanize(err), "")
+		return
+	}
+
+	addrs := resp.Config.GetAdvertiseAddrs()
+	if len(addrs) == 0 {
+		c.result(check, doctorFail,
+			"The server doesn't advertise an address for entrypoints.",
+			"Set one with `waypoint server config-set -advertise-addr=HOST:PORT`.")
+		return
+	}
+
+	var unreachable []string
+	for _, addr := range addrs {
+		conn, err := net.DialTimeout("tcp", addr.Addr, doctorDialTimeout)
+		if err != nil {
+			c.Log.Debug("advertise address is unreachable", "addr", addr.Addr, "err", err)
+			unreachable = append(unreachable, addr.Addr)
+			continue
+		}
+		conn.Close()
+	}
+	if len(unreachable) == len(addrs) {
+		c.result(check, doctorWarn,
+			"Advertise addresses can't be reached from here: "+strings.Join(unreachable, ", "),
+			"Entrypoints connect to the advertise address, so it must be reachable "+
+				"from where apps are deployed. Change it with `waypoint server config-set "+
+				"-advertise-addr` if it is wrong.")
+		return
+	}
+
+	// Deployments that have the entrypoint should have instances
+	// connected to the server.
+	var missing []string
+	if cfg != nil {
+		for _, app := range cfg.Apps {
+			ref := &pb.Ref_Application{Project: cfg.Project, Application: app.Name}
+			ids, err := c.entrypointDeployments(ctx, client, ref)
+			if err != nil {
+				c.result(check, doctorFail, clierrors.Humanize(err), "")
+				return
+			}
+
+			for _, id := range ids {
+				resp, err := client.ListInstances(ctx, &pb.ListInstancesRequest{
+					Scope: &pb.ListInstancesRequest_DeploymentId{DeploymentId: id},
+				})
+				if err != nil {
+					c.result(check, doctorFail, clierrors.Humanize(err), "")
+					return
+				}
+
+				if len(resp.Instances) == 0 {
+					missing = append(missing, fmt.Sprintf("%s (%s)", app.Name, id))
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		c.result(check, doctorWarn,
+			"Deployments without connected entrypoints: "+strings.Join(missing, ", "),
+			"Check the logs of the deployed apps for entrypoint errors. The entrypoint "+
+				"must be able to reach an advertise address of the server.")
+		return
+	}
+
+	c.result(check, doctorOK, "Entrypoints can connect to "+addrs[0].Addr+".", "")
+}
+
+// entrypointDeployments returns the IDs of the running deployments of the
+// app that have the entrypoint.
+func (c *DoctorCommand) entrypointDeployments(
+	ctx context.Context,
+	client pb.WaypointClient,
+	ref *pb.Ref_Application,
+) ([]string, error) {
+	resp, err := client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   ref,
+		Workspace:     c.refWorkspace,
+		PhysicalState: pb.Operation_CREATED,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, d := range resp.Deployments {
+		if d.HasEntrypointConfig {
+			result = append(result, d.Id)
+		}
+	}
+
+	return result, nil
+}
+
+// cleanupConn closes the connection when the command context is done.
+func (c *DoctorCommand) cleanupConn(conn *grpc.ClientConn) {
+	go func() {
+		<-c.Ctx.Done()
+		conn.Close()
+	}()
+}
+
+func (c *DoctorCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetConnection, nil)
+}
+
+func (c *DoctorCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *DoctorCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *DoctorCommand) Synopsis() string {
+	return "Diagnose common problems with the CLI, server and project"
+}
+
+func (c *DoctorCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint doctor [options]
+
+  Check the setup of Waypoint for common problems.
+
+  This checks the connection to the server, whether the token is valid,
+  whether runners are available, whether the plugins used by the
+  waypoint.hcl in the current directory can be found, and whether
+  entrypoints can reach the server. For every problem found a suggested
+  fix is shown. The exit code is non-zero if any check failed.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ExecHistoryCommand struct {
+	*baseCommand
+
+	flagDeploymentId string
+	flagLimit        int
+}
+
+func (c *ExecHistoryCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 1 {
+		c.ui.Output(c.Flags().Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if len(c.args) == 1 {
+		idx, err := strconv.ParseUint(c.args[0], 10, 64)
+		if err != nil {
+			c.ui.Output("Session must be the number of a session from the list.",
+				terminal.WithErrorStyle())
+			return 1
+		}
+
+		return c.showSession(idx)
+	}
+
+	limit := c.flagLimit
+	if limit < 0 {
+		limit = 0
+	}
+
+	resp, err := c.project.Client().ListExecSessions(c.Ctx, &pb.ListExecSessionsRequest{
+		DeploymentId: c.flagDeploymentId,
+		Limit:        uint32(limit),
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if len(resp.Sessions) == 0 {
+		c.ui.Output("No exec sessions have been recorded. Recording is enabled with\n"+
+			"\"waypoint server config-set -exec-recording=true\".", terminal.WithWarningStyle())
+		return 0
+	}
+
+	table := terminal.NewTable("#", "Deployment", "Command", "Identity", "Started", "Exit Code")
+	for _, s := range resp.Sessions {
+		var started string
+		if t, err := ptypes.Timestamp(s.StartTime); err == nil {
+			started = humanize.Time(t)
+		}
+
+		exit := "disconnected"
+		if s.Exited {
+			exit = strconv.Itoa(int(s.ExitCode))
+		}
+
+		table.Rich([]string{
+			strconv.FormatUint(s.Index, 10),
+			s.DeploymentId,
+			strings.Join(s.Args, " "),
+			s.Identity,
+			started,
+			exit,
+		}, nil)
+	}
+
+	c.ui.Table(table)
+	return 0
+}
+
+// showSession writes the recorded output of a session to stdout and stderr
+// as it was sent to the client.
+func (c *ExecHistoryCommand) showSession(idx uint64) int {
+	s, err := c.project.Client().GetExecSession(c.Ctx, &pb.GetExecSessionRequest{
+		Index: idx,
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	for _, o := range s.Output {
+		out := os.Stdout
+		if o.Channel == pb.ExecStreamResponse_Output_STDERR {
+			out = os.Stderr
+		}
+
+		out.Write(o.Data)
+	}
+
+	if s.Truncated {
+		c.ui.Output("The output was too large to record completely.", terminal.WithWarningStyle())
+	}
+
+	return 0
+}
+
+func (c *ExecHistoryCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "deployment-id",
+			Target: &c.flagDeploymentId,
+			Usage:  "Only list sessions for this deployment.",
+		})
+
+		f.IntVar(&flag.IntVar{
+			Name:    "limit",
+			Target:  &c.flagLimit,
+			Default: 20,
+			Usage:   "Maximum number of recent sessions to list. Set to 0 to list all.",
+		})
+	})
+}
+
+func (c *ExecHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExecHistoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExecHistoryCommand) Synopsis() string {
+	return "List recorded exec sessions or show the output of one"
+}
+
+func (c *ExecHistoryCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint exec-history [options] [SESSION]
+
+  List recorded "waypoint exec" sessions, newest first. With SESSION,
+  show the output of that session.
+
+  Sessions are only recorded if the server is configured to with
+  "waypoint server config-set -exec-recording=true". Only the output is
+  recorded. Input, such as passwords typed at a prompt, is never stored.
+
+` + c.Flags().Help())
+}
@@ -14,11 +14,13 @@ const (
 	filterOptionState
 	filterOptionPhyState
 	filterOptionOrder
+	filterOptionLabel
 )
 
 type filterFlags struct {
-	flagStatusFilter []string
-	flagPhysState    string
+	flagStatusFilter  []string
+	flagPhysState     string
+	flagLabelSelector string
 
 	order *pb.OperationOrder
 }
@@ -116,6 +118,10 @@ func (ff *filterFlags) orderOp() *pb.OperationOrder {
 	return ff.order
 }
 
+func (ff *filterFlags) labelSelector() string {
+	return ff.flagLabelSelector
+}
+
 func initFilterFlags(set *flag.Sets, ff *filterFlags, opts filterOption) {
 	f := set.NewSet("Filter Options")
 
@@ -127,6 +133,15 @@ func initFilterFlags(set *flag.Sets, ff *filterFlags, opts filterOption) {
 		f.EnumSingleVar(phyStateFlagVar(&ff.flagPhysState))
 	}
 
+	if opts == fillterOptionAll || opts&filterOptionLabel != 0 {
+		f.StringVar(&flag.StringVar{
+			Name:   "label",
+			Target: &ff.flagLabelSelector,
+			Usage: "Filter values by their labels, such as \"env=prod,team!=infra\". " +
+				"Use \"key\" or \"!key\" to filter on whether a label is set.",
+		})
+	}
+
 	if opts == fillterOptionAll || opts&filterOptionOrder != 0 {
 		f.EnumSingleVar(&flag.EnumSingleVar{
 			Name:   "order-by",
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+)
+
+// fmtCheckExitCode is the exit code of "fmt -check" when files aren't
+// formatted. This matches "terraform fmt -check".
+const fmtCheckExitCode = 3
+
+type FmtCommand struct {
+	*baseCommand
+
+	flagCheck bool
+}
+
+func (c *FmtCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithClient(false),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	paths := c.args
+	if len(paths) == 0 {
+		path, err := c.initConfigPath()
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		if path == "" {
+			c.ui.Output("No waypoint.hcl found. Give the paths of the files to format.\n\n"+
+				c.Help(), terminal.WithErrorStyle())
+			return 1
+		}
+
+		paths = []string{path}
+	}
+
+	unformatted := false
+	for _, path := range paths {
+		// The JSON syntax has no canonical format to rewrite to.
+		if configpkg.IsJSON(path) {
+			c.ui.Output("%s uses the JSON syntax of HCL, which can't be formatted.",
+				path, terminal.WithErrorStyle())
+			return 1
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		out, err := configpkg.Format(src, path)
+		if err != nil {
+			c.ui.Output("%s", err, terminal.WithErrorStyle())
+			return 1
+		}
+		if bytes.Equal(src, out) {
+			continue
+		}
+
+		// The files that changed, or would change with -check, are listed.
+		unformatted = true
+		c.ui.Output(path)
+		if c.flagCheck {
+			continue
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		if err := ioutil.WriteFile(path, out, fi.Mode()); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	if c.flagCheck && unformatted {
+		return fmtCheckExitCode
+	}
+
+	return 0
+}
+
+func (c *FmtCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "check",
+			Target: &c.flagCheck,
+			Usage: "Don't write the files. Exit with status 3 if any file isn't\n" +
+				"formatted, such as to check formatting in CI.",
+		})
+	})
+}
+
+func (c *FmtCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.hcl")
+}
+
+func (c *FmtCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *FmtCommand) Synopsis() string {
+	return "Rewrite waypoint.hcl in the canonical format"
+}
+
+func (c *FmtCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint fmt [options] [FILE...]
+
+  Rewrite waypoint.hcl in the canonical format and style of HCL, the same
+  as "terraform fmt". If no files are given, the waypoint.hcl of the
+  current project is formatted.
+
+  The files that were changed are listed. With -check no files are
+  written and the command exits with status 3 if any file isn't
+  formatted.
+
+` + c.Flags().Help())
+}
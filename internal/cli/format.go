@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 type idFormat struct {
@@ -26,3 +27,31 @@ func (i *idFormat) FormatId(seq uint64, long string) string {
 
 	return strconv.FormatUint(seq, 10)
 }
+
+// formatVCS returns the VCS info of an operation for output, such as
+// "abc1234 (main)". The commit is suffixed with "-dirty" if the source had
+// uncommitted changes. This is empty if the VCS info isn't known.
+func formatVCS(vcs *pb.Operation_VCS) string {
+	if vcs == nil || vcs.Commit == "" {
+		return ""
+	}
+
+	result := shortCommit(vcs.Commit)
+	if vcs.Dirty {
+		result += "-dirty"
+	}
+	if vcs.Branch != "" {
+		result += " (" + vcs.Branch + ")"
+	}
+
+	return result
+}
+
+// shortCommit returns the abbreviated form of a commit SHA for output.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+
+	return commit
+}
@@ -31,6 +31,9 @@ type InitCommand struct {
 	into   string
 	update bool
 
+	flagTemplate     string
+	flagTemplateVars map[string]string
+
 	project *clientpkg.Project
 	cfg     *configpkg.Config
 }
@@ -46,6 +49,12 @@ func (c *InitCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.from != "" && c.flagTemplate != "" {
+		c.ui.Output("Only one of -from-project and -template may be given.\n\n"+c.Help(),
+			terminal.WithErrorStyle())
+		return 1
+	}
+
 	if c.from != "" {
 		if c.into == "" {
 			if u, err := url.Parse(c.from); err == nil {
@@ -130,6 +139,24 @@ func (c *InitCommand) Run(args []string) int {
 		return 0
 	}
 
+	// Create the project from a template, then continue to initialize it
+	// like any other project so that it is registered with the server.
+	if c.flagTemplate != "" {
+		dir := c.into
+		if dir == "" {
+			dir = "."
+		}
+
+		if !c.initTemplate(dir) {
+			return 1
+		}
+
+		if err := os.Chdir(dir); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
 	path, err := c.initConfigPath()
 	if err != nil {
 		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
@@ -299,11 +326,15 @@ func (c *InitCommand) validateProject() bool {
 			}
 		}
 
+		// Polling and the stored waypoint.hcl are set with "project apply"
+		// so keep what is there.
 		resp, err := client.UpsertProject(c.Ctx, &pb.UpsertProjectRequest{
 			Project: &pb.Project{
-				Name:          ref.Project,
-				RemoteEnabled: c.cfg.Runner.Enabled,
-				DataSource:    ds,
+				Name:           ref.Project,
+				RemoteEnabled:  c.cfg.Runner.Enabled,
+				DataSource:     ds,
+				DataSourcePoll: project.GetDataSourcePoll(),
+				WaypointHcl:    project.GetWaypointHcl(),
 			},
 		})
 		if err != nil {
@@ -560,7 +591,21 @@ func (c *InitCommand) Flags() *flag.Sets {
 			Name:    "into",
 			Target:  &c.into,
 			Default: "",
-			Usage:   "Where to write the application fetched via -from",
+			Usage:   "Where to write the application fetched via -from-project or created with -template",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "template",
+			Target: &c.flagTemplate,
+			Usage: "Create a new project from a template and initialize it. This is the name " +
+				"of a built-in template such as \"go-grpc\" or a source such as a Git URL.",
+		})
+
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "template-var",
+			Target: &c.flagTemplateVars,
+			Usage: "Set a variable of the template as key=value instead of being prompted " +
+				"for it. This can be repeated.",
 		})
 
 		f.BoolVar(&flag.BoolVar{
@@ -598,6 +643,12 @@ Usage: waypoint init [options]
   This command is always safe to run multiple times. This command will never
   delete your configuration or any data in the server.
 
+  With -template a new project is created from a template, such as the
+  built-in "go-grpc" template or one fetched from a Git URL. You're prompted
+  for the variables of the template, then waypoint.hcl and the other files
+  of the template are written to the current directory (or -into) and the
+  project is initialized. Existing files are never overwritten.
+
 ` + c.Flags().Help())
 }
 
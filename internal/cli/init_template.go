@@ -0,0 +1,348 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	getter "github.com/hashicorp/go-getter"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/cli/datagen"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+)
+
+const (
+	// initTemplateAssetDir is the directory of the embedded templates.
+	initTemplateAssetDir = "templates"
+
+	// initTemplateManifest is the file in a template that declares its
+	// variables. It isn't written to the project.
+	initTemplateManifest = "template.hcl"
+
+	// initTemplateExt is the extension of the files in a template that
+	// are rendered. The extension is removed from the written file.
+	initTemplateExt = ".tpl"
+)
+
+// initTemplateConfig is the configuration in the manifest of a template.
+type initTemplateConfig struct {
+	Variables []*initTemplateVariable `hcl:"variable,block"`
+}
+
+// initTemplateVariable is a variable of a template. The default is itself
+// rendered so that it can refer to the variables declared before it.
+type initTemplateVariable struct {
+	Name        string `hcl:",label"`
+	Description string `hcl:"description,optional"`
+	Default     string `hcl:"default,optional"`
+}
+
+// initTemplate creates a project in dir from the template c.flagTemplate.
+// The template is either the name of an embedded template or a source
+// such as a git URL that go-getter can fetch.
+func (c *InitCommand) initTemplate(dir string) bool {
+	// We never want to overwrite an existing project.
+	if existing, err := configpkg.DirPath(dir); err != nil || existing != "" {
+		if err == nil {
+			err = fmt.Errorf(
+				"A Waypoint configuration already exists at %q. A template can "+
+					"only be used to create a new project.", existing)
+		}
+
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return false
+	}
+
+	td, err := ioutil.TempDir("", "waypoint-template")
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return false
+	}
+	defer os.RemoveAll(td)
+
+	sg := c.ui.StepGroup()
+	s := sg.Add("Fetching template %q...", c.flagTemplate)
+	src, err := c.initTemplateFetch(td)
+	if err != nil {
+		s.Status(terminal.StatusError)
+		s.Done()
+		sg.Wait()
+
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return false
+	}
+	s.Update("Fetched template %q", c.flagTemplate)
+	s.Status(terminal.StatusOK)
+	s.Done()
+	sg.Wait()
+
+	var cfg initTemplateConfig
+	manifest := filepath.Join(src, initTemplateManifest)
+	if _, err := os.Stat(manifest); err == nil {
+		file, diags := hclparse.NewParser().ParseHCLFile(manifest)
+		if !diags.HasErrors() {
+			diags = gohcl.DecodeBody(file.Body, nil, &cfg)
+		}
+		if diags.HasErrors() {
+			c.ui.Output(diags.Error(), terminal.WithErrorStyle())
+			return false
+		}
+	}
+
+	values, err := c.initTemplateValues(&cfg)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return false
+	}
+
+	files, err := initTemplateRender(src, dir, values)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return false
+	}
+
+	c.ui.Output("Project created from template %q:", c.flagTemplate,
+		terminal.WithStyle(terminal.SuccessBoldStyle))
+	for _, f := range files {
+		c.ui.Output("- %s", f, terminal.WithSuccessStyle())
+	}
+	c.ui.Output("")
+
+	return true
+}
+
+// initTemplateFetch writes the files of the template to a directory in td
+// and returns the path of that directory.
+func (c *InitCommand) initTemplateFetch(td string) (string, error) {
+	dst := filepath.Join(td, "template")
+
+	// Embedded templates take precedence over fetching a relative path.
+	names, err := datagen.AssetDir(initTemplateAssetDir)
+	if err != nil {
+		// Should never happen because it is embedded.
+		panic(err)
+	}
+	for _, name := range names {
+		if name == c.flagTemplate {
+			return dst, initTemplateRestore(dst, path.Join(initTemplateAssetDir, name))
+		}
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	client := &getter.Client{
+		Src: c.flagTemplate,
+		Dst: dst,
+		Pwd: pwd,
+		Dir: true,
+	}
+	if err := client.Get(); err != nil {
+		return "", fmt.Errorf(
+			"%q is not an embedded template and couldn't be fetched: %s",
+			c.flagTemplate, err)
+	}
+
+	return dst, nil
+}
+
+// initTemplateValues returns the values of the variables of the template.
+// Values come from -template-var, then a prompt if we're interactive,
+// then the default.
+func (c *InitCommand) initTemplateValues(cfg *initTemplateConfig) (map[string]string, error) {
+	values := map[string]string{}
+	for _, v := range cfg.Variables {
+		if value, ok := c.flagTemplateVars[v.Name]; ok {
+			values[v.Name] = value
+			continue
+		}
+
+		def, err := initTemplateExecute(v.Name, v.Default, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.ui.Interactive() {
+			desc := v.Description
+			if desc == "" {
+				desc = v.Name
+			}
+			if def != "" {
+				desc = fmt.Sprintf("%s [%s]", desc, def)
+			}
+
+			value, err := c.ui.Input(&terminal.Input{
+				Prompt: desc + ": ",
+			})
+			if err != nil {
+				return nil, err
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				def = value
+			}
+		}
+
+		if def == "" {
+			return nil, fmt.Errorf(
+				"The template variable %q is required. Set it with -template-var.", v.Name)
+		}
+
+		values[v.Name] = def
+	}
+
+	// Values for variables the template doesn't declare are likely typos.
+	var unknown []string
+	for k := range c.flagTemplateVars {
+		if _, ok := values[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf(
+			"The template doesn't declare the variable(s) set with -template-var: %s",
+			strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// initTemplateRestore writes the embedded asset directory name to dst.
+func initTemplateRestore(dst, name string) error {
+	children, err := datagen.AssetDir(name)
+	if err != nil {
+		// Not a directory, so a file.
+		data, err := datagen.Asset(name)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(dst, data, 0644)
+	}
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := initTemplateRestore(
+			filepath.Join(dst, child), path.Join(name, child)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initTemplateRender writes the files of the template in src to dst,
+// rendering the files ending in initTemplateExt with values. The paths of
+// the written files relative to dst are returned. No files are written if
+// any of them already exist.
+func initTemplateRender(src, dst string, values map[string]string) ([]string, error) {
+	type file struct {
+		Src  string
+		Rel  string
+		Mode os.FileMode
+	}
+
+	var files []file
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			// Templates fetched from git have the repository metadata.
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if rel == initTemplateManifest {
+			return nil
+		}
+
+		files = append(files, file{
+			Src:  p,
+			Rel:  strings.TrimSuffix(rel, initTemplateExt),
+			Mode: info.Mode(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Render everything first so that a bad template writes nothing.
+	data := make([][]byte, len(files))
+	for i, f := range files {
+		if _, err := os.Stat(filepath.Join(dst, f.Rel)); err == nil {
+			return nil, fmt.Errorf(
+				"The file %q already exists and would be overwritten by the template.", f.Rel)
+		}
+
+		src, err := ioutil.ReadFile(f.Src)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(f.Src, initTemplateExt) {
+			out, err := initTemplateExecute(f.Rel, string(src), values)
+			if err != nil {
+				return nil, err
+			}
+
+			src = []byte(out)
+		}
+
+		data[i] = src
+	}
+
+	var result []string
+	for i, f := range files {
+		p := filepath.Join(dst, f.Rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(p, data[i], f.Mode); err != nil {
+			return nil, err
+		}
+
+		result = append(result, f.Rel)
+	}
+
+	return result, nil
+}
+
+// initTemplateExecute renders the text/template text with values. It is an
+// error to refer to a value that isn't set.
+func initTemplateExecute(name, text string, values map[string]string) (string, error) {
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, values); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type JobCancelCommand struct {
+	*baseCommand
+}
+
+func (c *JobCancelCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single job ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := c.project.Client()
+	job, err := client.GetJob(c.Ctx, &pb.GetJobRequest{JobId: args[0]})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	switch job.State {
+	case pb.Job_SUCCESS, pb.Job_ERROR, pb.Job_TIMED_OUT:
+		c.ui.Output("Job %q has already completed.", job.Id, terminal.WithWarningStyle())
+		return 0
+	}
+
+	if _, err := client.CancelJob(c.Ctx, &pb.CancelJobRequest{JobId: job.Id}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if job.State == pb.Job_QUEUED || job.State == pb.Job_WAITING_APPROVAL {
+		c.ui.Output("Job %q cancelled.", job.Id, terminal.WithSuccessStyle())
+	} else {
+		c.ui.Output("Cancellation of job %q requested. The runner will stop the "+
+			"job and run any cancel hooks. Use \"waypoint job inspect\" to check "+
+			"its state.", job.Id, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *JobCancelCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *JobCancelCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *JobCancelCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *JobCancelCommand) Synopsis() string {
+	return "Cancel a queued or running job."
+}
+
+func (c *JobCancelCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint job cancel [options] JOB-ID
+
+  Cancel a queued or running job.
+
+  A queued job is cancelled immediately. For a running job the runner is
+  told to stop the operation, which runs any "cancel" hooks configured
+  for it. This command returns as soon as the cancellation is requested.
+
+` + c.Flags().Help())
+}
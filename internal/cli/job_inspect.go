@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type JobInspectCommand struct {
+	*baseCommand
+}
+
+func (c *JobInspectCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single job ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	job, err := c.project.Client().GetJob(c.Ctx, &pb.GetJobRequest{JobId: args[0]})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	var target string
+	switch t := job.TargetRunner.GetTarget().(type) {
+	case *pb.Ref_Runner_Any:
+		target = "any"
+	case *pb.Ref_Runner_Id:
+		target = t.Id.Id
+	case *pb.Ref_Runner_Labels:
+		var pairs []string
+		for k, v := range t.Labels.Labels {
+			pairs = append(pairs, k+"="+v)
+		}
+		target = strings.Join(pairs, ", ")
+	}
+
+	values := []terminal.NamedValue{
+		{Name: "ID", Value: job.Id},
+		{Name: "Operation", Value: jobOperationName(job)},
+		{Name: "Project", Value: job.Application.GetProject()},
+		{Name: "App", Value: job.Application.GetApplication()},
+		{Name: "Workspace", Value: job.Workspace.GetWorkspace()},
+		{Name: "State", Value: jobStateName(job)},
+		{Name: "Priority", Value: strings.ToLower(job.Priority.String())},
+		{Name: "Target Runner", Value: target},
+		{Name: "Assigned Runner", Value: job.AssignedRunner.GetId()},
+		{Name: "Queued", Value: jobTime(job.QueueTime)},
+		{Name: "Assigned", Value: jobTime(job.AssignTime)},
+		{Name: "Acked", Value: jobTime(job.AckTime)},
+		{Name: "Completed", Value: jobTime(job.CompleteTime)},
+	}
+	if job.ApprovalExpireTime != nil || job.ApproveTime != nil {
+		values = append(values,
+			terminal.NamedValue{Name: "Approval Expires", Value: jobTime(job.ApprovalExpireTime)},
+			terminal.NamedValue{Name: "Approved", Value: jobTime(job.ApproveTime)},
+		)
+	}
+	if d, ok := jobDuration(job); ok {
+		values = append(values, terminal.NamedValue{Name: "Duration", Value: d.String()})
+	}
+	if vcs := job.Vcs; vcs != nil {
+		values = append(values,
+			terminal.NamedValue{Name: "Commit", Value: vcs.Commit},
+			terminal.NamedValue{Name: "Branch", Value: vcs.Branch},
+			terminal.NamedValue{Name: "Dirty", Value: vcs.Dirty},
+			terminal.NamedValue{Name: "Remote", Value: vcs.RemoteUrl},
+		)
+	}
+	if job.Error != nil {
+		values = append(values,
+			terminal.NamedValue{Name: "Error Code", Value: job.Error.Code},
+			terminal.NamedValue{Name: "Error", Value: job.Error.Message},
+		)
+	}
+
+	c.ui.NamedValues(values)
+	return 0
+}
+
+// jobTime formats a job timestamp for output. Unset times are empty.
+func jobTime(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func (c *JobInspectCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *JobInspectCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *JobInspectCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *JobInspectCommand) Synopsis() string {
+	return "Show the details of a job."
+}
+
+func (c *JobInspectCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint job inspect [options] JOB-ID
+
+  Show the details of a single job, including the runner it was
+  assigned to, its timestamps, and any error it completed with.
+
+  Use "waypoint job list" to find job IDs.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type JobListCommand struct {
+	*baseCommand
+
+	flagWorkspaceAll bool
+	flagStates       []string
+	flagLimit        int
+}
+
+func (c *JobListCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithConfig(true),
+	); err != nil {
+		return 1
+	}
+
+	req := &pb.ListJobsRequest{}
+	if c.refProject != nil && c.refProject.Project != "" {
+		req.Project = c.refProject
+	}
+	if !c.flagWorkspaceAll {
+		req.Workspace = c.project.WorkspaceRef()
+	}
+	if c.flagLimit > 0 {
+		req.Limit = uint32(c.flagLimit)
+	}
+	for _, v := range c.flagStates {
+		st, ok := pb.Job_State_value[strings.ToUpper(v)]
+		if !ok {
+			c.ui.Output("Unknown job state %q.", v, terminal.WithErrorStyle())
+			return 1
+		}
+
+		req.States = append(req.States, pb.Job_State(st))
+	}
+
+	client := c.project.Client()
+	resp, err := client.ListJobs(c.Ctx, req)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if len(resp.Jobs) == 0 {
+		c.ui.Output("No jobs found.", terminal.WithWarningStyle())
+		return 0
+	}
+
+	tbl := terminal.NewTable("ID", "Operation", "App", "Workspace", "State", "Queued", "Duration", "Runner")
+	for _, job := range resp.Jobs {
+		var queued string
+		if t, err := ptypes.Timestamp(job.QueueTime); err == nil {
+			queued = humanize.Time(t)
+		}
+
+		var duration string
+		if d, ok := jobDuration(job); ok {
+			duration = d.String()
+		}
+
+		tbl.Rich([]string{
+			job.Id,
+			jobOperationName(job),
+			job.Application.GetApplication(),
+			job.Workspace.GetWorkspace(),
+			jobStateName(job),
+			queued,
+			duration,
+			job.AssignedRunner.GetId(),
+		}, []string{
+			"",
+			"",
+			"",
+			"",
+			jobStateColor(job.State),
+		})
+	}
+
+	c.ui.Table(tbl)
+	return 0
+}
+
+// jobOperationName returns the name of the operation of a job, such as
+// "build" or "deploy".
+func jobOperationName(job *pb.Job) string {
+	if job.Operation == nil {
+		return ""
+	}
+
+	name := fmt.Sprintf("%T", job.Operation)
+	name = strings.TrimPrefix(name[strings.LastIndex(name, ".")+1:], "Job_")
+	return strings.ToLower(strings.TrimSuffix(name, "_"))
+}
+
+// jobDuration returns how long the job has run for. This is from when the
+// runner accepted the job until it completed, or until now if it is still
+// running. This returns false if the job never started.
+func jobDuration(job *pb.Job) (time.Duration, bool) {
+	start := job.AckTime
+	if start == nil {
+		start = job.AssignTime
+	}
+	if start == nil {
+		return 0, false
+	}
+
+	from, err := ptypes.Timestamp(start)
+	if err != nil {
+		return 0, false
+	}
+
+	to := time.Now()
+	if job.CompleteTime != nil {
+		if t, err := ptypes.Timestamp(job.CompleteTime); err == nil {
+			to = t
+		}
+	}
+
+	return to.Sub(from).Round(time.Second), true
+}
+
+// jobStateName returns the lowercase name of the job state. Jobs that
+// were cancelled are in the error state so they are reported separately.
+func jobStateName(job *pb.Job) string {
+	if job.State == pb.Job_ERROR && job.CancelTime != nil {
+		return "cancelled"
+	}
+
+	return strings.ToLower(job.State.String())
+}
+
+// jobStateColor returns the table color for a job state.
+func jobStateColor(s pb.Job_State) string {
+	switch s {
+	case pb.Job_SUCCESS:
+		return terminal.Green
+	case pb.Job_ERROR, pb.Job_TIMED_OUT:
+		return terminal.Red
+	case pb.Job_RUNNING:
+		return terminal.Yellow
+	default:
+		return ""
+	}
+}
+
+func (c *JobListCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "workspace-all",
+			Target: &c.flagWorkspaceAll,
+			Usage:  "List jobs in all workspaces for this project.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "state",
+			Target: &c.flagStates,
+			Usage: "Only list jobs in this state, such as \"queued\", \"running\",\n" +
+				"\"success\", or \"error\". This can be repeated.",
+		})
+
+		f.IntVar(&flag.IntVar{
+			Name:    "limit",
+			Target:  &c.flagLimit,
+			Default: 20,
+			Usage:   "Maximum number of jobs to list, newest first. Set to 0 to list all.",
+		})
+	})
+}
+
+func (c *JobListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *JobListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *JobListCommand) Synopsis() string {
+	return "List queued, running, and completed jobs."
+}
+
+func (c *JobListCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint job list [options]
+
+  List the jobs for the current project and workspace, newest first.
+
+  If there is no waypoint.hcl in the current directory, jobs for all
+  projects are listed. Use "waypoint job inspect" for details of a job.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+	"github.com/skratchdot/open-golang/open"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/serverclient"
+)
+
+// loginTimeout is how long we wait for the user to log in with the
+// browser.
+const loginTimeout = 5 * time.Minute
+
+type LoginCommand struct {
+	*baseCommand
+
+	flagAuthMethod     string
+	flagContext        string
+	flagContextDefault bool
+}
+
+func (c *LoginCommand) Run(args []string) int {
+	ctx := c.Ctx
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithClient(false),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 1 {
+		c.ui.Output(c.Flags().Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	config := c.flagConnection
+	if len(c.args) == 1 {
+		config.Server.Address = c.args[0]
+	}
+	if config.Server.Address == "" {
+		c.ui.Output("A server address is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	conn, err := serverclient.Connect(ctx, serverclient.FromContextConfig(&config))
+	if err != nil {
+		c.ui.Output(
+			"Error connecting to the server: %s",
+			clierrors.Humanize(err),
+			terminal.WithErrorStyle(),
+		)
+		return 1
+	}
+	defer conn.Close()
+	client := pb.NewWaypointClient(conn)
+
+	method, err := c.authMethod(ctx, client)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	token, err := c.loginOIDC(ctx, client, method)
+	if err != nil {
+		c.ui.Output(
+			"Error logging in: %s",
+			clierrors.Humanize(err),
+			terminal.WithErrorStyle(),
+		)
+		return 1
+	}
+
+	// Store the token in a context for the server
+	name := c.flagContext
+	if name == "" {
+		name = config.Server.Address
+		if host, _, err := net.SplitHostPort(name); err == nil {
+			name = host
+		}
+	}
+
+	config.Server.RequireAuth = true
+	config.Server.AuthToken = token
+	if err := c.contextStorage.Set(name, &config); err != nil {
+		c.ui.Output(
+			"Error setting the CLI context: %s",
+			clierrors.Humanize(err),
+			terminal.WithErrorStyle(),
+		)
+		return 1
+	}
+	if c.flagContextDefault {
+		if err := c.contextStorage.SetDefault(name); err != nil {
+			c.ui.Output(
+				"Error setting the default CLI context: %s",
+				clierrors.Humanize(err),
+				terminal.WithErrorStyle(),
+			)
+			return 1
+		}
+	}
+
+	c.ui.Output("Logged in. The token is stored in the CLI context %q.", name,
+		terminal.WithSuccessStyle())
+	return 0
+}
+
+// authMethod returns the name of the auth method to log in with. If the
+// flag isn't set, the server must have exactly one OIDC auth method.
+func (c *LoginCommand) authMethod(ctx context.Context, client pb.WaypointClient) (string, error) {
+	if c.flagAuthMethod != "" {
+		return c.flagAuthMethod, nil
+	}
+
+	resp, err := client.ListOIDCAuthMethods(ctx, &empty.Empty{})
+	if err != nil {
+		return "", err
+	}
+
+	switch len(resp.AuthMethods) {
+	case 0:
+		return "", fmt.Errorf("The server has no auth methods to log in with.")
+
+	case 1:
+		return resp.AuthMethods[0].Name, nil
+
+	default:
+		var names []string
+		for _, m := range resp.AuthMethods {
+			names = append(names, m.Name)
+		}
+
+		return "", fmt.Errorf(
+			"The server has multiple auth methods. Choose one with -auth-method: %s",
+			strings.Join(names, ", "))
+	}
+}
+
+// loginOIDC sends the user to the OIDC provider in their browser and waits
+// for it to redirect back to a local listener with the code that the
+// server exchanges for a token.
+func (c *LoginCommand) loginOIDC(
+	ctx context.Context,
+	client pb.WaypointClient,
+	method string,
+) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	redirectURI := fmt.Sprintf("http://%s/oidc/callback", ln.Addr().String())
+
+	state, err := loginRandom()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := loginRandom()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.GetOIDCAuthURL(ctx, &pb.GetOIDCAuthURLRequest{
+		AuthMethod:  method,
+		RedirectUri: redirectURI,
+		State:       state,
+		Nonce:       nonce,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	codeCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/oidc/callback" {
+				http.NotFound(w, r)
+				return
+			}
+
+			q := r.URL.Query()
+			if q.Get("state") != state {
+				http.Error(w, "Invalid state. Please try logging in again.", http.StatusBadRequest)
+				return
+			}
+
+			if v := q.Get("error"); v != "" {
+				http.Error(w, "Login failed. You can close this window.", http.StatusUnauthorized)
+				select {
+				case errCh <- fmt.Errorf("%s %s", v, q.Get("error_description")):
+				default:
+				}
+				return
+			}
+
+			io.WriteString(w, "Login complete. You can close this window and return to the CLI.")
+			select {
+			case codeCh <- q.Get("code"):
+			default:
+			}
+		}),
+	}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	c.ui.Output("Opening the browser to log in. If it doesn't open, visit:\n\n  %s\n", resp.Url)
+	if err := open.Run(resp.Url); err != nil {
+		c.Log.Debug("error opening browser", "err", err)
+	}
+
+	var code string
+	select {
+	case code = <-codeCh:
+	case err := <-errCh:
+		return "", err
+	case <-time.After(loginTimeout):
+		return "", fmt.Errorf("timed out waiting for the browser login")
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	tokenResp, err := client.CompleteOIDCAuth(ctx, &pb.CompleteOIDCAuthRequest{
+		AuthMethod:  method,
+		RedirectUri: redirectURI,
+		Nonce:       nonce,
+		Code:        code,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tokenResp.Token, nil
+}
+
+// loginRandom returns a random value for the OIDC state and nonce.
+func loginRandom() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func (c *LoginCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetConnection, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "auth-method",
+			Target: &c.flagAuthMethod,
+			Usage: "Auth method to log in with. This is only required if the server " +
+				"has more than one.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "context-create",
+			Target: &c.flagContext,
+			Usage: "Name of the CLI context to store the token in. Defaults to the " +
+				"host name of the server.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:    "context-set-default",
+			Target:  &c.flagContextDefault,
+			Default: true,
+			Usage:   "Set the context as the default CLI context.",
+		})
+	})
+}
+
+func (c *LoginCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *LoginCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *LoginCommand) Synopsis() string {
+	return "Log in to a Waypoint server with the browser"
+}
+
+func (c *LoginCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint login [options] SERVER
+
+  Log in to a Waypoint server using an OIDC auth method configured on the
+  server, such as Google or Okta.
+
+  This opens the browser to log in with the provider. Afterwards the
+  server issues a token with the role of the auth method, which is stored
+  in a CLI context for the server.
+
+  SERVER is the address of the server, such as "waypoint.example.com:9701".
+
+` + c.Flags().Help())
+}
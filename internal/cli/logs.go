@@ -2,19 +2,34 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/posener/complete"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	clientpkg "github.com/hashicorp/waypoint/internal/client"
 	"github.com/hashicorp/waypoint/internal/clierrors"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 type LogsCommand struct {
 	*baseCommand
+
+	flagHistory  bool
+	flagLimit    int
+	flagFilter   string
+	flagSince    string
+	flagUntil    string
+	flagInstance string
+	flagFields   map[string]string
 }
 
 var headerColor = color.New(color.FgCyan)
@@ -29,8 +44,25 @@ func (c *LogsCommand) Run(args []string) int {
 		return 1
 	}
 
-	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
-		lv, err := app.Logs(ctx)
+	filter, err := c.filter(time.Now())
+	if err != nil {
+		c.ui.Output(err.Error(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	err = c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
+		var lv component.LogViewer
+		var err error
+		if c.flagHistory {
+			limit := c.flagLimit
+			if limit <= 0 {
+				limit = -1
+			}
+
+			lv, err = app.LogHistory(ctx, int32(limit), filter)
+		} else {
+			lv, err = app.Logs(ctx, filter)
+		}
 		if err != nil {
 			if !clierrors.IsCanceled(err) {
 				app.UI.Output("Error reading logs: %s", err, terminal.WithErrorStyle())
@@ -87,8 +119,107 @@ func (c *LogsCommand) Run(args []string) int {
 	return 0
 }
 
+// filter returns the server-side filter from the flags, or nil if no
+// filter flags are set.
+func (c *LogsCommand) filter(now time.Time) (*pb.GetLogStreamRequest_Filter, error) {
+	if c.flagFilter == "" && c.flagSince == "" && c.flagUntil == "" &&
+		c.flagInstance == "" && len(c.flagFields) == 0 {
+		return nil, nil
+	}
+
+	if _, err := regexp.Compile(c.flagFilter); err != nil {
+		return nil, fmt.Errorf("Invalid -filter pattern: %s", err)
+	}
+
+	result := &pb.GetLogStreamRequest_Filter{
+		Pattern:    c.flagFilter,
+		InstanceId: c.flagInstance,
+		Fields:     c.flagFields,
+	}
+
+	var err error
+	if result.Since, err = logsTime(now, "since", c.flagSince); err != nil {
+		return nil, err
+	}
+	if result.Until, err = logsTime(now, "until", c.flagUntil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// logsTime parses the value of -since or -until. This is either a duration
+// before now, such as "1h", or an RFC3339 time.
+func logsTime(now time.Time, name, v string) (*timestamp.Timestamp, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		d, derr := time.ParseDuration(v)
+		if derr != nil {
+			return nil, fmt.Errorf(
+				"-%s must be a duration such as \"1h\" or an RFC3339 time", name)
+		}
+
+		t = now.Add(-d)
+	}
+
+	return ptypes.TimestampProto(t)
+}
+
 func (c *LogsCommand) Flags() *flag.Sets {
-	return c.flagSet(0, nil)
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "history",
+			Target: &c.flagHistory,
+			Usage: "Show the logs stored by the server instead of following the\n" +
+				"running deployments. Logs are only stored if log retention is\n" +
+				"configured with \"waypoint server config-set\".",
+		})
+
+		f.IntVar(&flag.IntVar{
+			Name:    "limit",
+			Target:  &c.flagLimit,
+			Default: 1000,
+			Usage:   "Maximum number of recent lines to show with -history. Set to 0 to show all.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "filter",
+			Target: &c.flagFilter,
+			Usage:  "Only show lines matching this regular expression.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "since",
+			Target: &c.flagSince,
+			Usage: "Only show lines logged after this time. This is a duration before\n" +
+				"now such as \"30m\", or an RFC3339 time.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "until",
+			Target: &c.flagUntil,
+			Usage:  "Only show lines logged before this time, in the same format as -since.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "instance",
+			Target: &c.flagInstance,
+			Usage: "Only show lines from the instance with this ID. The six character\n" +
+				"short ID shown before each line can be used.",
+		})
+
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "field",
+			Target: &c.flagFields,
+			Usage: "Only show JSON log lines with this field set to this value, such\n" +
+				"as \"level=error\". This can be repeated to match multiple fields.",
+		})
+	})
 }
 
 func (c *LogsCommand) AutocompleteArgs() complete.Predictor {
@@ -116,5 +247,18 @@ Usage: waypoint logs [options]
   characters of the instance ID. This can be used to trace any logs back
   to a specific deployment or filter out certain log messages.
 
+  With -history, the logs stored by the server are shown instead, which
+  includes logs of instances that are no longer running.
+
+  The filter flags are applied by the server so only the matching lines
+  are downloaded. For example, to find errors from the last hour:
+
+    waypoint logs -history -filter 'error|panic' -since 1h
+
+  Lines that the app writes as JSON objects can also be filtered on their
+  top-level fields with -field:
+
+    waypoint logs -field level=error -field component=db
+
 ` + c.Flags().Help())
 }
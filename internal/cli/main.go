@@ -10,15 +10,19 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/go-glint"
+	"go.opentelemetry.io/otel/api/trace"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/pkg/signalcontext"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 	"github.com/hashicorp/waypoint/internal/version"
 )
 
@@ -28,6 +32,10 @@ const (
 
 	// EnvPlain is the env var that can be set to force plain output mode.
 	EnvPlain = "WAYPOINT_PLAIN"
+
+	// telemetryShutdownTimeout is how long we wait for pending spans to be
+	// exported before exiting.
+	telemetryShutdownTimeout = 5 * time.Second
 )
 
 var (
@@ -83,6 +91,35 @@ func Main(args []string) int {
 	base, commands := Commands(ctx, log, logOutput)
 	defer base.Close()
 
+	// Set up tracing. The server and runner agent are services of their
+	// own and aren't wrapped in a span since they run until stopped.
+	name := commandName(commands, args[1:])
+	service := "waypoint-cli"
+	switch name {
+	case "server run":
+		service = "waypoint-server"
+	case "runner agent":
+		service = "waypoint-runner"
+	}
+	shutdown, err := telemetry.Init(ctx, service)
+	if err != nil {
+		log.Warn("error initializing tracing, spans will not be exported", "err", err)
+	} else {
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+			defer cancel()
+			shutdown(ctx)
+		}()
+	}
+	if service == "waypoint-cli" && name != "" {
+		var span trace.Span
+		ctx, span = telemetry.Tracer().Start(ctx, "waypoint "+name)
+		defer span.End()
+
+		// Commands use the base context so they run inside the span.
+		base.Ctx = ctx
+	}
+
 	// Build the CLI
 	cli := &cli.CLI{
 		Name:                       args[0],
@@ -102,6 +139,20 @@ func Main(args []string) int {
 	return exitCode
 }
 
+// commandName returns the longest prefix of args that is a command, such
+// as "server config-set". The rest of the arguments aren't included since
+// they can contain values such as config variables.
+func commandName(commands map[string]cli.CommandFactory, args []string) string {
+	for i := len(args); i > 0; i-- {
+		name := strings.Join(args[:i], " ")
+		if _, ok := commands[name]; ok {
+			return name
+		}
+	}
+
+	return ""
+}
+
 // commands returns the map of commands that can be used to initialize a CLI.
 func Commands(
 	ctx context.Context,
@@ -144,17 +195,52 @@ func Commands(
 			}, nil
 		},
 
+		"fmt": func() (cli.Command, error) {
+			return &FmtCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"validate": func() (cli.Command, error) {
+			return &ValidateCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
 		"destroy": func() (cli.Command, error) {
 			return &DestroyCommand{
 				baseCommand: baseCommand,
 			}, nil
 		},
 
+		"rollback": func() (cli.Command, error) {
+			return &RollbackCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"status": func() (cli.Command, error) {
+			return &StatusCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"doctor": func() (cli.Command, error) {
+			return &DoctorCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
 		"exec": func() (cli.Command, error) {
 			return &ExecCommand{
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"exec-history": func() (cli.Command, error) {
+			return &ExecHistoryCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"config": func() (cli.Command, error) {
 			return &helpCommand{
 				SynopsisText: helpText["config"][0],
@@ -171,12 +257,29 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"config sync": func() (cli.Command, error) {
+			return &ConfigSyncCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"logs": func() (cli.Command, error) {
 			return &LogsCommand{
 				baseCommand: baseCommand,
 			}, nil
 		},
 
+		"auth": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["auth"][0],
+				HelpText:     helpText["auth"][1],
+			}, nil
+		},
+		"auth status": func() (cli.Command, error) {
+			return &AuthStatusCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
 		"artifact": func() (cli.Command, error) {
 			return &helpCommand{
 				SynopsisText: helpText["artifact"][0],
@@ -220,6 +323,16 @@ func Commands(
 			}, nil
 		},
 
+		"deployment approve": func() (cli.Command, error) {
+			return &DeploymentApproveCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"deployment diff": func() (cli.Command, error) {
+			return &DeploymentDiffCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"deployment destroy": func() (cli.Command, error) {
 			return &DeploymentDestroyCommand{
 				baseCommand: baseCommand,
@@ -259,6 +372,36 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"server audit-log": func() (cli.Command, error) {
+			return &ServerAuditLogCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"server snapshot": func() (cli.Command, error) {
+			return &ServerSnapshotCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"server restore": func() (cli.Command, error) {
+			return &ServerRestoreCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"server config-get": func() (cli.Command, error) {
+			return &ServerConfigGetCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"server config-history": func() (cli.Command, error) {
+			return &ServerConfigHistoryCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"server config-rollback": func() (cli.Command, error) {
+			return &ServerConfigRollbackCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"server config-set": func() (cli.Command, error) {
 			return &ServerConfigSetCommand{
 				baseCommand: baseCommand,
@@ -270,6 +413,16 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"plugin install": func() (cli.Command, error) {
+			return &PluginInstallCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"plugin list": func() (cli.Command, error) {
+			return &PluginListCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &VersionCommand{
 				baseCommand: baseCommand,
@@ -299,6 +452,38 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"job": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["job"][0],
+				HelpText:     helpText["job"][1],
+			}, nil
+		},
+		"job list": func() (cli.Command, error) {
+			return &JobListCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"job inspect": func() (cli.Command, error) {
+			return &JobInspectCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"job cancel": func() (cli.Command, error) {
+			return &JobCancelCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"pipeline": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["pipeline"][0],
+				HelpText:     helpText["pipeline"][1],
+			}, nil
+		},
+		"pipeline run": func() (cli.Command, error) {
+			return &PipelineRunCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"token": func() (cli.Command, error) {
 			return &helpCommand{
 				SynopsisText: helpText["token"][0],
@@ -315,6 +500,11 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"token rotate": func() (cli.Command, error) {
+			return &RotateTokenCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 		"token exchange": func() (cli.Command, error) {
 			return &ExchangeInviteCommand{
 				baseCommand: baseCommand,
@@ -332,6 +522,52 @@ func Commands(
 				baseCommand: baseCommand,
 			}, nil
 		},
+		"runner list": func() (cli.Command, error) {
+			return &RunnerListCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner inspect": func() (cli.Command, error) {
+			return &RunnerInspectCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner adopt": func() (cli.Command, error) {
+			return &RunnerAdoptCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner reject": func() (cli.Command, error) {
+			return &RunnerRejectCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner forget": func() (cli.Command, error) {
+			return &RunnerForgetCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner profile": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["runner profile"][0],
+				HelpText:     helpText["runner profile"][1],
+			}, nil
+		},
+		"runner profile set": func() (cli.Command, error) {
+			return &RunnerProfileSetCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner profile list": func() (cli.Command, error) {
+			return &RunnerProfileListCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"runner profile delete": func() (cli.Command, error) {
+			return &RunnerProfileDeleteCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
 
 		"context": func() (cli.Command, error) {
 			return &helpCommand{
@@ -375,6 +611,85 @@ func Commands(
 			}, nil
 		},
 
+		"project": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["project"][0],
+				HelpText:     helpText["project"][1],
+			}, nil
+		},
+		"project apply": func() (cli.Command, error) {
+			return &ProjectApplyCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"project inspect": func() (cli.Command, error) {
+			return &ProjectInspectCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"login": func() (cli.Command, error) {
+			return &LoginCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"auth-method": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["auth-method"][0],
+				HelpText:     helpText["auth-method"][1],
+			}, nil
+		},
+		"auth-method set": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["auth-method set"][0],
+				HelpText:     helpText["auth-method set"][1],
+			}, nil
+		},
+		"auth-method set oidc": func() (cli.Command, error) {
+			return &AuthMethodSetOIDCCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"auth-method list": func() (cli.Command, error) {
+			return &AuthMethodListCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"auth-method delete": func() (cli.Command, error) {
+			return &AuthMethodDeleteCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"trigger": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["trigger"][0],
+				HelpText:     helpText["trigger"][1],
+			}, nil
+		},
+		"trigger create": func() (cli.Command, error) {
+			return &TriggerCreateCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+		"trigger delete": func() (cli.Command, error) {
+			return &TriggerDeleteCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
+		"workspace": func() (cli.Command, error) {
+			return &helpCommand{
+				SynopsisText: helpText["workspace"][0],
+				HelpText:     helpText["workspace"][1],
+			}, nil
+		},
+		"workspace delete": func() (cli.Command, error) {
+			return &WorkspaceDeleteCommand{
+				baseCommand: baseCommand,
+			}, nil
+		},
+
 		"ui": func() (cli.Command, error) {
 			return &UICommand{
 				baseCommand: baseCommand,
@@ -593,6 +908,17 @@ Waypoint will search for artifacts to pass to the deployment phase.
 `,
 	},
 
+	"auth": {
+		"Plugin authentication",
+		`
+Check plugin authentication.
+
+Plugins such as builders and platforms often need credentials to
+access cloud providers and registries. These commands can be used to
+verify those credentials before running any operations.
+`,
+	},
+
 	"config": {
 		"Application configuration management",
 		`
@@ -642,6 +968,28 @@ For more information see: https://waypointproject.io/docs/url
 `,
 	},
 
+	"job": {
+		"Job inspection and management",
+		`
+Job inspection and management.
+
+Every operation such as a build or deploy is queued on the server as a
+job and executed by a runner. These commands show the jobs the server has
+processed, which is useful for debugging remote operations.
+`,
+	},
+
+	"pipeline": {
+		"Run app pipelines",
+		`
+Run app pipelines.
+
+A pipeline is an ordered list of stages configured with the "pipeline"
+stanza of an app in waypoint.hcl, such as building, deploying to a staging
+workspace, waiting for approval, and releasing to production.
+`,
+	},
+
 	"runner": {
 		"Runner management",
 		`
@@ -653,6 +1001,18 @@ entire section.
 `,
 	},
 
+	"runner profile": {
+		"Runner profile management",
+		`
+Runner profile management.
+
+Runner profiles describe how the server launches on-demand runners on
+Docker, Kubernetes or ECS. When a job is queued that no registered runner
+can run, the server launches a runner for the job with the profile of the
+job's project and stops it once the job completes.
+`,
+	},
+
 	"server": {
 		"Server management",
 		`
@@ -673,6 +1033,58 @@ Authenticate and invite collaborators.
 
 Tokens are the primary form of authentication to Waypoint. Everyone who
 accesses a Waypoint server requires a token.
+`,
+	},
+
+	"project": {
+		"Project management",
+		`
+Project management.
+
+Projects are registered with the server by "waypoint init". These commands
+change the settings the server keeps for a project, such as its data
+source for remote operations.
+`,
+	},
+
+	"auth-method": {
+		"Auth method management",
+		`
+Auth method management.
+
+Auth methods let users log in with "waypoint login" instead of being
+given a token. Logging in issues a token with the role of the auth method.
+`,
+	},
+
+	"auth-method set": {
+		"Create or update auth methods",
+		`
+Create or update auth methods.
+
+Each type of auth method has its own subcommand.
+`,
+	},
+
+	"trigger": {
+		"Webhook trigger management",
+		`
+Webhook trigger management.
+
+Triggers are webhook URLs that GitHub or GitLab call on push. Each push
+queues an up job for every application of the project at the pushed
+commit.
+`,
+	},
+
+	"workspace": {
+		"Workspace management",
+		`
+Workspace management.
+
+Workspaces separate the builds, deployments, and releases of an app, such
+as for staging and production. They are created automatically the first
+time an operation runs in them.
 `,
 	},
 }
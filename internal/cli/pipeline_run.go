@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	clientpkg "github.com/hashicorp/waypoint/internal/client"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type PipelineRunCommand struct {
+	*baseCommand
+}
+
+func (c *PipelineRunCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithSingleApp(),
+	); err != nil {
+		return 1
+	}
+
+	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
+		appCfg, ok := c.cfg.AppConfig(app.Ref().Application)
+		if !ok || appCfg.Pipeline == nil {
+			app.UI.Output("App %q has no pipeline configured.",
+				app.Ref().Application, terminal.WithErrorStyle())
+			return ErrSentinel
+		}
+
+		p := &core.Pipeline{
+			Stages: appCfg.Pipeline.Stages,
+			DoJob: func(ctx context.Context, stageJob *pb.Job) (*pb.Job_Result, error) {
+				job := app.Job()
+				job.Operation = stageJob.Operation
+				if stageJob.Workspace != nil {
+					job.Workspace = stageJob.Workspace
+				}
+
+				return app.DoJob(ctx, job)
+			},
+			Approve: func(ctx context.Context, s *config.PipelineStage) error {
+				return c.approve(app.UI, s)
+			},
+			UI:     app.UI,
+			Logger: c.Log,
+		}
+		if err := p.Run(ctx); err != nil {
+			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return ErrSentinel
+		}
+
+		app.UI.Output("Pipeline complete.", terminal.WithSuccessStyle())
+		return nil
+	})
+	if err != nil {
+		if err != ErrSentinel {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+// approve asks the user to approve an approval stage.
+func (c *PipelineRunCommand) approve(ui terminal.UI, s *config.PipelineStage) error {
+	if !ui.Interactive() {
+		return status.Errorf(codes.FailedPrecondition,
+			"approval stages require an interactive terminal")
+	}
+
+	for {
+		result, err := ui.Input(&terminal.Input{
+			Prompt: fmt.Sprintf("Continue past stage %q? [y/n]", s.Name),
+			Style:  terminal.WarningBoldStyle,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch strings.ToLower(result) {
+		case "y":
+			return nil
+		case "n":
+			return status.Errorf(codes.Aborted, "not approved")
+		}
+	}
+}
+
+func (c *PipelineRunCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetOperation, nil)
+}
+
+func (c *PipelineRunCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PipelineRunCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *PipelineRunCommand) Synopsis() string {
+	return "Run the pipeline configured for an app."
+}
+
+func (c *PipelineRunCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint pipeline run [options]
+
+  Run the stages of the "pipeline" configured for the app in order.
+
+  Each build, deploy, and release stage is run as a job the same as the
+  equivalent command. Deploy and release stages may target another
+  workspace. Approval stages ask for confirmation before continuing. The
+  pipeline stops at the first stage that fails.
+
+` + c.Flags().Help())
+}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"github.com/mitchellh/cli"
+
 	"github.com/hashicorp/waypoint-plugin-sdk"
 	"github.com/hashicorp/waypoint/internal/plugin"
 )
@@ -10,6 +12,10 @@ type PluginCommand struct {
 }
 
 func (c *PluginCommand) Run(args []string) int {
+	if len(args) == 0 {
+		return cli.RunResultHelp
+	}
+
 	plugin, ok := plugin.Builtins[args[0]]
 	if !ok {
 		panic("no such plugin: " + args[0])
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	"github.com/hashicorp/waypoint/internal/plugin"
+)
+
+type PluginInstallCommand struct {
+	*baseCommand
+
+	flagSource  string
+	flagVersion string
+	flagGlobal  bool
+}
+
+func (c *PluginInstallCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI. We
+	// load the config ourselves since it is optional for this command.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	path, err := c.initConfigPath()
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	configured := map[string]*configpkg.Plugin{}
+	if path != "" {
+		pwd = filepath.Dir(path)
+
+		cfg, err := c.initConfigLoad(path)
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		for _, p := range cfg.Plugins() {
+			configured[p.Name] = p
+		}
+	}
+
+	// Determine the plugins to install. Without arguments we install
+	// every plugin of the project that isn't built in.
+	names := c.args
+	if len(names) == 0 {
+		if path == "" {
+			c.ui.Output(
+				"No waypoint.hcl found. Specify the plugins to install as arguments.\n\n%s",
+				c.Help(),
+				terminal.WithErrorStyle(),
+			)
+			return 1
+		}
+
+		for name, p := range configured {
+			_, builtin := plugin.Builtins[name]
+			if builtin && p.Source == "" && c.flagSource == "" {
+				continue
+			}
+
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			c.ui.Output("All plugins used by %s are built in.", path)
+			return 0
+		}
+	}
+
+	dir := plugin.ProjectPath(pwd)
+	if c.flagGlobal {
+		dir, err = plugin.GlobalPath()
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	installer := &plugin.Installer{
+		Dir:    dir,
+		Logger: c.Log.Named("plugin-install"),
+	}
+
+	sg := c.ui.StepGroup()
+	defer sg.Wait()
+
+	failed := false
+	for _, name := range names {
+		source, constraint := c.flagSource, c.flagVersion
+		if p, ok := configured[name]; ok {
+			if source == "" {
+				source = p.Source
+			}
+			if constraint == "" {
+				constraint = p.Version
+			}
+		}
+
+		s := sg.Add("Installing plugin %q...", name)
+		installed, err := installer.Install(c.Ctx, name, source, constraint)
+		if err != nil {
+			s.Update("Error installing plugin %q: %s", name, clierrors.Humanize(err))
+			s.Status(terminal.StatusError)
+			s.Done()
+			failed = true
+			continue
+		}
+
+		s.Update("Installed plugin %q version %s to %s", name, installed.Version, installed.Path)
+		s.Status(terminal.StatusOK)
+		s.Done()
+	}
+
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+func (c *PluginInstallCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "source",
+			Target: &c.flagSource,
+			Usage: "Registry URL or GitHub repository (github.com/<owner>/<repo>) " +
+				"to install from. This overrides the source set in waypoint.hcl.",
+		})
+		f.StringVar(&flag.StringVar{
+			Name:   "version",
+			Target: &c.flagVersion,
+			Usage: "Version constraint of the plugin, such as \">= 0.3\". This " +
+				"overrides the version set in waypoint.hcl.",
+		})
+		f.BoolVar(&flag.BoolVar{
+			Name:   "global",
+			Target: &c.flagGlobal,
+			Usage: "Install into the plugin directory shared by all projects " +
+				"instead of the project's .waypoint/plugins directory.",
+		})
+	})
+}
+
+func (c *PluginInstallCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PluginInstallCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *PluginInstallCommand) Synopsis() string {
+	return "Install external plugins from a plugin registry."
+}
+
+func (c *PluginInstallCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint plugin install [options] [NAME...]
+
+  Download and install external plugins.
+
+  Without arguments this installs every plugin used by the waypoint.hcl in
+  the current directory that isn't built in. The source and version of
+  each plugin are taken from its plugin stanza:
+
+    plugin "hashicloud" {
+      source  = "github.com/example/waypoint-plugin-hashicloud"
+      version = ">= 0.3"
+    }
+
+  The newest version that matches the version constraint is installed.
+  Every download is verified against the checksum published by the
+  source before it is installed.
+
+  Plugins are installed into the project's .waypoint/plugins directory
+  unless -global is set.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	"github.com/hashicorp/waypoint/internal/plugin"
+)
+
+type PluginListCommand struct {
+	*baseCommand
+}
+
+func (c *PluginListCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI. We
+	// load the config ourselves since it is optional for this command.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	// Plugins are searched for relative to the waypoint.hcl if we have
+	// one and the working directory otherwise.
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	path, err := c.initConfigPath()
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	// The plugins used by the project, if we're in one.
+	used := map[string]struct{}{}
+	if path != "" {
+		pwd = filepath.Dir(path)
+
+		cfg, err := c.initConfigLoad(path)
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		for _, p := range cfg.Plugins() {
+			used[p.Name] = struct{}{}
+		}
+	}
+
+	paths, err := plugin.DefaultPaths(pwd)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	discovered, err := plugin.DiscoverAll(paths)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Plugin search paths, in order of precedence:", terminal.WithHeaderStyle())
+	for i, p := range paths {
+		if p == "" {
+			p = "."
+		}
+
+		c.ui.Output("%d. %s", i+1, p)
+	}
+	c.ui.Output("%d. built-in plugins", len(paths)+1)
+	c.ui.Output("")
+
+	// Build up the rows. Every name gets the plugin that is used first,
+	// followed by any plugins it shadows.
+	type row struct {
+		source   string
+		path     string
+		shadowed bool
+	}
+	rows := map[string][]row{}
+	for _, d := range discovered {
+		rows[d.Name] = append(rows[d.Name], row{source: "external", path: d.Path})
+		for _, p := range d.Shadowed {
+			rows[d.Name] = append(rows[d.Name], row{source: "external", path: p, shadowed: true})
+		}
+	}
+	for name := range plugin.Builtins {
+		rows[name] = append(rows[name], row{
+			source:   "builtin",
+			shadowed: len(rows[name]) > 0,
+		})
+	}
+
+	// Plugins used by the project that we can't find at all.
+	for name := range used {
+		if _, ok := rows[name]; !ok {
+			rows[name] = []row{{source: "not found"}}
+		}
+	}
+
+	names := make([]string, 0, len(rows))
+	for name := range rows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := terminal.NewTable("", "Name", "Source", "Path", "Status")
+	for _, name := range names {
+		usedMark := ""
+		if _, ok := used[name]; ok {
+			usedMark = "*"
+		}
+
+		for _, r := range rows[name] {
+			status := "active"
+			color := ""
+			switch {
+			case r.source == "not found":
+				status = "missing"
+				color = terminal.Red
+
+			case r.shadowed:
+				status = "shadowed"
+				color = terminal.Yellow
+			}
+
+			table.Rich([]string{
+				usedMark,
+				name,
+				r.source,
+				r.path,
+				status,
+			}, []string{
+				"",
+				"",
+				"",
+				"",
+				color,
+			})
+		}
+	}
+	c.ui.Table(table)
+
+	if len(used) > 0 {
+		c.ui.Output("")
+		c.ui.Output("Plugins marked with * are used by %s.", path)
+	}
+
+	return 0
+}
+
+func (c *PluginListCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *PluginListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *PluginListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *PluginListCommand) Synopsis() string {
+	return "List available plugins and where they are loaded from."
+}
+
+func (c *PluginListCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint plugin list [options]
+
+  Lists the plugins available to the project in the current directory.
+
+  External plugins are binaries named "waypoint-plugin-<name>" in the
+  plugin search paths. The search paths are shown in order of precedence:
+  if a plugin is found in multiple paths, the first one is used and the
+  others are shown as shadowed. External plugins take precedence over
+  built-in plugins of the same name.
+
+  Plugins used by the waypoint.hcl in the current directory are marked
+  and shown as missing if they can't be found.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/posener/complete"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	configpkg "github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ProjectApplyCommand struct {
+	*baseCommand
+
+	flagGitURL       string
+	flagGitRef       string
+	flagGitPath      string
+	flagPoll         bool
+	flagPollSet      bool
+	flagPollInterval time.Duration
+	flagWaypointHcl  string
+}
+
+func (c *ProjectApplyCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single project name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	name := c.args[0]
+
+	client := c.project.Client()
+
+	// Start from the current project so that settings that aren't given
+	// as flags are kept.
+	project := &pb.Project{Name: name}
+	resp, err := client.GetProject(c.Ctx, &pb.GetProjectRequest{
+		Project: &pb.Ref_Project{Project: name},
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	if err == nil {
+		project = resp.Project
+	}
+
+	if c.flagGitURL != "" {
+		project.DataSource = &pb.Job_DataSource{
+			Source: &pb.Job_DataSource_Git{
+				Git: &pb.Job_Git{
+					Url:  c.flagGitURL,
+					Ref:  c.flagGitRef,
+					Path: c.flagGitPath,
+				},
+			},
+		}
+		project.RemoteEnabled = true
+	}
+
+	if c.flagWaypointHcl != "" {
+		src, err := ioutil.ReadFile(c.flagWaypointHcl)
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		// Catch syntax errors now rather than when a runner uses it.
+		parser := hclparse.NewParser()
+		parse := parser.ParseHCL
+		if configpkg.IsJSON(c.flagWaypointHcl) {
+			parse = parser.ParseJSON
+		}
+		if _, diags := parse(src, c.flagWaypointHcl); diags.HasErrors() {
+			c.ui.Output(diags.Error(), terminal.WithErrorStyle())
+			return 1
+		}
+
+		project.WaypointHcl = src
+	}
+
+	if c.flagPollSet || c.flagPollInterval > 0 {
+		if project.DataSourcePoll == nil {
+			project.DataSourcePoll = &pb.Project_Poll{}
+		}
+		if c.flagPollSet {
+			project.DataSourcePoll.Enabled = c.flagPoll
+		}
+		if c.flagPollInterval > 0 {
+			project.DataSourcePoll.Interval = c.flagPollInterval.String()
+		}
+	}
+
+	if _, err := client.UpsertProject(c.Ctx, &pb.UpsertProjectRequest{
+		Project: project,
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Project %q applied.", name, terminal.WithSuccessStyle())
+	if poll := project.DataSourcePoll; poll != nil && poll.Enabled {
+		interval := poll.Interval
+		if interval == "" {
+			interval = "30s"
+		}
+
+		c.ui.Output("The git data source is polled every %s. New commits are "+
+			"deployed automatically.", interval, terminal.WithInfoStyle())
+	}
+
+	return 0
+}
+
+func (c *ProjectApplyCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "git-url",
+			Target: &c.flagGitURL,
+			Usage:  "URL of the Git repository to use as the data source for remote operations.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "git-ref",
+			Target: &c.flagGitRef,
+			Usage:  "Git ref to check out, such as a branch name. Defaults to the remote HEAD.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "git-path",
+			Target: &c.flagGitPath,
+			Usage:  "Subdirectory of the Git repository with the waypoint.hcl file.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "waypoint-hcl",
+			Target: &c.flagWaypointHcl,
+			Usage: "Path to a waypoint.hcl or waypoint.hcl.json to store on the server for " +
+				"the project. Remote runners use it if the data source doesn't have one.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "poll",
+			Target: &c.flagPoll,
+			Usage: "Poll the Git data source for new commits and run an up " +
+				"for every application when one lands. Use -poll=false to stop.",
+			SetHook: func(bool) { c.flagPollSet = true },
+		})
+
+		f.DurationVar(&flag.DurationVar{
+			Name:   "poll-interval",
+			Target: &c.flagPollInterval,
+			Usage:  "Interval between polls. Defaults to 30s.",
+		})
+	})
+}
+
+func (c *ProjectApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ProjectApplyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ProjectApplyCommand) Synopsis() string {
+	return "Create or update the server settings of a project."
+}
+
+func (c *ProjectApplyCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint project apply [options] NAME
+
+  Create or update the server settings of a project. Settings that aren't
+  given as flags are left unchanged.
+
+  With -waypoint-hcl the configuration of the project is stored on the
+  server. Remote operations, including those queued by polling, use it
+  when the data source doesn't have a waypoint.hcl, so the file doesn't
+  need to be committed to the repository. Use "waypoint project inspect
+  -waypoint-hcl" to view it.
+
+  With -poll the server watches the Git data source of the project and
+  queues an up job for every application when a new commit lands on the
+  tracked ref.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ProjectInspectCommand struct {
+	*baseCommand
+
+	flagWaypointHcl bool
+}
+
+func (c *ProjectInspectCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single project name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	ref := &pb.Ref_Project{Project: c.args[0]}
+
+	client := c.project.Client()
+
+	// Only output the stored waypoint.hcl so that it can be redirected
+	// to a file.
+	if c.flagWaypointHcl {
+		resp, err := client.GetProjectConfig(c.Ctx, &pb.GetProjectConfigRequest{
+			Project: ref,
+		})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		if len(resp.WaypointHcl) == 0 {
+			c.ui.Output("The project has no waypoint.hcl stored on the server.",
+				terminal.WithErrorStyle())
+			return 1
+		}
+
+		out, _, err := c.ui.OutputWriters()
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		out.Write(resp.WaypointHcl)
+		return 0
+	}
+
+	resp, err := client.GetProject(c.Ctx, &pb.GetProjectRequest{Project: ref})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	project := resp.Project
+
+	var apps []string
+	for _, app := range project.Applications {
+		apps = append(apps, app.Name)
+	}
+
+	dataSource := "none"
+	if git := project.DataSource.GetGit(); git != nil {
+		dataSource = "git " + git.Url
+		if git.Ref != "" {
+			dataSource += " (" + git.Ref + ")"
+		}
+	}
+
+	poll := "disabled"
+	if p := project.DataSourcePoll; p != nil && p.Enabled {
+		poll = "every " + p.Interval
+		if p.Interval == "" {
+			poll = "every 30s"
+		}
+	}
+
+	storedHcl := "no"
+	if len(project.WaypointHcl) > 0 {
+		storedHcl = "yes"
+	}
+
+	c.ui.NamedValues([]terminal.NamedValue{
+		{Name: "Name", Value: project.Name},
+		{Name: "Applications", Value: strings.Join(apps, ", ")},
+		{Name: "Remote Enabled", Value: project.RemoteEnabled},
+		{Name: "Data Source", Value: dataSource},
+		{Name: "Poll", Value: poll},
+		{Name: "Stored waypoint.hcl", Value: storedHcl},
+	})
+
+	return 0
+}
+
+func (c *ProjectInspectCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "waypoint-hcl",
+			Target: &c.flagWaypointHcl,
+			Usage:  "Only output the waypoint.hcl stored on the server for the project.",
+		})
+	})
+}
+
+func (c *ProjectInspectCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ProjectInspectCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ProjectInspectCommand) Synopsis() string {
+	return "Show the server settings of a project"
+}
+
+func (c *ProjectInspectCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint project inspect [options] NAME
+
+  Show the server settings of a project, such as its data source and
+  whether a waypoint.hcl is stored on the server for it.
+
+  With -waypoint-hcl only the stored waypoint.hcl is output.
+
+` + c.Flags().Help())
+}
@@ -21,6 +21,7 @@ type ReleaseCreateCommand struct {
 
 	flagRepeat     bool
 	flagDeployment string
+	flagDryRun     bool
 }
 
 func (c *ReleaseCreateCommand) Run(args []string) int {
@@ -124,6 +125,22 @@ func (c *ReleaseCreateCommand) Run(args []string) int {
 			return ErrSentinel
 		}
 
+		if c.flagDryRun {
+			app.UI.Output("Planning release...", terminal.WithHeaderStyle())
+			result, err := app.Release(ctx, &pb.Job_ReleaseOp{
+				Deployment: deploy,
+				Prune:      true,
+				DryRun:     true,
+			})
+			if err != nil {
+				app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+				return ErrSentinel
+			}
+
+			outputPlannedChanges(app.UI, result.PlannedChanges)
+			return nil
+		}
+
 		// UI
 		app.UI.Output("Releasing...", terminal.WithHeaderStyle())
 
@@ -169,6 +186,12 @@ func (c *ReleaseCreateCommand) Flags() *flag.Sets {
 			Target:  &c.flagDeployment,
 			Usage:   "Release the specified deployment.",
 		})
+		f.BoolVar(&flag.BoolVar{
+			Name:   "dry-run",
+			Target: &c.flagDryRun,
+			Usage: "Only show the changes the release would make. Nothing is released\n" +
+				"and no deployments are pruned.",
+		})
 	})
 }
 
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	clientpkg "github.com/hashicorp/waypoint/internal/client"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RollbackCommand struct {
+	*baseCommand
+
+	flagConfirm bool
+}
+
+func (c *RollbackCommand) Run(args []string) int {
+	defer c.Close()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithSingleApp(),
+	); err != nil {
+		return 1
+	}
+
+	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
+		result, err := app.Rollback(ctx, &pb.Job_RollbackOp{
+			Confirm: c.flagConfirm,
+		})
+		if err != nil {
+			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return ErrSentinel
+		}
+
+		app.UI.Output("\nRolled back to deployment %s of artifact %s.",
+			result.Deployment.Id, result.Deployment.ArtifactId,
+			terminal.WithSuccessStyle())
+		if result.Release.Url != "" {
+			app.UI.Output("Release URL: %s", result.Release.Url, terminal.WithSuccessStyle())
+		}
+
+		return nil
+	})
+	if err != nil {
+		if err != ErrSentinel {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func (c *RollbackCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetOperation, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "confirm",
+			Target: &c.flagConfirm,
+			Usage:  "Confirm rolling back a release that is baked.",
+		})
+	})
+}
+
+func (c *RollbackCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RollbackCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RollbackCommand) Synopsis() string {
+	return "Release the previously released deployment again"
+}
+
+func (c *RollbackCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint rollback [options]
+
+  Roll back to the previously released deployment.
+
+  This releases the deployment that was released before the latest
+  release again. If that deployment has been destroyed since, its
+  artifact is deployed again and the new deployment is released.
+
+  A release with a "rollback_window" is rolled back automatically if it
+  becomes unhealthy within the window. Once the window passes, or if the
+  release has none, the release is baked and this command requires
+  -confirm.
+
+  To roll back automatically when a deployment is unhealthy or its
+  release fails, set "rollback = true" in the deploy stanza.
+
+` + c.Flags().Help())
+}
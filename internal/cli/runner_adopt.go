@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerAdoptCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerAdoptCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single runner ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := c.project.Client()
+	if _, err := client.AdoptRunner(c.Ctx, &pb.AdoptRunnerRequest{
+		RunnerId: args[0],
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Runner %q adopted.", args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *RunnerAdoptCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerAdoptCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerAdoptCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerAdoptCommand) Synopsis() string {
+	return "Adopt a runner so it is assigned jobs"
+}
+
+func (c *RunnerAdoptCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner adopt [options] RUNNER-ID
+
+  Adopt a runner that is pending adoption so that the server assigns it
+  jobs and sends it its config.
+
+  Runners that register with the server are pending adoption until an
+  operator adopts them, unless they only run jobs that target them by ID.
+  Adopted runners are sent a runner token that they use to reconnect, so
+  they stay adopted across restarts. A rejected runner can be adopted.
+
+` + c.Flags().Help())
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/posener/complete"
@@ -15,6 +17,12 @@ import (
 
 type RunnerAgentCommand struct {
 	*baseCommand
+
+	flagLabels    map[string]string
+	flagId        string
+	flagByIdOnly  bool
+	flagTokenFile string
+	flagMaxJobs   int
 }
 
 func (c *RunnerAgentCommand) Run(args []string) int {
@@ -31,11 +39,32 @@ func (c *RunnerAgentCommand) Run(args []string) int {
 		return 1
 	}
 
+	// The server sends a runner token once the runner is adopted. We use
+	// it from then on for the runner endpoints and store it so that we're
+	// adopted on restart. Runners launched by the server are given one.
+	token := &serverclient.DynamicToken{}
+	token.Set(os.Getenv(serverclient.EnvRunnerToken))
+	if c.flagTokenFile != "" {
+		data, err := ioutil.ReadFile(c.flagTokenFile)
+		if err != nil && !os.IsNotExist(err) {
+			c.ui.Output(
+				"Error reading the runner token file: %s", err.Error(),
+				terminal.WithErrorStyle(),
+			)
+			return 1
+		}
+
+		if v := strings.TrimSpace(string(data)); v != "" {
+			token.Set(v)
+		}
+	}
+
 	// Connect to the server
 	log.Info("sourcing credentials and connecting to the Waypoint server")
 	conn, err := serverclient.Connect(ctx,
 		serverclient.FromContext(c.contextStorage, ""),
 		serverclient.FromEnv(),
+		serverclient.WithRunnerToken(token),
 	)
 	if err != nil {
 		c.ui.Output(
@@ -82,10 +111,33 @@ func (c *RunnerAgentCommand) Run(args []string) int {
 
 	// Create our runner
 	log.Info("initializing the runner")
-	runner, err := runnerpkg.New(
+	opts := []runnerpkg.Option{
 		runnerpkg.WithClient(client),
 		runnerpkg.WithLogger(log.Named("runner")),
-	)
+		runnerpkg.WithLabels(c.flagLabels),
+		runnerpkg.WithTokenHandler(func(v string) {
+			token.Set(v)
+			if c.flagTokenFile == "" {
+				return
+			}
+
+			if err := ioutil.WriteFile(c.flagTokenFile, []byte(v), 0600); err != nil {
+				log.Warn("error writing the runner token file", "err", err)
+			}
+		}),
+	}
+	if c.flagId != "" {
+		opts = append(opts, runnerpkg.WithId(c.flagId))
+	}
+	if c.flagByIdOnly {
+		opts = append(opts, runnerpkg.ByIdOnly())
+	}
+	if c.flagMaxJobs < 1 {
+		c.ui.Output("-max-jobs must be at least 1.", terminal.WithErrorStyle())
+		return 1
+	}
+	opts = append(opts, runnerpkg.WithMaxJobs(uint32(c.flagMaxJobs)))
+	runner, err := runnerpkg.New(opts...)
 	if err != nil {
 		c.ui.Output(
 			"Error initializing the runner: %s", err.Error(),
@@ -101,18 +153,21 @@ func (c *RunnerAgentCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Accept jobs in goroutine so that we can interrupt it.
-	go func() {
-		for {
-			if err := runner.Accept(ctx); err != nil {
-				if err == runnerpkg.ErrClosed {
-					return
+	// Accept jobs in goroutines so that we can interrupt them. We accept
+	// as many jobs at once as we may run.
+	for i := 0; i < c.flagMaxJobs; i++ {
+		go func() {
+			for {
+				if err := runner.Accept(ctx); err != nil {
+					if err == runnerpkg.ErrClosed {
+						return
+					}
+
+					log.Error("error running job", "err", err)
 				}
-
-				log.Error("error running job", "err", err)
 			}
-		}
-	}()
+		}()
+	}
 
 	// Wait for end
 	<-ctx.Done()
@@ -128,7 +183,40 @@ func (c *RunnerAgentCommand) Run(args []string) int {
 }
 
 func (c *RunnerAgentCommand) Flags() *flag.Sets {
-	return c.flagSet(0, nil)
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "label",
+			Target: &c.flagLabels,
+			Usage: "Labels to identify this runner, such as the host it runs on. " +
+				"Can be specified multiple times.",
+		})
+		f.StringVar(&flag.StringVar{
+			Name:   "id",
+			Target: &c.flagId,
+			Usage: "ID of the runner. This must be unique among all runners. " +
+				"By default a random ID is generated.",
+		})
+		f.BoolVar(&flag.BoolVar{
+			Name:   "by-id-only",
+			Target: &c.flagByIdOnly,
+			Usage:  "Only run jobs that target this runner by its ID.",
+		})
+		f.StringVar(&flag.StringVar{
+			Name:   "token-file",
+			Target: &c.flagTokenFile,
+			Usage: "File to store the runner token in. Once the runner is adopted, " +
+				"the server sends it a token that is written to this file. If the " +
+				"file exists, the runner connects with its token and is adopted " +
+				"again immediately. Use this with -id.",
+		})
+		f.IntVar(&flag.IntVar{
+			Name:    "max-jobs",
+			Target:  &c.flagMaxJobs,
+			Default: 1,
+			Usage:   "Maximum number of jobs the runner runs at once.",
+		})
+	})
 }
 
 func (c *RunnerAgentCommand) AutocompleteArgs() complete.Predictor {
@@ -149,5 +237,10 @@ Usage: waypoint runner agent [options]
 
   Run a runner for executing remote operations.
 
+  New runners are pending until an operator adopts them with
+  "waypoint runner adopt". Only adopted runners are assigned jobs.
+  Runners that only run jobs targeting them by ID are adopted
+  automatically.
+
 ` + c.Flags().Help())
 }
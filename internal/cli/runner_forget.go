@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerForgetCommand struct {
+	*baseCommand
+
+	flagAllUnhealthy bool
+}
+
+func (c *RunnerForgetCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	client := c.project.Client()
+
+	ids := c.args
+	if c.flagAllUnhealthy {
+		if len(ids) > 0 {
+			c.ui.Output("Runner IDs can't be given with -all-unhealthy.\n\n"+c.Help(), terminal.WithErrorStyle())
+			return 1
+		}
+
+		resp, err := client.ListRunners(c.Ctx, &empty.Empty{})
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+		for _, r := range resp.Runners {
+			if r.Health == pb.Runner_UNHEALTHY {
+				ids = append(ids, r.Id)
+			}
+		}
+
+		if len(ids) == 0 {
+			c.ui.Output("No unhealthy runners found.")
+			return 0
+		}
+	}
+	if len(ids) == 0 {
+		c.ui.Output("At least one runner ID is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	for _, id := range ids {
+		if _, err := client.ForgetRunner(c.Ctx, &pb.ForgetRunnerRequest{
+			RunnerId: id,
+		}); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		c.ui.Output("Runner %q forgotten.", id, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *RunnerForgetCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "all-unhealthy",
+			Target: &c.flagAllUnhealthy,
+			Usage:  "Forget every runner that stopped heartbeating.",
+		})
+	})
+}
+
+func (c *RunnerForgetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerForgetCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerForgetCommand) Synopsis() string {
+	return "Remove runners that stopped heartbeating"
+}
+
+func (c *RunnerForgetCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner forget [options] [RUNNER-ID...]
+
+  Remove runners that stopped heartbeating from the server.
+
+  Runners that go away without deregistering are kept as unhealthy so that
+  they show up in "waypoint runner list". Forget them once they won't come
+  back. Healthy runners can't be forgotten. A forgotten runner that comes
+  back registers again.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerInspectCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerInspectCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single runner ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := c.project.Client()
+	r, err := client.GetRunner(c.Ctx, &pb.GetRunnerRequest{RunnerId: args[0]})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	jobsResp, err := client.ListJobs(c.Ctx, &pb.ListJobsRequest{
+		States:         []pb.Job_State{pb.Job_WAITING, pb.Job_RUNNING},
+		AssignedRunner: &pb.Ref_RunnerId{Id: r.Id},
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	var components []string
+	for _, comp := range r.Components {
+		components = append(components, strings.ToLower(comp.Type.String())+"/"+comp.Name)
+	}
+
+	health, _ := runnerHealth(r)
+	adoption, _ := runnerAdoption(r)
+	c.ui.NamedValues([]terminal.NamedValue{
+		{Name: "ID", Value: r.Id},
+		{Name: "Health", Value: health},
+		{Name: "Adoption", Value: adoption},
+		{Name: "Last Seen", Value: jobTime(r.LastHeartbeatTime)},
+		{Name: "Labels", Value: runnerLabels(r)},
+		{Name: "By ID Only", Value: r.ByIdOnly},
+		{Name: "Max Jobs", Value: runnerMaxJobs(r)},
+		{Name: "Version", Value: r.Version},
+		{Name: "Components", Value: strings.Join(components, ", ")},
+	})
+
+	if len(jobsResp.Jobs) == 0 {
+		c.ui.Output("")
+		c.ui.Output("The runner isn't running any jobs.")
+		return 0
+	}
+
+	c.ui.Output("Assigned Jobs", terminal.WithHeaderStyle())
+	tbl := terminal.NewTable("ID", "Operation", "Project", "App", "Workspace", "State")
+	for _, job := range jobsResp.Jobs {
+		tbl.Rich([]string{
+			job.Id,
+			jobOperationName(job),
+			job.Application.GetProject(),
+			job.Application.GetApplication(),
+			job.Workspace.GetWorkspace(),
+			jobStateName(job),
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *RunnerInspectCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerInspectCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerInspectCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerInspectCommand) Synopsis() string {
+	return "Show the details of a runner"
+}
+
+func (c *RunnerInspectCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner inspect [options] RUNNER-ID
+
+  Show the details of a single runner, including its health, when it
+  last heartbeat, and the jobs it is running.
+
+  Use "waypoint runner list" to find runner IDs.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerListCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerListCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	client := c.project.Client()
+	resp, err := client.ListRunners(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	if len(resp.Runners) == 0 {
+		c.ui.Output("No runners found.")
+		return 0
+	}
+
+	// Count the jobs each runner is currently running
+	jobsResp, err := client.ListJobs(c.Ctx, &pb.ListJobsRequest{
+		States: []pb.Job_State{pb.Job_WAITING, pb.Job_RUNNING},
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	jobs := map[string]int{}
+	for _, job := range jobsResp.Jobs {
+		jobs[job.AssignedRunner.GetId()]++
+	}
+
+	tbl := terminal.NewTable("ID", "Health", "Adoption", "Last Seen", "Labels", "Jobs", "Version")
+	for _, r := range resp.Runners {
+		health, color := runnerHealth(r)
+		adoption, adoptionColor := runnerAdoption(r)
+		jobCount := strconv.Itoa(jobs[r.Id])
+		if r.MaxJobs > 0 {
+			jobCount += "/" + strconv.Itoa(int(r.MaxJobs))
+		}
+		tbl.Rich([]string{
+			r.Id,
+			health,
+			adoption,
+			runnerLastSeen(r),
+			runnerLabels(r),
+			jobCount,
+			r.Version,
+		}, []string{
+			"",
+			color,
+			adoptionColor,
+		})
+	}
+
+	c.ui.Table(tbl)
+	return 0
+}
+
+// runnerHealth returns the health of the runner for output and the color
+// to show it in.
+func runnerHealth(r *pb.Runner) (string, string) {
+	switch r.Health {
+	case pb.Runner_HEALTHY:
+		return "healthy", terminal.Green
+	case pb.Runner_UNHEALTHY:
+		return "unhealthy", terminal.Red
+	default:
+		return "unknown", ""
+	}
+}
+
+// runnerAdoption returns the adoption state of the runner for output and
+// the color to show it in.
+func runnerAdoption(r *pb.Runner) (string, string) {
+	switch r.AdoptionState {
+	case pb.Runner_ADOPTED:
+		return "adopted", ""
+	case pb.Runner_REJECTED:
+		return "rejected", terminal.Red
+	default:
+		return "pending", terminal.Yellow
+	}
+}
+
+// runnerMaxJobs returns the number of jobs the runner runs at once for
+// output.
+func runnerMaxJobs(r *pb.Runner) string {
+	if r.MaxJobs == 0 {
+		return "unlimited"
+	}
+
+	return strconv.Itoa(int(r.MaxJobs))
+}
+
+// runnerLastSeen returns when the runner last heartbeat for output.
+func runnerLastSeen(r *pb.Runner) string {
+	t, err := ptypes.Timestamp(r.LastHeartbeatTime)
+	if err != nil {
+		return ""
+	}
+
+	return humanize.Time(t)
+}
+
+// runnerLabels returns the labels of the runner sorted as k=v pairs.
+func runnerLabels(r *pb.Runner) string {
+	labels := make([]string, 0, len(r.Labels))
+	for k, v := range r.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+
+	return strings.Join(labels, ", ")
+}
+
+func (c *RunnerListCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerListCommand) Synopsis() string {
+	return "List registered runners"
+}
+
+func (c *RunnerListCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner list
+
+  List the runners registered with the server, their health and the
+  number of jobs they're running.
+
+  Runners heartbeat to the server while they're connected. A runner that
+  stops heartbeating is unhealthy and isn't assigned jobs. The jobs it was
+  running are errored, or re-queued if the server is configured with
+  "waypoint server config-set -requeue-lost-jobs=true". Use "waypoint runner
+  forget" to remove runners that won't come back.
+
+  Runners that are pending adoption aren't assigned jobs until they're
+  adopted with "waypoint runner adopt".
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerProfileDeleteCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerProfileDeleteCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single runner profile name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if _, err := c.project.Client().DeleteRunnerProfile(c.Ctx, &pb.DeleteRunnerProfileRequest{
+		Name: c.args[0],
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Runner profile %q deleted.", c.args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *RunnerProfileDeleteCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerProfileDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerProfileDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerProfileDeleteCommand) Synopsis() string {
+	return "Delete a runner profile"
+}
+
+func (c *RunnerProfileDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner profile delete NAME
+
+  Delete a runner profile. Runners that were already launched with the
+  profile aren't stopped.
+
+` + c.Flags().Help())
+}
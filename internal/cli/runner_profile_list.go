@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/server/runnerlaunch"
+)
+
+type RunnerProfileListCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerProfileListCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	resp, err := c.project.Client().ListRunnerProfiles(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+	if len(resp.Profiles) == 0 {
+		c.ui.Output("No runner profiles found.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("Name", "Project", "Platform", "Image")
+	for _, p := range resp.Profiles {
+		project := p.Project
+		if project == "" {
+			project = "(default)"
+		}
+
+		image := p.Image
+		if image == "" {
+			image = runnerlaunch.DefaultImage
+		}
+
+		var platform string
+		switch p.Platform.(type) {
+		case *pb.RunnerProfile_Docker_:
+			platform = "docker"
+		case *pb.RunnerProfile_Kubernetes_:
+			platform = "kubernetes"
+		case *pb.RunnerProfile_Ecs:
+			platform = "ecs"
+		}
+
+		tbl.Rich([]string{
+			p.Name,
+			project,
+			platform,
+			image,
+		}, nil)
+	}
+
+	c.ui.Table(tbl)
+	return 0
+}
+
+func (c *RunnerProfileListCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerProfileListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerProfileListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerProfileListCommand) Synopsis() string {
+	return "List runner profiles"
+}
+
+func (c *RunnerProfileListCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner profile list
+
+  List the profiles that on-demand runners are launched with.
+
+` + c.Flags().Help())
+}
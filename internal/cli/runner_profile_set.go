@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerProfileSetCommand struct {
+	*baseCommand
+
+	flagProject  string
+	flagImage    string
+	flagEnvVars  map[string]string
+	flagPlatform string
+
+	flagDockerNetwork string
+
+	flagK8SNamespace      string
+	flagK8SServiceAccount string
+
+	flagECSRegion           string
+	flagECSCluster          string
+	flagECSSubnets          []string
+	flagECSSecurityGroups   []string
+	flagECSExecutionRoleArn string
+	flagECSTaskRoleArn      string
+	flagECSCPU              string
+	flagECSMemory           string
+	flagECSAssignPublicIp   bool
+}
+
+func (c *RunnerProfileSetCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single runner profile name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	profile := &pb.RunnerProfile{
+		Name:    c.args[0],
+		Project: c.flagProject,
+		Image:   c.flagImage,
+		EnvVars: c.flagEnvVars,
+	}
+
+	switch c.flagPlatform {
+	case "docker":
+		profile.Platform = &pb.RunnerProfile_Docker_{
+			Docker: &pb.RunnerProfile_Docker{
+				Network: c.flagDockerNetwork,
+			},
+		}
+
+	case "kubernetes":
+		profile.Platform = &pb.RunnerProfile_Kubernetes_{
+			Kubernetes: &pb.RunnerProfile_Kubernetes{
+				Namespace:      c.flagK8SNamespace,
+				ServiceAccount: c.flagK8SServiceAccount,
+			},
+		}
+
+	case "ecs":
+		profile.Platform = &pb.RunnerProfile_Ecs{
+			Ecs: &pb.RunnerProfile_ECS{
+				Region:           c.flagECSRegion,
+				Cluster:          c.flagECSCluster,
+				Subnets:          c.flagECSSubnets,
+				SecurityGroups:   c.flagECSSecurityGroups,
+				ExecutionRoleArn: c.flagECSExecutionRoleArn,
+				TaskRoleArn:      c.flagECSTaskRoleArn,
+				Cpu:              c.flagECSCPU,
+				Memory:           c.flagECSMemory,
+				AssignPublicIp:   c.flagECSAssignPublicIp,
+			},
+		}
+
+	default:
+		c.ui.Output("The -platform flag is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := c.project.Client().UpsertRunnerProfile(c.Ctx, &pb.UpsertRunnerProfileRequest{
+		Profile: profile,
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Runner profile %q set.", resp.Profile.Name, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *RunnerProfileSetCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "project",
+			Target: &c.flagProject,
+			Usage: "Project whose jobs use this profile. If this isn't set, the " +
+				"profile is used for all projects without a profile of their own.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "image",
+			Target: &c.flagImage,
+			Usage: "Container image of the runner. Its entrypoint must be the " +
+				"waypoint binary. Defaults to hashicorp/waypoint:latest.",
+		})
+
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "env-var",
+			Target: &c.flagEnvVars,
+			Usage: "Environment variable to set for the runner, in the format " +
+				"KEY=VALUE. This can be repeated.",
+		})
+
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "platform",
+			Target: &c.flagPlatform,
+			Values: []string{"docker", "kubernetes", "ecs"},
+			Usage:  "Platform to launch runners on.",
+		})
+
+		f = set.NewSet("Docker Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "docker-network",
+			Target: &c.flagDockerNetwork,
+			Usage:  "Network to attach runner containers to.",
+		})
+
+		f = set.NewSet("Kubernetes Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "k8s-namespace",
+			Target: &c.flagK8SNamespace,
+			Usage:  "Namespace of runner pods. Defaults to the configured namespace.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "k8s-service-account",
+			Target: &c.flagK8SServiceAccount,
+			Usage:  "Service account that runner pods run as.",
+		})
+
+		f = set.NewSet("ECS Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-region",
+			Target: &c.flagECSRegion,
+			Usage:  "AWS region to run runner tasks in.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-cluster",
+			Target: &c.flagECSCluster,
+			Usage:  "ECS cluster to run runner tasks in. Defaults to the default cluster.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "ecs-subnet",
+			Target: &c.flagECSSubnets,
+			Usage:  "Subnet of runner tasks. This can be repeated.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "ecs-security-group",
+			Target: &c.flagECSSecurityGroups,
+			Usage:  "Security group of runner tasks. This can be repeated.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-execution-role-arn",
+			Target: &c.flagECSExecutionRoleArn,
+			Usage:  "IAM role used to pull the runner image and write logs.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-task-role-arn",
+			Target: &c.flagECSTaskRoleArn,
+			Usage:  "IAM role the runner uses to access AWS.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-cpu",
+			Target: &c.flagECSCPU,
+			Usage:  "CPU units of runner tasks. Defaults to 512.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "ecs-memory",
+			Target: &c.flagECSMemory,
+			Usage:  "Memory of runner tasks in MiB. Defaults to 1024.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "ecs-assign-public-ip",
+			Target: &c.flagECSAssignPublicIp,
+			Usage:  "Assign a public IP address to runner tasks.",
+		})
+	})
+}
+
+func (c *RunnerProfileSetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerProfileSetCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerProfileSetCommand) Synopsis() string {
+	return "Create or update a runner profile"
+}
+
+func (c *RunnerProfileSetCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner profile set [options] NAME
+
+  Create or update a runner profile.
+
+  When a job is queued that no registered runner can run, the server
+  launches an on-demand runner with the profile of the job's project.
+  The runner only runs that job and is stopped once the job completes.
+
+  The runner connects to the first advertise address of the server, so
+  the address must be set with "waypoint server config-set". Credentials
+  for the platform come from the environment of the server.
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type RunnerRejectCommand struct {
+	*baseCommand
+}
+
+func (c *RunnerRejectCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	args = c.args
+	if len(args) != 1 {
+		c.ui.Output("A single runner ID is required.\n\n%s", c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := c.project.Client()
+	if _, err := client.RejectRunner(c.Ctx, &pb.RejectRunnerRequest{
+		RunnerId: args[0],
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Runner %q rejected.", args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *RunnerRejectCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *RunnerRejectCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunnerRejectCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunnerRejectCommand) Synopsis() string {
+	return "Reject a runner so it is never assigned jobs"
+}
+
+func (c *RunnerRejectCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint runner reject [options] RUNNER-ID
+
+  Reject a runner so that the server never assigns it jobs or sends it its
+  config. The runner is disconnected.
+
+  Use this for runners that are pending adoption but aren't trusted. An
+  adopted runner can be rejected, which stops it from receiving new jobs.
+  Rejected runners stay rejected when they register again until they are
+  adopted with "waypoint runner adopt".
+
+` + c.Flags().Help())
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ServerAuditLogCommand struct {
+	*baseCommand
+
+	flagLimit int
+	flagJson  bool
+}
+
+func (c *ServerAuditLogCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	limit := c.flagLimit
+	if limit < 0 {
+		limit = 0
+	}
+
+	client := c.project.Client()
+	resp, err := client.GetAuditLog(c.Ctx, &pb.GetAuditLogRequest{
+		Limit: uint32(limit),
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if c.flagJson {
+		if err := c.displayJson(resp.Events); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		return 0
+	}
+
+	if len(resp.Events) == 0 {
+		c.ui.Output("No audit events have been recorded.", terminal.WithWarningStyle())
+		return 0
+	}
+
+	table := terminal.NewTable("Time", "Endpoint", "Identity", "Result")
+	for i := len(resp.Events) - 1; i >= 0; i-- {
+		e := resp.Events[i]
+
+		var eventTime string
+		if t, err := ptypes.Timestamp(e.Time); err == nil {
+			eventTime = humanize.Time(t)
+		}
+
+		result := "ok"
+		if e.Error != nil {
+			result = e.Error.Message
+		}
+
+		table.Rich([]string{
+			eventTime,
+			e.Endpoint,
+			auditIdentity(e),
+			result,
+		}, nil)
+	}
+
+	c.ui.Table(table)
+	return 0
+}
+
+func (c *ServerAuditLogCommand) displayJson(events []*pb.AuditEvent) error {
+	output := []interface{}{}
+	for _, e := range events {
+		i := map[string]interface{}{
+			"index":    e.Index,
+			"endpoint": e.Endpoint,
+			"identity": auditIdentity(e),
+		}
+
+		if t, err := ptypes.Timestamp(e.Time); err == nil {
+			i["time"] = t.Format(time.RFC3339Nano)
+		}
+
+		if e.Error != nil {
+			i["error"] = map[string]interface{}{
+				"code":    e.Error.Code,
+				"message": e.Error.Message,
+			}
+		}
+
+		output = append(output, i)
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	c.ui.Output(string(data))
+	return nil
+}
+
+// auditIdentity returns the caller of an audit event for display. Calls
+// made without a token show as anonymous.
+func auditIdentity(e *pb.AuditEvent) string {
+	if e.Identity == "" {
+		return "anonymous"
+	}
+
+	return e.Identity
+}
+
+func (c *ServerAuditLogCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.IntVar(&flag.IntVar{
+			Name:    "limit",
+			Target:  &c.flagLimit,
+			Default: 50,
+			Usage:   "Maximum number of recent events to show. Set to 0 to show all.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "json",
+			Target: &c.flagJson,
+			Usage:  "Output the audit events as JSON, oldest first.",
+		})
+	})
+}
+
+func (c *ServerAuditLogCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServerAuditLogCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerAuditLogCommand) Synopsis() string {
+	return "Show the audit log of changes made through the server API."
+}
+
+func (c *ServerAuditLogCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server audit-log [options]
+
+  Show the calls that changed server state, newest first.
+
+  Every API call that can change state is recorded with the time, the
+  identity of the token that made it, and whether it succeeded.
+
+` + c.Flags().Help())
+}
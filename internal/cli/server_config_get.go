@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ServerConfigGetCommand struct {
+	*baseCommand
+
+	flagJson bool
+}
+
+func (c *ServerConfigGetCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	client := c.project.Client()
+	resp, err := client.GetServerConfig(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	cfg := resp.Config
+	if cfg == nil {
+		cfg = &pb.ServerConfig{}
+	}
+
+	if c.flagJson {
+		if err := c.displayJson(cfg); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		return 0
+	}
+
+	if len(cfg.AdvertiseAddrs) == 0 {
+		c.ui.Output("No server configuration is set.", terminal.WithWarningStyle())
+		return 0
+	}
+
+	table := terminal.NewTable("Advertise Address", "TLS", "TLS Skip Verify")
+	for _, addr := range cfg.AdvertiseAddrs {
+		table.Rich([]string{
+			addr.Addr,
+			strconv.FormatBool(addr.Tls),
+			strconv.FormatBool(addr.TlsSkipVerify),
+		}, nil)
+	}
+
+	c.ui.Table(table)
+
+	if cfg.DeploymentRetention > 0 {
+		c.ui.Output("Deployment retention: %d", cfg.DeploymentRetention)
+	}
+	if cfg.LogRetention > 0 {
+		c.ui.Output("Log retention: %d lines", cfg.LogRetention)
+	}
+	apps := make([]string, 0, len(cfg.AppLogRetention))
+	for app := range cfg.AppLogRetention {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+	for _, app := range apps {
+		c.ui.Output("Log retention for %s: %d lines", app, cfg.AppLogRetention[app])
+	}
+	if cfg.ExecRecording {
+		c.ui.Output("Exec recording: enabled")
+	}
+	if cfg.RequeueLostJobs {
+		c.ui.Output("Re-queue lost jobs: enabled")
+	}
+	if cfg.ProjectJobLimit > 0 {
+		c.ui.Output("Project job limit: %d jobs", cfg.ProjectJobLimit)
+	}
+	projects := make([]string, 0, len(cfg.ProjectJobLimits))
+	for project := range cfg.ProjectJobLimits {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	for _, project := range projects {
+		c.ui.Output("Job limit for %s: %d jobs", project, cfg.ProjectJobLimits[project])
+	}
+	if cfg.JobScheduling != "" {
+		c.ui.Output("Job scheduling: %s", cfg.JobScheduling)
+	}
+	ops := make([]string, 0, len(cfg.JobTimeouts))
+	for op := range cfg.JobTimeouts {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	for _, op := range ops {
+		c.ui.Output("Job timeout for %s: %s", op, cfg.JobTimeouts[op])
+	}
+	for _, sink := range cfg.LogSinks {
+		c.ui.Output("Log sink: %s", logSinkString(sink))
+	}
+
+	return 0
+}
+
+func (c *ServerConfigGetCommand) displayJson(cfg *pb.ServerConfig) error {
+	logSinks := []string{}
+	for _, sink := range cfg.LogSinks {
+		logSinks = append(logSinks, logSinkString(sink))
+	}
+
+	addrs := []interface{}{}
+	for _, addr := range cfg.AdvertiseAddrs {
+		addrs = append(addrs, map[string]interface{}{
+			"addr":            addr.Addr,
+			"tls":             addr.Tls,
+			"tls_skip_verify": addr.TlsSkipVerify,
+		})
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"advertise_addrs":      addrs,
+		"deployment_retention": cfg.DeploymentRetention,
+		"exec_recording":       cfg.ExecRecording,
+		"log_retention":        cfg.LogRetention,
+		"app_log_retention":    cfg.AppLogRetention,
+		"log_sinks":            logSinks,
+		"requeue_lost_jobs":    cfg.RequeueLostJobs,
+		"job_timeouts":         cfg.JobTimeouts,
+		"project_job_limit":    cfg.ProjectJobLimit,
+		"project_job_limits":   cfg.ProjectJobLimits,
+		"job_scheduling":       cfg.JobScheduling,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	c.ui.Output(string(data))
+	return nil
+}
+
+func (c *ServerConfigGetCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "json",
+			Target: &c.flagJson,
+			Usage:  "Output the server configuration as JSON.",
+		})
+	})
+}
+
+func (c *ServerConfigGetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServerConfigGetCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerConfigGetCommand) Synopsis() string {
+	return "Show the server online configuration."
+}
+
+func (c *ServerConfigGetCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server config-get [options]
+
+  Show the online configuration for a running Waypoint server.
+
+  This is the configuration set with "waypoint server config-set" that is
+  persisted in the server database.
+
+` + c.Flags().Help())
+}
+
+// logSinkString formats the sink in the same form as -log-sink.
+func logSinkString(sink *pb.ServerConfig_LogSink) string {
+	switch s := sink.Sink.(type) {
+	case *pb.ServerConfig_LogSink_Syslog_:
+		network := s.Syslog.Network
+		if network == "" {
+			network = "udp"
+		}
+
+		return fmt.Sprintf("syslog=%s://%s", network, s.Syslog.Address)
+
+	case *pb.ServerConfig_LogSink_Loki_:
+		return "loki=" + s.Loki.Url
+
+	case *pb.ServerConfig_LogSink_Cloudwatch:
+		if s.Cloudwatch.Region != "" {
+			return fmt.Sprintf("cloudwatch=%s@%s", s.Cloudwatch.LogGroup, s.Cloudwatch.Region)
+		}
+
+		return "cloudwatch=" + s.Cloudwatch.LogGroup
+
+	default:
+		return "unknown"
+	}
+}
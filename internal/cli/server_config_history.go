@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+)
+
+type ServerConfigHistoryCommand struct {
+	*baseCommand
+}
+
+func (c *ServerConfigHistoryCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	client := c.project.Client()
+	resp, err := client.ListServerConfigHistory(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if len(resp.Versions) == 0 {
+		c.ui.Output("No server configuration has been set.", terminal.WithWarningStyle())
+		return 0
+	}
+
+	table := terminal.NewTable("Version", "Set", "Set By", "Advertise Addresses")
+	for i := len(resp.Versions) - 1; i >= 0; i-- {
+		v := resp.Versions[i]
+
+		var setTime string
+		if t, err := ptypes.Timestamp(v.Time); err == nil {
+			setTime = humanize.Time(t)
+		}
+
+		setBy := v.SetBy
+		if setBy == "" {
+			setBy = "server"
+		}
+
+		var addrs []string
+		for _, addr := range v.Config.GetAdvertiseAddrs() {
+			addrs = append(addrs, addr.Addr)
+		}
+
+		table.Rich([]string{
+			strconv.FormatUint(v.Version, 10),
+			setTime,
+			setBy,
+			strings.Join(addrs, ", "),
+		}, nil)
+	}
+
+	c.ui.Table(table)
+	return 0
+}
+
+func (c *ServerConfigHistoryCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *ServerConfigHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServerConfigHistoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerConfigHistoryCommand) Synopsis() string {
+	return "List previous versions of the server online configuration."
+}
+
+func (c *ServerConfigHistoryCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server config-history [options]
+
+  List every version of the server online configuration, newest first.
+
+  A previous version can be restored with "waypoint server config-rollback".
+
+` + c.Flags().Help())
+}
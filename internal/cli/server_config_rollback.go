@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ServerConfigRollbackCommand struct {
+	*baseCommand
+}
+
+func (c *ServerConfigRollbackCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	version, err := strconv.ParseUint(c.args[0], 10, 64)
+	if err != nil {
+		c.ui.Output("Version must be a number: %s", c.args[0], terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := c.project.Client()
+	_, err = client.RollbackServerConfig(c.Ctx, &pb.RollbackServerConfigRequest{
+		Version: version,
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Server configuration rolled back to version %d.", version,
+		terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ServerConfigRollbackCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *ServerConfigRollbackCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ServerConfigRollbackCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerConfigRollbackCommand) Synopsis() string {
+	return "Restore a previous version of the server online configuration."
+}
+
+func (c *ServerConfigRollbackCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server config-rollback [options] VERSION
+
+  Restore a previous version of the server online configuration.
+
+  Versions are listed with "waypoint server config-history". The rollback
+  is recorded as a new version so it can itself be undone.
+
+` + c.Flags().Help())
+}
@@ -1,17 +1,38 @@
 package cli
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/clierrors"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/posener/complete"
 )
 
 type ServerConfigSetCommand struct {
 	*baseCommand
 
-	flagAdvertiseAddr pb.ServerConfig_AdvertiseAddr
+	flagAdvertiseAddrs    []string
+	flagAdvertiseInsecure []string
+	flagAllowInsecure     bool
+	flagAdvertiseTls      bool
+	flagAdvertiseSkip     bool
+	flagRetention         int
+	flagExecRecording     string
+	flagLogRetention      int
+	flagAppLogRetention   map[string]string
+	flagLogSinks          []string
+	flagRequeueLostJobs   string
+	flagJobTimeouts       map[string]string
+	flagProjectJobLimit   int
+	flagProjectJobLimits  map[string]string
+	flagJobScheduling     string
 }
 
 func (c *ServerConfigSetCommand) Run(args []string) int {
@@ -23,14 +44,68 @@ func (c *ServerConfigSetCommand) Run(args []string) int {
 		return 1
 	}
 
-	cfg := &pb.ServerConfig{
-		AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{
-			&c.flagAdvertiseAddr,
-		},
+	client := c.project.Client()
+
+	// Settings are kept as they are unless their flags are set.
+	resp, err := client.GetServerConfig(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
 	}
 
-	client := c.project.Client()
-	_, err := client.SetServerConfig(c.Ctx, &pb.SetServerConfigRequest{
+	cfg, err := c.serverConfig(resp.Config)
+	if err != nil {
+		c.ui.Output(err.Error(), terminal.WithErrorStyle())
+		return 1
+	}
+	if resp.Config != nil {
+		cfg.DeploymentRetention = resp.Config.DeploymentRetention
+		cfg.ExecRecording = resp.Config.ExecRecording
+		cfg.LogRetention = resp.Config.LogRetention
+		cfg.AppLogRetention = resp.Config.AppLogRetention
+		cfg.LogSinks = resp.Config.LogSinks
+		cfg.RequeueLostJobs = resp.Config.RequeueLostJobs
+		cfg.JobTimeouts = resp.Config.JobTimeouts
+		cfg.ProjectJobLimit = resp.Config.ProjectJobLimit
+		cfg.ProjectJobLimits = resp.Config.ProjectJobLimits
+		cfg.JobScheduling = resp.Config.JobScheduling
+	}
+	if c.flagRetention >= 0 {
+		cfg.DeploymentRetention = uint32(c.flagRetention)
+	}
+	if c.flagExecRecording != "" {
+		cfg.ExecRecording = c.flagExecRecording == "true"
+	}
+	if c.flagRequeueLostJobs != "" {
+		cfg.RequeueLostJobs = c.flagRequeueLostJobs == "true"
+	}
+	if c.flagLogRetention >= 0 {
+		cfg.LogRetention = uint32(c.flagLogRetention)
+	}
+	if err := c.setAppLogRetention(cfg); err != nil {
+		c.ui.Output(err.Error(), terminal.WithErrorStyle())
+		return 1
+	}
+	c.setJobTimeouts(cfg)
+	if c.flagProjectJobLimit >= 0 {
+		cfg.ProjectJobLimit = uint32(c.flagProjectJobLimit)
+	}
+	if err := c.setProjectJobLimits(cfg); err != nil {
+		c.ui.Output(err.Error(), terminal.WithErrorStyle())
+		return 1
+	}
+	if c.flagJobScheduling != "" {
+		cfg.JobScheduling = c.flagJobScheduling
+	}
+	if len(c.flagLogSinks) > 0 {
+		cfg.LogSinks, err = parseLogSinks(c.flagLogSinks)
+		if err != nil {
+			c.ui.Output(err.Error(), terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	_, err = client.SetServerConfig(c.Ctx, &pb.SetServerConfigRequest{
 		Config: cfg,
 	})
 	if err != nil {
@@ -42,29 +117,339 @@ func (c *ServerConfigSetCommand) Run(args []string) int {
 	return 0
 }
 
+// setAppLogRetention applies -app-log-retention to cfg. An empty value
+// removes the app so that it uses the default retention again.
+func (c *ServerConfigSetCommand) setAppLogRetention(cfg *pb.ServerConfig) error {
+	if len(c.flagAppLogRetention) == 0 {
+		return nil
+	}
+
+	result := map[string]uint32{}
+	for k, v := range cfg.AppLogRetention {
+		result[k] = v
+	}
+
+	for app, value := range c.flagAppLogRetention {
+		if strings.Count(app, "/") != 1 {
+			return fmt.Errorf(
+				"-app-log-retention apps must be in the form project/app, got %q", app)
+		}
+
+		if value == "" {
+			delete(result, app)
+			continue
+		}
+
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf(
+				"-app-log-retention for %q must be a number of lines", app)
+		}
+
+		result[app] = uint32(n)
+	}
+
+	cfg.AppLogRetention = result
+	return nil
+}
+
+// setJobTimeouts applies -job-timeout to cfg. An empty value removes the
+// timeout for the operation. The server validates the timeouts.
+func (c *ServerConfigSetCommand) setJobTimeouts(cfg *pb.ServerConfig) {
+	if len(c.flagJobTimeouts) == 0 {
+		return
+	}
+
+	result := map[string]string{}
+	for k, v := range cfg.JobTimeouts {
+		result[k] = v
+	}
+
+	for op, timeout := range c.flagJobTimeouts {
+		if timeout == "" {
+			delete(result, op)
+			continue
+		}
+
+		result[op] = timeout
+	}
+
+	cfg.JobTimeouts = result
+}
+
+// setProjectJobLimits applies -job-limit-for-project to cfg. An empty value
+// removes the project so that it uses the default limit again.
+func (c *ServerConfigSetCommand) setProjectJobLimits(cfg *pb.ServerConfig) error {
+	if len(c.flagProjectJobLimits) == 0 {
+		return nil
+	}
+
+	result := map[string]uint32{}
+	for k, v := range cfg.ProjectJobLimits {
+		result[k] = v
+	}
+
+	for project, value := range c.flagProjectJobLimits {
+		if value == "" {
+			delete(result, project)
+			continue
+		}
+
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf(
+				"-job-limit-for-project for %q must be a number of jobs", project)
+		}
+
+		result[project] = uint32(n)
+	}
+
+	cfg.ProjectJobLimits = result
+	return nil
+}
+
+// parseLogSinks parses the -log-sink values. "none" removes all sinks.
+func parseLogSinks(values []string) ([]*pb.ServerConfig_LogSink, error) {
+	var result []*pb.ServerConfig_LogSink
+	for _, v := range values {
+		if v == "none" {
+			if len(values) > 1 {
+				return nil, fmt.Errorf("-log-sink none can't be combined with other sinks")
+			}
+
+			return nil, nil
+		}
+
+		idx := strings.Index(v, "=")
+		if idx <= 0 || idx == len(v)-1 {
+			return nil, fmt.Errorf("-log-sink must be in the form TYPE=ADDRESS, got %q", v)
+		}
+		kind, addr := v[:idx], v[idx+1:]
+
+		switch kind {
+		case "syslog":
+			network := "udp"
+			if idx := strings.Index(addr, "://"); idx >= 0 {
+				network, addr = addr[:idx], addr[idx+3:]
+			}
+
+			result = append(result, &pb.ServerConfig_LogSink{
+				Sink: &pb.ServerConfig_LogSink_Syslog_{
+					Syslog: &pb.ServerConfig_LogSink_Syslog{
+						Network: network,
+						Address: addr,
+					},
+				},
+			})
+
+		case "loki":
+			result = append(result, &pb.ServerConfig_LogSink{
+				Sink: &pb.ServerConfig_LogSink_Loki_{
+					Loki: &pb.ServerConfig_LogSink_Loki{Url: addr},
+				},
+			})
+
+		case "cloudwatch":
+			var region string
+			if idx := strings.LastIndex(addr, "@"); idx >= 0 {
+				addr, region = addr[:idx], addr[idx+1:]
+			}
+
+			result = append(result, &pb.ServerConfig_LogSink{
+				Sink: &pb.ServerConfig_LogSink_Cloudwatch{
+					Cloudwatch: &pb.ServerConfig_LogSink_CloudWatch{
+						Region:   region,
+						LogGroup: addr,
+					},
+				},
+			})
+
+		default:
+			return nil, fmt.Errorf(
+				"-log-sink type must be syslog, loki or cloudwatch, got %q", kind)
+		}
+	}
+
+	return result, nil
+}
+
+// serverConfig builds the server config from the address flags. Addresses
+// keep the order they were given in. If no address flags are set, the
+// addresses of the current config are kept.
+func (c *ServerConfigSetCommand) serverConfig(current *pb.ServerConfig) (*pb.ServerConfig, error) {
+	if len(c.flagAdvertiseAddrs) == 0 && len(c.flagAdvertiseInsecure) == 0 &&
+		current != nil && len(current.AdvertiseAddrs) > 0 {
+		return &pb.ServerConfig{AdvertiseAddrs: current.AdvertiseAddrs}, nil
+	}
+
+	// Entrypoints that connect without TLS can't use client certificates,
+	// so this must be asked for explicitly.
+	if len(c.flagAdvertiseInsecure) > 0 && !c.flagAllowInsecure {
+		return nil, fmt.Errorf(
+			"-advertise-insecure is deprecated because entrypoints connecting without\n" +
+				"TLS can't use mutual TLS. Set -allow-insecure-entrypoints to use it anyway.")
+	}
+
+	insecure := map[string]bool{}
+	for _, addr := range c.flagAdvertiseInsecure {
+		insecure[addr] = true
+	}
+
+	cfg := &pb.ServerConfig{}
+	for _, addr := range c.flagAdvertiseAddrs {
+		tls := c.flagAdvertiseTls
+		if insecure[addr] {
+			tls = false
+			delete(insecure, addr)
+		}
+
+		cfg.AdvertiseAddrs = append(cfg.AdvertiseAddrs, &pb.ServerConfig_AdvertiseAddr{
+			Addr:          addr,
+			Tls:           tls,
+			TlsSkipVerify: tls && c.flagAdvertiseSkip,
+		})
+	}
+
+	// Every insecure address must pair with an advertised address.
+	for addr := range insecure {
+		return nil, fmt.Errorf(
+			"-advertise-insecure %q must also be set with -advertise-addr", addr)
+	}
+
+	// With no addresses we send a single blank address. This disables
+	// entrypoint communication with the server. A blank -advertise-addr
+	// does the same for a server that has addresses.
+	if len(cfg.AdvertiseAddrs) == 0 {
+		cfg.AdvertiseAddrs = []*pb.ServerConfig_AdvertiseAddr{{}}
+	}
+
+	return cfg, nil
+}
+
 func (c *ServerConfigSetCommand) Flags() *flag.Sets {
 	return c.flagSet(0, func(set *flag.Sets) {
 		f := set.NewSet("Command Options")
-		f.StringVar(&flag.StringVar{
+		f.StringSliceVar(&flag.StringSliceVar{
 			Name:   "advertise-addr",
-			Target: &c.flagAdvertiseAddr.Addr,
+			Target: &c.flagAdvertiseAddrs,
 			Usage: "Address to advertise for the server. This is used by the entrypoints\n" +
 				"binaries to communicate back to the server. If this is blank, then\n" +
 				"the entrypoints will not communicate to the server. Features such as\n" +
-				"logs, exec, etc. will not work.",
+				"logs, exec, etc. will not work. This can be specified multiple times\n" +
+				"to advertise multiple addresses, in order of preference. If this\n" +
+				"isn't set, the current addresses are kept.",
+		})
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "advertise-insecure",
+			Target: &c.flagAdvertiseInsecure,
+			Usage: "An address given with -advertise-addr that should be connected to\n" +
+				"without TLS. This can be specified multiple times. This is deprecated\n" +
+				"and requires -allow-insecure-entrypoints.",
+		})
+		f.BoolVar(&flag.BoolVar{
+			Name:   "allow-insecure-entrypoints",
+			Target: &c.flagAllowInsecure,
+			Usage: "Allow -advertise-insecure. Entrypoints connecting without TLS\n" +
+				"authenticate with their token only, without a client certificate.",
 		})
 		f.BoolVar(&flag.BoolVar{
 			Name:    "advertise-tls",
-			Target:  &c.flagAdvertiseAddr.Tls,
-			Usage:   "If true, the advertised address should be connected to with TLS.",
+			Target:  &c.flagAdvertiseTls,
+			Usage:   "If true, the advertised addresses should be connected to with TLS.",
 			Default: true,
 		})
 		f.BoolVar(&flag.BoolVar{
 			Name:    "advertise-tls-skip-verify",
-			Target:  &c.flagAdvertiseAddr.TlsSkipVerify,
+			Target:  &c.flagAdvertiseSkip,
 			Usage:   "Do not verify the TLS certificate presented by the server.",
 			Default: false,
 		})
+		f.IntVar(&flag.IntVar{
+			Name:    "deployment-retention",
+			Target:  &c.flagRetention,
+			Default: -1,
+			Usage: "Number of deployments to keep for each app in each workspace.\n" +
+				"Older deployments are destroyed automatically when a deployment\n" +
+				"completes. Released and pinned deployments are always kept. Set\n" +
+				"this to 0 to disable pruning. If this isn't set, the current\n" +
+				"value is kept.",
+		})
+		f.IntVar(&flag.IntVar{
+			Name:    "log-retention",
+			Target:  &c.flagLogRetention,
+			Default: -1,
+			Usage: "Number of entrypoint log lines to store for each app in each\n" +
+				"workspace. Stored logs are shown with \"waypoint logs -history\".\n" +
+				"Set this to 0 to not store logs. If this isn't set, the current\n" +
+				"value is kept.",
+		})
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "app-log-retention",
+			Target: &c.flagAppLogRetention,
+			Usage: "Log retention for a specific app as project/app=lines, overriding\n" +
+				"-log-retention. An empty value removes the override. This can be\n" +
+				"specified multiple times.",
+		})
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "log-sink",
+			Target: &c.flagLogSinks,
+			Usage: "Forward entrypoint logs to an external sink as TYPE=ADDRESS. This\n" +
+				"is one of \"syslog=[udp|tcp://]host:port\", \"loki=URL\" or\n" +
+				"\"cloudwatch=LOG-GROUP[@REGION]\". This can be specified multiple\n" +
+				"times and replaces the current sinks. Set this to \"none\" to remove\n" +
+				"all sinks. If this isn't set, the current sinks are kept.",
+		})
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "exec-recording",
+			Target: &c.flagExecRecording,
+			Values: []string{"true", "false"},
+			Usage: "Record the output of \"waypoint exec\" sessions in the server\n" +
+				"state. Input is never recorded. Recordings are shown with\n" +
+				"\"waypoint exec-history\". If this isn't set, the current value is kept.",
+		})
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "requeue-lost-jobs",
+			Target: &c.flagRequeueLostJobs,
+			Values: []string{"true", "false"},
+			Usage: "Re-queue the jobs of a runner that stops heartbeating so that\n" +
+				"another runner can run them. Otherwise these jobs are errored. If\n" +
+				"this isn't set, the current value is kept.",
+		})
+		f.IntVar(&flag.IntVar{
+			Name:    "project-job-limit",
+			Target:  &c.flagProjectJobLimit,
+			Default: -1,
+			Usage: "Maximum number of jobs of each project that are assigned to\n" +
+				"runners at once, so one project can't occupy every runner. Set this\n" +
+				"to 0 to not limit projects. If this isn't set, the current value\n" +
+				"is kept.",
+		})
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "job-limit-for-project",
+			Target: &c.flagProjectJobLimits,
+			Usage: "Job limit for a specific project as project=jobs, overriding\n" +
+				"-project-job-limit. An empty value removes the override. This can\n" +
+				"be specified multiple times.",
+		})
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "job-scheduling",
+			Target: &c.flagJobScheduling,
+			Values: []string{serverptypes.JobSchedulingFIFO, serverptypes.JobSchedulingFair},
+			Usage: "Order that queued jobs of the same priority are assigned to\n" +
+				"runners in. \"fifo\" assigns them in the order they were queued.\n" +
+				"\"fair\" takes turns between apps so that apps with many or slow\n" +
+				"jobs can't starve other apps. If this isn't set, the current value\n" +
+				"is kept.",
+		})
+		f.StringMapVar(&flag.StringMapVar{
+			Name:   "job-timeout",
+			Target: &c.flagJobTimeouts,
+			Usage: "Maximum duration of jobs for an operation as OPERATION=DURATION,\n" +
+				"such as \"build=30m\". Jobs that run longer are cancelled and marked\n" +
+				"as timed out. The job_timeouts of a project's waypoint.hcl take\n" +
+				"precedence. An empty duration removes the timeout. This can be\n" +
+				"specified multiple times.",
+		})
 	})
 }
 
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServerConfigSetServerConfig(t *testing.T) {
+	current := &pb.ServerConfig{
+		AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{
+			{Addr: "waypoint.example.com:9701", Tls: true},
+		},
+		DeploymentRetention: 5,
+	}
+
+	t.Run("keeps the current addresses without address flags", func(t *testing.T) {
+		require := require.New(t)
+
+		c := &ServerConfigSetCommand{flagRetention: 3}
+		cfg, err := c.serverConfig(current)
+		require.NoError(err)
+		require.Equal(current.AdvertiseAddrs, cfg.AdvertiseAddrs)
+	})
+
+	t.Run("replaces the current addresses", func(t *testing.T) {
+		require := require.New(t)
+
+		c := &ServerConfigSetCommand{flagAdvertiseAddrs: []string{"other:9701"}}
+		cfg, err := c.serverConfig(current)
+		require.NoError(err)
+		require.Len(cfg.AdvertiseAddrs, 1)
+		require.Equal("other:9701", cfg.AdvertiseAddrs[0].Addr)
+	})
+
+	t.Run("blank address disables entrypoint communication", func(t *testing.T) {
+		require := require.New(t)
+
+		c := &ServerConfigSetCommand{flagAdvertiseAddrs: []string{""}}
+		cfg, err := c.serverConfig(current)
+		require.NoError(err)
+		require.Len(cfg.AdvertiseAddrs, 1)
+		require.Empty(cfg.AdvertiseAddrs[0].Addr)
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		require := require.New(t)
+
+		c := &ServerConfigSetCommand{}
+		cfg, err := c.serverConfig(nil)
+		require.NoError(err)
+		require.Len(cfg.AdvertiseAddrs, 1)
+		require.Empty(cfg.AdvertiseAddrs[0].Addr)
+	})
+}
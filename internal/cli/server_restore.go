@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ServerRestoreCommand struct {
+	*baseCommand
+}
+
+func (c *ServerRestoreCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	path := c.args[0]
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			c.ui.Output("Error opening snapshot file: %s", err, terminal.WithErrorStyle())
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	client := c.project.Client()
+	stream, err := client.RestoreSnapshot(c.Ctx)
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	buf := make([]byte, 1024*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.RestoreSnapshotRequest{
+				Chunk: append([]byte(nil), buf[:n]...),
+			}); err != nil {
+				// The server closed the stream so the real error is
+				// returned by CloseAndRecv below.
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.ui.Output("Error reading snapshot: %s", err, terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Snapshot staged. Restart the server to restore it.",
+		terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ServerRestoreCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *ServerRestoreCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("")
+}
+
+func (c *ServerRestoreCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerRestoreCommand) Synopsis() string {
+	return "Restore the server state from a snapshot."
+}
+
+func (c *ServerRestoreCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server restore [options] PATH
+
+  Restore the server state from a snapshot at PATH, or from stdin if PATH
+  is "-". The snapshot must have been created by "waypoint server snapshot".
+
+  The snapshot is validated and staged on the server. The server keeps
+  running with its current state until it is restarted. On start the
+  current database is moved aside with a ".backup-" suffix and replaced
+  by the snapshot.
+
+` + c.Flags().Help())
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
 	"github.com/hashicorp/waypoint/internal/server"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess"
+	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
 const tosStatement = `
@@ -95,6 +96,17 @@ func (c *ServerRunCommand) Run(args []string) int {
 		c.config.DBPath = "data.db"
 	}
 	path := c.config.DBPath
+
+	// A snapshot restore is staged while the server is running and takes
+	// effect here, before the database is opened.
+	if err := state.FinalizeRestore(log, path); err != nil {
+		c.ui.Output(
+			"Error restoring snapshot: %s", err.Error(),
+			terminal.WithErrorStyle(),
+		)
+		return 1
+	}
+
 	log.Info("opening DB", "path", path)
 	db, err := bolt.Open(path, 0600, nil)
 	if err != nil {
@@ -164,8 +176,23 @@ func (c *ServerRunCommand) Run(args []string) int {
 		return 1
 	}
 
-	// We listen on a random locally bound port
-	ln, err := c.listenerForConfig(log.Named("grpc"), &c.config.GRPC)
+	// Stop the timers of the server before the database is closed.
+	if closer, ok := impl.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// Entrypoints connect to the gRPC listener with client certificates
+	// issued by the server's CA, so verify them if they're given.
+	var clientCAs *x509.CertPool
+	if ca, ok := impl.(interface {
+		EntrypointCAPool() *x509.CertPool
+	}); ok {
+		clientCAs = ca.EntrypointCAPool()
+	}
+
+	// We listen on a random locally bound port. TLS is handled by gRPC
+	// so that the client certificates are available to the service.
+	ln, grpcTLS, err := c.listenerForConfig(log.Named("grpc"), &c.config.GRPC, clientCAs)
 	if err != nil {
 		c.ui.Output(
 			"Error starting listener: %s", err.Error(),
@@ -175,7 +202,7 @@ func (c *ServerRunCommand) Run(args []string) int {
 	}
 	defer ln.Close()
 
-	httpLn, err := c.listenerForConfig(log.Named("http"), &c.config.HTTP)
+	httpLn, httpTLS, err := c.listenerForConfig(log.Named("http"), &c.config.HTTP, nil)
 	if err != nil {
 		c.ui.Output(
 			"Error starting listener: %s", err.Error(),
@@ -183,15 +210,35 @@ func (c *ServerRunCommand) Run(args []string) int {
 		)
 		return 1
 	}
+	if httpTLS != nil {
+		httpLn = tls.NewListener(httpLn, httpTLS)
+	}
 	defer httpLn.Close()
 
 	options := []server.Option{
 		server.WithContext(c.Ctx),
 		server.WithLogger(log),
 		server.WithGRPC(ln),
+		server.WithGRPCTLS(grpcTLS),
 		server.WithHTTP(httpLn),
 		server.WithImpl(impl),
 	}
+
+	// Metrics are only served if an address is set.
+	var metricsLn net.Listener
+	if c.config.Metrics != nil && c.config.Metrics.Addr != "" {
+		metricsLn, err = net.Listen("tcp", c.config.Metrics.Addr)
+		if err != nil {
+			c.ui.Output(
+				"Error starting metrics listener: %s", err.Error(),
+				terminal.WithErrorStyle(),
+			)
+			return 1
+		}
+		defer metricsLn.Close()
+
+		options = append(options, server.WithMetrics(metricsLn))
+	}
 	auth := false
 	if ac, ok := impl.(server.AuthChecker); ok {
 		options = append(options, server.WithAuthentication(ac))
@@ -213,6 +260,9 @@ func (c *ServerRunCommand) Run(args []string) int {
 		{Name: "gRPC Address", Value: ln.Addr().String()},
 		{Name: "HTTP Address", Value: httpLn.Addr().String()},
 	}
+	if metricsLn != nil {
+		values = append(values, terminal.NamedValue{Name: "Metrics Address", Value: metricsLn.Addr().String()})
+	}
 	if auth {
 		values = append(values, terminal.NamedValue{Name: "Auth Required", Value: "yes"})
 	}
@@ -296,6 +346,9 @@ func (c *ServerRunCommand) Flags() *flag.Sets {
 		if c.config.URL == nil {
 			c.config.URL = &config.URL{}
 		}
+		if c.config.Metrics == nil {
+			c.config.Metrics = &config.Metrics{}
+		}
 
 		f := set.NewSet("Command Options")
 		f.StringVar(&flag.StringVar{
@@ -319,6 +372,20 @@ func (c *ServerRunCommand) Flags() *flag.Sets {
 			Default: "127.0.0.1:9702",
 		})
 
+		f.StringVar(&flag.StringVar{
+			Name:   "listen-metrics",
+			Target: &c.config.Metrics.Addr,
+			Usage: "Address to bind to for the Prometheus metrics endpoint, which is\n" +
+				"served over plain HTTP on /metrics. Metrics are disabled if this isn't set.",
+		})
+
+		f.BoolVar(&flag.BoolVar{
+			Name:   "auto-adopt-runners",
+			Target: &c.config.AutoAdoptRunners,
+			Usage: "Adopt runners as they register. By default runners must be adopted\n" +
+				"with \"waypoint runner adopt\" before they're assigned jobs.",
+		})
+
 		f.BoolVar(&flag.BoolVar{
 			Name:    "disable-ui",
 			Target:  &c.flagDisableUI,
@@ -430,18 +497,25 @@ Usage: waypoint server run [options]
 ` + c.Flags().Help())
 }
 
-func (c *ServerRunCommand) listenerForConfig(log hclog.Logger, cfg *config.Listener) (net.Listener, error) {
+// listenerForConfig starts a listener for the config and returns the TLS
+// config to serve it with, which is nil if TLS is disabled. If clientCAs is
+// set, client certificates are verified against it when they're given.
+func (c *ServerRunCommand) listenerForConfig(
+	log hclog.Logger,
+	cfg *config.Listener,
+	clientCAs *x509.CertPool,
+) (net.Listener, *tls.Config, error) {
 	// Start our bare listener
 	log.Debug("starting listener", "addr", cfg.Addr)
 	ln, err := net.Listen("tcp", cfg.Addr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If we have TLS disabled then we're done.
 	if cfg.TLSDisable {
 		log.Warn("TLS is disabled for this listener")
-		return ln, nil
+		return ln, nil, nil
 	}
 
 	// If we don't have a cert then we self-sign.
@@ -449,11 +523,11 @@ func (c *ServerRunCommand) listenerForConfig(log hclog.Logger, cfg *config.Liste
 	if cfg.TLSCertFile != "" {
 		certPEM, err = ioutil.ReadFile(cfg.TLSCertFile)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		keyPEM, err = ioutil.ReadFile(cfg.TLSKeyFile)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		log.Info("TLS certs loaded from specified files",
@@ -466,7 +540,7 @@ func (c *ServerRunCommand) listenerForConfig(log hclog.Logger, cfg *config.Liste
 
 		priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		template := x509.Certificate{
@@ -483,21 +557,21 @@ func (c *ServerRunCommand) listenerForConfig(log hclog.Logger, cfg *config.Liste
 
 		derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey(priv), priv)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Write the cert
 		var out bytes.Buffer
 		err = pem.Encode(&out, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		certPEM = out.Bytes()
 
 		// Write the key
 		out = bytes.Buffer{}
 		if err := pem.Encode(&out, pemBlockForKey(priv)); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		keyPEM = out.Bytes()
 	}
@@ -506,13 +580,20 @@ func (c *ServerRunCommand) listenerForConfig(log hclog.Logger, cfg *config.Liste
 	cert, err := tls.X509KeyPair(certPEM, keyPEM)
 	if err != nil {
 		ln.Close()
-		return nil, err
+		return nil, nil, err
 	}
 
-	log.Info("listener is wrapped with TLS")
-	return tls.NewListener(ln, &tls.Config{
+	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{cert},
-	}), nil
+	}
+	if clientCAs != nil {
+		// Clients without a certificate still authenticate with tokens.
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	log.Info("listener uses TLS", "client_certs", clientCAs != nil)
+	return ln, tlsConfig, nil
 }
 
 func publicKey(priv interface{}) interface{} {
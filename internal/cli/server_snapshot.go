@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+)
+
+type ServerSnapshotCommand struct {
+	*baseCommand
+}
+
+func (c *ServerSnapshotCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	path := c.args[0]
+
+	// Writing to stdout must not output anything else.
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			c.ui.Output("Error creating snapshot file: %s", err, terminal.WithErrorStyle())
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	client := c.project.Client()
+	stream, err := client.CreateSnapshot(c.Ctx, &empty.Empty{})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			c.ui.Output("Error writing snapshot: %s", err, terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	if path != "-" {
+		c.ui.Output("Server snapshot written to %s.", path, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *ServerSnapshotCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *ServerSnapshotCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("")
+}
+
+func (c *ServerSnapshotCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ServerSnapshotCommand) Synopsis() string {
+	return "Write a snapshot of the server state to a file."
+}
+
+func (c *ServerSnapshotCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint server snapshot [options] PATH
+
+  Write a snapshot of the entire server state to PATH, or to stdout if
+  PATH is "-".
+
+  The snapshot contains all projects, operations, configuration, and the
+  keys used to sign tokens. Store it securely since anyone with the
+  snapshot can create valid tokens for the server. A snapshot can be
+  restored with "waypoint server restore".
+
+` + c.Flags().Help())
+}
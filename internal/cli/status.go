@@ -0,0 +1,318 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/posener/complete"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	clientpkg "github.com/hashicorp/waypoint/internal/client"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type StatusCommand struct {
+	*baseCommand
+
+	flagCheck bool
+	flagJson  bool
+	flagId    idFormat
+}
+
+// appStatus is the latest state of a single app. Any of the fields other
+// than App may be nil if that operation never completed.
+type appStatus struct {
+	App        string
+	Build      *pb.Build
+	Deployment *pb.Deployment
+	Release    *pb.Release
+	Health     *pb.HealthReport
+}
+
+func (c *StatusCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	var lock sync.Mutex
+	var statuses []*appStatus
+	err := c.DoApp(c.Ctx, func(ctx context.Context, app *clientpkg.App) error {
+		if c.flagCheck {
+			if _, err := app.HealthCheck(ctx, nil); err != nil {
+				app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+				return ErrSentinel
+			}
+		}
+
+		s, err := c.appStatus(ctx, app.Ref())
+		if err != nil {
+			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return ErrSentinel
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		statuses = append(statuses, s)
+		return nil
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].App < statuses[j].App
+	})
+
+	if c.flagJson {
+		if err := c.displayJson(statuses); err != nil {
+			c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+			return 1
+		}
+	} else if len(statuses) > 0 {
+		c.displayTable(statuses)
+	}
+
+	if err != nil {
+		return 1
+	}
+
+	return 0
+}
+
+// appStatus queries the server for the latest operations of the app in
+// the current workspace.
+func (c *StatusCommand) appStatus(ctx context.Context, ref *pb.Ref_Application) (*appStatus, error) {
+	client := c.project.Client()
+	ws := c.project.WorkspaceRef()
+	result := &appStatus{App: ref.Application}
+
+	build, err := client.GetLatestBuild(ctx, &pb.GetLatestBuildRequest{
+		Application: ref,
+		Workspace:   ws,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	result.Build = build
+
+	deployments, err := client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   ref,
+		Workspace:     ws,
+		PhysicalState: pb.Operation_CREATED,
+		Status: []*pb.StatusFilter{{
+			Filters: []*pb.StatusFilter_Filter{{
+				Filter: &pb.StatusFilter_Filter_State{State: pb.Status_SUCCESS},
+			}},
+		}},
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+			Limit: 1,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments.Deployments) > 0 {
+		result.Deployment = deployments.Deployments[0]
+	}
+
+	release, err := client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: ref,
+		Workspace:   ws,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	result.Release = release
+
+	health, err := client.GetLatestHealthReport(ctx, &pb.GetLatestHealthReportRequest{
+		Application: ref,
+		Workspace:   ws,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	result.Health = health
+
+	return result, nil
+}
+
+func (c *StatusCommand) displayTable(statuses []*appStatus) {
+	tbl := terminal.NewTable("App", "Build", "Deployment", "Release", "Health")
+	for _, s := range statuses {
+		health, healthColor := "-", ""
+		if r := s.Health; r != nil {
+			health = strings.ToLower(r.Health.String())
+			switch r.Health {
+			case pb.HealthReport_HEALTHY:
+				healthColor = terminal.Green
+			case pb.HealthReport_UNHEALTHY:
+				healthColor = terminal.Red
+			default:
+				healthColor = terminal.Yellow
+			}
+
+			if r.Message != "" {
+				health += ": " + r.Message
+			}
+		}
+
+		build, deployment, release := "-", "-", "-"
+		if b := s.Build; b != nil {
+			build = c.formatOperation(b.Sequence, b.Id, b.Status)
+		}
+		if d := s.Deployment; d != nil {
+			deployment = c.formatOperation(d.Sequence, d.Id, d.Status)
+		}
+		if r := s.Release; r != nil {
+			release = c.formatOperation(r.Sequence, r.Id, r.Status)
+		}
+
+		tbl.Rich([]string{
+			s.App,
+			build,
+			deployment,
+			release,
+			health,
+		}, []string{
+			"",
+			"",
+			"",
+			"",
+			healthColor,
+		})
+	}
+
+	c.ui.Table(tbl)
+}
+
+// formatOperation formats the ID of an operation along with how long ago
+// it completed.
+func (c *StatusCommand) formatOperation(seq uint64, id string, st *pb.Status) string {
+	result := c.flagId.FormatId(seq, id)
+	if st != nil {
+		if t, err := ptypes.Timestamp(st.CompleteTime); err == nil {
+			result = fmt.Sprintf("%s (%s)", result, humanize.Time(t))
+		}
+	}
+
+	return result
+}
+
+func (c *StatusCommand) displayJson(statuses []*appStatus) error {
+	output := []map[string]interface{}{}
+	for _, s := range statuses {
+		i := map[string]interface{}{
+			"application": s.App,
+			"workspace":   c.project.WorkspaceRef().Workspace,
+			"build":       nil,
+			"deployment":  nil,
+			"release":     nil,
+			"health":      nil,
+		}
+
+		if b := s.Build; b != nil {
+			i["build"] = c.operationJson(b.Id, b.Sequence, b.Status)
+		}
+		if d := s.Deployment; d != nil {
+			i["deployment"] = c.operationJson(d.Id, d.Sequence, d.Status)
+		}
+		if r := s.Release; r != nil {
+			v := c.operationJson(r.Id, r.Sequence, r.Status)
+			v["deployment_id"] = r.DeploymentId
+			v["url"] = r.Url
+			i["release"] = v
+		}
+		if r := s.Health; r != nil {
+			i["health"] = map[string]interface{}{
+				"deployment_id": r.DeploymentId,
+				"health":        r.Health.String(),
+				"message":       r.Message,
+				"check_time":    r.Status.CompleteTime.AsTime().Format(time.RFC3339Nano),
+			}
+		}
+
+		output = append(output, i)
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	c.ui.Output(string(data))
+	return nil
+}
+
+func (c *StatusCommand) operationJson(id string, seq uint64, st *pb.Status) map[string]interface{} {
+	i := map[string]interface{}{
+		"id":       id,
+		"sequence": seq,
+	}
+	if st != nil {
+		i["complete_time"] = st.CompleteTime.AsTime().Format(time.RFC3339Nano)
+	}
+
+	return i
+}
+
+func (c *StatusCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetOperation, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "check",
+			Target: &c.flagCheck,
+			Usage: "Run a new health check of the active deployment of each app " +
+				"before showing the status.",
+		})
+		f.BoolVar(&flag.BoolVar{
+			Name:   "json",
+			Target: &c.flagJson,
+			Usage:  "Output the status of each app as JSON.",
+		})
+
+		initIdFormat(f, &c.flagId)
+	})
+}
+
+func (c *StatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Show the latest status of every app."
+}
+
+func (c *StatusCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint status [options]
+
+  Show the latest build, deployment, release, and health of every app in
+  the project for the current workspace.
+
+  The health is the most recent health check of the active deployment,
+  which is the released deployment or, if nothing has been released, the
+  most recent deployment. Use -check to run a new health check first.
+  A health check uses the latest health report that another source, such
+  as a monitoring system, recorded for the deployment. Without one the
+  health is unknown.
+
+` + c.Flags().Help())
+}
@@ -4,7 +4,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/clierrors"
 	"github.com/hashicorp/waypoint/internal/pkg/flag"
@@ -149,6 +148,11 @@ Usage: waypoint token exchange [options]
 
 type GetTokenCommand struct {
 	*baseCommand
+
+	ttl      time.Duration
+	role     string
+	projects []string
+	apps     []string
 }
 
 func (c *GetTokenCommand) Run(args []string) int {
@@ -164,7 +168,29 @@ func (c *GetTokenCommand) Run(args []string) int {
 	// Get our API client
 	client := c.project.Client()
 
-	resp, err := client.GenerateLoginToken(c.Ctx, &empty.Empty{})
+	req := &pb.LoginTokenRequest{
+		Duration: tokenDuration(c.ttl),
+		Role:     pb.Token_Role(pb.Token_Role_value[strings.ToUpper(c.role)]),
+	}
+	for _, p := range c.projects {
+		req.Projects = append(req.Projects, &pb.Ref_Project{Project: p})
+	}
+	for _, v := range c.apps {
+		idx := strings.Index(v, "/")
+		if idx <= 0 || idx == len(v)-1 {
+			c.project.UI.Output(
+				"Invalid -app value %q. Applications must be in the form PROJECT/APP.", v,
+				terminal.WithErrorStyle())
+			return 1
+		}
+
+		req.Applications = append(req.Applications, &pb.Ref_Application{
+			Project:     v[:idx],
+			Application: v[idx+1:],
+		})
+	}
+
+	resp, err := client.GenerateLoginToken(c.Ctx, req)
 	if err != nil {
 		c.project.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
 		return 1
@@ -175,7 +201,34 @@ func (c *GetTokenCommand) Run(args []string) int {
 }
 
 func (c *GetTokenCommand) Flags() *flag.Sets {
-	return c.flagSet(0, func(set *flag.Sets) {})
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.DurationVar(&flag.DurationVar{
+			Name:   "ttl",
+			Target: &c.ttl,
+			Usage:  "How long the token will be valid for, starting now. If not set, the token never expires.",
+		})
+
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:    "role",
+			Target:  &c.role,
+			Values:  []string{"operator", "reader"},
+			Default: "operator",
+			Usage:   "The role of the token. Readers can only call APIs that don't change state",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "project",
+			Target: &c.projects,
+			Usage:  "Limit the token to this project. This can be repeated.",
+		})
+
+		f.StringSliceVar(&flag.StringSliceVar{
+			Name:   "app",
+			Target: &c.apps,
+			Usage:  "Limit the token to this application, in the form PROJECT/APP. This can be repeated.",
+		})
+	})
 }
 
 func (c *GetTokenCommand) AutocompleteArgs() complete.Predictor {
@@ -196,7 +249,132 @@ Usage: waypoint token new [options]
 
   Request a new token to log into the server.
 
+  By default the token never expires. Use -ttl to create a token that is
+  only valid for a limited time.
+
+  By default the token can call every API for every project. Use -role
+  to create a read-only token, and -project or -app to limit the token to
+  specific projects and applications. A limited token can't change state
+  that isn't part of its projects, such as the server configuration, and
+  can't create other tokens.
+
+` + c.Flags().Help()
+
+	return strings.TrimSpace(helpText)
+}
+
+type RotateTokenCommand struct {
+	*baseCommand
+
+	ttl time.Duration
+}
+
+func (c *RotateTokenCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	// Get our API client
+	client := c.project.Client()
+
+	resp, err := client.RotateToken(c.Ctx, &pb.RotateTokenRequest{
+		Duration: tokenDuration(c.ttl),
+	})
+	if err != nil {
+		c.project.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	// The old token no longer works, so update any contexts that use it.
+	if c.clientContext != nil && c.clientContext.Server.AuthToken != "" {
+		if err := c.replaceContextToken(c.clientContext.Server.AuthToken, resp.Token); err != nil {
+			c.project.UI.Output(
+				"The token was rotated but updating the CLI context failed: %s\n\n"+
+					"The new token is shown below. Store it before closing this terminal.",
+				clierrors.Humanize(err),
+				terminal.WithErrorStyle(),
+			)
+		}
+	}
+
+	c.project.UI.Output(resp.Token)
+	return 0
+}
+
+// replaceContextToken sets the auth token of every stored context that
+// uses the old token to the new token.
+func (c *RotateTokenCommand) replaceContextToken(old, new string) error {
+	names, err := c.contextStorage.List()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range names {
+		cfg, err := c.contextStorage.Load(n)
+		if err != nil {
+			return err
+		}
+		if cfg.Server.AuthToken != old {
+			continue
+		}
+
+		cfg.Server.AuthToken = new
+		if err := c.contextStorage.Set(n, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RotateTokenCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.DurationVar(&flag.DurationVar{
+			Name:   "ttl",
+			Target: &c.ttl,
+			Usage:  "How long the new token will be valid for, starting now. If not set, the token never expires.",
+		})
+	})
+}
+
+func (c *RotateTokenCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RotateTokenCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RotateTokenCommand) Synopsis() string {
+	return "Replace the current token with a new one"
+}
+
+func (c *RotateTokenCommand) Help() string {
+	helpText := `
+Usage: waypoint token rotate [options]
+
+  Replace the token used by this CLI with a new token.
+
+  The new token is issued before the current token is revoked. Any CLI
+  contexts that use the current token are updated to the new token.
+
 ` + c.Flags().Help()
 
 	return strings.TrimSpace(helpText)
 }
+
+// tokenDuration returns the duration to request for a token. A zero value
+// is sent as empty for a token that never expires.
+func tokenDuration(ttl time.Duration) string {
+	if ttl <= 0 {
+		return ""
+	}
+
+	return ttl.String()
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"net"
+	"strings"
+
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// triggerDefaultHTTPPort is the port of the server's HTTP listener that
+// is used in webhook URLs if -url-base isn't set.
+const triggerDefaultHTTPPort = "9702"
+
+type TriggerCreateCommand struct {
+	*baseCommand
+
+	flagBranch  string
+	flagURLBase string
+}
+
+func (c *TriggerCreateCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithConfig(true),
+	); err != nil {
+		return 1
+	}
+
+	// The project comes from the args or the configuration.
+	ref := c.refProject
+	if len(c.args) > 0 {
+		ref = &pb.Ref_Project{Project: c.args[0]}
+	}
+	if ref == nil || ref.Project == "" {
+		c.ui.Output("A project name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := c.project.Client().CreateTrigger(c.Ctx, &pb.CreateTriggerRequest{
+		Project: ref,
+		Branch:  c.flagBranch,
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	base := c.flagURLBase
+	if base == "" {
+		base = c.defaultURLBase()
+	}
+
+	c.ui.Output("Trigger %s created.", resp.Trigger.Id, terminal.WithSuccessStyle())
+	c.ui.NamedValues([]terminal.NamedValue{
+		{Name: "Webhook URL", Value: strings.TrimSuffix(base, "/") + resp.Path},
+		{Name: "Secret", Value: resp.Trigger.Secret},
+	}, terminal.WithInfoStyle())
+	c.ui.Output("\nAdd the URL as a push webhook with the secret in GitHub or as " +
+		"the secret token in GitLab. The secret isn't shown again.")
+	return 0
+}
+
+// defaultURLBase returns the base URL of the server's HTTP listener
+// assuming that it listens on the default port of the same host as the
+// server's gRPC address.
+func (c *TriggerCreateCommand) defaultURLBase() string {
+	host := "localhost"
+	if c.clientContext != nil && c.clientContext.Server.Address != "" {
+		host = c.clientContext.Server.Address
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+
+	return "https://" + net.JoinHostPort(host, triggerDefaultHTTPPort)
+}
+
+func (c *TriggerCreateCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.StringVar(&flag.StringVar{
+			Name:   "branch",
+			Target: &c.flagBranch,
+			Usage:  "Only pushes to this branch queue jobs. By default pushes to any branch do.",
+		})
+
+		f.StringVar(&flag.StringVar{
+			Name:   "url-base",
+			Target: &c.flagURLBase,
+			Usage: "Base URL of the server's HTTP listener that the Git host can " +
+				"reach. Defaults to port 9702 of the server address.",
+		})
+	})
+}
+
+func (c *TriggerCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *TriggerCreateCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *TriggerCreateCommand) Synopsis() string {
+	return "Create a webhook that deploys a project on push."
+}
+
+func (c *TriggerCreateCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint trigger create [options] [PROJECT]
+
+  Create a webhook URL for a project. When GitHub or GitLab sends a push
+  event to the URL, the server queues an up job for every application of
+  the project at the pushed commit.
+
+  The project must have a Git data source. If PROJECT isn't given, the
+  project in the current directory's waypoint.hcl is used.
+
+` + c.Flags().Help())
+}
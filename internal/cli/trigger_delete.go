@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type TriggerDeleteCommand struct {
+	*baseCommand
+}
+
+func (c *TriggerDeleteCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single trigger ID is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if _, err := c.project.Client().DeleteTrigger(c.Ctx, &pb.DeleteTriggerRequest{
+		Id: c.args[0],
+	}); err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Trigger %s deleted.", c.args[0], terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *TriggerDeleteCommand) Flags() *flag.Sets {
+	return c.flagSet(0, nil)
+}
+
+func (c *TriggerDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *TriggerDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *TriggerDeleteCommand) Synopsis() string {
+	return "Delete a webhook trigger."
+}
+
+func (c *TriggerDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint trigger delete [options] ID
+
+  Delete a webhook trigger. Requests to its URL are rejected afterwards.
+
+` + c.Flags().Help())
+}
@@ -13,6 +13,8 @@ import (
 
 type UpCommand struct {
 	*baseCommand
+
+	flagNoCache bool
 }
 
 func (c *UpCommand) Run(args []string) int {
@@ -31,20 +33,26 @@ func (c *UpCommand) Run(args []string) int {
 		// Build it
 		app.UI.Output("Building...", terminal.WithHeaderStyle())
 
-		_, err := app.Build(ctx, &pb.Job_BuildOp{})
+		buildResult, err := app.Build(ctx, &pb.Job_BuildOp{
+			DisableCache: c.flagNoCache,
+		})
 		if err != nil {
 			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
 			return ErrSentinel
 		}
 
-		// Get the most recent pushed artifact
-		push, err := client.GetLatestPushedArtifact(ctx, &pb.GetLatestPushedArtifactRequest{
-			Application: app.Ref(),
-			Workspace:   c.project.WorkspaceRef(),
-		})
-		if err != nil {
-			app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
-			return ErrSentinel
+		// Deploy the artifact we built. If the build was reused from the
+		// cache this may not be the most recently pushed artifact.
+		push := buildResult.Push
+		if push == nil {
+			push, err = client.GetLatestPushedArtifact(ctx, &pb.GetLatestPushedArtifactRequest{
+				Application: app.Ref(),
+				Workspace:   c.project.WorkspaceRef(),
+			})
+			if err != nil {
+				app.UI.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+				return ErrSentinel
+			}
 		}
 
 		// Push it
@@ -123,6 +131,19 @@ func (c *UpCommand) Run(args []string) int {
 
 func (c *UpCommand) Flags() *flag.Sets {
 	return c.flagSet(flagSetOperation, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "no-cache",
+			Target: &c.flagNoCache,
+			Usage:  "Build even if a previous build of the same source exists.",
+		})
+		f.EnumSingleVar(&flag.EnumSingleVar{
+			Name:   "priority",
+			Target: &c.flagPriority,
+			Values: []string{"low", "normal", "high"},
+			Usage: "Priority of the queued jobs. Queued jobs with a higher priority\n" +
+				"are assigned to runners first. Jobs are normal priority by default.",
+		})
 	})
 }
 
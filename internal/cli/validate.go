@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type ValidateCommand struct {
+	*baseCommand
+}
+
+func (c *ValidateCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI. This
+	// decodes and validates waypoint.hcl so syntax errors are shown here.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+	); err != nil {
+		return 1
+	}
+
+	sg := c.ui.StepGroup()
+	s := sg.Add("Validating the configuration of every plugin...")
+
+	// The validate job loads every plugin and decodes its configuration,
+	// which reports unknown or invalid attributes with their location.
+	if _, err := c.project.Validate(c.Ctx, &pb.Job_ValidateOp{}); err != nil {
+		s.Status(terminal.StatusError)
+		s.Done()
+		sg.Wait()
+
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	s.Update("The configuration is valid.")
+	s.Status(terminal.StatusOK)
+	s.Done()
+	sg.Wait()
+
+	return 0
+}
+
+func (c *ValidateCommand) Flags() *flag.Sets {
+	return c.flagSet(flagSetOperation, nil)
+}
+
+func (c *ValidateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ValidateCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Validate waypoint.hcl and the configuration of its plugins"
+}
+
+func (c *ValidateCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint validate [options]
+
+  Validate waypoint.hcl without running any operation.
+
+  The file is decoded with its input variables, then every plugin of every
+  app is loaded and its configuration is decoded with the plugin's schema.
+  Errors such as syntax errors and unknown or invalid attributes are
+  reported with their line and column. The errors of all the apps and
+  plugins are reported at once.
+
+  Plugins are loaded by a local runner unless -remote is given.
+
+` + c.Flags().Help())
+}
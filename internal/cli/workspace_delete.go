@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/clierrors"
+	"github.com/hashicorp/waypoint/internal/pkg/flag"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+type WorkspaceDeleteCommand struct {
+	*baseCommand
+
+	flagForce bool
+}
+
+func (c *WorkspaceDeleteCommand) Run(args []string) int {
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(c.Flags()),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output("A single workspace name is required.\n\n"+c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	name := c.args[0]
+
+	_, err := c.project.Client().DeleteWorkspace(c.Ctx, &pb.DeleteWorkspaceRequest{
+		Workspace: &pb.Ref_Workspace{Workspace: name},
+		Force:     c.flagForce,
+	})
+	if err != nil {
+		c.ui.Output(clierrors.Humanize(err), terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("Workspace %q deleted.", name, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *WorkspaceDeleteCommand) Flags() *flag.Sets {
+	return c.flagSet(0, func(set *flag.Sets) {
+		f := set.NewSet("Command Options")
+		f.BoolVar(&flag.BoolVar{
+			Name:   "force",
+			Target: &c.flagForce,
+			Usage: "Delete the workspace even if it has deployments that haven't " +
+				"been destroyed. The deployments are left running.",
+		})
+	})
+}
+
+func (c *WorkspaceDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *WorkspaceDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *WorkspaceDeleteCommand) Synopsis() string {
+	return "Delete a workspace and its history."
+}
+
+func (c *WorkspaceDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: waypoint workspace delete [options] WORKSPACE
+
+  Delete a workspace and all the builds, deployments, and releases
+  recorded in it.
+
+  This fails if the workspace has deployments that haven't been destroyed.
+  Destroy them first with "waypoint destroy -workspace=WORKSPACE" or use
+  -force to delete the workspace anyway.
+
+` + c.Flags().Help())
+}
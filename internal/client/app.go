@@ -2,6 +2,11 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
@@ -27,6 +32,110 @@ func (c *Project) App(n string) *App {
 	}
 }
 
+// DoApps calls f for each of the named apps. Up to the number of apps set
+// with WithParallel are operated on at once, otherwise one at a time in
+// order. Each call gets its own context that is canceled when it returns
+// so canceling one app doesn't affect the others. A failing app doesn't
+// stop the remaining apps; all errors are returned together once every
+// call has completed, each labeled with its app name.
+//
+// Names should be given in dependency order. An app isn't started until
+// the apps it depends on (see WithAppDependencies) that come before it in
+// names have succeeded. If one of them fails, the app is skipped with an
+// error.
+//
+// If ctx is canceled, no further apps are started.
+func (c *Project) DoApps(ctx context.Context, names []string, f func(context.Context, *App) error) error {
+	limit := c.parallel
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+
+	// done is closed for each app when it completes. failed is set before
+	// done is closed if the app didn't succeed.
+	done := map[string]chan struct{}{}
+	failed := map[string]bool{}
+	for _, name := range names {
+		done[name] = make(chan struct{})
+	}
+	position := map[string]int{}
+	for i, name := range names {
+		position[name] = i
+	}
+
+	var (
+		g      errgroup.Group
+		lock   sync.Mutex
+		result error
+	)
+	for _, name := range names {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		name := name
+		app := c.App(name)
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			var err error
+			for _, dep := range c.dependencies[name] {
+				// We only wait on dependencies that were started before
+				// us, otherwise we could wait forever.
+				if i, ok := position[dep]; !ok || i >= position[name] {
+					continue
+				}
+
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					err = ctx.Err()
+				}
+				if err != nil {
+					break
+				}
+
+				lock.Lock()
+				depFailed := failed[dep]
+				lock.Unlock()
+				if depFailed {
+					err = fmt.Errorf("dependency %q failed", dep)
+					break
+				}
+			}
+			if err == nil {
+				appCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				err = f(appCtx, app)
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				failed[name] = true
+				result = multierror.Append(result, fmt.Errorf("app %q: %w", name, err))
+			}
+			close(done[name])
+
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	if err := ctx.Err(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result
+}
+
 // Ref returns the application reference that this client is using.
 func (c *App) Ref() *pb.Ref_Application {
 	return c.application
@@ -40,6 +149,18 @@ func (c *App) job() *pb.Job {
 	return job
 }
 
+// Job returns the job skeleton for this app. The operation can be set and
+// the job executed with DoJob for operations composed outside this
+// package, such as the stages of a pipeline.
+func (c *App) Job() *pb.Job {
+	return c.job()
+}
+
+// DoJob queues and executes the job, returning its result.
+func (c *App) DoJob(ctx context.Context, job *pb.Job) (*pb.Job_Result, error) {
+	return c.doJob(ctx, job)
+}
+
 // doJob is the same as Project.doJob except we set the proper app-specific UI.
 func (c *App) doJob(ctx context.Context, job *pb.Job) (*pb.Job_Result, error) {
 	return c.project.doJob(ctx, job, c.UI)
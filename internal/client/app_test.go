@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectDoApps(t *testing.T) {
+	names := []string{"a", "b", "c", "d"}
+
+	t.Run("sequential by default", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t)
+
+		var order []string
+		require.NoError(c.DoApps(context.Background(), names, func(ctx context.Context, app *App) error {
+			order = append(order, app.Ref().Application)
+			return nil
+		}))
+		require.Equal(names, order)
+	})
+
+	t.Run("parallel limit", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t, WithParallel(2))
+
+		var running, max int32
+		require.NoError(c.DoApps(context.Background(), names, func(ctx context.Context, app *App) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				old := atomic.LoadInt32(&max)
+				if n <= old || atomic.CompareAndSwapInt32(&max, old, n) {
+					break
+				}
+			}
+
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}))
+		require.Equal(int32(2), max)
+	})
+
+	t.Run("aggregates errors", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t, WithParallel(4))
+
+		var lock sync.Mutex
+		var called []string
+		err := c.DoApps(context.Background(), names, func(ctx context.Context, app *App) error {
+			lock.Lock()
+			called = append(called, app.Ref().Application)
+			lock.Unlock()
+
+			switch app.Ref().Application {
+			case "a", "c":
+				return errors.New("failed")
+			}
+
+			return nil
+		})
+		require.Error(err)
+		require.Contains(err.Error(), `app "a": failed`)
+		require.Contains(err.Error(), `app "c": failed`)
+		require.NotContains(err.Error(), `app "b"`)
+		require.ElementsMatch(names, called)
+	})
+
+	t.Run("waits for dependencies", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t, WithParallel(4), WithAppDependencies(map[string][]string{
+			"b": {"a"},
+			"c": {"b"},
+		}))
+
+		var lock sync.Mutex
+		var order []string
+		require.NoError(c.DoApps(context.Background(), names, func(ctx context.Context, app *App) error {
+			time.Sleep(20 * time.Millisecond)
+
+			lock.Lock()
+			defer lock.Unlock()
+			order = append(order, app.Ref().Application)
+			return nil
+		}))
+		require.Len(order, len(names))
+
+		index := map[string]int{}
+		for i, name := range order {
+			index[name] = i
+		}
+		require.True(index["a"] < index["b"])
+		require.True(index["b"] < index["c"])
+	})
+
+	t.Run("skips apps with failed dependencies", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t, WithParallel(4), WithAppDependencies(map[string][]string{
+			"c": {"a"},
+		}))
+
+		var lock sync.Mutex
+		var called []string
+		err := c.DoApps(context.Background(), names, func(ctx context.Context, app *App) error {
+			lock.Lock()
+			called = append(called, app.Ref().Application)
+			lock.Unlock()
+
+			if app.Ref().Application == "a" {
+				return errors.New("failed")
+			}
+
+			return nil
+		})
+		require.Error(err)
+		require.Contains(err.Error(), `app "c": dependency "a" failed`)
+		require.ElementsMatch([]string{"a", "b", "d"}, called)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		require := require.New(t)
+		c := TestProject(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var called []string
+		err := c.DoApps(ctx, names, func(ctx context.Context, app *App) error {
+			called = append(called, app.Ref().Application)
+			cancel()
+			return nil
+		})
+		require.Error(err)
+		require.True(errors.Is(err, context.Canceled))
+		require.Equal([]string{"a"}, called)
+	})
+}
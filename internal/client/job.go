@@ -11,8 +11,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/datasource"
 	"github.com/hashicorp/waypoint/internal/pkg/finalcontext"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 // job returns the basic job skeleton prepoulated with the correct
@@ -23,6 +25,8 @@ func (c *Project) job() *pb.Job {
 		TargetRunner: c.runner,
 		Labels:       c.labels,
 		Workspace:    c.workspace,
+		Priority:     c.priority,
+		Variables:    c.variables,
 		Application: &pb.Ref_Application{
 			Project: c.project.Project,
 		},
@@ -102,11 +106,50 @@ func (c *Project) doJob(ctx context.Context, job *pb.Job, ui terminal.UI) (*pb.J
 				},
 			},
 		}
+
+		// The local runner works in our working directory
+		c.setJobVCS(ctx, job, "")
+	} else if job.DataSource == nil && c.sourceDir != "" {
+		// Remote jobs use the project's data source. If there isn't one
+		// we upload our local copy for the runner to use.
+		resp, err := c.client.GetProject(ctx, &pb.GetProjectRequest{
+			Project: c.project,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Project.DataSource == nil {
+			log.Info("project has no data source, uploading source", "path", c.sourceDir)
+			job.DataSource, err = datasource.Upload(ctx, c.client, c.project, c.sourceDir)
+			if err != nil {
+				return nil, err
+			}
+
+			c.setJobVCS(ctx, job, c.sourceDir)
+		}
 	}
 
 	return c.queueAndStreamJob(ctx, job, ui, cb)
 }
 
+// setJobVCS sets the VCS info of the job to that of the local directory
+// at path, since the runner can't read it from the data it is given. This
+// does nothing if the directory isn't in a git repository.
+func (c *Project) setJobVCS(ctx context.Context, job *pb.Job, path string) {
+	if job.Vcs != nil {
+		return
+	}
+
+	vcs, err := datasource.GitVCS(ctx, path)
+	if err != nil {
+		c.logger.Debug("no VCS info for the local directory", "path", path, "err", err)
+		return
+	}
+
+	job.Vcs = vcs
+}
+
 // queueAndStreamJob will queue the job. If the client is configured to watch the job,
 // it'll also stream the output to the configured UI.
 func (c *Project) queueAndStreamJob(
@@ -125,12 +168,24 @@ func (c *Project) queueAndStreamJob(
 		expiration = "30s"
 	}
 
-	// Queue the job
-	log.Debug("queueing job", "operation", fmt.Sprintf("%T", job.Operation))
-	queueResp, err := c.client.QueueJob(ctx, &pb.QueueJobRequest{
+	if job.Timeout == "" {
+		job.Timeout = c.jobTimeouts[serverptypes.JobOperationName(job)]
+	}
+
+	req := &pb.QueueJobRequest{
 		Job:       job,
 		ExpiresIn: expiration,
-	})
+	}
+	if _, ok := job.Operation.(*pb.Job_Deploy); ok && c.requireApproval {
+		req.RequireApproval = true
+		if c.approvalTimeout > 0 {
+			req.ApprovalTimeout = c.approvalTimeout.String()
+		}
+	}
+
+	// Queue the job
+	log.Debug("queueing job", "operation", fmt.Sprintf("%T", job.Operation))
+	queueResp, err := c.client.QueueJob(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -180,6 +235,18 @@ func (c *Project) queueAndStreamJob(
 		steps = map[int32]*stepData{}
 	)
 
+	// Output from remote runners is rendered as it arrives, so on return
+	// we wait for any open step group to finish drawing and stop any
+	// pending state message.
+	defer func() {
+		if stateEventTimer != nil {
+			stateEventTimer.Stop()
+		}
+		if sg != nil {
+			sg.Wait()
+		}
+	}()
+
 	if c.local {
 		defer func() {
 			// If we completed then do nothing, or if the context is still
@@ -295,6 +362,8 @@ func (c *Project) queueAndStreamJob(
 								Color: ent.Color,
 							})
 						}
+
+						tbl.Rows = append(tbl.Rows, trow)
 					}
 
 					ui.Table(tbl)
@@ -303,6 +372,7 @@ func (c *Project) queueAndStreamJob(
 						sg.Wait()
 					}
 
+					sg = nil
 					if !ev.StepGroup.Close {
 						sg = ui.StepGroup()
 					}
@@ -365,6 +435,12 @@ func (c *Project) queueAndStreamJob(
 						terminal.WithInfoStyle())
 				})
 
+			case pb.Job_WAITING_APPROVAL:
+				ui.Output("Operation is waiting for approval. To approve it run:",
+					terminal.WithHeaderStyle())
+				ui.Output("waypoint deployment approve %s", queueResp.JobId,
+					terminal.WithInfoStyle())
+
 			case pb.Job_WAITING:
 				stateEventTimer = time.AfterFunc(stateEventPause, func() {
 					ui.Output("Operation is assigned to a runner. Waiting for start...",
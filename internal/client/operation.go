@@ -67,6 +67,44 @@ func (c *App) Docs(ctx context.Context, op *pb.Job_DocsOp) (*pb.Job_DocsResult,
 	return result.Docs, nil
 }
 
+func (c *App) HealthCheck(ctx context.Context, op *pb.Job_HealthCheckOp) (*pb.Job_HealthCheckResult, error) {
+	if op == nil {
+		op = &pb.Job_HealthCheckOp{}
+	}
+
+	job := c.job()
+	job.Operation = &pb.Job_HealthCheck{
+		HealthCheck: op,
+	}
+
+	// Execute it
+	result, err := c.doJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.HealthCheck, nil
+}
+
+func (c *App) Rollback(ctx context.Context, op *pb.Job_RollbackOp) (*pb.Job_RollbackResult, error) {
+	if op == nil {
+		op = &pb.Job_RollbackOp{}
+	}
+
+	job := c.job()
+	job.Operation = &pb.Job_Rollback{
+		Rollback: op,
+	}
+
+	// Execute it
+	result, err := c.doJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rollback, nil
+}
+
 func (c *App) Build(ctx context.Context, op *pb.Job_BuildOp) (*pb.Job_BuildResult, error) {
 	if op == nil {
 		op = &pb.Job_BuildOp{}
@@ -159,7 +197,7 @@ func (c *App) Release(ctx context.Context, op *pb.Job_ReleaseOp) (*pb.Job_Releas
 	return result.Release, nil
 }
 
-func (a *App) Logs(ctx context.Context) (component.LogViewer, error) {
+func (a *App) Logs(ctx context.Context, filter *pb.GetLogStreamRequest_Filter) (component.LogViewer, error) {
 	log := a.project.logger.Named("logs")
 
 	// First we attempt to query the server for logs for this deployment.
@@ -171,6 +209,7 @@ func (a *App) Logs(ctx context.Context) (component.LogViewer, error) {
 				Workspace:   a.project.WorkspaceRef(),
 			},
 		},
+		Filter: filter,
 	})
 	if err != nil {
 		return nil, err
@@ -179,3 +218,28 @@ func (a *App) Logs(ctx context.Context) (component.LogViewer, error) {
 	// Build our log viewer
 	return &logviewer.Viewer{Stream: client}, nil
 }
+
+// LogHistory returns the logs stored by the server for the app, oldest
+// first. The viewer returns an empty batch once all of them are read.
+func (a *App) LogHistory(
+	ctx context.Context,
+	limit int32,
+	filter *pb.GetLogStreamRequest_Filter,
+) (component.LogViewer, error) {
+	client, err := a.project.client.GetLogStream(ctx, &pb.GetLogStreamRequest{
+		Scope: &pb.GetLogStreamRequest_Application_{
+			Application: &pb.GetLogStreamRequest_Application{
+				Application: a.Ref(),
+				Workspace:   a.project.WorkspaceRef(),
+			},
+		},
+		LimitBacklog: limit,
+		History:      true,
+		Filter:       filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &logviewer.Viewer{Stream: client}, nil
+}
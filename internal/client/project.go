@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/runner"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/hashicorp/waypoint/internal/serverclient"
 )
@@ -28,6 +30,37 @@ type Project struct {
 
 	local bool
 
+	// runnerOpts are added to the options of the local runner.
+	runnerOpts []runner.Option
+
+	// sourceDir is the project directory that is uploaded for remote jobs
+	// if the project has no data source.
+	sourceDir string
+
+	// parallel is the maximum number of apps that DoApps operates on at once.
+	parallel int
+
+	// dependencies are the names of the apps that each app depends on.
+	// DoApps doesn't start an app until its dependencies have succeeded.
+	dependencies map[string][]string
+
+	// requireApproval is true if deploy jobs must be approved before they
+	// run. If approvalTimeout is non-zero, unapproved jobs are cancelled
+	// after that long.
+	requireApproval bool
+	approvalTimeout time.Duration
+
+	// jobTimeouts are the timeouts of the jobs queued by this client, keyed
+	// by operation name. Jobs for other operations use the server timeouts.
+	jobTimeouts map[string]string
+
+	// priority is the priority of the jobs queued by this client.
+	priority pb.Job_Priority
+
+	// variables are the input variable values set on the jobs queued by
+	// this client, in order of precedence.
+	variables []*pb.Operation_Variable
+
 	localServer bool // True when a local server is created
 }
 
@@ -172,6 +205,23 @@ func WithLabels(m map[string]string) Option {
 	}
 }
 
+// WithRunnerLabels sets the labels a runner must have to be assigned the
+// jobs queued by this client. This has no effect in local mode since local
+// jobs always target the local runner.
+func WithRunnerLabels(m map[string]string) Option {
+	return func(c *Project, cfg *config) error {
+		if len(m) > 0 {
+			c.runner = &pb.Ref_Runner{
+				Target: &pb.Ref_Runner_Labels{
+					Labels: &pb.Ref_RunnerLabels{Labels: m},
+				},
+			}
+		}
+
+		return nil
+	}
+}
+
 // WithSourceOverrides sets the data source overrides for queued jobs.
 func WithSourceOverrides(m map[string]string) Option {
 	return func(c *Project, cfg *config) error {
@@ -180,6 +230,16 @@ func WithSourceOverrides(m map[string]string) Option {
 	}
 }
 
+// WithSourceUpload sets the directory to upload as the data source of
+// remote jobs for projects that don't have a data source. This has no
+// effect in local mode.
+func WithSourceUpload(dir string) Option {
+	return func(c *Project, cfg *config) error {
+		c.sourceDir = dir
+		return nil
+	}
+}
+
 // WithLocal puts the client in local exec mode. In this mode, the client
 // will spin up a per-operation runner locally and reference the local on-disk
 // data for all operations.
@@ -190,6 +250,73 @@ func WithLocal() Option {
 	}
 }
 
+// WithLocalRunnerOptions sets additional options for the runner that is
+// started for operations in local mode. See WithLocal.
+func WithLocalRunnerOptions(opts ...runner.Option) Option {
+	return func(c *Project, cfg *config) error {
+		c.runnerOpts = append(c.runnerOpts, opts...)
+		return nil
+	}
+}
+
+// WithParallel sets the maximum number of apps that DoApps will operate
+// on at once. Values less than one are treated as one.
+func WithParallel(n int) Option {
+	return func(c *Project, cfg *config) error {
+		c.parallel = n
+		return nil
+	}
+}
+
+// WithAppDependencies sets the names of the apps that each app depends
+// on. DoApps waits for the dependencies of an app to complete before
+// operating on it, even when operating on apps in parallel.
+func WithAppDependencies(deps map[string][]string) Option {
+	return func(c *Project, cfg *config) error {
+		c.dependencies = deps
+		return nil
+	}
+}
+
+// WithApproval requires the deploy jobs queued by this client to be
+// approved with ApproveJob before they run. If timeout is non-zero, jobs
+// that aren't approved in that time are cancelled.
+func WithApproval(timeout time.Duration) Option {
+	return func(c *Project, cfg *config) error {
+		c.requireApproval = true
+		c.approvalTimeout = timeout
+		return nil
+	}
+}
+
+// WithJobTimeouts sets the maximum duration of the jobs queued by this
+// client, keyed by operation name such as "build". Jobs that run longer
+// are cancelled by the server.
+func WithJobTimeouts(timeouts map[string]string) Option {
+	return func(c *Project, cfg *config) error {
+		c.jobTimeouts = timeouts
+		return nil
+	}
+}
+
+// WithPriority sets the priority of the jobs queued by this client. Queued
+// jobs with a higher priority are assigned to runners first.
+func WithPriority(p pb.Job_Priority) Option {
+	return func(c *Project, cfg *config) error {
+		c.priority = p
+		return nil
+	}
+}
+
+// WithVariables sets the input variable values of the jobs queued by this
+// client. Later values take precedence over earlier values.
+func WithVariables(vars []*pb.Operation_Variable) Option {
+	return func(c *Project, cfg *config) error {
+		c.variables = vars
+		return nil
+	}
+}
+
 // WithLogger sets the logger for the client.
 func WithLogger(log hclog.Logger) Option {
 	return func(c *Project, cfg *config) error {
@@ -8,12 +8,14 @@ import (
 // runner is non-nil, you must call Close on it to clean up resources properly.
 func (c *Project) startRunner() (*runner.Runner, error) {
 	// Initialize our runner
-	r, err := runner.New(
+	opts := []runner.Option{
 		runner.WithClient(c.client),
 		runner.WithLogger(c.logger.Named("runner")),
 		runner.ByIdOnly(),      // We'll direct target this
 		runner.WithLocal(c.UI), // Local mode
-	)
+	}
+	opts = append(opts, c.runnerOpts...)
+	r, err := runner.New(opts...)
 	if err != nil {
 		return nil, err
 	}
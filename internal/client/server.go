@@ -16,6 +16,7 @@ import (
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess"
 	"github.com/hashicorp/waypoint/internal/serverclient"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 // initServerClient will initialize a gRPC connection to the Waypoint server.
@@ -106,6 +107,11 @@ func (c *Project) initLocalServer(ctx context.Context) (*grpc.ClientConn, error)
 		return nil, err
 	}
 
+	// Stop the timers of the server before the database is closed.
+	if closer, ok := impl.(io.Closer); ok {
+		closers = append([]io.Closer{closer}, closers...)
+	}
+
 	// We listen on a random locally bound port
 	// TODO(mitchellh): we should use Unix domain sockets if supported
 	ln, err := net.Listen("tcp", "127.0.0.1:")
@@ -131,6 +137,8 @@ func (c *Project) initLocalServer(ctx context.Context) (*grpc.ClientConn, error)
 		grpc.WithInsecure(),
 		grpc.WithUnaryInterceptor(protocolversion.UnaryClientInterceptor(protocolversion.Current())),
 		grpc.WithStreamInterceptor(protocolversion.StreamClientInterceptor(protocolversion.Current())),
+		grpc.WithChainUnaryInterceptor(telemetry.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(telemetry.StreamClientInterceptor()),
 	)
 	if err != nil {
 		cancel()
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// defaultFileMode is the mode of config files that don't set permissions.
+const defaultFileMode = 0600
+
+// AppConfig is the "config" stanza of an app. The values are set on the
+// server for the app when it is deployed and the entrypoint exposes
+// them to the deployments.
+type AppConfig struct {
+	// Env is set as environment variables.
+	Env map[string]string `hcl:"env,optional"`
+
+	// File is written by the entrypoint to the app data directory.
+	File []*ConfigFile `hcl:"file,block"`
+}
+
+// ConfigFile is a file that the entrypoint writes for the app. The file
+// is updated when the contents change on the server.
+type ConfigFile struct {
+	// Path is relative to the app data directory.
+	Path     string `hcl:",label"`
+	Contents string `hcl:"contents,attr"`
+
+	// Permissions is the octal file mode, such as "0644". This defaults
+	// to "0600".
+	Permissions string `hcl:"permissions,optional"`
+}
+
+// Mode returns the file mode from Permissions. Validation ensures this
+// is valid.
+func (c *ConfigFile) Mode() os.FileMode {
+	if c.Permissions == "" {
+		return defaultFileMode
+	}
+
+	v, err := strconv.ParseUint(c.Permissions, 8, 32)
+	if err != nil {
+		return defaultFileMode
+	}
+
+	return os.FileMode(v)
+}
+
+func (c *AppConfig) validate(key string) error {
+	if c == nil {
+		return nil
+	}
+
+	var result error
+	seen := map[string]struct{}{}
+	for _, f := range c.File {
+		fk := fmt.Sprintf("%s: file[%s]", key, f.Path)
+		if _, ok := seen[f.Path]; ok {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: file paths must be unique", fk))
+		}
+		seen[f.Path] = struct{}{}
+
+		if _, ok := c.Env[f.Path]; ok {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: path must not be the same as an env name", fk))
+		}
+
+		// The path must stay in the app data directory.
+		clean := filepath.ToSlash(filepath.Clean(f.Path))
+		if f.Path == "" || filepath.IsAbs(f.Path) ||
+			clean == ".." || strings.HasPrefix(clean, "../") {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: path must be relative to the app data directory", fk))
+		}
+
+		if f.Permissions != "" {
+			v, err := strconv.ParseUint(f.Permissions, 8, 32)
+			if err != nil || v > 0777 {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: permissions must be an octal file mode such as \"0644\"", fk))
+			}
+		}
+	}
+
+	return result
+}
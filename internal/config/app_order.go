@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppOrder returns the names of the apps in the order they should be
+// operated on so that every app comes after the apps it depends on. Apps
+// that don't depend on each other keep the order they are declared in.
+//
+// This returns an error if an app depends on an unknown app or if the
+// dependencies have a cycle.
+func (c *Config) AppOrder() ([]string, error) {
+	deps := map[string][]string{}
+	for _, app := range c.Apps {
+		deps[app.Name] = app.DependsOn
+	}
+	for _, app := range c.Apps {
+		for _, dep := range app.DependsOn {
+			if _, ok := deps[dep]; !ok {
+				return nil, fmt.Errorf(
+					"app[%s]: depends_on: unknown app %q", app.Name, dep)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	var (
+		result []string
+		stack  []string
+		visit  func(string) error
+	)
+	marks := map[string]int{}
+	visit = func(name string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+
+		case visiting:
+			// Report the cycle starting at the first time we saw this app.
+			for i, v := range stack {
+				if v == name {
+					cycle := append(append([]string{}, stack[i:]...), name)
+					return fmt.Errorf("app dependency cycle: %s",
+						strings.Join(cycle, " -> "))
+				}
+			}
+		}
+
+		marks[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		marks[name] = visited
+
+		result = append(result, name)
+		return nil
+	}
+
+	for _, app := range c.Apps {
+		if err := visit(app.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// AppDependencies returns the names of the apps that each app depends on.
+// Apps without any dependencies are not present in the map.
+func (c *Config) AppDependencies() map[string][]string {
+	result := map[string][]string{}
+	for _, app := range c.Apps {
+		if len(app.DependsOn) > 0 {
+			result[app.Name] = app.DependsOn
+		}
+	}
+
+	return result
+}
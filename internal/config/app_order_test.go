@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigAppOrder(t *testing.T) {
+	app := func(name string, deps ...string) *App {
+		return &App{Name: name, DependsOn: deps}
+	}
+
+	cases := []struct {
+		Name     string
+		Apps     []*App
+		Expected []string
+		Err      string
+	}{
+		{
+			"no dependencies keeps declared order",
+			[]*App{app("a"), app("b"), app("c")},
+			[]string{"a", "b", "c"},
+			"",
+		},
+
+		{
+			"dependency declared later",
+			[]*App{app("frontend", "backend"), app("backend")},
+			[]string{"backend", "frontend"},
+			"",
+		},
+
+		{
+			"transitive",
+			[]*App{app("web", "api"), app("api", "db"), app("db"), app("worker")},
+			[]string{"db", "api", "web", "worker"},
+			"",
+		},
+
+		{
+			"unknown app",
+			[]*App{app("web", "nope")},
+			nil,
+			`unknown app "nope"`,
+		},
+
+		{
+			"cycle",
+			[]*App{app("a", "b"), app("b", "c"), app("c", "a")},
+			nil,
+			"a -> b -> c -> a",
+		},
+
+		{
+			"self",
+			[]*App{app("a", "a")},
+			nil,
+			"a -> a",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := &Config{Apps: tt.Apps}
+			result, err := cfg.AppOrder()
+			if tt.Err != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Err)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.Expected, result)
+		})
+	}
+}
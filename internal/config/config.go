@@ -1,7 +1,11 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Config is the configuration structure.
@@ -11,6 +15,22 @@ type Config struct {
 	Apps    []*App            `hcl:"app,block"`
 	Labels  map[string]string `hcl:"labels,optional"`
 	Plugin  []*Plugin         `hcl:"plugin,block"`
+
+	// Variables are the input variables of the project. Use LoadFile to
+	// decode a configuration that references them.
+	Variables []*Variable `hcl:"variable,block"`
+
+	// Parallel is the maximum number of apps to operate on at once. Zero
+	// or one means apps are operated on one at a time.
+	Parallel int `hcl:"parallel,optional"`
+
+	// JobTimeouts is the maximum duration of the jobs of this project, keyed
+	// by operation such as "build" or "deploy". Jobs that run longer are
+	// cancelled. These override the job timeouts of the server config.
+	JobTimeouts map[string]string `hcl:"job_timeouts,optional"`
+
+	// variables are the final values of Variables, set by LoadFile.
+	variables map[string]cty.Value
 }
 
 // Retrieve the app config for the named application
@@ -31,9 +51,19 @@ type App struct {
 	Labels map[string]string `hcl:"labels,optional"`
 	URL    *AppURL           `hcl:"url,block" default:"{}"`
 
+	// DependsOn is the names of the apps that must be deployed before
+	// this one. Apps are operated on in dependency order.
+	DependsOn []string `hcl:"depends_on,optional"`
+
 	Build   *Build   `hcl:"build,block"`
 	Deploy  *Deploy  `hcl:"deploy,block"`
 	Release *Release `hcl:"release,block"`
+
+	// Pipeline, if set, is the ordered stages run by "waypoint pipeline run".
+	Pipeline *Pipeline `hcl:"pipeline,block"`
+
+	// Config is the env vars and files that are available to deployments.
+	Config *AppConfig `hcl:"config,block"`
 }
 
 // AppURL configures the App-specific URL settings.
@@ -72,6 +102,10 @@ type Runner struct {
 
 	// DataSource is the default data source when a remote job is queued.
 	DataSource *DataSource `hcl:"data_source,block"`
+
+	// Labels, if set, limits remote jobs for this project to runners that
+	// have all of these labels, such as "os" or "env".
+	Labels map[string]string `hcl:"labels,optional"`
 }
 
 // DataSource configures the data source for the runner.
@@ -85,18 +119,79 @@ type Hook struct {
 	When      string   `hcl:"when,attr"`
 	Command   []string `hcl:"command,attr"`
 	OnFailure string   `hcl:"on_failure,optional"`
+
+	// Interpolate, if true, expands references to environment variables
+	// as $NAME or ${NAME} in the arguments of Command before the hook is
+	// run. This is opt-in so that arguments meant for a shell, such as
+	// "sh -c", are passed through unchanged.
+	Interpolate bool `hcl:"interpolate,optional"`
+
+	// Condition, if set, must be true for the hook to run. "NAME" requires
+	// the env var NAME to be non-empty and "NAME=value" requires it to
+	// equal value.
+	Condition string `hcl:"condition,optional"`
+
+	// Timeout, if set, is the maximum time each attempt to run the hook
+	// may take, such as "30s". A hook that runs longer is killed and fails.
+	// Retries is the number of times a failed hook is run again.
+	Timeout string `hcl:"timeout,optional"`
+	Retries int    `hcl:"retries,optional"`
 }
 
 func (h *Hook) ContinueOnFailure() bool {
 	return h.OnFailure == "continue"
 }
 
+// TimeoutDuration returns the parsed Timeout. This returns zero if there
+// is no timeout. Validation ensures this is valid.
+func (h *Hook) TimeoutDuration() time.Duration {
+	if h.Timeout == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(h.Timeout)
+	if err != nil {
+		return 0
+	}
+
+	return d
+}
+
+// ConditionMet returns true if the hook condition is true using getenv
+// to look up environment variables. This is true if there is no condition.
+func (h *Hook) ConditionMet(getenv func(string) string) bool {
+	if h.Condition == "" {
+		return true
+	}
+
+	idx := strings.Index(h.Condition, "=")
+	if idx == -1 {
+		return getenv(h.Condition) != ""
+	}
+
+	return getenv(h.Condition[:idx]) == h.Condition[idx+1:]
+}
+
 // Build are the build settings.
 type Build struct {
-	Labels   map[string]string `hcl:"labels,optional"`
-	Hooks    []*Hook           `hcl:"hook,block"`
-	Use      *Use              `hcl:"use,block"`
-	Registry *Registry         `hcl:"registry,block"`
+	Labels map[string]string `hcl:"labels,optional"`
+	Hooks  []*Hook           `hcl:"hook,block"`
+	Use    *Use              `hcl:"use,block"`
+
+	// Registries are the registries that the built artifact is pushed to.
+	// If there are multiple, the artifact is pushed to all of them.
+	Registries []*Registry `hcl:"registry,block"`
+
+	// Matrix, if set, runs the builder once for every entry.
+	Matrix *BuildMatrix `hcl:"matrix,block"`
+}
+
+// BuildMatrix configures building an app multiple times with different
+// settings.
+type BuildMatrix struct {
+	// Platforms are the platforms to build for as "os/arch", such as
+	// "linux/arm64". The builder is given each as the "platform" argument.
+	Platforms []string `hcl:"platforms,attr"`
 }
 
 // Registry are the registry settings.
@@ -111,6 +206,30 @@ type Deploy struct {
 	Labels map[string]string `hcl:"labels,optional"`
 	Hooks  []*Hook           `hcl:"hook,block"`
 	Use    *Use              `hcl:"use,block"`
+
+	// Signature, if set, requires the artifact being deployed to be
+	// signed by one of the trusted keys.
+	Signature *Signature `hcl:"signature,block"`
+
+	// Rollback, if true, deploys the previous successful artifact again
+	// if a new deployment was reported unhealthy by the time the deploy
+	// finished or its release fails.
+	Rollback bool `hcl:"rollback,optional"`
+}
+
+// Signature configures artifact signature verification.
+type Signature struct {
+	// TrustedKeys are base64-encoded ed25519 public keys.
+	TrustedKeys []string `hcl:"trusted_keys,attr"`
+
+	// Mode is "enforce" (the default) to fail a deploy with a missing or
+	// invalid signature, or "warn" to only output a warning.
+	Mode string `hcl:"mode,optional"`
+}
+
+// Enforce returns true if an invalid signature should fail the deploy.
+func (s *Signature) Enforce() bool {
+	return s.Mode != "warn"
 }
 
 // Release are the release settings.
@@ -118,6 +237,38 @@ type Release struct {
 	Labels map[string]string `hcl:"labels,optional"`
 	Hooks  []*Hook           `hcl:"hook,block"`
 	Use    *Use              `hcl:"use,block"`
+
+	// RollbackWindow is a duration after a release during which a health
+	// breach automatically rolls back to the previous release. After the
+	// window the release is considered baked and "waypoint rollback"
+	// requires -confirm. Empty disables this.
+	RollbackWindow string `hcl:"rollback_window,optional"`
+
+	// FeatureFlags are flags that are rolled out to a percentage of
+	// deployments each time a release is made.
+	FeatureFlags []*FeatureFlag `hcl:"feature_flag,block"`
+}
+
+// FeatureFlag is an application feature flag that is enabled for a
+// percentage of deployments, starting with the most recent.
+type FeatureFlag struct {
+	Name    string `hcl:",label"`
+	Percent int    `hcl:"percent,attr"`
+}
+
+// RollbackWindowDuration returns the parsed RollbackWindow. This returns
+// zero if the window is not set. Validation ensures this is valid.
+func (c *Release) RollbackWindowDuration() time.Duration {
+	if c == nil || c.RollbackWindow == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(c.RollbackWindow)
+	if err != nil {
+		return 0
+	}
+
+	return d
 }
 
 // Use is something in the Waypoint configuration that is executed
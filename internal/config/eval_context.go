@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 
 	"github.com/hashicorp/waypoint/internal/config/funcs"
@@ -30,6 +31,20 @@ func EvalContext(pwd string) *hcl.EvalContext {
 	addFuncs(funcs.VCSGitFuncs(pwd))
 	addFuncs(funcs.Filesystem(pwd))
 	addFuncs(funcs.Encoding())
+	addFuncs(funcs.Env())
 
 	return &result
 }
+
+// SetWorkspace sets the workspace in the eval context so that the
+// configuration, including the defaults of input variables, can reference
+// the name of the workspace it is used in as workspace.name.
+func SetWorkspace(ctx *hcl.EvalContext, name string) {
+	if ctx.Variables == nil {
+		ctx.Variables = map[string]cty.Value{}
+	}
+
+	ctx.Variables["workspace"] = cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal(name),
+	})
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Format returns src in the canonical format for HCL, the same as
+// "terraform fmt". Files with syntax errors aren't formatted and the
+// errors are returned. The filename is only used for error messages.
+func Format(src []byte, filename string) ([]byte, error) {
+	_, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return hclwrite.Format(src), nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("formats", func(t *testing.T) {
+		require := require.New(t)
+
+		out, err := Format([]byte(testFormatUnformatted), "waypoint.hcl")
+		require.NoError(err)
+		require.Equal(testFormatFormatted, string(out))
+
+		// Formatting is idempotent
+		out, err = Format(out, "waypoint.hcl")
+		require.NoError(err)
+		require.Equal(testFormatFormatted, string(out))
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := Format([]byte(`app "web" {`), "waypoint.hcl")
+		require.Error(t, err)
+	})
+}
+
+const testFormatUnformatted = `project="foo"
+
+app "web" {
+path = "./web"
+
+    labels = {
+  "a" = "b"
+      "long" = "c"
+    }
+}
+`
+
+const testFormatFormatted = `project = "foo"
+
+app "web" {
+  path = "./web"
+
+  labels = {
+    "a"    = "b"
+    "long" = "c"
+  }
+}
+`
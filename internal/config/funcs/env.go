@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func Env() map[string]function.Function {
+	return map[string]function.Function{
+		"env": EnvFunc,
+	}
+}
+
+// EnvFunc constructs a function that returns the value of an environment
+// variable. An optional second argument is the default if the variable is
+// unset.
+var EnvFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "default",
+		Type: cty.String,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(2,
+				"env takes at most one default value")
+		}
+
+		if v, ok := os.LookupEnv(args[0].AsString()); ok {
+			return cty.StringVal(v), nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+
+		return cty.StringVal(""), nil
+	},
+})
+
+// EnvLookup returns the value of the environment variable with the given
+// name. If the variable is unset, this returns the first default or an
+// empty string if there is none.
+//
+// The environment is read when the configuration is loaded, so for remote
+// operations this is the environment of the runner rather than the CLI.
+func EnvLookup(name cty.Value, def ...cty.Value) (cty.Value, error) {
+	return EnvFunc.Call(append([]cty.Value{name}, def...))
+}
@@ -0,0 +1,71 @@
+package funcs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEnvLookup(t *testing.T) {
+	os.Setenv("WAYPOINT_TEST_ENV_FUNC", "hello")
+	defer os.Unsetenv("WAYPOINT_TEST_ENV_FUNC")
+
+	tests := []struct {
+		Name    cty.Value
+		Default []cty.Value
+		Want    cty.Value
+		Err     bool
+	}{
+		{
+			cty.StringVal("WAYPOINT_TEST_ENV_FUNC"),
+			nil,
+			cty.StringVal("hello"),
+			false,
+		},
+		{
+			cty.StringVal("WAYPOINT_TEST_ENV_FUNC"),
+			[]cty.Value{cty.StringVal("default")},
+			cty.StringVal("hello"),
+			false,
+		},
+		{ // Unset
+			cty.StringVal("WAYPOINT_TEST_ENV_FUNC_UNSET"),
+			nil,
+			cty.StringVal(""),
+			false,
+		},
+		{ // Unset with a default
+			cty.StringVal("WAYPOINT_TEST_ENV_FUNC_UNSET"),
+			[]cty.Value{cty.StringVal("default")},
+			cty.StringVal("default"),
+			false,
+		},
+		{ // Too many defaults
+			cty.StringVal("WAYPOINT_TEST_ENV_FUNC_UNSET"),
+			[]cty.Value{cty.StringVal("a"), cty.StringVal("b")},
+			cty.UnknownVal(cty.String),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("env(%#v)", test.Name), func(t *testing.T) {
+			got, err := EnvLookup(test.Name, test.Default...)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
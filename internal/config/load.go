@@ -1,20 +1,27 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Filename is the default filename for the Waypoint configuration.
 const Filename = "waypoint.hcl"
 
+// FilenameJSON is the filename for the Waypoint configuration written in
+// the JSON syntax of HCL, such as by tools that generate it.
+const FilenameJSON = "waypoint.hcl.json"
+
 // FindPath looks for our configuration file starting at "start" and
 // traversing parent directories until it is found. If it is found, the
 // path is returned. If it is not found, an empty string is returned.
 // Error will be non-nil only if an error occurred.
 //
 // If start is empty, start will be the current working directory. If
-// filename is empty, it will default to the Filename constant.
+// filename is empty, both Filename and FilenameJSON are looked for and
+// it is an error if a directory has both.
 func FindPath(start, filename string) (string, error) {
 	var err error
 	if start == "" {
@@ -24,16 +31,15 @@ func FindPath(start, filename string) (string, error) {
 		}
 	}
 
-	if filename == "" {
-		filename = Filename
-	}
-
 	for {
-		path := filepath.Join(start, filename)
-		if _, err := os.Stat(path); err == nil {
-			return path, nil
-		} else if !os.IsNotExist(err) {
-			return "", err
+		var path string
+		if filename == "" {
+			path, err = DirPath(start)
+		} else {
+			path, err = filePath(start, filename)
+		}
+		if err != nil || path != "" {
+			return path, err
 		}
 
 		next := filepath.Dir(start)
@@ -44,3 +50,48 @@ func FindPath(start, filename string) (string, error) {
 		start = next
 	}
 }
+
+// DirPath returns the path of the configuration file in dir, which is
+// either Filename or FilenameJSON. This returns an empty string if dir
+// has neither, and an error if it has both since it isn't clear which
+// should be used.
+func DirPath(dir string) (string, error) {
+	path, err := filePath(dir, Filename)
+	if err != nil {
+		return "", err
+	}
+	pathJSON, err := filePath(dir, FilenameJSON)
+	if err != nil {
+		return "", err
+	}
+
+	if path != "" && pathJSON != "" {
+		return "", fmt.Errorf(
+			"both %s and %s exist in %s. Remove one of them so it is clear "+
+				"which configuration to use", Filename, FilenameJSON, dir)
+	}
+	if pathJSON != "" {
+		return pathJSON, nil
+	}
+
+	return path, nil
+}
+
+// IsJSON returns true if the configuration file at path uses the JSON
+// syntax of HCL.
+func IsJSON(path string) bool {
+	return strings.HasSuffix(path, ".json")
+}
+
+// filePath returns the path of filename in dir if it exists and an empty
+// string otherwise.
+func filePath(dir, filename string) (string, error) {
+	path := filepath.Join(dir, filename)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	return "", nil
+}
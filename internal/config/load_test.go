@@ -45,3 +45,32 @@ func TestFindPath_notFound(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, result)
 }
+
+func TestFindPath_default(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		result, err := FindPath(filepath.Join("testdata", "findpath-json", "a"), "")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join("testdata", "findpath-json", FilenameJSON), result)
+	})
+
+	t.Run("both files", func(t *testing.T) {
+		_, err := FindPath(filepath.Join("testdata", "findpath-ambiguous"), "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "both")
+	})
+}
+
+func TestLoadFile_json(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join("testdata", "findpath-json", FilenameJSON)
+	cfg, err := LoadFile(path, EvalContext(filepath.Dir(path)), []*VariableValue{
+		{Name: "image", Value: "bar", Source: VariableSourceCLI},
+	})
+	require.NoError(err)
+	require.Equal("foo", cfg.Project)
+	require.Len(cfg.Apps, 1)
+	require.Equal("web", cfg.Apps[0].Name)
+	require.Equal("bar", cfg.Apps[0].Labels["image"])
+	require.Equal("docker", cfg.Apps[0].Build.Use.Type)
+}
@@ -20,12 +20,19 @@ func (b *Build) Operation() *Operation {
 	return mapoperation(b, true)
 }
 
-func (b *Build) RegistryOperation() *Operation {
+// RegistryOperations returns the operations for all the registries of
+// the build in the order they're configured.
+func (b *Build) RegistryOperations() []*Operation {
 	if b == nil {
 		return nil
 	}
 
-	return b.Registry.Operation()
+	result := make([]*Operation, len(b.Registries))
+	for i, r := range b.Registries {
+		result[i] = r.Operation()
+	}
+
+	return result
 }
 
 func (b *Registry) Operation() *Operation {
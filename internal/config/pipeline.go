@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Pipeline is an ordered list of stages that are run one after another by
+// "waypoint pipeline run", such as building, deploying to a staging
+// workspace, waiting for approval, and releasing to production.
+type Pipeline struct {
+	Stages []*PipelineStage `hcl:"stage,block"`
+}
+
+// PipelineStage is a single stage of a pipeline.
+type PipelineStage struct {
+	Name string `hcl:",label"`
+
+	// Type is one of "build", "hook", "deploy", "approval", or "release".
+	Type string `hcl:"type,attr"`
+
+	// Workspace is the workspace that a deploy or release stage targets.
+	// If this is empty, the workspace the pipeline is run in is used.
+	Workspace string `hcl:"workspace,optional"`
+
+	// Command is the command that a hook stage runs. Env vars in the
+	// arguments are expanded the same as for hooks.
+	Command []string `hcl:"command,optional"`
+}
+
+func (c *Pipeline) validate(key string) error {
+	if c == nil {
+		return nil
+	}
+
+	var result error
+	if len(c.Stages) == 0 {
+		result = multierror.Append(result, fmt.Errorf(
+			"%s: at least one stage is required", key))
+	}
+
+	seen := map[string]struct{}{}
+	built := false
+	for i, s := range c.Stages {
+		sk := fmt.Sprintf("%s: stage[%s]", key, s.Name)
+		if _, ok := seen[s.Name]; ok {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: stage names must be unique", sk))
+		}
+		seen[s.Name] = struct{}{}
+
+		switch s.Type {
+		case "build":
+			built = true
+
+		case "deploy":
+			if !built {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: a deploy stage must come after a build stage", sk))
+			}
+
+		case "release":
+			if !pipelineDeploysBefore(c.Stages[:i], s.Workspace) {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: a release stage must come after a deploy stage to the same workspace", sk))
+			}
+
+		case "hook":
+			if len(s.Command) == 0 {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: command must be non-empty", sk))
+			}
+
+		case "approval":
+
+		default:
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: type must be 'build', 'hook', 'deploy', 'approval', or 'release'", sk))
+		}
+
+		if s.Workspace != "" && s.Type != "deploy" && s.Type != "release" {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: workspace is only valid for deploy and release stages", sk))
+		}
+		if len(s.Command) > 0 && s.Type != "hook" {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: command is only valid for hook stages", sk))
+		}
+	}
+
+	return result
+}
+
+// pipelineDeploysBefore returns true if any of the stages deploys to the
+// workspace.
+func pipelineDeploysBefore(stages []*PipelineStage, workspace string) bool {
+	for _, s := range stages {
+		if s.Type == "deploy" && s.Workspace == workspace {
+			return true
+		}
+	}
+
+	return false
+}
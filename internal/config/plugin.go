@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 )
 
@@ -19,8 +23,8 @@ func (c *Config) Plugins() []*Plugin {
 		// Get all the implied stage plugins: build, deploy, etc.
 		if v := app.Build; v != nil {
 			result = trackPlugin(result, known, v.Use, component.BuilderType)
-			if v := v.Registry; v != nil {
-				result = trackPlugin(result, known, v.Use, component.RegistryType)
+			for _, r := range v.Registries {
+				result = trackPlugin(result, known, r.Use, component.RegistryType)
 			}
 		}
 		if v := app.Deploy; v != nil {
@@ -60,17 +64,47 @@ type Plugin struct {
 	// "waypoint-plugin-<name>" including casing.
 	Name string `hcl:",label"`
 
-	// Type is the type of plugin this is. This can be multiple.
-	Type struct {
-		Mapper   bool `hcl:"mapper,optional"`
-		Builder  bool `hcl:"build,optional"`
-		Registry bool `hcl:"registry,optional"`
-		Platform bool `hcl:"deploy,optional"`
-		Releaser bool `hcl:"release,optional"`
-	} `hcl:"type,block"`
+	// Type is the type of plugin this is. This can be multiple. This is
+	// optional since the types are also implied by `use` statements.
+	Type *PluginType `hcl:"type,block"`
 
-	// Checksum is the SHA256 checksum to validate this plugin.
+	// Checksum is the SHA256 checksum to validate this plugin. This is
+	// deprecated in favor of Version and Source since the installer
+	// verifies the checksum of every download.
 	Checksum string `hcl:"checksum,optional"`
+
+	// Version is a version constraint for the plugin, such as ">= 0.3".
+	// `waypoint plugin install` installs the newest version matching it.
+	Version string `hcl:"version,optional"`
+
+	// Source is where `waypoint plugin install` downloads the plugin from.
+	// This is either the URL of a plugin registry or a GitHub repository
+	// as "github.com/<owner>/<repo>".
+	Source string `hcl:"source,optional"`
+}
+
+// VersionConstraints returns the parsed version constraint of the plugin.
+// This returns nil if the plugin has no version constraint.
+func (p *Plugin) VersionConstraints() (version.Constraints, error) {
+	if p.Version == "" {
+		return nil, nil
+	}
+
+	result, err := version.NewConstraint(p.Version)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q: invalid version %q: %s", p.Name, p.Version, err)
+	}
+
+	return result, nil
+}
+
+// PluginType are the component types that a plugin implements.
+type PluginType struct {
+	Mapper   bool `hcl:"mapper,optional"`
+	Builder  bool `hcl:"build,optional"`
+	Registry bool `hcl:"registry,optional"`
+	Platform bool `hcl:"deploy,optional"`
+	Releaser bool `hcl:"release,optional"`
 }
 
 // Types returns the list of types that this plugin implements.
@@ -98,6 +132,10 @@ func (p *Plugin) markType(typ component.Type) {
 }
 
 func (p *Plugin) typeMap() map[component.Type]*bool {
+	if p.Type == nil {
+		p.Type = &PluginType{}
+	}
+
 	return map[component.Type]*bool{
 		component.MapperType:         &p.Type.Mapper,
 		component.BuilderType:        &p.Type.Builder,
@@ -16,6 +16,19 @@ type ServerConfig struct {
 
 	// CEBConfig configures the entrypoint binary for deployments
 	CEBConfig *CEBConfig `hcl:"entrypoint_config,block"`
+
+	// Metrics enables the Prometheus metrics endpoint if it is set.
+	Metrics *Metrics `hcl:"metrics,block"`
+
+	// AutoAdoptRunners adopts runners as they register rather than
+	// waiting for an operator to adopt them.
+	AutoAdoptRunners bool `hcl:"auto_adopt_runners,optional"`
+}
+
+// Metrics configures the Prometheus metrics endpoint. Metrics are served
+// over plain HTTP on /metrics.
+type Metrics struct {
+	Addr string `hcl:"address,attr"`
 }
 
 // CEBConfig is specific configuration for the entrypoint binaries
@@ -0,0 +1,29 @@
+project = "foo"
+
+variable "region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "replicas" {
+  type    = number
+  default = workspace.name == "prod" ? 3 : 1
+}
+
+variable "zones" {
+  type = list(string)
+}
+
+labels = {
+  "region" = var.region
+}
+
+app "web" {
+  build {
+    use "docker" {}
+  }
+
+  deploy {
+    use "docker" {}
+  }
+}
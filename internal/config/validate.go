@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 )
@@ -14,24 +16,143 @@ import (
 // messages.
 type internalValidator interface {
 	validate(name string) error
+
+	// operation returns the Operation for this value. This may be nil.
+	operation() *Operation
+}
+
+// ValidationResults are the results of validating configuration. Errors
+// make the configuration unusable. Warnings are problems such as deprecated
+// or ineffective settings that don't block any operations.
+type ValidationResults struct {
+	Errors   error
+	Warnings []string
+}
+
+// HasErrors returns true if there were any errors.
+func (r *ValidationResults) HasErrors() bool {
+	return r.Errors != nil
 }
 
+// Validate validates the configuration, returning only the errors. Use
+// ValidateWithWarnings to also get warnings.
 func (c *Config) Validate() error {
-	var result error
+	return c.ValidateWithWarnings().Errors
+}
+
+// ValidateWithWarnings validates the configuration and returns both the
+// errors and warnings.
+func (c *Config) ValidateWithWarnings() *ValidationResults {
+	var result ValidationResults
 
 	if errs := ValidateLabels(c.Labels); len(errs) > 0 {
-		result = multierror.Append(result, errs...)
+		result.Errors = multierror.Append(result.Errors, errs...)
+	}
+	if c.Parallel < 0 {
+		result.Errors = multierror.Append(result.Errors,
+			fmt.Errorf("parallel must not be negative"))
+	}
+	for op, timeout := range c.JobTimeouts {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			result.Errors = multierror.Append(result.Errors, fmt.Errorf(
+				"job_timeouts: %s: timeout must be a positive duration such as '30m'", op))
+		}
+	}
+
+	for _, p := range c.Plugin {
+		if _, err := p.VersionConstraints(); err != nil {
+			result.Errors = multierror.Append(result.Errors, err)
+		}
+
+		// The installer verifies the checksum of every download, and a
+		// pinned checksum fails as soon as another version is installed.
+		if p.Checksum != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"plugin[%s]: checksum is deprecated, set version and source and "+
+					"use `waypoint plugin install` which verifies checksums", p.Name))
+		}
 	}
 
 	for _, app := range c.Apps {
-		if err := app.Validate(); err != nil {
-			result = multierror.Append(result, err)
+		r := app.ValidateWithWarnings()
+		if r.Errors != nil {
+			result.Errors = multierror.Append(result.Errors, r.Errors)
+		}
+
+		result.Warnings = append(result.Warnings, r.Warnings...)
+	}
+
+	if _, err := c.AppOrder(); err != nil {
+		result.Errors = multierror.Append(result.Errors, err)
+	}
+
+	return &result
+}
+
+// ValidateWithWarnings validates the app and returns both the errors
+// and warnings.
+func (app *App) ValidateWithWarnings() *ValidationResults {
+	return &ValidationResults{
+		Errors:   app.Validate(),
+		Warnings: app.warnings(),
+	}
+}
+
+// warnings returns the non-fatal problems with the app configuration.
+func (app *App) warnings() []string {
+	var result []string
+	for k, v := range app.validatorChildren() {
+		op := v.operation()
+		if op == nil {
+			continue
+		}
+
+		for _, w := range op.warnings() {
+			result = append(result, fmt.Sprintf("app[%s]: %s: %s", app.Name, k, w))
+		}
+
+		// Required operations without a `use` are already an error.
+		if op.Use != nil || op.required {
+			continue
 		}
+
+		// The release block may be used purely for settings.
+		if k == "release" && app.Release.hasSettings() {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf(
+			"app[%s]: %s: no `use` statement, this block has no effect", app.Name, k))
 	}
 
+	sort.Strings(result)
 	return result
 }
 
+// hasSettings returns true if any release setting is set, so that the
+// block has an effect even without a release plugin. The labels and hooks
+// that every operation has only apply to the plugin's release so they
+// aren't settings.
+func (r *Release) hasSettings() bool {
+	return r.RollbackWindow != "" ||
+		len(r.FeatureFlags) > 0
+}
+
+// warnings returns the non-fatal problems with the operation, without the
+// operation key.
+func (c *Operation) warnings() []string {
+	var result []string
+	for i, h := range c.Hooks {
+		if h.Retries > 0 && h.Timeout == "" {
+			result = append(result, fmt.Sprintf(
+				"hook[%d]: retries without a timeout, a hook that hangs is never retried", i))
+		}
+	}
+
+	return result
+}
+
+// Validate validates the app, returning only the errors.
 func (app *App) Validate() error {
 	var result error
 	if errs := ValidateLabels(app.Labels); len(errs) > 0 {
@@ -73,6 +194,13 @@ func (app *App) Validate() error {
 		}
 	}
 
+	if err := app.Pipeline.validate("pipeline"); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := app.Config.validate("config"); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	return multierror.Prefix(result, fmt.Sprintf("app[%s]:", app.Name))
 }
 
@@ -83,19 +211,113 @@ func (app *App) validatorChildren() map[string]internalValidator {
 		"release": app.Release,
 	}
 
-	if app.Build != nil && app.Build.Registry != nil {
-		result["build.registry"] = app.Build.Registry
+	if app.Build != nil {
+		switch len(app.Build.Registries) {
+		case 0:
+		case 1:
+			result["build.registry"] = app.Build.Registries[0]
+		default:
+			for i, r := range app.Build.Registries {
+				result[fmt.Sprintf("build.registry[%d]", i)] = r
+			}
+		}
 	}
 
 	return result
 }
 
+func (c *Build) operation() *Operation {
+	if c == nil {
+		return nil
+	}
+
+	return c.Operation()
+}
+
+func (c *Deploy) operation() *Operation {
+	if c == nil {
+		return nil
+	}
+
+	return c.Operation()
+}
+
+func (c *Registry) operation() *Operation {
+	if c == nil {
+		return nil
+	}
+
+	return c.Operation()
+}
+
+func (c *Release) operation() *Operation {
+	if c == nil {
+		return nil
+	}
+
+	return c.Operation()
+}
+
 func (c *Build) validate(key string) error {
-	return c.Operation().validate(key)
+	if c == nil || c.Matrix == nil {
+		return c.Operation().validate(key)
+	}
+
+	var result error
+	if err := c.Operation().validate(key); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if m := c.Matrix; m != nil {
+		if len(m.Platforms) == 0 {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: matrix: at least one platform is required", key))
+		}
+
+		seen := map[string]struct{}{}
+		for _, p := range m.Platforms {
+			parts := strings.Split(p, "/")
+			if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: matrix: platform %q must be in the form os/arch", key, p))
+			}
+
+			if _, ok := seen[p]; ok {
+				result = multierror.Append(result, fmt.Errorf(
+					"%s: matrix: platform %q is listed more than once", key, p))
+			}
+			seen[p] = struct{}{}
+		}
+	}
+
+	return result
 }
 
 func (c *Deploy) validate(key string) error {
-	return c.Operation().validate(key)
+	if c == nil {
+		return nil
+	}
+
+	var result error
+	if err := c.Operation().validate(key); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if s := c.Signature; s != nil {
+		switch s.Mode {
+		case "", "enforce", "warn":
+		default:
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: signature: mode must be 'enforce' or 'warn'", key))
+		}
+
+		if len(s.TrustedKeys) == 0 {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: signature: at least one trusted key is required", key))
+		}
+	}
+
+	return result
 }
 
 func (c *Registry) validate(key string) error {
@@ -103,7 +325,30 @@ func (c *Registry) validate(key string) error {
 }
 
 func (c *Release) validate(key string) error {
-	return c.Operation().validate(key)
+	if c == nil {
+		return nil
+	}
+
+	var result error
+	if err := c.Operation().validate(key); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if c.RollbackWindow != "" {
+		if _, err := time.ParseDuration(c.RollbackWindow); err != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: rollback_window is not a valid duration: %s", key, err))
+		}
+	}
+
+	for _, f := range c.FeatureFlags {
+		if f.Percent < 0 || f.Percent > 100 {
+			result = multierror.Append(result, fmt.Errorf(
+				"%s: feature_flag[%s]: percent must be between 0 and 100", key, f.Name))
+		}
+	}
+
+	return result
 }
 
 func (c *Operation) validate(key string) error {
@@ -135,9 +380,10 @@ func (h *Hook) validate(key string) error {
 	var result error
 
 	switch h.When {
-	case "before", "after":
+	case "before", "after", "cancel":
 	default:
-		result = multierror.Append(result, fmt.Errorf("label must be 'before' or 'after'"))
+		result = multierror.Append(result, fmt.Errorf(
+			"label must be 'before', 'after', or 'cancel'"))
 	}
 
 	if len(h.Command) == 0 {
@@ -150,6 +396,21 @@ func (h *Hook) validate(key string) error {
 		result = multierror.Append(result, fmt.Errorf("on_failure must be 'continue' or 'fail'"))
 	}
 
+	if h.Condition != "" && strings.HasPrefix(h.Condition, "=") {
+		result = multierror.Append(result, fmt.Errorf("condition must start with an env var name"))
+	}
+
+	if h.Timeout != "" {
+		if d, err := time.ParseDuration(h.Timeout); err != nil || d <= 0 {
+			result = multierror.Append(result, fmt.Errorf(
+				"timeout must be a positive duration such as '30s'"))
+		}
+	}
+
+	if h.Retries < 0 {
+		result = multierror.Append(result, fmt.Errorf("retries must not be negative"))
+	}
+
 	return multierror.Prefix(result, fmt.Sprintf("%s:", key))
 }
 
@@ -0,0 +1,633 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidateWithWarnings(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Source   string
+		Err      bool
+		Warnings int
+	}{
+		{
+			"valid",
+			testValidateValid,
+			false,
+			0,
+		},
+
+		{
+			"release feature flags",
+			testValidateReleaseFlags,
+			false,
+			0,
+		},
+
+		{
+			"empty release",
+			testValidateReleaseEmpty,
+			false,
+			1,
+		},
+
+		{
+			"deprecated plugin checksum",
+			testValidatePluginChecksum,
+			false,
+			1,
+		},
+
+		{
+			"hook retries without timeout",
+			testValidateHookRetries,
+			false,
+			1,
+		},
+
+		{
+			"warning only",
+			testValidateWarning,
+			false,
+			1,
+		},
+
+		{
+			"warning and error",
+			testValidateWarningError,
+			true,
+			1,
+		},
+
+		{
+			"negative parallel",
+			testValidateNegativeParallel,
+			true,
+			0,
+		},
+
+		{
+			"job timeouts",
+			testValidateJobTimeouts,
+			false,
+			0,
+		},
+
+		{
+			"invalid job timeout",
+			testValidateJobTimeoutInvalid,
+			true,
+			0,
+		},
+
+		{
+			"invalid hook timeout",
+			testValidateHookTimeout,
+			true,
+			0,
+		},
+
+		{
+			"pipeline",
+			testValidatePipeline,
+			false,
+			0,
+		},
+
+		{
+			"pipeline release before deploy",
+			testValidatePipelineOrder,
+			true,
+			0,
+		},
+
+		{
+			"config files",
+			testValidateConfigFiles,
+			false,
+			0,
+		},
+
+		{
+			"config file outside app data dir",
+			testValidateConfigFilePath,
+			true,
+			0,
+		},
+
+		{
+			"app dependency cycle",
+			testValidateDependsOnCycle,
+			true,
+			0,
+		},
+
+		{
+			"multiple registries",
+			testValidateMultipleRegistries,
+			false,
+			1,
+		},
+
+		{
+			"build matrix",
+			testValidateBuildMatrix,
+			false,
+			0,
+		},
+
+		{
+			"invalid build matrix",
+			testValidateBuildMatrixInvalid,
+			true,
+			0,
+		},
+
+		{
+			"plugin version",
+			testValidatePluginVersion,
+			false,
+			0,
+		},
+
+		{
+			"invalid plugin version",
+			testValidatePluginVersionInvalid,
+			true,
+			0,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := TestConfig(t, tt.Source)
+			result := cfg.ValidateWithWarnings()
+			require.Equal(tt.Err, result.HasErrors())
+			require.Len(result.Warnings, tt.Warnings)
+
+			// Validate only returns errors
+			if tt.Err {
+				require.Error(cfg.Validate())
+			} else {
+				require.NoError(cfg.Validate())
+			}
+		})
+	}
+}
+
+const testValidateValid = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	release {
+		rollback_window = "5m"
+	}
+}
+`
+
+const testValidateReleaseFlags = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	release {
+		feature_flag "checkout" {
+			percent = 10
+		}
+	}
+}
+`
+
+const testValidateReleaseEmpty = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	release {}
+}
+`
+
+const testValidatePluginChecksum = `
+project = "test"
+
+plugin "docker" {
+	checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+}
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateHookRetries = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+
+		hook {
+			when    = "before"
+			command = ["./migrate"]
+			retries = 2
+		}
+
+		hook {
+			when    = "after"
+			command = ["./smoke-test"]
+			retries = 2
+			timeout = "1m"
+		}
+	}
+}
+`
+
+const testValidateWarning = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+
+		registry {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateWarningError = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	release {
+		labels = { "waypoint/reserved" = "yes" }
+	}
+}
+`
+
+const testValidateNegativeParallel = `
+project = "test"
+parallel = -1
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateJobTimeouts = `
+project = "test"
+job_timeouts = {
+	build  = "30m"
+	deploy = "1h"
+}
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateJobTimeoutInvalid = `
+project = "test"
+job_timeouts = {
+	build = "forever"
+}
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateHookTimeout = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+
+		hook {
+			when    = "before"
+			command = ["make", "test"]
+			timeout = "forever"
+			retries = 2
+		}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidatePipeline = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	pipeline {
+		stage "build" {
+			type = "build"
+		}
+
+		stage "test" {
+			type    = "hook"
+			command = ["make", "test"]
+		}
+
+		stage "staging" {
+			type      = "deploy"
+			workspace = "staging"
+		}
+
+		stage "approve" {
+			type = "approval"
+		}
+
+		stage "deploy" {
+			type      = "deploy"
+			workspace = "production"
+		}
+
+		stage "release" {
+			type      = "release"
+			workspace = "production"
+		}
+	}
+}
+`
+
+const testValidatePipelineOrder = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	pipeline {
+		stage "build" {
+			type = "build"
+		}
+
+		stage "staging" {
+			type      = "deploy"
+			workspace = "staging"
+		}
+
+		stage "release" {
+			type      = "release"
+			workspace = "production"
+		}
+	}
+}
+`
+
+const testValidateConfigFiles = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	config {
+		env = {
+			"PORT" = "8080"
+		}
+
+		file "cert.pem" {
+			contents    = "cert"
+			permissions = "0644"
+		}
+
+		file "tls/key.pem" {
+			contents = "key"
+		}
+	}
+}
+`
+
+const testValidateConfigFilePath = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+
+	config {
+		file "../key.pem" {
+			contents    = "key"
+			permissions = "0999"
+		}
+	}
+}
+`
+
+const testValidateDependsOnCycle = `
+project = "test"
+
+app "frontend" {
+	depends_on = ["backend"]
+
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+
+app "backend" {
+	depends_on = ["frontend"]
+
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidatePluginVersion = `
+project = "test"
+
+plugin "docker" {
+	version = ">= 0.3, < 1.0"
+}
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidatePluginVersionInvalid = `
+project = "test"
+
+plugin "docker" {
+	version = "newest"
+}
+
+app "web" {
+	build {
+		use "docker" {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateMultipleRegistries = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+
+		registry {
+			use "aws-ecr" {}
+		}
+
+		registry {
+			use "docker" {}
+		}
+
+		registry {}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateBuildMatrix = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+
+		matrix {
+			platforms = ["linux/amd64", "linux/arm/v7"]
+		}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
+
+const testValidateBuildMatrixInvalid = `
+project = "test"
+
+app "web" {
+	build {
+		use "docker" {}
+
+		matrix {
+			platforms = ["linux/amd64", "arm64", "linux/amd64"]
+		}
+	}
+
+	deploy {
+		use "docker" {}
+	}
+}
+`
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// VariableEnvPrefix is the prefix of env vars that set input variables.
+// WP_VAR_region sets the "region" variable.
+const VariableEnvPrefix = "WP_VAR_"
+
+// Variable is an input variable of the project. The rest of the
+// configuration references its value as var.<name>.
+type Variable struct {
+	Name        string `hcl:",label"`
+	Description string `hcl:"description,optional"`
+
+	// Type is the type constraint of the variable, such as "string" or
+	// "list(string)". If this isn't set, any type is allowed.
+	Type *hcl.Attribute `hcl:"type,optional"`
+
+	// Default is the value of the variable if it isn't set. A variable
+	// without a default must be set. The default may reference the
+	// workspace, such as `workspace.name == "prod" ? 3 : 1`, so that it
+	// can vary by workspace.
+	Default *hcl.Attribute `hcl:"default,optional"`
+}
+
+// VariableSource is where the value of an input variable was set. The
+// values match those of the Operation.Variable.Source enum of the API.
+type VariableSource int
+
+const (
+	VariableSourceCLI  VariableSource = iota // -var flag
+	VariableSourceFile                       // -var-file flag
+	VariableSourceEnv                        // WP_VAR_ env var
+)
+
+// VariableValue is a value for an input variable that is set outside of
+// the configuration.
+type VariableValue struct {
+	Name   string
+	Value  string
+	Source VariableSource
+}
+
+// LoadVariableFile loads the variable values from the file at path, such
+// as one given with -var-file. The file sets variables as attributes such
+// as `region = "us-east-1"`.
+func LoadVariableFile(path string) ([]*VariableValue, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, diags := hclsyntax.ParseConfig(src, path, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	result := make([]*VariableValue, 0, len(attrs))
+	for name, attr := range attrs {
+		result = append(result, &VariableValue{
+			Name:   name,
+			Value:  string(attr.Expr.Range().SliceBytes(src)),
+			Source: VariableSourceFile,
+		})
+	}
+
+	// Sort so that the values are in a stable order.
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// VariableValuesFromEnv returns the variable values set by WP_VAR_ env vars
+// in environ, which is in the format of os.Environ.
+func VariableValuesFromEnv(environ []string) []*VariableValue {
+	var result []*VariableValue
+	for _, kv := range environ {
+		if !strings.HasPrefix(kv, VariableEnvPrefix) {
+			continue
+		}
+
+		idx := strings.Index(kv, "=")
+		if idx == -1 || idx == len(VariableEnvPrefix) {
+			continue
+		}
+
+		result = append(result, &VariableValue{
+			Name:   kv[len(VariableEnvPrefix):idx],
+			Value:  kv[idx+1:],
+			Source: VariableSourceEnv,
+		})
+	}
+
+	return result
+}
+
+// LoadFile decodes the configuration at path. The variable blocks are
+// decoded first so that the rest of the configuration can reference them
+// as var.<name> using ctx. The workspace should be set in ctx with
+// SetWorkspace if the configuration references it.
+//
+// Each variable starts with its default and is overridden by values in
+// order, so later values take precedence. The final values are available
+// with VariableValues.
+func LoadFile(path string, ctx *hcl.EvalContext, values []*VariableValue) (*Config, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(src, path, ctx, values)
+}
+
+// Load decodes the configuration in src the same as LoadFile. The filename
+// is used for error messages, and src uses the JSON syntax of HCL if it
+// ends in ".json".
+func Load(src []byte, filename string, ctx *hcl.EvalContext, values []*VariableValue) (*Config, error) {
+	parser := hclparse.NewParser()
+	parse := parser.ParseHCL
+	if IsJSON(filename) {
+		parse = parser.ParseJSON
+	}
+
+	file, diags := parse(src, filename)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var vars struct {
+		Variables []*Variable `hcl:"variable,block"`
+		Remain    hcl.Body    `hcl:",remain"`
+	}
+	if diags := gohcl.DecodeBody(file.Body, ctx, &vars); diags.HasErrors() {
+		return nil, diags
+	}
+
+	final, err := variableValues(vars.Variables, values, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Variables == nil {
+		ctx.Variables = map[string]cty.Value{}
+	}
+	ctx.Variables["var"] = cty.ObjectVal(final)
+
+	var cfg Config
+	if diags := gohcl.DecodeBody(file.Body, ctx, &cfg); diags.HasErrors() {
+		return nil, diags
+	}
+	cfg.variables = final
+
+	return &cfg, nil
+}
+
+// VariableValues returns the final values of the input variables as HCL
+// syntax, keyed by variable name. These are recorded on operations so
+// that the values they ran with are known.
+func (c *Config) VariableValues() map[string]string {
+	if len(c.variables) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(c.variables))
+	for k, v := range c.variables {
+		result[k] = string(hclwrite.TokensForValue(v).Bytes())
+	}
+
+	return result
+}
+
+// variableValues returns the final values of the variables. The defaults
+// are evaluated with ctx.
+func variableValues(
+	vars []*Variable,
+	values []*VariableValue,
+	ctx *hcl.EvalContext,
+) (map[string]cty.Value, error) {
+	types := map[string]cty.Type{}
+	result := map[string]cty.Value{}
+	for _, v := range vars {
+		if !hclsyntax.ValidIdentifier(v.Name) {
+			return nil, fmt.Errorf("variable %q: name must be a valid identifier", v.Name)
+		}
+		if _, ok := types[v.Name]; ok {
+			return nil, fmt.Errorf("variable %q: declared more than once", v.Name)
+		}
+
+		ty := cty.DynamicPseudoType
+		if v.Type != nil {
+			var diags hcl.Diagnostics
+			ty, diags = typeexpr.TypeConstraint(v.Type.Expr)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+		}
+		types[v.Name] = ty
+
+		if v.Default == nil {
+			continue
+		}
+
+		val, diags := v.Default.Expr.Value(ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		val, err := convert.Convert(val, ty)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: invalid default: %s", v.Name, err)
+		}
+		result[v.Name] = val
+	}
+
+	for _, v := range values {
+		ty, ok := types[v.Name]
+		if !ok {
+			// Env vars may be set for other projects so we ignore them.
+			if v.Source == VariableSourceEnv {
+				continue
+			}
+
+			return nil, fmt.Errorf("variable %q: not declared in the configuration", v.Name)
+		}
+
+		val, err := parseVariableValue(v, ty)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %s", v.Name, err)
+		}
+		result[v.Name] = val
+	}
+
+	for _, v := range vars {
+		if _, ok := result[v.Name]; !ok {
+			return nil, fmt.Errorf(
+				"variable %q: no value set. Set it with -var, -var-file or %s%s",
+				v.Name, VariableEnvPrefix, v.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// parseVariableValue parses the value of a variable with type ty. Values
+// from files are HCL syntax. Values from flags and env vars are strings
+// unless the variable has a type other than string, in which case they
+// are parsed as HCL such as `["a", "b"]`.
+func parseVariableValue(v *VariableValue, ty cty.Type) (cty.Value, error) {
+	if v.Source != VariableSourceFile &&
+		(ty.Equals(cty.String) || ty.Equals(cty.DynamicPseudoType)) {
+		return cty.StringVal(v.Value), nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(v.Value), v.Name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+
+	return convert.Convert(val, ty)
+}
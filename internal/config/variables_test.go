@@ -0,0 +1,89 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFile_variables(t *testing.T) {
+	path := filepath.Join("testdata", "variables", "waypoint.hcl")
+	fileValues, err := LoadVariableFile(filepath.Join("testdata", "variables", "prod.wpvars"))
+	require.NoError(t, err)
+
+	t.Run("defaults and file", func(t *testing.T) {
+		require := require.New(t)
+
+		cfg, err := LoadFile(path, testVariablesEvalContext("default"), fileValues)
+		require.NoError(err)
+		require.Equal("us-west-2", cfg.Labels["region"])
+		require.Equal(map[string]string{
+			"region":   `"us-west-2"`,
+			"replicas": "1",
+			"zones":    `["a", "b"]`,
+		}, cfg.VariableValues())
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		require := require.New(t)
+
+		// Env vars have the lowest precedence, then files, then flags
+		values := VariableValuesFromEnv([]string{
+			"WP_VAR_region=eu-west-1",
+			"WP_VAR_replicas=2",
+			"WP_VAR_other=ignored",
+			"HOME=/root",
+		})
+		values = append(values, fileValues...)
+		values = append(values, &VariableValue{
+			Name:   "replicas",
+			Value:  "3",
+			Source: VariableSourceCLI,
+		})
+
+		cfg, err := LoadFile(path, testVariablesEvalContext("default"), values)
+		require.NoError(err)
+		require.Equal("us-west-2", cfg.Labels["region"])
+		require.Equal("3", cfg.VariableValues()["replicas"])
+	})
+
+	t.Run("workspace default", func(t *testing.T) {
+		require := require.New(t)
+
+		cfg, err := LoadFile(path, testVariablesEvalContext("prod"), fileValues)
+		require.NoError(err)
+		require.Equal("3", cfg.VariableValues()["replicas"])
+	})
+
+	t.Run("required variable not set", func(t *testing.T) {
+		_, err := LoadFile(path, testVariablesEvalContext("default"), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "zones")
+	})
+
+	t.Run("undeclared variable", func(t *testing.T) {
+		_, err := LoadFile(path, testVariablesEvalContext("default"), append(fileValues, &VariableValue{
+			Name:   "nope",
+			Value:  "x",
+			Source: VariableSourceCLI,
+		}))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := LoadFile(path, testVariablesEvalContext("default"), append(fileValues, &VariableValue{
+			Name:   "replicas",
+			Value:  "many",
+			Source: VariableSourceCLI,
+		}))
+		require.Error(t, err)
+	})
+}
+
+func testVariablesEvalContext(workspace string) *hcl.EvalContext {
+	ctx := EvalContext("testdata")
+	SetWorkspace(ctx, workspace)
+	return ctx
+}
@@ -0,0 +1,60 @@
+// Package configsource reads the values of dynamic config variables from
+// external systems such as Vault or AWS SSM Parameter Store. Entrypoints
+// use this to resolve config variables that have a dynamic source.
+package configsource
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// Sourcer is implemented by config sources.
+type Sourcer interface {
+	// Read reads the current value using the source specific config.
+	Read(ctx context.Context, config map[string]string) (string, error)
+}
+
+// FromString maps the name of a config source to its implementation.
+var FromString = map[string]func() Sourcer{
+	"aws-ssm": newSSMSource,
+	"vault":   newVaultSource,
+}
+
+// Names returns the names of the available config sources, sorted.
+func Names() []string {
+	result := make([]string, 0, len(FromString))
+	for k := range FromString {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Read reads the value of a dynamic config variable.
+func Read(ctx context.Context, v *pb.ConfigVar_DynamicVal) (string, error) {
+	f, ok := FromString[v.From]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument,
+			"unknown config source %q", v.From)
+	}
+
+	return f().Read(ctx, v.Config)
+}
+
+// requireConfig returns an error if any of the keys aren't set in config.
+func requireConfig(source string, config map[string]string, keys ...string) error {
+	for _, k := range keys {
+		if config[k] == "" {
+			return status.Errorf(codes.InvalidArgument,
+				"config source %q requires %q to be set", source, k)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package configsource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// dynamicFuncName is the function that config values call to read the
+// value from a config source.
+const dynamicFuncName = "configdynamic"
+
+// ParseDynamic parses a config value that reads from a config source, such
+// as `configdynamic("vault", {path = "secret/data/app", key = "password"})`.
+// This returns nil if the value doesn't call configdynamic since it is a
+// static value then.
+func ParseDynamic(v string) (*pb.ConfigVar_DynamicVal, error) {
+	if !strings.HasPrefix(strings.TrimSpace(v), dynamicFuncName+"(") {
+		return nil, nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(v), "<value>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	// Anything other than a single call, such as string concatenation,
+	// can't be evaluated until the entrypoint reads the value.
+	if _, ok := expr.(*hclsyntax.FunctionCallExpr); !ok {
+		return nil, fmt.Errorf("%s must be the only part of the value", dynamicFuncName)
+	}
+
+	var result pb.ConfigVar_DynamicVal
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			dynamicFuncName: function.New(&function.Spec{
+				Params: []function.Parameter{
+					{Name: "from", Type: cty.String},
+					{Name: "config", Type: cty.Map(cty.String)},
+				},
+				Type: function.StaticReturnType(cty.String),
+				Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+					result.From = args[0].AsString()
+					result.Config = map[string]string{}
+					for k, v := range args[1].AsValueMap() {
+						if v.IsNull() {
+							return cty.NilVal, fmt.Errorf("config %q must not be null", k)
+						}
+
+						result.Config[k] = v.AsString()
+					}
+
+					return cty.StringVal(""), nil
+				},
+			}),
+		},
+	}
+	if _, diags := expr.Value(ctx); diags.HasErrors() {
+		return nil, diags
+	}
+
+	if _, ok := FromString[result.From]; !ok {
+		return nil, fmt.Errorf("unknown config source %q, must be one of: %s",
+			result.From, strings.Join(Names(), ", "))
+	}
+
+	return &result, nil
+}
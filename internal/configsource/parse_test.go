@@ -0,0 +1,78 @@
+package configsource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestParseDynamic(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected *pb.ConfigVar_DynamicVal
+		Err      string
+	}{
+		{
+			"static value",
+			"hello",
+			nil,
+			"",
+		},
+
+		{
+			"vault",
+			`configdynamic("vault", {path = "secret/data/app", key = "password"})`,
+			&pb.ConfigVar_DynamicVal{
+				From:   "vault",
+				Config: map[string]string{"path": "secret/data/app", "key": "password"},
+			},
+			"",
+		},
+
+		{
+			"unknown source",
+			`configdynamic("nope", {})`,
+			nil,
+			"unknown config source",
+		},
+
+		{
+			"not only the call",
+			`configdynamic("vault", {}) + "x"`,
+			nil,
+			"only part",
+		},
+
+		{
+			"invalid syntax",
+			`configdynamic("vault"`,
+			nil,
+			"<value>",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			actual, err := ParseDynamic(tt.Input)
+			if tt.Err != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Err)
+				return
+			}
+
+			require.NoError(err)
+			if tt.Expected == nil {
+				require.Nil(actual)
+				return
+			}
+
+			require.Equal(tt.Expected.From, actual.From)
+			require.Equal(tt.Expected.Config, actual.Config)
+		})
+	}
+}
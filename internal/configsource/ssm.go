@@ -0,0 +1,52 @@
+package configsource
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// ssmSource reads a parameter from AWS SSM Parameter Store. SecureString
+// parameters are decrypted.
+//
+// Config:
+//
+//	path (required): name of the parameter, such as "/myapp/db-password"
+//	region: AWS region. Defaults to the region of the environment.
+//
+// Credentials come from the environment, such as the task or instance
+// role, in the same way as other AWS SDK clients.
+type ssmSource struct{}
+
+func newSSMSource() Sourcer { return &ssmSource{} }
+
+func (s *ssmSource) Read(ctx context.Context, config map[string]string) (string, error) {
+	if err := requireConfig("aws-ssm", config, "path"); err != nil {
+		return "", err
+	}
+
+	awsConfig := aws.NewConfig()
+	if v := config["region"]; v != "" {
+		awsConfig = awsConfig.WithRegion(v)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := ssm.New(sess).GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(config["path"]),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.Parameter.Value), nil
+}
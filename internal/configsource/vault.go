@@ -0,0 +1,117 @@
+package configsource
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// vaultMaxResponse is the largest secret response that is read.
+const vaultMaxResponse = 1024 * 1024
+
+// vaultSource reads a key of a Vault secret. Both versions of the KV
+// secrets engine are supported.
+//
+// Config:
+//
+//	path (required): path of the secret, such as "secret/data/myapp"
+//	key (required): key of the secret's data to use as the value
+//	addr: address of Vault. Defaults to VAULT_ADDR.
+//	namespace: Vault Enterprise namespace. Defaults to VAULT_NAMESPACE.
+//
+// The token is always read from VAULT_TOKEN so that it isn't stored on
+// the server.
+type vaultSource struct{}
+
+func newVaultSource() Sourcer { return &vaultSource{} }
+
+func (s *vaultSource) Read(ctx context.Context, config map[string]string) (string, error) {
+	if err := requireConfig("vault", config, "path", "key"); err != nil {
+		return "", err
+	}
+
+	addr := config["addr"]
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	if addr == "" {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"vault address must be set with the addr config or VAULT_ADDR")
+	}
+
+	u := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(config["path"], "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	namespace := config["namespace"]
+	if namespace == "" {
+		namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "error reading from vault: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, vaultMaxResponse))
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "error reading from vault: %s", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", status.Errorf(codes.NotFound, "vault secret %q not found", config["path"])
+	default:
+		return "", status.Errorf(codes.Unavailable,
+			"vault returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", status.Errorf(codes.Internal, "invalid vault response: %s", err)
+	}
+
+	// KV version 2 nests the secret's data with its metadata.
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	v, ok := data[config["key"]]
+	if !ok {
+		return "", status.Errorf(codes.NotFound,
+			"vault secret %q has no key %q", config["path"], config["key"])
+	}
+
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+
+	// Other types are used as their JSON encoding, which is the same as
+	// the value for numbers and bools.
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
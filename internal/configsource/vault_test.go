@@ -0,0 +1,81 @@
+package configsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVaultSource(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "t" {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/app":
+			w.Write([]byte(`{"data": {"data": {"password": "v2", "port": 5432}, "metadata": {}}}`))
+
+		case "/v1/kv/app":
+			w.Write([]byte(`{"data": {"password": "v1"}}`))
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	defer os.Setenv("VAULT_TOKEN", os.Getenv("VAULT_TOKEN"))
+	require.NoError(t, os.Setenv("VAULT_TOKEN", "t"))
+
+	read := func(path, key string) (string, error) {
+		return newVaultSource().Read(ctx, map[string]string{
+			"addr": srv.URL,
+			"path": path,
+			"key":  key,
+		})
+	}
+
+	t.Run("kv v2", func(t *testing.T) {
+		v, err := read("secret/data/app", "password")
+		require.NoError(t, err)
+		require.Equal(t, "v2", v)
+
+		v, err = read("secret/data/app", "port")
+		require.NoError(t, err)
+		require.Equal(t, "5432", v)
+	})
+
+	t.Run("kv v1", func(t *testing.T) {
+		v, err := read("kv/app", "password")
+		require.NoError(t, err)
+		require.Equal(t, "v1", v)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := read("kv/app", "nope")
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := read("kv/nope", "password")
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("missing config", func(t *testing.T) {
+		_, err := newVaultSource().Read(ctx, map[string]string{"path": "kv/app"})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -6,10 +6,14 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -20,6 +24,7 @@ import (
 	"github.com/hashicorp/waypoint/internal/factory"
 	"github.com/hashicorp/waypoint/internal/plugin"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 // App represents a single application and exposes all the operations
@@ -34,6 +39,10 @@ type App struct {
 	Platform component.Platform
 	Releaser component.ReleaseManager
 
+	// Registries are all the registries that artifacts are pushed to.
+	// Registry is the first of these.
+	Registries []component.Registry
+
 	// UI is the UI that should be used for any output that is specific
 	// to this app vs the project UI.
 	UI terminal.UI
@@ -50,6 +59,9 @@ type App struct {
 	mappers    []*argmapper.Func
 	components map[interface{}]*appComponent
 	closers    []func() error
+
+	// now returns the current time and is overridden in tests.
+	now func() time.Time
 }
 
 type appComponent struct {
@@ -66,6 +78,10 @@ type appComponent struct {
 
 	// Hooks are the hooks associated with this component keyed by their When value
 	Hooks map[string][]*config.Hook
+
+	// Config is the evaluated configuration of the component keyed by
+	// attribute path. See componentConfig.
+	Config map[string]string
 }
 
 // newApp creates an App for the given project and configuration. This will
@@ -92,14 +108,14 @@ func newApp(
 		},
 		workspace: p.WorkspaceRef(),
 		config:    cfg,
+		now:       time.Now,
 
 		// very important below that we allocate a new slice since we modify
 		mappers: append([]*argmapper.Func{}, p.mappers...),
 
-		// set the UI, which for now is identical to project but in the
-		// future should probably change as we do app-scoping, parallelization,
-		// etc.
-		UI: p.UI,
+		// the UI is scoped to this app so that output from multiple apps
+		// can be told apart and safely written in parallel.
+		UI: newAppUI(p.UI, &p.uiLock, cfg.Name, p.appPrefix),
 	}
 
 	// Determine our path
@@ -116,6 +132,15 @@ func newApp(
 	}
 	app.dir = dir
 
+	// Plugin configuration can reference the outputs of the apps this
+	// app depends on.
+	if len(cfg.DependsOn) > 0 {
+		evalContext, err = app.dependencyEvalContext(ctx, evalContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Load all the components
 	components := []struct {
 		Target interface{}
@@ -123,10 +148,12 @@ func newApp(
 		Config *config.Operation
 	}{
 		{&app.Builder, component.BuilderType, cfg.Build.Operation()},
-		{&app.Registry, component.RegistryType, cfg.Build.RegistryOperation()},
 		{&app.Platform, component.PlatformType, cfg.Deploy.Operation()},
 		{&app.Releaser, component.ReleaseManagerType, cfg.Release.Operation()},
 	}
+	// We load every component even if one fails so that all their
+	// configuration errors are reported at once.
+	var componentErr error
 	for _, c := range components {
 		if c.Config == nil || c.Config.Use == nil {
 			// This component is not set, ignore.
@@ -135,10 +162,35 @@ func newApp(
 
 		err = app.initComponent(ctx, evalContext, c.Type, c.Target, p.factories[c.Type], c.Config, c.Config.Labels)
 		if err != nil {
-			return nil, err
+			componentErr = multierror.Append(componentErr, fmt.Errorf(
+				"%s %q: %s", strings.ToLower(c.Type.String()), c.Config.Use.Type, err))
 		}
 	}
 
+	// Load the registries. There can be any number of these.
+	for _, op := range cfg.Build.RegistryOperations() {
+		if op == nil || op.Use == nil {
+			continue
+		}
+
+		var r component.Registry
+		f := p.factories[component.RegistryType]
+		if err := app.initComponent(ctx, evalContext, component.RegistryType, &r, f, op, op.Labels); err != nil {
+			componentErr = multierror.Append(componentErr, fmt.Errorf(
+				"registry %q: %s", op.Use.Type, err))
+			continue
+		}
+
+		app.Registries = append(app.Registries, r)
+	}
+	if componentErr != nil {
+		app.Close()
+		return nil, componentErr
+	}
+	if len(app.Registries) > 0 {
+		app.Registry = app.Registries[0]
+	}
+
 	// Initialize mappers if we have those
 	if f, ok := p.factories[component.MapperType]; ok {
 		err = app.initMappers(ctx, f)
@@ -256,10 +308,32 @@ func (a *App) callDynamicFunc(
 		return nil, fmt.Errorf("component dir not found for: %T", c)
 	}
 
+	// Every call is a span so that plugin time shows up in the trace of
+	// the operation.
+	ctx, span := telemetry.Tracer().Start(ctx, "callDynamicFunc",
+		trace.WithAttributes(
+			kv.String("app", a.config.Name),
+			kv.String("component.type", componentData.Info.Type.String()),
+			kv.String("component.name", componentData.Info.Name),
+		),
+	)
+	defer span.End()
+
 	// Be sure that the status is closed after every operation so we don't leak
 	// weird output outside the normal execution.
 	defer a.UI.Status().Close()
 
+	// If we have output sinks, those receive all output instead of our UI.
+	// If enabled, label the output of this component so it can be
+	// attributed when multiple components are writing at once.
+	var ui terminal.UI = a.UI
+	if sinks := a.project.outputSinks; len(sinks) > 0 {
+		ui = newSinkUI(sinks)
+	}
+	if a.project.componentPrefix {
+		ui = newPrefixUI(ui, componentData.Info.Name)
+	}
+
 	// Make sure we have access to our context and logger and default args
 	args = append(args,
 		argmapper.ConverterFunc(a.mappers...),
@@ -270,18 +344,22 @@ func (a *App) callDynamicFunc(
 			a.jobInfo,
 			a.dir,
 			componentData.Dir,
-			a.UI,
+			ui,
 		),
 
 		argmapper.Named("labels", &component.LabelSet{Labels: componentData.Labels}),
 	)
 
 	// Build the chain and call it
+	start := time.Now()
 	callResult := rawFunc.Call(args...)
 	if err := callResult.Err(); err != nil {
+		a.traceCall(log, c, start, len(args), nil, err)
+		telemetry.SetError(span, err)
 		return nil, err
 	}
 	raw := callResult.Out(0)
+	a.traceCall(log, c, start, len(args), raw, nil)
 
 	// If we don't have an expected result type, then just return as-is.
 	// Otherwise, we need to verify the result type matches properly.
@@ -394,6 +472,7 @@ func (a *App) initComponent(
 		Dir:    cdir,
 		Hooks:  hooks,
 		Labels: labels,
+		Config: componentConfig(cfg.Use.Body, evalContext),
 	}
 
 	return nil
@@ -3,8 +3,10 @@ package core
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 // CanAuth returns true if the provided component supports authenticating and
@@ -58,3 +60,49 @@ func (a *App) Auth(ctx context.Context, c interface{}) (*component.AuthResult, e
 
 	return authresult, nil
 }
+
+// CredentialStatus is the result of checking the credentials of a
+// single component with CredentialsCheck.
+type CredentialStatus struct {
+	// Component is the component that was checked.
+	Component *pb.Component
+
+	// Supported is true if the component implements component.Authenticator.
+	// Components that don't are always considered valid.
+	Supported bool
+
+	// Err is the error from validating auth. If this is nil then valid
+	// credentials are available.
+	Err error
+}
+
+// Valid returns true if valid credentials are available.
+func (s *CredentialStatus) Valid() bool {
+	return s.Err == nil
+}
+
+// CredentialsCheck checks whether each component of the app has valid
+// credentials available without performing any operation. This can be
+// used to find auth problems before starting a long pipeline. The results
+// are sorted by component type.
+func (a *App) CredentialsCheck(ctx context.Context) ([]CredentialStatus, error) {
+	var result []CredentialStatus
+	for _, c := range a.Components() {
+		info := a.ComponentProto(c)
+		if info == nil {
+			continue
+		}
+
+		result = append(result, CredentialStatus{
+			Component: info,
+			Supported: a.CanAuth(c),
+			Err:       a.ValidateAuth(ctx, c),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Component.Type < result[j].Component.Type
+	})
+
+	return result, nil
+}
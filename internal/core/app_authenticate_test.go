@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
 	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 func TestAppAuthenticate(t *testing.T) {
@@ -79,6 +80,58 @@ func TestAppAuthenticate_noAuth(t *testing.T) {
 	}
 }
 
+func TestAppCredentialsCheck(t *testing.T) {
+	require := require.New(t)
+
+	// Our builder is authenticated
+	builder := struct {
+		*componentmocks.Builder
+		*componentmocks.Authenticator
+	}{
+		&componentmocks.Builder{},
+		&componentmocks.Authenticator{},
+	}
+	builder.Authenticator.On("ValidateAuthFunc").Return(func() error {
+		return nil
+	})
+
+	// Our platform is not
+	platform := struct {
+		*componentmocks.Platform
+		*componentmocks.Authenticator
+	}{
+		&componentmocks.Platform{},
+		&componentmocks.Authenticator{},
+	}
+	platform.Authenticator.On("ValidateAuthFunc").Return(func() error {
+		return errors.New("no credentials")
+	})
+
+	builderFactory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, builderFactory, "test", builder)
+	platformFactory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, platformFactory, "test", platform)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testAuthPlatformConfig)),
+		WithFactory(component.BuilderType, builderFactory),
+		WithFactory(component.PlatformType, platformFactory),
+	), "test")
+
+	result, err := app.CredentialsCheck(context.Background())
+	require.NoError(err)
+	require.Len(result, 2)
+
+	require.Equal(pb.Component_BUILDER, result[0].Component.Type)
+	require.True(result[0].Supported)
+	require.True(result[0].Valid())
+
+	require.Equal(pb.Component_PLATFORM, result[1].Component.Type)
+	require.True(result[1].Supported)
+	require.False(result[1].Valid())
+	require.Contains(result[1].Err.Error(), "no credentials")
+}
+
 const testAuthPlatformConfig = `
 project = "test"
 
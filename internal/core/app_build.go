@@ -5,29 +5,87 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/config"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 // Build builds the artifact from source for this app.
+//
+// The source is fingerprinted first. Unless caching is disabled with
+// BuildWithCache, if a successful build of the same source exists in this
+// workspace then that build and its pushed artifact are returned instead
+// of building again.
+//
+// If the build stanza has a matrix, this builds every platform with
+// BuildMatrix and returns the build of the first platform.
 // TODO(mitchellh): test
 func (a *App) Build(ctx context.Context, optFuncs ...BuildOption) (
 	*pb.Build,
 	*pb.PushedArtifact,
 	error,
 ) {
+	if b := a.config.Build; b != nil && b.Matrix != nil && len(b.Matrix.Platforms) > 0 {
+		result, err := a.BuildMatrix(ctx, b.Matrix.Platforms, optFuncs...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		first := result.Builds[0]
+		return first.Build, first.Push, nil
+	}
+
 	opts, err := newBuildOptions(optFuncs...)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return a.build(ctx, a.logger.Named("build"), opts, &buildOperation{})
+}
+
+// build runs the build operation op, reusing a cached build if allowed,
+// and pushes the result if requested.
+func (a *App) build(
+	ctx context.Context,
+	log hclog.Logger,
+	opts *buildOptions,
+	op *buildOperation,
+) (*pb.Build, *pb.PushedArtifact, error) {
+	// Fingerprint the source so that this build can be reused later. If
+	// the source can't be fingerprinted the build is just never reused.
+	var err error
+	op.Fingerprint, err = sourceFingerprint(a.source.Path)
+	if err != nil {
+		log.Warn("error fingerprinting source, not using build cache", "err", err)
+	}
+
+	if opts.Cache && op.Fingerprint != "" {
+		build, push, err := a.cachedBuild(ctx, op.Fingerprint, op.Platform)
+		if err != nil {
+			return nil, nil, err
+		}
+		if build != nil {
+			log.Info("source unchanged, reusing build", "build", build.Id)
+			a.UI.Output("Source unchanged since build %s, skipping build.",
+				build.Id, terminal.WithInfoStyle())
+			if !opts.Push || push != nil {
+				return build, push, nil
+			}
+
+			// The cached build was never pushed, so push it now.
+			push, err := a.PushBuild(ctx, PushWithBuild(build))
+			return build, push, err
+		}
+	}
+
 	// First we do the build
-	_, msg, err := a.doOperation(ctx, a.logger.Named("build"), &buildOperation{})
+	_, msg, err := a.doOperation(ctx, log, op)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,13 +113,24 @@ func BuildWithPush(v bool) BuildOption {
 	}
 }
 
+// BuildWithCache sets whether a previous build of the same source can be
+// reused. The default is to reuse builds.
+func BuildWithCache(v bool) BuildOption {
+	return func(opts *buildOptions) error {
+		opts.Cache = v
+		return nil
+	}
+}
+
 type buildOptions struct {
-	Push bool
+	Push  bool
+	Cache bool
 }
 
 func defaultBuildOptions() *buildOptions {
 	return &buildOptions{
-		Push: true,
+		Push:  true,
+		Cache: true,
 	}
 }
 
@@ -83,6 +152,15 @@ func (opts *buildOptions) Validate() error {
 // buildOperation implements the operation interface.
 type buildOperation struct {
 	Build *pb.Build
+
+	// Fingerprint, if set, is recorded as a label on the build.
+	Fingerprint string
+
+	// Platform, if set, is given to the builder as the "platform" argument.
+	Platform string
+
+	// ExtraLabels are additional labels to set on the build.
+	ExtraLabels map[string]string
 }
 
 func (op *buildOperation) Init(app *App) (proto.Message, error) {
@@ -111,7 +189,13 @@ func (op *buildOperation) Labels(app *App) map[string]string {
 	if !ok {
 		return nil
 	}
-	return builder.Labels
+	labels := labelsMerge(builder.Labels, op.ExtraLabels)
+	if op.Fingerprint == "" {
+		return labels
+	}
+	return labelsMerge(labels, map[string]string{
+		labelFingerprint: op.Fingerprint,
+	})
 }
 
 func (op *buildOperation) Upsert(
@@ -130,11 +214,17 @@ func (op *buildOperation) Upsert(
 }
 
 func (op *buildOperation) Do(ctx context.Context, log hclog.Logger, app *App, _ proto.Message) (interface{}, error) {
+	var args []argmapper.Arg
+	if op.Platform != "" {
+		args = append(args, argmapper.Named("platform", op.Platform))
+	}
+
 	return app.callDynamicFunc(ctx,
 		log,
 		(*component.Artifact)(nil),
 		app.Builder,
 		app.Builder.BuildFunc(),
+		args...,
 	)
 }
 
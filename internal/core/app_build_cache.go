@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/waypoint/internal/pkg/ignore"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// labelFingerprint is the build label with the fingerprint of the source
+// that was built. See sourceFingerprint.
+const labelFingerprint = "waypoint/fingerprint"
+
+// cachedBuild returns the most recent successful build in this workspace
+// of source with the given fingerprint by the current builder for the
+// given platform, which is empty for builds outside a matrix. If that
+// build was pushed, the artifact is returned too. This returns nil if
+// there is no such build.
+func (a *App) cachedBuild(ctx context.Context, fingerprint, platform string) (*pb.Build, *pb.PushedArtifact, error) {
+	resp, err := a.client.ListBuilds(ctx, &pb.ListBuildsRequest{
+		Application:   a.ref,
+		Workspace:     a.workspace,
+		LabelSelector: labelFingerprint + "=" + fingerprint,
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builderName := a.components[a.Builder].Info.Name
+	var build *pb.Build
+	for _, b := range resp.Builds {
+		if b.Status == nil || b.Status.State != pb.Status_SUCCESS {
+			continue
+		}
+		if b.Labels[labelFingerprint] != fingerprint {
+			continue
+		}
+		if b.Labels[labelPlatform] != platform {
+			continue
+		}
+		if b.Component != nil && b.Component.Name != builderName {
+			continue
+		}
+
+		build = b
+		break
+	}
+	if build == nil {
+		return nil, nil, nil
+	}
+
+	pushResp, err := a.client.ListPushedArtifacts(ctx, &pb.ListPushedArtifactsRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+		Status: []*pb.StatusFilter{{
+			Filters: []*pb.StatusFilter_Filter{{
+				Filter: &pb.StatusFilter_Filter_State{State: pb.Status_SUCCESS},
+			}},
+		}},
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, push := range pushResp.Artifacts {
+		if push.BuildId == build.Id {
+			return build, push, nil
+		}
+	}
+
+	return build, nil, nil
+}
+
+// sourceFingerprint returns a hash of the names and contents of all the
+// files in the source directory at path. The .git and .waypoint
+// directories are skipped, as are paths ignored by the .waypointignore
+// file in path.
+func sourceFingerprint(path string) (string, error) {
+	matcher, err := ignore.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == ".waypoint") {
+			return filepath.SkipDir
+		}
+		if matcher.Match(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.Mode().IsRegular() {
+			files = append(files, rel)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(files)
+	h := sha256.New()
+	for _, rel := range files {
+		// Write the name so that renames change the fingerprint.
+		io.WriteString(h, filepath.ToSlash(rel))
+		h.Write([]byte{0})
+
+		f, err := os.Open(filepath.Join(path, rel))
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
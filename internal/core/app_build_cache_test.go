@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppBuild_cache(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	write := func(name, contents string) {
+		require.NoError(ioutil.WriteFile(filepath.Join(td, name), []byte(contents), 0644))
+	}
+	write("main.go", "package main")
+	write(".waypointignore", "*.log\n")
+
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+		WithFactory(component.BuilderType, factory),
+		WithRootDir(td),
+	), "test")
+
+	calls := 0
+	artifact := &componentmocks.Artifact{}
+	artifact.On("Labels").Return(map[string]string{})
+	mock.On("BuildFunc").Return(func() component.Artifact {
+		calls++
+		return artifact
+	})
+
+	build, push, err := app.Build(ctx)
+	require.NoError(err)
+	require.NotNil(push)
+	require.NotEmpty(build.Labels[labelFingerprint])
+	require.Equal(1, calls)
+
+	// Same source reuses the build and artifact
+	cached, cachedPush, err := app.Build(ctx)
+	require.NoError(err)
+	require.Equal(build.Id, cached.Id)
+	require.Equal(push.Id, cachedPush.Id)
+	require.Equal(1, calls)
+
+	// Ignored files don't change the fingerprint
+	write("debug.log", "hello")
+	cached, _, err = app.Build(ctx)
+	require.NoError(err)
+	require.Equal(build.Id, cached.Id)
+	require.Equal(1, calls)
+
+	// Disabling the cache always builds
+	_, _, err = app.Build(ctx, BuildWithCache(false))
+	require.NoError(err)
+	require.Equal(2, calls)
+
+	// Changed source builds again
+	write("main.go", "package main // changed")
+	changed, _, err := app.Build(ctx)
+	require.NoError(err)
+	require.NotEqual(build.Id, changed.Id)
+	require.NotEqual(build.Labels[labelFingerprint], changed.Labels[labelFingerprint])
+	require.Equal(3, calls)
+}
+
+func TestSourceFingerprint(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	write := func(name, contents string) {
+		path := filepath.Join(td, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	write("a.txt", "a")
+	write(".waypointignore", "# comments are skipped\ntmp/\n")
+
+	initial, err := sourceFingerprint(td)
+	require.NoError(err)
+
+	// Ignored directories and the .git directory don't matter
+	write("tmp/cache", "x")
+	write(".git/HEAD", "ref")
+	v, err := sourceFingerprint(td)
+	require.NoError(err)
+	require.Equal(initial, v)
+
+	// Renaming a file changes the fingerprint
+	require.NoError(os.Rename(filepath.Join(td, "a.txt"), filepath.Join(td, "b.txt")))
+	v, err = sourceFingerprint(td)
+	require.NoError(err)
+	require.NotEqual(initial, v)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// labelPlatform is set on each build of a matrix to its platform.
+	labelPlatform = "waypoint/platform"
+
+	// labelMatrixBuild is set on each build of a matrix to the ID of the
+	// matrix build that it is a part of.
+	labelMatrixBuild = "waypoint/matrix-build"
+)
+
+// MatrixResult is the result of BuildMatrix.
+type MatrixResult struct {
+	// Id is the ID of the matrix build. Every build in the matrix has this
+	// set as the "waypoint/matrix-build" label.
+	Id string
+
+	// Builds has the result for each platform in the order given.
+	Builds []*MatrixBuild
+}
+
+// MatrixBuild is the result of building for a single platform.
+type MatrixBuild struct {
+	Platform string
+	Build    *pb.Build
+	Push     *pb.PushedArtifact
+}
+
+// BuildMatrix builds the app once for each of the given platforms. The
+// builder is given the platform as the "platform" argument and each
+// platform gets its own build record. The records are linked together
+// with the ID of the matrix build.
+//
+// The platforms are built one at a time since builders commonly share
+// local state such as a Docker daemon. If a platform fails to build, the
+// platforms built so far are returned along with the error.
+func (a *App) BuildMatrix(
+	ctx context.Context,
+	platforms []string,
+	optFuncs ...BuildOption,
+) (*MatrixResult, error) {
+	opts, err := newBuildOptions(optFuncs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(platforms) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"at least one platform is required")
+	}
+
+	id, err := server.Id()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MatrixResult{Id: id}
+	for _, platform := range platforms {
+		build, push, err := a.build(ctx, a.logger.Named("build").Named(platform), opts, &buildOperation{
+			Platform: platform,
+			ExtraLabels: map[string]string{
+				labelPlatform:    platform,
+				labelMatrixBuild: id,
+			},
+		})
+		if err != nil {
+			return result, fmt.Errorf("platform %s: %w", platform, err)
+		}
+
+		result.Builds = append(result.Builds, &MatrixBuild{
+			Platform: platform,
+			Build:    build,
+			Push:     push,
+		})
+	}
+
+	return result, nil
+}
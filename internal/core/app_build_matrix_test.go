@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppBuildMatrix(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	require.NoError(ioutil.WriteFile(filepath.Join(td, "main.go"), []byte("package main"), 0644))
+
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildMatrixConfig)),
+		WithFactory(component.BuilderType, factory),
+		WithRootDir(td),
+	), "test")
+
+	calls := 0
+	artifact := &componentmocks.Artifact{}
+	artifact.On("Labels").Return(map[string]string{})
+	mock.On("BuildFunc").Return(func() component.Artifact {
+		calls++
+		return artifact
+	})
+
+	_, err = app.BuildMatrix(ctx, nil)
+	require.Error(err)
+
+	result, err := app.BuildMatrix(ctx, []string{"linux/amd64", "linux/arm64"})
+	require.NoError(err)
+	require.NotEmpty(result.Id)
+	require.Len(result.Builds, 2)
+	require.Equal(2, calls)
+
+	for i, platform := range []string{"linux/amd64", "linux/arm64"} {
+		b := result.Builds[i]
+		require.Equal(platform, b.Platform)
+		require.Equal(platform, b.Build.Labels[labelPlatform])
+		require.Equal(result.Id, b.Build.Labels[labelMatrixBuild])
+		require.Equal(b.Build.Id, b.Push.BuildId)
+		require.Equal(platform, b.Push.Labels[labelPlatform])
+		require.Equal(result.Id, b.Push.Labels[labelMatrixBuild])
+	}
+
+	// Build uses the matrix from the config and reuses the builds of the
+	// unchanged source for every platform.
+	build, push, err := app.Build(ctx)
+	require.NoError(err)
+	require.Equal(2, calls)
+	require.Equal(result.Builds[0].Build.Id, build.Id)
+	require.Equal(result.Builds[0].Push.Id, push.Id)
+
+	// A build outside the matrix doesn't reuse a platform build.
+	build, _, err = app.build(ctx, app.logger, defaultBuildOptions(), &buildOperation{})
+	require.NoError(err)
+	require.Equal(3, calls)
+	require.Empty(build.Labels[labelPlatform])
+}
+
+const testBuildMatrixConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+
+		matrix {
+			platforms = ["linux/amd64", "linux/arm64"]
+		}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
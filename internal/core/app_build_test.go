@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
 	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 func TestAppBuild_happy(t *testing.T) {
@@ -24,6 +25,7 @@ func TestAppBuild_happy(t *testing.T) {
 		WithConfig(config.TestConfig(t, testBuildConfig)),
 		WithFactory(component.BuilderType, factory),
 		WithJobInfo(&component.JobInfo{Id: "hello"}),
+		WithVCS(&pb.Operation_VCS{Commit: "abc123", Branch: "main"}),
 	), "test")
 
 	// Setup our value
@@ -44,6 +46,10 @@ func TestAppBuild_happy(t *testing.T) {
 
 		// Verify we have the ID set
 		require.Equal("hello", build.JobId)
+
+		// Verify we have the VCS info set
+		require.Equal("abc123", build.Vcs.Commit)
+		require.Equal("main", build.Vcs.Branch)
 	}
 }
 
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// componentConfig returns the evaluated attributes of the "use" stanza of
+// a component keyed by their path. Nested blocks are separated by a "."
+// and include their labels. This is recorded on operations so that the
+// configuration of two operations can be compared.
+//
+// Attributes that can't be evaluated are skipped since the component
+// would have failed to configure with them.
+func componentConfig(body hcl.Body, ctx *hcl.EvalContext) map[string]string {
+	result := map[string]string{}
+	componentConfigBody(result, "", body, ctx)
+	return result
+}
+
+func componentConfigBody(
+	result map[string]string,
+	prefix string,
+	body hcl.Body,
+	ctx *hcl.EvalContext,
+) {
+	if body == nil {
+		return
+	}
+
+	// Bodies that aren't native syntax, such as JSON, can't tell blocks
+	// from attributes without a schema so we only record the attributes.
+	sb, ok := body.(*hclsyntax.Body)
+	if !ok {
+		attrs, diags := body.JustAttributes()
+		if diags.HasErrors() {
+			return
+		}
+
+		for name, attr := range attrs {
+			componentConfigAttr(result, prefix+name, attr.Expr, ctx)
+		}
+
+		return
+	}
+
+	for name, attr := range sb.Attributes {
+		componentConfigAttr(result, prefix+name, attr.Expr, ctx)
+	}
+
+	// Blocks of the same type and labels are numbered in order after the
+	// first so that they don't overwrite each other.
+	seen := map[string]int{}
+	for _, block := range sb.Blocks {
+		path := prefix + strings.Join(append([]string{block.Type}, block.Labels...), ".")
+		if n := seen[path]; n > 0 {
+			seen[path]++
+			path += "." + strconv.Itoa(n)
+		} else {
+			seen[path] = 1
+		}
+
+		componentConfigBody(result, path+".", block.Body, ctx)
+	}
+}
+
+func componentConfigAttr(
+	result map[string]string,
+	path string,
+	expr hcl.Expression,
+	ctx *hcl.EvalContext,
+) {
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return
+	}
+
+	result[path] = string(hclwrite.TokensForValue(val).Bytes())
+}
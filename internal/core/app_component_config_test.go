@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestComponentConfig(t *testing.T) {
+	require := require.New(t)
+
+	f, diags := hclsyntax.ParseConfig([]byte(`
+service_port = 8080
+image        = "app:${var.tag}"
+
+static_environment {
+  PORT = "8080"
+}
+
+volume "data" {
+  path = "/data"
+}
+
+volume "data" {
+  path = "/backup"
+}
+`), "test.hcl", hcl.InitialPos)
+	require.False(diags.HasErrors(), diags.Error())
+
+	result := componentConfig(f.Body, &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{
+				"tag": cty.StringVal("v1"),
+			}),
+		},
+	})
+	require.Equal(map[string]string{
+		"service_port":            "8080",
+		"image":                   `"app:v1"`,
+		"static_environment.PORT": `"8080"`,
+		"volume.data.path":        `"/data"`,
+		"volume.data.1.path":      `"/backup"`,
+	}, result)
+
+	// No body
+	require.Empty(componentConfig(nil, nil))
+}
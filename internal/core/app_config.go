@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// syncConfig sets the env vars and files from the config stanza of the
+// app on the server so that deployments get them from their entrypoint.
+// Values that were removed from the stanza are left on the server.
+func (a *App) syncConfig(ctx context.Context) error {
+	vars := AppConfigVars(a.ref, a.config.Config)
+	if len(vars) == 0 {
+		return nil
+	}
+
+	a.logger.Debug("setting config from the config stanza", "count", len(vars))
+	_, err := a.client.SetConfig(ctx, &pb.ConfigSetRequest{Variables: vars})
+	return err
+}
+
+// AppConfigVars returns the config variables for the config stanza of
+// the app with the given ref, sorted by name. This returns nil if the
+// stanza is nil.
+func AppConfigVars(ref *pb.Ref_Application, cfg *config.AppConfig) []*pb.ConfigVar {
+	if cfg == nil {
+		return nil
+	}
+
+	scope := &pb.ConfigVar_Application{Application: ref}
+
+	var result []*pb.ConfigVar
+	for k, v := range cfg.Env {
+		result = append(result, &pb.ConfigVar{
+			Scope: scope,
+			Name:  k,
+			Value: v,
+		})
+	}
+	for _, f := range cfg.File {
+		result = append(result, &pb.ConfigVar{
+			Scope: scope,
+			Name:  f.Path,
+			Value: f.Contents,
+			File: &pb.ConfigVar_FileOptions{
+				Mode: uint32(f.Mode()),
+			},
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// configHashes returns the SHA-256 hashes of the given config variables
+// keyed by name. Dynamic variables hash their source and its configuration
+// since their value is only read by the entrypoint.
+func configHashes(vars []*pb.ConfigVar) map[string]string {
+	result := map[string]string{}
+	for _, v := range vars {
+		h := sha256.New()
+		if d := v.Dynamic; d != nil {
+			keys := make([]string, 0, len(d.Config))
+			for k := range d.Config {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			fmt.Fprintf(h, "dynamic:%s\n", d.From)
+			for _, k := range keys {
+				fmt.Fprintf(h, "%s=%s\n", k, d.Config[k])
+			}
+		} else {
+			io.WriteString(h, v.Value)
+		}
+		if v.File != nil {
+			fmt.Fprintf(h, "\nfile:%o", v.File.Mode)
+		}
+
+		result[v.Name] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppSyncConfig(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testConfigStanza)),
+	), "test")
+	require.NoError(app.syncConfig(ctx))
+
+	resp, err := app.client.GetConfig(ctx, &pb.ConfigGetRequest{
+		Scope: &pb.ConfigGetRequest_Application{Application: app.ref},
+	})
+	require.NoError(err)
+	require.Len(resp.Variables, 2)
+
+	vars := map[string]*pb.ConfigVar{}
+	for _, v := range resp.Variables {
+		vars[v.Name] = v
+	}
+
+	require.Equal("8080", vars["PORT"].Value)
+	require.Nil(vars["PORT"].File)
+	require.Equal("cert", vars["cert.pem"].Value)
+	require.NotNil(vars["cert.pem"].File)
+	require.Equal(uint32(0644), vars["cert.pem"].File.Mode)
+}
+
+const testConfigStanza = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+
+	config {
+		env = {
+			"PORT" = "8080"
+		}
+
+		file "cert.pem" {
+			contents    = "cert"
+			permissions = "0644"
+		}
+	}
+}
+`
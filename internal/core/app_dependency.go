@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// dependencyEvalContext returns an eval context for the app's plugin
+// configuration that has the outputs of the apps it depends on set in
+// the "dep" variable. For example, the URL of the "backend" app is
+// dep.backend.url.
+//
+// The values are from each dependency's active deployment in this
+// workspace when the app is loaded. Apps that haven't been deployed have
+// empty values so that the app can still be loaded.
+func (a *App) dependencyEvalContext(
+	ctx context.Context,
+	parent *hcl.EvalContext,
+) (*hcl.EvalContext, error) {
+	deps := map[string]cty.Value{}
+	for _, name := range a.config.DependsOn {
+		v, err := a.dependencyValue(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("error loading outputs of dependency %q: %w", name, err)
+		}
+
+		deps[name] = v
+	}
+
+	result := parent.NewChild()
+	result.Variables = map[string]cty.Value{
+		"dep": cty.ObjectVal(deps),
+	}
+
+	return result, nil
+}
+
+// dependencyValue returns the outputs of the named app as an object.
+func (a *App) dependencyValue(ctx context.Context, name string) (cty.Value, error) {
+	ref := &pb.Ref_Application{
+		Application: name,
+		Project:     a.ref.Project,
+	}
+
+	d, release, err := a.latestDeployment(ctx, ref)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	var deploymentId, artifactId, deploymentUrl, url string
+	if d != nil {
+		deploymentId = d.Id
+		artifactId = d.ArtifactId
+		if d.Preload != nil && d.Preload.DeployUrl != "" {
+			deploymentUrl = "https://" + d.Preload.DeployUrl
+		}
+	}
+
+	// The URL is the released URL if there is one.
+	url = deploymentUrl
+	if release != nil && release.Url != "" && release.DeploymentId == deploymentId {
+		url = release.Url
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{
+		"url":            cty.StringVal(url),
+		"deployment_url": cty.StringVal(deploymentUrl),
+		"artifact_id":    cty.StringVal(artifactId),
+		"deployment_id":  cty.StringVal(deploymentId),
+	}), nil
+}
+
+// latestDeployment returns the deployment of the given app that is
+// released or, if there is no release, the most recently completed
+// deployment that hasn't been destroyed. The release is returned if there
+// is one. The deployment is nil if there isn't one.
+func (a *App) latestDeployment(
+	ctx context.Context,
+	ref *pb.Ref_Application,
+) (*pb.Deployment, *pb.Release, error) {
+	release, err := a.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: ref,
+		Workspace:   a.workspace,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, nil, err
+	}
+	if release != nil && release.DeploymentId != "" {
+		d, err := a.getDeployment(ctx, release.DeploymentId)
+		return d, release, err
+	}
+
+	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   ref,
+		Workspace:     a.workspace,
+		PhysicalState: pb.Operation_CREATED,
+		Status: []*pb.StatusFilter{{
+			Filters: []*pb.StatusFilter_Filter{{
+				Filter: &pb.StatusFilter_Filter_State{State: pb.Status_SUCCESS},
+			}},
+		}},
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+			Limit: 1,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Deployments) == 0 {
+		return nil, release, nil
+	}
+
+	return resp.Deployments[0], release, nil
+}
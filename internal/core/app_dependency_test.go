@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppDependencyEvalContext(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	platform := &componentmocks.Platform{}
+	platform.On("DeployFunc").Return(func() component.Deployment {
+		return &empty.Empty{}
+	})
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	p := TestProject(t,
+		WithConfig(config.TestConfig(t, testDependencyConfig)),
+		WithFactory(component.PlatformType, factory),
+	)
+
+	// With nothing deployed the outputs are empty
+	frontend := TestApp(t, p, "frontend")
+	evalCtx, err := frontend.dependencyEvalContext(ctx, nil)
+	require.NoError(err)
+	backendVal := evalCtx.Variables["dep"].GetAttr("backend")
+	require.Equal(cty.StringVal(""), backendVal.GetAttr("deployment_id"))
+
+	backend := TestApp(t, p, "backend")
+	push := testPushArtifact(t, backend)
+	d, err := backend.Deploy(ctx, push)
+	require.NoError(err)
+
+	// A project loaded after the deploy sees the outputs
+	p = TestProject(t,
+		WithClient(p.client),
+		WithConfig(config.TestConfig(t, testDependencyConfig)),
+		WithFactory(component.PlatformType, factory),
+	)
+	frontend = TestApp(t, p, "frontend")
+	evalCtx, err = frontend.dependencyEvalContext(ctx, nil)
+	require.NoError(err)
+	backendVal = evalCtx.Variables["dep"].GetAttr("backend")
+	require.Equal(cty.StringVal(d.Id), backendVal.GetAttr("deployment_id"))
+	require.Equal(cty.StringVal(push.Id), backendVal.GetAttr("artifact_id"))
+}
+
+const testDependencyConfig = `
+project = "test"
+
+app "backend" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+
+app "frontend" {
+	depends_on = ["backend"]
+
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
@@ -16,22 +16,61 @@ import (
 )
 
 // Deploy deploys the given artifact.
+//
+// If the deploy configuration enables rollback and the new deployment is
+// unhealthy, the previous successful deployment is deployed again and an
+// Aborted error is returned along with the unhealthy deployment.
 // TODO(mitchellh): test
 func (a *App) Deploy(ctx context.Context, push *pb.PushedArtifact) (*pb.Deployment, error) {
+	d, err := a.deploy(ctx, a.logger.Named("deploy"), &deployOperation{Push: push})
+	if err != nil {
+		return nil, err
+	}
+
+	if a.rollbackEnabled() {
+		if err := a.deployRollback(ctx, d); err != nil {
+			return d, err
+		}
+	}
+
+	return d, nil
+}
+
+// deploy runs the given deploy operation. The deployment config is set
+// on the operation automatically.
+func (a *App) deploy(ctx context.Context, log hclog.Logger, op *deployOperation) (*pb.Deployment, error) {
+	// Verify the artifact signature if we're configured to
+	if err := a.checkArtifactSignature(ctx, op.Push); err != nil {
+		return nil, err
+	}
+
+	// Set the config stanza values on the server for the entrypoint
+	if err := a.syncConfig(ctx); err != nil {
+		return nil, err
+	}
+
+	// Record the config of the app so that deployments can be compared
+	configResp, err := a.client.GetConfig(ctx, &pb.ConfigGetRequest{
+		Scope: &pb.ConfigGetRequest_Application{Application: a.ref},
+	})
+	if err != nil {
+		return nil, err
+	}
+	op.configHashes = configHashes(configResp.Variables)
+
 	// Get the deployment config
 	resp, err := a.client.RunnerGetDeploymentConfig(ctx, &pb.RunnerGetDeploymentConfigRequest{})
 	if err != nil {
 		return nil, err
 	}
 
-	_, msg, err := a.doOperation(ctx, a.logger.Named("deploy"), &deployOperation{
-		Push: push,
-		DeploymentConfig: &component.DeploymentConfig{
-			ServerAddr:          resp.ServerAddr,
-			ServerTls:           resp.ServerTls,
-			ServerTlsSkipVerify: resp.ServerTlsSkipVerify,
-		},
-	})
+	op.DeploymentConfig = &component.DeploymentConfig{
+		ServerAddr:          resp.ServerAddr,
+		ServerTls:           resp.ServerTls,
+		ServerTlsSkipVerify: resp.ServerTlsSkipVerify,
+	}
+
+	_, msg, err := a.doOperation(ctx, log, op)
 	if err != nil {
 		return nil, err
 	}
@@ -43,9 +82,18 @@ type deployOperation struct {
 	Push             *pb.PushedArtifact
 	DeploymentConfig *component.DeploymentConfig
 
+	// Region, if set, is given to the platform as the "region" argument.
+	Region string
+
+	// ExtraLabels are additional labels to set on the deployment.
+	ExtraLabels map[string]string
+
 	// Set by init
 	autoHostname pb.UpsertDeploymentRequest_Tristate
 
+	// configHashes are the hashes of the app config set on the deployment
+	configHashes map[string]string
+
 	// id is populated with the deployment id on Upsert
 	id string
 
@@ -77,6 +125,8 @@ func (op *deployOperation) Init(app *App) (proto.Message, error) {
 		State:       pb.Operation_CREATED,
 		HasEntrypointConfig: op.DeploymentConfig != nil &&
 			op.DeploymentConfig.ServerAddr != "",
+		ConfigHashes: op.configHashes,
+		PluginConfig: app.components[app.Platform].Config,
 	}, nil
 }
 
@@ -93,7 +143,10 @@ func (op *deployOperation) Labels(app *App) map[string]string {
 	if !ok {
 		return nil
 	}
-	return platform.Labels
+	if len(op.ExtraLabels) == 0 {
+		return platform.Labels
+	}
+	return labelsMerge(platform.Labels, op.ExtraLabels)
 }
 
 func (op *deployOperation) Upsert(
@@ -140,13 +193,20 @@ func (op *deployOperation) Do(ctx context.Context, log hclog.Logger, app *App, _
 	dconfig.Id = op.id
 	dconfig.EntrypointInviteToken = op.cebToken
 
+	args := []argmapper.Arg{
+		argNamedAny("artifact", op.Push.Artifact.Artifact),
+		argmapper.Typed(&dconfig),
+	}
+	if op.Region != "" {
+		args = append(args, argmapper.Named("region", op.Region))
+	}
+
 	return app.callDynamicFunc(ctx,
 		log,
 		(*component.Deployment)(nil),
 		app.Platform,
 		app.Platform.DeployFunc(),
-		argNamedAny("artifact", op.Push.Artifact.Artifact),
-		argmapper.Typed(&dconfig),
+		args...,
 	)
 }
 
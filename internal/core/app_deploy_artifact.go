@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// GetArtifactForDeployment returns the artifact that the deployment with
+// the given ID is running. This follows the deployment to its pushed
+// artifact and the build that artifact came from. If any link in that
+// chain no longer exists, a FailedPrecondition error is returned naming
+// the missing record. The deployment must belong to this app and
+// workspace, otherwise it is NotFound.
+func (a *App) GetArtifactForDeployment(ctx context.Context, deploymentId string) (*pb.Artifact, error) {
+	deployment, err := a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: deploymentId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Deployment IDs are global, so don't follow another app's deployment.
+	if deployment.Application.GetProject() != a.ref.Project ||
+		deployment.Application.GetApplication() != a.ref.Application ||
+		deployment.Workspace.GetWorkspace() != a.workspace.Workspace {
+		return nil, status.Errorf(codes.NotFound,
+			"deployment %s not found for app %s in workspace %s",
+			deploymentId, a.ref.Application, a.workspace.Workspace)
+	}
+
+	if deployment.ArtifactId == "" {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"deployment %s has no associated artifact", deploymentId)
+	}
+
+	pushed, err := a.client.GetPushedArtifact(ctx, &pb.GetPushedArtifactRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: deployment.ArtifactId},
+		},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"artifact %s for deployment %s no longer exists, it may have been pruned",
+			deployment.ArtifactId, deploymentId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pushed.BuildId != "" {
+		_, err = a.client.GetBuild(ctx, &pb.GetBuildRequest{
+			Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Id{Id: pushed.BuildId},
+			},
+		})
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"build %s for artifact %s no longer exists, it may have been pruned",
+				pushed.BuildId, pushed.Id)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if pushed.Artifact == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"artifact %s for deployment %s has no artifact value",
+			pushed.Id, deploymentId)
+	}
+
+	return pushed.Artifact, nil
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppGetArtifactForDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+	), "test")
+
+	value, err := ptypes.MarshalAny(&empty.Empty{})
+	require.NoError(t, err)
+
+	buildResp, err := app.client.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+		Build: serverptypes.TestValidBuild(t, &pb.Build{
+			Application: app.ref,
+			Workspace:   app.workspace,
+		}),
+	})
+	require.NoError(t, err)
+
+	artifactResp, err := app.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+		Artifact: serverptypes.TestValidArtifact(t, &pb.PushedArtifact{
+			Application: app.ref,
+			Workspace:   app.workspace,
+			BuildId:     buildResp.Build.Id,
+			Artifact:    &pb.Artifact{Artifact: value},
+		}),
+	})
+	require.NoError(t, err)
+
+	deploy := func(artifactId string) string {
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				ArtifactId:  artifactId,
+			}),
+		})
+		require.NoError(t, err)
+		return resp.Deployment.Id
+	}
+
+	t.Run("valid chain", func(t *testing.T) {
+		require := require.New(t)
+
+		result, err := app.GetArtifactForDeployment(ctx, deploy(artifactResp.Artifact.Id))
+		require.NoError(err)
+		require.NotNil(result)
+		require.Equal(value.TypeUrl, result.Artifact.TypeUrl)
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := app.GetArtifactForDeployment(ctx, deploy("pruned"))
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+		require.Contains(err.Error(), "pruned")
+	})
+
+	t.Run("deployment of another app", func(t *testing.T) {
+		require := require.New(t)
+
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: &pb.Ref_Application{
+					Project:     app.ref.Project,
+					Application: "other",
+				},
+				Workspace:  app.workspace,
+				ArtifactId: artifactResp.Artifact.Id,
+			}),
+		})
+		require.NoError(err)
+
+		_, err = app.GetArtifactForDeployment(ctx, resp.Deployment.Id)
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+}
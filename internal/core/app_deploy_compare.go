@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// DeployCompare compares two deployments of this app, summarizing the
+// differences in their artifacts, recorded configuration and deployment
+// values. This is useful to review a blue/green cutover before releasing.
+// The deployments can be referenced by ID or by sequence number.
+//
+// Both deployments must belong to this app and workspace. Comparing a
+// deployment with itself is an InvalidArgument error since there is
+// nothing to compare.
+func (a *App) DeployCompare(ctx context.Context, refA, refB *pb.Ref_Operation) (*DeploymentDiff, error) {
+	da, err := a.compareDeployment(ctx, refA)
+	if err != nil {
+		return nil, err
+	}
+	db, err := a.compareDeployment(ctx, refB)
+	if err != nil {
+		return nil, err
+	}
+
+	if da.Id == db.Id {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"both references are deployment %s (v%d), there is nothing to compare",
+			da.Id, da.Sequence)
+	}
+
+	return DiffDeployments(da, db), nil
+}
+
+// compareDeployment gets the deployment for DeployCompare with its
+// artifact loaded.
+func (a *App) compareDeployment(ctx context.Context, ref *pb.Ref_Operation) (*pb.Deployment, error) {
+	// Sequence numbers are per app.
+	if seq, ok := ref.GetTarget().(*pb.Ref_Operation_Sequence); ok {
+		ref = &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Sequence{
+				Sequence: &pb.Ref_OperationSeq{
+					Application: a.ref,
+					Number:      seq.Sequence.GetNumber(),
+				},
+			},
+		}
+	}
+
+	d, err := a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref:         ref,
+		LoadDetails: pb.Deployment_ARTIFACT,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Application.GetProject() != a.ref.Project ||
+		d.Application.GetApplication() != a.ref.Application ||
+		d.Workspace.GetWorkspace() != a.workspace.Workspace {
+		return nil, status.Errorf(codes.NotFound,
+			"deployment %s not found for app %s in workspace %s",
+			d.Id, a.ref.Application, a.workspace.Workspace)
+	}
+
+	return d, nil
+}
+
+// LabelDiff is a single differing key between two deployments. An empty
+// value means the key is not set.
+type LabelDiff struct {
+	Key string
+	A   string
+	B   string
+}
+
+func deploymentArtifactLabels(d *pb.Deployment) map[string]string {
+	if d.Preload == nil || d.Preload.Artifact == nil {
+		return nil
+	}
+
+	return d.Preload.Artifact.Labels
+}
+
+// labelsDiff returns the keys that differ between a and b, sorted by key.
+func labelsDiff(a, b map[string]string) []*LabelDiff {
+	keys := map[string]struct{}{}
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+
+	var result []*LabelDiff
+	for k := range keys {
+		if a[k] != b[k] {
+			result = append(result, &LabelDiff{Key: k, A: a[k], B: b[k]})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// DeploymentDiff summarizes the differences between two deployments. This
+// is returned by DiffDeployments.
+type DeploymentDiff struct {
+	// A and B are the deployments that were compared, in the order given.
+	A *pb.Deployment
+	B *pb.Deployment
+
+	// ArtifactLabels, Labels, Config and PluginConfig are the differences
+	// in the pushed artifact labels, the deployment labels, the config
+	// variable hashes and the platform plugin configuration, respectively,
+	// sorted by key. Only keys whose values differ are present.
+	ArtifactLabels []*LabelDiff
+	Labels         []*LabelDiff
+	Config         []*LabelDiff
+	PluginConfig   []*LabelDiff
+}
+
+// ArtifactChanged returns true if the two deployments deployed different
+// artifacts.
+func (d *DeploymentDiff) ArtifactChanged() bool {
+	return d.A.ArtifactId != d.B.ArtifactId
+}
+
+// PluginChanged returns true if the two deployments were deployed by
+// different platform plugins.
+func (d *DeploymentDiff) PluginChanged() bool {
+	return d.A.Component.GetName() != d.B.Component.GetName()
+}
+
+// ValueChanged returns true if the deployment values stored by the
+// platform plugin differ.
+func (d *DeploymentDiff) ValueChanged() bool {
+	return !proto.Equal(d.A.Deployment, d.B.Deployment)
+}
+
+// DiffDeployments compares two deployments, summarizing the differences in
+// what they deployed and how. This is useful to find out what changed
+// between two rollouts.
+//
+// The artifact labels are only compared if the deployments were loaded
+// with the ARTIFACT load details. Deployments created before the config
+// and plugin configuration were recorded have none of either.
+func DiffDeployments(a, b *pb.Deployment) *DeploymentDiff {
+	return &DeploymentDiff{
+		A:              a,
+		B:              b,
+		ArtifactLabels: labelsDiff(deploymentArtifactLabels(a), deploymentArtifactLabels(b)),
+		Labels:         labelsDiff(a.Labels, b.Labels),
+		Config:         labelsDiff(a.ConfigHashes, b.ConfigHashes),
+		PluginConfig:   labelsDiff(a.PluginConfig, b.PluginConfig),
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppDeployCompare(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Make our factory for platforms
+	mock := &componentmocks.Platform{}
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	// Create a deployment of the app running an artifact with a tag
+	deploy := func(ref *pb.Ref_Application, tag, port string) *pb.Deployment {
+		artifactResp, err := app.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+			Artifact: serverptypes.TestValidArtifact(t, &pb.PushedArtifact{
+				Application: ref,
+				Workspace:   app.workspace,
+				Labels:      map[string]string{"tag": tag},
+			}),
+		})
+		require.NoError(err)
+
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application:  ref,
+				Workspace:    app.workspace,
+				State:        pb.Operation_CREATED,
+				ArtifactId:   artifactResp.Artifact.Id,
+				ConfigHashes: map[string]string{"PORT": port},
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment
+	}
+
+	byId := func(d *pb.Deployment) *pb.Ref_Operation {
+		return &pb.Ref_Operation{Target: &pb.Ref_Operation_Id{Id: d.Id}}
+	}
+
+	blue := deploy(app.ref, "v1", "1")
+	green := deploy(app.ref, "v2", "2")
+
+	diff, err := app.DeployCompare(ctx, byId(blue), byId(green))
+	require.NoError(err)
+	require.Equal(blue.Id, diff.A.Id)
+	require.Equal(green.Id, diff.B.Id)
+	require.True(diff.ArtifactChanged())
+	require.Equal([]*LabelDiff{
+		{Key: "tag", A: "v1", B: "v2"},
+	}, diff.ArtifactLabels)
+	require.Equal([]*LabelDiff{
+		{Key: "PORT", A: "1", B: "2"},
+	}, diff.Config)
+
+	// By sequence number
+	diff, err = app.DeployCompare(ctx, byId(blue), &pb.Ref_Operation{
+		Target: &pb.Ref_Operation_Sequence{
+			Sequence: &pb.Ref_OperationSeq{Number: green.Sequence},
+		},
+	})
+	require.NoError(err)
+	require.Equal(green.Id, diff.B.Id)
+
+	// The same deployment twice
+	_, err = app.DeployCompare(ctx, byId(blue), byId(blue))
+	require.Error(err)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// A deployment of another app
+	other := deploy(&pb.Ref_Application{
+		Project:     app.ref.Project,
+		Application: "other",
+	}, "v3", "3")
+	_, err = app.DeployCompare(ctx, byId(blue), byId(other))
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestDiffDeployments(t *testing.T) {
+	require := require.New(t)
+
+	a := &pb.Deployment{
+		Component:    &pb.Component{Name: "docker"},
+		ArtifactId:   "A",
+		Labels:       map[string]string{"region": "us-east-1"},
+		ConfigHashes: map[string]string{"PORT": "1", "DATABASE_URL": "2"},
+		PluginConfig: map[string]string{"service_port": "8080"},
+		Preload: &pb.Deployment_Preload{
+			Artifact: &pb.PushedArtifact{Labels: map[string]string{"tag": "v1"}},
+		},
+	}
+	b := &pb.Deployment{
+		Component:    &pb.Component{Name: "docker"},
+		ArtifactId:   "B",
+		Labels:       map[string]string{"region": "us-east-1"},
+		ConfigHashes: map[string]string{"PORT": "1", "DATABASE_URL": "3", "DEBUG": "4"},
+		PluginConfig: map[string]string{"service_port": "3000"},
+		Preload: &pb.Deployment_Preload{
+			Artifact: &pb.PushedArtifact{Labels: map[string]string{"tag": "v2"}},
+		},
+	}
+
+	diff := DiffDeployments(a, b)
+	require.True(diff.ArtifactChanged())
+	require.False(diff.PluginChanged())
+	require.False(diff.ValueChanged())
+	require.Empty(diff.Labels)
+	require.Equal([]*LabelDiff{
+		{Key: "tag", A: "v1", B: "v2"},
+	}, diff.ArtifactLabels)
+	require.Equal([]*LabelDiff{
+		{Key: "DATABASE_URL", A: "2", B: "3"},
+		{Key: "DEBUG", A: "", B: "4"},
+	}, diff.Config)
+	require.Equal([]*LabelDiff{
+		{Key: "service_port", A: "8080", B: "3000"},
+	}, diff.PluginConfig)
+
+	// Same deployment
+	diff = DiffDeployments(a, a)
+	require.False(diff.ArtifactChanged())
+	require.Empty(diff.Config)
+	require.Empty(diff.PluginConfig)
+}
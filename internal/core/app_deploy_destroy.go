@@ -35,7 +35,8 @@ func (a *App) DestroyDeploy(ctx context.Context, d *pb.Deployment) error {
 	return err
 }
 
-// destroyAllDeploys will destroy all non-destroyed releases.
+// destroyAllDeploys will destroy all non-destroyed deployments that
+// aren't pinned.
 func (a *App) destroyAllDeploys(ctx context.Context) error {
 	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
 		Application:   a.ref,
@@ -59,6 +60,12 @@ func (a *App) destroyAllDeploys(ctx context.Context) error {
 
 	a.UI.Output("Destroying deployments...", terminal.WithHeaderStyle())
 	for _, v := range results {
+		if DeploymentPinned(v) {
+			a.UI.Output("Skipping pinned deployment %s: %s",
+				v.Id, v.Labels[labelPinned], terminal.WithInfoStyle())
+			continue
+		}
+
 		err := a.DestroyDeploy(ctx, v)
 		if err != nil {
 			return err
@@ -70,10 +77,27 @@ func (a *App) destroyAllDeploys(ctx context.Context) error {
 
 // destroyDeployWorkspace will call the DestroyWorkspace hook if there
 // are any valid operations. This expects all operations of this type to
-// already be destroyed and will error if they are not.
+// already be destroyed and will error if they are not. If pinned
+// deployments remain, the hook isn't called since they may still use
+// the shared resources.
 func (a *App) destroyDeployWorkspace(ctx context.Context) error {
 	log := a.logger
 
+	remaining, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   a.ref,
+		Workspace:     a.workspace,
+		PhysicalState: pb.Operation_CREATED,
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range remaining.Deployments {
+		if DeploymentPinned(d) {
+			log.Info("pinned deployments remain, not destroying workspace resources")
+			return nil
+		}
+	}
+
 	// Get the last destroyed value.
 	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
 		Application:   a.ref,
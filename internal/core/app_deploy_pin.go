@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"os/user"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// labelPinned is the deployment label that marks it as pinned. The
+	// value is the reason it was pinned.
+	labelPinned = "waypoint/pinned"
+
+	// labelPinnedBy is the deployment label with the user that pinned it.
+	labelPinnedBy = "waypoint/pinned-by"
+)
+
+// DeploymentPinned returns true if the deployment is pinned. Pinned
+// deployments are never destroyed by Destroy, GC or release pruning.
+func DeploymentPinned(d *pb.Deployment) bool {
+	_, ok := d.Labels[labelPinned]
+	return ok
+}
+
+// PinDeployment marks the deployment with the given ID as pinned so that
+// it is skipped by Destroy and GC until UnpinDeployment is called. This is
+// used to protect a critical deployment from accidental teardown. The
+// reason and the current user are recorded on the deployment.
+func (a *App) PinDeployment(ctx context.Context, id string, reason string) error {
+	if reason == "" {
+		return status.Errorf(codes.InvalidArgument, "a reason is required to pin a deployment")
+	}
+
+	d, err := a.getDeployment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if d.Labels == nil {
+		d.Labels = map[string]string{}
+	}
+	d.Labels[labelPinned] = reason
+	d.Labels[labelPinnedBy] = pinActor()
+
+	_, err = a.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: d,
+	})
+	return err
+}
+
+// UnpinDeployment removes the pin from the deployment with the given ID.
+// This does nothing if the deployment isn't pinned.
+func (a *App) UnpinDeployment(ctx context.Context, id string) error {
+	d, err := a.getDeployment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !DeploymentPinned(d) {
+		return nil
+	}
+
+	delete(d.Labels, labelPinned)
+	delete(d.Labels, labelPinnedBy)
+
+	_, err = a.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: d,
+	})
+	return err
+}
+
+// GC destroys old deployments of the app's current platform, keeping the
+// keep most recently completed deployments. The released deployment and
+// pinned deployments are never destroyed. This returns the deployments
+// that were destroyed.
+func (a *App) GC(ctx context.Context, keep int) ([]*pb.Deployment, error) {
+	if a.Platform == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"no deployment plugin is configured")
+	}
+
+	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   a.ref,
+		Workspace:     a.workspace,
+		PhysicalState: pb.Operation_CREATED,
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Deployments) <= keep {
+		return nil, nil
+	}
+
+	// We never destroy the deployment that is currently released.
+	var releasedId string
+	release, err := a.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	if release != nil {
+		releasedId = release.DeploymentId
+	}
+
+	platformName := a.components[a.Platform].Info.Name
+	var result []*pb.Deployment
+	for _, d := range resp.Deployments[keep:] {
+		if d.Id == releasedId {
+			continue
+		}
+		if d.Component != nil && d.Component.Name != platformName {
+			continue
+		}
+		if DeploymentPinned(d) {
+			a.UI.Output("Skipping pinned deployment %s: %s",
+				d.Id, d.Labels[labelPinned], terminal.WithInfoStyle())
+			continue
+		}
+
+		if err := a.DestroyDeploy(ctx, d); err != nil {
+			return result, err
+		}
+
+		result = append(result, d)
+	}
+
+	return result, nil
+}
+
+func (a *App) getDeployment(ctx context.Context, id string) (*pb.Deployment, error) {
+	return a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: id},
+		},
+	})
+}
+
+// pinActor returns the name of the user pinning a deployment.
+func pinActor() string {
+	u, err := user.Current()
+	if err != nil || u.Username == "" {
+		return "unknown"
+	}
+
+	return u.Username
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppPinDeployment(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mock := struct {
+		*componentmocks.Platform
+		*componentmocks.Destroyer
+	}{
+		&componentmocks.Platform{},
+		&componentmocks.Destroyer{},
+	}
+	mock.Destroyer.On("DestroyFunc").Return(func() error { return nil })
+
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	deploy := func() string {
+		value, err := ptypes.MarshalAny(&empty.Empty{})
+		require.NoError(err)
+
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				State:       pb.Operation_CREATED,
+				Component: &pb.Component{
+					Type: pb.Component_PLATFORM,
+					Name: "test",
+				},
+				Deployment: value,
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment.Id
+	}
+
+	state := func(id string) pb.Operation_PhysicalState {
+		d, err := app.getDeployment(ctx, id)
+		require.NoError(err)
+		return d.State
+	}
+
+	pinned := deploy()
+	other := deploy()
+
+	// A reason is required
+	err := app.PinDeployment(ctx, pinned, "")
+	require.Error(err)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	require.NoError(app.PinDeployment(ctx, pinned, "production traffic"))
+	d, err := app.getDeployment(ctx, pinned)
+	require.NoError(err)
+	require.True(DeploymentPinned(d))
+	require.Equal("production traffic", d.Labels[labelPinned])
+	require.NotEmpty(d.Labels[labelPinnedBy])
+
+	// GC skips the pinned deployment
+	destroyed, err := app.GC(ctx, 0)
+	require.NoError(err)
+	require.Len(destroyed, 1)
+	require.Equal(other, destroyed[0].Id)
+	require.Equal(pb.Operation_CREATED, state(pinned))
+	require.Equal(pb.Operation_DESTROYED, state(other))
+
+	// Destroy all skips the pinned deployment
+	other = deploy()
+	require.NoError(app.Destroy(ctx))
+	require.Equal(pb.Operation_CREATED, state(pinned))
+	require.Equal(pb.Operation_DESTROYED, state(other))
+
+	// Once unpinned it is destroyed as usual
+	require.NoError(app.UnpinDeployment(ctx, pinned))
+	destroyed, err = app.GC(ctx, 0)
+	require.NoError(err)
+	require.Len(destroyed, 1)
+	require.Equal(pb.Operation_DESTROYED, state(pinned))
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// labelRegion is set on each regional deployment to its region.
+	labelRegion = "waypoint/region"
+
+	// labelParentDeployment is set on each regional deployment to the ID
+	// of the logical deployment that it is a part of.
+	labelParentDeployment = "waypoint/parent-deployment"
+)
+
+// RegionPolicy determines how DeployRegions handles a deploy failing in
+// some regions but not others.
+type RegionPolicy uint8
+
+const (
+	// RegionFailAll fails the deploy if any region fails.
+	RegionFailAll RegionPolicy = iota
+
+	// RegionBestEffort only fails the deploy if every region fails.
+	RegionBestEffort
+
+	// RegionQuorum fails the deploy unless a majority of regions succeed.
+	RegionQuorum
+)
+
+// RegionsResult is the result of DeployRegions.
+type RegionsResult struct {
+	// ParentId is the ID of the logical deployment. Every regional
+	// deployment has this set as the "waypoint/parent-deployment" label.
+	ParentId string
+
+	// Regions has the result for each region in the order given.
+	Regions []*RegionDeployment
+}
+
+// RegionDeployment is the result of deploying to a single region.
+type RegionDeployment struct {
+	Region     string
+	Deployment *pb.Deployment
+	Err        error
+}
+
+// Succeeded returns the number of regions that deployed successfully.
+func (r *RegionsResult) Succeeded() int {
+	count := 0
+	for _, rd := range r.Regions {
+		if rd.Err == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// DeployRegions deploys the latest pushed artifact to each of the given
+// regions in parallel. The platform is given the region as the "region"
+// argument and each region gets its own deployment record. The records
+// are linked together with a parent logical deployment ID.
+//
+// The result is always returned if the deploys were attempted, even if
+// an error is returned due to the failure policy.
+func (a *App) DeployRegions(
+	ctx context.Context,
+	regions []string,
+	optFuncs ...DeployRegionsOption,
+) (*RegionsResult, error) {
+	opts, err := newDeployRegionsOptions(optFuncs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"at least one region is required")
+	}
+
+	push, err := a.client.GetLatestPushedArtifact(ctx, &pb.GetLatestPushedArtifactRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parentId, err := server.Id()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RegionsResult{
+		ParentId: parentId,
+		Regions:  make([]*RegionDeployment, len(regions)),
+	}
+
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		i, region := i, region
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			d, err := a.deploy(ctx, a.logger.Named("deploy").Named(region), &deployOperation{
+				Push:   push,
+				Region: region,
+				ExtraLabels: map[string]string{
+					labelRegion:           region,
+					labelParentDeployment: parentId,
+				},
+			})
+			result.Regions[i] = &RegionDeployment{
+				Region:     region,
+				Deployment: d,
+				Err:        err,
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, opts.Policy.check(result)
+}
+
+// check returns an error if the result doesn't satisfy the policy.
+func (p RegionPolicy) check(result *RegionsResult) error {
+	var errs error
+	for _, rd := range result.Regions {
+		if rd.Err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("region %s: %w", rd.Region, rd.Err))
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+
+	ok, total := result.Succeeded(), len(result.Regions)
+	switch p {
+	case RegionBestEffort:
+		if ok > 0 {
+			return nil
+		}
+
+	case RegionQuorum:
+		if ok > total/2 {
+			return nil
+		}
+	}
+
+	return errs
+}
+
+// DeployRegionsOption is used to configure DeployRegions.
+type DeployRegionsOption func(*deployRegionsOptions) error
+
+// DeployRegionsPolicy sets the policy for partial failures. The default
+// is RegionFailAll.
+func DeployRegionsPolicy(p RegionPolicy) DeployRegionsOption {
+	return func(opts *deployRegionsOptions) error {
+		opts.Policy = p
+		return nil
+	}
+}
+
+type deployRegionsOptions struct {
+	Policy RegionPolicy
+}
+
+func newDeployRegionsOptions(opts ...DeployRegionsOption) (*deployRegionsOptions, error) {
+	def := &deployRegionsOptions{Policy: RegionFailAll}
+	for _, f := range opts {
+		if err := f(def); err != nil {
+			return nil, err
+		}
+	}
+
+	return def, nil
+}
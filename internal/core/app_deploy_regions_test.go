@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-argmapper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppDeployRegions(t *testing.T) {
+	ctx := context.Background()
+	regions := []string{"us-east", "us-west", "eu-central"}
+
+	cases := []struct {
+		Name   string
+		Policy RegionPolicy
+		Err    bool
+	}{
+		{"fail-all", RegionFailAll, true},
+		{"best-effort", RegionBestEffort, false},
+		{"quorum", RegionQuorum, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Our platform fails in one region
+			mock := &componentmocks.Platform{}
+			mock.On("DeployFunc").Return(func(args struct {
+				argmapper.Struct
+
+				Region string `argmapper:"region"`
+			}) (component.Deployment, error) {
+				if args.Region == "eu-central" {
+					return nil, fmt.Errorf("region unavailable")
+				}
+
+				return &empty.Empty{}, nil
+			})
+
+			factory := TestFactory(t, component.PlatformType)
+			TestFactoryRegister(t, factory, "test", mock)
+
+			app := TestApp(t, TestProject(t,
+				WithConfig(config.TestConfig(t, testPlatformConfig)),
+				WithFactory(component.PlatformType, factory),
+			), "test")
+			testPushArtifact(t, app)
+
+			result, err := app.DeployRegions(ctx, regions, DeployRegionsPolicy(tt.Policy))
+			require.Equal(tt.Err, err != nil)
+			require.NotNil(result)
+			require.NotEmpty(result.ParentId)
+			require.Equal(2, result.Succeeded())
+
+			for i, rd := range result.Regions {
+				require.Equal(regions[i], rd.Region)
+				if rd.Region == "eu-central" {
+					require.Error(rd.Err)
+					continue
+				}
+
+				require.NoError(rd.Err)
+				require.Equal(rd.Region, rd.Deployment.Labels[labelRegion])
+				require.Equal(result.ParentId, rd.Deployment.Labels[labelParentDeployment])
+			}
+		})
+	}
+
+	t.Run("quorum not met", func(t *testing.T) {
+		require := require.New(t)
+
+		result := &RegionsResult{Regions: []*RegionDeployment{
+			{Region: "a"},
+			{Region: "b", Err: fmt.Errorf("failed")},
+			{Region: "c", Err: fmt.Errorf("failed")},
+		}}
+		require.Error(RegionQuorum.check(result))
+		require.NoError(RegionBestEffort.check(result))
+	})
+}
+
+// testPushArtifact creates a build and pushed artifact for the app.
+func testPushArtifact(t *testing.T, app *App) *pb.PushedArtifact {
+	ctx := context.Background()
+
+	value, err := ptypes.MarshalAny(&empty.Empty{})
+	require.NoError(t, err)
+
+	build, err := app.client.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+		Build: serverptypes.TestValidBuild(t, &pb.Build{
+			Application: app.ref,
+			Workspace:   app.workspace,
+		}),
+	})
+	require.NoError(t, err)
+
+	resp, err := app.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+		Artifact: serverptypes.TestValidArtifact(t, &pb.PushedArtifact{
+			Application: app.ref,
+			Workspace:   app.workspace,
+			BuildId:     build.Build.Id,
+			Artifact:    &pb.Artifact{Artifact: value},
+		}),
+	})
+	require.NoError(t, err)
+	return resp.Artifact
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// Health is the health of a deployment from its latest health report.
+type Health struct {
+	// Healthy is true if the deployment is working as expected.
+	Healthy bool
+
+	// Message is an optional explanation, such as why a deployment is
+	// unhealthy.
+	Message string
+}
+
+// HealthCheck checks the health of the active deployment and records the
+// result on the server. The active deployment is the released deployment
+// or, if nothing is released, the most recently completed deployment.
+//
+// Plugins can't check health, so the health is that of the latest report
+// that another source, such as a monitoring system, recorded for the
+// deployment with UpsertHealthReport. If there is none the health is
+// unknown. If getting the report fails the check is recorded with an
+// error status and the error is returned.
+//
+// If the released deployment is unhealthy within the rollback window of
+// its release, the previous deployment is released again. See
+// ReleaseHealthBreach.
+func (a *App) HealthCheck(ctx context.Context) (*pb.HealthReport, error) {
+	d, err := a.activeDeployment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &pb.HealthReport{
+		Application:  a.ref,
+		Workspace:    a.workspace,
+		DeploymentId: d.Id,
+		Status:       server.NewStatus(pb.Status_RUNNING),
+		JobId:        a.jobInfo.Id,
+	}
+
+	health, checkErr := a.reportedHealth(ctx, d)
+	if checkErr != nil {
+		server.StatusSetError(report.Status, checkErr)
+	} else {
+		server.StatusSetSuccess(report.Status)
+		if health != nil {
+			report.Health = pb.HealthReport_UNHEALTHY
+			if health.Healthy {
+				report.Health = pb.HealthReport_HEALTHY
+			}
+			report.Message = health.Message
+		}
+	}
+
+	resp, err := a.client.UpsertHealthReport(ctx, &pb.UpsertHealthReportRequest{
+		Report: report,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if checkErr != nil {
+		return nil, checkErr
+	}
+
+	if resp.Report.Health == pb.HealthReport_UNHEALTHY {
+		if err := a.healthBreach(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Report, nil
+}
+
+// healthBreach rolls back the release of the unhealthy deployment d if it
+// is within its rollback window. This does nothing if d isn't released.
+func (a *App) healthBreach(ctx context.Context, d *pb.Deployment) error {
+	released, err := a.previousReleasedDeployment(ctx)
+	if err != nil || released == nil || released.Id != d.Id {
+		return err
+	}
+
+	release, err := a.ReleaseHealthBreach(ctx)
+	if err != nil {
+		return err
+	}
+	if release != nil {
+		a.UI.Output("Deployment %s is unhealthy, rolled back to deployment %s",
+			d.Id, release.DeploymentId, terminal.WithWarningStyle())
+	} else {
+		a.UI.Output("Deployment %s is unhealthy but its release is baked. "+
+			"Run \"waypoint rollback -confirm\" to roll back.",
+			d.Id, terminal.WithWarningStyle())
+	}
+
+	return nil
+}
+
+// reportedHealth returns the health of the latest health report for the
+// deployment d. This returns nil if there is no report with a known
+// health.
+func (a *App) reportedHealth(ctx context.Context, d *pb.Deployment) (*Health, error) {
+	report, err := a.client.GetLatestHealthReport(ctx, &pb.GetLatestHealthReportRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if report.DeploymentId != d.Id || report.Health == pb.HealthReport_UNKNOWN {
+		return nil, nil
+	}
+
+	return &Health{
+		Healthy: report.Health == pb.HealthReport_HEALTHY,
+		Message: report.Message,
+	}, nil
+}
+
+// activeDeployment returns the deployment that is released or, if there
+// is no release, the most recently completed deployment that hasn't been
+// destroyed.
+func (a *App) activeDeployment(ctx context.Context) (*pb.Deployment, error) {
+	d, _, err := a.latestDeployment(ctx, a.ref)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"no active deployment to check the health of")
+	}
+
+	return d, nil
+}
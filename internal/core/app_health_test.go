@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	platform := &componentmocks.Platform{}
+	platform.On("DeployFunc").Return(func() component.Deployment {
+		return &empty.Empty{}
+	})
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	// With nothing deployed there is nothing to check
+	_, err := app.HealthCheck(ctx)
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	deployment, err := app.Deploy(ctx, testPushArtifact(t, app))
+	require.NoError(err)
+
+	// The health comes from the report of the monitoring system
+	testReportHealth(t, app, deployment, pb.HealthReport_UNHEALTHY, "503 from /health")
+	report, err := app.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(deployment.Id, report.DeploymentId)
+	require.Equal(pb.HealthReport_UNHEALTHY, report.Health)
+	require.Equal("503 from /health", report.Message)
+
+	// The report is recorded on the server
+	latest, err := app.client.GetLatestHealthReport(ctx, &pb.GetLatestHealthReportRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Equal(report.Id, latest.Id)
+}
+
+func TestAppHealthCheck_noReport(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	platform := &componentmocks.Platform{}
+	platform.On("DeployFunc").Return(func() component.Deployment {
+		return &empty.Empty{}
+	})
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	_, err := app.Deploy(ctx, testPushArtifact(t, app))
+	require.NoError(err)
+
+	report, err := app.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(pb.HealthReport_UNKNOWN, report.Health)
+	require.Equal(pb.Status_SUCCESS, report.Status.State)
+}
+
+// testReportHealth records a health report for the deployment d like a
+// monitoring system would.
+func testReportHealth(
+	t *testing.T,
+	app *App,
+	d *pb.Deployment,
+	health pb.HealthReport_Health,
+	message string,
+) {
+	st := server.NewStatus(pb.Status_RUNNING)
+	server.StatusSetSuccess(st)
+	_, err := app.client.UpsertHealthReport(context.Background(), &pb.UpsertHealthReportRequest{
+		Report: &pb.HealthReport{
+			Application:  app.ref,
+			Workspace:    app.workspace,
+			DeploymentId: d.Id,
+			Status:       st,
+			Health:       health,
+			Message:      message,
+		},
+	})
+	require.NoError(t, err)
+}
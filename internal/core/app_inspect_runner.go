@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// RunnerInfo is information about the runner that executed an operation.
+type RunnerInfo struct {
+	// Id is the ID of the runner.
+	Id string
+
+	// JobId is the ID of the job that the runner executed.
+	JobId string
+
+	// Labels and Version are reported by the runner when it registered.
+	Labels  map[string]string
+	Version string
+}
+
+// InspectRunner returns information about the runner that executed the
+// operation with the given ID. The operation can be a build, pushed
+// artifact, deployment, or release. This is useful for debugging failures
+// that only happen on some runners.
+//
+// This returns a FailedPrecondition error if the operation wasn't run by
+// a runner or if the runner is no longer registered.
+func (a *App) InspectRunner(ctx context.Context, operationId string) (*RunnerInfo, error) {
+	jobId, err := a.operationJobId(ctx, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if jobId == "" {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"operation %s was not executed by a job", operationId)
+	}
+
+	job, err := a.client.GetJob(ctx, &pb.GetJobRequest{JobId: jobId})
+	if err != nil {
+		return nil, err
+	}
+	if job.AssignedRunner == nil || job.AssignedRunner.Id == "" {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"job %s for operation %s was never assigned to a runner", jobId, operationId)
+	}
+
+	runnerId := job.AssignedRunner.Id
+	runner, err := a.client.GetRunner(ctx, &pb.GetRunnerRequest{RunnerId: runnerId})
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"runner %s that executed operation %s is no longer registered",
+			runnerId, operationId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunnerInfo{
+		Id:      runner.Id,
+		JobId:   jobId,
+		Labels:  runner.Labels,
+		Version: runner.Version,
+	}, nil
+}
+
+// operationJobId returns the ID of the job that created the operation with
+// the given ID. Operation IDs are unique across types so each type is tried.
+func (a *App) operationJobId(ctx context.Context, id string) (string, error) {
+	ref := &pb.Ref_Operation{
+		Target: &pb.Ref_Operation_Id{Id: id},
+	}
+
+	build, err := a.client.GetBuild(ctx, &pb.GetBuildRequest{Ref: ref})
+	if err == nil {
+		return build.JobId, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return "", err
+	}
+
+	artifact, err := a.client.GetPushedArtifact(ctx, &pb.GetPushedArtifactRequest{Ref: ref})
+	if err == nil {
+		return artifact.JobId, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return "", err
+	}
+
+	deployment, err := a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{Ref: ref})
+	if err == nil {
+		return deployment.JobId, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return "", err
+	}
+
+	release, err := a.client.GetRelease(ctx, &pb.GetReleaseRequest{Ref: ref})
+	if err == nil {
+		return release.JobId, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		return "", err
+	}
+
+	return "", status.Errorf(codes.NotFound, "operation %s not found", id)
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+	"github.com/hashicorp/waypoint/internal/server/singleprocess"
+)
+
+func TestAppInspectRunner(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+	), "test")
+	client := app.client
+	singleprocess.TestApp(t, client, app.ref)
+
+	// Register a runner
+	runnerId, closeRunner := singleprocess.TestRunner(t, client, &pb.Runner{
+		Labels:  map[string]string{"region": "us-east-1"},
+		Version: "0.1.0",
+	})
+
+	// Queue a job for the runner and have the runner execute it
+	queueResp, err := client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, &pb.Job{
+			Application: app.ref,
+			Workspace:   app.workspace,
+			TargetRunner: &pb.Ref_Runner{
+				Target: &pb.Ref_Runner_Id{
+					Id: &pb.Ref_RunnerId{Id: runnerId},
+				},
+			},
+		}),
+	})
+	require.NoError(err)
+
+	stream, err := client.RunnerJobStream(ctx)
+	require.NoError(err)
+	require.NoError(stream.Send(&pb.RunnerJobStreamRequest{
+		Event: &pb.RunnerJobStreamRequest_Request_{
+			Request: &pb.RunnerJobStreamRequest_Request{
+				RunnerId: runnerId,
+			},
+		},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(err)
+	_, ok := resp.Event.(*pb.RunnerJobStreamResponse_Assignment)
+	require.True(ok, "should be an assignment")
+	require.NoError(stream.Send(&pb.RunnerJobStreamRequest{
+		Event: &pb.RunnerJobStreamRequest_Ack_{
+			Ack: &pb.RunnerJobStreamRequest_Ack{},
+		},
+	}))
+	require.NoError(stream.Send(&pb.RunnerJobStreamRequest{
+		Event: &pb.RunnerJobStreamRequest_Complete_{
+			Complete: &pb.RunnerJobStreamRequest_Complete{},
+		},
+	}))
+	_, err = stream.Recv()
+	require.Error(err)
+
+	// Record the operation the job created
+	buildResp, err := client.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+		Build: serverptypes.TestValidBuild(t, &pb.Build{
+			Application: app.ref,
+			Workspace:   app.workspace,
+			JobId:       queueResp.JobId,
+		}),
+	})
+	require.NoError(err)
+	buildId := buildResp.Build.Id
+
+	info, err := app.InspectRunner(ctx, buildId)
+	require.NoError(err)
+	require.Equal(runnerId, info.Id)
+	require.Equal(queueResp.JobId, info.JobId)
+	require.Equal("us-east-1", info.Labels["region"])
+	require.Equal("0.1.0", info.Version)
+
+	// Once the runner deregisters we get a clear error
+	closeRunner()
+	require.Eventually(func() bool {
+		_, err := app.InspectRunner(ctx, buildId)
+		return status.Code(err) == codes.FailedPrecondition
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Unknown operations
+	_, err = app.InspectRunner(ctx, "nope")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// PlanDeploy returns the changes that deploying the given artifact would
+// make, without deploying it. Nothing is written to the server. Plugins
+// can't report the changes they would make, so this is the hooks that
+// would run and a summary of the deploy.
+func (a *App) PlanDeploy(ctx context.Context, push *pb.PushedArtifact) ([]string, error) {
+	if a.Platform == nil {
+		return nil, status.Error(codes.NotFound, "no deployment configured")
+	}
+	if push.Artifact == nil || push.Artifact.Artifact == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"pushed artifact %s has no artifact to deploy", push.Id)
+	}
+
+	info := a.components[a.Platform]
+	changes := planHooks(info.Hooks, "before")
+	changes = append(changes, fmt.Sprintf(
+		"deploy artifact %s with %q", push.Id, info.Info.Name))
+
+	return append(changes, planHooks(info.Hooks, "after")...), nil
+}
+
+// PlanRelease returns the changes that releasing the given deployment
+// would make, without releasing it. Nothing is written to the server. Like
+// PlanDeploy, this is the hooks and a summary of the release.
+func (a *App) PlanRelease(ctx context.Context, target *pb.Deployment) ([]string, error) {
+	if a.Releaser == nil {
+		return []string{fmt.Sprintf(
+			"record deployment %s as released (no release manager is configured)",
+			target.Id)}, nil
+	}
+
+	info := a.components[a.Releaser]
+	changes := planHooks(info.Hooks, "before")
+
+	changes = append(changes, fmt.Sprintf(
+		"release deployment %s with %q", target.Id, info.Info.Name))
+
+	return append(changes, planHooks(info.Hooks, "after")...), nil
+}
+
+// planHooks describes the hooks for when that would run.
+func planHooks(hooks map[string][]*config.Hook, when string) []string {
+	var result []string
+	for _, h := range hooks[when] {
+		if !h.ConditionMet(os.Getenv) {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("run %s hook: %s",
+			when, strings.Join(hookCommand(h, redactedGetenv), " ")))
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppPlanDeploy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	platform := &componentmocks.Platform{}
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlanConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+	push := testPushArtifact(t, app)
+
+	changes, err := app.PlanDeploy(ctx, push)
+	require.NoError(err)
+	require.Equal([]string{
+		"run before hook: echo before",
+		fmt.Sprintf("deploy artifact %s with \"test\"", push.Id),
+	}, changes)
+
+	// Nothing was deployed or recorded
+	platform.AssertNotCalled(t, "DeployFunc")
+	resp, err := app.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Empty(resp.Deployments)
+}
+
+func TestAppPlanRelease(t *testing.T) {
+	require := require.New(t)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlanConfig)),
+	), "test")
+
+	// Without a release manager the release would only be recorded
+	changes, err := app.PlanRelease(context.Background(), &pb.Deployment{Id: "d1"})
+	require.NoError(err)
+	require.Len(changes, 1)
+	require.Contains(changes[0], "d1")
+
+	resp, err := app.client.ListReleases(context.Background(), &pb.ListReleasesRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Empty(resp.Releases)
+}
+
+const testPlanConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+
+		hook {
+			when    = "before"
+			command = ["echo", "before"]
+		}
+	}
+}
+`
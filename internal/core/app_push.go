@@ -2,11 +2,14 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint/internal/config"
@@ -19,15 +22,59 @@ import (
 // machines, long after a build is done (because the person may have deleted
 // the physical artifact, etc.).
 //
-// TODO(mitchellh): test
+// If multiple registries are configured, the build is pushed to all of
+// them in parallel and each push is recorded as its own pushed artifact.
+// The pushed artifact of the first registry is returned.
 func (a *App) PushBuild(ctx context.Context, optFuncs ...PushBuildOption) (*pb.PushedArtifact, error) {
 	opts, err := newPushBuildOptions(optFuncs...)
 	if err != nil {
 		return nil, err
 	}
 
-	_, msg, err := a.doOperation(ctx, a.logger.Named("push"), &pushBuildOperation{
-		Build: opts.Build,
+	if len(a.Registries) <= 1 {
+		return a.pushBuild(ctx, a.logger.Named("push"), opts.Build, a.Registry)
+	}
+
+	results := make([]*pb.PushedArtifact, len(a.Registries))
+	errs := make([]error, len(a.Registries))
+	var wg sync.WaitGroup
+	for i, r := range a.Registries {
+		i, r := i, r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			log := a.logger.Named("push").Named(a.components[r].Info.Name)
+			results[i], errs[i] = a.pushBuild(ctx, log, opts.Build, r)
+		}()
+	}
+	wg.Wait()
+
+	var result error
+	for i, err := range errs {
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"registry[%d] %s: %w", i, a.components[a.Registries[i]].Info.Name, err))
+		}
+	}
+	if result != nil {
+		return nil, result
+	}
+
+	return results[0], nil
+}
+
+// pushBuild pushes the build to a single registry. The registry may be
+// nil to record the build artifact as pushed as-is.
+func (a *App) pushBuild(
+	ctx context.Context,
+	log hclog.Logger,
+	build *pb.Build,
+	registry component.Registry,
+) (*pb.PushedArtifact, error) {
+	_, msg, err := a.doOperation(ctx, log, &pushBuildOperation{
+		Build:    build,
+		Registry: registry,
 	})
 	if err != nil {
 		return nil, err
@@ -65,6 +112,10 @@ func newPushBuildOptions(opts ...PushBuildOption) (*pushBuildOptions, error) {
 
 type pushBuildOperation struct {
 	Build *pb.Build
+
+	// Registry is the registry to push to. If this is nil, the build
+	// artifact is used as the pushed artifact.
+	Registry component.Registry
 }
 
 func (opts *pushBuildOptions) Validate() error {
@@ -77,8 +128,8 @@ func (op *pushBuildOperation) Init(app *App) (proto.Message, error) {
 	// Our component is typically the registry but if we don't have
 	// one configured, then we specify the component as our builder since
 	// that is what is creating the pushed artifact.
-	var component interface{} = app.Registry
-	if component == nil {
+	var component interface{} = op.Registry
+	if op.Registry == nil {
 		component = app.Builder
 	}
 
@@ -92,19 +143,28 @@ func (op *pushBuildOperation) Init(app *App) (proto.Message, error) {
 }
 
 func (op *pushBuildOperation) Hooks(app *App) map[string][]*config.Hook {
-	if app.Registry == nil {
+	if op.Registry == nil {
 		return nil
 	}
 
-	return app.components[app.Registry].Hooks
+	return app.components[op.Registry].Hooks
 }
 
 func (op *pushBuildOperation) Labels(app *App) map[string]string {
-	if app.Registry == nil {
-		return nil
+	// Artifacts of a matrix build are tagged the same as their build.
+	var matrix map[string]string
+	if p, ok := op.Build.Labels[labelPlatform]; ok {
+		matrix = map[string]string{
+			labelPlatform:    p,
+			labelMatrixBuild: op.Build.Labels[labelMatrixBuild],
+		}
+	}
+
+	if op.Registry == nil {
+		return matrix
 	}
 
-	return app.components[app.Registry].Labels
+	return labelsMerge(app.components[op.Registry].Labels, matrix)
 }
 
 func (op *pushBuildOperation) Upsert(
@@ -124,15 +184,15 @@ func (op *pushBuildOperation) Upsert(
 
 func (op *pushBuildOperation) Do(ctx context.Context, log hclog.Logger, app *App, _ proto.Message) (interface{}, error) {
 	// If we have no registry, we just push the local build.
-	if app.Registry == nil {
+	if op.Registry == nil {
 		return op.Build.Artifact.Artifact, nil
 	}
 
 	return app.callDynamicFunc(ctx,
 		log,
 		(*component.Artifact)(nil),
-		app.Registry,
-		app.Registry.PushFunc(),
+		op.Registry,
+		op.Registry.PushFunc(),
 		argNamedAny("artifact", op.Build.Artifact.Artifact),
 	)
 }
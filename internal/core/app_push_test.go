@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppPushBuild_multipleRegistries(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// The artifacts must be protobufs so that the build can be pushed.
+	newArtifact := func() component.Artifact {
+		artifact := &componentmocks.Artifact{}
+		artifact.On("Labels").Return(map[string]string{})
+		return &struct {
+			*empty.Empty
+			*componentmocks.Artifact
+		}{&empty.Empty{}, artifact}
+	}
+
+	builder := &componentmocks.Builder{}
+	builder.On("BuildFunc").Return(newArtifact)
+	builderFactory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, builderFactory, "test", builder)
+
+	factory := TestFactory(t, component.RegistryType)
+	mocks := map[string]*componentmocks.Registry{}
+	for _, name := range []string{"ecr", "dockerhub"} {
+		mock := &componentmocks.Registry{}
+		mock.On("PushFunc").Return(newArtifact)
+		TestFactoryRegister(t, factory, name, mock)
+		mocks[name] = mock
+	}
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPushMultipleConfig)),
+		WithFactory(component.BuilderType, builderFactory),
+		WithFactory(component.RegistryType, factory),
+	), "test")
+	require.Len(app.Registries, 2)
+	require.Equal(app.Registries[0], app.Registry)
+
+	build, push, err := app.Build(ctx)
+	require.NoError(err)
+	require.Equal(build.Id, push.BuildId)
+	require.Equal("ecr", push.Component.Name)
+
+	// Every registry pushed and recorded its own artifact.
+	for _, mock := range mocks {
+		mock.AssertCalled(t, "PushFunc")
+	}
+	resp, err := app.client.ListPushedArtifacts(ctx, &pb.ListPushedArtifactsRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Len(resp.Artifacts, 2)
+
+	var names []string
+	for _, a := range resp.Artifacts {
+		require.Equal(build.Id, a.BuildId)
+		names = append(names, a.Component.Name)
+	}
+	require.ElementsMatch([]string{"ecr", "dockerhub"}, names)
+}
+
+const testPushMultipleConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+
+		registry {
+			use "ecr" {}
+		}
+
+		registry {
+			use "dockerhub" {}
+		}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
@@ -13,19 +13,54 @@ import (
 )
 
 // Release releases a set of deploys.
+//
+// If the release configuration sets a rollback window, this starts it.
+// See ReleaseHealthBreach. If the deploy configuration enables rollback, a
+// failed release rolls back from the target.
 // TODO(mitchellh): test
 func (a *App) Release(ctx context.Context, target *pb.Deployment) (
 	*pb.Release,
 	component.Release,
 	error,
 ) {
-	result, releasepb, err := a.doOperation(ctx, a.logger.Named("release"), &releaseOperation{
-		Target: target,
+	release, result, err := a.release(ctx, target, true)
+	if err != nil && a.rollbackEnabled() && ctx.Err() == nil {
+		return nil, nil, a.autoRollback(ctx, target, "release failed: "+err.Error())
+	}
+
+	return release, result, err
+}
+
+func (a *App) release(ctx context.Context, target *pb.Deployment, arm bool) (
+	*pb.Release,
+	component.Release,
+	error,
+) {
+	// If we're arming a rollback window we need to know what we'd roll
+	// back to before we release.
+	var labels map[string]string
+	if arm {
+		var err error
+		labels, err = a.rollbackWindowLabels(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	log := a.logger.Named("release")
+	result, releasepb, err := a.doOperation(ctx, log, &releaseOperation{
+		Target:      target,
+		ExtraLabels: labels,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Roll out feature flags now that traffic has moved.
+	if err := a.rolloutFlags(ctx, target); err != nil {
+		return nil, nil, err
+	}
+
 	var release component.Release
 	if result != nil {
 		release = result.(component.Release)
@@ -37,6 +72,9 @@ func (a *App) Release(ctx context.Context, target *pb.Deployment) (
 type releaseOperation struct {
 	Target *pb.Deployment
 
+	// ExtraLabels are additional labels to set on the release.
+	ExtraLabels map[string]string
+
 	result component.Release
 }
 
@@ -71,10 +109,12 @@ func (op *releaseOperation) Hooks(app *App) map[string][]*config.Hook {
 
 func (op *releaseOperation) Labels(app *App) map[string]string {
 	if app.Releaser == nil {
-		return nil
+		return op.ExtraLabels
 	}
-
-	return app.components[app.Releaser].Labels
+	if len(op.ExtraLabels) == 0 {
+		return app.components[app.Releaser].Labels
+	}
+	return labelsMerge(app.components[app.Releaser].Labels, op.ExtraLabels)
 }
 
 func (op *releaseOperation) Upsert(
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"strconv"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// labelFlagPrefix is the prefix of the deployment labels that store the
+// state of each feature flag for that deployment.
+const labelFlagPrefix = "waypoint/flag/"
+
+// DeploymentFlags returns the feature flag state that was persisted for
+// the deployment by a release.
+func DeploymentFlags(d *pb.Deployment) map[string]bool {
+	result := map[string]bool{}
+	for k, v := range d.Labels {
+		if len(k) > len(labelFlagPrefix) && k[:len(labelFlagPrefix)] == labelFlagPrefix {
+			enabled, _ := strconv.ParseBool(v)
+			result[k[len(labelFlagPrefix):]] = enabled
+		}
+	}
+
+	return result
+}
+
+// rolloutFlags updates the feature flag state on every live deployment
+// for the flags configured for release. For each flag, the released
+// target and then the newest other deployments up to the configured
+// percentage are enabled and the rest are disabled. The target is the
+// canary that gets new flags first, even if it isn't the newest such as
+// after a rollback.
+func (a *App) rolloutFlags(ctx context.Context, target *pb.Deployment) error {
+	if a.config.Release == nil || len(a.config.Release.FeatureFlags) == 0 {
+		return nil
+	}
+
+	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application:   a.ref,
+		Workspace:     a.workspace,
+		PhysicalState: pb.Operation_CREATED,
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_START_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The target is first, followed by the rest newest first.
+	deployments := make([]*pb.Deployment, 0, len(resp.Deployments))
+	for _, d := range resp.Deployments {
+		if d.Id == target.Id {
+			deployments = append([]*pb.Deployment{d}, deployments...)
+		} else {
+			deployments = append(deployments, d)
+		}
+	}
+
+	total := len(deployments)
+	for i, d := range deployments {
+		changed := false
+		for _, f := range a.config.Release.FeatureFlags {
+			// Round up so any non-zero percent gets at least one deployment.
+			enabledCount := (total*f.Percent + 99) / 100
+			v := strconv.FormatBool(i < enabledCount)
+
+			k := labelFlagPrefix + f.Name
+			if d.Labels[k] != v {
+				if d.Labels == nil {
+					d.Labels = map[string]string{}
+				}
+
+				d.Labels[k] = v
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		a.logger.Debug("updating deployment feature flags", "deployment", d.Id)
+		if _, err := a.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: d,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppReleaseFeatureFlags(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testReleaseFlagsConfig)),
+	), "test")
+
+	deploy := func() *pb.Deployment {
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				State:       pb.Operation_CREATED,
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment
+	}
+
+	get := func(id string) *pb.Deployment {
+		d, err := app.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+			Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Id{Id: id},
+			},
+		})
+		require.NoError(err)
+		return d
+	}
+
+	stable := deploy()
+	canary := deploy()
+
+	_, _, err := app.Release(ctx, canary)
+	require.NoError(err)
+
+	require.Equal(map[string]bool{"checkout": true, "off": false}, DeploymentFlags(get(canary.Id)))
+	require.Equal(map[string]bool{"checkout": false, "off": false}, DeploymentFlags(get(stable.Id)))
+
+	// Releasing the older deployment again, as a rollback does, moves the
+	// flags to it.
+	_, _, err = app.Release(ctx, get(stable.Id))
+	require.NoError(err)
+
+	require.Equal(map[string]bool{"checkout": true, "off": false}, DeploymentFlags(get(stable.Id)))
+	require.Equal(map[string]bool{"checkout": false, "off": false}, DeploymentFlags(get(canary.Id)))
+}
+
+const testReleaseFlagsConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+
+	release {
+		feature_flag "checkout" {
+			percent = 10
+		}
+
+		feature_flag "off" {
+			percent = 0
+		}
+	}
+}
+`
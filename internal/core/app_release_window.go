@@ -0,0 +1,267 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// labelRollbackUntil is the release label with the end of its rollback
+	// window in RFC 3339 format. During the window a health breach
+	// automatically rolls back to the previously released deployment.
+	labelRollbackUntil = "waypoint/rollback-until"
+
+	// labelRollbackTo is the release label with the ID of the deployment
+	// that was released before it. This is empty if there was none.
+	labelRollbackTo = "waypoint/rollback-to"
+)
+
+// rollbackWindow is the period after a release during which a health
+// breach automatically rolls back to the previously released deployment.
+// This is started by App.Release if the release configuration sets a
+// rollback_window, and is stored as labels on the release so that health
+// checks in later jobs see it.
+type rollbackWindow struct {
+	// Release is the release that started this window.
+	Release *pb.Release
+
+	// PreviousId is the ID of the deployment that was released prior to
+	// Release. This is empty if there was no prior release.
+	PreviousId string
+
+	// Expires is when the release is considered baked.
+	Expires time.Time
+}
+
+// rollbackWindowLabels returns the release labels that start a rollback
+// window if one is configured. This must be called before the release
+// since it looks up the deployment that is currently released.
+func (a *App) rollbackWindowLabels(ctx context.Context) (map[string]string, error) {
+	d := a.config.Release.RollbackWindowDuration()
+	if d <= 0 {
+		return nil, nil
+	}
+
+	previous, err := a.previousReleasedDeployment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousId string
+	if previous != nil {
+		previousId = previous.Id
+	}
+
+	a.logger.Info("release rollback window started", "duration", d)
+	return map[string]string{
+		labelRollbackUntil: a.now().Add(d).UTC().Format(time.RFC3339),
+		labelRollbackTo:    previousId,
+	}, nil
+}
+
+// latestRollbackWindow returns the rollback window of the latest release.
+// This returns nil if there is no release or it has no window.
+func (a *App) latestRollbackWindow(ctx context.Context) (*rollbackWindow, error) {
+	release, err := a.latestRelease(ctx)
+	if err != nil || release == nil {
+		return nil, err
+	}
+
+	return releaseRollbackWindow(release)
+}
+
+// releaseRollbackWindow returns the rollback window that release started,
+// or nil if it didn't start one.
+func releaseRollbackWindow(release *pb.Release) (*rollbackWindow, error) {
+	until, ok := release.Labels[labelRollbackUntil]
+	if !ok {
+		return nil, nil
+	}
+	expires, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"release %s has an invalid %s label: %s", release.Id, labelRollbackUntil, err)
+	}
+
+	return &rollbackWindow{
+		Release:    release,
+		PreviousId: release.Labels[labelRollbackTo],
+		Expires:    expires,
+	}, nil
+}
+
+// ReleaseBaked returns true if there is no active rollback window for the
+// latest release. This is true once the window expires or if no window was
+// configured.
+func (a *App) ReleaseBaked(ctx context.Context) (bool, error) {
+	w, err := a.latestRollbackWindow(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return w == nil || !a.now().Before(w.Expires), nil
+}
+
+// ReleaseHealthBreach should be called when the deployment of the latest
+// release is detected to be unhealthy. HealthCheck calls this. If this
+// happens within the rollback window, the previous deployment is
+// automatically released again and that release is returned. Outside of
+// the window the release is baked and this returns a nil release; the
+// caller must use ReleaseRollback with confirmation.
+func (a *App) ReleaseHealthBreach(ctx context.Context) (*pb.Release, error) {
+	baked, err := a.ReleaseBaked(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baked {
+		a.logger.Warn("release health breach detected but release is baked, not rolling back")
+		return nil, nil
+	}
+
+	a.logger.Warn("release health breach within rollback window, rolling back")
+	return a.ReleaseRollback(ctx, false)
+}
+
+// ReleaseRollback releases the deployment that was released prior to the
+// latest release. This is what `waypoint rollback` runs. Once the latest
+// release is baked, which is always the case if it had no rollback window,
+// confirmed must be true since the rollback is no longer automatic.
+//
+// If the earlier deployment was destroyed since, its artifact is deployed
+// again and the new deployment is released.
+func (a *App) ReleaseRollback(ctx context.Context, confirmed bool) (*pb.Release, error) {
+	latest, err := a.latestRelease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"no release to roll back from")
+	}
+
+	w, err := releaseRollbackWindow(latest)
+	if err != nil {
+		return nil, err
+	}
+	if (w == nil || !a.now().Before(w.Expires)) && !confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"release %s is baked, rolling it back requires confirmation",
+			latest.Id)
+	}
+
+	// The window records what was released before it started. Otherwise
+	// we look through the earlier releases.
+	var previous *pb.Deployment
+	if w != nil {
+		if w.PreviousId != "" {
+			previous, err = a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+				Ref: &pb.Ref_Operation{
+					Target: &pb.Ref_Operation_Id{Id: w.PreviousId},
+				},
+			})
+		}
+	} else {
+		previous, err = a.previousRelease(ctx, latest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"no previous release to roll back to from release %s", latest.Id)
+	}
+
+	if previous.State == pb.Operation_DESTROYED {
+		previous, err = a.redeploy(ctx, previous)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The rollback release doesn't start a window, so the window is
+	// consumed by the rollback.
+	release, _, err := a.release(ctx, previous, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// latestRelease returns the latest release or nil if there isn't one.
+func (a *App) latestRelease(ctx context.Context) (*pb.Release, error) {
+	release, err := a.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	return release, err
+}
+
+// previousRelease returns the deployment of the most recent successful
+// release before latest that released a different deployment. This
+// returns nil if there is none.
+func (a *App) previousRelease(ctx context.Context, latest *pb.Release) (*pb.Deployment, error) {
+	resp, err := a.client.ListReleases(ctx, &pb.ListReleasesRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := false
+	for _, r := range resp.Releases {
+		if !seen {
+			seen = r.Id == latest.Id
+			continue
+		}
+
+		if r.DeploymentId == latest.DeploymentId {
+			continue
+		}
+		if r.Status == nil || r.Status.State != pb.Status_SUCCESS {
+			continue
+		}
+
+		d, err := a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+			Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Id{Id: r.DeploymentId},
+			},
+		})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+
+		return d, err
+	}
+
+	return nil, nil
+}
+
+// previousReleasedDeployment returns the deployment that is currently
+// released, or nil if there isn't one.
+func (a *App) previousReleasedDeployment(ctx context.Context) (*pb.Deployment, error) {
+	release, err := a.latestRelease(ctx)
+	if err != nil || release == nil {
+		return nil, err
+	}
+
+	return a.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: release.DeploymentId},
+		},
+	})
+}
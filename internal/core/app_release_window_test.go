@@ -0,0 +1,237 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppReleaseRollbackWindow(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testReleaseWindowConfig)),
+	), "test")
+
+	now := time.Now()
+	app.now = func() time.Time { return now }
+
+	deploy := func() *pb.Deployment {
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				State:       pb.Operation_CREATED,
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment
+	}
+
+	latest := func() *pb.Release {
+		r, err := app.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+			Application: app.ref,
+			Workspace:   app.workspace,
+		})
+		require.NoError(err)
+		return r
+	}
+
+	stable := deploy()
+	canary := deploy()
+
+	_, _, err := app.Release(ctx, stable)
+	require.NoError(err)
+
+	// Breach inside the window rolls back to stable
+	{
+		_, _, err := app.Release(ctx, canary)
+		require.NoError(err)
+		baked, err := app.ReleaseBaked(ctx)
+		require.NoError(err)
+		require.False(baked)
+
+		now = now.Add(5 * time.Minute)
+		r, err := app.ReleaseHealthBreach(ctx)
+		require.NoError(err)
+		require.NotNil(r)
+		require.Equal(stable.Id, r.DeploymentId)
+		require.Equal(stable.Id, latest().DeploymentId)
+	}
+
+	// Breach outside the window does nothing
+	{
+		_, _, err := app.Release(ctx, canary)
+		require.NoError(err)
+
+		now = now.Add(11 * time.Minute)
+		baked, err := app.ReleaseBaked(ctx)
+		require.NoError(err)
+		require.True(baked)
+
+		r, err := app.ReleaseHealthBreach(ctx)
+		require.NoError(err)
+		require.Nil(r)
+		require.Equal(canary.Id, latest().DeploymentId)
+
+		// Explicit rollback requires confirmation
+		_, err = app.ReleaseRollback(ctx, false)
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+
+		r, err = app.ReleaseRollback(ctx, true)
+		require.NoError(err)
+		require.Equal(stable.Id, r.DeploymentId)
+	}
+}
+
+func TestAppHealthCheck_rollbackWindow(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The release is made by one app and the health checked by another,
+	// like separate jobs.
+	project := TestProject(t,
+		WithConfig(config.TestConfig(t, testReleaseWindowConfig)),
+	)
+	app := TestApp(t, project, "test")
+
+	value, err := ptypes.MarshalAny(&empty.Empty{})
+	require.NoError(err)
+	deploy := func() *pb.Deployment {
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				State:       pb.Operation_CREATED,
+				Deployment:  value,
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment
+	}
+
+	stable := deploy()
+	canary := deploy()
+
+	_, _, err = app.Release(ctx, stable)
+	require.NoError(err)
+	release, _, err := app.Release(ctx, canary)
+	require.NoError(err)
+	require.Equal(stable.Id, release.Labels[labelRollbackTo])
+	require.NotEmpty(release.Labels[labelRollbackUntil])
+
+	// The unhealthy canary is rolled back by the health check
+	testReportHealth(t, app, canary, pb.HealthReport_UNHEALTHY, "")
+	report, err := TestApp(t, project, "test").HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(canary.Id, report.DeploymentId)
+
+	latest, err := app.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Equal(stable.Id, latest.DeploymentId)
+
+	// The rollback doesn't start a window so it isn't rolled back again
+	testReportHealth(t, app, stable, pb.HealthReport_UNHEALTHY, "")
+	_, err = app.HealthCheck(ctx)
+	require.NoError(err)
+
+	latest, err = app.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+		Application: app.ref,
+		Workspace:   app.workspace,
+	})
+	require.NoError(err)
+	require.Equal(stable.Id, latest.DeploymentId)
+}
+
+func TestAppHealthCheck_reportedBreach(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The health comes from reports recorded by another source
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testReleaseWindowConfig)),
+	), "test")
+
+	deploy := func() *pb.Deployment {
+		resp, err := app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				State:       pb.Operation_CREATED,
+			}),
+		})
+		require.NoError(err)
+		return resp.Deployment
+	}
+	latest := func() string {
+		r, err := app.client.GetLatestRelease(ctx, &pb.GetLatestReleaseRequest{
+			Application: app.ref,
+			Workspace:   app.workspace,
+		})
+		require.NoError(err)
+		return r.DeploymentId
+	}
+
+	stable := deploy()
+	canary := deploy()
+
+	_, _, err := app.Release(ctx, stable)
+	require.NoError(err)
+	_, _, err = app.Release(ctx, canary)
+	require.NoError(err)
+
+	// No report means unknown health
+	r, err := app.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(pb.HealthReport_UNKNOWN, r.Health)
+	require.Equal(canary.Id, latest())
+
+	// A healthy report does nothing
+	testReportHealth(t, app, canary, pb.HealthReport_HEALTHY, "from monitoring")
+	r, err = app.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(pb.HealthReport_HEALTHY, r.Health)
+	require.Equal(canary.Id, latest())
+
+	// An unhealthy report within the window rolls back
+	testReportHealth(t, app, canary, pb.HealthReport_UNHEALTHY, "from monitoring")
+	r, err = app.HealthCheck(ctx)
+	require.NoError(err)
+	require.Equal(pb.HealthReport_UNHEALTHY, r.Health)
+	require.Equal("from monitoring", r.Message)
+	require.Equal(stable.Id, latest())
+}
+
+const testReleaseWindowConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+
+	release {
+		rollback_window = "10m"
+	}
+}
+`
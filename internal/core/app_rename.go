@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// RenameComponent renames every component of this app named oldName to
+// newName. The component data directories are moved and all server-side
+// operation records for the component are updated so that cached data
+// and history are kept.
+//
+// The loaded configuration is updated but the configuration file itself
+// is not modified. The "use" stanzas in the file must be updated to match.
+func (a *App) RenameComponent(ctx context.Context, oldName, newName string) error {
+	if newName == "" {
+		return status.Errorf(codes.FailedPrecondition, "new component name must not be empty")
+	}
+
+	var targets []*appComponent
+	for _, c := range a.components {
+		switch c.Info.Name {
+		case oldName:
+			targets = append(targets, c)
+
+		case newName:
+			return status.Errorf(codes.AlreadyExists,
+				"a %s component is already named %q",
+				strings.ToLower(c.Info.Type.String()), newName)
+		}
+	}
+	if len(targets) == 0 {
+		return status.Errorf(codes.NotFound, "no component named %q", oldName)
+	}
+
+	log := a.logger.Named("rename").With("old", oldName, "new", newName)
+	for _, c := range targets {
+		typ := strings.ToLower(component.Type(c.Info.Type).String())
+		if err := a.renameComponentDir(log, c, typ, newName); err != nil {
+			return err
+		}
+		if err := a.renameComponentRecords(ctx, c.Info.Type, oldName, newName); err != nil {
+			return err
+		}
+
+		info := proto.Clone(c.Info).(*pb.Component)
+		info.Name = newName
+		c.Info = info
+	}
+
+	// Update our loaded configuration
+	var uses []*config.Use
+	if v := a.config.Build; v != nil {
+		uses = append(uses, v.Use)
+		for _, r := range v.Registries {
+			uses = append(uses, r.Use)
+		}
+	}
+	if v := a.config.Deploy; v != nil {
+		uses = append(uses, v.Use)
+	}
+	if v := a.config.Release; v != nil {
+		uses = append(uses, v.Use)
+	}
+	for _, use := range uses {
+		if use != nil && use.Type == oldName {
+			use.Type = newName
+		}
+	}
+
+	return nil
+}
+
+// renameComponentDir moves the data and cache directories of the component
+// to the directories for the new name.
+func (a *App) renameComponentDir(log hclog.Logger, c *appComponent, typ, newName string) error {
+	newDir, err := a.dir.Component(typ, newName)
+	if err != nil {
+		return err
+	}
+
+	paths := []struct{ Old, New string }{
+		{c.Dir.DataDir(), newDir.DataDir()},
+		{c.Dir.CacheDir(), newDir.CacheDir()},
+	}
+	for _, p := range paths {
+		if p.Old == p.New {
+			continue
+		}
+
+		// The new directories were just created by Component and must
+		// be removed so we can move the old ones into place.
+		log.Debug("moving component directory", "from", p.Old, "to", p.New)
+		if err := os.RemoveAll(p.New); err != nil {
+			return err
+		}
+		if err := os.Rename(p.Old, p.New); err != nil {
+			return err
+		}
+	}
+
+	c.Dir = newDir
+	return nil
+}
+
+// renameComponentRecords updates the component name on all the operation
+// records for this app in every workspace.
+func (a *App) renameComponentRecords(ctx context.Context, typ pb.Component_Type, oldName, newName string) error {
+	match := func(c *pb.Component) bool {
+		if c == nil || c.Type != typ || c.Name != oldName {
+			return false
+		}
+
+		c.Name = newName
+		return true
+	}
+
+	switch typ {
+	case pb.Component_BUILDER:
+		resp, err := a.client.ListBuilds(ctx, &pb.ListBuildsRequest{Application: a.ref})
+		if err != nil {
+			return err
+		}
+		for _, v := range resp.Builds {
+			if match(v.Component) {
+				if _, err := a.client.UpsertBuild(ctx, &pb.UpsertBuildRequest{Build: v}); err != nil {
+					return err
+				}
+			}
+		}
+
+	case pb.Component_REGISTRY:
+		resp, err := a.client.ListPushedArtifacts(ctx, &pb.ListPushedArtifactsRequest{Application: a.ref})
+		if err != nil {
+			return err
+		}
+		for _, v := range resp.Artifacts {
+			if match(v.Component) {
+				if _, err := a.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{Artifact: v}); err != nil {
+					return err
+				}
+			}
+		}
+
+	case pb.Component_PLATFORM:
+		resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{Application: a.ref})
+		if err != nil {
+			return err
+		}
+		for _, v := range resp.Deployments {
+			if match(v.Component) {
+				if _, err := a.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{Deployment: v}); err != nil {
+					return err
+				}
+			}
+		}
+
+	case pb.Component_RELEASEMANAGER:
+		resp, err := a.client.ListReleases(ctx, &pb.ListReleasesRequest{Application: a.ref})
+		if err != nil {
+			return err
+		}
+		for _, v := range resp.Releases {
+			if match(v.Component) {
+				if _, err := a.client.UpsertRelease(ctx, &pb.UpsertReleaseRequest{Release: v}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
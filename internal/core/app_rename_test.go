@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppRenameComponent(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+		WithFactory(component.BuilderType, factory),
+	), "test")
+
+	// Write some cached data for the builder
+	oldDir := app.components[mock].Dir
+	oldPath := filepath.Join(oldDir.DataDir(), "state")
+	require.NoError(ioutil.WriteFile(oldPath, []byte("hello"), 0600))
+
+	// Create a record for the builder
+	buildResp, err := app.client.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+		Build: serverptypes.TestValidBuild(t, &pb.Build{
+			Application: app.ref,
+			Workspace:   app.workspace,
+			Component: &pb.Component{
+				Type: pb.Component_BUILDER,
+				Name: "test",
+			},
+		}),
+	})
+	require.NoError(err)
+
+	// Rename
+	require.NoError(app.RenameComponent(ctx, "test", "renamed"))
+
+	// The data should've moved and the old path should be gone
+	newDir := app.components[mock].Dir
+	data, err := ioutil.ReadFile(filepath.Join(newDir.DataDir(), "state"))
+	require.NoError(err)
+	require.Equal("hello", string(data))
+	_, err = os.Stat(oldDir.DataDir())
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(oldDir.CacheDir())
+	require.True(os.IsNotExist(err))
+
+	// The record should have the new name
+	build, err := app.client.GetBuild(ctx, &pb.GetBuildRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: buildResp.Build.Id},
+		},
+	})
+	require.NoError(err)
+	require.Equal("renamed", build.Component.Name)
+
+	// The component and config should have the new name
+	require.Equal("renamed", app.ComponentProto(mock).Name)
+	require.Equal("renamed", app.config.Build.Use.Type)
+
+	// The old name no longer exists
+	err = app.RenameComponent(ctx, "test", "again")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestAppRenameComponent_collision(t *testing.T) {
+	require := require.New(t)
+
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "other", &componentmocks.Platform{})
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testRenameConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	err := app.RenameComponent(context.Background(), "test", "other")
+	require.Error(err)
+	require.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+const testRenameConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "other" {}
+	}
+}
+`
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// rollbackDeploy deploys the artifact of the most recent successful
+// deployment before from again and releases the new deployment. This is
+// used when from failed before it was released, so unlike ReleaseRollback
+// there is no baked release to confirm. Deployments of the same artifact
+// as from are skipped since they would not change anything.
+//
+// This returns a FailedPrecondition error if there is no earlier
+// successful deployment to roll back to.
+func (a *App) rollbackDeploy(ctx context.Context, from *pb.Deployment) (*pb.Deployment, error) {
+	resp, err := a.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+		Application: a.ref,
+		Workspace:   a.workspace,
+		Order: &pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the first successful deployment of another artifact that
+	// completed before the one we're rolling back from.
+	var previous *pb.Deployment
+	seen := false
+	for _, d := range resp.Deployments {
+		if !seen {
+			seen = d.Id == from.Id
+			continue
+		}
+
+		if d.ArtifactId == from.ArtifactId {
+			continue
+		}
+		if d.Status == nil || d.Status.State != pb.Status_SUCCESS {
+			continue
+		}
+
+		previous = d
+		break
+	}
+	if previous == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"no earlier successful deployment to roll back to from deployment %s", from.Id)
+	}
+
+	d, err := a.redeploy(ctx, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := a.release(ctx, d, true); err != nil {
+		return d, err
+	}
+
+	return d, nil
+}
+
+// redeploy deploys the artifact of the deployment d again and returns the
+// new deployment.
+func (a *App) redeploy(ctx context.Context, d *pb.Deployment) (*pb.Deployment, error) {
+	push, err := a.client.GetPushedArtifact(ctx, &pb.GetPushedArtifactRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: d.ArtifactId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("deploying artifact again", "deployment", d.Id, "artifact", push.Id)
+	return a.deploy(ctx, a.logger.Named("rollback"), &deployOperation{Push: push})
+}
+
+// rollbackEnabled returns true if the deploy configuration enables
+// automatic rollback.
+func (a *App) rollbackEnabled() bool {
+	return a.config.Deploy != nil && a.config.Deploy.Rollback
+}
+
+// deployRollback is called after a deploy when the deploy configuration
+// enables automatic rollback. If the new deployment was already reported
+// unhealthy, such as by an "after" hook, this rolls back from it and
+// returns an Aborted error noting the rollback.
+func (a *App) deployRollback(ctx context.Context, d *pb.Deployment) error {
+	health, err := a.reportedHealth(ctx, d)
+	if err == nil && (health == nil || health.Healthy) {
+		return nil
+	}
+
+	reason := "health check failed"
+	if err != nil {
+		reason = err.Error()
+	} else if health.Message != "" {
+		reason = health.Message
+	}
+
+	return a.autoRollback(ctx, d, "deployment "+d.Id+" is unhealthy: "+reason)
+}
+
+// autoRollback rolls back from the deployment d after it failed for the
+// given reason. This always returns an error: Aborted if the rollback
+// worked, otherwise the rollback error.
+func (a *App) autoRollback(ctx context.Context, d *pb.Deployment, reason string) error {
+	a.logger.Warn("automatically rolling back", "deployment", d.Id, "reason", reason)
+	rolledBack, err := a.rollbackDeploy(ctx, d)
+	if err != nil {
+		return status.Errorf(status.Code(err),
+			"%s; automatic rollback failed: %s", reason, status.Convert(err).Message())
+	}
+
+	return status.Errorf(codes.Aborted,
+		"%s; rolled back with deployment %s of artifact %s",
+		reason, rolledBack.Id, rolledBack.ArtifactId)
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppReleaseRollback(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	platform := &componentmocks.Platform{}
+	platform.On("DeployFunc").Return(func() component.Deployment {
+		return &empty.Empty{}
+	})
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testPlatformConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	deployRelease := func() *pb.Deployment {
+		d, err := app.Deploy(ctx, testPushArtifact(t, app))
+		require.NoError(err)
+		_, _, err = app.Release(ctx, d)
+		require.NoError(err)
+		return d
+	}
+
+	// Nothing to roll back from
+	_, err := app.ReleaseRollback(ctx, true)
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	first := deployRelease()
+
+	// Nothing to roll back to
+	_, err = app.ReleaseRollback(ctx, true)
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	second := deployRelease()
+
+	// Without a rollback window the release is baked right away
+	_, err = app.ReleaseRollback(ctx, false)
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	require.Contains(err.Error(), "confirmation")
+
+	// Rolling back releases the first deployment again
+	release, err := app.ReleaseRollback(ctx, true)
+	require.NoError(err)
+	require.Equal(first.Id, release.DeploymentId)
+
+	// Rolling back a deployment that was destroyed deploys its artifact again
+	second.State = pb.Operation_DESTROYED
+	_, err = app.client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: second,
+	})
+	require.NoError(err)
+
+	release, err = app.ReleaseRollback(ctx, true)
+	require.NoError(err)
+	require.NotEqual(second.Id, release.DeploymentId)
+
+	d, err := app.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: release.DeploymentId},
+		},
+	})
+	require.NoError(err)
+	require.Equal(second.ArtifactId, d.ArtifactId)
+}
+
+func TestAppDeploy_rollbackUnhealthy(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Once unhealthy is set, the deployment is reported unhealthy while it
+	// is deployed, like an "after" hook running a smoke test would.
+	var app *App
+	unhealthy := false
+	platform := &componentmocks.Platform{}
+	platform.On("DeployFunc").Return(func() component.Deployment {
+		if unhealthy {
+			resp, err := app.client.ListDeployments(ctx, &pb.ListDeploymentsRequest{
+				Application: app.ref,
+				Workspace:   app.workspace,
+			})
+			require.NoError(err)
+			for _, d := range resp.Deployments {
+				if d.Status.State == pb.Status_RUNNING {
+					testReportHealth(t, app, d, pb.HealthReport_UNHEALTHY, "crash loop")
+				}
+			}
+		}
+
+		return &empty.Empty{}
+	})
+	factory := TestFactory(t, component.PlatformType)
+	TestFactoryRegister(t, factory, "test", platform)
+
+	app = TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testRollbackConfig)),
+		WithFactory(component.PlatformType, factory),
+	), "test")
+
+	first, err := app.Deploy(ctx, testPushArtifact(t, app))
+	require.NoError(err)
+
+	// The next deployment is unhealthy so it is rolled back
+	unhealthy = true
+	bad, err := app.Deploy(ctx, testPushArtifact(t, app))
+	unhealthy = false
+	require.Error(err)
+	require.Equal(codes.Aborted, status.Code(err))
+	require.Contains(err.Error(), "crash loop")
+	require.NotNil(bad)
+
+	// The first artifact is released again
+	active, err := app.activeDeployment(ctx)
+	require.NoError(err)
+	require.Equal(first.ArtifactId, active.ArtifactId)
+	require.NotEqual(bad.Id, active.Id)
+}
+
+const testRollbackConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+
+		rollback = true
+	}
+}
+`
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+// OperationSpec describes an operation to execute for ScheduleOperation.
+// Exactly one of the operation fields must be set.
+type OperationSpec struct {
+	Build   *pb.Job_BuildOp
+	Deploy  *pb.Job_DeployOp
+	Release *pb.Job_ReleaseOp
+	Destroy *pb.Job_DestroyOp
+
+	// Labels are the labels to set on the job.
+	Labels map[string]string
+}
+
+// job returns the job that executes this operation for the given app.
+func (op *OperationSpec) job(a *App) (*pb.Job, error) {
+	job := &pb.Job{
+		Application: a.ref,
+		Workspace:   a.workspace,
+		Labels:      op.Labels,
+		TargetRunner: &pb.Ref_Runner{
+			Target: &pb.Ref_Runner_Any{
+				Any: &pb.Ref_RunnerAny{},
+			},
+		},
+	}
+
+	count := 0
+	if op.Build != nil {
+		job.Operation = &pb.Job_Build{Build: op.Build}
+		count++
+	}
+	if op.Deploy != nil {
+		job.Operation = &pb.Job_Deploy{Deploy: op.Deploy}
+		count++
+	}
+	if op.Release != nil {
+		job.Operation = &pb.Job_Release{Release: op.Release}
+		count++
+	}
+	if op.Destroy != nil {
+		job.Operation = &pb.Job_Destroy{Destroy: op.Destroy}
+		count++
+	}
+	if count != 1 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"exactly one operation must be set, got %d", count)
+	}
+
+	return job, nil
+}
+
+// ScheduleOperation records an operation on the server to be queued for
+// a runner at the given time, such as a deploy during a maintenance
+// window. This returns the ID of the scheduled operation, which can be
+// used with CancelScheduledOperation.
+func (a *App) ScheduleOperation(ctx context.Context, op OperationSpec, at time.Time) (string, error) {
+	job, err := op.job(a)
+	if err != nil {
+		return "", err
+	}
+
+	ts, err := ptypes.TimestampProto(at)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := a.client.ScheduleOperation(ctx, &pb.ScheduleOperationRequest{
+		Job:          job,
+		ScheduleTime: ts,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Id, nil
+}
+
+// CancelScheduledOperation cancels an operation scheduled with
+// ScheduleOperation. This fails if the operation was already queued.
+func (a *App) CancelScheduledOperation(ctx context.Context, id string) error {
+	_, err := a.client.CancelScheduledOperation(ctx, &pb.CancelScheduledOperationRequest{
+		Id: id,
+	})
+	return err
+}
+
+// ScheduleRecurring records an operation on the server to be queued for a
+// runner each time the cron expression matches, such as a nightly rebuild.
+// This returns the ID of the recurring schedule, which can be used with
+// RemoveRecurringSchedule.
+func (a *App) ScheduleRecurring(ctx context.Context, op OperationSpec, cronExpr string) (string, error) {
+	if _, err := serverptypes.NextCronTime(cronExpr, time.Now()); err != nil {
+		return "", status.Errorf(codes.InvalidArgument,
+			"invalid cron expression %q: %s", cronExpr, err)
+	}
+
+	job, err := op.job(a)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := a.client.ScheduleRecurringOperation(ctx, &pb.ScheduleRecurringOperationRequest{
+		Job:  job,
+		Cron: cronExpr,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Id, nil
+}
+
+// RecurringSchedules returns the recurring schedules for this app.
+func (a *App) RecurringSchedules(ctx context.Context) ([]*pb.RecurringSchedule, error) {
+	resp, err := a.client.ListRecurringSchedules(ctx, &pb.ListRecurringSchedulesRequest{
+		Application: a.ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Schedules, nil
+}
+
+// RemoveRecurringSchedule deletes a schedule created with
+// ScheduleRecurring. Jobs it already queued are not cancelled.
+func (a *App) RemoveRecurringSchedule(ctx context.Context, id string) error {
+	_, err := a.client.DeleteRecurringSchedule(ctx, &pb.DeleteRecurringScheduleRequest{
+		Id: id,
+	})
+	return err
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAppScheduleRecurring(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+	), "test")
+
+	op := OperationSpec{Build: &pb.Job_BuildOp{}}
+
+	// Invalid cron expressions are rejected
+	_, err := app.ScheduleRecurring(ctx, op, "every night")
+	require.Error(err)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	id, err := app.ScheduleRecurring(ctx, op, "0 2 * * *")
+	require.NoError(err)
+	require.NotEmpty(id)
+
+	schedules, err := app.RecurringSchedules(ctx)
+	require.NoError(err)
+	require.Len(schedules, 1)
+	require.Equal(id, schedules[0].Id)
+	require.Equal("0 2 * * *", schedules[0].Cron)
+	require.NotNil(schedules[0].NextTime)
+	require.NotNil(schedules[0].Job.GetBuild())
+
+	require.NoError(app.RemoveRecurringSchedule(ctx, id))
+	schedules, err = app.RecurringSchedules(ctx)
+	require.NoError(err)
+	require.Empty(schedules)
+}
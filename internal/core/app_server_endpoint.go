@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetServerEndpoint returns the server address that entrypoints of this
+// app's deployments use to reach the server, along with whether the
+// connection is insecure. A connection is insecure if it doesn't use TLS
+// or skips TLS verification.
+//
+// This returns a FailedPrecondition error if the server has no advertise
+// address configured.
+func (a *App) GetServerEndpoint(ctx context.Context) (string, bool, error) {
+	resp, err := a.client.GetServerConfig(ctx, &empty.Empty{})
+	if err != nil {
+		return "", false, err
+	}
+
+	// The server only supports a single advertise address currently, so
+	// this mirrors RunnerGetDeploymentConfig and uses the first.
+	if resp.Config == nil || len(resp.Config.AdvertiseAddrs) == 0 {
+		return "", false, status.Errorf(codes.FailedPrecondition,
+			"the server has no advertise address configured. Set one with "+
+				"`waypoint server config-set -advertise-addr`")
+	}
+	addr := resp.Config.AdvertiseAddrs[0]
+
+	return addr.Addr, !addr.Tls || addr.TlsSkipVerify, nil
+}
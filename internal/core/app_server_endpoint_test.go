@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppGetServerEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no advertise address", func(t *testing.T) {
+		require := require.New(t)
+
+		app := TestApp(t, TestProject(t,
+			WithConfig(config.TestConfig(t, testBuildConfig)),
+		), "test")
+
+		_, _, err := app.GetServerEndpoint(ctx)
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("configured address", func(t *testing.T) {
+		require := require.New(t)
+
+		app := TestApp(t, TestProject(t,
+			WithConfig(config.TestConfig(t, testBuildConfig)),
+		), "test")
+
+		_, err := app.client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+			Config: serverptypes.TestServerConfig(t, &pb.ServerConfig{
+				AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{
+					{Addr: "waypoint.example.com:9701", Tls: true},
+				},
+			}),
+		})
+		require.NoError(err)
+
+		addr, insecure, err := app.GetServerEndpoint(ctx)
+		require.NoError(err)
+		require.Equal("waypoint.example.com:9701", addr)
+		require.False(insecure)
+	})
+
+	t.Run("skip verify is insecure", func(t *testing.T) {
+		require := require.New(t)
+
+		app := TestApp(t, TestProject(t,
+			WithConfig(config.TestConfig(t, testBuildConfig)),
+		), "test")
+
+		_, err := app.client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+			Config: serverptypes.TestServerConfig(t, &pb.ServerConfig{
+				AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{
+					{Addr: "10.0.0.1:9701", Tls: true, TlsSkipVerify: true},
+				},
+			}),
+		})
+		require.NoError(err)
+
+		addr, insecure, err := app.GetServerEndpoint(ctx)
+		require.NoError(err)
+		require.Equal("10.0.0.1:9701", addr)
+		require.True(insecure)
+	})
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// labelSignature is the pushed artifact label that holds the base64-encoded
+// ed25519 signature of the pushed artifact. This is the artifact that is
+// deployed, rather than the artifact of the build it was pushed from. See
+// ArtifactSignatureDigest.
+const labelSignature = "waypoint/signature"
+
+// ArtifactSignatureDigest returns the digest of an artifact value that is
+// signed to produce the signature stored on a pushed artifact.
+func ArtifactSignatureDigest(v *any.Any) []byte {
+	h := sha256.New()
+	h.Write([]byte(v.TypeUrl))
+	h.Write([]byte{0})
+	h.Write(v.Value)
+	return h.Sum(nil)
+}
+
+// ValidateArtifactSignature verifies that the pushed artifact with the
+// given ID is signed by one of the trusted keys in the deploy signature
+// configuration. An error is returned if the signature is missing or
+// invalid, or if no signature configuration exists.
+func (a *App) ValidateArtifactSignature(ctx context.Context, pushedArtifactId string) error {
+	if a.config.Deploy == nil || a.config.Deploy.Signature == nil {
+		return status.Errorf(codes.FailedPrecondition,
+			"no trusted keys are configured for artifact signatures")
+	}
+	cfg := a.config.Deploy.Signature
+
+	push, err := a.client.GetPushedArtifact(ctx, &pb.GetPushedArtifactRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: pushedArtifactId},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if push.Artifact == nil || push.Artifact.Artifact == nil {
+		return status.Errorf(codes.FailedPrecondition,
+			"pushed artifact %s has no artifact to verify", pushedArtifactId)
+	}
+
+	raw, ok := push.Labels[labelSignature]
+	if !ok || raw == "" {
+		return status.Errorf(codes.PermissionDenied,
+			"pushed artifact %s is not signed", pushedArtifactId)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied,
+			"signature of pushed artifact %s is malformed: %s", pushedArtifactId, err)
+	}
+
+	digest := ArtifactSignatureDigest(push.Artifact.Artifact)
+	for _, k := range cfg.TrustedKeys {
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			a.logger.Warn("ignoring invalid trusted key", "key", k)
+			continue
+		}
+
+		if ed25519.Verify(ed25519.PublicKey(key), digest, sig) {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied,
+		"signature of pushed artifact %s is not valid for any trusted key", pushedArtifactId)
+}
+
+// checkArtifactSignature is called before a deploy to validate the
+// signature if that is configured. In warn mode a failure is output to
+// the UI but does not prevent the deploy.
+func (a *App) checkArtifactSignature(ctx context.Context, push *pb.PushedArtifact) error {
+	if a.config.Deploy == nil || a.config.Deploy.Signature == nil {
+		return nil
+	}
+
+	err := a.ValidateArtifactSignature(ctx, push.Id)
+	if err == nil || a.config.Deploy.Signature.Enforce() {
+		return err
+	}
+
+	a.logger.Warn("artifact signature invalid, continuing in warn mode", "err", err)
+	a.UI.Output("Warning: %s", status.Convert(err).Message(), terminal.WithWarningStyle())
+	return nil
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestAppValidateArtifactSignature(t *testing.T) {
+	ctx := context.Background()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, fmt.Sprintf(testSignatureConfig,
+			base64.StdEncoding.EncodeToString(pub)))),
+	), "test")
+
+	value, err := ptypes.MarshalAny(&empty.Empty{})
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(
+		ed25519.Sign(priv, ArtifactSignatureDigest(value)))
+
+	push := func(artifact *pb.Artifact, labels map[string]string) string {
+		resp, err := app.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+			Artifact: serverptypes.TestValidArtifact(t, &pb.PushedArtifact{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				Artifact:    artifact,
+				Labels:      labels,
+			}),
+		})
+		require.NoError(t, err)
+		return resp.Artifact.Id
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		require := require.New(t)
+
+		id := push(&pb.Artifact{Artifact: value}, map[string]string{
+			labelSignature: signature,
+		})
+		require.NoError(app.ValidateArtifactSignature(ctx, id))
+	})
+
+	t.Run("tampered artifact", func(t *testing.T) {
+		require := require.New(t)
+
+		tampered, err := ptypes.MarshalAny(&pb.Ref_Workspace{Workspace: "evil"})
+		require.NoError(err)
+
+		id := push(&pb.Artifact{Artifact: tampered}, map[string]string{
+			labelSignature: signature,
+		})
+		err = app.ValidateArtifactSignature(ctx, id)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("signed build with an unsigned push", func(t *testing.T) {
+		require := require.New(t)
+
+		// The build is signed but what is deployed is the pushed artifact
+		resp, err := app.client.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+			Build: serverptypes.TestValidBuild(t, &pb.Build{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				Artifact:    &pb.Artifact{Artifact: value},
+				Labels:      map[string]string{labelSignature: signature},
+			}),
+		})
+		require.NoError(err)
+
+		tampered, err := ptypes.MarshalAny(&pb.Ref_Workspace{Workspace: "evil"})
+		require.NoError(err)
+		pushResp, err := app.client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+			Artifact: serverptypes.TestValidArtifact(t, &pb.PushedArtifact{
+				Application: app.ref,
+				Workspace:   app.workspace,
+				BuildId:     resp.Build.Id,
+				Artifact:    &pb.Artifact{Artifact: tampered},
+			}),
+		})
+		require.NoError(err)
+
+		err = app.ValidateArtifactSignature(ctx, pushResp.Artifact.Id)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		require := require.New(t)
+
+		id := push(&pb.Artifact{Artifact: value}, nil)
+		err := app.ValidateArtifactSignature(ctx, id)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	})
+}
+
+const testSignatureConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+
+		signature {
+			trusted_keys = [%q]
+		}
+	}
+}
+`
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppLogLevel(t *testing.T) {
+	require := require.New(t)
+
+	log := hclog.New(&hclog.LoggerOptions{Level: hclog.Info})
+
+	// Make our factory for builders
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+		WithFactory(component.BuilderType, factory),
+		WithLogger(log),
+	), "test")
+	require.False(app.logger.IsTrace())
+
+	// Change the level on the root logger like the CLI -log-level flag does
+	log.SetLevel(hclog.Trace)
+	require.True(app.logger.IsTrace())
+
+	// The logger given to plugin functions should have the level too
+	var funcLog hclog.Logger
+	artifact := &componentmocks.Artifact{}
+	artifact.On("Labels").Return(map[string]string{})
+	mock.On("BuildFunc").Return(func(l hclog.Logger) component.Artifact {
+		funcLog = l
+		return artifact
+	})
+
+	_, _, err := app.Build(context.Background(), BuildWithPush(false))
+	require.NoError(err)
+	require.NotNil(funcLog)
+	require.True(funcLog.IsTrace())
+}
@@ -2,27 +2,78 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/hashicorp/waypoint/internal/config"
 )
 
 // execHook executes the given hook. This will return any errors. This ignores
 // on_failure configurations so this must be processed external.
+//
+// Each attempt is limited to the hook timeout, if set, and a failed hook
+// is retried as many times as configured. The last error is returned.
+//
+// If the hook has a condition that isn't met, this does nothing.
 func (a *App) execHook(ctx context.Context, log hclog.Logger, h *config.Hook) error {
+	if !h.ConditionMet(os.Getenv) {
+		log.Debug("hook condition not met, skipping", "condition", h.Condition)
+		return nil
+	}
+
+	// We log the raw command since the expanded one may contain secrets.
 	log.Debug("executing hook", "command", h.Command)
+	command := hookCommand(h, os.Getenv)
+
+	var err error
+	for attempt := 0; attempt <= h.Retries; attempt++ {
+		if attempt > 0 {
+			log.Info("retrying hook", "attempt", attempt, "retries", h.Retries)
+		}
+
+		if err = execHookCommand(ctx, log, a.UI, command, h.TimeoutDuration()); err == nil {
+			return nil
+		}
+
+		// If our parent context is done we don't retry.
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// execHookCommand runs command once with its output sent to ui. If timeout
+// is non-zero, the command is killed if it hasn't exited after that long.
+func execHookCommand(
+	ctx context.Context,
+	log hclog.Logger,
+	ui terminal.UI,
+	command []string,
+	timeout time.Duration,
+) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
 	// Get our writers
-	stdout, stderr, err := a.UI.OutputWriters()
+	stdout, stderr, err := ui.OutputWriters()
 	if err != nil {
 		log.Warn("error getting UI stdout/stderr", "err", err)
 		return err
 	}
 
 	// Build our command
-	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
@@ -41,9 +92,126 @@ func (a *App) execHook(ctx context.Context, log hclog.Logger, h *config.Hook) er
 			L = L.With("code", exiterr.ExitCode())
 		}
 
+		if timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			L.Warn("command timed out", "timeout", timeout)
+			return fmt.Errorf("hook timed out after %s", timeout)
+		}
+
 		L.Warn("error running command", "err", err)
 		return err
 	}
 
 	return nil
 }
+
+// HookPlan describes a hook that would run. This is returned by
+// App.DryRunHooks.
+type HookPlan struct {
+	// Component is the type of component the hook belongs to, such
+	// as "builder" or "platform".
+	Component string
+
+	// Index is the index of the hook within the component's hooks
+	// for the same "when" value.
+	Index int
+
+	// Command is the command that would run. If the hook interpolates env
+	// vars, values of env vars that look like secrets are redacted.
+	Command []string
+
+	// OnFailure is "fail" or "continue".
+	OnFailure string
+}
+
+// DryRunHooks returns the hooks that would run for the given "when" value
+// ("before", "after", or "cancel") across all components, without
+// executing them. Hooks with conditions that aren't currently met are
+// excluded.
+func (a *App) DryRunHooks(ctx context.Context, when string) ([]HookPlan, error) {
+	type namedComponent struct {
+		Name      string
+		Component interface{}
+	}
+
+	components := []namedComponent{{"builder", a.Builder}}
+	if len(a.Registries) > 1 {
+		for i, r := range a.Registries {
+			components = append(components, namedComponent{fmt.Sprintf("registry[%d]", i), r})
+		}
+	} else {
+		components = append(components, namedComponent{"registry", a.Registry})
+	}
+	components = append(components,
+		namedComponent{"platform", a.Platform},
+		namedComponent{"releaser", a.Releaser},
+	)
+
+	var result []HookPlan
+	for _, c := range components {
+		if c.Component == nil {
+			continue
+		}
+
+		info, ok := a.components[c.Component]
+		if !ok {
+			continue
+		}
+
+		for i, h := range info.Hooks[when] {
+			if !h.ConditionMet(os.Getenv) {
+				continue
+			}
+
+			onFailure := "fail"
+			if h.ContinueOnFailure() {
+				onFailure = "continue"
+			}
+
+			result = append(result, HookPlan{
+				Component: c.Name,
+				Index:     i,
+				Command:   hookCommand(h, redactedGetenv),
+				OnFailure: onFailure,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// hookCommand returns the hook command with env vars expanded if the hook
+// interpolates them.
+func hookCommand(h *config.Hook, getenv func(string) string) []string {
+	if !h.Interpolate {
+		return h.Command
+	}
+
+	result := make([]string, len(h.Command))
+	for i, arg := range h.Command {
+		result[i] = os.Expand(arg, getenv)
+	}
+
+	return result
+}
+
+// redactedGetenv is os.Getenv but returns a placeholder for env vars whose
+// names look like they hold secrets.
+func redactedGetenv(k string) string {
+	v := os.Getenv(k)
+	if v == "" {
+		return v
+	}
+
+	upper := strings.ToUpper(k)
+	for _, s := range secretEnvSubstrings {
+		if strings.Contains(upper, s) {
+			return "[REDACTED]"
+		}
+	}
+
+	return v
+}
+
+var secretEnvSubstrings = []string{
+	"TOKEN", "SECRET", "PASSWORD", "PASSWD", "CREDENTIAL", "KEY", "AUTH",
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppDryRunHooks(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(os.Setenv("WP_TEST_HOOK_ENV", "prod"))
+	require.NoError(os.Setenv("WP_TEST_HOOK_TOKEN", "hunter2"))
+	defer os.Unsetenv("WP_TEST_HOOK_ENV")
+	defer os.Unsetenv("WP_TEST_HOOK_TOKEN")
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testDryRunHooksConfig)),
+	), "test")
+
+	plan, err := app.DryRunHooks(context.Background(), "before")
+	require.NoError(err)
+	require.Len(plan, 3)
+
+	// Unconditional hook
+	require.Equal("builder", plan[0].Component)
+	require.Equal(0, plan[0].Index)
+	require.Equal([]string{"echo", "always"}, plan[0].Command)
+	require.Equal("fail", plan[0].OnFailure)
+
+	// Conditional hook that matches, with the secret redacted
+	require.Equal("builder", plan[1].Component)
+	require.Equal(1, plan[1].Index)
+	require.Equal([]string{"notify", "prod", "[REDACTED]"}, plan[1].Command)
+	require.Equal("continue", plan[1].OnFailure)
+
+	// Hook that doesn't interpolate, so the shell gets the command as is
+	require.Equal("builder", plan[2].Component)
+	require.Equal(2, plan[2].Index)
+	require.Equal([]string{"sh", "-c", "echo ${WP_TEST_HOOK_ENV:-x} $1"}, plan[2].Command)
+
+	// After hooks are all conditional on something unset
+	plan, err = app.DryRunHooks(context.Background(), "after")
+	require.NoError(err)
+	require.Empty(plan)
+}
+
+func TestAppExecHook_timeout(t *testing.T) {
+	require := require.New(t)
+
+	app := TestApp(t, TestProject(t), "test")
+
+	start := time.Now()
+	err := app.execHook(context.Background(), hclog.L(), &config.Hook{
+		When:    "before",
+		Command: []string{"sleep", "30"},
+		Timeout: "100ms",
+	})
+	require.Error(err)
+	require.Contains(err.Error(), "timed out")
+	require.True(time.Since(start) < 10*time.Second)
+}
+
+func TestAppExecHook_retries(t *testing.T) {
+	// Our hook appends a line to a file and only succeeds on the third run.
+	hook := func(path string, retries int) *config.Hook {
+		return &config.Hook{
+			When: "before",
+			Command: []string{"sh", "-c",
+				"echo x >> " + path + "; test $(wc -l < " + path + ") -ge 3"},
+			Retries: retries,
+		}
+	}
+
+	runs := func(t *testing.T, path string) int {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		n := 0
+		for _, b := range data {
+			if b == '\n' {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	t.Run("succeeds within retries", func(t *testing.T) {
+		require := require.New(t)
+
+		td, err := ioutil.TempDir("", "waypoint")
+		require.NoError(err)
+		defer os.RemoveAll(td)
+
+		app := TestApp(t, TestProject(t), "test")
+		path := filepath.Join(td, "runs")
+		require.NoError(app.execHook(context.Background(), hclog.L(), hook(path, 2)))
+		require.Equal(3, runs(t, path))
+	})
+
+	t.Run("fails after retries", func(t *testing.T) {
+		require := require.New(t)
+
+		td, err := ioutil.TempDir("", "waypoint")
+		require.NoError(err)
+		defer os.RemoveAll(td)
+
+		app := TestApp(t, TestProject(t), "test")
+		path := filepath.Join(td, "runs")
+		require.Error(app.execHook(context.Background(), hclog.L(), hook(path, 1)))
+		require.Equal(2, runs(t, path))
+	})
+}
+
+const testDryRunHooksConfig = `
+project = "test"
+
+app "test" {
+	build {
+		use "test" {}
+
+		hook {
+			when    = "before"
+			command = ["echo", "always"]
+		}
+
+		hook {
+			when        = "before"
+			command     = ["notify", "$WP_TEST_HOOK_ENV", "$${WP_TEST_HOOK_TOKEN}"]
+			interpolate = true
+			condition   = "WP_TEST_HOOK_ENV=prod"
+			on_failure  = "continue"
+		}
+
+		hook {
+			when    = "before"
+			command = ["sh", "-c", "echo $${WP_TEST_HOOK_ENV:-x} $1"]
+		}
+
+		hook {
+			when      = "before"
+			command   = ["echo", "staging"]
+			condition = "WP_TEST_HOOK_ENV=staging"
+		}
+
+		hook {
+			when      = "after"
+			command   = ["echo", "unset"]
+			condition = "WP_TEST_HOOK_UNSET"
+		}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
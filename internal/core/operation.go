@@ -70,6 +70,20 @@ func (a *App) doOperation(
 		f.Set(reflect.ValueOf(a.jobInfo.Id))
 	}
 
+	// Setup our VCS info if we have it and that field.
+	if vcs := a.project.vcs; vcs != nil {
+		if f := msgField(msg, "Vcs"); f.IsValid() {
+			f.Set(reflect.ValueOf(vcs))
+		}
+	}
+
+	// Setup our input variable values if we have them and that field.
+	if vars := a.project.variables; len(vars) > 0 {
+		if f := msgField(msg, "Variables"); f.IsValid() {
+			f.Set(reflect.ValueOf(vars))
+		}
+	}
+
 	// If we have no status pointer, then we just allocate one for this
 	// function. We don't send this anywhere but this just lets us follow
 	// the remaining logic without a bunch of nil checks.
@@ -165,10 +179,22 @@ func (a *App) doOperation(
 
 	// If our context ended we need to create a final context so we
 	// can attempt to finalize our metadata.
-	if ctx.Err() != nil {
+	if err := ctx.Err(); err != nil {
 		var cancel context.CancelFunc
 		ctx, cancel = finalcontext.Context(log)
 		defer cancel()
+
+		// If we were cancelled, such as by a CancelJob request, then
+		// run the cancel hooks so they can clean up anything the operation
+		// left behind. Errors here are only logged since the operation
+		// has already failed.
+		if err == context.Canceled {
+			for i, h := range hooks["cancel"] {
+				if err := a.execHook(ctx, log.Named(fmt.Sprintf("hook-cancel-%d", i)), h); err != nil {
+					log.Warn("error running cancel hook", "err", err)
+				}
+			}
+		}
 	}
 
 	// Set the final metadata
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// Pipeline runs the stages of an app pipeline in order, stopping at the
+// first stage that fails.
+//
+// Build, deploy, and release stages each generate a job that is executed
+// with DoJob. Results are passed on to later stages: a deploy uses the
+// artifact of the most recent build and a release uses the most recent
+// deployment to the same workspace. Hook stages run their command in this
+// process and approval stages call Approve.
+type Pipeline struct {
+	Stages []*config.PipelineStage
+
+	// DoJob executes the job for a stage and returns its result. The job
+	// only has the operation set, plus the workspace if the stage has one.
+	// The caller is responsible for filling in the rest of the job.
+	DoJob func(context.Context, *pb.Job) (*pb.Job_Result, error)
+
+	// Approve is called for approval stages. The pipeline only continues
+	// if this returns nil. If this is nil then approval stages fail.
+	Approve func(context.Context, *config.PipelineStage) error
+
+	UI     terminal.UI
+	Logger hclog.Logger
+}
+
+// Run runs every stage of the pipeline.
+func (p *Pipeline) Run(ctx context.Context) error {
+	log := p.Logger
+	if log == nil {
+		log = hclog.L()
+	}
+
+	var artifact *pb.PushedArtifact
+	deployments := map[string]*pb.Deployment{}
+	for i, s := range p.Stages {
+		L := log.With("stage", s.Name, "type", s.Type)
+		p.UI.Output("Stage %d of %d: %s", i+1, len(p.Stages), s.Name,
+			terminal.WithHeaderStyle())
+
+		var err error
+		switch s.Type {
+		case "build":
+			var result *pb.Job_Result
+			result, err = p.doJob(ctx, s, &pb.Job{
+				Operation: &pb.Job_Build{
+					Build: &pb.Job_BuildOp{},
+				},
+			})
+			if err == nil {
+				artifact = result.Build.GetPush()
+			}
+
+		case "deploy":
+			if artifact == nil {
+				err = status.Errorf(codes.FailedPrecondition,
+					"no artifact has been built and pushed by an earlier stage")
+				break
+			}
+
+			var result *pb.Job_Result
+			result, err = p.doJob(ctx, s, &pb.Job{
+				Operation: &pb.Job_Deploy{
+					Deploy: &pb.Job_DeployOp{Artifact: artifact},
+				},
+			})
+			if err == nil {
+				deployments[s.Workspace] = result.Deploy.GetDeployment()
+			}
+
+		case "release":
+			d := deployments[s.Workspace]
+			if d == nil {
+				err = status.Errorf(codes.FailedPrecondition,
+					"no earlier stage deployed to this workspace")
+				break
+			}
+
+			_, err = p.doJob(ctx, s, &pb.Job{
+				Operation: &pb.Job_Release{
+					Release: &pb.Job_ReleaseOp{
+						Deployment: d,
+						Prune:      true,
+					},
+				},
+			})
+
+		case "hook":
+			command := hookCommand(&config.Hook{Command: s.Command}, os.Getenv)
+			err = execHookCommand(ctx, L, p.UI, command, 0)
+
+		case "approval":
+			if p.Approve == nil {
+				err = status.Errorf(codes.FailedPrecondition,
+					"approval stages are not supported here")
+				break
+			}
+
+			err = p.Approve(ctx, s)
+
+		default:
+			err = status.Errorf(codes.InvalidArgument,
+				"unknown stage type %q", s.Type)
+		}
+
+		if err != nil {
+			L.Warn("pipeline stage failed", "err", err)
+			return fmt.Errorf("pipeline stage %q failed: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// doJob executes the job for the stage, targeting the stage workspace.
+func (p *Pipeline) doJob(ctx context.Context, s *config.PipelineStage, job *pb.Job) (*pb.Job_Result, error) {
+	if s.Workspace != "" {
+		job.Workspace = &pb.Ref_Workspace{Workspace: s.Workspace}
+	}
+
+	return p.DoJob(ctx, job)
+}
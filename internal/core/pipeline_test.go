@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/config"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestPipelineRun(t *testing.T) {
+	stages := []*config.PipelineStage{
+		{Name: "build", Type: "build"},
+		{Name: "test", Type: "hook", Command: []string{"true"}},
+		{Name: "staging", Type: "deploy", Workspace: "staging"},
+		{Name: "approve", Type: "approval"},
+		{Name: "prod", Type: "deploy", Workspace: "prod"},
+		{Name: "release", Type: "release", Workspace: "prod"},
+	}
+
+	// doJob records the jobs and returns a result for each operation.
+	var jobs []*pb.Job
+	doJob := func(ctx context.Context, job *pb.Job) (*pb.Job_Result, error) {
+		jobs = append(jobs, job)
+
+		switch op := job.Operation.(type) {
+		case *pb.Job_Build:
+			return &pb.Job_Result{Build: &pb.Job_BuildResult{
+				Push: &pb.PushedArtifact{Id: "A"},
+			}}, nil
+
+		case *pb.Job_Deploy:
+			return &pb.Job_Result{Deploy: &pb.Job_DeployResult{
+				Deployment: &pb.Deployment{
+					Id:         job.Workspace.GetWorkspace(),
+					ArtifactId: op.Deploy.Artifact.Id,
+				},
+			}}, nil
+
+		default:
+			return &pb.Job_Result{}, nil
+		}
+	}
+
+	t.Run("all stages", func(t *testing.T) {
+		require := require.New(t)
+		jobs = nil
+
+		var approved []string
+		p := &Pipeline{
+			Stages: stages,
+			DoJob:  doJob,
+			Approve: func(ctx context.Context, s *config.PipelineStage) error {
+				approved = append(approved, s.Name)
+				return nil
+			},
+			UI:     terminal.NonInteractiveUI(context.Background()),
+			Logger: hclog.L(),
+		}
+		require.NoError(p.Run(context.Background()))
+		require.Equal([]string{"approve"}, approved)
+
+		// Build, two deploys, and the release are jobs
+		require.Len(jobs, 4)
+		require.Nil(jobs[0].Workspace)
+		require.Equal("staging", jobs[1].Workspace.Workspace)
+		require.Equal("A", jobs[1].Operation.(*pb.Job_Deploy).Deploy.Artifact.Id)
+		require.Equal("prod", jobs[2].Workspace.Workspace)
+
+		// The release is of the deployment to the same workspace
+		release := jobs[3].Operation.(*pb.Job_Release).Release
+		require.Equal("prod", release.Deployment.Id)
+	})
+
+	t.Run("approval denied", func(t *testing.T) {
+		require := require.New(t)
+		jobs = nil
+
+		p := &Pipeline{
+			Stages: stages,
+			DoJob:  doJob,
+			Approve: func(ctx context.Context, s *config.PipelineStage) error {
+				return errors.New("denied")
+			},
+			UI: terminal.NonInteractiveUI(context.Background()),
+		}
+		err := p.Run(context.Background())
+		require.Error(err)
+		require.Contains(err.Error(), "approve")
+
+		// Nothing after the approval ran
+		require.Len(jobs, 2)
+	})
+
+	t.Run("failed hook", func(t *testing.T) {
+		require := require.New(t)
+		jobs = nil
+
+		p := &Pipeline{
+			Stages: []*config.PipelineStage{
+				{Name: "build", Type: "build"},
+				{Name: "test", Type: "hook", Command: []string{"false"}},
+				{Name: "staging", Type: "deploy"},
+			},
+			DoJob: doJob,
+			UI:    terminal.NonInteractiveUI(context.Background()),
+		}
+		require.Error(p.Run(context.Background()))
+		require.Len(jobs, 1)
+	})
+}
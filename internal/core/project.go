@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
@@ -43,12 +44,22 @@ type Project struct {
 	// labels is the list of labels that are assigned to this project.
 	labels map[string]string
 
+	// pluginVersions are the known versions of plugins by plugin name.
+	pluginVersions map[string]string
+
 	// workspace is the workspace that this project will work in.
 	workspace string
 
 	// jobInfo is the base job info for executed functions.
 	jobInfo *component.JobInfo
 
+	// vcs is the version control information recorded on operations.
+	vcs *pb.Operation_VCS
+
+	// variables are the final values of the input variables recorded
+	// on operations.
+	variables map[string]string
+
 	// This lock only needs to be held currently to protect localClosers.
 	lock sync.Mutex
 
@@ -63,6 +74,23 @@ type Project struct {
 	// overrideLabels are the labels specified via the CLI to override
 	// all other conflicting keys.
 	overrideLabels map[string]string
+
+	// tracer, if non-nil, records all dynamic function calls.
+	tracer *callTracer
+
+	// outputSinks, if set, receive output from components instead of UI.
+	outputSinks []OutputSink
+
+	// componentPrefix, if true, prefixes all output from components
+	// with the component name.
+	componentPrefix bool
+
+	// appPrefix is true if app UIs prefix output with the app name. This
+	// is set when the project has more than one app.
+	appPrefix bool
+
+	// uiLock serializes writes from all app UIs to UI.
+	uiLock sync.Mutex
 }
 
 // NewProject creates a new Project with the given options.
@@ -110,9 +138,15 @@ func NewProject(ctx context.Context, os ...Option) (*Project, error) {
 	if err := opts.Config.Validate(); err != nil {
 		return nil, err
 	}
+	if err := p.checkPluginVersions(opts.Config); err != nil {
+		return nil, err
+	}
 	if errs := config.ValidateLabels(p.overrideLabels); len(errs) > 0 {
 		return nil, multierror.Append(nil, errs...)
 	}
+	if len(p.outputSinks) > 0 && newSinkUI(p.outputSinks).primary == nil {
+		return nil, fmt.Errorf("WithOutputSinks requires at least one sink with a UI")
+	}
 
 	// Init our server connection. This may be in-process if we're in
 	// local mode.
@@ -126,20 +160,73 @@ func NewProject(ctx context.Context, os ...Option) (*Project, error) {
 	// Set our final job info
 	p.jobInfo.Workspace = p.workspace
 
-	// Initialize all the applications and load all their components.
+	// Initialize all the applications and load all their components. We
+	// initialize every app even if one fails so that all the configuration
+	// errors are reported at once.
+	p.appPrefix = len(opts.Config.Apps) > 1
+	var appErr error
 	for _, appConfig := range opts.Config.Apps {
 		app, err := newApp(ctx, p, appConfig, opts.ConfigContext)
 		if err != nil {
-			return nil, err
+			appErr = multierror.Append(appErr, fmt.Errorf("app %q: %s", appConfig.Name, err))
+			continue
 		}
 
 		p.apps[appConfig.Name] = app
 	}
+	if appErr != nil {
+		for _, app := range p.apps {
+			app.Close()
+		}
+
+		return nil, appErr
+	}
 
 	p.logger.Info("project initialized", "workspace", p.workspace)
 	return p, nil
 }
 
+// checkPluginVersions verifies that the plugins satisfy the version
+// constraints of the configuration.
+func (p *Project) checkPluginVersions(cfg *config.Config) error {
+	var result error
+	for _, pluginCfg := range cfg.Plugin {
+		constraints, err := pluginCfg.VersionConstraints()
+		if err != nil {
+			return err
+		}
+		if constraints == nil {
+			continue
+		}
+
+		raw, ok := p.pluginVersions[pluginCfg.Name]
+		if !ok {
+			result = multierror.Append(result, fmt.Errorf(
+				"plugin %q requires version %q but its version is unknown. "+
+					"Install it with `waypoint plugin install` so that its version "+
+					"is recorded.",
+				pluginCfg.Name, pluginCfg.Version))
+			continue
+		}
+
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"plugin %q has invalid version %q: %s", pluginCfg.Name, raw, err))
+			continue
+		}
+
+		if !constraints.Check(v) {
+			result = multierror.Append(result, fmt.Errorf(
+				"plugin %q version %s does not satisfy the required version %q. "+
+					"Run `waypoint plugin install %s` to install a matching version.",
+				pluginCfg.Name, raw, pluginCfg.Version, pluginCfg.Name))
+		}
+	}
+
+	return result
+}
+
 // App initializes and returns the app with the given name.
 func (p *Project) App(name string) (*App, error) {
 	return p.apps[name], nil
@@ -296,7 +383,53 @@ func WithUI(ui terminal.UI) Option {
 	return func(p *Project, opts *options) { p.UI = ui }
 }
 
+// WithComponentPrefix enables labeling all UI output from plugin functions
+// with the name of the component that produced it. The CLI sets this with
+// -component-prefix.
+func WithComponentPrefix(v bool) Option {
+	return func(p *Project, opts *options) { p.componentPrefix = v }
+}
+
+// WithOutputSinks sends output from components to each of the given sinks
+// instead of only the UI. This can be used for example to send output to
+// the terminal and a file at once, with each filtered differently. At least
+// one sink must have a UI. The CLI adds a file sink with -output-file.
+func WithOutputSinks(sinks ...OutputSink) Option {
+	return func(p *Project, opts *options) { p.outputSinks = sinks }
+}
+
+// WithCallTrace enables writing a trace of every plugin function call
+// made by operations to the file at path, with one JSON object per line.
+// Argument and result values are redacted; only timings, types, and errors
+// are written. The CLI sets this with -call-trace.
+func WithCallTrace(path string) Option {
+	return func(p *Project, opts *options) {
+		if path != "" {
+			p.tracer = newCallTracer(path)
+		}
+	}
+}
+
+// WithPluginVersions sets the versions of the plugins that are used, keyed
+// by plugin name. Plugins with a version constraint in the configuration
+// must have a version that satisfies it.
+func WithPluginVersions(m map[string]string) Option {
+	return func(p *Project, opts *options) { p.pluginVersions = m }
+}
+
 // WithJobInfo sets the base job info used for any executed operations.
 func WithJobInfo(info *component.JobInfo) Option {
 	return func(p *Project, opts *options) { p.jobInfo = info }
 }
+
+// WithVCS sets the version control information of the source that is
+// recorded on operations that have it, such as builds and deployments.
+func WithVCS(vcs *pb.Operation_VCS) Option {
+	return func(p *Project, opts *options) { p.vcs = vcs }
+}
+
+// WithVariables sets the final values of the input variables of the
+// project as HCL syntax. These are recorded on operations.
+func WithVariables(vars map[string]string) Option {
+	return func(p *Project, opts *options) { p.variables = vars }
+}
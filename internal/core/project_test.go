@@ -1,12 +1,18 @@
 package core
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	//"github.com/stretchr/testify/mock"
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/datadir"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/server/singleprocess"
 )
 
 func TestNewProject(t *testing.T) {
@@ -35,3 +41,116 @@ app "test" {
 	}
 }
 `
+
+func TestNewProject_allErrors(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	projDir, err := datadir.NewProject(td)
+	require.NoError(err)
+
+	builders, _ := TestFactorySingle(t, component.BuilderType, "test")
+	platforms, _ := TestFactorySingle(t, component.PlatformType, "test")
+	_, err = NewProject(context.Background(),
+		WithClient(singleprocess.TestServer(t)),
+		WithConfig(config.TestConfig(t, testNewProjectAllErrorsConfig)),
+		WithDataDir(projDir),
+		WithFactory(component.BuilderType, builders),
+		WithFactory(component.PlatformType, platforms),
+	)
+	require.Error(err)
+
+	// The errors of every app are reported
+	require.Contains(err.Error(), `app "a"`)
+	require.Contains(err.Error(), `"nope-a"`)
+	require.Contains(err.Error(), `app "b"`)
+	require.Contains(err.Error(), `"nope-b"`)
+}
+
+const testNewProjectAllErrorsConfig = `
+project = "test"
+
+app "a" {
+	build {
+		use "nope-a" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+
+app "b" {
+	build {
+		use "nope-b" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
+
+func TestProjectCheckPluginVersions(t *testing.T) {
+	cfg := config.TestConfig(t, testProjectPluginVersionConfig)
+
+	cases := []struct {
+		Name     string
+		Versions map[string]string
+		Err      string
+	}{
+		{
+			"satisfied",
+			map[string]string{"test": "0.4.1"},
+			"",
+		},
+
+		{
+			"not satisfied",
+			map[string]string{"test": "1.2.0"},
+			"does not satisfy",
+		},
+
+		{
+			"unknown version",
+			nil,
+			"unknown",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			p := &Project{pluginVersions: tt.Versions}
+			err := p.checkPluginVersions(cfg)
+			if tt.Err == "" {
+				require.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.Err)
+		})
+	}
+}
+
+const testProjectPluginVersionConfig = `
+project = "test"
+
+plugin "test" {
+	version = ">= 0.3, < 1.0"
+}
+
+app "test" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// callTracer records a structured trace of every dynamic function call
+// made during operations and writes it to a file with one JSON object per
+// line. This is meant for offline debugging: users can attach the file to
+// bug reports.
+//
+// Argument and result values are never recorded since they may contain
+// secrets. Only their types are written to the trace.
+type callTracer struct {
+	path string
+
+	lock sync.Mutex
+	f    *os.File
+}
+
+// callTraceEntry is a single dynamic function invocation in the trace.
+type callTraceEntry struct {
+	App        string    `json:"app"`
+	Component  string    `json:"component"`
+	Start      time.Time `json:"start"`
+	DurationMs int64     `json:"duration_ms"`
+	Args       int       `json:"args"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// callTracers are the tracers created by newCallTracer, keyed by path.
+var (
+	callTracersLock sync.Mutex
+	callTracers     = map[string]*callTracer{}
+)
+
+// newCallTracer returns the tracer that writes to path. Projects in the
+// same process that trace to the same path share the tracer, so that a
+// local "up", which creates a project for every job, writes one trace with
+// every call.
+func newCallTracer(path string) *callTracer {
+	callTracersLock.Lock()
+	defer callTracersLock.Unlock()
+
+	t, ok := callTracers[path]
+	if !ok {
+		t = &callTracer{path: path}
+		callTracers[path] = t
+	}
+
+	return t
+}
+
+// Record appends an entry to the trace file as a single line. The file is
+// truncated by the first entry so that a trace only has the calls of this
+// process. Every written line is complete, so the trace is usable even if
+// the process exits abruptly.
+func (t *callTracer) Record(log hclog.Logger, e *callTraceEntry) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.Warn("error encoding call trace entry", "err", err)
+		return
+	}
+
+	if t.f == nil {
+		t.f, err = os.OpenFile(t.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+		if err != nil {
+			log.Warn("error opening call trace", "path", t.path, "err", err)
+			return
+		}
+	}
+
+	if _, err := t.f.Write(append(data, '\n')); err != nil {
+		log.Warn("error writing call trace", "path", t.path, "err", err)
+	}
+}
+
+// traceCall records a call to a dynamic function if tracing is enabled.
+func (a *App) traceCall(
+	log hclog.Logger,
+	c interface{},
+	start time.Time,
+	args int,
+	result interface{},
+	err error,
+) {
+	if a.project.tracer == nil {
+		return
+	}
+
+	e := &callTraceEntry{
+		App:        a.config.Name,
+		Component:  fmt.Sprintf("%T", c),
+		Start:      start,
+		DurationMs: time.Since(start).Milliseconds(),
+		Args:       args,
+		Result:     "success",
+	}
+	if err != nil {
+		e.Result = "error"
+		e.Error = err.Error()
+	} else if result != nil {
+		e.Result = fmt.Sprintf("%T", result)
+	}
+
+	a.project.tracer.Record(log, e)
+}
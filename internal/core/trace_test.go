@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mockpkg "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestCallTrace(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	path := filepath.Join(td, "trace.json")
+
+	// Make our factory for builders
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	// Make our app
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+		WithFactory(component.BuilderType, factory),
+		WithCallTrace(path),
+	), "test")
+
+	// A successful build
+	artifact := &componentmocks.Artifact{}
+	artifact.On("Labels").Return(map[string]string{})
+	mock.On("BuildFunc").Return(func() component.Artifact {
+		return artifact
+	})
+	_, _, err = app.Build(context.Background(), BuildWithPush(false))
+	require.NoError(err)
+
+	// A failed build
+	mock.Mock = mockpkg.Mock{}
+	mock.On("BuildFunc").Return(func() (component.Artifact, error) {
+		return nil, fmt.Errorf("secret-free failure")
+	})
+	_, _, err = app.Build(context.Background(), BuildWithPush(false), BuildWithCache(false))
+	require.Error(err)
+
+	// Read the trace, which has a line per call
+	f, err := os.Open(path)
+	require.NoError(err)
+	defer f.Close()
+
+	var entries []*callTraceEntry
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var e callTraceEntry
+		require.NoError(dec.Decode(&e))
+		entries = append(entries, &e)
+	}
+	require.Len(entries, 2)
+
+	require.Equal("test", entries[0].App)
+	require.True(entries[0].DurationMs >= 0)
+	require.Equal(fmt.Sprintf("%T", artifact), entries[0].Result)
+	require.Empty(entries[0].Error)
+
+	require.Equal("error", entries[1].Result)
+	require.Contains(entries[1].Error, "secret-free failure")
+}
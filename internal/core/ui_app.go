@@ -0,0 +1,64 @@
+package core
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// appUI is the terminal.UI for a single app. When a project has more than
+// one app, all output is prefixed with the app name. Writes are serialized
+// with a lock shared by every app in the project so that operations on
+// multiple apps can run in parallel without interleaving partial output.
+type appUI struct {
+	terminal.UI
+
+	lock *sync.Mutex
+}
+
+// newAppUI returns the UI for the app with the given name. The lock must
+// be shared by all apps that write to ui.
+func newAppUI(ui terminal.UI, lock *sync.Mutex, name string, prefix bool) *appUI {
+	if prefix {
+		ui = newPrefixUI(ui, name)
+	}
+
+	return &appUI{UI: ui, lock: lock}
+}
+
+func (u *appUI) Input(input *terminal.Input) (string, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.UI.Input(input)
+}
+
+func (u *appUI) Output(msg string, raw ...interface{}) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.UI.Output(msg, raw...)
+}
+
+func (u *appUI) NamedValues(rows []terminal.NamedValue, opts ...terminal.Option) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.UI.NamedValues(rows, opts...)
+}
+
+func (u *appUI) Table(tbl *terminal.Table, opts ...terminal.Option) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.UI.Table(tbl, opts...)
+}
+
+func (u *appUI) OutputWriters() (io.Writer, io.Writer, error) {
+	stdout, stderr, err := u.UI.OutputWriters()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &lockedWriter{w: stdout, lock: u.lock},
+		&lockedWriter{w: stderr, lock: u.lock}, nil
+}
+
+var _ terminal.UI = (*appUI)(nil)
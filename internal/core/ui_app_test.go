@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint/internal/config"
+	"github.com/hashicorp/waypoint/internal/factory"
+)
+
+func TestAppUI_prefix(t *testing.T) {
+	t.Run("single app", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := &recordingUI{}
+		app := TestApp(t, TestProject(t,
+			WithConfig(config.TestConfig(t, testBuildConfig)),
+			WithFactory(component.BuilderType, testAppUIFactory(t)),
+			WithUI(ui),
+		), "test")
+
+		app.UI.Output("hello")
+		require.Equal([]string{"hello"}, ui.Lines)
+	})
+
+	t.Run("multiple apps", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := &recordingUI{}
+		p := TestProject(t,
+			WithConfig(config.TestConfig(t, testAppUIConfig)),
+			WithFactory(component.BuilderType, testAppUIFactory(t)),
+			WithUI(ui),
+		)
+
+		app := TestApp(t, p, "web")
+		app.UI.Output("hello")
+		stdout, _, err := app.UI.OutputWriters()
+		require.NoError(err)
+		fmt.Fprint(stdout, "one\n")
+
+		require.Equal([]string{"[web] hello"}, ui.Lines)
+		require.Equal("[web] one\n", ui.Stdout.String())
+
+		// The project UI is never prefixed.
+		p.UI.Output("project")
+		require.Equal("project", ui.Lines[1])
+	})
+}
+
+func TestAppUI_parallel(t *testing.T) {
+	require := require.New(t)
+
+	ui := &recordingUI{}
+	var lock sync.Mutex
+	uis := []*appUI{
+		newAppUI(ui, &lock, "a", true),
+		newAppUI(ui, &lock, "b", true),
+	}
+
+	var wg sync.WaitGroup
+	for _, u := range uis {
+		wg.Add(1)
+		go func(u *appUI) {
+			defer wg.Done()
+
+			stdout, _, err := u.OutputWriters()
+			if err != nil {
+				panic(err)
+			}
+
+			for i := 0; i < 100; i++ {
+				u.Output("line %d", i)
+				fmt.Fprintf(stdout, "write %d\n", i)
+			}
+		}(u)
+	}
+	wg.Wait()
+
+	require.Len(ui.Lines, 200)
+	for _, line := range ui.Lines {
+		require.True(strings.HasPrefix(line, "[a] line ") ||
+			strings.HasPrefix(line, "[b] line "), line)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(ui.Stdout.String(), "\n"), "\n")
+	require.Len(lines, 200)
+	for _, line := range lines {
+		require.True(strings.HasPrefix(line, "[a] write ") ||
+			strings.HasPrefix(line, "[b] write "), line)
+	}
+}
+
+func testAppUIFactory(t *testing.T) *factory.Factory {
+	f := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, f, "test", &componentmocks.Builder{})
+	return f
+}
+
+const testAppUIConfig = `
+project = "test"
+
+app "web" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+
+app "api" {
+	build {
+		use "test" {}
+	}
+
+	deploy {
+		use "test" {}
+	}
+}
+`
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// prefixColors are the colors used for component prefixes when the
+// UI is interactive. A component always gets the same color.
+var prefixColors = []color.Attribute{
+	color.FgCyan,
+	color.FgMagenta,
+	color.FgYellow,
+	color.FgGreen,
+	color.FgBlue,
+}
+
+// prefixUI is a terminal.UI that labels all output with a prefix. This is
+// used to attribute plugin output to the component that produced it.
+type prefixUI struct {
+	terminal.UI
+
+	prefix string
+}
+
+// newPrefixUI returns a UI that prefixes all output to ui with the
+// given name. If ui is interactive the prefix is colored.
+func newPrefixUI(ui terminal.UI, name string) *prefixUI {
+	prefix := "[" + name + "] "
+	if ui.Interactive() {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		c := prefixColors[int(h.Sum32())%len(prefixColors)]
+		prefix = color.New(c).Sprint(prefix)
+	}
+
+	return &prefixUI{UI: ui, prefix: prefix}
+}
+
+func (u *prefixUI) Output(msg string, raw ...interface{}) {
+	// The message is a format string so we escape the prefix.
+	u.UI.Output(strings.Replace(u.prefix, "%", "%%", -1)+msg, raw...)
+}
+
+func (u *prefixUI) OutputWriters() (io.Writer, io.Writer, error) {
+	stdout, stderr, err := u.UI.OutputWriters()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newPrefixWriter(stdout, u.prefix), newPrefixWriter(stderr, u.prefix), nil
+}
+
+func (u *prefixUI) Status() terminal.Status {
+	return &prefixStatus{Status: u.UI.Status(), prefix: u.prefix}
+}
+
+func (u *prefixUI) StepGroup() terminal.StepGroup {
+	return &prefixStepGroup{StepGroup: u.UI.StepGroup(), prefix: u.prefix}
+}
+
+type prefixStatus struct {
+	terminal.Status
+
+	prefix string
+}
+
+func (s *prefixStatus) Update(msg string) {
+	s.Status.Update(s.prefix + msg)
+}
+
+func (s *prefixStatus) Step(status, msg string) {
+	s.Status.Step(status, s.prefix+msg)
+}
+
+type prefixStepGroup struct {
+	terminal.StepGroup
+
+	prefix string
+}
+
+func (sg *prefixStepGroup) Add(str string, args ...interface{}) terminal.Step {
+	return sg.StepGroup.Add(strings.Replace(sg.prefix, "%", "%%", -1)+str, args...)
+}
+
+// prefixWriter is an io.Writer that writes prefix at the start of
+// every line.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+
+	lock sync.Mutex
+	mid  bool // true if we're in the middle of a line
+}
+
+func newPrefixWriter(w io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{w: w, prefix: []byte(prefix)}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		if !w.mid {
+			buf.Write(w.prefix)
+		}
+		buf.Write(line)
+		w.mid = line[len(line)-1] != '\n'
+	}
+
+	if _, err := w.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+var (
+	_ terminal.UI     = (*prefixUI)(nil)
+	_ terminal.Status = (*prefixStatus)(nil)
+)
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppComponentPrefix(t *testing.T) {
+	run := func(t *testing.T, enabled bool) *recordingUI {
+		mock := &componentmocks.Builder{}
+		factory := TestFactory(t, component.BuilderType)
+		TestFactoryRegister(t, factory, "test", mock)
+
+		ui := &recordingUI{}
+		app := TestApp(t, TestProject(t,
+			WithConfig(config.TestConfig(t, testBuildConfig)),
+			WithFactory(component.BuilderType, factory),
+			WithUI(ui),
+			WithComponentPrefix(enabled),
+		), "test")
+
+		artifact := &componentmocks.Artifact{}
+		artifact.On("Labels").Return(map[string]string{})
+		mock.On("BuildFunc").Return(func(ui terminal.UI) component.Artifact {
+			ui.Output("hello %s", "world")
+
+			stdout, _, err := ui.OutputWriters()
+			require.NoError(t, err)
+			fmt.Fprint(stdout, "one\ntwo\n")
+
+			return artifact
+		})
+
+		_, _, err := app.Build(context.Background(), BuildWithPush(false))
+		require.NoError(t, err)
+		return ui
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := run(t, true)
+		require.Equal([]string{"[test] hello world"}, ui.Lines)
+		require.Equal("[test] one\n[test] two\n", ui.Stdout.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require := require.New(t)
+
+		ui := run(t, false)
+		require.Equal([]string{"hello world"}, ui.Lines)
+		require.Equal("one\ntwo\n", ui.Stdout.String())
+	})
+}
+
+// recordingUI is a terminal.UI that records output for tests.
+type recordingUI struct {
+	Lines  []string
+	Stdout bytes.Buffer
+}
+
+func (u *recordingUI) Input(*terminal.Input) (string, error) {
+	return "", terminal.ErrNonInteractive
+}
+
+func (u *recordingUI) Interactive() bool { return false }
+
+func (u *recordingUI) Output(msg string, raw ...interface{}) {
+	var args []interface{}
+	for _, v := range raw {
+		if _, ok := v.(terminal.Option); !ok {
+			args = append(args, v)
+		}
+	}
+
+	u.Lines = append(u.Lines, fmt.Sprintf(msg, args...))
+}
+
+func (u *recordingUI) NamedValues([]terminal.NamedValue, ...terminal.Option) {}
+
+func (u *recordingUI) OutputWriters() (io.Writer, io.Writer, error) {
+	return &u.Stdout, &u.Stdout, nil
+}
+
+func (u *recordingUI) Status() terminal.Status { return &recordingStatus{} }
+
+func (u *recordingUI) Table(*terminal.Table, ...terminal.Option) {}
+
+func (u *recordingUI) StepGroup() terminal.StepGroup { return nil }
+
+type recordingStatus struct{}
+
+func (s *recordingStatus) Update(string)       {}
+func (s *recordingStatus) Step(string, string) {}
+func (s *recordingStatus) Close() error        { return nil }
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// OutputSink is a destination for operation output. See WithOutputSinks.
+type OutputSink struct {
+	// UI or Writer is where output is sent. If both are set, UI is used.
+	// Writers receive plain lines without any styling.
+	UI     terminal.UI
+	Writer io.Writer
+
+	// Level is the minimum level of output sent to this sink. Output
+	// with an error style is hclog.Error, a warning style is hclog.Warn,
+	// and everything else is hclog.Info.
+	Level hclog.Level
+}
+
+// accepts returns true if output at the given level should go to this sink.
+func (s *OutputSink) accepts(level hclog.Level) bool {
+	return level >= s.Level
+}
+
+// styleLevel returns the level for output with the given style.
+func styleLevel(style string) hclog.Level {
+	switch style {
+	case terminal.ErrorStyle, terminal.ErrorBoldStyle:
+		return hclog.Error
+
+	case terminal.WarningStyle, terminal.WarningBoldStyle:
+		return hclog.Warn
+
+	default:
+		return hclog.Info
+	}
+}
+
+// sinkUI is a terminal.UI that sends output to multiple sinks, filtering
+// each sink by level. Interactive features such as input and status are
+// only available through the first UI sink.
+type sinkUI struct {
+	sinks   []OutputSink
+	primary terminal.UI
+
+	// lock protects writes to Writer sinks
+	lock sync.Mutex
+}
+
+// newSinkUI returns a UI that sends all output to sinks. At least one
+// sink must have a UI.
+func newSinkUI(sinks []OutputSink) *sinkUI {
+	result := &sinkUI{sinks: sinks}
+	for _, s := range sinks {
+		if s.UI != nil {
+			result.primary = s.UI
+			break
+		}
+	}
+
+	return result
+}
+
+func (u *sinkUI) Input(input *terminal.Input) (string, error) {
+	return u.primary.Input(input)
+}
+
+func (u *sinkUI) Interactive() bool {
+	return u.primary.Interactive()
+}
+
+func (u *sinkUI) Output(msg string, raw ...interface{}) {
+	line, style, _ := terminal.Interpret(msg, raw...)
+	level := styleLevel(style)
+
+	for _, s := range u.sinks {
+		if !s.accepts(level) {
+			continue
+		}
+
+		if s.UI != nil {
+			s.UI.Output(msg, raw...)
+			continue
+		}
+
+		u.writeLine(s.Writer, line)
+	}
+}
+
+func (u *sinkUI) NamedValues(values []terminal.NamedValue, opts ...terminal.Option) {
+	for _, s := range u.sinks {
+		if !s.accepts(hclog.Info) {
+			continue
+		}
+
+		if s.UI != nil {
+			s.UI.NamedValues(values, opts...)
+			continue
+		}
+
+		for _, nv := range values {
+			u.writeLine(s.Writer, fmt.Sprintf("%s: %v", nv.Name, nv.Value))
+		}
+	}
+}
+
+func (u *sinkUI) Table(tbl *terminal.Table, opts ...terminal.Option) {
+	for _, s := range u.sinks {
+		if s.UI != nil && s.accepts(hclog.Info) {
+			s.UI.Table(tbl, opts...)
+		}
+	}
+}
+
+// OutputWriters returns the writers of the primary UI. Writer sinks that
+// accept info level output also receive everything written.
+func (u *sinkUI) OutputWriters() (io.Writer, io.Writer, error) {
+	stdout, stderr, err := u.primary.OutputWriters()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outs, errs := []io.Writer{stdout}, []io.Writer{stderr}
+	for _, s := range u.sinks {
+		if s.UI == nil && s.accepts(hclog.Info) {
+			w := &lockedWriter{w: s.Writer, lock: &u.lock}
+			outs = append(outs, w)
+			errs = append(errs, w)
+		}
+	}
+
+	return io.MultiWriter(outs...), io.MultiWriter(errs...), nil
+}
+
+func (u *sinkUI) Status() terminal.Status {
+	return u.primary.Status()
+}
+
+func (u *sinkUI) StepGroup() terminal.StepGroup {
+	return u.primary.StepGroup()
+}
+
+func (u *sinkUI) writeLine(w io.Writer, line string) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	fmt.Fprintln(w, line)
+}
+
+// lockedWriter is an io.Writer that holds lock for each write so that
+// writes are serialized with other output sharing the lock.
+type lockedWriter struct {
+	w    io.Writer
+	lock *sync.Mutex
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.w.Write(p)
+}
+
+var _ terminal.UI = (*sinkUI)(nil)
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	componentmocks "github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/config"
+)
+
+func TestAppOutputSinks(t *testing.T) {
+	require := require.New(t)
+
+	mock := &componentmocks.Builder{}
+	factory := TestFactory(t, component.BuilderType)
+	TestFactoryRegister(t, factory, "test", mock)
+
+	// Our sinks: the terminal, the server, and a file that only
+	// receives warnings and errors.
+	term := &recordingUI{}
+	server := &recordingUI{}
+	var file bytes.Buffer
+
+	app := TestApp(t, TestProject(t,
+		WithConfig(config.TestConfig(t, testBuildConfig)),
+		WithFactory(component.BuilderType, factory),
+		WithOutputSinks(
+			OutputSink{UI: term, Level: hclog.Info},
+			OutputSink{UI: server, Level: hclog.Trace},
+			OutputSink{Writer: &file, Level: hclog.Warn},
+		),
+	), "test")
+
+	artifact := &componentmocks.Artifact{}
+	artifact.On("Labels").Return(map[string]string{})
+	mock.On("BuildFunc").Return(func(ui terminal.UI) component.Artifact {
+		ui.Output("building %s", "image")
+		ui.Output("cache miss", terminal.WithWarningStyle())
+		ui.Output("failed layer", terminal.WithErrorStyle())
+		return artifact
+	})
+
+	_, _, err := app.Build(context.Background(), BuildWithPush(false))
+	require.NoError(err)
+
+	all := []string{"building image", "cache miss", "failed layer"}
+	require.Equal(all, term.Lines)
+	require.Equal(all, server.Lines)
+	require.Equal("cache miss\nfailed layer\n", file.String())
+}
@@ -46,7 +46,8 @@ var (
 
 	// FromType maps a server DataSource type to a source implementation.
 	FromType = map[reflect.Type]func() Sourcer{
-		reflect.TypeOf((*pb.Job_DataSource_Git)(nil)):   newGitSource,
-		reflect.TypeOf((*pb.Job_DataSource_Local)(nil)): newLocalSource,
+		reflect.TypeOf((*pb.Job_DataSource_Git)(nil)):    newGitSource,
+		reflect.TypeOf((*pb.Job_DataSource_Local)(nil)):  newLocalSource,
+		reflect.TypeOf((*pb.Job_DataSource_Upload)(nil)): newUploadSource,
 	}
 )
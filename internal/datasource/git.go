@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl/v2"
@@ -134,6 +135,37 @@ func (s *GitSource) Get(
 	return result, closer, nil
 }
 
+// LatestCommit returns the commit that the ref of the data source points
+// to in the remote repository. If the data source has no ref, the remote
+// HEAD is used.
+func (s *GitSource) LatestCommit(ctx context.Context, raw *pb.Job_DataSource) (string, error) {
+	source := raw.Source.(*pb.Job_DataSource_Git)
+
+	ref := source.Git.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", source.Git.Url, ref)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = nil
+	if err := cmd.Run(); err != nil {
+		return "", status.Errorf(codes.Aborted,
+			"Git ls-remote failed: %s", stderr.String())
+	}
+
+	// Each line is "<commit>\t<ref>". The first match is used.
+	fields := strings.Fields(stdout.String())
+	if len(fields) == 0 {
+		return "", status.Errorf(codes.NotFound,
+			"Git ref %q not found", ref)
+	}
+
+	return fields[0], nil
+}
+
 type gitConfig struct {
 	Url  string `hcl:"url,attr"`
 	Path string `hcl:"path,optional"`
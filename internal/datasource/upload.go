@@ -0,0 +1,312 @@
+package datasource
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/pkg/ignore"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// uploadChunkSize is the size of the chunks that bundles are uploaded in.
+const uploadChunkSize = 1024 * 1024
+
+// UploadSource gets data that a client uploaded to the server with
+// Upload. Client must be set before Get is called.
+type UploadSource struct {
+	Client pb.WaypointClient
+}
+
+func newUploadSource() Sourcer { return &UploadSource{} }
+
+func (s *UploadSource) ProjectSource(body hcl.Body, ctx *hcl.EvalContext) (*pb.Job_DataSource, error) {
+	// Uploads are made per job so they can't be the default for a project.
+	return nil, fmt.Errorf("upload data source can't be configured for a project")
+}
+
+func (s *UploadSource) Override(raw *pb.Job_DataSource, m map[string]string) error {
+	if len(m) > 0 {
+		return fmt.Errorf("overrides not allowed for upload data source")
+	}
+
+	return nil
+}
+
+func (s *UploadSource) Get(
+	ctx context.Context,
+	log hclog.Logger,
+	ui terminal.UI,
+	raw *pb.Job_DataSource,
+	baseDir string,
+) (string, func() error, error) {
+	source := raw.Source.(*pb.Job_DataSource_Upload)
+	if s.Client == nil {
+		return "", nil, status.Errorf(codes.Internal,
+			"upload data source has no server client")
+	}
+
+	td, err := ioutil.TempDir(baseDir, "waypoint")
+	if err != nil {
+		return "", nil, err
+	}
+	closer := func() error {
+		return os.RemoveAll(td)
+	}
+
+	ui.Output("Downloading uploaded source", terminal.WithHeaderStyle())
+	stream, err := s.Client.GetSource(ctx, &pb.GetSourceRequest{
+		Id: source.Upload.Id,
+	})
+	if err != nil {
+		closer()
+		return "", nil, err
+	}
+
+	if err := ExtractBundle(&sourceReader{stream: stream}, td); err != nil {
+		closer()
+		return "", nil, err
+	}
+
+	log.Debug("uploaded source extracted", "id", source.Upload.Id, "path", td)
+	return td, closer, nil
+}
+
+// Upload bundles the directory dir and uploads it to the server for the
+// given project. This returns a data source for jobs to use the upload.
+func Upload(
+	ctx context.Context,
+	client pb.WaypointClient,
+	project *pb.Ref_Project,
+	dir string,
+) (*pb.Job_DataSource, error) {
+	stream, err := client.UploadSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first message always has the project, even if the bundle
+	// fits in that chunk.
+	w := &sourceWriter{stream: stream, project: project}
+	if err := WriteBundle(w, dir); err != nil {
+		stream.CloseSend()
+		return nil, err
+	}
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Job_DataSource{
+		Source: &pb.Job_DataSource_Upload{
+			Upload: &pb.Job_Upload{Id: resp.Id},
+		},
+	}, nil
+}
+
+// WriteBundle writes a gzipped tar of the files in dir to w. The .git and
+// .waypoint directories and the paths ignored by the .waypointignore file
+// in dir are left out.
+func WriteBundle(w io.Writer, dir string) error {
+	matcher, err := ignore.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == ".waypoint") {
+			return filepath.SkipDir
+		}
+		if matcher.Match(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		// Only directories and regular files are bundled.
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gzw.Close()
+}
+
+// ExtractBundle extracts a bundle written by WriteBundle into dir.
+func ExtractBundle(r io.Reader, dir string) error {
+	dir = filepath.Clean(dir)
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Don't allow entries to write outside of the directory.
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			return status.Errorf(codes.InvalidArgument,
+				"bundle entry %q is outside of the bundle", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode)&0777)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sourceWriter is an io.Writer that sends the data as chunks of an
+// UploadSource stream.
+type sourceWriter struct {
+	stream  pb.Waypoint_UploadSourceClient
+	project *pb.Ref_Project
+	buf     []byte
+}
+
+func (w *sourceWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= uploadChunkSize {
+		if err := w.send(w.buf[:uploadChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[uploadChunkSize:]
+	}
+
+	return len(p), nil
+}
+
+// flush sends any buffered data. This always sends a message if nothing
+// was sent yet so that the server gets the project.
+func (w *sourceWriter) flush() error {
+	if len(w.buf) == 0 && w.project == nil {
+		return nil
+	}
+
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *sourceWriter) send(chunk []byte) error {
+	err := w.stream.Send(&pb.UploadSourceRequest{
+		Project: w.project,
+		Chunk:   append([]byte(nil), chunk...),
+	})
+	w.project = nil
+	return err
+}
+
+// sourceReader is an io.Reader over the chunks of a GetSource stream.
+type sourceReader struct {
+	stream pb.Waypoint_GetSourceClient
+	buf    []byte
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		resp, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		r.buf = resp.Chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+var (
+	_ Sourcer   = (*UploadSource)(nil)
+	_ io.Writer = (*sourceWriter)(nil)
+	_ io.Reader = (*sourceReader)(nil)
+)
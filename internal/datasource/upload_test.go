@@ -0,0 +1,51 @@
+package datasource
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	require := require.New(t)
+
+	src, err := ioutil.TempDir("", "waypoint")
+	require.NoError(err)
+	defer os.RemoveAll(src)
+
+	write := func(name, contents string) {
+		path := filepath.Join(src, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	write("waypoint.hcl", "project = \"test\"")
+	write("sub/main.go", "package main")
+	write("debug.log", "ignored")
+	write("tmp/cache", "ignored")
+	write(".git/HEAD", "ignored")
+	write(".waypointignore", "*.log\ntmp/\n")
+
+	var buf bytes.Buffer
+	require.NoError(WriteBundle(&buf, src))
+
+	dst, err := ioutil.TempDir("", "waypoint")
+	require.NoError(err)
+	defer os.RemoveAll(dst)
+	require.NoError(ExtractBundle(&buf, dst))
+
+	contents, err := ioutil.ReadFile(filepath.Join(dst, "sub", "main.go"))
+	require.NoError(err)
+	require.Equal("package main", string(contents))
+
+	_, err = os.Stat(filepath.Join(dst, "waypoint.hcl"))
+	require.NoError(err)
+
+	for _, name := range []string{"debug.log", "tmp", ".git"} {
+		_, err = os.Stat(filepath.Join(dst, name))
+		require.True(os.IsNotExist(err), name)
+	}
+}
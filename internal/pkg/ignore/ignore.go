@@ -0,0 +1,224 @@
+// Package ignore matches file paths against gitignore-style patterns such
+// as the ones in a .waypointignore file.
+package ignore
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Filename is the name of the ignore file in the root of a project.
+const Filename = ".waypointignore"
+
+// Matcher matches paths against a list of patterns. The zero value and
+// a nil Matcher match nothing.
+//
+// The patterns follow the gitignore rules: blank lines and lines starting
+// with "#" are skipped, a leading "!" re-includes paths matched by an
+// earlier pattern, a trailing "/" only matches directories, and a pattern
+// containing a "/" elsewhere is relative to the root rather than matching
+// at any depth. "*" and "?" don't match "/", and "**" matches any number
+// of directories. Paths can't be re-included if a parent directory is
+// ignored.
+type Matcher struct {
+	patterns []*pattern
+}
+
+type pattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// New returns a Matcher for the given pattern lines.
+func New(lines []string) *Matcher {
+	var m Matcher
+	for _, line := range lines {
+		if p := parse(line); p != nil {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+
+	return &m
+}
+
+// Parse reads patterns, one per line, from r.
+func Parse(r io.Reader) (*Matcher, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return New(lines), nil
+}
+
+// ReadFile reads the patterns in the file at path. If the file doesn't
+// exist, the returned Matcher matches nothing.
+func ReadFile(path string) (*Matcher, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &Matcher{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// ReadDir reads the ignore file in the directory dir.
+func ReadDir(dir string) (*Matcher, error) {
+	return ReadFile(filepath.Join(dir, Filename))
+}
+
+// Match returns true if the path is ignored. The path must be relative to
+// the directory the patterns are relative to. isDir should be true if the
+// path is a directory.
+func (m *Matcher) Match(p string, isDir bool) bool {
+	if m == nil || len(m.patterns) == 0 {
+		return false
+	}
+
+	p = strings.Trim(filepath.ToSlash(p), "/")
+	if p == "" || p == "." {
+		return false
+	}
+
+	// If any parent directory is ignored then so is everything in it.
+	parts := strings.Split(p, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+
+	return m.match(p, isDir)
+}
+
+// match checks only the path itself, not its parents. The last matching
+// pattern wins so that negations can re-include paths.
+func (m *Matcher) match(p string, isDir bool) bool {
+	result := false
+	for _, pat := range m.patterns {
+		if pat.dirOnly && !isDir {
+			continue
+		}
+		if pat.re.MatchString(p) {
+			result = !pat.negate
+		}
+	}
+
+	return result
+}
+
+// parse parses a single pattern line. This returns nil if the line isn't
+// a pattern.
+func parse(line string) *pattern {
+	// Trailing spaces are ignored unless they're escaped.
+	line = strings.TrimRight(line, " \t\r")
+	if strings.HasSuffix(line, `\`) {
+		line += " "
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	var result pattern
+	switch {
+	case strings.HasPrefix(line, "!"):
+		result.negate = true
+		line = line[1:]
+
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		result.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return nil
+	}
+
+	// A pattern with a slash is anchored to the root. Otherwise it can
+	// match at any depth.
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = "**/" + line
+	}
+
+	re, err := regexp.Compile("^" + globRegexp(path.Clean(line)) + "$")
+	if err != nil {
+		return nil
+	}
+	result.re = re
+
+	return &result
+}
+
+// globRegexp converts a glob to a regular expression.
+func globRegexp(glob string) string {
+	var buf strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				switch {
+				case i+1 < len(glob) && glob[i+1] == '/':
+					// "**/" matches zero or more directories.
+					i++
+					buf.WriteString("(?:.*/)?")
+
+				default:
+					buf.WriteString(".*")
+				}
+
+				continue
+			}
+
+			buf.WriteString("[^/]*")
+
+		case '?':
+			buf.WriteString("[^/]")
+
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				buf.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			buf.WriteString("[" + strings.Replace(class, `\`, `\\`, -1) + "]")
+			i += end + 1
+
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				buf.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,83 @@
+package ignore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Patterns string
+		Path     string
+		Dir      bool
+		Expected bool
+	}{
+		{"empty", "", "foo", false, false},
+		{"comment", "# foo", "# foo", false, false},
+		{"escaped comment", `\#foo`, "#foo", false, true},
+		{"name", "foo", "foo", false, true},
+		{"name at any depth", "foo", "a/b/foo", false, true},
+		{"name prefix", "foo", "foobar", false, false},
+		{"star", "*.log", "a/debug.log", false, true},
+		{"star doesn't match slash", "a/*.log", "a/b/debug.log", false, false},
+		{"question mark", "?.txt", "a.txt", false, true},
+		{"class", "[ab].txt", "b.txt", false, true},
+		{"negated class", "[!ab].txt", "b.txt", false, false},
+		{"anchored", "/foo", "foo", false, true},
+		{"anchored not nested", "/foo", "a/foo", false, false},
+		{"inner slash anchored", "a/foo", "b/a/foo", false, false},
+		{"dir only matches dir", "tmp/", "tmp", true, true},
+		{"dir only skips file", "tmp/", "tmp", false, false},
+		{"dir only contents", "tmp/", "tmp/a.txt", false, true},
+		{"nested dir contents", "tmp/", "a/tmp/b/c.txt", false, true},
+		{"double star prefix", "**/foo", "a/b/foo", false, true},
+		{"double star prefix root", "**/foo", "foo", false, true},
+		{"double star suffix", "a/**", "a/b/c", false, true},
+		{"double star middle", "a/**/b", "a/x/y/b", false, true},
+		{"double star middle none", "a/**/b", "a/b", false, true},
+		{"negate", "*.log\n!keep.log", "keep.log", false, false},
+		{"negate order", "!keep.log\n*.log", "keep.log", false, true},
+		{"negate in ignored dir", "tmp/\n!tmp/keep", "tmp/keep", false, true},
+		{"trailing space", "foo  ", "foo", false, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			m := New(strings.Split(tt.Patterns, "\n"))
+			require.Equal(tt.Expected, m.Match(tt.Path, tt.Dir))
+		})
+	}
+}
+
+func TestMatcher_nil(t *testing.T) {
+	var m *Matcher
+	require.False(t, m.Match("foo", false))
+}
+
+func TestReadDir(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "ignore")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+
+	// No file matches nothing
+	m, err := ReadDir(td)
+	require.NoError(err)
+	require.False(m.Match("foo", false))
+
+	require.NoError(ioutil.WriteFile(
+		filepath.Join(td, Filename), []byte("# build output\nbin/\n"), 0644))
+	m, err = ReadDir(td)
+	require.NoError(err)
+	require.True(m.Match("bin/app", false))
+	require.False(m.Match("main.go", false))
+}
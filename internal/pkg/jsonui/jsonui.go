@@ -0,0 +1,265 @@
+// Package jsonui provides a terminal.UI implementation that writes all
+// output as newline-delimited JSON events. This is used to give machines
+// such as CI systems output that is reliable to parse.
+//
+// Every event is a JSON object on its own line with at least a "type"
+// field. See the Event* constants for the types of events.
+package jsonui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+const (
+	// EventOutput is a message. It has "msg" and "style" fields.
+	EventOutput = "output"
+
+	// EventStdout and EventStderr are lines written to the UI output
+	// writers. They have a "msg" field.
+	EventStdout = "stdout"
+	EventStderr = "stderr"
+
+	// EventNamedValues has a "values" field with the names and values.
+	EventNamedValues = "named_values"
+
+	// EventTableRow is a single row of a table. It has "headers" and
+	// "row" fields of the same length.
+	EventTableRow = "table_row"
+
+	// EventStatus is a status update. It has "msg" and, for status
+	// steps, "status" fields.
+	EventStatus = "status"
+
+	// EventStepStart, EventStepUpdate, EventStepOutput, and
+	// EventStepFinish are the lifecycle of a step in a step group. They
+	// have a "step" field with an ID that is unique within the UI. Start
+	// and update events have a "msg" field, update events may have a
+	// "status" field, and finish events have a "result" field of "done"
+	// or "aborted".
+	EventStepStart  = "step_start"
+	EventStepUpdate = "step_update"
+	EventStepOutput = "step_output"
+	EventStepFinish = "step_finish"
+)
+
+// UI is a terminal.UI that writes NDJSON events. It is safe for
+// concurrent use. Input is not supported since a machine is reading.
+type UI struct {
+	lock     sync.Mutex
+	enc      *json.Encoder
+	nextStep int
+
+	// now is used for event timestamps and can be replaced in tests.
+	now func() time.Time
+}
+
+// New returns a UI that writes events to w.
+func New(w io.Writer) *UI {
+	return &UI{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// event writes a single event of the given type with the given fields.
+func (u *UI) event(typ string, fields map[string]interface{}) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	fields["type"] = typ
+	fields["time"] = u.now().UTC().Format(time.RFC3339Nano)
+
+	// Encoding can only fail for values we never set.
+	u.enc.Encode(fields)
+}
+
+func (u *UI) Input(*terminal.Input) (string, error) {
+	return "", terminal.ErrNonInteractive
+}
+
+func (u *UI) Interactive() bool {
+	return false
+}
+
+func (u *UI) Output(msg string, raw ...interface{}) {
+	msg, style, _ := terminal.Interpret(msg, raw...)
+	u.event(EventOutput, map[string]interface{}{
+		"msg":   msg,
+		"style": style,
+	})
+}
+
+func (u *UI) NamedValues(rows []terminal.NamedValue, opts ...terminal.Option) {
+	values := map[string]interface{}{}
+	for _, row := range rows {
+		values[row.Name] = fmt.Sprintf("%v", row.Value)
+	}
+
+	u.event(EventNamedValues, map[string]interface{}{
+		"values": values,
+	})
+}
+
+func (u *UI) OutputWriters() (io.Writer, io.Writer, error) {
+	return u.lineWriter(EventStdout, nil), u.lineWriter(EventStderr, nil), nil
+}
+
+func (u *UI) Table(tbl *terminal.Table, opts ...terminal.Option) {
+	for _, row := range tbl.Rows {
+		values := make([]string, len(row))
+		for i, entry := range row {
+			values[i] = entry.Value
+		}
+
+		u.event(EventTableRow, map[string]interface{}{
+			"headers": tbl.Headers,
+			"row":     values,
+		})
+	}
+}
+
+func (u *UI) Status() terminal.Status {
+	return &status{ui: u}
+}
+
+func (u *UI) StepGroup() terminal.StepGroup {
+	return &stepGroup{ui: u}
+}
+
+// lineWriter returns a writer that emits an event of the given type for
+// every line written to it. Partial lines are buffered until complete.
+func (u *UI) lineWriter(typ string, fields map[string]interface{}) io.Writer {
+	return &lineWriter{ui: u, typ: typ, fields: fields}
+}
+
+type lineWriter struct {
+	ui     *UI
+	typ    string
+	fields map[string]interface{}
+
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx == -1 {
+			break
+		}
+
+		line := string(w.buf.Next(idx + 1))
+		fields := map[string]interface{}{"msg": line[:len(line)-1]}
+		for k, v := range w.fields {
+			fields[k] = v
+		}
+
+		w.ui.event(w.typ, fields)
+	}
+
+	return len(p), nil
+}
+
+type status struct {
+	ui *UI
+}
+
+func (s *status) Update(msg string) {
+	s.ui.event(EventStatus, map[string]interface{}{"msg": msg})
+}
+
+func (s *status) Step(status, msg string) {
+	s.ui.event(EventStatus, map[string]interface{}{
+		"msg":    msg,
+		"status": status,
+	})
+}
+
+func (s *status) Close() error {
+	return nil
+}
+
+type stepGroup struct {
+	ui *UI
+	wg sync.WaitGroup
+}
+
+func (g *stepGroup) Add(str string, args ...interface{}) terminal.Step {
+	g.ui.lock.Lock()
+	g.ui.nextStep++
+	id := g.ui.nextStep
+	g.ui.lock.Unlock()
+
+	g.wg.Add(1)
+	g.ui.event(EventStepStart, map[string]interface{}{
+		"step": id,
+		"msg":  fmt.Sprintf(str, args...),
+	})
+
+	return &step{ui: g.ui, group: g, id: id}
+}
+
+func (g *stepGroup) Wait() {
+	g.wg.Wait()
+}
+
+type step struct {
+	ui    *UI
+	group *stepGroup
+	id    int
+
+	once sync.Once
+}
+
+func (s *step) TermOutput() io.Writer {
+	return s.ui.lineWriter(EventStepOutput, map[string]interface{}{"step": s.id})
+}
+
+func (s *step) Update(str string, args ...interface{}) {
+	s.ui.event(EventStepUpdate, map[string]interface{}{
+		"step": s.id,
+		"msg":  fmt.Sprintf(str, args...),
+	})
+}
+
+func (s *step) Status(status string) {
+	s.ui.event(EventStepUpdate, map[string]interface{}{
+		"step":   s.id,
+		"status": status,
+	})
+}
+
+func (s *step) Done() {
+	s.finish("done")
+}
+
+func (s *step) Abort() {
+	s.finish("aborted")
+}
+
+// finish emits the finish event. A step finishes only once, so calling
+// Abort after Done, a common pattern with defer, does nothing.
+func (s *step) finish(result string) {
+	s.once.Do(func() {
+		s.ui.event(EventStepFinish, map[string]interface{}{
+			"step":   s.id,
+			"result": result,
+		})
+		s.group.wg.Done()
+	})
+}
+
+var (
+	_ terminal.UI        = (*UI)(nil)
+	_ terminal.Status    = (*status)(nil)
+	_ terminal.StepGroup = (*stepGroup)(nil)
+	_ terminal.Step      = (*step)(nil)
+)
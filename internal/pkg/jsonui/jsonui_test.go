@@ -0,0 +1,63 @@
+package jsonui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+func TestUI(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := New(&buf)
+	ui.now = func() time.Time { return time.Unix(0, 0) }
+
+	ui.Output("hello %s", "world", terminal.WithSuccessStyle())
+
+	stdout, _, err := ui.OutputWriters()
+	require.NoError(err)
+	fmt.Fprint(stdout, "one\ntw")
+	fmt.Fprint(stdout, "o\n")
+
+	tbl := terminal.NewTable("ID", "Status")
+	tbl.Rich([]string{"1", "ok"}, nil)
+	ui.Table(tbl)
+
+	sg := ui.StepGroup()
+	s := sg.Add("Deploying %s", "web")
+	fmt.Fprint(s.TermOutput(), "pulling\n")
+	s.Done()
+	s.Abort()
+	sg.Wait()
+
+	events := decode(t, &buf)
+	require.Equal([]map[string]interface{}{
+		{"type": "output", "time": "1970-01-01T00:00:00Z", "msg": "hello world", "style": terminal.SuccessStyle},
+		{"type": "stdout", "time": "1970-01-01T00:00:00Z", "msg": "one"},
+		{"type": "stdout", "time": "1970-01-01T00:00:00Z", "msg": "two"},
+		{"type": "table_row", "time": "1970-01-01T00:00:00Z",
+			"headers": []interface{}{"ID", "Status"}, "row": []interface{}{"1", "ok"}},
+		{"type": "step_start", "time": "1970-01-01T00:00:00Z", "step": float64(1), "msg": "Deploying web"},
+		{"type": "step_output", "time": "1970-01-01T00:00:00Z", "step": float64(1), "msg": "pulling"},
+		{"type": "step_finish", "time": "1970-01-01T00:00:00Z", "step": float64(1), "result": "done"},
+	}, events)
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+		result = append(result, event)
+	}
+
+	return result
+}
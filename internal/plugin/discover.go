@@ -5,10 +5,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/adrg/xdg"
@@ -67,25 +69,112 @@ func Discover(cfg *config.Plugin, paths []string) (*exec.Cmd, error) {
 	return nil, nil
 }
 
-// DefaultPaths returns the default search paths for plugins. These are:
+// Discovered is an external plugin binary found by DiscoverAll.
+type Discovered struct {
+	// Name is the name of the plugin, the binary name without the
+	// "waypoint-plugin-" prefix.
+	Name string
+
+	// Path is the path to the binary that is used for this plugin.
+	Path string
+
+	// Shadowed are the paths of binaries for the same plugin that are
+	// found later in the search paths and are therefore not used.
+	Shadowed []string
+}
+
+// DiscoverAll finds all the external plugin binaries in the given paths.
+// Paths that come first take precedence, so if a plugin is found in
+// multiple paths the binary in the earliest path is used just like
+// Discover. The result is sorted by plugin name.
+//
+// Paths that don't exist are ignored.
+func DiscoverAll(paths []string) ([]*Discovered, error) {
+	const prefix = "waypoint-plugin-"
+
+	known := map[string]*Discovered{}
+	var result []*Discovered
+	for _, dir := range paths {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+				continue
+			}
+
+			name := strings.TrimPrefix(entry.Name(), prefix)
+			if runtime.GOOS == "windows" {
+				if !strings.HasSuffix(name, ".exe") {
+					continue
+				}
+
+				name = strings.TrimSuffix(name, ".exe")
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if d, ok := known[name]; ok {
+				d.Shadowed = append(d.Shadowed, path)
+				continue
+			}
+
+			d := &Discovered{Name: name, Path: path}
+			known[name] = d
+			result = append(result, d)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// DefaultPaths returns the default search paths for plugins. These are,
+// in order of precedence:
 //
 //   * pwd given
 //   * "$pwd/.waypoint/plugins"
 //   * "$XDG_CONFIG_DIR/waypoint/plugins"
 //
+// The first two are local to the project while the last one is shared
+// by all projects of the user.
 func DefaultPaths(pwd string) ([]string, error) {
-	xdgPath, err := xdg.ConfigFile("waypoint/plugins/.ignore")
+	globalPath, err := GlobalPath()
 	if err != nil {
 		return nil, err
 	}
 
 	return []string{
 		pwd,
-		filepath.Join(pwd, ".waypoint", "plugins"),
-		filepath.Dir(xdgPath),
+		ProjectPath(pwd),
+		globalPath,
 	}, nil
 }
 
+// ProjectPath returns the plugin directory of the project in pwd.
+func ProjectPath(pwd string) string {
+	return filepath.Join(pwd, ".waypoint", "plugins")
+}
+
+// GlobalPath returns the plugin directory shared by all projects of
+// the user.
+func GlobalPath() (string, error) {
+	xdgPath, err := xdg.ConfigFile("waypoint/plugins/.ignore")
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Dir(xdgPath), nil
+}
+
 func checksum(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
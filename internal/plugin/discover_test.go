@@ -126,3 +126,27 @@ func TestDiscover(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscoverAll(t *testing.T) {
+	require := require.New(t)
+
+	result, err := DiscoverAll([]string{
+		filepath.Join("testdata", "pathA"),
+		filepath.Join("testdata", "pathB"),
+		filepath.Join("testdata", "nope"),
+	})
+	require.NoError(err)
+	require.Equal([]*Discovered{
+		{
+			Name: "a",
+			Path: filepath.Join("testdata", "pathA", "waypoint-plugin-a"),
+			Shadowed: []string{
+				filepath.Join("testdata", "pathB", "waypoint-plugin-a"),
+			},
+		},
+		{
+			Name: "b",
+			Path: filepath.Join("testdata", "pathB", "waypoint-plugin-b"),
+		},
+	}, result)
+}
@@ -0,0 +1,541 @@
+package plugin
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// installedFile is the name of the file in a plugin directory that
+	// records the plugins installed by the Installer.
+	installedFile = "installed.json"
+
+	// githubPrefix is the prefix of sources that are GitHub repositories.
+	githubPrefix = "github.com/"
+
+	// defaultGitHubAPI is the GitHub API used for GitHub sources.
+	defaultGitHubAPI = "https://api.github.com"
+
+	// installMaxIndex is the largest registry index or GitHub response
+	// that is read.
+	installMaxIndex = 16 * 1024 * 1024
+)
+
+// validName matches the plugin names that can be installed. This keeps the
+// binary inside the plugin directory since the name is part of its path.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Installer downloads external plugins into a plugin directory.
+//
+// Plugins are downloaded from a source, which is either the URL of an
+// HTTP plugin registry or a GitHub repository written as
+// "github.com/<owner>/<repo>".
+//
+// An HTTP registry serves "<source>/<name>/index.json" for every plugin,
+// which lists the available versions and their binaries:
+//
+//	{
+//	  "versions": [{
+//	    "version": "0.3.1",
+//	    "builds": [{
+//	      "os": "linux",
+//	      "arch": "amd64",
+//	      "url": "waypoint-plugin-docker_0.3.1_linux_amd64.zip",
+//	      "sha256": "..."
+//	    }]
+//	  }]
+//	}
+//
+// URLs may be relative to the index. For GitHub repositories every
+// release is a version of the plugin. Releases must have an asset named
+// "waypoint-plugin-<name>_<version>_<os>_<arch>.zip" and checksums of the
+// assets in "waypoint-plugin-<name>_<version>_SHA256SUMS".
+//
+// Downloads that are zip archives must contain the plugin binary at the
+// root. Everything else is used as the binary directly. The checksum is
+// always verified before the plugin is installed.
+type Installer struct {
+	// Dir is the directory that plugins are installed into.
+	Dir string
+
+	// Logger is the logger to use. This defaults to hclog.L().
+	Logger hclog.Logger
+
+	// HTTPClient is the client used for downloads. This defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// GitHubAPI is the base URL of the GitHub API. This defaults to
+	// "https://api.github.com".
+	GitHubAPI string
+}
+
+// Installed is a plugin installed by the Installer.
+type Installed struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"`
+
+	// SHA256 is the checksum of the download the plugin was installed from.
+	SHA256 string `json:"sha256"`
+
+	// Path is the path to the plugin binary.
+	Path string `json:"-"`
+}
+
+// release is a single version of a plugin that can be installed.
+type release struct {
+	Version *version.Version
+	URL     string
+	SHA256  string
+}
+
+// Install installs the newest version of the plugin that matches the
+// version constraint. An empty constraint matches every version. An
+// already installed plugin is replaced.
+func (i *Installer) Install(ctx context.Context, name, source, constraint string) (*Installed, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf(
+			"invalid plugin name %q: must contain only lowercase letters, "+
+				"digits, underscores and dashes", name)
+	}
+
+	log := i.logger().With("plugin_name", name, "source", source)
+
+	var constraints version.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = version.NewConstraint(constraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %s", constraint, err)
+		}
+	}
+
+	var releases []*release
+	var err error
+	switch {
+	case source == "":
+		return nil, fmt.Errorf("plugin %q has no source to install it from", name)
+
+	case strings.HasPrefix(source, githubPrefix):
+		releases, err = i.githubReleases(ctx, name, strings.TrimPrefix(source, githubPrefix))
+
+	default:
+		releases, err = i.registryReleases(ctx, name, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("found plugin releases", "count", len(releases))
+
+	// Newest first so that we pick the newest matching version.
+	sort.Slice(releases, func(a, b int) bool {
+		return releases[a].Version.GreaterThan(releases[b].Version)
+	})
+
+	var match *release
+	for _, r := range releases {
+		if constraints == nil || constraints.Check(r.Version) {
+			match = r
+			break
+		}
+	}
+	if match == nil {
+		if constraint == "" {
+			return nil, fmt.Errorf(
+				"no releases of plugin %q found for %s/%s",
+				name, runtime.GOOS, runtime.GOARCH)
+		}
+
+		return nil, fmt.Errorf(
+			"no releases of plugin %q for %s/%s match version %q",
+			name, runtime.GOOS, runtime.GOARCH, constraint)
+	}
+
+	log.Info("installing plugin", "version", match.Version, "url", match.URL)
+	path, err := i.download(ctx, name, match)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Installed{
+		Name:    name,
+		Version: match.Version.String(),
+		Source:  source,
+		SHA256:  match.SHA256,
+		Path:    path,
+	}
+
+	installed, err := ReadInstalled(i.Dir)
+	if err != nil {
+		return nil, err
+	}
+	installed[name] = result
+	if err := writeInstalled(i.Dir, installed); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReadInstalled returns the plugins installed into dir by the Installer
+// keyed by their name. The result is empty if there are none.
+func ReadInstalled(dir string) (map[string]*Installed, error) {
+	result := map[string]*Installed{}
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, installedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("error reading installed plugins in %s: %s", dir, err)
+	}
+	for name, p := range result {
+		if !validName.MatchString(name) {
+			return nil, fmt.Errorf(
+				"error reading installed plugins in %s: invalid plugin name %q", dir, name)
+		}
+
+		p.Path = filepath.Join(dir, binaryName(name))
+	}
+
+	return result, nil
+}
+
+func writeInstalled(dir string, installed map[string]*Installed) error {
+	raw, err := json.MarshalIndent(installed, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, installedFile), raw, 0644)
+}
+
+// registryReleases returns the releases of the plugin in an HTTP registry
+// that can run on this platform.
+func (i *Installer) registryReleases(ctx context.Context, name, source string) ([]*release, error) {
+	base, err := url.Parse(strings.TrimSuffix(source, "/") + "/" + name + "/index.json")
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin source %q: %s", source, err)
+	}
+
+	var index struct {
+		Versions []struct {
+			Version string `json:"version"`
+			Builds  []struct {
+				OS     string `json:"os"`
+				Arch   string `json:"arch"`
+				URL    string `json:"url"`
+				SHA256 string `json:"sha256"`
+			} `json:"builds"`
+		} `json:"versions"`
+	}
+	if err := i.getJSON(ctx, base.String(), nil, &index); err != nil {
+		return nil, err
+	}
+
+	var result []*release
+	for _, v := range index.Versions {
+		ver, err := version.NewVersion(v.Version)
+		if err != nil {
+			i.logger().Warn("ignoring invalid plugin version", "version", v.Version, "err", err)
+			continue
+		}
+
+		for _, b := range v.Builds {
+			if b.OS != runtime.GOOS || b.Arch != runtime.GOARCH {
+				continue
+			}
+
+			u, err := base.Parse(b.URL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid url for plugin version %s: %s", v.Version, err)
+			}
+
+			result = append(result, &release{
+				Version: ver,
+				URL:     u.String(),
+				SHA256:  b.SHA256,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// githubReleases returns the releases of the plugin in a GitHub repository
+// that can run on this platform.
+func (i *Installer) githubReleases(ctx context.Context, name, repo string) ([]*release, error) {
+	api := i.GitHubAPI
+	if api == "" {
+		api = defaultGitHubAPI
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github.v3+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		header.Set("Authorization", "token "+token)
+	}
+
+	var releases []struct {
+		TagName    string `json:"tag_name"`
+		Draft      bool   `json:"draft"`
+		Prerelease bool   `json:"prerelease"`
+		Assets     []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	u := strings.TrimSuffix(api, "/") + "/repos/" + strings.Trim(repo, "/") + "/releases"
+	if err := i.getJSON(ctx, u, header, &releases); err != nil {
+		return nil, err
+	}
+
+	var result []*release
+	for _, r := range releases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+
+		ver, err := version.NewVersion(r.TagName)
+		if err != nil {
+			i.logger().Debug("ignoring release that isn't a version", "tag", r.TagName)
+			continue
+		}
+
+		// Asset names use the version without a "v" prefix.
+		prefix := fmt.Sprintf("waypoint-plugin-%s_%s_", name, strings.TrimPrefix(r.TagName, "v"))
+		assetName := fmt.Sprintf("%s%s_%s.zip", prefix, runtime.GOOS, runtime.GOARCH)
+		var assetURL, sumsURL string
+		for _, a := range r.Assets {
+			switch a.Name {
+			case assetName:
+				assetURL = a.URL
+			case prefix + "SHA256SUMS":
+				sumsURL = a.URL
+			}
+		}
+		if assetURL == "" {
+			continue
+		}
+		if sumsURL == "" {
+			i.logger().Warn("ignoring release without checksums", "tag", r.TagName)
+			continue
+		}
+
+		sum, err := i.githubChecksum(ctx, sumsURL, assetName)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &release{
+			Version: ver,
+			URL:     assetURL,
+			SHA256:  sum,
+		})
+	}
+
+	return result, nil
+}
+
+// githubChecksum returns the checksum of the asset from a SHA256SUMS file.
+func (i *Installer) githubChecksum(ctx context.Context, u, asset string) (string, error) {
+	body, err := i.get(ctx, u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(io.LimitReader(body, installMaxIndex))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no checksum for %s in %s", asset, u)
+}
+
+// download downloads the release, verifies it and installs the binary.
+// This returns the path to the binary.
+func (i *Installer) download(ctx context.Context, name string, r *release) (string, error) {
+	if r.SHA256 == "" {
+		return "", fmt.Errorf("plugin %q version %s has no checksum", name, r.Version)
+	}
+
+	if err := os.MkdirAll(i.Dir, 0755); err != nil {
+		return "", err
+	}
+
+	body, err := i.get(ctx, r.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	// Download into the plugin directory so that the final rename is
+	// within the same filesystem.
+	f, err := ioutil.TempFile(i.Dir, ".download-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), body); err != nil {
+		return "", fmt.Errorf("error downloading %s: %s", r.URL, err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, r.SHA256) {
+		return "", fmt.Errorf(
+			"plugin %q version %s checksum mismatch. expected: %s, got: %s",
+			name, r.Version, r.SHA256, actual)
+	}
+
+	src := f.Name()
+	if strings.HasSuffix(r.URL, ".zip") {
+		bin, err := ioutil.TempFile(i.Dir, ".download-")
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(bin.Name())
+		defer bin.Close()
+
+		if err := unzipBinary(f.Name(), binaryName(name), bin); err != nil {
+			return "", err
+		}
+		if err := bin.Close(); err != nil {
+			return "", err
+		}
+
+		src = bin.Name()
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Chmod(src, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(i.Dir, binaryName(name))
+	if err := os.Rename(src, path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// unzipBinary writes the file with the given name in the zip archive at
+// path to w.
+func unzipBinary(path, name string, w io.Writer) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("error opening plugin archive: %s", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		_, err = io.Copy(w, r)
+		return err
+	}
+
+	return fmt.Errorf("plugin archive doesn't contain %s", name)
+}
+
+// get performs a GET request and returns the body if it succeeded.
+func (i *Installer) get(ctx context.Context, u string, header http.Header) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	client := i.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error requesting %s: %s", u, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into v.
+func (i *Installer) getJSON(ctx context.Context, u string, header http.Header, v interface{}) error {
+	body, err := i.get(ctx, u, header)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(body, installMaxIndex)).Decode(v); err != nil {
+		return fmt.Errorf("invalid response from %s: %s", u, err)
+	}
+
+	return nil
+}
+
+func (i *Installer) logger() hclog.Logger {
+	if i.Logger != nil {
+		return i.Logger
+	}
+
+	return hclog.L()
+}
+
+// binaryName returns the file name of the binary of the plugin.
+func binaryName(name string) string {
+	result := "waypoint-plugin-" + name
+	if runtime.GOOS == "windows" {
+		result += ".exe"
+	}
+
+	return result
+}
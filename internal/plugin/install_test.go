@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstaller_registry(t *testing.T) {
+	ctx := context.Background()
+
+	archive := testPluginZip(t, "foo", "binary-0.3.1")
+	sum := sha256.Sum256(archive)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plugins/foo/index.json":
+			fmt.Fprintf(w, `{"versions": [
+				{"version": "0.2.0", "builds": [{"os": %[1]q, "arch": %[2]q, "url": "old.zip", "sha256": "nope"}]},
+				{"version": "0.3.1", "builds": [{"os": %[1]q, "arch": %[2]q, "url": "foo.zip", "sha256": %[3]q}]},
+				{"version": "0.4.0", "builds": [{"os": "plan9", "arch": %[2]q, "url": "new.zip", "sha256": "nope"}]}
+			]}`, runtime.GOOS, runtime.GOARCH, hex.EncodeToString(sum[:]))
+
+		case "/plugins/foo/foo.zip", "/plugins/foo/old.zip":
+			w.Write(archive)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	i := &Installer{Dir: dir}
+
+	t.Run("newest matching version", func(t *testing.T) {
+		require := require.New(t)
+
+		installed, err := i.Install(ctx, "foo", srv.URL+"/plugins", ">= 0.3")
+		require.NoError(err)
+		require.Equal("0.3.1", installed.Version)
+		require.Equal(filepath.Join(dir, binaryName("foo")), installed.Path)
+
+		data, err := ioutil.ReadFile(installed.Path)
+		require.NoError(err)
+		require.Equal("binary-0.3.1", string(data))
+
+		// The installed plugin is recorded and can be discovered.
+		all, err := ReadInstalled(dir)
+		require.NoError(err)
+		require.Equal(installed, all["foo"])
+
+		discovered, err := DiscoverAll([]string{dir})
+		require.NoError(err)
+		require.Len(discovered, 1)
+		require.Equal("foo", discovered[0].Name)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := i.Install(ctx, "foo", srv.URL+"/plugins", "< 0.3")
+		require.Error(err)
+		require.Contains(err.Error(), "checksum")
+	})
+
+	t.Run("no matching version", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := i.Install(ctx, "foo", srv.URL+"/plugins", ">= 1.0")
+		require.Error(err)
+		require.Contains(err.Error(), "match version")
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		_, err := i.Install(ctx, "foo", "", "")
+		require.Error(t, err)
+	})
+
+	t.Run("path traversal name", func(t *testing.T) {
+		require := require.New(t)
+
+		for _, name := range []string{"../../foo", "foo/../../bar", `..\..\foo`, ".."} {
+			_, err := i.Install(ctx, name, srv.URL+"/plugins", "")
+			require.Error(err)
+			require.Contains(err.Error(), "invalid plugin name")
+		}
+	})
+}
+
+func TestInstaller_github(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	asset := fmt.Sprintf("waypoint-plugin-foo_1.2.0_%s_%s.zip", runtime.GOOS, runtime.GOARCH)
+	archive := testPluginZip(t, "foo", "binary-1.2.0")
+	sum := sha256.Sum256(archive)
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/example/foo/releases":
+			fmt.Fprintf(w, `[
+				{"tag_name": "v1.3.0-beta1", "prerelease": true, "assets": []},
+				{"tag_name": "v1.2.0", "assets": [
+					{"name": %[1]q, "browser_download_url": "%[2]s/dl/asset.zip"},
+					{"name": "waypoint-plugin-foo_1.2.0_SHA256SUMS", "browser_download_url": "%[2]s/dl/sums"}
+				]}
+			]`, asset, srv.URL)
+
+		case "/dl/asset.zip":
+			w.Write(archive)
+
+		case "/dl/sums":
+			fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(sum[:]), asset)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	i := &Installer{Dir: dir, GitHubAPI: srv.URL}
+	installed, err := i.Install(ctx, "foo", "github.com/example/foo", "")
+	require.NoError(err)
+	require.Equal("1.2.0", installed.Version)
+
+	data, err := ioutil.ReadFile(installed.Path)
+	require.NoError(err)
+	require.Equal("binary-1.2.0", string(data))
+}
+
+// testPluginZip returns a zip archive with a plugin binary with the
+// given contents.
+func testPluginZip(t *testing.T, name, contents string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(binaryName(name))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,36 @@
+package plugin
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hashicorp/waypoint/internal/version"
+)
+
+// Version returns the version of a plugin. The command is the command
+// returned by Discover and must be nil for built-in plugins.
+//
+// Built-in plugins have the version of Waypoint itself. External plugins
+// only have a known version if they were installed by the Installer. If
+// the version isn't known, this returns false.
+func Version(name string, cmd *exec.Cmd) (string, bool) {
+	if cmd == nil {
+		if _, ok := Builtins[name]; !ok {
+			return "", false
+		}
+
+		return version.GetVersion().VersionNumber(), true
+	}
+
+	installed, err := ReadInstalled(filepath.Dir(cmd.Path))
+	if err != nil {
+		return "", false
+	}
+
+	p, ok := installed[name]
+	if !ok || p.Path != cmd.Path {
+		return "", false
+	}
+
+	return p.Version, true
+}
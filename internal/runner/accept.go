@@ -3,14 +3,19 @@ package runner
 import (
 	"context"
 	"io"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 var heartbeatDuration = 5 * time.Second
@@ -117,6 +122,17 @@ func (r *Runner) accept(ctx context.Context, id string) error {
 		return err
 	}
 
+	// The job is traced in the trace of the request that queued it.
+	ctx, span := telemetry.Tracer().Start(
+		telemetry.ExtractMap(ctx, assignment.Assignment.Job.TraceContext),
+		"job "+jobOperationName(assignment.Assignment.Job),
+		trace.WithAttributes(
+			kv.String("job.id", assignment.Assignment.Job.Id),
+			kv.String("runner.id", r.id),
+		),
+	)
+	defer span.End()
+
 	// Create a cancelable context so we can stop if job is canceled
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -210,6 +226,7 @@ func (r *Runner) accept(ctx context.Context, id string) error {
 		// Execute the job. We have to close the UI right afterwards to
 		// ensure that no more output is writting to the client.
 		log.Info("starting job execution")
+		span.AddEvent(ctx, "data downloaded")
 		result, err = r.executeJob(ctx, log, ui, assignment.Assignment.Job, wd)
 		if ui, ok := ui.(*runnerUI); ok {
 			ui.Close()
@@ -240,6 +257,7 @@ func (r *Runner) accept(ctx context.Context, id string) error {
 
 	// Handle job execution errors
 	if err != nil {
+		telemetry.SetError(span, err)
 		st, _ := status.FromError(err)
 
 		log.Warn("error during job execution", "err", err)
@@ -278,3 +296,12 @@ func (r *Runner) accept(ctx context.Context, id string) error {
 
 	return err
 }
+
+// jobOperationName returns the name of the job operation, such as "Build".
+func jobOperationName(job *pb.Job) string {
+	if job.Operation == nil {
+		return "unknown"
+	}
+
+	return strings.TrimPrefix(reflect.TypeOf(job.Operation).Elem().Name(), "Job_")
+}
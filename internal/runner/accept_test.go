@@ -2,6 +2,7 @@ package runner
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/datasource"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess"
@@ -51,6 +54,41 @@ func TestRunnerAccept(t *testing.T) {
 	require.Equal(pb.Job_SUCCESS, job.State)
 }
 
+func TestRunnerAccept_projectOptions(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Setup our runner with a project option that fails validation so
+	// that we know it was used.
+	client := singleprocess.TestServer(t)
+	runner := TestRunner(t, WithClient(client), WithProjectOptions(
+		core.WithOutputSinks(core.OutputSink{Writer: ioutil.Discard}),
+	))
+	require.NoError(runner.Start())
+
+	// Initialize our app
+	singleprocess.TestApp(t, client, serverptypes.TestJobNew(t, nil).Application)
+
+	// Queue a job
+	queueResp, err := client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, nil),
+	})
+	require.NoError(err)
+	jobId := queueResp.JobId
+
+	// Accept should complete
+	require.NoError(runner.Accept(ctx))
+
+	// Verify that the job failed creating the project
+	var job *pb.Job
+	require.Eventually(func() bool {
+		job, err = client.GetJob(ctx, &pb.GetJobRequest{JobId: jobId})
+		require.NoError(err)
+		return job.State == pb.Job_ERROR
+	}, 3*time.Second, 25*time.Millisecond)
+	require.Contains(job.Error.Message, "WithOutputSinks")
+}
+
 func TestRunnerAccept_cancelContext(t *testing.T) {
 	require := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -176,6 +214,43 @@ func TestRunnerAccept_gitData(t *testing.T) {
 	require.Equal(pb.Job_SUCCESS, job.State)
 }
 
+func TestRunnerAccept_uploadData(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Get the path before TestRunner changes our working directory
+	path, err := filepath.Abs(filepath.Join("testdata", "git-noop"))
+	require.NoError(err)
+
+	// Setup our runner
+	client := singleprocess.TestServer(t)
+	runner := TestRunner(t, WithClient(client))
+	require.NoError(runner.Start())
+
+	// Initialize our app
+	job := serverptypes.TestJobNew(t, nil)
+	singleprocess.TestApp(t, client, job.Application)
+
+	// Upload the source
+	source, err := datasource.Upload(ctx, client,
+		&pb.Ref_Project{Project: job.Application.Project}, path)
+	require.NoError(err)
+
+	// Queue a job
+	job.DataSource = source
+	queueResp, err := client.QueueJob(ctx, &pb.QueueJobRequest{Job: job})
+	require.NoError(err)
+	jobId := queueResp.JobId
+
+	// Accept should complete
+	require.NoError(runner.Accept(ctx))
+
+	// Verify that the job is completed
+	job, err = client.GetJob(ctx, &pb.GetJobRequest{JobId: jobId})
+	require.NoError(err)
+	require.Equal(pb.Job_SUCCESS, job.State)
+}
+
 // testGitFixture MUST be called before TestRunner since TestRunner
 // changes our working directory.
 func testGitFixture(t *testing.T, n string) string {
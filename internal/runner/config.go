@@ -5,10 +5,16 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
+// configHeartbeatDuration is how often the runner heartbeats on the config
+// stream. The server considers the runner lost if it stops heartbeating.
+var configHeartbeatDuration = 15 * time.Second
+
 // watchConfig sits in a goroutine receiving the new configurations from the
 // server.
 func (r *Runner) watchConfig(ch <-chan *pb.RunnerConfig) {
@@ -94,6 +100,16 @@ func (r *Runner) handleConfig(c *pb.RunnerConfig) {
 	}
 }
 
+// handleToken passes a new runner token to the token handler.
+func (r *Runner) handleToken(token string) {
+	if token == "" || r.tokenHandler == nil {
+		return
+	}
+
+	r.logger.Debug("new runner token received")
+	r.tokenHandler(token)
+}
+
 func (r *Runner) recvConfig(
 	ctx context.Context,
 	client pb.Waypoint_RunnerConfigClient,
@@ -121,6 +137,45 @@ func (r *Runner) recvConfig(
 		}
 
 		log.Info("new configuration received")
+		r.handleToken(resp.RunnerToken)
 		ch <- resp.Config
 	}
 }
+
+// heartbeatConfig sends heartbeats on the config stream until the context
+// is done so that the server knows this runner is alive.
+func (r *Runner) heartbeatConfig(
+	ctx context.Context,
+	client pb.Waypoint_RunnerConfigClient,
+	sendMutex *sync.Mutex,
+) {
+	log := r.logger.Named("config_heartbeat")
+	defer log.Trace("exiting heartbeat goroutine")
+
+	tick := time.NewTicker(configHeartbeatDuration)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-tick.C:
+		}
+
+		sendMutex.Lock()
+		err := client.Send(&pb.RunnerConfigRequest{
+			Event: &pb.RunnerConfigRequest_Heartbeat_{
+				Heartbeat: &pb.RunnerConfigRequest_Heartbeat{},
+			},
+		})
+		sendMutex.Unlock()
+		if err != nil {
+			if err != io.EOF {
+				log.Warn("error during heartbeat, exiting", "err", err)
+			}
+
+			return
+		}
+	}
+}
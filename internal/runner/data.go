@@ -41,6 +41,11 @@ func (r *Runner) downloadJobData(
 	}
 	sourcer := factory()
 
+	// Uploaded data is downloaded from the server.
+	if s, ok := sourcer.(*datasource.UploadSource); ok {
+		s.Client = r.client
+	}
+
 	// Apply any overrides
 	if len(overrides) > 0 {
 		if err := sourcer.Override(source, overrides); err != nil {
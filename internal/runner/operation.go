@@ -1,13 +1,16 @@
 package runner
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
-	"github.com/hashicorp/hcl/v2/hclsimple"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	configpkg "github.com/hashicorp/waypoint/internal/config"
 	"github.com/hashicorp/waypoint/internal/core"
+	"github.com/hashicorp/waypoint/internal/datasource"
 	"github.com/hashicorp/waypoint/internal/factory"
 	"github.com/hashicorp/waypoint/internal/plugin"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
@@ -32,18 +36,46 @@ func (r *Runner) executeJob(
 ) (*pb.Job_Result, error) {
 	// Eventually we'll need to extract the data source. For now we're
 	// just building for local exec so it is the working directory.
-	path := configpkg.Filename
-	if wd != "" {
-		path = filepath.Join(wd, path)
+	dir := wd
+	if dir == "" {
+		dir = "."
+	}
+
+	// Find the configuration, which is either waypoint.hcl or
+	// waypoint.hcl.json. If there is neither we default to waypoint.hcl
+	// so the error when reading it mentions the usual name.
+	path, err := configpkg.DirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		path = filepath.Join(dir, configpkg.Filename)
 	}
 
 	// Determine the evaluation context we'll be using
 	configCtx := configpkg.EvalContext(filepath.Dir(path))
+	configpkg.SetWorkspace(configCtx, job.Workspace.GetWorkspace())
 
-	// Decode the configuration
-	var cfg configpkg.Config
+	// Read the configuration. If the data we downloaded doesn't have
+	// one, we use the configuration stored on the server for the project.
 	log.Trace("reading configuration", "path", path)
-	if err := hclsimple.DecodeFile(path, configCtx, &cfg); err != nil {
+	src, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && wd != "" {
+		src, err = r.serverProjectConfig(ctx, job, err)
+
+		// The stored configuration may use the JSON syntax, which always
+		// starts with an object unlike the native syntax.
+		if err == nil && bytes.HasPrefix(bytes.TrimSpace(src), []byte("{")) {
+			path = filepath.Join(dir, configpkg.FilenameJSON)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the configuration with the input variables of the job
+	cfg, err := configpkg.Load(src, path, configCtx, jobVariables(job))
+	if err != nil {
 		return nil, err
 	}
 
@@ -53,8 +85,18 @@ func (r *Runner) executeJob(
 		return nil, err
 	}
 
+	// Operations record the VCS info of the job. If it wasn't known when
+	// the job was queued, we read it from the data we downloaded.
+	vcs := job.Vcs
+	if vcs == nil && wd != "" {
+		vcs, err = datasource.GitVCS(ctx, wd)
+		if err != nil {
+			log.Debug("no VCS info for the job data", "err", err)
+		}
+	}
+
 	// Find all our plugins
-	factories, err := r.pluginFactories(log, cfg.Plugins(), wd)
+	factories, versions, err := r.pluginFactories(log, cfg.Plugins(), wd)
 	if err != nil {
 		return nil, err
 	}
@@ -67,19 +109,30 @@ func (r *Runner) executeJob(
 
 	// Create our project
 	log.Trace("initializing project", "project", cfg.Project)
-	project, err := core.NewProject(ctx,
+	opts := []core.Option{
 		core.WithLogger(log),
 		core.WithUI(ui),
 		core.WithComponents(factories),
+		core.WithPluginVersions(versions),
 		core.WithClient(r.client),
-		core.WithConfig(&cfg),
+		core.WithConfig(cfg),
 		core.WithConfigContext(configCtx),
 		core.WithDataDir(projDir),
 		core.WithRootDir(filepath.Dir(path)),
 		core.WithLabels(job.Labels),
 		core.WithWorkspace(job.Workspace.Workspace),
 		core.WithJobInfo(jobInfo),
-	)
+		core.WithVCS(vcs),
+		core.WithVariables(cfg.VariableValues()),
+	}
+	opts = append(opts, r.projectOpts...)
+	if r.outputWriter != nil {
+		opts = append(opts, core.WithOutputSinks(
+			core.OutputSink{UI: ui},
+			core.OutputSink{Writer: r.outputWriter},
+		))
+	}
+	project, err := core.NewProject(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,16 +177,63 @@ func (r *Runner) executeJob(
 	case *pb.Job_Docs:
 		return r.executeDocsOp(ctx, log, job, project)
 
+	case *pb.Job_HealthCheck:
+		return r.executeHealthCheckOp(ctx, job, project)
+
+	case *pb.Job_Rollback:
+		return r.executeRollbackOp(ctx, job, project)
+
+	case *pb.Job_Up:
+		return r.executeUpOp(ctx, job, project)
+
 	default:
 		return nil, status.Errorf(codes.Aborted, "unknown operation %T", job.Operation)
 	}
 }
 
+// serverProjectConfig returns the waypoint.hcl stored on the server for
+// the project of the job. If none is stored, this returns notFoundErr,
+// the error from reading the file from the job data.
+func (r *Runner) serverProjectConfig(
+	ctx context.Context,
+	job *pb.Job,
+	notFoundErr error,
+) ([]byte, error) {
+	resp, err := r.client.GetProjectConfig(ctx, &pb.GetProjectConfigRequest{
+		Project: &pb.Ref_Project{Project: job.Application.GetProject()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.WaypointHcl) == 0 {
+		return nil, notFoundErr
+	}
+
+	return resp.WaypointHcl, nil
+}
+
+// jobVariables returns the input variable values of the job in order of
+// precedence.
+func jobVariables(job *pb.Job) []*configpkg.VariableValue {
+	result := make([]*configpkg.VariableValue, len(job.Variables))
+	for i, v := range job.Variables {
+		result[i] = &configpkg.VariableValue{
+			Name:   v.Name,
+			Value:  v.Value,
+			Source: configpkg.VariableSource(v.Source),
+		}
+	}
+
+	return result
+}
+
+// pluginFactories returns the factories for the given plugins along with
+// the versions of the plugins that have a known version.
 func (r *Runner) pluginFactories(
 	log hclog.Logger,
 	plugins []*configpkg.Plugin,
 	wd string,
-) (map[component.Type]*factory.Factory, error) {
+) (map[component.Type]*factory.Factory, map[string]string, error) {
 	// Copy all our base factories first
 	result := map[component.Type]*factory.Factory{}
 	for k, f := range r.factories {
@@ -143,11 +243,12 @@ func (r *Runner) pluginFactories(
 	// Get our plugin search paths
 	pluginPaths, err := plugin.DefaultPaths(wd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log.Debug("plugin search path", "path", pluginPaths)
 
 	// Search for all of our plugins
+	versions := map[string]string{}
 	var perr error
 	for _, pluginCfg := range plugins {
 		plog := log.With("plugin_name", pluginCfg.Name)
@@ -171,6 +272,9 @@ func (r *Runner) pluginFactories(
 				plog.Warn("plugin not found")
 			} else {
 				plog.Debug("plugin found as builtin")
+				if v, ok := plugin.Version(pluginCfg.Name, nil); ok {
+					versions[pluginCfg.Name] = v
+				}
 				for _, t := range pluginCfg.Types() {
 					result[t].Register(pluginCfg.Name, plugin.BuiltinFactory(pluginCfg.Name, t))
 				}
@@ -181,10 +285,41 @@ func (r *Runner) pluginFactories(
 
 		// Register the command
 		plog.Debug("plugin found as external binary", "path", cmd.Path)
+		if v, ok := plugin.Version(pluginCfg.Name, cmd); ok {
+			versions[pluginCfg.Name] = v
+		}
 		for _, t := range pluginCfg.Types() {
 			result[t].Register(pluginCfg.Name, plugin.Factory(cmd, t))
 		}
 	}
 
-	return result, perr
+	// Register the remaining external plugins in the search paths so that
+	// they can be used without a plugin block. We don't register these as
+	// mappers since mappers are launched eagerly.
+	discovered, err := plugin.DiscoverAll(pluginPaths)
+	if err != nil {
+		log.Warn("error listing plugins in search path", "err", err)
+		return result, versions, multierror.Append(perr, err)
+	}
+	configured := map[string]struct{}{}
+	for _, pluginCfg := range plugins {
+		configured[pluginCfg.Name] = struct{}{}
+	}
+	for _, d := range discovered {
+		if _, ok := configured[d.Name]; ok {
+			continue
+		}
+
+		log.Debug("registering discovered plugin", "plugin_name", d.Name, "path", d.Path)
+		cmd := exec.Command(d.Path)
+		for t := range result {
+			if t == component.MapperType {
+				continue
+			}
+
+			result[t].Register(d.Name, plugin.Factory(cmd, t))
+		}
+	}
+
+	return result, versions, perr
 }
@@ -30,6 +30,11 @@ func (r *Runner) executeAuthOp(
 		return nil, err
 	}
 
+	// If we're only checking all components, we don't need to attempt auth.
+	if op.Auth.CheckOnly && op.Auth.Component == nil {
+		return r.executeAuthCheck(ctx, log, app)
+	}
+
 	var results []*pb.Job_AuthResult_Result
 	for _, c := range app.Components() {
 		info := app.ComponentProto(c)
@@ -106,3 +111,41 @@ func (r *Runner) executeAuthOp(
 		},
 	}, nil
 }
+
+// executeAuthCheck checks the credentials of every component of the app
+// without attempting to authenticate.
+func (r *Runner) executeAuthCheck(
+	ctx context.Context,
+	log hclog.Logger,
+	app *core.App,
+) (*pb.Job_Result, error) {
+	statuses, err := app.CredentialsCheck(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*pb.Job_AuthResult_Result
+	for _, s := range statuses {
+		result := &pb.Job_AuthResult_Result{
+			Component:     s.Component,
+			AuthSupported: s.Supported,
+			CheckResult:   s.Valid(),
+		}
+		if s.Err != nil {
+			st, _ := status.FromError(s.Err)
+			result.CheckError = st.Proto()
+		}
+
+		log.Debug("auth result",
+			"type", s.Component.Type.String(),
+			"name", s.Component.Name,
+			"result", result.CheckResult)
+		results = append(results, result)
+	}
+
+	return &pb.Job_Result{
+		Auth: &pb.Job_AuthResult{
+			Results: results,
+		},
+	}, nil
+}
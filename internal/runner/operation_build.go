@@ -24,7 +24,10 @@ func (r *Runner) executeBuildOp(
 		panic("operation not expected type")
 	}
 
-	build, push, err := app.Build(ctx, core.BuildWithPush(!op.Build.DisablePush))
+	build, push, err := app.Build(ctx,
+		core.BuildWithPush(!op.Build.DisablePush),
+		core.BuildWithCache(!op.Build.DisableCache),
+	)
 	if err != nil {
 		return nil, err
 	}
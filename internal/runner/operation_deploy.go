@@ -24,6 +24,17 @@ func (r *Runner) executeDeployOp(
 		panic("operation not expected type")
 	}
 
+	if op.Deploy.DryRun {
+		changes, err := app.PlanDeploy(ctx, op.Deploy.Artifact)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Job_Result{
+			Deploy: &pb.Job_DeployResult{PlannedChanges: changes},
+		}, nil
+	}
+
 	deployment, err := app.Deploy(ctx, op.Deploy.Artifact)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,30 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/hashicorp/waypoint/internal/core"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func (r *Runner) executeHealthCheckOp(
+	ctx context.Context,
+	job *pb.Job,
+	project *core.Project,
+) (*pb.Job_Result, error) {
+	app, err := project.App(job.Application.Application)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := app.HealthCheck(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Job_Result{
+		HealthCheck: &pb.Job_HealthCheckResult{
+			Report: report,
+		},
+	}, nil
+}
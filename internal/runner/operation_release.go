@@ -2,6 +2,7 @@ package runner
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -63,6 +64,11 @@ func (r *Runner) executeReleaseOp(
 				continue
 			}
 
+			// Pinned deployments are never pruned.
+			if core.DeploymentPinned(d) {
+				continue
+			}
+
 			// TODO this should instead check against the app's platform component
 			// and ignore any deployments that are NOT the app's current platform
 			// component (ya dig?)
@@ -78,6 +84,20 @@ func (r *Runner) executeReleaseOp(
 		pruneDeploys = ds
 	}
 
+	if op.Release.DryRun {
+		changes, err := app.PlanRelease(ctx, op.Release.Deployment)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range pruneDeploys {
+			changes = append(changes, fmt.Sprintf("destroy deployment %s (prune)", d.Id))
+		}
+
+		return &pb.Job_Result{
+			Release: &pb.Job_ReleaseResult{PlannedChanges: changes},
+		}, nil
+	}
+
 	// Do the release
 	release, _, err := app.Release(ctx, op.Release.Deployment)
 	if err != nil {
@@ -0,0 +1,47 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/hashicorp/waypoint/internal/core"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func (r *Runner) executeRollbackOp(
+	ctx context.Context,
+	job *pb.Job,
+	project *core.Project,
+) (*pb.Job_Result, error) {
+	app, err := project.App(job.Application.Application)
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := job.Operation.(*pb.Job_Rollback)
+	if !ok {
+		// this shouldn't happen since the call to this function is gated
+		// on the above type match.
+		panic("operation not expected type")
+	}
+
+	release, err := app.ReleaseRollback(ctx, op.Rollback.Confirm)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := r.client.GetDeployment(ctx, &pb.GetDeploymentRequest{
+		Ref: &pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: release.DeploymentId},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Job_Result{
+		Rollback: &pb.Job_RollbackResult{
+			Deployment: deployment,
+			Release:    release,
+		},
+	}, nil
+}
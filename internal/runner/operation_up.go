@@ -0,0 +1,44 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/hashicorp/waypoint/internal/core"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func (r *Runner) executeUpOp(
+	ctx context.Context,
+	job *pb.Job,
+	project *core.Project,
+) (*pb.Job_Result, error) {
+	app, err := project.App(job.Application.Application)
+	if err != nil {
+		return nil, err
+	}
+
+	build, push, err := app.Build(ctx, core.BuildWithPush(true))
+	if err != nil {
+		return nil, err
+	}
+	result := &pb.Job_Result{
+		Up: &pb.Job_UpResult{
+			Build: build,
+			Push:  push,
+		},
+	}
+
+	deployment, err := app.Deploy(ctx, push)
+	if err != nil {
+		return result, err
+	}
+	result.Up.Deployment = deployment
+
+	release, _, err := app.Release(ctx, deployment)
+	if err != nil {
+		return result, err
+	}
+	result.Up.Release = release
+
+	return result, nil
+}
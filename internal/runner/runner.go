@@ -3,6 +3,7 @@ package runner
 import (
 	"context"
 	"errors"
+	"io"
 	"sync"
 	"sync/atomic"
 
@@ -12,10 +13,12 @@ import (
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+	"github.com/hashicorp/waypoint/internal/core"
 	"github.com/hashicorp/waypoint/internal/factory"
 	"github.com/hashicorp/waypoint/internal/plugin"
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/version"
 )
 
 var ErrClosed = errors.New("runner is closed")
@@ -59,6 +62,14 @@ type Runner struct {
 	config      *pb.RunnerConfig
 	originalEnv []*pb.ConfigVar
 
+	// tokenHandler is called with each runner token the server sends.
+	tokenHandler func(string)
+
+	// projectOpts are added to the options of the project of every job.
+	// outputWriter, if set, also receives the output of every job.
+	projectOpts  []core.Option
+	outputWriter io.Writer
+
 	// noopCh is used in tests only. This will cause any noop operations
 	// to block until this channel is closed.
 	noopCh <-chan struct{}
@@ -81,7 +92,10 @@ func New(opts ...Option) (*Runner, error) {
 		id:     id,
 		logger: hclog.L(),
 		ctx:    context.Background(),
-		runner: &pb.Runner{Id: id},
+		runner: &pb.Runner{
+			Id:      id,
+			Version: version.GetVersion().VersionNumber(),
+		},
 		factories: map[component.Type]*factory.Factory{
 			component.MapperType:         plugin.BaseFactories[component.MapperType],
 			component.BuilderType:        plugin.BaseFactories[component.BuilderType],
@@ -134,7 +148,18 @@ func (r *Runner) Start() error {
 	if err != nil {
 		return err
 	}
-	r.cleanup(func() { client.CloseSend() })
+
+	// The heartbeat goroutine sends on the stream too, so it is stopped
+	// before we close our side of the stream.
+	heartbeatCtx, heartbeatCancel := context.WithCancel(r.ctx)
+	var sendMutex sync.Mutex
+	r.cleanup(func() {
+		heartbeatCancel()
+
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		client.CloseSend()
+	})
 
 	// Send request
 	if err := client.Send(&pb.RunnerConfigRequest{
@@ -155,6 +180,7 @@ func (r *Runner) Start() error {
 	}
 
 	// Handle the first config so our initial setup is done
+	r.handleToken(resp.RunnerToken)
 	r.handleConfig(resp.Config)
 
 	// Start the watcher
@@ -164,6 +190,9 @@ func (r *Runner) Start() error {
 	// Start the goroutine that waits for all other configs
 	go r.recvConfig(r.ctx, client, ch)
 
+	// Start the goroutine that heartbeats so the server knows we're alive
+	go r.heartbeatConfig(heartbeatCtx, client, &sendMutex)
+
 	log.Info("runner registered with server")
 	return nil
 }
@@ -236,6 +265,26 @@ func WithLocal(ui terminal.UI) Option {
 	}
 }
 
+// WithId sets the ID of the runner. By default a unique ID is generated.
+// This is used by on-demand runners that the server launches for a job
+// so that the job can target the runner before it is registered.
+func WithId(id string) Option {
+	return func(r *Runner, cfg *config) error {
+		r.id = id
+		r.runner.Id = id
+		return nil
+	}
+}
+
+// WithLabels sets the labels that identify this runner. These are
+// informational only and don't affect which jobs are assigned.
+func WithLabels(labels map[string]string) Option {
+	return func(r *Runner, cfg *config) error {
+		r.runner.Labels = labels
+		return nil
+	}
+}
+
 // ByIdOnly sets it so that only jobs that target this runner by specific
 // ID may be assigned.
 func ByIdOnly() Option {
@@ -244,3 +293,41 @@ func ByIdOnly() Option {
 		return nil
 	}
 }
+
+// WithMaxJobs sets the maximum number of jobs the runner runs at once.
+// The server doesn't assign the runner more jobs than this, so Accept may
+// be called this many times concurrently. Zero means no limit.
+func WithMaxJobs(n uint32) Option {
+	return func(r *Runner, cfg *config) error {
+		r.runner.MaxJobs = n
+		return nil
+	}
+}
+
+// WithTokenHandler sets a function that is called with the runner token
+// that the server sends once the runner is adopted, and each time the
+// token is rotated. The client should use the token from then on.
+func WithTokenHandler(f func(string)) Option {
+	return func(r *Runner, cfg *config) error {
+		r.tokenHandler = f
+		return nil
+	}
+}
+
+// WithProjectOptions sets additional options for the project that is
+// created for every job, such as core.WithCallTrace.
+func WithProjectOptions(opts ...core.Option) Option {
+	return func(r *Runner, cfg *config) error {
+		r.projectOpts = append(r.projectOpts, opts...)
+		return nil
+	}
+}
+
+// WithOutputWriter sends the output of the components of every job to w as
+// plain text in addition to the UI. See core.WithOutputSinks.
+func WithOutputWriter(w io.Writer) Option {
+	return func(r *Runner, cfg *config) error {
+		r.outputWriter = w
+		return nil
+	}
+}
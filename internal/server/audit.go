@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// AuditRecorder is implemented by a service that records the calls made
+// to its mutating endpoints. If the service given to the server implements
+// this then every unary or streaming call that may mutate state is recorded.
+type AuditRecorder interface {
+	// RecordAudit is called after a mutating endpoint returns. For streams
+	// this is when the stream ends. err is the error returned by the
+	// endpoint, if any.
+	RecordAudit(ctx context.Context, endpoint string, err error)
+}
+
+// auditUnaryInterceptor returns a gRPC unary interceptor that records
+// calls to mutating endpoints with the given recorder.
+func auditUnaryInterceptor(rec AuditRecorder) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		name := filepath.Base(info.FullMethod)
+		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") || !EndpointMutating(name) {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+		rec.RecordAudit(ctx, name, err)
+		return resp, err
+	}
+}
+
+// auditStreamInterceptor returns a gRPC stream interceptor that records
+// calls to mutating streaming endpoints, such as RestoreSnapshot, with the
+// given recorder.
+func auditStreamInterceptor(rec AuditRecorder) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		name := filepath.Base(info.FullMethod)
+		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") || !EndpointMutating(name) {
+			return handler(srv, ss)
+		}
+
+		err := handler(srv, ss)
+		rec.RecordAudit(ss.Context(), name, err)
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestAuditUnaryInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	var rec testAuditRecorder
+	f := auditUnaryInterceptor(&rec)
+
+	call := func(method string, err error) {
+		_, _ = f(context.Background(), nil, &grpc.UnaryServerInfo{
+			FullMethod: "/hashicorp.waypoint.Waypoint/" + method,
+		}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+
+	call("GetDeployment", nil)
+	call("ListBuilds", nil)
+	call("ValidateJob", nil)
+	require.Empty(rec.Endpoints)
+
+	call("UpsertDeployment", nil)
+	call("SetServerConfig", errors.New("bad config"))
+	require.Equal([]string{"UpsertDeployment", "SetServerConfig"}, rec.Endpoints)
+	require.Nil(rec.Errors[0])
+	require.Error(rec.Errors[1])
+}
+
+func TestAuditStreamInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	var rec testAuditRecorder
+	f := auditStreamInterceptor(&rec)
+
+	call := func(method string, err error) {
+		_ = f(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{
+			FullMethod: "/hashicorp.waypoint.Waypoint/" + method,
+		}, func(srv interface{}, stream grpc.ServerStream) error {
+			return err
+		})
+	}
+
+	call("GetLogStream", nil)
+	call("GetSource", nil)
+	require.Empty(rec.Endpoints)
+
+	call("RestoreSnapshot", nil)
+	call("UploadSource", errors.New("bad source"))
+	require.Equal([]string{"RestoreSnapshot", "UploadSource"}, rec.Endpoints)
+	require.Nil(rec.Errors[0])
+	require.Error(rec.Errors[1])
+}
+
+type testAuditRecorder struct {
+	Endpoints []string
+	Errors    []error
+}
+
+func (r *testAuditRecorder) RecordAudit(ctx context.Context, endpoint string, err error) {
+	r.Endpoints = append(r.Endpoints, endpoint)
+	r.Errors = append(r.Errors, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -28,6 +29,37 @@ var Effects = map[string][]string{
 
 var DefaultEffects = []string{"mutable"}
 
+// readonlyEndpoints are the endpoints that don't start with a readonly
+// prefix but never mutate state.
+var readonlyEndpoints = map[string]struct{}{
+	"ValidateJob":               {},
+	"RunnerGetDeploymentConfig": {},
+}
+
+// EndpointMutating returns true if the endpoint with the given name may
+// mutate server state. Endpoints that start with "Get" or "List" only
+// read state.
+func EndpointMutating(endpoint string) bool {
+	if strings.HasPrefix(endpoint, "Get") || strings.HasPrefix(endpoint, "List") {
+		return false
+	}
+
+	_, ok := readonlyEndpoints[endpoint]
+	return !ok
+}
+
+// endpointEffects returns the effects of the endpoint with the given name.
+func endpointEffects(endpoint string) []string {
+	if effects, ok := Effects[endpoint]; ok {
+		return effects
+	}
+	if !EndpointMutating(endpoint) {
+		return readonly
+	}
+
+	return DefaultEffects
+}
+
 // authUnaryInterceptor returns a gRPC unary interceptor that inspects the metadata
 // attached to the context. A token is extract from that metadata and the given
 // AuthChecker is invoked to guard calling the target handler. Effectively
@@ -45,10 +77,7 @@ func authUnaryInterceptor(checker AuthChecker) grpc.UnaryServerInterceptor {
 
 		name := filepath.Base(info.FullMethod)
 
-		effects, ok := Effects[name]
-		if !ok {
-			effects = DefaultEffects
-		}
+		effects := endpointEffects(name)
 
 		md, ok := metadata.FromIncomingContext(ctx)
 		if !ok {
@@ -64,6 +93,20 @@ func authUnaryInterceptor(checker AuthChecker) grpc.UnaryServerInterceptor {
 		if err != nil {
 			return nil, err
 		}
+
+		// If the checker supports scoped tokens, check that the token can
+		// access the project or application that the request targets.
+		if sc, ok := checker.(ScopeChecker); ok {
+			scope, err := resolveRequestScope(ctx, sc, req)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := sc.AuthorizeScope(ctx, token, name, effects, scope); err != nil {
+				return nil, err
+			}
+		}
+
 		return handler(ctx, req)
 	}
 }
@@ -85,10 +128,7 @@ func authStreamInterceptor(checker AuthChecker) grpc.StreamServerInterceptor {
 
 		name := filepath.Base(info.FullMethod)
 
-		effects, ok := Effects[name]
-		if !ok {
-			effects = DefaultEffects
-		}
+		effects := endpointEffects(name)
 
 		md, ok := metadata.FromIncomingContext(ss.Context())
 		if !ok {
@@ -107,7 +147,115 @@ func authStreamInterceptor(checker AuthChecker) grpc.StreamServerInterceptor {
 			return err
 		}
 
+		// If the checker supports scoped tokens, streams that can be
+		// scoped are checked on their first message. All other streams
+		// are checked without a scope which denies them to scoped tokens.
+		if sc, ok := checker.(ScopeChecker); ok {
+			if _, ok := scopedStreams[name]; !ok {
+				if err := sc.AuthorizeScope(ss.Context(), token, name, DefaultEffects, nil); err != nil {
+					return err
+				}
+			} else {
+				ctx := ss.Context()
+				ss = &scopedServerStream{
+					ServerStream: ss,
+					check: func(m interface{}) error {
+						return streamAuthorizeScope(ctx, sc, token, name, effects, m)
+					},
+				}
+			}
+		}
+
 		// Invoke the handler.
 		return handler(srv, ss)
 	}
 }
+
+// scopedStreams are the streams that scoped tokens may call. The scope
+// of these is checked against the first message received on the stream.
+var scopedStreams = map[string]struct{}{
+	"GetLogStream":    {},
+	"GetJobStream":    {},
+	"GetSource":       {},
+	"StartExecStream": {},
+	"UploadSource":    {},
+}
+
+// streamAuthorizeScope checks the scope of the first message m of a stream.
+// If the message has no project or application reference, the checker may
+// resolve one by implementing ScopeResolver. Messages without a scope are
+// treated as mutating so that scoped tokens are denied.
+func streamAuthorizeScope(
+	ctx context.Context,
+	sc ScopeChecker,
+	token, endpoint string,
+	effects []string,
+	m interface{},
+) error {
+	scope, err := resolveRequestScope(ctx, sc, m)
+	if err != nil {
+		return err
+	}
+	if scope == nil {
+		effects = DefaultEffects
+	}
+
+	return sc.AuthorizeScope(ctx, token, endpoint, effects, scope)
+}
+
+// resolveRequestScope returns the scope of the request message. If the
+// message has no project or application reference, the checker may
+// resolve one by implementing ScopeResolver.
+func resolveRequestScope(ctx context.Context, sc ScopeChecker, req interface{}) (*Scope, error) {
+	if scope := RequestScope(req); scope != nil {
+		return scope, nil
+	}
+
+	if r, ok := sc.(ScopeResolver); ok {
+		return r.ResolveScope(ctx, req)
+	}
+
+	return nil, nil
+}
+
+// scopedServerStream is a grpc.ServerStream that checks the scope of the
+// first message received before anything else can happen on the stream.
+type scopedServerStream struct {
+	grpc.ServerStream
+
+	check func(m interface{}) error
+
+	mu      sync.Mutex
+	checked bool
+	err     error
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.checked {
+		s.checked = true
+		s.err = s.check(m)
+	}
+
+	return s.err
+}
+
+func (s *scopedServerStream) SendMsg(m interface{}) error {
+	s.mu.Lock()
+	checked, err := s.checked, s.err
+	s.mu.Unlock()
+	if !checked {
+		return status.Errorf(codes.PermissionDenied,
+			"stream must be scoped by receiving a message before sending")
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.ServerStream.SendMsg(m)
+}
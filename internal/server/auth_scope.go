@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"reflect"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// ScopeChecker is an optional interface that an AuthChecker can implement
+// to limit tokens to specific projects and applications. It is called
+// after Authenticate succeeds. For unary calls it is called with the scope
+// of the request. Streams are only given a scope if they are listed in
+// scopedStreams, in which case it is called after the first message is
+// received. All other streams are checked with a nil scope. A nil scope
+// must not give a scoped token access to the data of any project.
+type ScopeChecker interface {
+	// AuthorizeScope is called with the scope of the request. The scope
+	// is nil if the request doesn't target a project or application.
+	AuthorizeScope(ctx context.Context, token, endpoint string, effects []string, scope *Scope) error
+}
+
+// ScopeResolver is an optional interface that a ScopeChecker can implement
+// to find the scope of requests and stream messages that only refer to a
+// project or application indirectly, such as by a deployment or job ID.
+type ScopeResolver interface {
+	// ResolveScope returns the scope of the message or nil if it has
+	// none.
+	ResolveScope(ctx context.Context, req interface{}) (*Scope, error)
+}
+
+// Scope is the project and application that a request targets.
+type Scope struct {
+	Project string
+
+	// Application is empty if the request targets the whole project.
+	Application string
+}
+
+// RequestScope returns the scope of the given request message. This looks
+// for an application or project reference on the request and then on each
+// of its message fields, such as the deployment of UpsertDeploymentRequest.
+// This returns nil if no reference is found.
+func RequestScope(req interface{}) *Scope {
+	if scope := messageScope(req); scope != nil {
+		return scope
+	}
+
+	v := reflect.ValueOf(req)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() || !f.CanInterface() {
+			continue
+		}
+
+		if scope := messageScope(f.Interface()); scope != nil {
+			return scope
+		}
+	}
+
+	return nil
+}
+
+// messageScope returns the scope of a single message without looking at
+// its fields.
+func messageScope(v interface{}) *Scope {
+	if m, ok := v.(interface{ GetApplication() *pb.Ref_Application }); ok {
+		if ref := m.GetApplication(); ref != nil {
+			return &Scope{Project: ref.Project, Application: ref.Application}
+		}
+	}
+
+	if m, ok := v.(interface{ GetProject() *pb.Ref_Project }); ok {
+		if ref := m.GetProject(); ref != nil {
+			return &Scope{Project: ref.Project}
+		}
+	}
+
+	if m, ok := v.(interface{ GetProject() *pb.Project }); ok {
+		if p := m.GetProject(); p != nil {
+			return &Scope{Project: p.Name}
+		}
+	}
+
+	return nil
+}
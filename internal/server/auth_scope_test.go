@@ -0,0 +1,69 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestRequestScope(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Req      interface{}
+		Expected *Scope
+	}{
+		{
+			"application ref",
+			&pb.ListDeploymentsRequest{
+				Application: &pb.Ref_Application{Project: "p", Application: "a"},
+			},
+			&Scope{Project: "p", Application: "a"},
+		},
+
+		{
+			"project ref",
+			&pb.GetProjectRequest{
+				Project: &pb.Ref_Project{Project: "p"},
+			},
+			&Scope{Project: "p"},
+		},
+
+		{
+			"project message",
+			&pb.UpsertProjectRequest{
+				Project: &pb.Project{Name: "p"},
+			},
+			&Scope{Project: "p"},
+		},
+
+		{
+			"nested message",
+			&pb.UpsertDeploymentRequest{
+				Deployment: &pb.Deployment{
+					Application: &pb.Ref_Application{Project: "p", Application: "a"},
+				},
+			},
+			&Scope{Project: "p", Application: "a"},
+		},
+
+		{
+			"no scope",
+			&pb.GetJobRequest{JobId: "foo"},
+			nil,
+		},
+
+		{
+			"nil",
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, RequestScope(tt.Req))
+		})
+	}
+}
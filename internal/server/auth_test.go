@@ -6,7 +6,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
 type trivialAuth struct {
@@ -55,3 +59,213 @@ func TestAuthUnaryInterceptor(t *testing.T) {
 	require.Equal("bar", chk.method)
 	require.Equal(DefaultEffects, chk.effects)
 }
+
+// scopeAuth is an AuthChecker for a token scoped to the application
+// "p/a". Deployment "d-a" belongs to that application and "d-b" doesn't.
+type scopeAuth struct {
+	trivialAuth
+}
+
+func (t *scopeAuth) AuthorizeScope(
+	ctx context.Context,
+	token, endpoint string,
+	effects []string,
+	scope *Scope,
+) error {
+	if scope == nil || scope.Project != "p" || scope.Application != "a" {
+		return status.Errorf(codes.PermissionDenied, "scoped")
+	}
+
+	return nil
+}
+
+func (t *scopeAuth) ResolveScope(ctx context.Context, req interface{}) (*Scope, error) {
+	var id string
+	switch req := req.(type) {
+	case *pb.ExecStreamRequest:
+		id = req.GetStart().GetDeploymentId()
+	case *pb.GetDeploymentRequest:
+		id = req.Ref.GetId()
+	}
+
+	switch id {
+	case "d-a":
+		return &Scope{Project: "p", Application: "a"}, nil
+	case "d-b":
+		return &Scope{Project: "p", Application: "b"}, nil
+	}
+
+	return nil, nil
+}
+
+func TestAuthUnaryInterceptor_scope(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+		"authorization": []string{"this-is-a-token"},
+	})
+
+	f := authUnaryInterceptor(&scopeAuth{})
+
+	call := func(name string, req interface{}) (bool, error) {
+		called := false
+		_, err := f(ctx, req,
+			&grpc.UnaryServerInfo{FullMethod: "/hashicorp.waypoint.Waypoint/" + name},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			},
+		)
+
+		return called, err
+	}
+
+	getDeployment := func(id string) (bool, error) {
+		return call("GetDeployment", &pb.GetDeploymentRequest{
+			Ref: &pb.Ref_Operation{Target: &pb.Ref_Operation_Id{Id: id}},
+		})
+	}
+
+	t.Run("request with an application reference", func(t *testing.T) {
+		require := require.New(t)
+
+		called, err := call("UpsertDeployment", &pb.UpsertDeploymentRequest{
+			Deployment: &pb.Deployment{
+				Application: &pb.Ref_Application{Project: "p", Application: "a"},
+			},
+		})
+		require.NoError(err)
+		require.True(called)
+	})
+
+	t.Run("resolved into the scoped application", func(t *testing.T) {
+		require := require.New(t)
+
+		called, err := getDeployment("d-a")
+		require.NoError(err)
+		require.True(called)
+	})
+
+	t.Run("resolved into another application", func(t *testing.T) {
+		require := require.New(t)
+
+		called, err := getDeployment("d-b")
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.False(called)
+	})
+
+	t.Run("unresolved requests are denied", func(t *testing.T) {
+		require := require.New(t)
+
+		called, err := getDeployment("d-unknown")
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.False(called)
+	})
+}
+
+// testServerStream is a grpc.ServerStream that receives msg.
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	msg  *pb.ExecStreamRequest
+	sent int
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	m.(*pb.ExecStreamRequest).Event = s.msg.Event
+	return nil
+}
+
+func (s *testServerStream) SendMsg(m interface{}) error {
+	s.sent++
+	return nil
+}
+
+func TestAuthStreamInterceptor_scope(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+		"authorization": []string{"this-is-a-token"},
+	})
+
+	f := authStreamInterceptor(&scopeAuth{})
+
+	exec := func(id string) (*testServerStream, error) {
+		ss := &testServerStream{
+			ctx: ctx,
+			msg: &pb.ExecStreamRequest{
+				Event: &pb.ExecStreamRequest_Start_{
+					Start: &pb.ExecStreamRequest_Start{DeploymentId: id},
+				},
+			},
+		}
+
+		return ss, f(nil, ss,
+			&grpc.StreamServerInfo{FullMethod: "/hashicorp.waypoint.Waypoint/StartExecStream"},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				var req pb.ExecStreamRequest
+				if err := ss.RecvMsg(&req); err != nil {
+					return err
+				}
+
+				return ss.SendMsg(&pb.ExecStreamResponse{})
+			},
+		)
+	}
+
+	t.Run("non-scoped streams are denied", func(t *testing.T) {
+		require := require.New(t)
+
+		called := false
+		err := f(nil, &testServerStream{ctx: ctx},
+			&grpc.StreamServerInfo{FullMethod: "/hashicorp.waypoint.Waypoint/CreateSnapshot"},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				called = true
+				return nil
+			},
+		)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.False(called)
+	})
+
+	t.Run("exec into the scoped application", func(t *testing.T) {
+		require := require.New(t)
+
+		ss, err := exec("d-a")
+		require.NoError(err)
+		require.Equal(1, ss.sent)
+	})
+
+	t.Run("exec into another application", func(t *testing.T) {
+		require := require.New(t)
+
+		ss, err := exec("d-b")
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.Equal(0, ss.sent)
+	})
+
+	t.Run("exec into an unknown deployment", func(t *testing.T) {
+		require := require.New(t)
+
+		ss, err := exec("d-unknown")
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.Equal(0, ss.sent)
+	})
+
+	t.Run("sending before receiving", func(t *testing.T) {
+		require := require.New(t)
+
+		err := f(nil, &testServerStream{ctx: ctx},
+			&grpc.StreamServerInfo{FullMethod: "/hashicorp.waypoint.Waypoint/GetLogStream"},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				return ss.SendMsg(&pb.LogBatch{})
+			},
+		)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	})
+}
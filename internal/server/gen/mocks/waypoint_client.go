@@ -18,8 +18,8 @@ type WaypointClient struct {
 	mock.Mock
 }
 
-// BootstrapToken provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) BootstrapToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
+// AdoptRunner provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) AdoptRunner(ctx context.Context, in *gen.AdoptRunnerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -29,17 +29,17 @@ func (_m *WaypointClient) BootstrapToken(ctx context.Context, in *emptypb.Empty,
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.NewTokenResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.AdoptRunnerRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.AdoptRunnerRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -48,8 +48,8 @@ func (_m *WaypointClient) BootstrapToken(ctx context.Context, in *emptypb.Empty,
 	return r0, r1
 }
 
-// CancelJob provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) CancelJob(ctx context.Context, in *gen.CancelJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+// ApproveJob provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ApproveJob(ctx context.Context, in *gen.ApproveJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -60,7 +60,7 @@ func (_m *WaypointClient) CancelJob(ctx context.Context, in *gen.CancelJobReques
 	ret := _m.Called(_ca...)
 
 	var r0 *emptypb.Empty
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.CancelJobRequest, ...grpc.CallOption) *emptypb.Empty); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ApproveJobRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
@@ -69,7 +69,7 @@ func (_m *WaypointClient) CancelJob(ctx context.Context, in *gen.CancelJobReques
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.CancelJobRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ApproveJobRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -78,8 +78,8 @@ func (_m *WaypointClient) CancelJob(ctx context.Context, in *gen.CancelJobReques
 	return r0, r1
 }
 
-// ConvertInviteToken provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ConvertInviteToken(ctx context.Context, in *gen.ConvertInviteTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
+// BootstrapToken provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) BootstrapToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -90,7 +90,7 @@ func (_m *WaypointClient) ConvertInviteToken(ctx context.Context, in *gen.Conver
 	ret := _m.Called(_ca...)
 
 	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConvertInviteTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.NewTokenResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
@@ -99,7 +99,7 @@ func (_m *WaypointClient) ConvertInviteToken(ctx context.Context, in *gen.Conver
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConvertInviteTokenRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -108,8 +108,8 @@ func (_m *WaypointClient) ConvertInviteToken(ctx context.Context, in *gen.Conver
 	return r0, r1
 }
 
-// CreateHostname provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) CreateHostname(ctx context.Context, in *gen.CreateHostnameRequest, opts ...grpc.CallOption) (*gen.CreateHostnameResponse, error) {
+// CancelJob provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CancelJob(ctx context.Context, in *gen.CancelJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -119,17 +119,17 @@ func (_m *WaypointClient) CreateHostname(ctx context.Context, in *gen.CreateHost
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.CreateHostnameResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateHostnameRequest, ...grpc.CallOption) *gen.CreateHostnameResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CancelJobRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.CreateHostnameResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateHostnameRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CancelJobRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -138,8 +138,8 @@ func (_m *WaypointClient) CreateHostname(ctx context.Context, in *gen.CreateHost
 	return r0, r1
 }
 
-// DeleteHostname provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) DeleteHostname(ctx context.Context, in *gen.DeleteHostnameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+// CancelScheduledOperation provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CancelScheduledOperation(ctx context.Context, in *gen.CancelScheduledOperationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -150,7 +150,7 @@ func (_m *WaypointClient) DeleteHostname(ctx context.Context, in *gen.DeleteHost
 	ret := _m.Called(_ca...)
 
 	var r0 *emptypb.Empty
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteHostnameRequest, ...grpc.CallOption) *emptypb.Empty); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CancelScheduledOperationRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
@@ -159,7 +159,7 @@ func (_m *WaypointClient) DeleteHostname(ctx context.Context, in *gen.DeleteHost
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteHostnameRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CancelScheduledOperationRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -168,8 +168,8 @@ func (_m *WaypointClient) DeleteHostname(ctx context.Context, in *gen.DeleteHost
 	return r0, r1
 }
 
-// EntrypointConfig provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) EntrypointConfig(ctx context.Context, in *gen.EntrypointConfigRequest, opts ...grpc.CallOption) (gen.Waypoint_EntrypointConfigClient, error) {
+// CompleteOIDCAuth provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CompleteOIDCAuth(ctx context.Context, in *gen.CompleteOIDCAuthRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -179,17 +179,17 @@ func (_m *WaypointClient) EntrypointConfig(ctx context.Context, in *gen.Entrypoi
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_EntrypointConfigClient
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.EntrypointConfigRequest, ...grpc.CallOption) gen.Waypoint_EntrypointConfigClient); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CompleteOIDCAuthRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_EntrypointConfigClient)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.EntrypointConfigRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CompleteOIDCAuthRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -198,29 +198,29 @@ func (_m *WaypointClient) EntrypointConfig(ctx context.Context, in *gen.Entrypoi
 	return r0, r1
 }
 
-// EntrypointExecStream provides a mock function with given fields: ctx, opts
-func (_m *WaypointClient) EntrypointExecStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_EntrypointExecStreamClient, error) {
+// ConvertInviteToken provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ConvertInviteToken(ctx context.Context, in *gen.ConvertInviteTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx)
+	_ca = append(_ca, ctx, in)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_EntrypointExecStreamClient
-	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_EntrypointExecStreamClient); ok {
-		r0 = rf(ctx, opts...)
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConvertInviteTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
+		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_EntrypointExecStreamClient)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
-		r1 = rf(ctx, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConvertInviteTokenRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -228,29 +228,29 @@ func (_m *WaypointClient) EntrypointExecStream(ctx context.Context, opts ...grpc
 	return r0, r1
 }
 
-// EntrypointLogStream provides a mock function with given fields: ctx, opts
-func (_m *WaypointClient) EntrypointLogStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_EntrypointLogStreamClient, error) {
+// CreateHostname provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CreateHostname(ctx context.Context, in *gen.CreateHostnameRequest, opts ...grpc.CallOption) (*gen.CreateHostnameResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx)
+	_ca = append(_ca, ctx, in)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_EntrypointLogStreamClient
-	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_EntrypointLogStreamClient); ok {
-		r0 = rf(ctx, opts...)
+	var r0 *gen.CreateHostnameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateHostnameRequest, ...grpc.CallOption) *gen.CreateHostnameResponse); ok {
+		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_EntrypointLogStreamClient)
+			r0 = ret.Get(0).(*gen.CreateHostnameResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
-		r1 = rf(ctx, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateHostnameRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -258,8 +258,8 @@ func (_m *WaypointClient) EntrypointLogStream(ctx context.Context, opts ...grpc.
 	return r0, r1
 }
 
-// GenerateInviteToken provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GenerateInviteToken(ctx context.Context, in *gen.InviteTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
+// CreateSnapshot provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CreateSnapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (gen.Waypoint_CreateSnapshotClient, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -269,17 +269,17 @@ func (_m *WaypointClient) GenerateInviteToken(ctx context.Context, in *gen.Invit
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.InviteTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
+	var r0 gen.Waypoint_CreateSnapshotClient
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) gen.Waypoint_CreateSnapshotClient); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(gen.Waypoint_CreateSnapshotClient)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.InviteTokenRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -288,8 +288,8 @@ func (_m *WaypointClient) GenerateInviteToken(ctx context.Context, in *gen.Invit
 	return r0, r1
 }
 
-// GenerateLoginToken provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GenerateLoginToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
+// CreateTrigger provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) CreateTrigger(ctx context.Context, in *gen.CreateTriggerRequest, opts ...grpc.CallOption) (*gen.CreateTriggerResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -299,17 +299,17 @@ func (_m *WaypointClient) GenerateLoginToken(ctx context.Context, in *emptypb.Em
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.NewTokenResponse); ok {
+	var r0 *gen.CreateTriggerResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateTriggerRequest, ...grpc.CallOption) *gen.CreateTriggerResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(*gen.CreateTriggerResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateTriggerRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -318,8 +318,8 @@ func (_m *WaypointClient) GenerateLoginToken(ctx context.Context, in *emptypb.Em
 	return r0, r1
 }
 
-// GetBuild provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetBuild(ctx context.Context, in *gen.GetBuildRequest, opts ...grpc.CallOption) (*gen.Build, error) {
+// DeleteAuthMethod provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteAuthMethod(ctx context.Context, in *gen.DeleteAuthMethodRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -329,17 +329,17 @@ func (_m *WaypointClient) GetBuild(ctx context.Context, in *gen.GetBuildRequest,
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Build
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetBuildRequest, ...grpc.CallOption) *gen.Build); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteAuthMethodRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Build)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetBuildRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteAuthMethodRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -348,8 +348,8 @@ func (_m *WaypointClient) GetBuild(ctx context.Context, in *gen.GetBuildRequest,
 	return r0, r1
 }
 
-// GetConfig provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetConfig(ctx context.Context, in *gen.ConfigGetRequest, opts ...grpc.CallOption) (*gen.ConfigGetResponse, error) {
+// DeleteHostname provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteHostname(ctx context.Context, in *gen.DeleteHostnameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -359,17 +359,17 @@ func (_m *WaypointClient) GetConfig(ctx context.Context, in *gen.ConfigGetReques
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ConfigGetResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConfigGetRequest, ...grpc.CallOption) *gen.ConfigGetResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteHostnameRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ConfigGetResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConfigGetRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteHostnameRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -378,8 +378,8 @@ func (_m *WaypointClient) GetConfig(ctx context.Context, in *gen.ConfigGetReques
 	return r0, r1
 }
 
-// GetDeployment provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetDeployment(ctx context.Context, in *gen.GetDeploymentRequest, opts ...grpc.CallOption) (*gen.Deployment, error) {
+// DeleteRecurringSchedule provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteRecurringSchedule(ctx context.Context, in *gen.DeleteRecurringScheduleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -389,17 +389,17 @@ func (_m *WaypointClient) GetDeployment(ctx context.Context, in *gen.GetDeployme
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Deployment
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetDeploymentRequest, ...grpc.CallOption) *gen.Deployment); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteRecurringScheduleRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Deployment)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetDeploymentRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteRecurringScheduleRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -408,8 +408,8 @@ func (_m *WaypointClient) GetDeployment(ctx context.Context, in *gen.GetDeployme
 	return r0, r1
 }
 
-// GetJob provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetJob(ctx context.Context, in *gen.GetJobRequest, opts ...grpc.CallOption) (*gen.Job, error) {
+// DeleteRunnerProfile provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteRunnerProfile(ctx context.Context, in *gen.DeleteRunnerProfileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -419,17 +419,17 @@ func (_m *WaypointClient) GetJob(ctx context.Context, in *gen.GetJobRequest, opt
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Job
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobRequest, ...grpc.CallOption) *gen.Job); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteRunnerProfileRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Job)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteRunnerProfileRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -438,8 +438,8 @@ func (_m *WaypointClient) GetJob(ctx context.Context, in *gen.GetJobRequest, opt
 	return r0, r1
 }
 
-// GetJobStream provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetJobStream(ctx context.Context, in *gen.GetJobStreamRequest, opts ...grpc.CallOption) (gen.Waypoint_GetJobStreamClient, error) {
+// DeleteTrigger provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteTrigger(ctx context.Context, in *gen.DeleteTriggerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -449,17 +449,17 @@ func (_m *WaypointClient) GetJobStream(ctx context.Context, in *gen.GetJobStream
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_GetJobStreamClient
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobStreamRequest, ...grpc.CallOption) gen.Waypoint_GetJobStreamClient); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteTriggerRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_GetJobStreamClient)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobStreamRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteTriggerRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -468,8 +468,8 @@ func (_m *WaypointClient) GetJobStream(ctx context.Context, in *gen.GetJobStream
 	return r0, r1
 }
 
-// GetLatestBuild provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetLatestBuild(ctx context.Context, in *gen.GetLatestBuildRequest, opts ...grpc.CallOption) (*gen.Build, error) {
+// DeleteWorkspace provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) DeleteWorkspace(ctx context.Context, in *gen.DeleteWorkspaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -479,17 +479,17 @@ func (_m *WaypointClient) GetLatestBuild(ctx context.Context, in *gen.GetLatestB
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Build
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestBuildRequest, ...grpc.CallOption) *gen.Build); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteWorkspaceRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Build)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestBuildRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteWorkspaceRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -498,8 +498,8 @@ func (_m *WaypointClient) GetLatestBuild(ctx context.Context, in *gen.GetLatestB
 	return r0, r1
 }
 
-// GetLatestPushedArtifact provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetLatestPushedArtifact(ctx context.Context, in *gen.GetLatestPushedArtifactRequest, opts ...grpc.CallOption) (*gen.PushedArtifact, error) {
+// EntrypointConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) EntrypointConfig(ctx context.Context, in *gen.EntrypointConfigRequest, opts ...grpc.CallOption) (gen.Waypoint_EntrypointConfigClient, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -509,17 +509,17 @@ func (_m *WaypointClient) GetLatestPushedArtifact(ctx context.Context, in *gen.G
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.PushedArtifact
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestPushedArtifactRequest, ...grpc.CallOption) *gen.PushedArtifact); ok {
+	var r0 gen.Waypoint_EntrypointConfigClient
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.EntrypointConfigRequest, ...grpc.CallOption) gen.Waypoint_EntrypointConfigClient); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.PushedArtifact)
+			r0 = ret.Get(0).(gen.Waypoint_EntrypointConfigClient)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestPushedArtifactRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.EntrypointConfigRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -528,8 +528,38 @@ func (_m *WaypointClient) GetLatestPushedArtifact(ctx context.Context, in *gen.G
 	return r0, r1
 }
 
-// GetLatestRelease provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetLatestRelease(ctx context.Context, in *gen.GetLatestReleaseRequest, opts ...grpc.CallOption) (*gen.Release, error) {
+// EntrypointExecStream provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) EntrypointExecStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_EntrypointExecStreamClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_EntrypointExecStreamClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_EntrypointExecStreamClient); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_EntrypointExecStreamClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EntrypointIssueCert provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) EntrypointIssueCert(ctx context.Context, in *gen.EntrypointIssueCertRequest, opts ...grpc.CallOption) (*gen.EntrypointIssueCertResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -539,17 +569,17 @@ func (_m *WaypointClient) GetLatestRelease(ctx context.Context, in *gen.GetLates
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Release
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestReleaseRequest, ...grpc.CallOption) *gen.Release); ok {
+	var r0 *gen.EntrypointIssueCertResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.EntrypointIssueCertRequest, ...grpc.CallOption) *gen.EntrypointIssueCertResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Release)
+			r0 = ret.Get(0).(*gen.EntrypointIssueCertResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestReleaseRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.EntrypointIssueCertRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -558,8 +588,38 @@ func (_m *WaypointClient) GetLatestRelease(ctx context.Context, in *gen.GetLates
 	return r0, r1
 }
 
-// GetLogStream provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetLogStream(ctx context.Context, in *gen.GetLogStreamRequest, opts ...grpc.CallOption) (gen.Waypoint_GetLogStreamClient, error) {
+// EntrypointLogStream provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) EntrypointLogStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_EntrypointLogStreamClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_EntrypointLogStreamClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_EntrypointLogStreamClient); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_EntrypointLogStreamClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgetRunner provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ForgetRunner(ctx context.Context, in *gen.ForgetRunnerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -569,17 +629,17 @@ func (_m *WaypointClient) GetLogStream(ctx context.Context, in *gen.GetLogStream
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_GetLogStreamClient
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLogStreamRequest, ...grpc.CallOption) gen.Waypoint_GetLogStreamClient); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ForgetRunnerRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_GetLogStreamClient)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLogStreamRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ForgetRunnerRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -588,8 +648,8 @@ func (_m *WaypointClient) GetLogStream(ctx context.Context, in *gen.GetLogStream
 	return r0, r1
 }
 
-// GetProject provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetProject(ctx context.Context, in *gen.GetProjectRequest, opts ...grpc.CallOption) (*gen.GetProjectResponse, error) {
+// GenerateInviteToken provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GenerateInviteToken(ctx context.Context, in *gen.InviteTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -599,17 +659,17 @@ func (_m *WaypointClient) GetProject(ctx context.Context, in *gen.GetProjectRequ
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.GetProjectResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectRequest, ...grpc.CallOption) *gen.GetProjectResponse); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.InviteTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetProjectResponse)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.InviteTokenRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -618,8 +678,8 @@ func (_m *WaypointClient) GetProject(ctx context.Context, in *gen.GetProjectRequ
 	return r0, r1
 }
 
-// GetPushedArtifact provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetPushedArtifact(ctx context.Context, in *gen.GetPushedArtifactRequest, opts ...grpc.CallOption) (*gen.PushedArtifact, error) {
+// GenerateLoginToken provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GenerateLoginToken(ctx context.Context, in *gen.LoginTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -629,17 +689,17 @@ func (_m *WaypointClient) GetPushedArtifact(ctx context.Context, in *gen.GetPush
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.PushedArtifact
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetPushedArtifactRequest, ...grpc.CallOption) *gen.PushedArtifact); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.LoginTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.PushedArtifact)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetPushedArtifactRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.LoginTokenRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -648,8 +708,938 @@ func (_m *WaypointClient) GetPushedArtifact(ctx context.Context, in *gen.GetPush
 	return r0, r1
 }
 
-// GetRelease provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetRelease(ctx context.Context, in *gen.GetReleaseRequest, opts ...grpc.CallOption) (*gen.Release, error) {
+// GetAuditLog provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetAuditLog(ctx context.Context, in *gen.GetAuditLogRequest, opts ...grpc.CallOption) (*gen.GetAuditLogResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetAuditLogResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetAuditLogRequest, ...grpc.CallOption) *gen.GetAuditLogResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetAuditLogResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetAuditLogRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBuild provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetBuild(ctx context.Context, in *gen.GetBuildRequest, opts ...grpc.CallOption) (*gen.Build, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Build
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetBuildRequest, ...grpc.CallOption) *gen.Build); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Build)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetBuildRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetConfig(ctx context.Context, in *gen.ConfigGetRequest, opts ...grpc.CallOption) (*gen.ConfigGetResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ConfigGetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConfigGetRequest, ...grpc.CallOption) *gen.ConfigGetResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ConfigGetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConfigGetRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeployment provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetDeployment(ctx context.Context, in *gen.GetDeploymentRequest, opts ...grpc.CallOption) (*gen.Deployment, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Deployment
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetDeploymentRequest, ...grpc.CallOption) *gen.Deployment); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Deployment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetDeploymentRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExecSession provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetExecSession(ctx context.Context, in *gen.GetExecSessionRequest, opts ...grpc.CallOption) (*gen.ExecSession, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ExecSession
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetExecSessionRequest, ...grpc.CallOption) *gen.ExecSession); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ExecSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetExecSessionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetJob(ctx context.Context, in *gen.GetJobRequest, opts ...grpc.CallOption) (*gen.Job, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Job
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobRequest, ...grpc.CallOption) *gen.Job); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobStream provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetJobStream(ctx context.Context, in *gen.GetJobStreamRequest, opts ...grpc.CallOption) (gen.Waypoint_GetJobStreamClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_GetJobStreamClient
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobStreamRequest, ...grpc.CallOption) gen.Waypoint_GetJobStreamClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_GetJobStreamClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobStreamRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBuild provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetLatestBuild(ctx context.Context, in *gen.GetLatestBuildRequest, opts ...grpc.CallOption) (*gen.Build, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Build
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestBuildRequest, ...grpc.CallOption) *gen.Build); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Build)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestBuildRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestHealthReport provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetLatestHealthReport(ctx context.Context, in *gen.GetLatestHealthReportRequest, opts ...grpc.CallOption) (*gen.HealthReport, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestHealthReportRequest, ...grpc.CallOption) *gen.HealthReport); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.HealthReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestHealthReportRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestPushedArtifact provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetLatestPushedArtifact(ctx context.Context, in *gen.GetLatestPushedArtifactRequest, opts ...grpc.CallOption) (*gen.PushedArtifact, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.PushedArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestPushedArtifactRequest, ...grpc.CallOption) *gen.PushedArtifact); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.PushedArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestPushedArtifactRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestRelease provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetLatestRelease(ctx context.Context, in *gen.GetLatestReleaseRequest, opts ...grpc.CallOption) (*gen.Release, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Release
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestReleaseRequest, ...grpc.CallOption) *gen.Release); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestReleaseRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLogStream provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetLogStream(ctx context.Context, in *gen.GetLogStreamRequest, opts ...grpc.CallOption) (gen.Waypoint_GetLogStreamClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_GetLogStreamClient
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLogStreamRequest, ...grpc.CallOption) gen.Waypoint_GetLogStreamClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_GetLogStreamClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLogStreamRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOIDCAuthURL provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetOIDCAuthURL(ctx context.Context, in *gen.GetOIDCAuthURLRequest, opts ...grpc.CallOption) (*gen.GetOIDCAuthURLResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetOIDCAuthURLResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetOIDCAuthURLRequest, ...grpc.CallOption) *gen.GetOIDCAuthURLResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetOIDCAuthURLResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetOIDCAuthURLRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetProject(ctx context.Context, in *gen.GetProjectRequest, opts ...grpc.CallOption) (*gen.GetProjectResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetProjectResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectRequest, ...grpc.CallOption) *gen.GetProjectResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetProjectResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetProjectConfig(ctx context.Context, in *gen.GetProjectConfigRequest, opts ...grpc.CallOption) (*gen.GetProjectConfigResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetProjectConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectConfigRequest, ...grpc.CallOption) *gen.GetProjectConfigResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetProjectConfigResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectConfigRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPushedArtifact provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetPushedArtifact(ctx context.Context, in *gen.GetPushedArtifactRequest, opts ...grpc.CallOption) (*gen.PushedArtifact, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.PushedArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetPushedArtifactRequest, ...grpc.CallOption) *gen.PushedArtifact); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.PushedArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetPushedArtifactRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRelease provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetRelease(ctx context.Context, in *gen.GetReleaseRequest, opts ...grpc.CallOption) (*gen.Release, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Release
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetReleaseRequest, ...grpc.CallOption) *gen.Release); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetReleaseRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRunner provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetRunner(ctx context.Context, in *gen.GetRunnerRequest, opts ...grpc.CallOption) (*gen.Runner, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.Runner
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetRunnerRequest, ...grpc.CallOption) *gen.Runner); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Runner)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetRunnerRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetServerConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.GetServerConfigResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetServerConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.GetServerConfigResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetServerConfigResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSource provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetSource(ctx context.Context, in *gen.GetSourceRequest, opts ...grpc.CallOption) (gen.Waypoint_GetSourceClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_GetSourceClient
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetSourceRequest, ...grpc.CallOption) gen.Waypoint_GetSourceClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_GetSourceClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetSourceRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersionInfo provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetVersionInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.GetVersionInfoResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetVersionInfoResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.GetVersionInfoResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetVersionInfoResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspace provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) GetWorkspace(ctx context.Context, in *gen.GetWorkspaceRequest, opts ...grpc.CallOption) (*gen.GetWorkspaceResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.GetWorkspaceResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetWorkspaceRequest, ...grpc.CallOption) *gen.GetWorkspaceResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetWorkspaceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetWorkspaceRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthMethods provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListAuthMethods(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListAuthMethodsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListAuthMethodsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListAuthMethodsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListAuthMethodsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBuilds provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListBuilds(ctx context.Context, in *gen.ListBuildsRequest, opts ...grpc.CallOption) (*gen.ListBuildsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListBuildsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListBuildsRequest, ...grpc.CallOption) *gen.ListBuildsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListBuildsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListBuildsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeployments provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListDeployments(ctx context.Context, in *gen.ListDeploymentsRequest, opts ...grpc.CallOption) (*gen.ListDeploymentsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListDeploymentsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListDeploymentsRequest, ...grpc.CallOption) *gen.ListDeploymentsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListDeploymentsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListDeploymentsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExecSessions provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListExecSessions(ctx context.Context, in *gen.ListExecSessionsRequest, opts ...grpc.CallOption) (*gen.ListExecSessionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListExecSessionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListExecSessionsRequest, ...grpc.CallOption) *gen.ListExecSessionsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListExecSessionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListExecSessionsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHostnames provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListHostnames(ctx context.Context, in *gen.ListHostnamesRequest, opts ...grpc.CallOption) (*gen.ListHostnamesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListHostnamesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListHostnamesRequest, ...grpc.CallOption) *gen.ListHostnamesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListHostnamesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListHostnamesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInstances provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListInstances(ctx context.Context, in *gen.ListInstancesRequest, opts ...grpc.CallOption) (*gen.ListInstancesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListInstancesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListInstancesRequest, ...grpc.CallOption) *gen.ListInstancesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListInstancesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListInstancesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobs provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListJobs(ctx context.Context, in *gen.ListJobsRequest, opts ...grpc.CallOption) (*gen.ListJobsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListJobsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListJobsRequest, ...grpc.CallOption) *gen.ListJobsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListJobsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListJobsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOIDCAuthMethods provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListOIDCAuthMethods(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListOIDCAuthMethodsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListOIDCAuthMethodsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListOIDCAuthMethodsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListOIDCAuthMethodsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjects provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListProjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListProjectsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.ListProjectsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListProjectsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListProjectsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPushedArtifacts provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListPushedArtifacts(ctx context.Context, in *gen.ListPushedArtifactsRequest, opts ...grpc.CallOption) (*gen.ListPushedArtifactsResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -659,17 +1649,17 @@ func (_m *WaypointClient) GetRelease(ctx context.Context, in *gen.GetReleaseRequ
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Release
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetReleaseRequest, ...grpc.CallOption) *gen.Release); ok {
+	var r0 *gen.ListPushedArtifactsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListPushedArtifactsRequest, ...grpc.CallOption) *gen.ListPushedArtifactsResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Release)
+			r0 = ret.Get(0).(*gen.ListPushedArtifactsResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetReleaseRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListPushedArtifactsRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -678,8 +1668,8 @@ func (_m *WaypointClient) GetRelease(ctx context.Context, in *gen.GetReleaseRequ
 	return r0, r1
 }
 
-// GetRunner provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetRunner(ctx context.Context, in *gen.GetRunnerRequest, opts ...grpc.CallOption) (*gen.Runner, error) {
+// ListRecurringSchedules provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListRecurringSchedules(ctx context.Context, in *gen.ListRecurringSchedulesRequest, opts ...grpc.CallOption) (*gen.ListRecurringSchedulesResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -689,17 +1679,17 @@ func (_m *WaypointClient) GetRunner(ctx context.Context, in *gen.GetRunnerReques
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.Runner
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetRunnerRequest, ...grpc.CallOption) *gen.Runner); ok {
+	var r0 *gen.ListRecurringSchedulesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListRecurringSchedulesRequest, ...grpc.CallOption) *gen.ListRecurringSchedulesResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Runner)
+			r0 = ret.Get(0).(*gen.ListRecurringSchedulesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetRunnerRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListRecurringSchedulesRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -708,8 +1698,8 @@ func (_m *WaypointClient) GetRunner(ctx context.Context, in *gen.GetRunnerReques
 	return r0, r1
 }
 
-// GetServerConfig provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetServerConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.GetServerConfigResponse, error) {
+// ListReleases provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListReleases(ctx context.Context, in *gen.ListReleasesRequest, opts ...grpc.CallOption) (*gen.ListReleasesResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -719,17 +1709,17 @@ func (_m *WaypointClient) GetServerConfig(ctx context.Context, in *emptypb.Empty
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.GetServerConfigResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.GetServerConfigResponse); ok {
+	var r0 *gen.ListReleasesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListReleasesRequest, ...grpc.CallOption) *gen.ListReleasesResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetServerConfigResponse)
+			r0 = ret.Get(0).(*gen.ListReleasesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListReleasesRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -738,8 +1728,8 @@ func (_m *WaypointClient) GetServerConfig(ctx context.Context, in *emptypb.Empty
 	return r0, r1
 }
 
-// GetVersionInfo provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetVersionInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.GetVersionInfoResponse, error) {
+// ListRunnerProfiles provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListRunnerProfiles(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListRunnerProfilesResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -749,12 +1739,12 @@ func (_m *WaypointClient) GetVersionInfo(ctx context.Context, in *emptypb.Empty,
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.GetVersionInfoResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.GetVersionInfoResponse); ok {
+	var r0 *gen.ListRunnerProfilesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListRunnerProfilesResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetVersionInfoResponse)
+			r0 = ret.Get(0).(*gen.ListRunnerProfilesResponse)
 		}
 	}
 
@@ -768,8 +1758,8 @@ func (_m *WaypointClient) GetVersionInfo(ctx context.Context, in *emptypb.Empty,
 	return r0, r1
 }
 
-// GetWorkspace provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) GetWorkspace(ctx context.Context, in *gen.GetWorkspaceRequest, opts ...grpc.CallOption) (*gen.GetWorkspaceResponse, error) {
+// ListRunners provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListRunners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListRunnersResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -779,17 +1769,17 @@ func (_m *WaypointClient) GetWorkspace(ctx context.Context, in *gen.GetWorkspace
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.GetWorkspaceResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetWorkspaceRequest, ...grpc.CallOption) *gen.GetWorkspaceResponse); ok {
+	var r0 *gen.ListRunnersResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListRunnersResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetWorkspaceResponse)
+			r0 = ret.Get(0).(*gen.ListRunnersResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetWorkspaceRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -798,8 +1788,8 @@ func (_m *WaypointClient) GetWorkspace(ctx context.Context, in *gen.GetWorkspace
 	return r0, r1
 }
 
-// ListBuilds provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListBuilds(ctx context.Context, in *gen.ListBuildsRequest, opts ...grpc.CallOption) (*gen.ListBuildsResponse, error) {
+// ListServerConfigHistory provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListServerConfigHistory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListServerConfigHistoryResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -809,17 +1799,17 @@ func (_m *WaypointClient) ListBuilds(ctx context.Context, in *gen.ListBuildsRequ
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListBuildsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListBuildsRequest, ...grpc.CallOption) *gen.ListBuildsResponse); ok {
+	var r0 *gen.ListServerConfigHistoryResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListServerConfigHistoryResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListBuildsResponse)
+			r0 = ret.Get(0).(*gen.ListServerConfigHistoryResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListBuildsRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -828,8 +1818,8 @@ func (_m *WaypointClient) ListBuilds(ctx context.Context, in *gen.ListBuildsRequ
 	return r0, r1
 }
 
-// ListDeployments provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListDeployments(ctx context.Context, in *gen.ListDeploymentsRequest, opts ...grpc.CallOption) (*gen.ListDeploymentsResponse, error) {
+// ListWorkspaces provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ListWorkspaces(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListWorkspacesResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -839,17 +1829,17 @@ func (_m *WaypointClient) ListDeployments(ctx context.Context, in *gen.ListDeplo
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListDeploymentsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListDeploymentsRequest, ...grpc.CallOption) *gen.ListDeploymentsResponse); ok {
+	var r0 *gen.ListWorkspacesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListWorkspacesResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListDeploymentsResponse)
+			r0 = ret.Get(0).(*gen.ListWorkspacesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListDeploymentsRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -858,8 +1848,8 @@ func (_m *WaypointClient) ListDeployments(ctx context.Context, in *gen.ListDeplo
 	return r0, r1
 }
 
-// ListHostnames provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListHostnames(ctx context.Context, in *gen.ListHostnamesRequest, opts ...grpc.CallOption) (*gen.ListHostnamesResponse, error) {
+// QueueJob provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) QueueJob(ctx context.Context, in *gen.QueueJobRequest, opts ...grpc.CallOption) (*gen.QueueJobResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -869,17 +1859,17 @@ func (_m *WaypointClient) ListHostnames(ctx context.Context, in *gen.ListHostnam
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListHostnamesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListHostnamesRequest, ...grpc.CallOption) *gen.ListHostnamesResponse); ok {
+	var r0 *gen.QueueJobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.QueueJobRequest, ...grpc.CallOption) *gen.QueueJobResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListHostnamesResponse)
+			r0 = ret.Get(0).(*gen.QueueJobResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListHostnamesRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.QueueJobRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -888,8 +1878,8 @@ func (_m *WaypointClient) ListHostnames(ctx context.Context, in *gen.ListHostnam
 	return r0, r1
 }
 
-// ListInstances provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListInstances(ctx context.Context, in *gen.ListInstancesRequest, opts ...grpc.CallOption) (*gen.ListInstancesResponse, error) {
+// RejectRunner provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) RejectRunner(ctx context.Context, in *gen.RejectRunnerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -899,17 +1889,17 @@ func (_m *WaypointClient) ListInstances(ctx context.Context, in *gen.ListInstanc
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListInstancesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListInstancesRequest, ...grpc.CallOption) *gen.ListInstancesResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RejectRunnerRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListInstancesResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListInstancesRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RejectRunnerRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -918,29 +1908,29 @@ func (_m *WaypointClient) ListInstances(ctx context.Context, in *gen.ListInstanc
 	return r0, r1
 }
 
-// ListProjects provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListProjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListProjectsResponse, error) {
+// RestoreSnapshot provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_RestoreSnapshotClient, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListProjectsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListProjectsResponse); ok {
-		r0 = rf(ctx, in, opts...)
+	var r0 gen.Waypoint_RestoreSnapshotClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_RestoreSnapshotClient); ok {
+		r0 = rf(ctx, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListProjectsResponse)
+			r0 = ret.Get(0).(gen.Waypoint_RestoreSnapshotClient)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
-		r1 = rf(ctx, in, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -948,8 +1938,8 @@ func (_m *WaypointClient) ListProjects(ctx context.Context, in *emptypb.Empty, o
 	return r0, r1
 }
 
-// ListPushedArtifacts provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListPushedArtifacts(ctx context.Context, in *gen.ListPushedArtifactsRequest, opts ...grpc.CallOption) (*gen.ListPushedArtifactsResponse, error) {
+// RollbackServerConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) RollbackServerConfig(ctx context.Context, in *gen.RollbackServerConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -959,17 +1949,17 @@ func (_m *WaypointClient) ListPushedArtifacts(ctx context.Context, in *gen.ListP
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListPushedArtifactsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListPushedArtifactsRequest, ...grpc.CallOption) *gen.ListPushedArtifactsResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RollbackServerConfigRequest, ...grpc.CallOption) *emptypb.Empty); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListPushedArtifactsResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListPushedArtifactsRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RollbackServerConfigRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -978,8 +1968,8 @@ func (_m *WaypointClient) ListPushedArtifacts(ctx context.Context, in *gen.ListP
 	return r0, r1
 }
 
-// ListReleases provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListReleases(ctx context.Context, in *gen.ListReleasesRequest, opts ...grpc.CallOption) (*gen.ListReleasesResponse, error) {
+// RotateToken provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) RotateToken(ctx context.Context, in *gen.RotateTokenRequest, opts ...grpc.CallOption) (*gen.NewTokenResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -989,17 +1979,17 @@ func (_m *WaypointClient) ListReleases(ctx context.Context, in *gen.ListReleases
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListReleasesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListReleasesRequest, ...grpc.CallOption) *gen.ListReleasesResponse); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RotateTokenRequest, ...grpc.CallOption) *gen.NewTokenResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListReleasesResponse)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListReleasesRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RotateTokenRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -1008,29 +1998,29 @@ func (_m *WaypointClient) ListReleases(ctx context.Context, in *gen.ListReleases
 	return r0, r1
 }
 
-// ListWorkspaces provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ListWorkspaces(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*gen.ListWorkspacesResponse, error) {
+// RunnerConfig provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) RunnerConfig(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_RunnerConfigClient, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListWorkspacesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *gen.ListWorkspacesResponse); ok {
-		r0 = rf(ctx, in, opts...)
+	var r0 gen.Waypoint_RunnerConfigClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_RunnerConfigClient); ok {
+		r0 = rf(ctx, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListWorkspacesResponse)
+			r0 = ret.Get(0).(gen.Waypoint_RunnerConfigClient)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
-		r1 = rf(ctx, in, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1038,8 +2028,8 @@ func (_m *WaypointClient) ListWorkspaces(ctx context.Context, in *emptypb.Empty,
 	return r0, r1
 }
 
-// QueueJob provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) QueueJob(ctx context.Context, in *gen.QueueJobRequest, opts ...grpc.CallOption) (*gen.QueueJobResponse, error) {
+// RunnerGetDeploymentConfig provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) RunnerGetDeploymentConfig(ctx context.Context, in *gen.RunnerGetDeploymentConfigRequest, opts ...grpc.CallOption) (*gen.RunnerGetDeploymentConfigResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -1049,17 +2039,17 @@ func (_m *WaypointClient) QueueJob(ctx context.Context, in *gen.QueueJobRequest,
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.QueueJobResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.QueueJobRequest, ...grpc.CallOption) *gen.QueueJobResponse); ok {
+	var r0 *gen.RunnerGetDeploymentConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RunnerGetDeploymentConfigRequest, ...grpc.CallOption) *gen.RunnerGetDeploymentConfigResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.QueueJobResponse)
+			r0 = ret.Get(0).(*gen.RunnerGetDeploymentConfigResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.QueueJobRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RunnerGetDeploymentConfigRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -1068,8 +2058,8 @@ func (_m *WaypointClient) QueueJob(ctx context.Context, in *gen.QueueJobRequest,
 	return r0, r1
 }
 
-// RunnerConfig provides a mock function with given fields: ctx, opts
-func (_m *WaypointClient) RunnerConfig(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_RunnerConfigClient, error) {
+// RunnerJobStream provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) RunnerJobStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_RunnerJobStreamClient, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -1079,12 +2069,12 @@ func (_m *WaypointClient) RunnerConfig(ctx context.Context, opts ...grpc.CallOpt
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_RunnerConfigClient
-	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_RunnerConfigClient); ok {
+	var r0 gen.Waypoint_RunnerJobStreamClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_RunnerJobStreamClient); ok {
 		r0 = rf(ctx, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_RunnerConfigClient)
+			r0 = ret.Get(0).(gen.Waypoint_RunnerJobStreamClient)
 		}
 	}
 
@@ -1098,8 +2088,8 @@ func (_m *WaypointClient) RunnerConfig(ctx context.Context, opts ...grpc.CallOpt
 	return r0, r1
 }
 
-// RunnerGetDeploymentConfig provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) RunnerGetDeploymentConfig(ctx context.Context, in *gen.RunnerGetDeploymentConfigRequest, opts ...grpc.CallOption) (*gen.RunnerGetDeploymentConfigResponse, error) {
+// ScheduleOperation provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ScheduleOperation(ctx context.Context, in *gen.ScheduleOperationRequest, opts ...grpc.CallOption) (*gen.ScheduleOperationResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -1109,17 +2099,17 @@ func (_m *WaypointClient) RunnerGetDeploymentConfig(ctx context.Context, in *gen
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.RunnerGetDeploymentConfigResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.RunnerGetDeploymentConfigRequest, ...grpc.CallOption) *gen.RunnerGetDeploymentConfigResponse); ok {
+	var r0 *gen.ScheduleOperationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ScheduleOperationRequest, ...grpc.CallOption) *gen.ScheduleOperationResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.RunnerGetDeploymentConfigResponse)
+			r0 = ret.Get(0).(*gen.ScheduleOperationResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.RunnerGetDeploymentConfigRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ScheduleOperationRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -1128,29 +2118,29 @@ func (_m *WaypointClient) RunnerGetDeploymentConfig(ctx context.Context, in *gen
 	return r0, r1
 }
 
-// RunnerJobStream provides a mock function with given fields: ctx, opts
-func (_m *WaypointClient) RunnerJobStream(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_RunnerJobStreamClient, error) {
+// ScheduleRecurringOperation provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ScheduleRecurringOperation(ctx context.Context, in *gen.ScheduleRecurringOperationRequest, opts ...grpc.CallOption) (*gen.ScheduleOperationResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx)
+	_ca = append(_ca, ctx, in)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 gen.Waypoint_RunnerJobStreamClient
-	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_RunnerJobStreamClient); ok {
-		r0 = rf(ctx, opts...)
+	var r0 *gen.ScheduleOperationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ScheduleRecurringOperationRequest, ...grpc.CallOption) *gen.ScheduleOperationResponse); ok {
+		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(gen.Waypoint_RunnerJobStreamClient)
+			r0 = ret.Get(0).(*gen.ScheduleOperationResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
-		r1 = rf(ctx, opts...)
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ScheduleRecurringOperationRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1248,6 +2238,36 @@ func (_m *WaypointClient) StartExecStream(ctx context.Context, opts ...grpc.Call
 	return r0, r1
 }
 
+// UploadSource provides a mock function with given fields: ctx, opts
+func (_m *WaypointClient) UploadSource(ctx context.Context, opts ...grpc.CallOption) (gen.Waypoint_UploadSourceClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gen.Waypoint_UploadSourceClient
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) gen.Waypoint_UploadSourceClient); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gen.Waypoint_UploadSourceClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertApplication provides a mock function with given fields: ctx, in, opts
 func (_m *WaypointClient) UpsertApplication(ctx context.Context, in *gen.UpsertApplicationRequest, opts ...grpc.CallOption) (*gen.UpsertApplicationResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -1278,6 +2298,36 @@ func (_m *WaypointClient) UpsertApplication(ctx context.Context, in *gen.UpsertA
 	return r0, r1
 }
 
+// UpsertAuthMethod provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) UpsertAuthMethod(ctx context.Context, in *gen.UpsertAuthMethodRequest, opts ...grpc.CallOption) (*gen.UpsertAuthMethodResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.UpsertAuthMethodResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertAuthMethodRequest, ...grpc.CallOption) *gen.UpsertAuthMethodResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.UpsertAuthMethodResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertAuthMethodRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertBuild provides a mock function with given fields: ctx, in, opts
 func (_m *WaypointClient) UpsertBuild(ctx context.Context, in *gen.UpsertBuildRequest, opts ...grpc.CallOption) (*gen.UpsertBuildResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -1338,6 +2388,36 @@ func (_m *WaypointClient) UpsertDeployment(ctx context.Context, in *gen.UpsertDe
 	return r0, r1
 }
 
+// UpsertHealthReport provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) UpsertHealthReport(ctx context.Context, in *gen.UpsertHealthReportRequest, opts ...grpc.CallOption) (*gen.UpsertHealthReportResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *gen.UpsertHealthReportResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertHealthReportRequest, ...grpc.CallOption) *gen.UpsertHealthReportResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.UpsertHealthReportResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertHealthReportRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertProject provides a mock function with given fields: ctx, in, opts
 func (_m *WaypointClient) UpsertProject(ctx context.Context, in *gen.UpsertProjectRequest, opts ...grpc.CallOption) (*gen.UpsertProjectResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -1428,8 +2508,8 @@ func (_m *WaypointClient) UpsertRelease(ctx context.Context, in *gen.UpsertRelea
 	return r0, r1
 }
 
-// ValidateJob provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) ValidateJob(ctx context.Context, in *gen.ValidateJobRequest, opts ...grpc.CallOption) (*gen.ValidateJobResponse, error) {
+// UpsertRunnerProfile provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) UpsertRunnerProfile(ctx context.Context, in *gen.UpsertRunnerProfileRequest, opts ...grpc.CallOption) (*gen.UpsertRunnerProfileResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -1439,17 +2519,17 @@ func (_m *WaypointClient) ValidateJob(ctx context.Context, in *gen.ValidateJobRe
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ValidateJobResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ValidateJobRequest, ...grpc.CallOption) *gen.ValidateJobResponse); ok {
+	var r0 *gen.UpsertRunnerProfileResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertRunnerProfileRequest, ...grpc.CallOption) *gen.UpsertRunnerProfileResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ValidateJobResponse)
+			r0 = ret.Get(0).(*gen.UpsertRunnerProfileResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ValidateJobRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertRunnerProfileRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -1458,8 +2538,8 @@ func (_m *WaypointClient) ValidateJob(ctx context.Context, in *gen.ValidateJobRe
 	return r0, r1
 }
 
-// XListJobs provides a mock function with given fields: ctx, in, opts
-func (_m *WaypointClient) XListJobs(ctx context.Context, in *gen.ListJobsRequest, opts ...grpc.CallOption) (*gen.ListJobsResponse, error) {
+// ValidateJob provides a mock function with given fields: ctx, in, opts
+func (_m *WaypointClient) ValidateJob(ctx context.Context, in *gen.ValidateJobRequest, opts ...grpc.CallOption) (*gen.ValidateJobResponse, error) {
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
 		_va[_i] = opts[_i]
@@ -1469,17 +2549,17 @@ func (_m *WaypointClient) XListJobs(ctx context.Context, in *gen.ListJobsRequest
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *gen.ListJobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListJobsRequest, ...grpc.CallOption) *gen.ListJobsResponse); ok {
+	var r0 *gen.ValidateJobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ValidateJobRequest, ...grpc.CallOption) *gen.ValidateJobResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListJobsResponse)
+			r0 = ret.Get(0).(*gen.ValidateJobResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListJobsRequest, ...grpc.CallOption) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ValidateJobRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
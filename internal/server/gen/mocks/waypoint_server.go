@@ -16,6 +16,52 @@ type WaypointServer struct {
 	mock.Mock
 }
 
+// AdoptRunner provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) AdoptRunner(_a0 context.Context, _a1 *gen.AdoptRunnerRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.AdoptRunnerRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.AdoptRunnerRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApproveJob provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ApproveJob(_a0 context.Context, _a1 *gen.ApproveJobRequest) (*emptypb.Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ApproveJobRequest) *emptypb.Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*emptypb.Empty)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ApproveJobRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BootstrapToken provides a mock function with given fields: _a0, _a1
 func (_m *WaypointServer) BootstrapToken(_a0 context.Context, _a1 *emptypb.Empty) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -62,21 +108,21 @@ func (_m *WaypointServer) CancelJob(_a0 context.Context, _a1 *gen.CancelJobReque
 	return r0, r1
 }
 
-// ConvertInviteToken provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ConvertInviteToken(_a0 context.Context, _a1 *gen.ConvertInviteTokenRequest) (*gen.NewTokenResponse, error) {
+// CancelScheduledOperation provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) CancelScheduledOperation(_a0 context.Context, _a1 *gen.CancelScheduledOperationRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConvertInviteTokenRequest) *gen.NewTokenResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CancelScheduledOperationRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConvertInviteTokenRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CancelScheduledOperationRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -85,21 +131,21 @@ func (_m *WaypointServer) ConvertInviteToken(_a0 context.Context, _a1 *gen.Conve
 	return r0, r1
 }
 
-// CreateHostname provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) CreateHostname(_a0 context.Context, _a1 *gen.CreateHostnameRequest) (*gen.CreateHostnameResponse, error) {
+// CompleteOIDCAuth provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) CompleteOIDCAuth(_a0 context.Context, _a1 *gen.CompleteOIDCAuthRequest) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.CreateHostnameResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateHostnameRequest) *gen.CreateHostnameResponse); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CompleteOIDCAuthRequest) *gen.NewTokenResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.CreateHostnameResponse)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateHostnameRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CompleteOIDCAuthRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -108,21 +154,21 @@ func (_m *WaypointServer) CreateHostname(_a0 context.Context, _a1 *gen.CreateHos
 	return r0, r1
 }
 
-// DeleteHostname provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) DeleteHostname(_a0 context.Context, _a1 *gen.DeleteHostnameRequest) (*emptypb.Empty, error) {
+// ConvertInviteToken provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ConvertInviteToken(_a0 context.Context, _a1 *gen.ConvertInviteTokenRequest) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *emptypb.Empty
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteHostnameRequest) *emptypb.Empty); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConvertInviteTokenRequest) *gen.NewTokenResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*emptypb.Empty)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteHostnameRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConvertInviteTokenRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -131,27 +177,36 @@ func (_m *WaypointServer) DeleteHostname(_a0 context.Context, _a1 *gen.DeleteHos
 	return r0, r1
 }
 
-// EntrypointConfig provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) EntrypointConfig(_a0 *gen.EntrypointConfigRequest, _a1 gen.Waypoint_EntrypointConfigServer) error {
+// CreateHostname provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) CreateHostname(_a0 context.Context, _a1 *gen.CreateHostnameRequest) (*gen.CreateHostnameResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gen.EntrypointConfigRequest, gen.Waypoint_EntrypointConfigServer) error); ok {
+	var r0 *gen.CreateHostnameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateHostnameRequest) *gen.CreateHostnameResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.CreateHostnameResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateHostnameRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// EntrypointExecStream provides a mock function with given fields: _a0
-func (_m *WaypointServer) EntrypointExecStream(_a0 gen.Waypoint_EntrypointExecStreamServer) error {
-	ret := _m.Called(_a0)
+// CreateSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) CreateSnapshot(_a0 *emptypb.Empty, _a1 gen.Waypoint_CreateSnapshotServer) error {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(gen.Waypoint_EntrypointExecStreamServer) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*emptypb.Empty, gen.Waypoint_CreateSnapshotServer) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -159,35 +214,44 @@ func (_m *WaypointServer) EntrypointExecStream(_a0 gen.Waypoint_EntrypointExecSt
 	return r0
 }
 
-// EntrypointLogStream provides a mock function with given fields: _a0
-func (_m *WaypointServer) EntrypointLogStream(_a0 gen.Waypoint_EntrypointLogStreamServer) error {
-	ret := _m.Called(_a0)
+// CreateTrigger provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) CreateTrigger(_a0 context.Context, _a1 *gen.CreateTriggerRequest) (*gen.CreateTriggerResponse, error) {
+	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(gen.Waypoint_EntrypointLogStreamServer) error); ok {
-		r0 = rf(_a0)
+	var r0 *gen.CreateTriggerResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.CreateTriggerRequest) *gen.CreateTriggerResponse); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.CreateTriggerResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.CreateTriggerRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GenerateInviteToken provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GenerateInviteToken(_a0 context.Context, _a1 *gen.InviteTokenRequest) (*gen.NewTokenResponse, error) {
+// DeleteAuthMethod provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteAuthMethod(_a0 context.Context, _a1 *gen.DeleteAuthMethodRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.InviteTokenRequest) *gen.NewTokenResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteAuthMethodRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.InviteTokenRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteAuthMethodRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -196,21 +260,21 @@ func (_m *WaypointServer) GenerateInviteToken(_a0 context.Context, _a1 *gen.Invi
 	return r0, r1
 }
 
-// GenerateLoginToken provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GenerateLoginToken(_a0 context.Context, _a1 *emptypb.Empty) (*gen.NewTokenResponse, error) {
+// DeleteHostname provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteHostname(_a0 context.Context, _a1 *gen.DeleteHostnameRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.NewTokenResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.NewTokenResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteHostnameRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.NewTokenResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteHostnameRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -219,21 +283,21 @@ func (_m *WaypointServer) GenerateLoginToken(_a0 context.Context, _a1 *emptypb.E
 	return r0, r1
 }
 
-// GetBuild provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetBuild(_a0 context.Context, _a1 *gen.GetBuildRequest) (*gen.Build, error) {
+// DeleteRecurringSchedule provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteRecurringSchedule(_a0 context.Context, _a1 *gen.DeleteRecurringScheduleRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Build
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetBuildRequest) *gen.Build); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteRecurringScheduleRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Build)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetBuildRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteRecurringScheduleRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -242,21 +306,21 @@ func (_m *WaypointServer) GetBuild(_a0 context.Context, _a1 *gen.GetBuildRequest
 	return r0, r1
 }
 
-// GetConfig provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetConfig(_a0 context.Context, _a1 *gen.ConfigGetRequest) (*gen.ConfigGetResponse, error) {
+// DeleteRunnerProfile provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteRunnerProfile(_a0 context.Context, _a1 *gen.DeleteRunnerProfileRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ConfigGetResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConfigGetRequest) *gen.ConfigGetResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteRunnerProfileRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ConfigGetResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConfigGetRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteRunnerProfileRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -265,21 +329,21 @@ func (_m *WaypointServer) GetConfig(_a0 context.Context, _a1 *gen.ConfigGetReque
 	return r0, r1
 }
 
-// GetDeployment provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetDeployment(_a0 context.Context, _a1 *gen.GetDeploymentRequest) (*gen.Deployment, error) {
+// DeleteTrigger provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteTrigger(_a0 context.Context, _a1 *gen.DeleteTriggerRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Deployment
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetDeploymentRequest) *gen.Deployment); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteTriggerRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Deployment)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetDeploymentRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteTriggerRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -288,21 +352,21 @@ func (_m *WaypointServer) GetDeployment(_a0 context.Context, _a1 *gen.GetDeploym
 	return r0, r1
 }
 
-// GetJob provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetJob(_a0 context.Context, _a1 *gen.GetJobRequest) (*gen.Job, error) {
+// DeleteWorkspace provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) DeleteWorkspace(_a0 context.Context, _a1 *gen.DeleteWorkspaceRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Job
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobRequest) *gen.Job); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.DeleteWorkspaceRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Job)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.DeleteWorkspaceRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -311,12 +375,12 @@ func (_m *WaypointServer) GetJob(_a0 context.Context, _a1 *gen.GetJobRequest) (*
 	return r0, r1
 }
 
-// GetJobStream provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetJobStream(_a0 *gen.GetJobStreamRequest, _a1 gen.Waypoint_GetJobStreamServer) error {
+// EntrypointConfig provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) EntrypointConfig(_a0 *gen.EntrypointConfigRequest, _a1 gen.Waypoint_EntrypointConfigServer) error {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gen.GetJobStreamRequest, gen.Waypoint_GetJobStreamServer) error); ok {
+	if rf, ok := ret.Get(0).(func(*gen.EntrypointConfigRequest, gen.Waypoint_EntrypointConfigServer) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -325,21 +389,35 @@ func (_m *WaypointServer) GetJobStream(_a0 *gen.GetJobStreamRequest, _a1 gen.Way
 	return r0
 }
 
-// GetLatestBuild provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetLatestBuild(_a0 context.Context, _a1 *gen.GetLatestBuildRequest) (*gen.Build, error) {
+// EntrypointExecStream provides a mock function with given fields: _a0
+func (_m *WaypointServer) EntrypointExecStream(_a0 gen.Waypoint_EntrypointExecStreamServer) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(gen.Waypoint_EntrypointExecStreamServer) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EntrypointIssueCert provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) EntrypointIssueCert(_a0 context.Context, _a1 *gen.EntrypointIssueCertRequest) (*gen.EntrypointIssueCertResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Build
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestBuildRequest) *gen.Build); ok {
+	var r0 *gen.EntrypointIssueCertResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.EntrypointIssueCertRequest) *gen.EntrypointIssueCertResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Build)
+			r0 = ret.Get(0).(*gen.EntrypointIssueCertResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestBuildRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.EntrypointIssueCertRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -348,21 +426,35 @@ func (_m *WaypointServer) GetLatestBuild(_a0 context.Context, _a1 *gen.GetLatest
 	return r0, r1
 }
 
-// GetLatestPushedArtifact provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetLatestPushedArtifact(_a0 context.Context, _a1 *gen.GetLatestPushedArtifactRequest) (*gen.PushedArtifact, error) {
+// EntrypointLogStream provides a mock function with given fields: _a0
+func (_m *WaypointServer) EntrypointLogStream(_a0 gen.Waypoint_EntrypointLogStreamServer) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(gen.Waypoint_EntrypointLogStreamServer) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgetRunner provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ForgetRunner(_a0 context.Context, _a1 *gen.ForgetRunnerRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.PushedArtifact
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestPushedArtifactRequest) *gen.PushedArtifact); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ForgetRunnerRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.PushedArtifact)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestPushedArtifactRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ForgetRunnerRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -371,21 +463,21 @@ func (_m *WaypointServer) GetLatestPushedArtifact(_a0 context.Context, _a1 *gen.
 	return r0, r1
 }
 
-// GetLatestRelease provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetLatestRelease(_a0 context.Context, _a1 *gen.GetLatestReleaseRequest) (*gen.Release, error) {
+// GenerateInviteToken provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GenerateInviteToken(_a0 context.Context, _a1 *gen.InviteTokenRequest) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Release
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestReleaseRequest) *gen.Release); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.InviteTokenRequest) *gen.NewTokenResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Release)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestReleaseRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.InviteTokenRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -394,35 +486,615 @@ func (_m *WaypointServer) GetLatestRelease(_a0 context.Context, _a1 *gen.GetLate
 	return r0, r1
 }
 
-// GetLogStream provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetLogStream(_a0 *gen.GetLogStreamRequest, _a1 gen.Waypoint_GetLogStreamServer) error {
+// GenerateLoginToken provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GenerateLoginToken(_a0 context.Context, _a1 *gen.LoginTokenRequest) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gen.GetLogStreamRequest, gen.Waypoint_GetLogStreamServer) error); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.LoginTokenRequest) *gen.NewTokenResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.LoginTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditLog provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetAuditLog(_a0 context.Context, _a1 *gen.GetAuditLogRequest) (*gen.GetAuditLogResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetAuditLogResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetAuditLogRequest) *gen.GetAuditLogResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetAuditLogResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetAuditLogRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBuild provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetBuild(_a0 context.Context, _a1 *gen.GetBuildRequest) (*gen.Build, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Build
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetBuildRequest) *gen.Build); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Build)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetBuildRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfig provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetConfig(_a0 context.Context, _a1 *gen.ConfigGetRequest) (*gen.ConfigGetResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ConfigGetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ConfigGetRequest) *gen.ConfigGetResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ConfigGetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ConfigGetRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeployment provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetDeployment(_a0 context.Context, _a1 *gen.GetDeploymentRequest) (*gen.Deployment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Deployment
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetDeploymentRequest) *gen.Deployment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Deployment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetDeploymentRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExecSession provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetExecSession(_a0 context.Context, _a1 *gen.GetExecSessionRequest) (*gen.ExecSession, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ExecSession
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetExecSessionRequest) *gen.ExecSession); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ExecSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetExecSessionRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetJob(_a0 context.Context, _a1 *gen.GetJobRequest) (*gen.Job, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Job
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetJobRequest) *gen.Job); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetJobRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobStream provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetJobStream(_a0 *gen.GetJobStreamRequest, _a1 gen.Waypoint_GetJobStreamServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gen.GetJobStreamRequest, gen.Waypoint_GetJobStreamServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLatestBuild provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetLatestBuild(_a0 context.Context, _a1 *gen.GetLatestBuildRequest) (*gen.Build, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Build
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestBuildRequest) *gen.Build); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Build)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestBuildRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestHealthReport provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetLatestHealthReport(_a0 context.Context, _a1 *gen.GetLatestHealthReportRequest) (*gen.HealthReport, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestHealthReportRequest) *gen.HealthReport); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.HealthReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestHealthReportRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestPushedArtifact provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetLatestPushedArtifact(_a0 context.Context, _a1 *gen.GetLatestPushedArtifactRequest) (*gen.PushedArtifact, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.PushedArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestPushedArtifactRequest) *gen.PushedArtifact); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.PushedArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestPushedArtifactRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestRelease provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetLatestRelease(_a0 context.Context, _a1 *gen.GetLatestReleaseRequest) (*gen.Release, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Release
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetLatestReleaseRequest) *gen.Release); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetLatestReleaseRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLogStream provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetLogStream(_a0 *gen.GetLogStreamRequest, _a1 gen.Waypoint_GetLogStreamServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gen.GetLogStreamRequest, gen.Waypoint_GetLogStreamServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOIDCAuthURL provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetOIDCAuthURL(_a0 context.Context, _a1 *gen.GetOIDCAuthURLRequest) (*gen.GetOIDCAuthURLResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetOIDCAuthURLResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetOIDCAuthURLRequest) *gen.GetOIDCAuthURLResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetOIDCAuthURLResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetOIDCAuthURLRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetProject(_a0 context.Context, _a1 *gen.GetProjectRequest) (*gen.GetProjectResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetProjectResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectRequest) *gen.GetProjectResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetProjectResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectConfig provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetProjectConfig(_a0 context.Context, _a1 *gen.GetProjectConfigRequest) (*gen.GetProjectConfigResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetProjectConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectConfigRequest) *gen.GetProjectConfigResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetProjectConfigResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectConfigRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPushedArtifact provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetPushedArtifact(_a0 context.Context, _a1 *gen.GetPushedArtifactRequest) (*gen.PushedArtifact, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.PushedArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetPushedArtifactRequest) *gen.PushedArtifact); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.PushedArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetPushedArtifactRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRelease provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetRelease(_a0 context.Context, _a1 *gen.GetReleaseRequest) (*gen.Release, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Release
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetReleaseRequest) *gen.Release); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetReleaseRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRunner provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetRunner(_a0 context.Context, _a1 *gen.GetRunnerRequest) (*gen.Runner, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.Runner
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetRunnerRequest) *gen.Runner); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.Runner)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetRunnerRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerConfig provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetServerConfig(_a0 context.Context, _a1 *emptypb.Empty) (*gen.GetServerConfigResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetServerConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.GetServerConfigResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetServerConfigResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSource provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetSource(_a0 *gen.GetSourceRequest, _a1 gen.Waypoint_GetSourceServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gen.GetSourceRequest, gen.Waypoint_GetSourceServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetVersionInfo provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetVersionInfo(_a0 context.Context, _a1 *emptypb.Empty) (*gen.GetVersionInfoResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetVersionInfoResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.GetVersionInfoResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetVersionInfoResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspace provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) GetWorkspace(_a0 context.Context, _a1 *gen.GetWorkspaceRequest) (*gen.GetWorkspaceResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.GetWorkspaceResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetWorkspaceRequest) *gen.GetWorkspaceResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.GetWorkspaceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetWorkspaceRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthMethods provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListAuthMethods(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListAuthMethodsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ListAuthMethodsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListAuthMethodsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListAuthMethodsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBuilds provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListBuilds(_a0 context.Context, _a1 *gen.ListBuildsRequest) (*gen.ListBuildsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ListBuildsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListBuildsRequest) *gen.ListBuildsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListBuildsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListBuildsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeployments provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListDeployments(_a0 context.Context, _a1 *gen.ListDeploymentsRequest) (*gen.ListDeploymentsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ListDeploymentsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListDeploymentsRequest) *gen.ListDeploymentsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListDeploymentsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListDeploymentsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExecSessions provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListExecSessions(_a0 context.Context, _a1 *gen.ListExecSessionsRequest) (*gen.ListExecSessionsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ListExecSessionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListExecSessionsRequest) *gen.ListExecSessionsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ListExecSessionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListExecSessionsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// GetProject provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetProject(_a0 context.Context, _a1 *gen.GetProjectRequest) (*gen.GetProjectResponse, error) {
+// ListHostnames provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListHostnames(_a0 context.Context, _a1 *gen.ListHostnamesRequest) (*gen.ListHostnamesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.GetProjectResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetProjectRequest) *gen.GetProjectResponse); ok {
+	var r0 *gen.ListHostnamesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListHostnamesRequest) *gen.ListHostnamesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetProjectResponse)
+			r0 = ret.Get(0).(*gen.ListHostnamesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetProjectRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListHostnamesRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -431,21 +1103,21 @@ func (_m *WaypointServer) GetProject(_a0 context.Context, _a1 *gen.GetProjectReq
 	return r0, r1
 }
 
-// GetPushedArtifact provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetPushedArtifact(_a0 context.Context, _a1 *gen.GetPushedArtifactRequest) (*gen.PushedArtifact, error) {
+// ListInstances provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListInstances(_a0 context.Context, _a1 *gen.ListInstancesRequest) (*gen.ListInstancesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.PushedArtifact
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetPushedArtifactRequest) *gen.PushedArtifact); ok {
+	var r0 *gen.ListInstancesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListInstancesRequest) *gen.ListInstancesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.PushedArtifact)
+			r0 = ret.Get(0).(*gen.ListInstancesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetPushedArtifactRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListInstancesRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -454,21 +1126,21 @@ func (_m *WaypointServer) GetPushedArtifact(_a0 context.Context, _a1 *gen.GetPus
 	return r0, r1
 }
 
-// GetRelease provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetRelease(_a0 context.Context, _a1 *gen.GetReleaseRequest) (*gen.Release, error) {
+// ListJobs provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListJobs(_a0 context.Context, _a1 *gen.ListJobsRequest) (*gen.ListJobsResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Release
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetReleaseRequest) *gen.Release); ok {
+	var r0 *gen.ListJobsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListJobsRequest) *gen.ListJobsResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Release)
+			r0 = ret.Get(0).(*gen.ListJobsResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetReleaseRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListJobsRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -477,21 +1149,21 @@ func (_m *WaypointServer) GetRelease(_a0 context.Context, _a1 *gen.GetReleaseReq
 	return r0, r1
 }
 
-// GetRunner provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetRunner(_a0 context.Context, _a1 *gen.GetRunnerRequest) (*gen.Runner, error) {
+// ListOIDCAuthMethods provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListOIDCAuthMethods(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListOIDCAuthMethodsResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.Runner
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetRunnerRequest) *gen.Runner); ok {
+	var r0 *gen.ListOIDCAuthMethodsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListOIDCAuthMethodsResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.Runner)
+			r0 = ret.Get(0).(*gen.ListOIDCAuthMethodsResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetRunnerRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -500,16 +1172,16 @@ func (_m *WaypointServer) GetRunner(_a0 context.Context, _a1 *gen.GetRunnerReque
 	return r0, r1
 }
 
-// GetServerConfig provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetServerConfig(_a0 context.Context, _a1 *emptypb.Empty) (*gen.GetServerConfigResponse, error) {
+// ListProjects provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListProjects(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListProjectsResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.GetServerConfigResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.GetServerConfigResponse); ok {
+	var r0 *gen.ListProjectsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListProjectsResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetServerConfigResponse)
+			r0 = ret.Get(0).(*gen.ListProjectsResponse)
 		}
 	}
 
@@ -523,21 +1195,21 @@ func (_m *WaypointServer) GetServerConfig(_a0 context.Context, _a1 *emptypb.Empt
 	return r0, r1
 }
 
-// GetVersionInfo provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetVersionInfo(_a0 context.Context, _a1 *emptypb.Empty) (*gen.GetVersionInfoResponse, error) {
+// ListPushedArtifacts provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListPushedArtifacts(_a0 context.Context, _a1 *gen.ListPushedArtifactsRequest) (*gen.ListPushedArtifactsResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.GetVersionInfoResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.GetVersionInfoResponse); ok {
+	var r0 *gen.ListPushedArtifactsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListPushedArtifactsRequest) *gen.ListPushedArtifactsResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetVersionInfoResponse)
+			r0 = ret.Get(0).(*gen.ListPushedArtifactsResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListPushedArtifactsRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -546,21 +1218,21 @@ func (_m *WaypointServer) GetVersionInfo(_a0 context.Context, _a1 *emptypb.Empty
 	return r0, r1
 }
 
-// GetWorkspace provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) GetWorkspace(_a0 context.Context, _a1 *gen.GetWorkspaceRequest) (*gen.GetWorkspaceResponse, error) {
+// ListRecurringSchedules provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListRecurringSchedules(_a0 context.Context, _a1 *gen.ListRecurringSchedulesRequest) (*gen.ListRecurringSchedulesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.GetWorkspaceResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.GetWorkspaceRequest) *gen.GetWorkspaceResponse); ok {
+	var r0 *gen.ListRecurringSchedulesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListRecurringSchedulesRequest) *gen.ListRecurringSchedulesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.GetWorkspaceResponse)
+			r0 = ret.Get(0).(*gen.ListRecurringSchedulesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.GetWorkspaceRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListRecurringSchedulesRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -569,21 +1241,21 @@ func (_m *WaypointServer) GetWorkspace(_a0 context.Context, _a1 *gen.GetWorkspac
 	return r0, r1
 }
 
-// ListBuilds provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListBuilds(_a0 context.Context, _a1 *gen.ListBuildsRequest) (*gen.ListBuildsResponse, error) {
+// ListReleases provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListReleases(_a0 context.Context, _a1 *gen.ListReleasesRequest) (*gen.ListReleasesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListBuildsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListBuildsRequest) *gen.ListBuildsResponse); ok {
+	var r0 *gen.ListReleasesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListReleasesRequest) *gen.ListReleasesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListBuildsResponse)
+			r0 = ret.Get(0).(*gen.ListReleasesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListBuildsRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListReleasesRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -592,21 +1264,21 @@ func (_m *WaypointServer) ListBuilds(_a0 context.Context, _a1 *gen.ListBuildsReq
 	return r0, r1
 }
 
-// ListDeployments provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListDeployments(_a0 context.Context, _a1 *gen.ListDeploymentsRequest) (*gen.ListDeploymentsResponse, error) {
+// ListRunnerProfiles provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListRunnerProfiles(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListRunnerProfilesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListDeploymentsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListDeploymentsRequest) *gen.ListDeploymentsResponse); ok {
+	var r0 *gen.ListRunnerProfilesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListRunnerProfilesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListDeploymentsResponse)
+			r0 = ret.Get(0).(*gen.ListRunnerProfilesResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListDeploymentsRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -615,21 +1287,21 @@ func (_m *WaypointServer) ListDeployments(_a0 context.Context, _a1 *gen.ListDepl
 	return r0, r1
 }
 
-// ListHostnames provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListHostnames(_a0 context.Context, _a1 *gen.ListHostnamesRequest) (*gen.ListHostnamesResponse, error) {
+// ListRunners provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListRunners(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListRunnersResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListHostnamesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListHostnamesRequest) *gen.ListHostnamesResponse); ok {
+	var r0 *gen.ListRunnersResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListRunnersResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListHostnamesResponse)
+			r0 = ret.Get(0).(*gen.ListRunnersResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListHostnamesRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -638,21 +1310,21 @@ func (_m *WaypointServer) ListHostnames(_a0 context.Context, _a1 *gen.ListHostna
 	return r0, r1
 }
 
-// ListInstances provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListInstances(_a0 context.Context, _a1 *gen.ListInstancesRequest) (*gen.ListInstancesResponse, error) {
+// ListServerConfigHistory provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListServerConfigHistory(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListServerConfigHistoryResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListInstancesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListInstancesRequest) *gen.ListInstancesResponse); ok {
+	var r0 *gen.ListServerConfigHistoryResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListServerConfigHistoryResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListInstancesResponse)
+			r0 = ret.Get(0).(*gen.ListServerConfigHistoryResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListInstancesRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -661,16 +1333,16 @@ func (_m *WaypointServer) ListInstances(_a0 context.Context, _a1 *gen.ListInstan
 	return r0, r1
 }
 
-// ListProjects provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListProjects(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListProjectsResponse, error) {
+// ListWorkspaces provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ListWorkspaces(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListWorkspacesResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListProjectsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListProjectsResponse); ok {
+	var r0 *gen.ListWorkspacesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListWorkspacesResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListProjectsResponse)
+			r0 = ret.Get(0).(*gen.ListWorkspacesResponse)
 		}
 	}
 
@@ -684,21 +1356,21 @@ func (_m *WaypointServer) ListProjects(_a0 context.Context, _a1 *emptypb.Empty)
 	return r0, r1
 }
 
-// ListPushedArtifacts provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListPushedArtifacts(_a0 context.Context, _a1 *gen.ListPushedArtifactsRequest) (*gen.ListPushedArtifactsResponse, error) {
+// QueueJob provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) QueueJob(_a0 context.Context, _a1 *gen.QueueJobRequest) (*gen.QueueJobResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListPushedArtifactsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListPushedArtifactsRequest) *gen.ListPushedArtifactsResponse); ok {
+	var r0 *gen.QueueJobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.QueueJobRequest) *gen.QueueJobResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListPushedArtifactsResponse)
+			r0 = ret.Get(0).(*gen.QueueJobResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListPushedArtifactsRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.QueueJobRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -707,21 +1379,21 @@ func (_m *WaypointServer) ListPushedArtifacts(_a0 context.Context, _a1 *gen.List
 	return r0, r1
 }
 
-// ListReleases provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListReleases(_a0 context.Context, _a1 *gen.ListReleasesRequest) (*gen.ListReleasesResponse, error) {
+// RejectRunner provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) RejectRunner(_a0 context.Context, _a1 *gen.RejectRunnerRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListReleasesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListReleasesRequest) *gen.ListReleasesResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RejectRunnerRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListReleasesResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListReleasesRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RejectRunnerRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -730,21 +1402,35 @@ func (_m *WaypointServer) ListReleases(_a0 context.Context, _a1 *gen.ListRelease
 	return r0, r1
 }
 
-// ListWorkspaces provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ListWorkspaces(_a0 context.Context, _a1 *emptypb.Empty) (*gen.ListWorkspacesResponse, error) {
+// RestoreSnapshot provides a mock function with given fields: _a0
+func (_m *WaypointServer) RestoreSnapshot(_a0 gen.Waypoint_RestoreSnapshotServer) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(gen.Waypoint_RestoreSnapshotServer) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RollbackServerConfig provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) RollbackServerConfig(_a0 context.Context, _a1 *gen.RollbackServerConfigRequest) (*emptypb.Empty, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListWorkspacesResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty) *gen.ListWorkspacesResponse); ok {
+	var r0 *emptypb.Empty
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RollbackServerConfigRequest) *emptypb.Empty); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListWorkspacesResponse)
+			r0 = ret.Get(0).(*emptypb.Empty)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RollbackServerConfigRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -753,21 +1439,21 @@ func (_m *WaypointServer) ListWorkspaces(_a0 context.Context, _a1 *emptypb.Empty
 	return r0, r1
 }
 
-// QueueJob provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) QueueJob(_a0 context.Context, _a1 *gen.QueueJobRequest) (*gen.QueueJobResponse, error) {
+// RotateToken provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) RotateToken(_a0 context.Context, _a1 *gen.RotateTokenRequest) (*gen.NewTokenResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.QueueJobResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.QueueJobRequest) *gen.QueueJobResponse); ok {
+	var r0 *gen.NewTokenResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.RotateTokenRequest) *gen.NewTokenResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.QueueJobResponse)
+			r0 = ret.Get(0).(*gen.NewTokenResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.QueueJobRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.RotateTokenRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -827,6 +1513,52 @@ func (_m *WaypointServer) RunnerJobStream(_a0 gen.Waypoint_RunnerJobStreamServer
 	return r0
 }
 
+// ScheduleOperation provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ScheduleOperation(_a0 context.Context, _a1 *gen.ScheduleOperationRequest) (*gen.ScheduleOperationResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ScheduleOperationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ScheduleOperationRequest) *gen.ScheduleOperationResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ScheduleOperationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ScheduleOperationRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRecurringOperation provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ScheduleRecurringOperation(_a0 context.Context, _a1 *gen.ScheduleRecurringOperationRequest) (*gen.ScheduleOperationResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.ScheduleOperationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ScheduleRecurringOperationRequest) *gen.ScheduleOperationResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.ScheduleOperationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ScheduleRecurringOperationRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetConfig provides a mock function with given fields: _a0, _a1
 func (_m *WaypointServer) SetConfig(_a0 context.Context, _a1 *gen.ConfigSetRequest) (*gen.ConfigSetResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -887,6 +1619,20 @@ func (_m *WaypointServer) StartExecStream(_a0 gen.Waypoint_StartExecStreamServer
 	return r0
 }
 
+// UploadSource provides a mock function with given fields: _a0
+func (_m *WaypointServer) UploadSource(_a0 gen.Waypoint_UploadSourceServer) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(gen.Waypoint_UploadSourceServer) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertApplication provides a mock function with given fields: _a0, _a1
 func (_m *WaypointServer) UpsertApplication(_a0 context.Context, _a1 *gen.UpsertApplicationRequest) (*gen.UpsertApplicationResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -910,6 +1656,29 @@ func (_m *WaypointServer) UpsertApplication(_a0 context.Context, _a1 *gen.Upsert
 	return r0, r1
 }
 
+// UpsertAuthMethod provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) UpsertAuthMethod(_a0 context.Context, _a1 *gen.UpsertAuthMethodRequest) (*gen.UpsertAuthMethodResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.UpsertAuthMethodResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertAuthMethodRequest) *gen.UpsertAuthMethodResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.UpsertAuthMethodResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertAuthMethodRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertBuild provides a mock function with given fields: _a0, _a1
 func (_m *WaypointServer) UpsertBuild(_a0 context.Context, _a1 *gen.UpsertBuildRequest) (*gen.UpsertBuildResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -956,6 +1725,29 @@ func (_m *WaypointServer) UpsertDeployment(_a0 context.Context, _a1 *gen.UpsertD
 	return r0, r1
 }
 
+// UpsertHealthReport provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) UpsertHealthReport(_a0 context.Context, _a1 *gen.UpsertHealthReportRequest) (*gen.UpsertHealthReportResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *gen.UpsertHealthReportResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertHealthReportRequest) *gen.UpsertHealthReportResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gen.UpsertHealthReportResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertHealthReportRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertProject provides a mock function with given fields: _a0, _a1
 func (_m *WaypointServer) UpsertProject(_a0 context.Context, _a1 *gen.UpsertProjectRequest) (*gen.UpsertProjectResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1025,21 +1817,21 @@ func (_m *WaypointServer) UpsertRelease(_a0 context.Context, _a1 *gen.UpsertRele
 	return r0, r1
 }
 
-// ValidateJob provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) ValidateJob(_a0 context.Context, _a1 *gen.ValidateJobRequest) (*gen.ValidateJobResponse, error) {
+// UpsertRunnerProfile provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) UpsertRunnerProfile(_a0 context.Context, _a1 *gen.UpsertRunnerProfileRequest) (*gen.UpsertRunnerProfileResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ValidateJobResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ValidateJobRequest) *gen.ValidateJobResponse); ok {
+	var r0 *gen.UpsertRunnerProfileResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.UpsertRunnerProfileRequest) *gen.UpsertRunnerProfileResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ValidateJobResponse)
+			r0 = ret.Get(0).(*gen.UpsertRunnerProfileResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ValidateJobRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.UpsertRunnerProfileRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -1048,21 +1840,21 @@ func (_m *WaypointServer) ValidateJob(_a0 context.Context, _a1 *gen.ValidateJobR
 	return r0, r1
 }
 
-// XListJobs provides a mock function with given fields: _a0, _a1
-func (_m *WaypointServer) XListJobs(_a0 context.Context, _a1 *gen.ListJobsRequest) (*gen.ListJobsResponse, error) {
+// ValidateJob provides a mock function with given fields: _a0, _a1
+func (_m *WaypointServer) ValidateJob(_a0 context.Context, _a1 *gen.ValidateJobRequest) (*gen.ValidateJobResponse, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *gen.ListJobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *gen.ListJobsRequest) *gen.ListJobsResponse); ok {
+	var r0 *gen.ValidateJobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *gen.ValidateJobRequest) *gen.ValidateJobResponse); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gen.ListJobsResponse)
+			r0 = ret.Get(0).(*gen.ValidateJobResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *gen.ListJobsRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *gen.ValidateJobRequest) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -6,9 +6,11 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/oklog/run"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 // grpcInit initializes the gRPC server and adds it to the run group.
@@ -24,6 +26,9 @@ func grpcInit(group *run.Group, opts *options) error {
 	var so []grpc.ServerOption
 	so = append(so,
 		grpc.ChainUnaryInterceptor(
+			// Continue the trace of the client
+			telemetry.UnaryServerInterceptor(),
+
 			// Insert our logger and also log req/resp
 			logUnaryInterceptor(log, false),
 
@@ -31,6 +36,9 @@ func grpcInit(group *run.Group, opts *options) error {
 			versionUnaryInterceptor(resp.Info),
 		),
 		grpc.ChainStreamInterceptor(
+			// Continue the trace of the client
+			telemetry.StreamServerInterceptor(),
+
 			// Insert our logger and log
 			logStreamInterceptor(log, false),
 
@@ -39,6 +47,10 @@ func grpcInit(group *run.Group, opts *options) error {
 		),
 	)
 
+	if opts.GRPCTLSConfig != nil {
+		so = append(so, grpc.Creds(credentials.NewTLS(opts.GRPCTLSConfig)))
+	}
+
 	if opts.AuthChecker != nil {
 		so = append(so,
 			grpc.ChainUnaryInterceptor(authUnaryInterceptor(opts.AuthChecker)),
@@ -46,6 +58,22 @@ func grpcInit(group *run.Group, opts *options) error {
 		)
 	}
 
+	// Metrics are recorded first so that the duration includes everything.
+	if opts.rpcMetrics != nil {
+		so = append([]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(metricsUnaryInterceptor(opts.rpcMetrics)),
+			grpc.ChainStreamInterceptor(metricsStreamInterceptor(opts.rpcMetrics)),
+		}, so...)
+	}
+
+	// Audit after authentication so only authenticated calls are recorded.
+	if rec, ok := opts.Service.(AuditRecorder); ok {
+		so = append(so,
+			grpc.ChainUnaryInterceptor(auditUnaryInterceptor(rec)),
+			grpc.ChainStreamInterceptor(auditStreamInterceptor(rec)),
+		)
+	}
+
 	s := grpc.NewServer(so...)
 	opts.grpcServer = s
 
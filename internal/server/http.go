@@ -37,10 +37,14 @@ func httpInit(group *run.Group, opts *options) error {
 	})
 
 	// If the path has a grpc prefix we assume it's a GRPC gateway request,
-	// otherwise fall back to serving the UI from the filesystem
+	// webhooks go to the service if it accepts them, otherwise fall back
+	// to serving the UI from the filesystem
+	webhooks, _ := opts.Service.(WebhookHandler)
 	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasPrefix(r.URL.Path, "/grpc") {
 			grpcWrapped.ServeHTTP(w, r)
+		} else if webhooks != nil && strings.HasPrefix(r.URL.Path, WebhookPathPrefix) {
+			webhooks.ServeWebhook(w, r)
 		} else if opts.BrowserUIEnabled {
 			uifs.ServeHTTP(w, r)
 		}
@@ -0,0 +1,112 @@
+package logsink
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// cloudWatchSink writes lines to a CloudWatch Logs group. Each instance
+// gets a log stream named "project/app/instance".
+type cloudWatchSink struct {
+	client *cloudwatchlogs.CloudWatchLogs
+	group  string
+
+	// tokens is the next sequence token of each stream we have written
+	// to. A nil token means the stream is new.
+	tokens map[string]*string
+}
+
+func newCloudWatchSink(cfg *pb.ServerConfig_LogSink_CloudWatch) (*cloudWatchSink, error) {
+	awsConfig := aws.NewConfig()
+	if cfg.Region != "" {
+		awsConfig = awsConfig.WithRegion(cfg.Region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudWatchSink{
+		client: cloudwatchlogs.New(sess),
+		group:  cfg.LogGroup,
+		tokens: map[string]*string{},
+	}, nil
+}
+
+func (s *cloudWatchSink) Send(ctx context.Context, src *Source, entries []*pb.LogBatch_Entry) error {
+	stream := src.Project + "/" + src.App + "/" + src.InstanceId
+	token, ok := s.tokens[stream]
+	if !ok {
+		_, err := s.client.CreateLogStreamWithContext(ctx, &cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(s.group),
+			LogStreamName: aws.String(stream),
+		})
+		if err != nil && !cloudWatchErrCode(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+			return err
+		}
+
+		s.tokens[stream] = nil
+	}
+
+	// Events in a request must be in chronological order.
+	var events []*cloudwatchlogs.InputLogEvent
+	for _, entry := range entries {
+		t, err := ptypes.Timestamp(entry.Timestamp)
+		if err != nil || entry.Line == "" {
+			continue
+		}
+
+		events = append(events, &cloudwatchlogs.InputLogEvent{
+			Timestamp: aws.Int64(t.UnixNano() / 1e6),
+			Message:   aws.String(entry.Line),
+		})
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return *events[i].Timestamp < *events[j].Timestamp
+	})
+
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(s.group),
+		LogStreamName: aws.String(stream),
+		LogEvents:     events,
+		SequenceToken: token,
+	}
+	resp, err := s.client.PutLogEventsWithContext(ctx, input)
+
+	// If the stream already existed or another server wrote to it, the
+	// error tells us the token to use so we retry once with it.
+	if invalid, ok := err.(*cloudwatchlogs.InvalidSequenceTokenException); ok {
+		input.SequenceToken = invalid.ExpectedSequenceToken
+		resp, err = s.client.PutLogEventsWithContext(ctx, input)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.tokens[stream] = resp.NextSequenceToken
+	return nil
+}
+
+func (s *cloudWatchSink) Close() error {
+	return nil
+}
+
+func cloudWatchErrCode(err error, code string) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == code
+}
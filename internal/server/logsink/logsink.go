@@ -0,0 +1,209 @@
+// Package logsink forwards entrypoint log lines to destinations outside of
+// Waypoint, such as syslog, Loki or CloudWatch Logs.
+package logsink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-hclog"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var (
+	// queueSize is the number of batches that are queued for each sink.
+	// Batches are dropped once the queue is full.
+	queueSize = 64
+
+	// sendTimeout is how long a sink has to send one batch.
+	sendTimeout = 10 * time.Second
+)
+
+// Source is the instance that log lines came from.
+type Source struct {
+	Project      string
+	App          string
+	Workspace    string
+	DeploymentId string
+	InstanceId   string
+}
+
+// Sink sends log lines to a destination. Send is never called
+// concurrently for a single sink. Entries must not be modified.
+type Sink interface {
+	Send(ctx context.Context, src *Source, entries []*pb.LogBatch_Entry) error
+	Close() error
+}
+
+// New returns the sink for the config.
+func New(cfg *pb.ServerConfig_LogSink) (Sink, error) {
+	switch s := cfg.GetSink().(type) {
+	case *pb.ServerConfig_LogSink_Syslog_:
+		return newSyslogSink(s.Syslog), nil
+
+	case *pb.ServerConfig_LogSink_Loki_:
+		return newLokiSink(s.Loki), nil
+
+	case *pb.ServerConfig_LogSink_Cloudwatch:
+		return newCloudWatchSink(s.Cloudwatch)
+
+	default:
+		return nil, fmt.Errorf("unknown log sink type %T", s)
+	}
+}
+
+// Fanout sends log lines to every configured sink. Each sink has its own
+// queue so that a slow or unreachable sink doesn't hold up the others or
+// the entrypoints sending logs.
+type Fanout struct {
+	logger hclog.Logger
+
+	mu      sync.Mutex
+	config  []*pb.ServerConfig_LogSink
+	workers []*worker
+}
+
+// NewFanout returns a Fanout with no sinks.
+func NewFanout(log hclog.Logger) *Fanout {
+	return &Fanout{logger: log}
+}
+
+// Configure replaces the sinks with the given config. This does nothing
+// if the config is unchanged so it is safe to call on every config
+// update. Lines queued for the previous sinks are still sent.
+func (f *Fanout) Configure(cfgs []*pb.ServerConfig_LogSink) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if configEqual(f.config, cfgs) {
+		return nil
+	}
+
+	var workers []*worker
+	for i, cfg := range cfgs {
+		sink, err := New(cfg)
+		if err != nil {
+			for _, w := range workers {
+				w.close()
+			}
+
+			return fmt.Errorf("log sink %d: %w", i, err)
+		}
+
+		workers = append(workers, newWorker(f.logger.With("sink", i), sink))
+	}
+
+	for _, w := range f.workers {
+		w.close()
+	}
+	f.config = cfgs
+	f.workers = workers
+
+	return nil
+}
+
+// Send queues the lines for every sink. This never blocks.
+func (f *Fanout) Send(src *Source, entries []*pb.LogBatch_Entry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, w := range f.workers {
+		w.send(&batch{src: src, entries: entries})
+	}
+}
+
+// Close stops all sinks. This waits for queued lines to be sent.
+func (f *Fanout) Close() error {
+	f.mu.Lock()
+	workers := f.workers
+	f.config = nil
+	f.workers = nil
+	f.mu.Unlock()
+
+	for _, w := range workers {
+		w.close()
+		<-w.doneCh
+	}
+
+	return nil
+}
+
+func configEqual(a, b []*pb.ServerConfig_LogSink) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type batch struct {
+	src     *Source
+	entries []*pb.LogBatch_Entry
+}
+
+// worker sends the queued batches of one sink.
+type worker struct {
+	log     hclog.Logger
+	sink    Sink
+	ch      chan *batch
+	doneCh  chan struct{}
+	dropped int
+}
+
+func newWorker(log hclog.Logger, sink Sink) *worker {
+	w := &worker{
+		log:    log,
+		sink:   sink,
+		ch:     make(chan *batch, queueSize),
+		doneCh: make(chan struct{}),
+	}
+
+	go w.run()
+	return w
+}
+
+func (w *worker) run() {
+	defer close(w.doneCh)
+	defer w.sink.Close()
+
+	for b := range w.ch {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err := w.sink.Send(ctx, b.src, b.entries)
+		cancel()
+		if err != nil {
+			w.log.Warn("error sending logs to sink", "err", err)
+		}
+	}
+}
+
+// send queues the batch. This is only called with the Fanout lock held,
+// which also guards dropped and close.
+func (w *worker) send(b *batch) {
+	select {
+	case w.ch <- b:
+		if w.dropped > 0 {
+			w.log.Warn("log sink was full and dropped batches", "dropped", w.dropped)
+			w.dropped = 0
+		}
+
+	default:
+		w.dropped++
+	}
+}
+
+func (w *worker) close() {
+	close(w.ch)
+}
@@ -0,0 +1,96 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// lokiSink pushes lines with the Loki HTTP API. Every instance is one
+// stream.
+type lokiSink struct {
+	url    string
+	labels map[string]string
+	client *http.Client
+}
+
+func newLokiSink(cfg *pb.ServerConfig_LogSink_Loki) *lokiSink {
+	return &lokiSink{
+		url:    strings.TrimSuffix(cfg.Url, "/") + "/loki/api/v1/push",
+		labels: cfg.Labels,
+		client: http.DefaultClient,
+	}
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *lokiSink) Send(ctx context.Context, src *Source, entries []*pb.LogBatch_Entry) error {
+	labels := map[string]string{}
+	for k, v := range s.labels {
+		labels[k] = v
+	}
+	labels["project"] = src.Project
+	labels["app"] = src.App
+	labels["workspace"] = src.Workspace
+
+	stream := lokiStream{Stream: labels}
+	for _, entry := range entries {
+		t, err := ptypes.Timestamp(entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(t.UnixNano(), 10),
+			entry.Line,
+		})
+	}
+	if len(stream.Values) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(&lokiPush{Streams: []lokiStream{stream}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("loki returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
+
+func (s *lokiSink) Close() error {
+	return nil
+}
@@ -0,0 +1,117 @@
+package logsink
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestLokiSink(t *testing.T) {
+	require := require.New(t)
+
+	var mu sync.Mutex
+	var pushes []lokiPush
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/push" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var push lokiPush
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		pushes = append(pushes, push)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	f := NewFanout(hclog.L())
+	defer f.Close()
+	require.NoError(f.Configure([]*pb.ServerConfig_LogSink{
+		{
+			Sink: &pb.ServerConfig_LogSink_Loki_{
+				Loki: &pb.ServerConfig_LogSink_Loki{
+					Url:    srv.URL + "/",
+					Labels: map[string]string{"env": "test"},
+				},
+			},
+		},
+	}))
+
+	now := time.Now()
+	ts, err := ptypes.TimestampProto(now)
+	require.NoError(err)
+	f.Send(&Source{
+		Project:    "p",
+		App:        "a",
+		Workspace:  "default",
+		InstanceId: "i",
+	}, []*pb.LogBatch_Entry{{Timestamp: ts, Line: "hello"}})
+
+	require.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(pushes) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(pushes[0].Streams, 1)
+	stream := pushes[0].Streams[0]
+	require.Equal(map[string]string{
+		"env":       "test",
+		"project":   "p",
+		"app":       "a",
+		"workspace": "default",
+	}, stream.Stream)
+	require.Equal([][2]string{{
+		strconv.FormatInt(now.UnixNano(), 10),
+		"hello",
+	}}, stream.Values)
+}
+
+func TestFanoutConfigure_unchanged(t *testing.T) {
+	require := require.New(t)
+
+	cfg := []*pb.ServerConfig_LogSink{
+		{
+			Sink: &pb.ServerConfig_LogSink_Loki_{
+				Loki: &pb.ServerConfig_LogSink_Loki{Url: "http://127.0.0.1:1"},
+			},
+		},
+	}
+
+	f := NewFanout(hclog.L())
+	defer f.Close()
+	require.NoError(f.Configure(cfg))
+	workers := f.workers
+
+	// The same config keeps the same sinks
+	require.NoError(f.Configure([]*pb.ServerConfig_LogSink{
+		{
+			Sink: &pb.ServerConfig_LogSink_Loki_{
+				Loki: &pb.ServerConfig_LogSink_Loki{Url: "http://127.0.0.1:1"},
+			},
+		},
+	}))
+	require.Equal(workers, f.workers)
+
+	// No config removes them
+	require.NoError(f.Configure(nil))
+	require.Empty(f.workers)
+}
@@ -0,0 +1,98 @@
+package logsink
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// syslogPriority is the priority of every message: the user facility
+// with the informational severity. Lines don't have a severity so this
+// is the best we can do.
+const syslogPriority = 1*8 + 6
+
+// syslogSink sends RFC 5424 messages. The connection is made on the first
+// send and made again after an error.
+type syslogSink struct {
+	network string
+	address string
+	conn    net.Conn
+}
+
+func newSyslogSink(cfg *pb.ServerConfig_LogSink_Syslog) *syslogSink {
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+
+	return &syslogSink{network: network, address: cfg.Address}
+}
+
+func (s *syslogSink) Send(ctx context.Context, src *Source, entries []*pb.LogBatch_Entry) error {
+	if s.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return err
+		}
+
+		s.conn = conn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+	}
+
+	// Each message is written separately so that every UDP datagram has
+	// exactly one message. TCP messages are separated by newlines.
+	for _, entry := range entries {
+		if _, err := s.conn.Write(syslogMessage(src, entry)); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	return s.conn.Close()
+}
+
+// syslogMessage formats the entry as an RFC 5424 message.
+func syslogMessage(src *Source, entry *pb.LogBatch_Entry) []byte {
+	ts := "-"
+	if t, err := ptypes.Timestamp(entry.Timestamp); err == nil {
+		ts = t.UTC().Format(time.RFC3339Nano)
+	}
+
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s - - - %s\n",
+		syslogPriority, ts, syslogHeader(src.InstanceId), syslogHeader(src.App), entry.Line))
+}
+
+// syslogHeader returns v as a header field. Header fields can't be empty
+// or contain spaces.
+func syslogHeader(v string) string {
+	if v == "" {
+		return "-"
+	}
+
+	result := []byte(v)
+	for i, c := range result {
+		if c <= ' ' || c > '~' {
+			result[i] = '_'
+		}
+	}
+
+	return string(result)
+}
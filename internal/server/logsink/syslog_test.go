@@ -0,0 +1,46 @@
+package logsink
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestSyslogSink(t *testing.T) {
+	require := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	defer conn.Close()
+
+	sink := newSyslogSink(&pb.ServerConfig_LogSink_Syslog{
+		Address: conn.LocalAddr().String(),
+	})
+	defer sink.Close()
+
+	ts, err := ptypes.TimestampProto(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(err)
+	require.NoError(sink.Send(context.Background(), &Source{
+		App:        "web app",
+		InstanceId: "01EXAMPLE",
+	}, []*pb.LogBatch_Entry{
+		{Timestamp: ts, Line: "hello"},
+		{Timestamp: ts, Line: "world"},
+	}))
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(err)
+	require.Equal("<14>1 2020-10-01T12:00:00Z 01EXAMPLE web_app - - - hello\n", string(buf[:n]))
+
+	n, _, err = conn.ReadFrom(buf)
+	require.NoError(err)
+	require.Equal("<14>1 2020-10-01T12:00:00Z 01EXAMPLE web_app - - - world\n", string(buf[:n]))
+}
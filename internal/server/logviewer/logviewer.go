@@ -2,6 +2,7 @@ package logviewer
 
 import (
 	"context"
+	"io"
 
 	"github.com/golang/protobuf/ptypes"
 
@@ -24,6 +25,10 @@ func (v *Viewer) NextLogBatch(ctx context.Context) ([]component.LogEvent, error)
 	// we want to provide the proper amount of backpressure and we expect our
 	// downstream caller to be calling these as quickly as possible.
 	batch, err := v.Stream.Recv()
+	if err == io.EOF {
+		// The server ended the stream, such as after sending stored logs.
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
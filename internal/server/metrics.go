@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/oklog/run"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// rpcMetrics are the metrics recorded by the gRPC interceptors.
+type rpcMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+func newRPCMetrics() *rpcMetrics {
+	return &rpcMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "waypoint_rpc_duration_seconds",
+			Help: "Duration of gRPC calls to the server, by method and status code. " +
+				"Streams are measured until they end.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"method", "code"}),
+	}
+}
+
+func (m *rpcMetrics) observe(method string, start time.Time, err error) {
+	m.duration.WithLabelValues(method, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+}
+
+// metricsUnaryInterceptor returns a gRPC unary interceptor that records
+// the duration of every call.
+func metricsUnaryInterceptor(m *rpcMetrics) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// metricsStreamInterceptor returns a gRPC stream interceptor that records
+// the duration of every stream.
+func metricsStreamInterceptor(m *rpcMetrics) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// metricsInit initializes the metrics HTTP server and adds it to the run
+// group. The service metrics are included if it implements
+// prometheus.Collector.
+func metricsInit(group *run.Group, opts *options) error {
+	log := opts.Logger.Named("metrics")
+	if opts.MetricsListener == nil {
+		return nil
+	}
+
+	reg := prometheus.NewRegistry()
+	collectors := []prometheus.Collector{
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		opts.rpcMetrics.duration,
+	}
+	if c, ok := opts.Service.(prometheus.Collector); ok {
+		collectors = append(collectors, c)
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{
+		ErrorLog: log.StandardLogger(nil),
+	}))
+
+	httpSrv := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler:           mux,
+		BaseContext: func(net.Listener) context.Context {
+			return opts.Context
+		},
+	}
+
+	group.Add(func() error {
+		ln := opts.MetricsListener
+		log.Info("starting metrics server", "addr", ln.Addr().String())
+		return httpSrv.Serve(ln)
+	}, func(err error) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancelFunc()
+
+		log.Info("shutting down metrics server")
+		httpSrv.Shutdown(ctx)
+	})
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint/internal/protocolversion"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	pbmocks "github.com/hashicorp/waypoint/internal/server/gen/mocks"
+)
+
+func TestRun_metrics(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &pbmocks.WaypointServer{}
+	m.On("GetVersionInfo", mock.Anything, mock.Anything).Return(testVersionInfoResponse(), nil)
+	m.On("GetWorkspace", mock.Anything, mock.Anything).Return(&pb.GetWorkspaceResponse{}, nil)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(err)
+	defer ln.Close()
+	metricsLn, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(err)
+	defer metricsLn.Close()
+
+	go Run(
+		WithContext(ctx),
+		WithGRPC(ln),
+		WithMetrics(metricsLn),
+		WithImpl(m),
+	)
+
+	vsnInfo := testVersionInfoResponse().Info
+	conn, err := grpc.DialContext(ctx, ln.Addr().String(),
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(protocolversion.UnaryClientInterceptor(vsnInfo)),
+		grpc.WithStreamInterceptor(protocolversion.StreamClientInterceptor(vsnInfo)),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = pb.NewWaypointClient(conn).GetWorkspace(ctx, &pb.GetWorkspaceRequest{
+		Workspace: &pb.Ref_Workspace{Workspace: "test"},
+	})
+	require.NoError(err)
+
+	resp, err := http.Get("http://" + metricsLn.Addr().String() + "/metrics")
+	require.NoError(err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+
+	require.Contains(string(body),
+		`waypoint_rpc_duration_seconds_count{code="OK",method="/hashicorp.waypoint.Waypoint/GetWorkspace"} 1`)
+	require.Contains(string(body), "go_goroutines")
+}
@@ -3,6 +3,8 @@ package ptypes
 import (
 	"errors"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/imdario/mergo"
@@ -53,9 +55,37 @@ func ValidateJob(job *pb.Job) error {
 		validation.Field(&job.Workspace, validation.Required),
 		validation.Field(&job.TargetRunner, validation.Required),
 		validation.Field(&job.Operation, validation.Required),
+		validation.Field(&job.Timeout, validation.By(isDuration)),
 	)
 }
 
+// JobOperationName returns the name of the job operation, such as "build"
+// or "healthcheck". This is empty if the job has no operation.
+func JobOperationName(job *pb.Job) string {
+	if job.Operation == nil {
+		return ""
+	}
+
+	name := reflect.TypeOf(job.Operation).Elem().Name()
+	return strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(name, "Job_"), "_"))
+}
+
+// JobOperationNames are the names returned by JobOperationName.
+var JobOperationNames = []string{
+	"noop", "build", "push", "deploy", "destroy", "release", "validate",
+	"auth", "docs", "healthcheck", "rollback", "purgeartifacts", "up",
+}
+
+func isDuration(v interface{}) error {
+	if s := v.(string); s != "" {
+		if _, err := time.ParseDuration(s); err != nil {
+			return errors.New("must be a duration such as \"30m\"")
+		}
+	}
+
+	return nil
+}
+
 func isEmpty(v interface{}) error {
 	if reflect.ValueOf(v).IsZero() {
 		return nil
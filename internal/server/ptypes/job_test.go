@@ -25,6 +25,18 @@ func TestValidateJob(t *testing.T) {
 			func(j *pb.Job) { j.Id = "nope" },
 			"id: must be empty",
 		},
+
+		{
+			"timeout",
+			func(j *pb.Job) { j.Timeout = "30m" },
+			"",
+		},
+
+		{
+			"invalid timeout",
+			func(j *pb.Job) { j.Timeout = "soon" },
+			"timeout: must be a duration",
+		},
 	}
 
 	for _, tt := range cases {
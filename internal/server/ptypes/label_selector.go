@@ -0,0 +1,113 @@
+package ptypes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelSelector is a parsed label selector expression used to filter
+// operations by their labels. All the requirements must match.
+type LabelSelector []*LabelRequirement
+
+// LabelRequirement is a single requirement of a LabelSelector.
+type LabelRequirement struct {
+	Key   string
+	Value string
+	Op    LabelOp
+}
+
+// LabelOp is the comparison of a LabelRequirement.
+type LabelOp uint8
+
+const (
+	// LabelEqual requires the label to be set to the value.
+	LabelEqual LabelOp = iota
+
+	// LabelNotEqual requires the label to not be set to the value. This
+	// matches if the label isn't set.
+	LabelNotEqual
+
+	// LabelExists and LabelNotExists require the label to be set or
+	// not set, respectively, to any value.
+	LabelExists
+	LabelNotExists
+)
+
+// ParseLabelSelector parses a label selector expression. The expression is
+// a comma-separated list of requirements that must all match:
+//
+//   - "key=value" or "key==value": the label is set to value
+//   - "key!=value": the label isn't set to value
+//   - "key": the label is set
+//   - "!key": the label isn't set
+//
+// Whitespace around keys and values is ignored. An empty expression
+// matches every set of labels.
+func ParseLabelSelector(v string) (LabelSelector, error) {
+	var result LabelSelector
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var req LabelRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			idx := strings.Index(part, "!=")
+			req = LabelRequirement{Key: part[:idx], Value: part[idx+2:], Op: LabelNotEqual}
+
+		case strings.Contains(part, "=="):
+			idx := strings.Index(part, "==")
+			req = LabelRequirement{Key: part[:idx], Value: part[idx+2:], Op: LabelEqual}
+
+		case strings.Contains(part, "="):
+			idx := strings.Index(part, "=")
+			req = LabelRequirement{Key: part[:idx], Value: part[idx+1:], Op: LabelEqual}
+
+		case strings.HasPrefix(part, "!"):
+			req = LabelRequirement{Key: part[1:], Op: LabelNotExists}
+
+		default:
+			req = LabelRequirement{Key: part, Op: LabelExists}
+		}
+
+		req.Key = strings.TrimSpace(req.Key)
+		req.Value = strings.TrimSpace(req.Value)
+		if req.Key == "" {
+			return nil, fmt.Errorf("label selector requirement %q has no key", part)
+		}
+		if strings.ContainsAny(req.Key, "!=") || strings.ContainsAny(req.Value, "!=") {
+			return nil, fmt.Errorf("label selector requirement %q is invalid", part)
+		}
+
+		result = append(result, &req)
+	}
+
+	return result, nil
+}
+
+// Match returns true if the labels match every requirement of the selector.
+func (s LabelSelector) Match(labels map[string]string) bool {
+	for _, req := range s {
+		v, ok := labels[req.Key]
+
+		var match bool
+		switch req.Op {
+		case LabelEqual:
+			match = ok && v == req.Value
+		case LabelNotEqual:
+			match = !ok || v != req.Value
+		case LabelExists:
+			match = ok
+		case LabelNotExists:
+			match = !ok
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,52 @@
+package ptypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{
+		"env":  "prod",
+		"team": "web",
+	}
+
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected bool
+		Error    string
+	}{
+		{"empty", "", true, ""},
+		{"equal", "env=prod", true, ""},
+		{"double equal", "env==prod", true, ""},
+		{"equal mismatch", "env=dev", false, ""},
+		{"not equal", "team!=infra", true, ""},
+		{"not equal mismatch", "team!=web", false, ""},
+		{"not equal unset", "region!=us", true, ""},
+		{"exists", "env", true, ""},
+		{"exists unset", "region", false, ""},
+		{"not exists", "!region", true, ""},
+		{"not exists set", "!env", false, ""},
+		{"multiple", "env=prod, team!=infra", true, ""},
+		{"multiple mismatch", "env=prod,team=infra", false, ""},
+		{"no key", "=prod", false, "no key"},
+		{"invalid value", "env=prod=dev", false, "is invalid"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			sel, err := ParseLabelSelector(tt.Input)
+			if tt.Error != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Error)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.Expected, sel.Match(labels))
+		})
+	}
+}
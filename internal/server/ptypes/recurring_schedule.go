@@ -0,0 +1,42 @@
+package ptypes
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hashicorp/cronexpr"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// ValidateRecurringSchedule validates the recurring schedule structure.
+// The job is validated with ValidateJob.
+func ValidateRecurringSchedule(s *pb.RecurringSchedule) error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Job, validation.Required, validation.By(func(v interface{}) error {
+			return ValidateJob(v.(*pb.Job))
+		})),
+		validation.Field(&s.Cron, validation.Required, validation.By(func(v interface{}) error {
+			_, err := cronexpr.Parse(v.(string))
+			return err
+		})),
+	)
+}
+
+// NextCronTime returns the first time after the given time that matches
+// the cron expression. This returns an error if the expression is invalid
+// or never matches again.
+func NextCronTime(expr string, after time.Time) (time.Time, error) {
+	e, err := cronexpr.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := e.Next(after)
+	if next.IsZero() {
+		return time.Time{}, errors.New("cron expression has no future times")
+	}
+
+	return next, nil
+}
@@ -0,0 +1,85 @@
+package ptypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestValidateRecurringSchedule(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Cron  string
+		Error string
+	}{
+		{
+			"valid",
+			"0 2 * * *",
+			"",
+		},
+
+		{
+			"macro",
+			"@daily",
+			"",
+		},
+
+		{
+			"missing",
+			"",
+			"cron: cannot be blank",
+		},
+
+		{
+			"invalid",
+			"every night",
+			"cron:",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := ValidateRecurringSchedule(&pb.RecurringSchedule{
+				Job:  TestJobNew(t, nil),
+				Cron: tt.Cron,
+			})
+			if tt.Error == "" {
+				require.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.Error)
+		})
+	}
+}
+
+func TestNextCronTime(t *testing.T) {
+	require := require.New(t)
+
+	from := time.Date(2020, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	// Nightly at 2am moves to the next day
+	next, err := NextCronTime("0 2 * * *", from)
+	require.NoError(err)
+	require.Equal(time.Date(2020, 10, 15, 2, 0, 0, 0, time.UTC), next)
+
+	// Every 15 minutes
+	next, err = NextCronTime("*/15 * * * *", from)
+	require.NoError(err)
+	require.Equal(time.Date(2020, 10, 14, 15, 45, 0, 0, time.UTC), next)
+
+	// Exactly on a match returns the following match
+	next, err = NextCronTime("30 15 * * *", from)
+	require.NoError(err)
+	require.Equal(time.Date(2020, 10, 15, 15, 30, 0, 0, time.UTC), next)
+
+	// Invalid
+	_, err = NextCronTime("not a cron", from)
+	require.Error(err)
+}
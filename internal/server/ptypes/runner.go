@@ -9,6 +9,8 @@ import (
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
+// TestRunner returns a runner for tests. The runner is adopted unless
+// src sets another adoption state.
 func TestRunner(t testing.T, src *pb.Runner) *pb.Runner {
 	t.Helper()
 
@@ -20,7 +22,8 @@ func TestRunner(t testing.T, src *pb.Runner) *pb.Runner {
 	require.NoError(t, err)
 
 	require.NoError(t, mergo.Merge(src, &pb.Runner{
-		Id: id,
+		Id:            id,
+		AdoptionState: pb.Runner_ADOPTED,
 	}))
 
 	return src
@@ -1,6 +1,10 @@
 package ptypes
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/imdario/mergo"
 	"github.com/mitchellh/go-testing-interface"
@@ -27,9 +31,99 @@ func TestServerConfig(t testing.T, src *pb.ServerConfig) *pb.ServerConfig {
 	return src
 }
 
+// The job scheduling policies of the server config. An empty policy is
+// the same as JobSchedulingFIFO.
+const (
+	JobSchedulingFIFO = "fifo"
+	JobSchedulingFair = "fair"
+)
+
 // ValidateServerConfig validates the server config structure.
 func ValidateServerConfig(c *pb.ServerConfig) error {
 	return validation.ValidateStruct(c,
-		validation.Field(&c.AdvertiseAddrs, validation.Required, validation.Length(1, 1)),
+		validation.Field(&c.AdvertiseAddrs, validation.Required, validation.By(validateAdvertiseAddrs)),
+		validation.Field(&c.LogSinks, validation.By(validateLogSinks)),
+		validation.Field(&c.JobTimeouts, validation.By(validateJobTimeouts)),
+		validation.Field(&c.JobScheduling, validation.In(JobSchedulingFIFO, JobSchedulingFair)),
 	)
 }
+
+// validateJobTimeouts validates that every job timeout is for a known
+// operation and is a positive duration.
+func validateJobTimeouts(v interface{}) error {
+	for op, timeout := range v.(map[string]string) {
+		known := false
+		for _, name := range JobOperationNames {
+			known = known || name == op
+		}
+		if !known {
+			return fmt.Errorf("unknown operation %q, must be one of: %s",
+				op, strings.Join(JobOperationNames, ", "))
+		}
+
+		dur, err := time.ParseDuration(timeout)
+		if err != nil || dur <= 0 {
+			return fmt.Errorf("timeout for %q must be a positive duration such as \"30m\"", op)
+		}
+	}
+
+	return nil
+}
+
+// validateAdvertiseAddrs validates that no address is listed twice. A
+// single blank address is allowed to disable entrypoint communication but
+// blank addresses can't be mixed with others.
+func validateAdvertiseAddrs(v interface{}) error {
+	addrs := v.([]*pb.ServerConfig_AdvertiseAddr)
+
+	seen := map[string]struct{}{}
+	for i, addr := range addrs {
+		if addr == nil || addr.Addr == "" {
+			if len(addrs) > 1 {
+				return fmt.Errorf("address %d must not be blank when multiple addresses are set", i)
+			}
+
+			continue
+		}
+
+		if _, ok := seen[addr.Addr]; ok {
+			return fmt.Errorf("address %q is listed more than once", addr.Addr)
+		}
+		seen[addr.Addr] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateLogSinks validates that every log sink has a type and the
+// settings that type requires.
+func validateLogSinks(v interface{}) error {
+	for i, sink := range v.([]*pb.ServerConfig_LogSink) {
+		switch s := sink.GetSink().(type) {
+		case *pb.ServerConfig_LogSink_Syslog_:
+			switch s.Syslog.Network {
+			case "", "udp", "tcp":
+			default:
+				return fmt.Errorf("log sink %d: syslog network must be \"udp\" or \"tcp\"", i)
+			}
+			if s.Syslog.Address == "" {
+				return fmt.Errorf("log sink %d: syslog address must be set", i)
+			}
+
+		case *pb.ServerConfig_LogSink_Loki_:
+			if s.Loki.Url == "" {
+				return fmt.Errorf("log sink %d: loki url must be set", i)
+			}
+
+		case *pb.ServerConfig_LogSink_Cloudwatch:
+			if s.Cloudwatch.LogGroup == "" {
+				return fmt.Errorf("log sink %d: cloudwatch log group must be set", i)
+			}
+
+		default:
+			return fmt.Errorf("log sink %d must have a type", i)
+		}
+	}
+
+	return nil
+}
@@ -28,10 +28,107 @@ func TestValidateServerConfig(t *testing.T) {
 
 		{
 			"two advertise addrs",
+			func(c *pb.ServerConfig) {
+				c.AdvertiseAddrs = append(c.AdvertiseAddrs, &pb.ServerConfig_AdvertiseAddr{
+					Addr: "10.0.0.2",
+				})
+			},
+			"",
+		},
+
+		{
+			"blank advertise addr",
 			func(c *pb.ServerConfig) {
 				c.AdvertiseAddrs = append(c.AdvertiseAddrs, nil)
 			},
-			"advertise_addrs: the length must be exactly 1",
+			"advertise_addrs: address 1 must not be blank when multiple addresses are set",
+		},
+
+		{
+			"duplicate advertise addrs",
+			func(c *pb.ServerConfig) {
+				c.AdvertiseAddrs = append(c.AdvertiseAddrs, &pb.ServerConfig_AdvertiseAddr{
+					Addr: c.AdvertiseAddrs[0].Addr,
+				})
+			},
+			"listed more than once",
+		},
+
+		{
+			"job timeouts",
+			func(c *pb.ServerConfig) {
+				c.JobTimeouts = map[string]string{"build": "30m", "healthcheck": "1m"}
+			},
+			"",
+		},
+
+		{
+			"job timeout unknown operation",
+			func(c *pb.ServerConfig) {
+				c.JobTimeouts = map[string]string{"compile": "30m"}
+			},
+			"unknown operation \"compile\"",
+		},
+
+		{
+			"job timeout not a duration",
+			func(c *pb.ServerConfig) {
+				c.JobTimeouts = map[string]string{"build": "0s"}
+			},
+			"must be a positive duration",
+		},
+
+		{
+			"fair job scheduling",
+			func(c *pb.ServerConfig) { c.JobScheduling = JobSchedulingFair },
+			"",
+		},
+
+		{
+			"unknown job scheduling",
+			func(c *pb.ServerConfig) { c.JobScheduling = "weighted" },
+			"job_scheduling: must be a valid value",
+		},
+
+		{
+			"valid log sinks",
+			func(c *pb.ServerConfig) {
+				c.LogSinks = []*pb.ServerConfig_LogSink{
+					{
+						Sink: &pb.ServerConfig_LogSink_Syslog_{
+							Syslog: &pb.ServerConfig_LogSink_Syslog{Address: "127.0.0.1:514"},
+						},
+					},
+					{
+						Sink: &pb.ServerConfig_LogSink_Loki_{
+							Loki: &pb.ServerConfig_LogSink_Loki{Url: "http://127.0.0.1:3100"},
+						},
+					},
+				}
+			},
+			"",
+		},
+
+		{
+			"log sink without type",
+			func(c *pb.ServerConfig) {
+				c.LogSinks = []*pb.ServerConfig_LogSink{{}}
+			},
+			"log sink 0 must have a type",
+		},
+
+		{
+			"syslog sink without address",
+			func(c *pb.ServerConfig) {
+				c.LogSinks = []*pb.ServerConfig_LogSink{
+					{
+						Sink: &pb.ServerConfig_LogSink_Syslog_{
+							Syslog: &pb.ServerConfig_LogSink_Syslog{Network: "tcp"},
+						},
+					},
+				}
+			},
+			"syslog address must be set",
 		},
 	}
 
@@ -0,0 +1,85 @@
+package runnerlaunch
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+
+	wpdockerclient "github.com/hashicorp/waypoint/builtin/docker/client"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// dockerLauncher runs runners as containers. The handle is the
+// container ID.
+type dockerLauncher struct {
+	client *client.Client
+	image  string
+	config *pb.RunnerProfile_Docker
+}
+
+func newDockerLauncher(image string, cfg *pb.RunnerProfile_Docker) (*dockerLauncher, error) {
+	cli, err := wpdockerclient.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return nil, err
+	}
+	cli.NegotiateAPIVersion(context.Background())
+
+	return &dockerLauncher{
+		client: cli,
+		image:  image,
+		config: cfg,
+	}, nil
+}
+
+func (l *dockerLauncher) Launch(ctx context.Context, r *Runner) (string, error) {
+	// Always pull so that mutable tags such as "latest" are current.
+	out, err := l.client.ImagePull(ctx, l.image, types.ImagePullOptions{})
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(ioutil.Discard, out)
+	out.Close()
+	if err != nil {
+		return "", err
+	}
+
+	cfg := container.Config{
+		Image: l.image,
+		Cmd:   r.Args(),
+		Env:   r.env(),
+		Labels: map[string]string{
+			"waypoint-runner-id": r.Id,
+		},
+	}
+
+	hostconfig := container.HostConfig{
+		NetworkMode: container.NetworkMode(l.config.Network),
+	}
+
+	cr, err := l.client.ContainerCreate(ctx, &cfg, &hostconfig, nil, "waypoint-runner-"+r.Id)
+	if err != nil {
+		return "", err
+	}
+
+	if err := l.client.ContainerStart(ctx, cr.ID, types.ContainerStartOptions{}); err != nil {
+		l.Stop(ctx, cr.ID)
+		return "", err
+	}
+
+	return cr.ID, nil
+}
+
+func (l *dockerLauncher) Stop(ctx context.Context, handle string) error {
+	err := l.client.ContainerRemove(ctx, handle, types.ContainerRemoveOptions{
+		Force: true,
+	})
+	if client.IsErrNotFound(err) {
+		err = nil
+	}
+
+	return err
+}
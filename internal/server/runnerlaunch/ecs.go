@@ -0,0 +1,147 @@
+package runnerlaunch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecs"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// ecsLauncher runs runners as Fargate tasks. The handle is the task ARN.
+type ecsLauncher struct {
+	client *ecs.ECS
+	image  string
+	config *pb.RunnerProfile_ECS
+}
+
+func newECSLauncher(image string, cfg *pb.RunnerProfile_ECS) (*ecsLauncher, error) {
+	awsConfig := aws.NewConfig()
+	if cfg.Region != "" {
+		awsConfig = awsConfig.WithRegion(cfg.Region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecsLauncher{
+		client: ecs.New(sess),
+		image:  image,
+		config: cfg,
+	}, nil
+}
+
+func (l *ecsLauncher) Launch(ctx context.Context, r *Runner) (string, error) {
+	var env []*ecs.KeyValuePair
+	for k, v := range r.Env {
+		env = append(env, &ecs.KeyValuePair{
+			Name:  aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return *env[i].Name < *env[j].Name
+	})
+
+	cpu, memory := l.config.Cpu, l.config.Memory
+	if cpu == "" {
+		cpu = "512"
+	}
+	if memory == "" {
+		memory = "1024"
+	}
+
+	def := &ecs.RegisterTaskDefinitionInput{
+		Family:                  aws.String("waypoint-runner"),
+		Cpu:                     aws.String(cpu),
+		Memory:                  aws.String(memory),
+		NetworkMode:             aws.String("awsvpc"),
+		RequiresCompatibilities: []*string{aws.String("FARGATE")},
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name:        aws.String("runner"),
+				Image:       aws.String(l.image),
+				Essential:   aws.Bool(true),
+				Command:     aws.StringSlice(r.Args()),
+				Environment: env,
+			},
+		},
+	}
+	if l.config.ExecutionRoleArn != "" {
+		def.ExecutionRoleArn = aws.String(l.config.ExecutionRoleArn)
+	}
+	if l.config.TaskRoleArn != "" {
+		def.TaskRoleArn = aws.String(l.config.TaskRoleArn)
+	}
+
+	defOut, err := l.client.RegisterTaskDefinitionWithContext(ctx, def)
+	if err != nil {
+		return "", err
+	}
+	defArn := defOut.TaskDefinition.TaskDefinitionArn
+
+	// Running tasks are unaffected by deregistering their definition, so
+	// we deregister right away to not leave a revision behind per runner.
+	defer l.client.DeregisterTaskDefinitionWithContext(ctx, &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: defArn,
+	})
+
+	assignPublicIp := "DISABLED"
+	if l.config.AssignPublicIp {
+		assignPublicIp = "ENABLED"
+	}
+
+	input := &ecs.RunTaskInput{
+		TaskDefinition: defArn,
+		LaunchType:     aws.String("FARGATE"),
+		Count:          aws.Int64(1),
+		StartedBy:      aws.String("waypoint"),
+		NetworkConfiguration: &ecs.NetworkConfiguration{
+			AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+				Subnets:        aws.StringSlice(l.config.Subnets),
+				SecurityGroups: aws.StringSlice(l.config.SecurityGroups),
+				AssignPublicIp: aws.String(assignPublicIp),
+			},
+		},
+	}
+	if l.config.Cluster != "" {
+		input.Cluster = aws.String(l.config.Cluster)
+	}
+
+	out, err := l.client.RunTaskWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	if len(out.Tasks) == 0 {
+		reason := "unknown reason"
+		if len(out.Failures) > 0 {
+			reason = aws.StringValue(out.Failures[0].Reason)
+		}
+
+		return "", fmt.Errorf("failed to start ECS task: %s", reason)
+	}
+
+	return aws.StringValue(out.Tasks[0].TaskArn), nil
+}
+
+func (l *ecsLauncher) Stop(ctx context.Context, handle string) error {
+	input := &ecs.StopTaskInput{
+		Task:   aws.String(handle),
+		Reason: aws.String("on-demand runner job completed"),
+	}
+	if l.config.Cluster != "" {
+		input.Cluster = aws.String(l.config.Cluster)
+	}
+
+	_, err := l.client.StopTaskWithContext(ctx, input)
+	return err
+}
@@ -0,0 +1,113 @@
+package runnerlaunch
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// kubernetesLauncher runs runners as pods. The handle is
+// "namespace/name" of the pod.
+type kubernetesLauncher struct {
+	clientset *kubernetes.Clientset
+	namespace string
+	image     string
+	config    *pb.RunnerProfile_Kubernetes
+}
+
+func newKubernetesLauncher(image string, cfg *pb.RunnerProfile_Kubernetes) (*kubernetesLauncher, error) {
+	// This uses the kubeconfig of the server's environment and falls back
+	// to the in-cluster config if there is none.
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	)
+
+	ns := cfg.Namespace
+	if ns == "" {
+		var err error
+		ns, _, err = config.Namespace()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	clientconfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(clientconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesLauncher{
+		clientset: clientset,
+		namespace: ns,
+		image:     image,
+		config:    cfg,
+	}, nil
+}
+
+func (l *kubernetesLauncher) Launch(ctx context.Context, r *Runner) (string, error) {
+	var env []corev1.EnvVar
+	for k, v := range r.Env {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "waypoint-runner-" + strings.ToLower(r.Id),
+			Labels: map[string]string{
+				"app":                "waypoint-runner",
+				"waypoint-runner-id": r.Id,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:      corev1.RestartPolicyNever,
+			ServiceAccountName: l.config.ServiceAccount,
+			Containers: []corev1.Container{
+				{
+					Name:            "runner",
+					Image:           l.image,
+					ImagePullPolicy: corev1.PullAlways,
+					Args:            r.Args(),
+					Env:             env,
+				},
+			},
+		},
+	}
+
+	pod, err := l.clientset.CoreV1().Pods(l.namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return pod.Namespace + "/" + pod.Name, nil
+}
+
+func (l *kubernetesLauncher) Stop(ctx context.Context, handle string) error {
+	ns, name := l.namespace, handle
+	if idx := strings.Index(handle, "/"); idx >= 0 {
+		ns, name = handle[:idx], handle[idx+1:]
+	}
+
+	err := l.clientset.CoreV1().Pods(ns).Delete(ctx, name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		err = nil
+	}
+
+	return err
+}
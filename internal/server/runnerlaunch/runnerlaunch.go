@@ -0,0 +1,70 @@
+// Package runnerlaunch launches and stops on-demand runners on the
+// platforms described by runner profiles, such as Docker, Kubernetes
+// or ECS.
+package runnerlaunch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// DefaultImage is the runner image used if a profile doesn't set one.
+const DefaultImage = "hashicorp/waypoint:latest"
+
+// Runner is a runner to launch.
+type Runner struct {
+	// Id is the ID the runner registers with. The runner only runs jobs
+	// that target this ID.
+	Id string
+
+	// Env is the environment of the runner. This includes the variables
+	// that connect the runner to the server.
+	Env map[string]string
+}
+
+// Args returns the arguments of the waypoint binary that start the runner.
+func (r *Runner) Args() []string {
+	return []string{"runner", "agent", "-id", r.Id, "-by-id-only"}
+}
+
+// env returns the environment as sorted "KEY=value" pairs.
+func (r *Runner) env() []string {
+	result := make([]string, 0, len(r.Env))
+	for k, v := range r.Env {
+		result = append(result, k+"="+v)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Launcher starts and stops runners. Launch returns a handle that
+// identifies the runner on the platform and is passed to Stop.
+type Launcher interface {
+	Launch(ctx context.Context, r *Runner) (string, error)
+	Stop(ctx context.Context, handle string) error
+}
+
+// New returns the launcher for the profile.
+func New(p *pb.RunnerProfile) (Launcher, error) {
+	image := p.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	switch v := p.GetPlatform().(type) {
+	case *pb.RunnerProfile_Docker_:
+		return newDockerLauncher(image, v.Docker)
+
+	case *pb.RunnerProfile_Kubernetes_:
+		return newKubernetesLauncher(image, v.Kubernetes)
+
+	case *pb.RunnerProfile_Ecs:
+		return newECSLauncher(image, v.Ecs)
+
+	default:
+		return nil, fmt.Errorf("runner profile %q has no platform", p.Name)
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 
 	"github.com/hashicorp/go-hclog"
@@ -45,6 +46,11 @@ func Run(opts ...Option) error {
 		return ctx.Err()
 	}, func(error) { cancelCtx() })
 
+	// RPC metrics are only recorded if they are served.
+	if cfg.MetricsListener != nil {
+		cfg.rpcMetrics = newRPCMetrics()
+	}
+
 	// Setup our gRPC server.
 	if err := grpcInit(&group, &cfg); err != nil {
 		return err
@@ -55,6 +61,11 @@ func Run(opts ...Option) error {
 		return err
 	}
 
+	// Setup our metrics server.
+	if err := metricsInit(&group, &cfg); err != nil {
+		return err
+	}
+
 	// Run!
 	return group.Run()
 }
@@ -80,6 +91,10 @@ type options struct {
 	// serves the HTTP endpoints as well.
 	GRPCListener net.Listener
 
+	// GRPCTLSConfig, if set, serves gRPC with TLS. The service can then
+	// read the verified client certificates of a call from its peer.
+	GRPCTLSConfig *tls.Config
+
 	// HTTPListener will setup the HTTP server. If this is nil, then
 	// the HTTP-based API will be disabled.
 	HTTPListener net.Listener
@@ -90,7 +105,12 @@ type options struct {
 	// BrowserUIEnabled determines if the browser UI should be mounted
 	BrowserUIEnabled bool
 
+	// MetricsListener will setup the HTTP server for Prometheus metrics
+	// on /metrics. If this is nil, then metrics are disabled.
+	MetricsListener net.Listener
+
 	grpcServer *grpc.Server
+	rpcMetrics *rpcMetrics
 }
 
 // WithContext sets the context for the server. When this context is cancelled,
@@ -111,6 +131,12 @@ func WithGRPC(ln net.Listener) Option {
 	return func(opts *options) { opts.GRPCListener = ln }
 }
 
+// WithGRPCTLS serves gRPC with TLS using the given config. If this is nil
+// then gRPC is served as is, such as when the listener handles TLS.
+func WithGRPCTLS(cfg *tls.Config) Option {
+	return func(opts *options) { opts.GRPCTLSConfig = cfg }
+}
+
 // WithHTTP sets the HTTP listener. This listener must be closed manually
 // by the caller. Prior to closing the listener, it is recommended that you
 // cancel the context set with WithContext and wait for Run to return.
@@ -118,6 +144,12 @@ func WithHTTP(ln net.Listener) Option {
 	return func(opts *options) { opts.HTTPListener = ln }
 }
 
+// WithMetrics sets the listener for the metrics endpoint. This listener
+// must be closed manually by the caller like the listener of WithHTTP.
+func WithMetrics(ln net.Listener) Option {
+	return func(opts *options) { opts.MetricsListener = ln }
+}
+
 // WithImpl sets the service implementation to serve.
 func WithImpl(impl pb.WaypointServer) Option {
 	return func(opts *options) { opts.Service = impl }
@@ -5,6 +5,8 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
@@ -98,6 +101,14 @@ func (s *service) DecodeToken(token string) (*pb.TokenTransport, *pb.Token, erro
 		}
 	}
 
+	revoked, err := s.state.TokenRevoked(body.TokenId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, errors.Wrapf(ErrInvalidToken, "token has been revoked")
+	}
+
 	return &tt, &body, nil
 }
 
@@ -111,6 +122,12 @@ func (s *service) Authenticate(ctx context.Context, token, endpoint string, effe
 		return nil
 	}
 
+	// The OIDC login flow is how users without a token get one.
+	switch endpoint {
+	case "ListOIDCAuthMethods", "GetOIDCAuthURL", "CompleteOIDCAuth":
+		return nil
+	}
+
 	if token == "" {
 		return status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
 	}
@@ -129,6 +146,26 @@ func (s *service) Authenticate(ctx context.Context, token, endpoint string, effe
 		return status.Errorf(codes.Unauthenticated, "Unauthorized endpoint")
 	}
 
+	// Over TLS, entrypoints must also present the client certificate of
+	// their deployment. They use the token alone to get it.
+	if body.Entrypoint != nil && endpoint != "EntrypointIssueCert" {
+		if err := entrypointPeerCheck(ctx, body.Entrypoint.DeploymentId); err != nil {
+			return err
+		}
+	}
+
+	// Likewise runner tokens can only access runner APIs. Runners run
+	// jobs with the token they were started with.
+	if body.Runner != nil && !strings.HasPrefix(endpoint, "Runner") {
+		return status.Errorf(codes.Unauthenticated, "Unauthorized endpoint")
+	}
+
+	// Readers can only call endpoints that don't mutate state.
+	if body.Role == pb.Token_READER && effectMutable(effects) && endpoint != "RotateToken" {
+		return status.Errorf(codes.PermissionDenied,
+			"token has the reader role and can't call %s", endpoint)
+	}
+
 	// TODO When we have a user model, this is where you'll check for the user.
 	if body.User != DefaultUser {
 		return ErrInvalidToken
@@ -137,6 +174,23 @@ func (s *service) Authenticate(ctx context.Context, token, endpoint string, effe
 	return nil
 }
 
+// tokenIdentity returns a description of the token used for the request in
+// ctx, made up of the token user and its ID. The token itself is never
+// returned. This returns an empty string if the request has no valid token.
+func (s *service) tokenIdentity(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return ""
+	}
+
+	_, body, err := s.DecodeToken(md["authorization"][0])
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s (token %s)", body.User, hex.EncodeToString(body.TokenId))
+}
+
 // Generate a new token by signing the data in body.
 // keyId controls which key is used to sign the key (key values are generated lazily).
 // metadata is attached to the token transport as configuration style information
@@ -183,12 +237,36 @@ func (s *service) NewLoginToken(
 	keyId string,
 	metadata map[string]string,
 	entrypoint *pb.Token_Entrypoint,
+) (string, error) {
+	return s.newLoginToken(0, keyId, metadata, &pb.Token{Entrypoint: entrypoint})
+}
+
+// newLoginToken creates a new login token that is valid for the given
+// duration. If duration is zero the token never expires. The entrypoint,
+// role, and scope of the new token are copied from access.
+func (s *service) newLoginToken(
+	duration time.Duration,
+	keyId string,
+	metadata map[string]string,
+	access *pb.Token,
 ) (string, error) {
 	var body pb.Token
 	body.Login = true
 	body.User = DefaultUser
 	body.TokenId = make([]byte, 16)
-	body.Entrypoint = entrypoint
+	body.Entrypoint = access.Entrypoint
+	body.Role = access.Role
+	body.Projects = access.Projects
+	body.Applications = access.Applications
+	body.Runner = access.Runner
+
+	if duration > 0 {
+		now := time.Now().UTC().Add(duration)
+		body.ValidUntil = &timestamp.Timestamp{
+			Seconds: now.Unix(),
+			Nanos:   int32(now.Nanosecond()),
+		}
+	}
 
 	_, err := io.ReadFull(rand.Reader, body.TokenId)
 	if err != nil {
@@ -199,8 +277,17 @@ func (s *service) NewLoginToken(
 }
 
 // Create a new login token. This is just a gRPC wrapper around NewLoginToken.
-func (s *service) GenerateLoginToken(ctx context.Context, _ *empty.Empty) (*pb.NewTokenResponse, error) {
-	token, err := s.NewLoginToken(DefaultKeyId, nil, nil)
+func (s *service) GenerateLoginToken(ctx context.Context, req *pb.LoginTokenRequest) (*pb.NewTokenResponse, error) {
+	dur, err := parseTokenDuration(req.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.newLoginToken(dur, DefaultKeyId, nil, &pb.Token{
+		Role:         req.Role,
+		Projects:     req.Projects,
+		Applications: req.Applications,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +295,59 @@ func (s *service) GenerateLoginToken(ctx context.Context, _ *empty.Empty) (*pb.N
 	return &pb.NewTokenResponse{Token: token}, nil
 }
 
+// Replace the login token used to make the request. The new token keeps the
+// metadata, entrypoint, role, and scope of the old token. The old token is
+// only revoked once the new token is created so that a failure never leaves
+// the caller without a valid token.
+func (s *service) RotateToken(ctx context.Context, req *pb.RotateTokenRequest) (*pb.NewTokenResponse, error) {
+	dur, err := parseTokenDuration(req.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
+	}
+
+	tt, body, err := s.DecodeToken(md["authorization"][0])
+	if err != nil {
+		return nil, err
+	}
+	if !body.Login {
+		return nil, status.Errorf(codes.FailedPrecondition, "only login tokens can be rotated")
+	}
+
+	token, err := s.newLoginToken(dur, DefaultKeyId, tt.Metadata, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.state.TokenRevoke(body); err != nil {
+		return nil, err
+	}
+
+	return &pb.NewTokenResponse{Token: token}, nil
+}
+
+// parseTokenDuration parses the requested lifetime of a login token. An
+// empty value is a token that never expires.
+func parseTokenDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	dur, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid token duration: %s", err)
+	}
+	if dur <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "token duration must be positive")
+	}
+
+	return dur, nil
+}
+
 // Create a new invite token. The duration controls for how long the invite token is valid.
 // keyId controls which key is used to sign the key (key values are generated lazily).
 // metadata is attached to the token transport as configuration style information
@@ -262,7 +402,7 @@ func (s *service) ExchangeInvite(keyId, invite string) (string, error) {
 		return "", errors.Wrapf(ErrInvalidToken, "not an invite token")
 	}
 
-	return s.NewLoginToken(keyId, tt.Metadata, body.Entrypoint)
+	return s.newLoginToken(0, keyId, tt.Metadata, body)
 }
 
 // Given an invite token, validate it and return a login token. This is a gRPC wrapper around ExchangeInvite.
@@ -0,0 +1,333 @@
+package singleprocess
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// scopeFreeEndpoints are the endpoints that scoped tokens can call without
+// a scope because they never return the data of a project.
+var scopeFreeEndpoints = map[string]struct{}{
+	"GetVersionInfo":      {},
+	"ListOIDCAuthMethods": {},
+	"GetOIDCAuthURL":      {},
+}
+
+// scopeFilteredEndpoints are the endpoints that scoped tokens can call
+// without a scope because they only return results that the token has
+// access to. See scopedToken.
+var scopeFilteredEndpoints = map[string]struct{}{
+	"ListJobs":     {},
+	"ListProjects": {},
+}
+
+// AuthorizeScope implements server.ScopeChecker. A token that is limited
+// to projects or applications can only call endpoints that target one of
+// them. Endpoints that don't target a project or application, such as
+// the server config or a deployment that doesn't exist, are denied unless
+// they are in scopeFreeEndpoints or scopeFilteredEndpoints.
+func (s *service) AuthorizeScope(
+	ctx context.Context,
+	token, endpoint string,
+	effects []string,
+	scope *server.Scope,
+) error {
+	// Authenticate already allowed these endpoints without a token.
+	// Rotating only replaces the token with one of the same scope.
+	if token == "" || endpoint == "RotateToken" {
+		return nil
+	}
+
+	_, body, err := s.DecodeToken(token)
+	if err != nil {
+		return err
+	}
+	if len(body.Projects) == 0 && len(body.Applications) == 0 {
+		return nil
+	}
+
+	if scope == nil {
+		if _, ok := scopeFreeEndpoints[endpoint]; ok {
+			return nil
+		}
+		if _, ok := scopeFilteredEndpoints[endpoint]; ok {
+			return nil
+		}
+
+		return status.Errorf(codes.PermissionDenied,
+			"token is limited to specific projects and applications and can't call %s",
+			endpoint)
+	}
+
+	if !tokenAllowsScope(body, scope, effectMutable(effects)) {
+		target := scope.Project
+		if scope.Application != "" {
+			target += "/" + scope.Application
+		}
+
+		return status.Errorf(codes.PermissionDenied,
+			"token does not have access to %q", target)
+	}
+
+	return nil
+}
+
+// scopedToken returns the token of the request in ctx if it is limited to
+// projects or applications, or nil if it isn't. Endpoints in
+// scopeFilteredEndpoints use this to only return what the token can read.
+func (s *service) scopedToken(ctx context.Context) *pb.Token {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return nil
+	}
+
+	_, body, err := s.DecodeToken(md["authorization"][0])
+	if err != nil || (len(body.Projects) == 0 && len(body.Applications) == 0) {
+		return nil
+	}
+
+	return body
+}
+
+// tokenAllowsScope returns true if the scoped token can access scope. A
+// token limited to applications can read the project they belong to but
+// not change it.
+func tokenAllowsScope(body *pb.Token, scope *server.Scope, mutable bool) bool {
+	for _, p := range body.Projects {
+		if strings.EqualFold(p.Project, scope.Project) {
+			return true
+		}
+	}
+
+	for _, app := range body.Applications {
+		if !strings.EqualFold(app.Project, scope.Project) {
+			continue
+		}
+
+		if scope.Application == "" {
+			if !mutable {
+				return true
+			}
+
+			continue
+		}
+
+		if strings.EqualFold(app.Application, scope.Application) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// effectMutable returns true if the endpoint effects include mutating state.
+func effectMutable(effects []string) bool {
+	for _, e := range effects {
+		if e == "mutable" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveScope implements server.ScopeResolver. This finds the project or
+// application of requests that refer to them indirectly, such as by the ID
+// of a deployment or job. Records that don't exist have no scope.
+func (s *service) ResolveScope(ctx context.Context, req interface{}) (*server.Scope, error) {
+	switch req := req.(type) {
+	case *pb.GetJobRequest:
+		return s.jobScope(req.JobId)
+
+	case *pb.CancelJobRequest:
+		return s.jobScope(req.JobId)
+
+	case *pb.ApproveJobRequest:
+		return s.jobScope(req.JobId)
+
+	case *pb.GetBuildRequest:
+		return s.operationScope(req.Ref, func(ref *pb.Ref_Operation) (scopedOperation, error) {
+			return s.state.BuildGet(ref)
+		})
+
+	case *pb.GetPushedArtifactRequest:
+		return s.operationScope(req.Ref, func(ref *pb.Ref_Operation) (scopedOperation, error) {
+			return s.state.ArtifactGet(ref)
+		})
+
+	case *pb.GetDeploymentRequest:
+		return s.operationScope(req.Ref, func(ref *pb.Ref_Operation) (scopedOperation, error) {
+			return s.state.DeploymentGet(ref)
+		})
+
+	case *pb.GetReleaseRequest:
+		return s.operationScope(req.Ref, func(ref *pb.Ref_Operation) (scopedOperation, error) {
+			return s.state.ReleaseGet(ref)
+		})
+
+	case *pb.ListInstancesRequest:
+		if app := req.GetApplication(); app != nil && app.Application != nil {
+			return &server.Scope{
+				Project:     app.Application.Project,
+				Application: app.Application.Application,
+			}, nil
+		}
+
+		return s.deploymentScope(req.GetDeploymentId())
+
+	case *pb.ListExecSessionsRequest:
+		return s.deploymentScope(req.DeploymentId)
+
+	case *pb.GetExecSessionRequest:
+		session, err := s.state.ExecSessionGet(req.Index)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return s.deploymentScope(session.DeploymentId)
+
+	case *pb.CancelScheduledOperationRequest:
+		op, err := s.state.ScheduledOperationGet(req.Id)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return applicationScope(op.Job.GetApplication()), nil
+
+	case *pb.DeleteRecurringScheduleRequest:
+		rs, err := s.state.RecurringScheduleGet(req.Id)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return applicationScope(rs.Job.GetApplication()), nil
+
+	case *pb.DeleteTriggerRequest:
+		trigger, err := s.state.TriggerGet(req.Id)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil || trigger.Project == nil {
+			return nil, err
+		}
+
+		return &server.Scope{Project: trigger.Project.Project}, nil
+
+	case *pb.CreateHostnameRequest:
+		return applicationScope(req.Target.GetApplication().GetApplication()), nil
+
+	case *pb.ListHostnamesRequest:
+		return applicationScope(req.Target.GetApplication().GetApplication()), nil
+
+	case *pb.GetLogStreamRequest:
+		if app := req.GetApplication(); app != nil && app.Application != nil {
+			return &server.Scope{
+				Project:     app.Application.Project,
+				Application: app.Application.Application,
+			}, nil
+		}
+
+		return s.deploymentScope(req.GetDeploymentId())
+
+	case *pb.ExecStreamRequest:
+		return s.deploymentScope(req.GetStart().GetDeploymentId())
+
+	case *pb.GetJobStreamRequest:
+		return s.jobScope(req.JobId)
+
+	case *pb.GetSourceRequest:
+		bundle, err := s.state.SourceBundleGet(req.Id)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil || bundle.Project == nil {
+			return nil, err
+		}
+
+		return &server.Scope{Project: bundle.Project.Project}, nil
+	}
+
+	return nil, nil
+}
+
+// scopedOperation is an operation such as a build or deployment that
+// belongs to an application.
+type scopedOperation interface {
+	GetApplication() *pb.Ref_Application
+}
+
+// operationScope returns the scope of the operation that ref refers to.
+// References by sequence name the application. Otherwise get is used to
+// look up the operation. This returns nil if it doesn't exist.
+func (s *service) operationScope(
+	ref *pb.Ref_Operation,
+	get func(*pb.Ref_Operation) (scopedOperation, error),
+) (*server.Scope, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if seq := ref.GetSequence(); seq != nil {
+		return applicationScope(seq.Application), nil
+	}
+
+	op, err := get(ref)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return applicationScope(op.GetApplication()), nil
+}
+
+// deploymentScope returns the scope of the deployment with the given ID
+// or nil if it doesn't exist.
+func (s *service) deploymentScope(id string) (*server.Scope, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	return s.operationScope(&pb.Ref_Operation{
+		Target: &pb.Ref_Operation_Id{Id: id},
+	}, func(ref *pb.Ref_Operation) (scopedOperation, error) {
+		return s.state.DeploymentGet(ref)
+	})
+}
+
+// jobScope returns the scope of the job with the given ID or nil if it
+// doesn't exist.
+func (s *service) jobScope(id string) (*server.Scope, error) {
+	job, err := s.state.JobById(id, nil)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	return applicationScope(job.Application), nil
+}
+
+// applicationScope returns the scope of the application reference, or nil
+// if the reference is nil.
+func applicationScope(ref *pb.Ref_Application) *server.Scope {
+	if ref == nil {
+		return nil
+	}
+
+	return &server.Scope{Project: ref.Project, Application: ref.Application}
+}
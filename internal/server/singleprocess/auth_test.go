@@ -3,6 +3,7 @@ package singleprocess
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,9 +14,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/blake2b"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 func TestServiceAuth(t *testing.T) {
@@ -180,3 +184,447 @@ func TestServiceBootstrapToken(t *testing.T) {
 		require.Nil(resp)
 	}
 }
+
+func TestServiceGenerateLoginToken_duration(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	{
+		// No duration never expires
+		resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{})
+		require.NoError(err)
+
+		_, body, err := s.DecodeToken(resp.Token)
+		require.NoError(err)
+		require.Nil(body.ValidUntil)
+	}
+
+	{
+		// Expires after the duration
+		resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+			Duration: "2s",
+		})
+		require.NoError(err)
+
+		_, body, err := s.DecodeToken(resp.Token)
+		require.NoError(err)
+		require.NotNil(body.ValidUntil)
+		require.NoError(s.Authenticate(ctx, resp.Token, "UpsertDeployment", nil))
+
+		time.Sleep(3 * time.Second)
+		require.Error(s.Authenticate(ctx, resp.Token, "UpsertDeployment", nil))
+	}
+
+	{
+		// Invalid durations
+		_, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+			Duration: "-1h",
+		})
+		require.Error(err)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestServiceRotateToken(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	md := map[string]string{"addr": "test"}
+	old, err := s.NewLoginToken(DefaultKeyId, md, nil)
+	require.NoError(err)
+
+	// Requires a token
+	_, err = impl.RotateToken(ctx, &pb.RotateTokenRequest{})
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	resp, err := impl.RotateToken(
+		metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", old)),
+		&pb.RotateTokenRequest{Duration: "72h"},
+	)
+	require.NoError(err)
+
+	// The new token is valid and keeps the metadata
+	tt, body, err := s.DecodeToken(resp.Token)
+	require.NoError(err)
+	require.Equal(md, tt.Metadata)
+	require.NotNil(body.ValidUntil)
+	require.NoError(s.Authenticate(ctx, resp.Token, "UpsertDeployment", nil))
+
+	// The old token is revoked
+	_, _, err = s.DecodeToken(old)
+	require.Error(err)
+	require.Error(s.Authenticate(ctx, old, "UpsertDeployment", nil))
+}
+
+func TestServiceAuth_reader(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+		Role: pb.Token_READER,
+	})
+	require.NoError(err)
+
+	require.NoError(s.Authenticate(ctx, resp.Token, "ListBuilds", []string{"readonly"}))
+
+	err = s.Authenticate(ctx, resp.Token, "UpsertDeployment", []string{"mutable"})
+	require.Error(err)
+	require.Equal(codes.PermissionDenied, status.Code(err))
+
+	// Readers can rotate their own token, which keeps the role
+	require.NoError(s.Authenticate(ctx, resp.Token, "RotateToken", []string{"mutable"}))
+	rotated, err := impl.RotateToken(
+		metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", resp.Token)),
+		&pb.RotateTokenRequest{},
+	)
+	require.NoError(err)
+	_, body, err := s.DecodeToken(rotated.Token)
+	require.NoError(err)
+	require.Equal(pb.Token_READER, body.Role)
+}
+
+func TestServiceAuth_runner(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	token, err := s.newLoginToken(0, DefaultKeyId, nil, &pb.Token{
+		Runner: &pb.Token_Runner{Id: "R"},
+	})
+	require.NoError(err)
+
+	// Runner tokens can only access the runner APIs
+	require.NoError(s.Authenticate(ctx, token, "RunnerConfig", []string{"mutable"}))
+	err = s.Authenticate(ctx, token, "UpsertDeployment", []string{"mutable"})
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestServiceAuthorizeScope(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	readonly := []string{"readonly"}
+	mutable := []string{"mutable"}
+
+	{
+		// Unscoped tokens can access everything
+		token, err := s.NewLoginToken(DefaultKeyId, nil, nil)
+		require.NoError(err)
+		require.NoError(s.AuthorizeScope(ctx, token, "UpsertDeployment", mutable,
+			&server.Scope{Project: "p", Application: "a"}))
+		require.NoError(s.AuthorizeScope(ctx, token, "SetServerConfig", mutable, nil))
+	}
+
+	{
+		// Project scope
+		resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+			Projects: []*pb.Ref_Project{{Project: "p"}},
+		})
+		require.NoError(err)
+		token := resp.Token
+
+		require.NoError(s.AuthorizeScope(ctx, token, "UpsertDeployment", mutable,
+			&server.Scope{Project: "P", Application: "a"}))
+		require.NoError(s.AuthorizeScope(ctx, token, "ListJobs", readonly, nil))
+		require.NoError(s.AuthorizeScope(ctx, token, "GetVersionInfo", readonly, nil))
+
+		// Requests without a scope, such as for a job that doesn't exist,
+		// are denied.
+		err = s.AuthorizeScope(ctx, token, "GetJob", readonly, nil)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+
+		err = s.AuthorizeScope(ctx, token, "UpsertDeployment", mutable,
+			&server.Scope{Project: "other", Application: "a"})
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+
+		err = s.AuthorizeScope(ctx, token, "SetServerConfig", mutable, nil)
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+
+		// Scoped tokens can rotate themselves
+		require.NoError(s.AuthorizeScope(ctx, token, "RotateToken", mutable, nil))
+	}
+
+	{
+		// Application scope
+		resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+			Applications: []*pb.Ref_Application{{Project: "p", Application: "a"}},
+		})
+		require.NoError(err)
+		token := resp.Token
+
+		require.NoError(s.AuthorizeScope(ctx, token, "UpsertDeployment", mutable,
+			&server.Scope{Project: "p", Application: "a"}))
+		require.NoError(s.AuthorizeScope(ctx, token, "GetProject", readonly,
+			&server.Scope{Project: "p"}))
+
+		err = s.AuthorizeScope(ctx, token, "UpsertDeployment", mutable,
+			&server.Scope{Project: "p", Application: "b"})
+		require.Error(err)
+
+		err = s.AuthorizeScope(ctx, token, "UpsertProject", mutable,
+			&server.Scope{Project: "p"})
+		require.Error(err)
+	}
+}
+
+func TestServiceResolveScope(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	resp, err := impl.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: serverptypes.TestValidDeployment(t, nil),
+	})
+	require.NoError(err)
+	id := resp.Deployment.Id
+
+	expected := &server.Scope{Project: "p_test", Application: "a_test"}
+
+	{
+		// Exec
+		scope, err := s.ResolveScope(ctx, &pb.ExecStreamRequest{
+			Event: &pb.ExecStreamRequest_Start_{
+				Start: &pb.ExecStreamRequest_Start{DeploymentId: id},
+			},
+		})
+		require.NoError(err)
+		require.Equal(expected, scope)
+	}
+
+	{
+		// Logs by deployment
+		scope, err := s.ResolveScope(ctx, &pb.GetLogStreamRequest{
+			Scope: &pb.GetLogStreamRequest_DeploymentId{DeploymentId: id},
+		})
+		require.NoError(err)
+		require.Equal(expected, scope)
+	}
+
+	{
+		// Unknown deployments have no scope
+		scope, err := s.ResolveScope(ctx, &pb.ExecStreamRequest{
+			Event: &pb.ExecStreamRequest_Start_{
+				Start: &pb.ExecStreamRequest_Start{DeploymentId: "nope"},
+			},
+		})
+		require.NoError(err)
+		require.Nil(scope)
+	}
+
+	{
+		// Source bundles
+		require.NoError(s.state.SourceBundlePut(&pb.SourceBundle{
+			Id:      "B",
+			Project: &pb.Ref_Project{Project: "p_test"},
+		}))
+		scope, err := s.ResolveScope(ctx, &pb.GetSourceRequest{Id: "B"})
+		require.NoError(err)
+		require.Equal(&server.Scope{Project: "p_test"}, scope)
+	}
+}
+
+func TestServiceScopedToken_otherProject(t *testing.T) {
+	ctx := context.Background()
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(t, err)
+	s := impl.(*service)
+
+	// Create the same records in project A and project B
+	type records struct {
+		deploymentId string
+		buildId      string
+		jobId        string
+		session      uint64
+	}
+	create := func(project string) records {
+		ref := &pb.Ref_Application{Project: project, Application: "app"}
+
+		_, err := impl.UpsertProject(ctx, &pb.UpsertProjectRequest{
+			Project: serverptypes.TestProject(t, &pb.Project{Name: project}),
+		})
+		require.NoError(t, err)
+
+		build, err := impl.UpsertBuild(ctx, &pb.UpsertBuildRequest{
+			Build: serverptypes.TestValidBuild(t, &pb.Build{Application: ref}),
+		})
+		require.NoError(t, err)
+
+		deploy, err := impl.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{Application: ref}),
+		})
+		require.NoError(t, err)
+
+		job, err := impl.QueueJob(ctx, &pb.QueueJobRequest{
+			Job: serverptypes.TestJobNew(t, &pb.Job{Application: ref}),
+		})
+		require.NoError(t, err)
+
+		session := &pb.ExecSession{DeploymentId: deploy.Deployment.Id}
+		require.NoError(t, s.state.ExecSessionAppend(session))
+
+		return records{
+			deploymentId: deploy.Deployment.Id,
+			buildId:      build.Build.Id,
+			jobId:        job.JobId,
+			session:      session.Index,
+		}
+	}
+	a := create("A")
+	b := create("B")
+
+	resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+		Projects: []*pb.Ref_Project{{Project: "A"}},
+	})
+	require.NoError(t, err)
+	token := resp.Token
+
+	// authorize checks the request the same way the auth interceptor does.
+	authorize := func(endpoint string, req interface{}) error {
+		scope := server.RequestScope(req)
+		if scope == nil {
+			var err error
+			scope, err = s.ResolveScope(ctx, req)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.AuthorizeScope(ctx, token, endpoint, []string{"readonly"}, scope)
+	}
+	byId := func(id string) *pb.Ref_Operation {
+		return &pb.Ref_Operation{Target: &pb.Ref_Operation_Id{Id: id}}
+	}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		reqA     interface{}
+		reqB     interface{}
+	}{
+		{
+			"GetDeployment",
+			"GetDeployment",
+			&pb.GetDeploymentRequest{Ref: byId(a.deploymentId)},
+			&pb.GetDeploymentRequest{Ref: byId(b.deploymentId)},
+		},
+		{
+			"GetDeployment by sequence",
+			"GetDeployment",
+			&pb.GetDeploymentRequest{Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Sequence{Sequence: &pb.Ref_OperationSeq{
+					Application: &pb.Ref_Application{Project: "A", Application: "app"},
+					Number:      1,
+				}},
+			}},
+			&pb.GetDeploymentRequest{Ref: &pb.Ref_Operation{
+				Target: &pb.Ref_Operation_Sequence{Sequence: &pb.Ref_OperationSeq{
+					Application: &pb.Ref_Application{Project: "B", Application: "app"},
+					Number:      1,
+				}},
+			}},
+		},
+		{
+			"GetBuild",
+			"GetBuild",
+			&pb.GetBuildRequest{Ref: byId(a.buildId)},
+			&pb.GetBuildRequest{Ref: byId(b.buildId)},
+		},
+		{
+			"GetJob",
+			"GetJob",
+			&pb.GetJobRequest{JobId: a.jobId},
+			&pb.GetJobRequest{JobId: b.jobId},
+		},
+		{
+			"ListExecSessions",
+			"ListExecSessions",
+			&pb.ListExecSessionsRequest{DeploymentId: a.deploymentId},
+			&pb.ListExecSessionsRequest{DeploymentId: b.deploymentId},
+		},
+		{
+			"GetExecSession",
+			"GetExecSession",
+			&pb.GetExecSessionRequest{Index: a.session},
+			&pb.GetExecSessionRequest{Index: b.session},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.NoError(authorize(tt.endpoint, tt.reqA))
+
+			err := authorize(tt.endpoint, tt.reqB)
+			require.Error(err)
+			require.Equal(codes.PermissionDenied, status.Code(err))
+		})
+	}
+
+	t.Run("requests for all projects are denied", func(t *testing.T) {
+		require := require.New(t)
+
+		err := authorize("ListExecSessions", &pb.ListExecSessionsRequest{})
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+
+		err = authorize("GetDeployment", &pb.GetDeploymentRequest{Ref: byId("nope")})
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	})
+
+	tokenCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token))
+
+	t.Run("ListJobs only returns jobs of the token's projects", func(t *testing.T) {
+		require := require.New(t)
+
+		require.NoError(authorize("ListJobs", &pb.ListJobsRequest{}))
+
+		resp, err := impl.ListJobs(tokenCtx, &pb.ListJobsRequest{})
+		require.NoError(err)
+		require.Len(resp.Jobs, 1)
+		require.Equal(a.jobId, resp.Jobs[0].Id)
+
+		// Without a token everything is returned
+		resp, err = impl.ListJobs(ctx, &pb.ListJobsRequest{})
+		require.NoError(err)
+		require.Len(resp.Jobs, 2)
+	})
+
+	t.Run("ListProjects only returns the token's projects", func(t *testing.T) {
+		require := require.New(t)
+
+		resp, err := impl.ListProjects(tokenCtx, &empty.Empty{})
+		require.NoError(err)
+		require.Len(resp.Projects, 1)
+		require.True(strings.EqualFold("A", resp.Projects[0].Project))
+	})
+}
@@ -0,0 +1,113 @@
+package singleprocess
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// logFilter matches log lines against a GetLogStreamRequest filter. A nil
+// logFilter matches everything.
+type logFilter struct {
+	pattern    *regexp.Regexp
+	since      time.Time
+	until      time.Time
+	instanceId string
+	fields     map[string]string
+}
+
+// newLogFilter validates and compiles the filter. This returns nil if
+// there is no filter.
+func newLogFilter(f *pb.GetLogStreamRequest_Filter) (*logFilter, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	result := &logFilter{instanceId: f.InstanceId, fields: f.Fields}
+	if f.Pattern != "" {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"invalid filter pattern: %s", err)
+		}
+
+		result.pattern = re
+	}
+
+	if f.Since != nil {
+		t, err := ptypes.Timestamp(f.Since)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since: %s", err)
+		}
+
+		result.since = t
+	}
+
+	if f.Until != nil {
+		t, err := ptypes.Timestamp(f.Until)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid until: %s", err)
+		}
+
+		result.until = t
+	}
+
+	return result, nil
+}
+
+// matchInstance returns true if lines from the instance may match.
+func (f *logFilter) matchInstance(id string) bool {
+	return f == nil || strings.HasSuffix(id, f.instanceId)
+}
+
+// match returns true if the line matches. This doesn't check the instance.
+func (f *logFilter) match(entry *pb.LogBatch_Entry) bool {
+	if f == nil {
+		return true
+	}
+
+	if !f.since.IsZero() || !f.until.IsZero() {
+		t, err := ptypes.Timestamp(entry.Timestamp)
+		if err != nil {
+			return false
+		}
+		if !f.since.IsZero() && t.Before(f.since) {
+			return false
+		}
+		if !f.until.IsZero() && t.After(f.until) {
+			return false
+		}
+	}
+
+	// Lines that weren't parsed have no fields so they never match a
+	// field filter.
+	for k, v := range f.fields {
+		if actual, ok := entry.Fields[k]; !ok || actual != v {
+			return false
+		}
+	}
+
+	return f.pattern == nil || f.pattern.MatchString(entry.Line)
+}
+
+// filter returns the matching lines.
+func (f *logFilter) filter(entries []*pb.LogBatch_Entry) []*pb.LogBatch_Entry {
+	if f == nil {
+		return entries
+	}
+
+	result := entries[:0:0]
+	for _, e := range entries {
+		if f.match(e) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
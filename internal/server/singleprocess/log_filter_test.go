@@ -0,0 +1,120 @@
+package singleprocess
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestLogFilter(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration, line string) *pb.LogBatch_Entry {
+		ts, err := ptypes.TimestampProto(now.Add(d))
+		require.NoError(t, err)
+		return &pb.LogBatch_Entry{Timestamp: ts, Line: line}
+	}
+	ts := func(d time.Duration) *pb.LogBatch_Entry {
+		return at(d, "")
+	}
+
+	cases := []struct {
+		Name     string
+		Filter   *pb.GetLogStreamRequest_Filter
+		Entry    *pb.LogBatch_Entry
+		Expected bool
+	}{
+		{
+			"no filter",
+			nil,
+			at(0, "hello"),
+			true,
+		},
+
+		{
+			"pattern match",
+			&pb.GetLogStreamRequest_Filter{Pattern: "^err"},
+			at(0, "error: bad"),
+			true,
+		},
+
+		{
+			"pattern no match",
+			&pb.GetLogStreamRequest_Filter{Pattern: "^err"},
+			at(0, "an error"),
+			false,
+		},
+
+		{
+			"since",
+			&pb.GetLogStreamRequest_Filter{Since: ts(-time.Minute).Timestamp},
+			at(-2*time.Minute, "old"),
+			false,
+		},
+
+		{
+			"window",
+			&pb.GetLogStreamRequest_Filter{
+				Since: ts(-time.Minute).Timestamp,
+				Until: ts(time.Minute).Timestamp,
+			},
+			at(0, "now"),
+			true,
+		},
+
+		{
+			"until",
+			&pb.GetLogStreamRequest_Filter{Until: ts(-time.Minute).Timestamp},
+			at(0, "now"),
+			false,
+		},
+
+		{
+			"field match",
+			&pb.GetLogStreamRequest_Filter{Fields: map[string]string{"level": "error"}},
+			&pb.LogBatch_Entry{Fields: map[string]string{"level": "error", "msg": "bad"}},
+			true,
+		},
+
+		{
+			"field mismatch",
+			&pb.GetLogStreamRequest_Filter{Fields: map[string]string{"level": "error"}},
+			&pb.LogBatch_Entry{Fields: map[string]string{"level": "info"}},
+			false,
+		},
+
+		{
+			"field on unstructured line",
+			&pb.GetLogStreamRequest_Filter{Fields: map[string]string{"level": "error"}},
+			at(0, "level=error"),
+			false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			f, err := newLogFilter(tt.Filter)
+			require.NoError(err)
+			require.Equal(tt.Expected, f.match(tt.Entry))
+		})
+	}
+
+	t.Run("instance suffix", func(t *testing.T) {
+		require := require.New(t)
+
+		f, err := newLogFilter(&pb.GetLogStreamRequest_Filter{InstanceId: "abc123"})
+		require.NoError(err)
+		require.True(f.matchInstance("01EXAMPLEabc123"))
+		require.False(f.matchInstance("01EXAMPLEabc124"))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := newLogFilter(&pb.GetLogStreamRequest_Filter{Pattern: "("})
+		require.Error(t, err)
+	})
+}
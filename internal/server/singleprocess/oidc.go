@@ -0,0 +1,310 @@
+package singleprocess
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// oidcTimeout is how long each request to an OIDC provider can take.
+	oidcTimeout = 10 * time.Second
+
+	// oidcMaxResponse is the largest response read from an OIDC provider.
+	oidcMaxResponse = 1024 * 1024
+
+	// oidcAnyDomain is the allowed email domain that allows every user of
+	// the provider to log in, whether or not they have an email address.
+	oidcAnyDomain = "*"
+)
+
+// oidcProvider is the part of an OIDC provider's discovery document that
+// is used.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// oidcClaims are the ID token claims that are checked.
+type oidcClaims struct {
+	Issuer        string       `json:"iss"`
+	Subject       string       `json:"sub"`
+	Audience      oidcAudience `json:"aud"`
+	Expiry        int64        `json:"exp"`
+	Nonce         string       `json:"nonce"`
+	Email         string       `json:"email"`
+	EmailVerified bool         `json:"email_verified"`
+}
+
+// oidcAudience is the "aud" claim, which may be a single string or a list.
+type oidcAudience []string
+
+func (a *oidcAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = oidcAudience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*a = list
+	return nil
+}
+
+// oidcDiscover gets the discovery document of the OIDC provider.
+func oidcDiscover(ctx context.Context, issuer string) (*oidcProvider, error) {
+	ctx, cancel := context.WithTimeout(ctx, oidcTimeout)
+	defer cancel()
+
+	u := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid OIDC issuer: %s", err)
+	}
+
+	var result oidcProvider
+	if err := oidcDo(req, &result); err != nil {
+		return nil, err
+	}
+
+	// The issuer must match so that a provider can't issue tokens as
+	// another one.
+	if strings.TrimSuffix(result.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"OIDC provider issuer %q doesn't match %q", result.Issuer, issuer)
+	}
+	if result.AuthorizationEndpoint == "" || result.TokenEndpoint == "" {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"OIDC provider configuration is missing endpoints")
+	}
+
+	return &result, nil
+}
+
+// oidcAuthURL returns the URL of the provider that the user logs in at.
+func oidcAuthURL(p *oidcProvider, m *pb.AuthMethod_OIDC, redirectURI, state, nonce string) string {
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", m.ClientId)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", strings.Join(append([]string{"openid"}, m.Scopes...), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return p.AuthorizationEndpoint + sep + q.Encode()
+}
+
+// oidcExchange exchanges the authorization code for an ID token and
+// returns its validated claims.
+func oidcExchange(
+	ctx context.Context,
+	p *oidcProvider,
+	m *pb.AuthMethod_OIDC,
+	redirectURI, code, nonce string,
+) (*oidcClaims, error) {
+	ctx, cancel := context.WithTimeout(ctx, oidcTimeout)
+	defer cancel()
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", m.ClientId)
+	form.Set("client_secret", m.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid OIDC token endpoint: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp struct {
+		IdToken string `json:"id_token"`
+	}
+	if err := oidcDo(req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.IdToken == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "OIDC provider didn't return an ID token")
+	}
+
+	// The ID token came straight from the token endpoint over the
+	// connection we opened, so the signature doesn't need to be checked
+	// (OpenID Connect Core 3.1.3.7). The claims still do.
+	claims, err := oidcParseClaims(resp.IdToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Issuer != p.Issuer {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token has the wrong issuer")
+	}
+	if !claims.Audience.contains(m.ClientId) {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token has the wrong audience")
+	}
+	if time.Now().Unix() >= claims.Expiry {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token has expired")
+	}
+	if claims.Nonce != nonce {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token has the wrong nonce")
+	}
+
+	return claims, nil
+}
+
+// oidcParseClaims decodes the claims of a JWT without checking its
+// signature.
+func oidcParseClaims(token string) (*oidcClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token is malformed")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token is malformed: %s", err)
+	}
+
+	var result oidcClaims
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "ID token is malformed: %s", err)
+	}
+
+	return &result, nil
+}
+
+// oidcDo sends the request and decodes the JSON response into result.
+func oidcDo(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "error contacting OIDC provider: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, oidcMaxResponse))
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "error reading OIDC provider response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return status.Errorf(codes.Unauthenticated,
+			"OIDC provider returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return status.Errorf(codes.Unavailable, "invalid OIDC provider response: %s", err)
+	}
+
+	return nil
+}
+
+func (a oidcAudience) contains(v string) bool {
+	for _, aud := range a {
+		if aud == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oidcRedirectAllowed returns true if the redirect URI is one of the
+// allowed URIs. Loopback URIs match with any port since the CLI listens
+// on a random one (RFC 8252 7.3).
+func oidcRedirectAllowed(allowed []string, uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+
+	for _, v := range allowed {
+		if v == uri {
+			return true
+		}
+
+		a, err := url.Parse(v)
+		if err != nil || !oidcLoopback(a) || !oidcLoopback(u) {
+			continue
+		}
+		if a.Scheme == u.Scheme && a.Hostname() == u.Hostname() && a.Path == u.Path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func oidcLoopback(u *url.URL) bool {
+	if u.Scheme != "http" {
+		return false
+	}
+	if u.Hostname() == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(u.Hostname())
+	return ip != nil && ip.IsLoopback()
+}
+
+// oidcEmailAllowed returns true if the claims have a verified email in
+// one of the domains. Any user is allowed if a domain is oidcAnyDomain and
+// nobody is allowed if there are no domains.
+func oidcEmailAllowed(domains []string, claims *oidcClaims) bool {
+	for _, d := range domains {
+		if d == oidcAnyDomain {
+			return true
+		}
+	}
+	if !claims.EmailVerified {
+		return false
+	}
+
+	idx := strings.LastIndex(claims.Email, "@")
+	if idx < 0 {
+		return false
+	}
+	domain := claims.Email[idx+1:]
+	for _, d := range domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oidcTokenMetadata is the metadata attached to tokens issued by OIDC
+// auth methods so that it is known who they were issued to.
+func oidcTokenMetadata(name string, claims *oidcClaims) map[string]string {
+	md := map[string]string{
+		"auth_method": name,
+		"oidc_sub":    claims.Subject,
+	}
+	if claims.Email != "" {
+		md["oidc_email"] = claims.Email
+	}
+
+	return md
+}
@@ -1,9 +1,16 @@
 package singleprocess
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"crypto/tls"
+	"crypto/x509"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
 
 	"github.com/hashicorp/go-hclog"
 	grpctoken "github.com/hashicorp/horizon/pkg/grpc/token"
@@ -14,6 +21,8 @@ import (
 	configpkg "github.com/hashicorp/waypoint/internal/config"
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/server/logsink"
+	"github.com/hashicorp/waypoint/internal/server/runnerlaunch"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -21,6 +30,11 @@ import (
 
 // service implements the gRPC service for the server.
 type service struct {
+	// entrypointConns is the number of connected entrypoints. This must
+	// be accessed atomically and is first in the struct so that it is
+	// 64-bit aligned.
+	entrypointConns int64
+
 	// state is the state management interface that provides functions for
 	// safely mutating server state.
 	state *state.State
@@ -32,6 +46,41 @@ type service struct {
 	// to have the configs set.
 	urlConfig *configpkg.URL
 	urlClient wphznpb.WaypointHznClient
+
+	// pollTimers are the timers for the next data source poll of each
+	// project, keyed by project name.
+	pollMu     sync.Mutex
+	pollTimers map[string]*time.Timer
+
+	// timers are the pending timers of scheduled operations and recurring
+	// schedules. See afterFunc.
+	timerMu sync.Mutex
+	timers  map[*time.Timer]struct{}
+
+	// closed is non-zero once Close is called. No more timers are armed
+	// after that. This must be accessed atomically.
+	closed int32
+
+	// pollLatestCommit returns the latest commit of a git data source.
+	// This is only set by tests. By default git is used.
+	pollLatestCommit func(context.Context, *pb.Job_DataSource) (string, error)
+
+	// newRunnerLauncher returns the launcher for on-demand runners of a
+	// runner profile. This is only set by tests. By default
+	// runnerlaunch.New is used.
+	newRunnerLauncher func(*pb.RunnerProfile) (runnerlaunch.Launcher, error)
+
+	// caCert and caKey are the CA that entrypoint client certificates
+	// are issued with.
+	caCert    *x509.Certificate
+	caCertPEM []byte
+	caKey     *ecdsa.PrivateKey
+
+	// logSinks forwards entrypoint logs to the sinks in the server config.
+	logSinks *logsink.Fanout
+
+	// autoAdoptRunners adopts runners as they register.
+	autoAdoptRunners bool
 }
 
 // New returns a Waypoint server implementation that uses BotlDB plus
@@ -74,6 +123,21 @@ func New(opts ...Option) (pb.WaypointServer, error) {
 	}
 	s.id = id
 
+	if err := s.initEntrypointCA(); err != nil {
+		return nil, err
+	}
+
+	// Restore the timers for any scheduled operations
+	if err := s.armScheduledOperations(log); err != nil {
+		return nil, err
+	}
+	if err := s.armRecurringSchedules(log); err != nil {
+		return nil, err
+	}
+	if err := s.armProjectPolls(log); err != nil {
+		return nil, err
+	}
+
 	// Setup our URL service config if it is enabled.
 	if scfg := cfg.serverConfig; scfg != nil && scfg.URL != nil && scfg.URL.Enabled {
 		// Set our config
@@ -104,6 +168,10 @@ func New(opts ...Option) (pb.WaypointServer, error) {
 		s.urlClient = wphznpb.NewWaypointHznClient(conn)
 	}
 
+	if scfg := cfg.serverConfig; scfg != nil {
+		s.autoAdoptRunners = scfg.AutoAdoptRunners
+	}
+
 	// Set specific server config for the deployment entrypoint binaries
 	if scfg := cfg.serverConfig; scfg != nil && scfg.CEBConfig != nil && scfg.CEBConfig.Addr != "" {
 		// only one advertise address can be configured
@@ -113,18 +181,91 @@ func New(opts ...Option) (pb.WaypointServer, error) {
 			TlsSkipVerify: scfg.CEBConfig.TLSSkipVerify,
 		}
 
-		conf := &pb.ServerConfig{
-			AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{addr},
+		// Settings that can't come from the startup config are retained.
+		current, err := s.state.ServerConfigGet()
+		if err != nil {
+			return nil, err
+		}
+		conf := &pb.ServerConfig{}
+		if current != nil {
+			conf = proto.Clone(current).(*pb.ServerConfig)
 		}
+		conf.AdvertiseAddrs = []*pb.ServerConfig_AdvertiseAddr{addr}
 
 		if err := s.state.ServerConfigSet(conf); err != nil {
 			return nil, err
 		}
 	}
 
+	// Start forwarding logs to the configured sinks. A sink that can't be
+	// created doesn't stop the server since it can be fixed with the API.
+	s.logSinks = logsink.NewFanout(log.Named("logsink"))
+	if current, err := s.state.ServerConfigGet(); err != nil {
+		return nil, err
+	} else if current != nil {
+		if err := s.logSinks.Configure(current.LogSinks); err != nil {
+			log.Warn("error configuring log sinks", "err", err)
+		}
+	}
+
 	return &s, nil
 }
 
+// Close stops the timers of scheduled operations, recurring schedules and
+// data source polls so that none fire after the server stops. This doesn't
+// close the state since the caller owns the database.
+func (s *service) Close() error {
+	atomic.StoreInt32(&s.closed, 1)
+
+	s.timerMu.Lock()
+	for t := range s.timers {
+		t.Stop()
+	}
+	s.timers = nil
+	s.timerMu.Unlock()
+
+	s.pollMu.Lock()
+	for _, t := range s.pollTimers {
+		t.Stop()
+	}
+	s.pollTimers = nil
+	s.pollMu.Unlock()
+
+	return nil
+}
+
+// afterFunc calls f in its own goroutine after d like time.AfterFunc. The
+// timer is tracked until it fires so that Close can stop it. This does
+// nothing once the service is closed.
+func (s *service) afterFunc(d time.Duration, f func()) {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return
+	}
+	if s.timers == nil {
+		s.timers = map[*time.Timer]struct{}{}
+	}
+
+	// The lock is held until t is set, so the timer can't remove itself
+	// before it is added.
+	var t *time.Timer
+	t = time.AfterFunc(d, func() {
+		s.timerMu.Lock()
+		delete(s.timers, t)
+		s.timerMu.Unlock()
+
+		// The timer may have fired just as the service was closed.
+		if atomic.LoadInt32(&s.closed) != 0 {
+			return
+		}
+
+		f()
+	})
+	s.timers[t] = struct{}{}
+}
+
 type config struct {
 	db           *bolt.DB
 	serverConfig *configpkg.ServerConfig
@@ -50,6 +50,17 @@ func (s *service) ListPushedArtifacts(
 		return nil, err
 	}
 
+	if req.IncludeDeployments {
+		deployments, err := s.state.ArtifactDeployments(req.Application, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range result {
+			a.DeploymentIds = deployments[a.Id]
+		}
+	}
+
 	if req.IncludeBuild {
 		for _, a := range result {
 			b, err := s.state.BuildGet(&pb.Ref_Operation{
@@ -111,3 +111,46 @@ func TestServiceArtifact_List(t *testing.T) {
 	})
 
 }
+
+func TestServiceArtifact_deployments(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	var artifacts []*pb.PushedArtifact
+	for i := 0; i < 2; i++ {
+		resp, err := client.UpsertPushedArtifact(ctx, &pb.UpsertPushedArtifactRequest{
+			Artifact: serverptypes.TestValidArtifact(t, nil),
+		})
+		require.NoError(err)
+		artifacts = append(artifacts, resp.Artifact)
+	}
+	live := artifacts[0]
+
+	deployResp, err := client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+			ArtifactId: live.Id,
+			State:      pb.Operation_CREATED,
+		}),
+	})
+	require.NoError(err)
+
+	// The deployment is returned for the live artifact
+	listResp, err := client.ListPushedArtifacts(ctx, &pb.ListPushedArtifactsRequest{
+		Application:        live.Application,
+		IncludeDeployments: true,
+	})
+	require.NoError(err)
+	require.Len(listResp.Artifacts, 2)
+	for _, a := range listResp.Artifacts {
+		if a.Id == live.Id {
+			require.Equal([]string{deployResp.Deployment.Id}, a.DeploymentIds)
+		} else {
+			require.Empty(a.DeploymentIds)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package singleprocess
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// RecordAudit implements server.AuditRecorder by appending the call to
+// the audit log. Failing to record is logged but doesn't fail the call
+// since the mutation has already happened.
+func (s *service) RecordAudit(ctx context.Context, endpoint string, err error) {
+	event := &pb.AuditEvent{
+		Time:     ptypes.TimestampNow(),
+		Endpoint: endpoint,
+		Identity: s.tokenIdentity(ctx),
+	}
+	if err != nil {
+		event.Error = status.Convert(err).Proto()
+	}
+
+	if err := s.state.AuditLogAppend(event); err != nil {
+		hclog.FromContext(ctx).Error("error recording audit event",
+			"endpoint", endpoint, "err", err)
+	}
+}
+
+func (s *service) GetAuditLog(
+	ctx context.Context,
+	req *pb.GetAuditLogRequest,
+) (*pb.GetAuditLogResponse, error) {
+	events, err := s.state.AuditLogList(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAuditLogResponse{Events: events}, nil
+}
@@ -0,0 +1,64 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceAuditLog(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// A mutation is recorded
+	_, err = client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+		Config: &pb.ServerConfig{
+			AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{{Addr: "a:1234"}},
+		},
+	})
+	require.NoError(err)
+
+	// A failed mutation is recorded with its error
+	_, err = client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+		Config: &pb.ServerConfig{},
+	})
+	require.Error(err)
+
+	// Reads are not recorded
+	_, err = client.GetAuditLog(ctx, &pb.GetAuditLogRequest{})
+	require.NoError(err)
+
+	resp, err := client.GetAuditLog(ctx, &pb.GetAuditLogRequest{})
+	require.NoError(err)
+
+	var events []*pb.AuditEvent
+	for _, e := range resp.Events {
+		if e.Endpoint == "SetServerConfig" {
+			events = append(events, e)
+		}
+	}
+	require.Len(events, 2)
+	require.Nil(events[0].Error)
+	require.NotNil(events[0].Time)
+	require.NotNil(events[1].Error)
+	require.NotEqual(int32(codes.OK), events[1].Error.Code)
+
+	for _, e := range resp.Events {
+		require.NotEqual("GetAuditLog", e.Endpoint)
+	}
+
+	// The limit returns the most recent
+	resp, err = client.GetAuditLog(ctx, &pb.GetAuditLogRequest{Limit: 1})
+	require.NoError(err)
+	require.Len(resp.Events, 1)
+	require.Equal(events[1].Index, resp.Events[0].Index)
+}
@@ -0,0 +1,225 @@
+package singleprocess
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// authMethodDefaultTokenDuration is how long tokens issued by auth methods
+// are valid for if the auth method doesn't set a duration.
+const authMethodDefaultTokenDuration = 24 * time.Hour
+
+func (s *service) UpsertAuthMethod(
+	ctx context.Context,
+	req *pb.UpsertAuthMethodRequest,
+) (*pb.UpsertAuthMethodResponse, error) {
+	m := req.AuthMethod
+	if m == nil || m.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "auth method name must be set")
+	}
+	if _, err := parseTokenDuration(m.TokenDuration); err != nil {
+		return nil, err
+	}
+
+	oidc := m.GetOidc()
+	if oidc == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "auth method must be configured")
+	}
+	if oidc.Issuer == "" || oidc.ClientId == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"OIDC auth methods require an issuer and client ID")
+	}
+	if len(oidc.AllowedRedirectUris) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"OIDC auth methods require at least one allowed redirect URI")
+	}
+	if len(oidc.AllowedEmailDomains) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"OIDC auth methods require at least one allowed email domain, "+
+				"or %q to allow every user of the provider", oidcAnyDomain)
+	}
+
+	// The secret is never returned so updates that don't set it keep the
+	// existing one.
+	if oidc.ClientSecret == "" {
+		existing, err := s.state.AuthMethodGet(m.Name)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		if err == nil {
+			oidc.ClientSecret = existing.GetOidc().GetClientSecret()
+		}
+	}
+
+	if err := s.state.AuthMethodPut(m); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpsertAuthMethodResponse{AuthMethod: authMethodRedacted(m)}, nil
+}
+
+func (s *service) ListAuthMethods(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.ListAuthMethodsResponse, error) {
+	list, err := s.state.AuthMethodList()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.AuthMethod, len(list))
+	for i, m := range list {
+		result[i] = authMethodRedacted(m)
+	}
+
+	return &pb.ListAuthMethodsResponse{AuthMethods: result}, nil
+}
+
+func (s *service) DeleteAuthMethod(
+	ctx context.Context,
+	req *pb.DeleteAuthMethodRequest,
+) (*empty.Empty, error) {
+	if err := s.state.AuthMethodDelete(req.Name); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *service) ListOIDCAuthMethods(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.ListOIDCAuthMethodsResponse, error) {
+	list, err := s.state.AuthMethodList()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pb.ListOIDCAuthMethodsResponse_Method
+	for _, m := range list {
+		if m.GetOidc() == nil {
+			continue
+		}
+
+		result = append(result, &pb.ListOIDCAuthMethodsResponse_Method{
+			Name:        m.Name,
+			DisplayName: m.DisplayName,
+		})
+	}
+
+	return &pb.ListOIDCAuthMethodsResponse{AuthMethods: result}, nil
+}
+
+func (s *service) GetOIDCAuthURL(
+	ctx context.Context,
+	req *pb.GetOIDCAuthURLRequest,
+) (*pb.GetOIDCAuthURLResponse, error) {
+	if req.State == "" || req.Nonce == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "state and nonce must be set")
+	}
+
+	oidc, err := s.oidcAuthMethod(req.AuthMethod, req.RedirectUri)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := oidcDiscover(ctx, oidc.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetOIDCAuthURLResponse{
+		Url: oidcAuthURL(p, oidc, req.RedirectUri, req.State, req.Nonce),
+	}, nil
+}
+
+func (s *service) CompleteOIDCAuth(
+	ctx context.Context,
+	req *pb.CompleteOIDCAuthRequest,
+) (*pb.NewTokenResponse, error) {
+	if req.Code == "" || req.Nonce == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "code and nonce must be set")
+	}
+
+	oidc, err := s.oidcAuthMethod(req.AuthMethod, req.RedirectUri)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the rest of the auth method now that we know it exists.
+	m, err := s.state.AuthMethodGet(req.AuthMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := oidcDiscover(ctx, oidc.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := oidcExchange(ctx, p, oidc, req.RedirectUri, req.Code, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !oidcEmailAllowed(oidc.AllowedEmailDomains, claims) {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"a verified email address in an allowed domain is required")
+	}
+
+	dur, err := parseTokenDuration(m.TokenDuration)
+	if err != nil {
+		return nil, err
+	}
+	if dur == 0 {
+		dur = authMethodDefaultTokenDuration
+	}
+
+	token, err := s.newLoginToken(dur, DefaultKeyId,
+		oidcTokenMetadata(m.Name, claims), &pb.Token{Role: m.Role})
+	if err != nil {
+		return nil, err
+	}
+
+	hclog.FromContext(ctx).Info("issued token with OIDC auth method",
+		"auth_method", m.Name, "sub", claims.Subject, "email", claims.Email)
+	return &pb.NewTokenResponse{Token: token}, nil
+}
+
+// oidcAuthMethod gets the OIDC settings of the named auth method and
+// checks that the redirect URI is allowed.
+func (s *service) oidcAuthMethod(name, redirectURI string) (*pb.AuthMethod_OIDC, error) {
+	m, err := s.state.AuthMethodGet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	oidc := m.GetOidc()
+	if oidc == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"auth method %q is not an OIDC auth method", name)
+	}
+	if !oidcRedirectAllowed(oidc.AllowedRedirectUris, redirectURI) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"redirect URI %q is not allowed by the auth method", redirectURI)
+	}
+
+	return oidc, nil
+}
+
+// authMethodRedacted returns a copy of the auth method without secrets.
+func authMethodRedacted(m *pb.AuthMethod) *pb.AuthMethod {
+	result := proto.Clone(m).(*pb.AuthMethod)
+	if oidc := result.GetOidc(); oidc != nil {
+		oidc.ClientSecret = ""
+	}
+
+	return result
+}
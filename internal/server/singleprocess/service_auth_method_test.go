@@ -0,0 +1,253 @@
+package singleprocess
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceAuthMethod_OIDC(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Fake OIDC provider. The code it accepts is "code" and the nonce
+	// in the ID token is whatever the test sets.
+	var nonce string
+	var provider *httptest.Server
+	provider = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 provider.URL,
+				"authorization_endpoint": provider.URL + "/authorize",
+				"token_endpoint":         provider.URL + "/token",
+			})
+
+		case "/token":
+			r.ParseForm()
+			if r.Form.Get("code") != "code" || r.Form.Get("client_secret") != "secret" {
+				http.Error(w, "invalid_grant", http.StatusBadRequest)
+				return
+			}
+
+			claims, _ := json.Marshal(map[string]interface{}{
+				"iss":            provider.URL,
+				"sub":            "user1",
+				"aud":            "client",
+				"exp":            time.Now().Add(time.Minute).Unix(),
+				"nonce":          nonce,
+				"email":          "user1@example.com",
+				"email_verified": true,
+			})
+			json.NewEncoder(w).Encode(map[string]string{
+				"id_token": "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".sig",
+			})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer provider.Close()
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	s := impl.(*service)
+
+	// An allowed email domain is required so that every user of the
+	// provider isn't allowed by accident.
+	_, err = client.UpsertAuthMethod(ctx, &pb.UpsertAuthMethodRequest{
+		AuthMethod: &pb.AuthMethod{
+			Name: "google",
+			Role: pb.Token_READER,
+			Method: &pb.AuthMethod_Oidc{
+				Oidc: &pb.AuthMethod_OIDC{
+					Issuer:              provider.URL,
+					ClientId:            "client",
+					ClientSecret:        "secret",
+					AllowedRedirectUris: []string{"http://127.0.0.1/oidc/callback"},
+				},
+			},
+		},
+	})
+	require.Error(err)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = client.UpsertAuthMethod(ctx, &pb.UpsertAuthMethodRequest{
+		AuthMethod: &pb.AuthMethod{
+			Name: "google",
+			Role: pb.Token_READER,
+			Method: &pb.AuthMethod_Oidc{
+				Oidc: &pb.AuthMethod_OIDC{
+					Issuer:              provider.URL,
+					ClientId:            "client",
+					ClientSecret:        "secret",
+					AllowedRedirectUris: []string{"http://127.0.0.1/oidc/callback"},
+					AllowedEmailDomains: []string{"example.com"},
+				},
+			},
+		},
+	})
+	require.NoError(err)
+
+	// Updating without a secret keeps the existing one and secrets are
+	// never returned.
+	resp, err := client.UpsertAuthMethod(ctx, &pb.UpsertAuthMethodRequest{
+		AuthMethod: &pb.AuthMethod{
+			Name:        "google",
+			DisplayName: "Google",
+			Role:        pb.Token_READER,
+			Method: &pb.AuthMethod_Oidc{
+				Oidc: &pb.AuthMethod_OIDC{
+					Issuer:              provider.URL,
+					ClientId:            "client",
+					AllowedRedirectUris: []string{"http://127.0.0.1/oidc/callback"},
+					AllowedEmailDomains: []string{"example.com"},
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	require.Empty(resp.AuthMethod.GetOidc().ClientSecret)
+
+	list, err := client.ListAuthMethods(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Len(list.AuthMethods, 1)
+	require.Empty(list.AuthMethods[0].GetOidc().ClientSecret)
+
+	names, err := client.ListOIDCAuthMethods(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Len(names.AuthMethods, 1)
+	require.Equal("Google", names.AuthMethods[0].DisplayName)
+
+	// Redirect URIs that aren't allowed are rejected
+	_, err = client.GetOIDCAuthURL(ctx, &pb.GetOIDCAuthURLRequest{
+		AuthMethod:  "google",
+		RedirectUri: "http://evil.example.com/oidc/callback",
+		State:       "state",
+		Nonce:       "nonce",
+	})
+	require.Error(err)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Any port of the loopback redirect URI is allowed
+	redirect := "http://127.0.0.1:4321/oidc/callback"
+	urlResp, err := client.GetOIDCAuthURL(ctx, &pb.GetOIDCAuthURLRequest{
+		AuthMethod:  "google",
+		RedirectUri: redirect,
+		State:       "state",
+		Nonce:       "nonce",
+	})
+	require.NoError(err)
+	u, err := url.Parse(urlResp.Url)
+	require.NoError(err)
+	require.Equal("/authorize", u.Path)
+	require.Equal("client", u.Query().Get("client_id"))
+	require.Equal(redirect, u.Query().Get("redirect_uri"))
+	require.Equal("state", u.Query().Get("state"))
+	require.Equal("openid", u.Query().Get("scope"))
+
+	// A nonce that doesn't match the ID token is rejected
+	nonce = "other"
+	_, err = client.CompleteOIDCAuth(ctx, &pb.CompleteOIDCAuthRequest{
+		AuthMethod:  "google",
+		RedirectUri: redirect,
+		Nonce:       "nonce",
+		Code:        "code",
+	})
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	nonce = "nonce"
+	tokenResp, err := client.CompleteOIDCAuth(ctx, &pb.CompleteOIDCAuthRequest{
+		AuthMethod:  "google",
+		RedirectUri: redirect,
+		Nonce:       "nonce",
+		Code:        "code",
+	})
+	require.NoError(err)
+
+	tt, body, err := s.DecodeToken(tokenResp.Token)
+	require.NoError(err)
+	require.True(body.Login)
+	require.Equal(pb.Token_READER, body.Role)
+	require.NotNil(body.ValidUntil)
+	require.Equal("google", tt.Metadata["auth_method"])
+	require.Equal("user1@example.com", tt.Metadata["oidc_email"])
+	require.NoError(s.Authenticate(ctx, tokenResp.Token, "ListProjects", nil))
+
+	// Delete
+	_, err = client.DeleteAuthMethod(ctx, &pb.DeleteAuthMethodRequest{Name: "google"})
+	require.NoError(err)
+	_, err = client.CompleteOIDCAuth(ctx, &pb.CompleteOIDCAuthRequest{
+		AuthMethod:  "google",
+		RedirectUri: redirect,
+		Nonce:       "nonce",
+		Code:        "code",
+	})
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestOIDCEmailAllowed(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Domains  []string
+		Claims   oidcClaims
+		Expected bool
+	}{
+		{
+			"no domains",
+			nil,
+			oidcClaims{Email: "a@example.com", EmailVerified: true},
+			false,
+		},
+
+		{
+			"any domain",
+			[]string{"example.com", "*"},
+			oidcClaims{},
+			true,
+		},
+
+		{
+			"allowed domain",
+			[]string{"example.com"},
+			oidcClaims{Email: "a@Example.com", EmailVerified: true},
+			true,
+		},
+
+		{
+			"unverified",
+			[]string{"example.com"},
+			oidcClaims{Email: "a@example.com"},
+			false,
+		},
+
+		{
+			"other domain",
+			[]string{"example.com"},
+			oidcClaims{Email: "a@example.com.evil", EmailVerified: true},
+			false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, oidcEmailAllowed(tt.Domains, &tt.Claims))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -41,9 +42,16 @@ func (s *service) ListBuilds(
 	ctx context.Context,
 	req *pb.ListBuildsRequest,
 ) (*pb.ListBuildsResponse, error) {
+	labels, err := serverptypes.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid label selector: %s", err)
+	}
+
 	result, err := s.state.BuildList(req.Application,
 		state.ListWithWorkspace(req.Workspace),
 		state.ListWithOrder(req.Order),
+		state.ListWithLabelSelector(labels),
 	)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,9 @@ package singleprocess
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
@@ -10,6 +13,15 @@ func (s *service) SetConfig(
 	ctx context.Context,
 	req *pb.ConfigSetRequest,
 ) (*pb.ConfigSetResponse, error) {
+	for _, v := range req.Variables {
+		if scope, ok := v.Scope.(*pb.ConfigVar_Runner); ok {
+			if _, ok := scope.Runner.Target.(*pb.Ref_Runner_Labels); ok {
+				return nil, status.Errorf(codes.InvalidArgument,
+					"config variable %q can't target runners by labels", v.Name)
+			}
+		}
+	}
+
 	if err := s.state.ConfigSet(req.Variables...); err != nil {
 		return nil, err
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -38,6 +39,16 @@ func (s *service) UpsertDeployment(
 		return nil, err
 	}
 
+	// A newly completed deployment may push older ones beyond the
+	// retention, so prune them. Errors don't fail the upsert.
+	if result.State == pb.Operation_CREATED &&
+		result.Status != nil && result.Status.State == pb.Status_SUCCESS {
+		log := hclog.FromContext(ctx)
+		if err := s.pruneDeployments(ctx, log, result.Application, result.Workspace); err != nil {
+			log.Warn("error pruning deployments", "err", err)
+		}
+	}
+
 	// This requires: (1) URL service is enabled (2) auto hostname isn't
 	// explicitly set to false in the request and (3) either the server
 	// default is true or we explicitly ask for it.
@@ -78,11 +89,18 @@ func (s *service) ListDeployments(
 	ctx context.Context,
 	req *pb.ListDeploymentsRequest,
 ) (*pb.ListDeploymentsResponse, error) {
+	labels, err := serverptypes.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid label selector: %s", err)
+	}
+
 	result, err := s.state.DeploymentList(req.Application,
 		state.ListWithStatusFilter(req.Status...),
 		state.ListWithOrder(req.Order),
 		state.ListWithWorkspace(req.Workspace),
 		state.ListWithPhysicalState(req.PhysicalState),
+		state.ListWithLabelSelector(labels),
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,168 @@
+package singleprocess
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
+)
+
+// labelPinned is the deployment label that marks it as pinned. This must
+// match the label set by App.PinDeployment in internal/core.
+const labelPinned = "waypoint/pinned"
+
+// pruneDeployments queues destroy jobs for the deployments of the app in
+// the workspace beyond the retention set in the server config. The most
+// recently completed deployments are kept. The released deployment and
+// pinned deployments are never destroyed and don't count toward the
+// retention. This does nothing if no retention is set.
+func (s *service) pruneDeployments(
+	ctx context.Context,
+	log hclog.Logger,
+	ref *pb.Ref_Application,
+	ws *pb.Ref_Workspace,
+) error {
+	cfg, err := s.state.ServerConfigGet()
+	if err != nil {
+		return err
+	}
+	if cfg.DeploymentRetention == 0 {
+		return nil
+	}
+
+	deployments, err := s.state.DeploymentList(ref,
+		state.ListWithWorkspace(ws),
+		state.ListWithPhysicalState(pb.Operation_CREATED),
+		state.ListWithOrder(&pb.OperationOrder{
+			Order: pb.OperationOrder_COMPLETE_TIME,
+			Desc:  true,
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	var releasedId string
+	release, err := s.state.ReleaseLatest(ref, ws)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	if release != nil {
+		releasedId = release.DeploymentId
+	}
+
+	// Deployments that already have a destroy job in progress are skipped
+	// so that we don't queue a second one.
+	destroying, err := s.destroyingDeployments()
+	if err != nil {
+		return err
+	}
+
+	var kept uint32
+	for _, d := range deployments {
+		if d.Id == releasedId {
+			continue
+		}
+		if _, ok := d.Labels[labelPinned]; ok {
+			continue
+		}
+		if d.Status == nil || d.Status.State != pb.Status_SUCCESS {
+			continue
+		}
+		if kept < cfg.DeploymentRetention {
+			kept++
+			continue
+		}
+		if _, ok := destroying[d.Id]; ok {
+			continue
+		}
+
+		jobId, err := s.queueDestroyDeployment(ctx, d)
+		if err != nil {
+			return err
+		}
+
+		log.Info("queued destroy for deployment beyond retention",
+			"deployment", d.Id,
+			"job", jobId,
+			"retention", cfg.DeploymentRetention)
+	}
+
+	return nil
+}
+
+// queueDestroyDeployment queues a job that destroys the given deployment.
+// The job uses the data source of the job that created the deployment if
+// that job is still known, otherwise the project default.
+func (s *service) queueDestroyDeployment(ctx context.Context, d *pb.Deployment) (string, error) {
+	job := &pb.Job{
+		Application: d.Application,
+		Workspace:   d.Workspace,
+		TargetRunner: &pb.Ref_Runner{
+			Target: &pb.Ref_Runner_Any{
+				Any: &pb.Ref_RunnerAny{},
+			},
+		},
+		Operation: &pb.Job_Destroy{
+			Destroy: &pb.Job_DestroyOp{
+				Target: &pb.Job_DestroyOp_Deployment{
+					Deployment: d,
+				},
+			},
+		},
+
+		// Pruning can wait for jobs that operators wait on
+		Priority: pb.Job_LOW,
+	}
+
+	if d.JobId != "" {
+		source, err := s.state.JobById(d.JobId, nil)
+		if err != nil {
+			return "", err
+		}
+		if source != nil {
+			job.DataSource = source.DataSource
+			job.DataSourceOverrides = source.DataSourceOverrides
+		}
+	}
+
+	resp, err := s.QueueJob(ctx, &pb.QueueJobRequest{Job: job})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.JobId, nil
+}
+
+// destroyingDeployments returns the IDs of the deployments that have a
+// destroy job that hasn't completed yet.
+func (s *service) destroyingDeployments() (map[string]struct{}, error) {
+	jobs, err := s.state.JobList()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]struct{}{}
+	for _, job := range jobs {
+		switch job.State {
+		case pb.Job_QUEUED, pb.Job_WAITING, pb.Job_RUNNING:
+		default:
+			continue
+		}
+
+		op, ok := job.Operation.(*pb.Job_Destroy)
+		if !ok {
+			continue
+		}
+
+		if t, ok := op.Destroy.Target.(*pb.Job_DestroyOp_Deployment); ok && t.Deployment != nil {
+			result[t.Deployment.Id] = struct{}{}
+		}
+	}
+
+	return result, nil
+}
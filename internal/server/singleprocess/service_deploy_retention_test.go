@@ -0,0 +1,85 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestServiceDeployment_retention(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// The project needs a data source for the destroy jobs
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{
+			Name: "p_test",
+			Applications: []*pb.Application{
+				{Name: "a_test", Project: &pb.Ref_Project{Project: "p_test"}},
+			},
+			DataSource: &pb.Job_DataSource{
+				Source: &pb.Job_DataSource_Local{
+					Local: &pb.Job_Local{},
+				},
+			},
+		},
+	})
+	require.NoError(err)
+
+	_, err = client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+		Config: &pb.ServerConfig{
+			AdvertiseAddrs:      []*pb.ServerConfig_AdvertiseAddr{{}},
+			DeploymentRetention: 1,
+		},
+	})
+	require.NoError(err)
+
+	// Create deployments from oldest to newest. The first is pinned.
+	start := time.Now()
+	var ids []string
+	for i := 0; i < 4; i++ {
+		ts, err := ptypes.TimestampProto(start.Add(time.Duration(i) * time.Second))
+		require.NoError(err)
+
+		d := serverptypes.TestValidDeployment(t, &pb.Deployment{
+			State: pb.Operation_CREATED,
+			Status: &pb.Status{
+				State:        pb.Status_SUCCESS,
+				StartTime:    ts,
+				CompleteTime: ts,
+			},
+		})
+		if i == 0 {
+			d.Labels = map[string]string{labelPinned: "critical"}
+		}
+
+		resp, err := client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: d,
+		})
+		require.NoError(err)
+		ids = append(ids, resp.Deployment.Id)
+	}
+
+	// Only the unpinned deployments beyond the newest are destroyed, and
+	// each only once.
+	destroying, err := testServiceImpl(impl).destroyingDeployments()
+	require.NoError(err)
+	require.Len(destroying, 2)
+	require.Contains(destroying, ids[1])
+	require.Contains(destroying, ids[2])
+
+	jobs, err := testServiceImpl(impl).state.JobList()
+	require.NoError(err)
+	require.Len(jobs, 2)
+}
@@ -250,4 +250,41 @@ func TestServiceDeployment_ListDeployments(t *testing.T) {
 		require.Equal(deployments.Deployments[0].Preload.Artifact.Id, artifactresp.Artifact.Id)
 		require.Equal(deployments.Deployments[0].Preload.Build.Id, build.Id)
 	})
+
+	t.Run("list with label selector", func(t *testing.T) {
+		require := require.New(t)
+
+		labeled := serverptypes.TestValidDeployment(t, &pb.Deployment{
+			Labels: map[string]string{"env": "prod", "team": "web"},
+		})
+		labeledResp, err := client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: labeled,
+		})
+		require.NoError(err)
+
+		deployments, err := client.ListDeployments(ctx, &Req{
+			Application:   resp.Deployment.Application,
+			LabelSelector: "env=prod,team!=infra",
+		})
+		require.NoError(err)
+		require.Len(deployments.Deployments, 1)
+		require.Equal(labeledResp.Deployment.Id, deployments.Deployments[0].Id)
+
+		deployments, err = client.ListDeployments(ctx, &Req{
+			Application:   resp.Deployment.Application,
+			LabelSelector: "!env",
+		})
+		require.NoError(err)
+		for _, d := range deployments.Deployments {
+			require.NotEqual(labeledResp.Deployment.Id, d.Id)
+		}
+
+		// Invalid selector
+		_, err = client.ListDeployments(ctx, &Req{
+			Application:   resp.Deployment.Application,
+			LabelSelector: "=prod",
+		})
+		require.Error(err)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
 }
@@ -15,6 +15,7 @@ import (
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/hashicorp/waypoint/internal/server/logbuffer"
+	"github.com/hashicorp/waypoint/internal/server/logsink"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -50,6 +51,8 @@ func (s *service) EntrypointConfig(
 	if err := s.state.InstanceCreate(record); err != nil {
 		return err
 	}
+	atomic.AddInt64(&s.entrypointConns, 1)
+	defer atomic.AddInt64(&s.entrypointConns, -1)
 
 	// Defer deleting this.
 	// TODO(mitchellh): this is too aggressive and we want to have some grace
@@ -157,6 +160,8 @@ func (s *service) EntrypointLogStream(
 	log := hclog.FromContext(server.Context())
 
 	var buf *logbuffer.Buffer
+	var instance *state.Instance
+	var retention uint32
 	for {
 		// Read the next log entry
 		batch, err := server.Recv()
@@ -169,7 +174,13 @@ func (s *service) EntrypointLogStream(
 			log = log.With("instance_id", batch.InstanceId)
 
 			// Read our instance record
-			instance, err := s.state.InstanceById(batch.InstanceId)
+			instance, err = s.state.InstanceById(batch.InstanceId)
+			if err != nil {
+				return err
+			}
+
+			// Logs are also stored if retention is configured for the app.
+			retention, err = s.logRetention(instance.Project, instance.Application)
 			if err != nil {
 				return err
 			}
@@ -196,6 +207,32 @@ func (s *service) EntrypointLogStream(
 
 		// Write our log data to the circular buffer
 		buf.Write(entries...)
+
+		s.logSinks.Send(&logsink.Source{
+			Project:      instance.Project,
+			App:          instance.Application,
+			Workspace:    instance.Workspace,
+			DeploymentId: instance.DeploymentId,
+			InstanceId:   instance.Id,
+		}, batch.Lines)
+
+		if retention > 0 {
+			if err := s.state.InstanceLogAppend(
+				&pb.Ref_Application{
+					Project:     instance.Project,
+					Application: instance.Application,
+				},
+				&pb.Ref_Workspace{Workspace: instance.Workspace},
+				&pb.LogBatch{
+					DeploymentId: instance.DeploymentId,
+					InstanceId:   instance.Id,
+					Lines:        batch.Lines,
+				},
+				retention,
+			); err != nil {
+				log.Warn("error storing log lines", "err", err)
+			}
+		}
 	}
 }
 
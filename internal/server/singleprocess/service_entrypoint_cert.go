@@ -0,0 +1,239 @@
+package singleprocess
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// entrypointCertTTL is how long entrypoint client certificates are
+	// valid for. Entrypoints rotate them before they expire.
+	entrypointCertTTL = time.Hour
+
+	// entrypointCATTL is how long the CA certificate is valid for.
+	entrypointCATTL = 10 * 365 * 24 * time.Hour
+
+	// entrypointCertBackdate is how far before now certificates are valid
+	// from so that small clock differences don't reject them.
+	entrypointCertBackdate = time.Minute
+)
+
+// initEntrypointCA loads the CA that entrypoint client certificates are
+// issued with, creating it if this is the first time the server started.
+func (s *service) initEntrypointCA() error {
+	ca, err := s.state.CertificateAuthorityGet()
+	if err != nil {
+		return err
+	}
+	if ca == nil {
+		ca, err = newEntrypointCA()
+		if err != nil {
+			return err
+		}
+
+		// Another server sharing the DB could have created one first, in
+		// which case we use that.
+		ca, err = s.state.CertificateAuthorityCreateIfNotExist(ca)
+		if err != nil {
+			return err
+		}
+	}
+
+	certBlock, _ := pem.Decode(ca.Cert)
+	keyBlock, _ := pem.Decode(ca.Key)
+	if certBlock == nil || keyBlock == nil {
+		return status.Errorf(codes.Internal, "stored entrypoint CA is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return err
+	}
+
+	s.caCert = cert
+	s.caCertPEM = ca.Cert
+	s.caKey = key
+	return nil
+}
+
+// newEntrypointCA generates a self-signed CA.
+func newEntrypointCA() (*pb.CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := certSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"Waypoint"},
+			CommonName:   "Waypoint Entrypoint CA",
+		},
+		NotBefore:             now.Add(-entrypointCertBackdate),
+		NotAfter:              now.Add(entrypointCATTL),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CertificateAuthority{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}, nil
+}
+
+// EntrypointCAPool returns a pool with the CA that entrypoint client
+// certificates are issued with. The gRPC listener verifies client
+// certificates against it.
+func (s *service) EntrypointCAPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.caCert)
+	return pool
+}
+
+func (s *service) EntrypointIssueCert(
+	ctx context.Context,
+	req *pb.EntrypointIssueCertRequest,
+) (*pb.EntrypointIssueCertResponse, error) {
+	if req.DeploymentId == "" || req.InstanceId == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"deployment ID and instance ID must be set")
+	}
+
+	// Entrypoint tokens can only get certificates for their deployment.
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) > 0 {
+		if _, body, err := s.DecodeToken(md["authorization"][0]); err == nil &&
+			body.Entrypoint != nil && body.Entrypoint.DeploymentId != req.DeploymentId {
+			return nil, status.Errorf(codes.PermissionDenied,
+				"token is not valid for deployment %q", req.DeploymentId)
+		}
+	}
+
+	if _, err := s.state.DeploymentGet(&pb.Ref_Operation{
+		Target: &pb.Ref_Operation_Id{Id: req.DeploymentId},
+	}); err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(req.Csr)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, status.Errorf(codes.InvalidArgument, "csr must be a PEM encoded certificate request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid csr: %s", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid csr signature: %s", err)
+	}
+
+	serial, err := certSerial()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "serial generation failed: %s", err)
+	}
+
+	// Only the key comes from the CSR. The subject is always set by us
+	// so that the certificate can't claim to be something else.
+	now := time.Now()
+	expire := now.Add(entrypointCertTTL)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"Waypoint"},
+			OrganizationalUnit: []string{req.DeploymentId},
+			CommonName:         req.InstanceId,
+		},
+		NotBefore:   now.Add(-entrypointCertBackdate),
+		NotAfter:    expire,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, s.caCert, csr.PublicKey, s.caKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error issuing certificate: %s", err)
+	}
+
+	expireTime, err := ptypes.TimestampProto(expire)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EntrypointIssueCertResponse{
+		Cert:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		CaCert:     s.caCertPEM,
+		ExpireTime: expireTime,
+	}, nil
+}
+
+// entrypointPeerCheck checks that a call over TLS came with a verified
+// client certificate issued for the deployment with the given ID. Calls
+// that aren't over TLS, such as from entrypoints that were configured to
+// connect without it, can't have a certificate and aren't checked.
+func entrypointPeerCheck(ctx context.Context, deploymentId string) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+
+	for _, chain := range info.State.VerifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+
+		for _, ou := range chain[0].Subject.OrganizationalUnit {
+			if ou == deploymentId {
+				return nil
+			}
+		}
+	}
+
+	return status.Errorf(codes.Unauthenticated,
+		"a client certificate for deployment %q is required", deploymentId)
+}
+
+// certSerial returns a random certificate serial number.
+func certSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
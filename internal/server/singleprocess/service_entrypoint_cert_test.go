@@ -0,0 +1,154 @@
+package singleprocess
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestServiceEntrypointIssueCert(t *testing.T) {
+	ctx := context.Background()
+
+	db := testDB(t)
+	impl, err := New(WithDB(db))
+	require.NoError(t, err)
+	client := server.TestServer(t, impl)
+
+	resp, err := client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+			Component: &pb.Component{
+				Name: "testapp",
+			},
+		}),
+	})
+	require.NoError(t, err)
+	dep := resp.Deployment
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	csrDer, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	require.NoError(t, err)
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDer})
+
+	t.Run("unknown deployment", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := client.EntrypointIssueCert(ctx, &pb.EntrypointIssueCertRequest{
+			DeploymentId: "nope",
+			InstanceId:   "i1",
+			Csr:          csr,
+		})
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid csr", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := client.EntrypointIssueCert(ctx, &pb.EntrypointIssueCertRequest{
+			DeploymentId: dep.Id,
+			InstanceId:   "i1",
+			Csr:          []byte("nope"),
+		})
+		require.Error(err)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("issues a client cert", func(t *testing.T) {
+		require := require.New(t)
+
+		certResp, err := client.EntrypointIssueCert(ctx, &pb.EntrypointIssueCertRequest{
+			DeploymentId: dep.Id,
+			InstanceId:   "i1",
+			Csr:          csr,
+		})
+		require.NoError(err)
+
+		pool := x509.NewCertPool()
+		require.True(pool.AppendCertsFromPEM(certResp.CaCert))
+
+		block, _ := pem.Decode(certResp.Cert)
+		require.NotNil(block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(err)
+		require.Equal("i1", cert.Subject.CommonName)
+		require.Equal([]string{dep.Id}, cert.Subject.OrganizationalUnit)
+
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     pool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		require.NoError(err)
+	})
+
+	t.Run("CA is kept across restarts", func(t *testing.T) {
+		require := require.New(t)
+
+		s := impl.(*service)
+		impl2, err := New(WithDB(db))
+		require.NoError(err)
+		require.Equal(s.caCertPEM, impl2.(*service).caCertPEM)
+	})
+}
+
+func TestServiceAuth_entrypointCert(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	token, err := s.NewLoginToken(DefaultKeyId, nil, &pb.Token_Entrypoint{
+		DeploymentId: "A",
+	})
+	require.NoError(err)
+
+	// tlsCtx returns a context for a call over TLS with a verified client
+	// certificate for the given deployment, or no certificate if empty.
+	tlsCtx := func(deploymentId string) context.Context {
+		var state tls.ConnectionState
+		if deploymentId != "" {
+			state.VerifiedChains = [][]*x509.Certificate{{{
+				Subject: pkix.Name{OrganizationalUnit: []string{deploymentId}},
+			}}}
+		}
+
+		return peer.NewContext(ctx, &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: state},
+		})
+	}
+
+	// Without TLS the token is enough
+	require.NoError(s.Authenticate(ctx, token, "EntrypointConfig", nil))
+
+	// With TLS the certificate of the deployment is required
+	require.NoError(s.Authenticate(tlsCtx("A"), token, "EntrypointConfig", nil))
+
+	err = s.Authenticate(tlsCtx(""), token, "EntrypointConfig", nil)
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	err = s.Authenticate(tlsCtx("B"), token, "EntrypointConfig", nil)
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	// The certificate is issued with the token alone
+	require.NoError(s.Authenticate(tlsCtx(""), token, "EntrypointIssueCert", nil))
+}
@@ -30,6 +30,14 @@ func (s *service) StartExecStream(
 	log = log.With("deployment_id", start.Start.DeploymentId)
 	log.Debug("exec requested", "args", start.Start.Args)
 
+	// Record the session if the server is configured to. Input is never
+	// recorded, only the output sent to the client.
+	rec, err := s.newExecRecorder(srv.Context(), start.Start)
+	if err != nil {
+		return err
+	}
+	defer s.saveExecRecording(log, rec)
+
 	// Create our exec. We have to populate everything here first because
 	// once we register, this will trigger any watchers to be notified of
 	// a change and the instance should try to connect to us.
@@ -103,7 +111,7 @@ func (s *service) StartExecStream(
 				return nil
 			}
 
-			exit, err := s.handleEntrypointExecRequest(log, srv, entryReq)
+			exit, err := s.handleEntrypointExecRequest(log, srv, rec, entryReq)
 			if exit || err != nil {
 				return err
 			}
@@ -114,6 +122,7 @@ func (s *service) StartExecStream(
 func (s *service) handleEntrypointExecRequest(
 	log hclog.Logger,
 	srv pb.Waypoint_StartExecStreamServer,
+	rec *execRecorder,
 	entryReq *pb.EntrypointExecRequest,
 ) (bool, error) {
 	log.Trace("event received from entrypoint", "event", entryReq.Event)
@@ -121,10 +130,12 @@ func (s *service) handleEntrypointExecRequest(
 	exit := false
 	switch event := entryReq.Event.(type) {
 	case *pb.EntrypointExecRequest_Output_:
+		channel := pb.ExecStreamResponse_Output_Channel(event.Output.Channel)
+		rec.output(channel, event.Output.Data)
 		send = &pb.ExecStreamResponse{
 			Event: &pb.ExecStreamResponse_Output_{
 				Output: &pb.ExecStreamResponse_Output{
-					Channel: pb.ExecStreamResponse_Output_Channel(event.Output.Channel),
+					Channel: channel,
 					Data:    event.Output.Data,
 				},
 			},
@@ -132,6 +143,7 @@ func (s *service) handleEntrypointExecRequest(
 
 	case *pb.EntrypointExecRequest_Exit_:
 		exit = true
+		rec.exit(event.Exit.Code)
 		send = &pb.ExecStreamResponse{
 			Event: &pb.ExecStreamResponse_Exit_{
 				Exit: &pb.ExecStreamResponse_Exit{
@@ -0,0 +1,107 @@
+package singleprocess
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// execRecordingMaxBytes is the most output that is recorded for a single
+// exec session. Output after this is dropped.
+const execRecordingMaxBytes = 1024 * 1024
+
+// execRecorder records the output of an exec session. A nil recorder
+// records nothing so callers don't need to check if recording is enabled.
+type execRecorder struct {
+	session *pb.ExecSession
+	size    int
+}
+
+// newExecRecorder returns a recorder for the exec session if exec recording
+// is enabled in the server config, or nil if it isn't.
+func (s *service) newExecRecorder(
+	ctx context.Context,
+	start *pb.ExecStreamRequest_Start,
+) (*execRecorder, error) {
+	cfg, err := s.state.ServerConfigGet()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || !cfg.ExecRecording {
+		return nil, nil
+	}
+
+	return &execRecorder{
+		session: &pb.ExecSession{
+			DeploymentId: start.DeploymentId,
+			Args:         start.Args,
+			Pty:          start.Pty != nil && start.Pty.Enable,
+			Identity:     s.tokenIdentity(ctx),
+			StartTime:    ptypes.TimestampNow(),
+		},
+	}, nil
+}
+
+// output records output sent to the client.
+func (r *execRecorder) output(channel pb.ExecStreamResponse_Output_Channel, data []byte) {
+	if r == nil || r.session.Truncated {
+		return
+	}
+
+	if r.size+len(data) > execRecordingMaxBytes {
+		r.session.Truncated = true
+		return
+	}
+	r.size += len(data)
+
+	r.session.Output = append(r.session.Output, &pb.ExecSession_Output{
+		Time:    ptypes.TimestampNow(),
+		Channel: channel,
+		Data:    data,
+	})
+}
+
+// exit records the exit code of the command.
+func (r *execRecorder) exit(code int32) {
+	if r == nil {
+		return
+	}
+
+	r.session.Exited = true
+	r.session.ExitCode = code
+}
+
+// save stores the recording when the session ends. Failing to store it is
+// logged since the session itself already happened.
+func (s *service) saveExecRecording(log hclog.Logger, r *execRecorder) {
+	if r == nil {
+		return
+	}
+
+	r.session.EndTime = ptypes.TimestampNow()
+	if err := s.state.ExecSessionAppend(r.session); err != nil {
+		log.Error("error recording exec session", "err", err)
+	}
+}
+
+func (s *service) ListExecSessions(
+	ctx context.Context,
+	req *pb.ListExecSessionsRequest,
+) (*pb.ListExecSessionsResponse, error) {
+	sessions, err := s.state.ExecSessionList(req.DeploymentId, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListExecSessionsResponse{Sessions: sessions}, nil
+}
+
+func (s *service) GetExecSession(
+	ctx context.Context,
+	req *pb.GetExecSessionRequest,
+) (*pb.ExecSession, error) {
+	return s.state.ExecSessionGet(req.Index)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -228,6 +229,163 @@ func TestServiceStartExecStream_entrypointEventChClose(t *testing.T) {
 	require.False(active)
 }
 
+func TestServiceStartExecStream_recording(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server with recording enabled
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	require.NoError(testServiceImpl(impl).state.ServerConfigSet(&pb.ServerConfig{
+		ExecRecording: true,
+	}))
+
+	// Create an instance
+	instanceId, deploymentId, closer := TestEntrypoint(t, client)
+	defer closer()
+
+	// Start stream
+	stream, err := client.StartExecStream(ctx)
+	require.NoError(err)
+	require.NoError(stream.Send(&pb.ExecStreamRequest{
+		Event: &pb.ExecStreamRequest_Start_{
+			Start: &pb.ExecStreamRequest_Start{
+				DeploymentId: deploymentId,
+				Args:         []string{"foo", "bar"},
+			},
+		},
+	}))
+	defer stream.CloseSend()
+
+	// Should open
+	{
+		resp, err := stream.Recv()
+		require.NoError(err)
+		_, ok := resp.Event.(*pb.ExecStreamResponse_Open_)
+		require.True(ok, "should be an open")
+	}
+
+	exec := testGetInstanceExec(t, impl, instanceId)
+
+	// Input is not recorded
+	require.NoError(stream.Send(&pb.ExecStreamRequest{
+		Event: &pb.ExecStreamRequest_Input_{
+			Input: &pb.ExecStreamRequest_Input{Data: []byte("secret")},
+		},
+	}))
+	<-exec.ClientEventCh
+
+	// Output and the exit code are
+	exec.EntrypointEventCh <- &pb.EntrypointExecRequest{
+		Event: &pb.EntrypointExecRequest_Output_{
+			Output: &pb.EntrypointExecRequest_Output{
+				Channel: pb.EntrypointExecRequest_Output_STDOUT,
+				Data:    []byte("hello"),
+			},
+		},
+	}
+	exec.EntrypointEventCh <- &pb.EntrypointExecRequest{
+		Event: &pb.EntrypointExecRequest_Exit_{
+			Exit: &pb.EntrypointExecRequest_Exit{
+				Code: 2,
+			},
+		},
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+	}
+
+	// The session is saved when the stream ends
+	var list *pb.ListExecSessionsResponse
+	require.Eventually(func() bool {
+		list, err = client.ListExecSessions(ctx, &pb.ListExecSessionsRequest{
+			DeploymentId: deploymentId,
+		})
+		require.NoError(err)
+		return len(list.Sessions) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(list.Sessions[0].Output)
+
+	session, err := client.GetExecSession(ctx, &pb.GetExecSessionRequest{
+		Index: list.Sessions[0].Index,
+	})
+	require.NoError(err)
+	require.Equal([]string{"foo", "bar"}, session.Args)
+	require.True(session.Exited)
+	require.Equal(int32(2), session.ExitCode)
+	require.Len(session.Output, 1)
+	require.Equal([]byte("hello"), session.Output[0].Data)
+	require.Equal(pb.ExecStreamResponse_Output_STDOUT, session.Output[0].Channel)
+}
+
+func TestServiceExecSession_scopedToken(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := testServiceImpl(impl)
+
+	// Record a session for a deployment of two applications
+	record := func(app string) *pb.ExecSession {
+		resp, err := impl.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+			Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+				Application: &pb.Ref_Application{Project: "p", Application: app},
+			}),
+		})
+		require.NoError(err)
+
+		session := &pb.ExecSession{DeploymentId: resp.Deployment.Id}
+		require.NoError(s.state.ExecSessionAppend(session))
+		return session
+	}
+	sessionA := record("a")
+	sessionB := record("b")
+
+	// Token for only application "a"
+	resp, err := impl.GenerateLoginToken(ctx, &pb.LoginTokenRequest{
+		Applications: []*pb.Ref_Application{{Project: "p", Application: "a"}},
+	})
+	require.NoError(err)
+
+	authorize := func(endpoint string, req interface{}) error {
+		scope, err := s.ResolveScope(ctx, req)
+		require.NoError(err)
+		return s.AuthorizeScope(ctx, resp.Token, endpoint, []string{"readonly"}, scope)
+	}
+
+	// Sessions of the application are visible
+	require.NoError(authorize("ListExecSessions", &pb.ListExecSessionsRequest{
+		DeploymentId: sessionA.DeploymentId,
+	}))
+	require.NoError(authorize("GetExecSession", &pb.GetExecSessionRequest{
+		Index: sessionA.Index,
+	}))
+
+	// Sessions of other applications, or of all of them, are not
+	for _, err := range []error{
+		authorize("ListExecSessions", &pb.ListExecSessionsRequest{
+			DeploymentId: sessionB.DeploymentId,
+		}),
+		authorize("ListExecSessions", &pb.ListExecSessionsRequest{}),
+		authorize("GetExecSession", &pb.GetExecSessionRequest{
+			Index: sessionB.Index,
+		}),
+		authorize("GetExecSession", &pb.GetExecSessionRequest{
+			Index: sessionB.Index + 1,
+		}),
+	} {
+		require.Error(err)
+		require.Equal(codes.PermissionDenied, status.Code(err))
+	}
+}
+
 func testGetInstanceExec(t *testing.T, impl pb.WaypointServer, instanceId string) *state.InstanceExec {
 	ws := memdb.NewWatchSet()
 	list, err := testServiceImpl(impl).state.InstanceExecListByInstanceId(instanceId, ws)
@@ -0,0 +1,44 @@
+package singleprocess
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func (s *service) UpsertHealthReport(
+	ctx context.Context,
+	req *pb.UpsertHealthReportRequest,
+) (*pb.UpsertHealthReportResponse, error) {
+	result := req.Report
+
+	// If we have no ID, then we're inserting and need to generate an ID.
+	insert := result.Id == ""
+	if insert {
+		// Get the next id
+		id, err := server.Id()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+		}
+
+		// Specify the id
+		result.Id = id
+	}
+
+	if err := s.state.HealthReportPut(!insert, result); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpsertHealthReportResponse{Report: result}, nil
+}
+
+func (s *service) GetLatestHealthReport(
+	ctx context.Context,
+	req *pb.GetLatestHealthReportRequest,
+) (*pb.HealthReport, error) {
+	return s.state.HealthReportLatest(req.Application, req.Workspace)
+}
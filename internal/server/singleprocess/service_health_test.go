@@ -0,0 +1,65 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceHealthReport(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	ref := &pb.Ref_Application{Application: "a_test", Project: "p_test"}
+	ws := &pb.Ref_Workspace{Workspace: "default"}
+
+	// Nothing yet
+	_, err = client.GetLatestHealthReport(ctx, &pb.GetLatestHealthReportRequest{
+		Application: ref,
+		Workspace:   ws,
+	})
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// A check that is still running isn't the latest
+	st := server.NewStatus(pb.Status_RUNNING)
+	resp, err := client.UpsertHealthReport(ctx, &pb.UpsertHealthReportRequest{
+		Report: &pb.HealthReport{
+			Application:  ref,
+			Workspace:    ws,
+			DeploymentId: "d1",
+			Status:       st,
+		},
+	})
+	require.NoError(err)
+	report := resp.Report
+	require.NotEmpty(report.Id)
+
+	// Complete it
+	server.StatusSetSuccess(report.Status)
+	report.Health = pb.HealthReport_UNHEALTHY
+	report.Message = "connection refused"
+	_, err = client.UpsertHealthReport(ctx, &pb.UpsertHealthReportRequest{
+		Report: report,
+	})
+	require.NoError(err)
+
+	latest, err := client.GetLatestHealthReport(ctx, &pb.GetLatestHealthReportRequest{
+		Application: ref,
+		Workspace:   ws,
+	})
+	require.NoError(err)
+	require.Equal(report.Id, latest.Id)
+	require.Equal(pb.HealthReport_UNHEALTHY, latest.Health)
+	require.Equal("connection refused", latest.Message)
+}
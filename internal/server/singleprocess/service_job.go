@@ -2,6 +2,8 @@ package singleprocess
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/waypoint/internal/server/logbuffer"
 	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 // TODO: test
@@ -34,8 +37,7 @@ func (s *service) GetJob(
 	return job.Job, nil
 }
 
-// TODO: test
-func (s *service) XListJobs(
+func (s *service) ListJobs(
 	ctx context.Context,
 	req *pb.ListJobsRequest,
 ) (*pb.ListJobsResponse, error) {
@@ -44,8 +46,63 @@ func (s *service) XListJobs(
 		return nil, err
 	}
 
+	states := map[pb.Job_State]struct{}{}
+	for _, st := range req.States {
+		states[st] = struct{}{}
+	}
+
+	// Scoped tokens only see the jobs of their projects and applications.
+	scoped := s.scopedToken(ctx)
+
+	var result []*pb.Job
+	for _, job := range jobs {
+		if scoped != nil && (job.Application == nil ||
+			!tokenAllowsScope(scoped, applicationScope(job.Application), false)) {
+			continue
+		}
+		if req.Project != nil &&
+			!strings.EqualFold(job.Application.GetProject(), req.Project.Project) {
+			continue
+		}
+		if req.Application != nil &&
+			(!strings.EqualFold(job.Application.GetProject(), req.Application.Project) ||
+				!strings.EqualFold(job.Application.GetApplication(), req.Application.Application)) {
+			continue
+		}
+		if req.Workspace != nil &&
+			!strings.EqualFold(job.Workspace.GetWorkspace(), req.Workspace.Workspace) {
+			continue
+		}
+		if len(states) > 0 {
+			if _, ok := states[job.State]; !ok {
+				continue
+			}
+		}
+		if req.AssignedRunner != nil &&
+			job.AssignedRunner.GetId() != req.AssignedRunner.Id {
+			continue
+		}
+
+		result = append(result, job)
+	}
+
+	// Newest first. Job IDs are ordered by creation so they break ties.
+	sort.Slice(result, func(i, j int) bool {
+		ti, _ := ptypes.Timestamp(result[i].QueueTime)
+		tj, _ := ptypes.Timestamp(result[j].QueueTime)
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+
+		return result[i].Id > result[j].Id
+	})
+
+	if req.Limit > 0 && len(result) > int(req.Limit) {
+		result = result[:req.Limit]
+	}
+
 	return &pb.ListJobsResponse{
-		Jobs: jobs,
+		Jobs: result,
 	}, nil
 }
 
@@ -60,6 +117,17 @@ func (s *service) CancelJob(
 	return &empty.Empty{}, nil
 }
 
+func (s *service) ApproveJob(
+	ctx context.Context,
+	req *pb.ApproveJobRequest,
+) (*empty.Empty, error) {
+	if err := s.state.JobApprove(req.JobId); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (s *service) QueueJob(
 	ctx context.Context,
 	req *pb.QueueJobRequest,
@@ -114,6 +182,9 @@ func (s *service) QueueJob(
 	}
 	job.Id = id
 
+	// The runner continues the trace of this request.
+	job.TraceContext = telemetry.InjectMap(ctx)
+
 	// Validate expiry if we have one
 	job.ExpireTime = nil
 	if req.ExpiresIn != "" {
@@ -129,11 +200,48 @@ func (s *service) QueueJob(
 		}
 	}
 
+	// If the job requires approval it waits for it before being queued.
+	job.State = pb.Job_UNKNOWN
+	job.ApprovalExpireTime = nil
+	job.ApproveTime = nil
+	if req.RequireApproval {
+		job.State = pb.Job_WAITING_APPROVAL
+
+		if req.ApprovalTimeout != "" {
+			dur, err := time.ParseDuration(req.ApprovalTimeout)
+			if err != nil {
+				return nil, status.Errorf(codes.FailedPrecondition,
+					"Invalid approval timeout: %s", err.Error())
+			}
+
+			job.ApprovalExpireTime, err = ptypes.TimestampProto(time.Now().Add(dur))
+			if err != nil {
+				return nil, status.Errorf(codes.Aborted, "error configuring approval timeout: %s", err)
+			}
+		}
+	}
+
+	// If no runner can run the job, launch one for it if we can.
+	log := hclog.FromContext(ctx)
+	odr, err := s.launchOnDemandRunner(ctx, log, job)
+	if err != nil {
+		return nil, err
+	}
+
 	// Queue the job
 	if err := s.state.JobCreate(job); err != nil {
+		if odr != nil {
+			go s.stopOnDemandRunner(log, odr, job.Id)
+		}
+
 		return nil, err
 	}
 
+	// The on-demand runner is stopped once the job completes.
+	if odr != nil {
+		go s.stopOnDemandRunner(log, odr, job.Id)
+	}
+
 	return &pb.QueueJobResponse{JobId: job.Id}, nil
 }
 
@@ -269,7 +377,7 @@ func (s *service) GetJobStream(
 			}
 
 			switch job.State {
-			case pb.Job_SUCCESS, pb.Job_ERROR:
+			case pb.Job_SUCCESS, pb.Job_ERROR, pb.Job_TIMED_OUT:
 				// TODO(mitchellh): we should drain the output buffer
 
 				// Job is done. For success, error will be nil, so this
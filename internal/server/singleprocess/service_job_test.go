@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
@@ -65,6 +67,128 @@ func TestServiceQueueJob(t *testing.T) {
 			return job.State == pb.Job_ERROR && job.CancelTime != nil
 		}, 2*time.Second, 10*time.Millisecond)
 	})
+
+	t.Run("require approval", func(t *testing.T) {
+		require := require.New(t)
+
+		resp, err := client.QueueJob(ctx, &Req{
+			Job:             serverptypes.TestJobNew(t, nil),
+			RequireApproval: true,
+			ApprovalTimeout: "1h",
+		})
+		require.NoError(err)
+
+		// Job should be waiting for approval
+		job, err := testServiceImpl(impl).state.JobById(resp.JobId, nil)
+		require.NoError(err)
+		require.Equal(pb.Job_WAITING_APPROVAL, job.State)
+		require.NotNil(job.ApprovalExpireTime)
+
+		// Approve it
+		_, err = client.ApproveJob(ctx, &pb.ApproveJobRequest{JobId: resp.JobId})
+		require.NoError(err)
+
+		job, err = testServiceImpl(impl).state.JobById(resp.JobId, nil)
+		require.NoError(err)
+		require.Equal(pb.Job_QUEUED, job.State)
+	})
+
+	t.Run("invalid approval timeout", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := client.QueueJob(ctx, &Req{
+			Job:             serverptypes.TestJobNew(t, nil),
+			RequireApproval: true,
+			ApprovalTimeout: "soon",
+		})
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("trace context", func(t *testing.T) {
+		require := require.New(t)
+
+		// The trace of the client is continued by the server and stored
+		// on the job for the runner.
+		traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+		ctx := metadata.AppendToOutgoingContext(ctx,
+			"traceparent", "00-"+traceId+"-00f067aa0ba902b7-01")
+		resp, err := client.QueueJob(ctx, &Req{
+			Job: serverptypes.TestJobNew(t, nil),
+		})
+		require.NoError(err)
+
+		job, err := testServiceImpl(impl).state.JobById(resp.JobId, nil)
+		require.NoError(err)
+		require.Contains(job.TraceContext["traceparent"], traceId)
+	})
+}
+
+func TestServiceListJobs(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// Initialize our app
+	TestApp(t, client, serverptypes.TestJobNew(t, nil).Application)
+
+	// Queue two jobs in different workspaces
+	var ids []string
+	for _, ws := range []string{"w_test", "other"} {
+		resp, err := client.QueueJob(ctx, &pb.QueueJobRequest{
+			Job: serverptypes.TestJobNew(t, &pb.Job{
+				Workspace: &pb.Ref_Workspace{Workspace: ws},
+			}),
+		})
+		require.NoError(err)
+		ids = append(ids, resp.JobId)
+	}
+
+	// All jobs, newest first
+	resp, err := client.ListJobs(ctx, &pb.ListJobsRequest{})
+	require.NoError(err)
+	require.Len(resp.Jobs, 2)
+	require.Equal(ids[1], resp.Jobs[0].Id)
+	require.Equal(ids[0], resp.Jobs[1].Id)
+
+	// By workspace
+	resp, err = client.ListJobs(ctx, &pb.ListJobsRequest{
+		Workspace: &pb.Ref_Workspace{Workspace: "w_test"},
+	})
+	require.NoError(err)
+	require.Len(resp.Jobs, 1)
+	require.Equal(ids[0], resp.Jobs[0].Id)
+
+	// By project
+	resp, err = client.ListJobs(ctx, &pb.ListJobsRequest{
+		Project: &pb.Ref_Project{Project: "nope"},
+	})
+	require.NoError(err)
+	require.Empty(resp.Jobs)
+
+	// By state
+	resp, err = client.ListJobs(ctx, &pb.ListJobsRequest{
+		States: []pb.Job_State{pb.Job_SUCCESS},
+	})
+	require.NoError(err)
+	require.Empty(resp.Jobs)
+
+	// Limit
+	resp, err = client.ListJobs(ctx, &pb.ListJobsRequest{Limit: 1})
+	require.NoError(err)
+	require.Len(resp.Jobs, 1)
+	require.Equal(ids[1], resp.Jobs[0].Id)
+
+	// By assigned runner
+	resp, err = client.ListJobs(ctx, &pb.ListJobsRequest{
+		AssignedRunner: &pb.Ref_RunnerId{Id: "R_A"},
+	})
+	require.NoError(err)
+	require.Empty(resp.Jobs)
 }
 
 func TestServiceValidateJob(t *testing.T) {
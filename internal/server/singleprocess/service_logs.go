@@ -12,8 +12,14 @@ import (
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
-// defaultLogLimitBacklog is the default backlog amount to send down.
-const defaultLogLimitBacklog = 100
+const (
+	// defaultLogLimitBacklog is the default backlog amount to send down.
+	defaultLogLimitBacklog = 100
+
+	// logHistoryBatchSize is the most lines sent in each batch of stored
+	// logs.
+	logHistoryBatchSize = 64
+)
 
 // TODO: test
 func (s *service) GetLogStream(
@@ -27,6 +33,16 @@ func (s *service) GetLogStream(
 		req.LimitBacklog = defaultLogLimitBacklog
 	}
 
+	filter, err := newLogFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	// History reads the stored logs instead of tailing
+	if req.History {
+		return s.getLogHistory(req, filter, srv)
+	}
+
 	var instanceFunc func(ws memdb.WatchSet) ([]*state.Instance, error)
 	switch scope := req.Scope.(type) {
 	case *pb.GetLogStreamRequest_DeploymentId:
@@ -85,6 +101,9 @@ func (s *service) GetLogStream(
 		for _, record := range records {
 			instanceId := record.Id
 			deploymentId := record.DeploymentId
+			if !filter.matchInstance(instanceId) {
+				continue
+			}
 
 			// If we already have a reader for this, then do nothing.
 			instanceSetLock.Lock()
@@ -119,6 +138,11 @@ func (s *service) GetLogStream(
 						lines[i] = v.(*pb.LogBatch_Entry)
 					}
 
+					lines = filter.filter(lines)
+					if len(lines) == 0 {
+						continue
+					}
+
 					instanceLog.Trace("sending instance log data", "entries", len(entries))
 					srv.Send(&pb.LogBatch{
 						DeploymentId: deploymentId,
@@ -140,3 +164,120 @@ func (s *service) GetLogStream(
 		}
 	}
 }
+
+// getLogHistory sends the stored logs for the scope of the request, oldest
+// first. Consecutive lines from the same instance are sent in one batch.
+func (s *service) getLogHistory(
+	req *pb.GetLogStreamRequest,
+	filter *logFilter,
+	srv pb.Waypoint_GetLogStreamServer,
+) error {
+	var ref *pb.Ref_Application
+	var ws *pb.Ref_Workspace
+	var deploymentId string
+	switch scope := req.Scope.(type) {
+	case *pb.GetLogStreamRequest_DeploymentId:
+		d, err := s.state.DeploymentGet(&pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: scope.DeploymentId},
+		})
+		if err != nil {
+			return err
+		}
+
+		ref, ws, deploymentId = d.Application, d.Workspace, d.Id
+
+	case *pb.GetLogStreamRequest_Application_:
+		if scope.Application == nil ||
+			scope.Application.Application == nil ||
+			scope.Application.Workspace == nil {
+			return status.Errorf(
+				codes.FailedPrecondition,
+				"application scope requires the application and workspace fields to be set",
+			)
+		}
+
+		ref, ws = scope.Application.Application, scope.Application.Workspace
+
+	default:
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"invalid scope supplied: %T",
+			req.Scope,
+		)
+	}
+
+	var limit uint32
+	if req.LimitBacklog > 0 {
+		limit = uint32(req.LimitBacklog)
+	}
+
+	// With a filter the limit applies to the matching lines so we have to
+	// read them all.
+	listLimit := limit
+	if filter != nil {
+		listLimit = 0
+	}
+
+	lines, err := s.state.InstanceLogList(ref, ws, deploymentId, listLimit)
+	if err != nil {
+		return err
+	}
+
+	if filter != nil {
+		matched := lines[:0]
+		for _, l := range lines {
+			if filter.matchInstance(l.InstanceId) && filter.match(l.Lines[0]) {
+				matched = append(matched, l)
+			}
+		}
+
+		lines = matched
+		if limit > 0 && len(lines) > int(limit) {
+			lines = lines[len(lines)-int(limit):]
+		}
+	}
+
+	var batch *pb.LogBatch
+	for _, l := range lines {
+		if batch != nil &&
+			(batch.InstanceId != l.InstanceId || len(batch.Lines) >= logHistoryBatchSize) {
+			if err := srv.Send(batch); err != nil {
+				return err
+			}
+
+			batch = nil
+		}
+
+		if batch == nil {
+			batch = &pb.LogBatch{
+				DeploymentId: l.DeploymentId,
+				InstanceId:   l.InstanceId,
+			}
+		}
+
+		batch.Lines = append(batch.Lines, l.Lines...)
+	}
+	if batch != nil {
+		return srv.Send(batch)
+	}
+
+	return nil
+}
+
+// logRetention returns the number of log lines that are stored for the
+// app. Zero means logs aren't stored.
+func (s *service) logRetention(project, app string) (uint32, error) {
+	cfg, err := s.state.ServerConfigGet()
+	if err != nil {
+		return 0, err
+	}
+	if cfg == nil {
+		return 0, nil
+	}
+
+	if v, ok := cfg.AppLogRetention[project+"/"+app]; ok {
+		return v, nil
+	}
+
+	return cfg.LogRetention, nil
+}
@@ -2,6 +2,7 @@ package singleprocess
 
 import (
 	"context"
+	"io"
 	"strconv"
 	"testing"
 	"time"
@@ -158,3 +159,88 @@ func TestServiceGetLogStream_byApp(t *testing.T) {
 	require.NotEmpty(batch.Lines)
 	require.Len(batch.Lines, 25)
 }
+
+func TestServiceGetLogStream_history(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server that stores the last 10 lines
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	require.NoError(testServiceImpl(impl).state.ServerConfigSet(&pb.ServerConfig{
+		LogRetention: 10,
+	}))
+
+	// Register our instances
+	resp, err := client.UpsertDeployment(ctx, &pb.UpsertDeploymentRequest{
+		Deployment: serverptypes.TestValidDeployment(t, &pb.Deployment{
+			Component: &pb.Component{
+				Name: "testapp",
+			},
+		}),
+	})
+	require.NoError(err)
+
+	dep := resp.Deployment
+	configClient, err := client.EntrypointConfig(ctx, &pb.EntrypointConfigRequest{
+		DeploymentId: dep.Id,
+		InstanceId:   "1",
+	})
+	require.NoError(err)
+	_, err = configClient.Recv()
+	require.NoError(err)
+
+	// Send some log messages
+	logSendClient, err := client.EntrypointLogStream(ctx)
+	require.NoError(err)
+	for i := 0; i < 25; i++ {
+		require.NoError(logSendClient.Send(&pb.EntrypointLogBatch{
+			InstanceId: "1",
+			Lines: []*pb.LogBatch_Entry{
+				{Line: strconv.Itoa(i)},
+			},
+		}))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// Only the retained lines are returned and the stream ends
+	logRecvClient, err := client.GetLogStream(ctx, &pb.GetLogStreamRequest{
+		Scope: &pb.GetLogStreamRequest_DeploymentId{
+			DeploymentId: dep.Id,
+		},
+		History:      true,
+		LimitBacklog: -1,
+	})
+	require.NoError(err)
+
+	batch, err := logRecvClient.Recv()
+	require.NoError(err)
+	require.Len(batch.Lines, 10)
+	require.Equal("15", batch.Lines[0].Line)
+	require.Equal("24", batch.Lines[9].Line)
+	require.Equal(dep.Id, batch.DeploymentId)
+
+	_, err = logRecvClient.Recv()
+	require.Equal(io.EOF, err)
+
+	// The limit applies to the lines matching the filter
+	logRecvClient, err = client.GetLogStream(ctx, &pb.GetLogStreamRequest{
+		Scope: &pb.GetLogStreamRequest_DeploymentId{
+			DeploymentId: dep.Id,
+		},
+		History:      true,
+		LimitBacklog: 3,
+		Filter: &pb.GetLogStreamRequest_Filter{
+			Pattern:    "^1",
+			InstanceId: "1",
+		},
+	})
+	require.NoError(err)
+
+	batch, err = logRecvClient.Recv()
+	require.NoError(err)
+	require.Len(batch.Lines, 3)
+	require.Equal("17", batch.Lines[0].Line)
+	require.Equal("19", batch.Lines[2].Line)
+}
@@ -0,0 +1,27 @@
+package singleprocess
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var entrypointConnsDesc = prometheus.NewDesc(
+	"waypoint_entrypoint_connections",
+	"Number of entrypoints that are connected to the server.",
+	nil, nil,
+)
+
+// Describe implements prometheus.Collector so that the server metrics
+// endpoint includes the service and state metrics.
+func (s *service) Describe(ch chan<- *prometheus.Desc) {
+	ch <- entrypointConnsDesc
+	s.state.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (s *service) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(entrypointConnsDesc, prometheus.GaugeValue,
+		float64(atomic.LoadInt64(&s.entrypointConns)))
+	s.state.Collect(ch)
+}
@@ -0,0 +1,249 @@
+package singleprocess
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/datasource"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// pollDefaultInterval is the poll interval for projects that don't
+	// set one.
+	pollDefaultInterval = 30 * time.Second
+
+	// pollMinInterval is the shortest poll interval that can be set.
+	pollMinInterval = 5 * time.Second
+
+	// pollTimeout is how long a single poll of a data source can take.
+	pollTimeout = time.Minute
+)
+
+// validateProjectPoll validates the poll settings of a project that is
+// being upserted.
+func validateProjectPoll(p *pb.Project) error {
+	poll := p.DataSourcePoll
+	if poll == nil || !poll.Enabled {
+		return nil
+	}
+
+	if _, ok := p.DataSource.GetSource().(*pb.Job_DataSource_Git); !ok {
+		return status.Errorf(codes.FailedPrecondition,
+			"polling requires the project to have a git data source")
+	}
+
+	if _, err := pollInterval(poll); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pollInterval returns the interval between polls.
+func pollInterval(poll *pb.Project_Poll) (time.Duration, error) {
+	if poll.Interval == "" {
+		return pollDefaultInterval, nil
+	}
+
+	dur, err := time.ParseDuration(poll.Interval)
+	if err != nil {
+		return 0, status.Errorf(codes.FailedPrecondition,
+			"invalid poll interval: %s", err)
+	}
+	if dur < pollMinInterval {
+		return 0, status.Errorf(codes.FailedPrecondition,
+			"poll interval must be at least %s", pollMinInterval)
+	}
+
+	return dur, nil
+}
+
+// armProjectPolls sets timers for all projects with polling enabled. This
+// is called at startup.
+func (s *service) armProjectPolls(log hclog.Logger) error {
+	refs, err := s.state.ProjectList()
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		p, err := s.state.ProjectGet(ref)
+		if err != nil {
+			return err
+		}
+
+		s.armProjectPoll(log, p)
+	}
+
+	return nil
+}
+
+// armProjectPoll sets the timer for the next poll of the project. Any
+// timer that is already set for the project is stopped first so that
+// changing the settings takes effect right away.
+func (s *service) armProjectPoll(log hclog.Logger, p *pb.Project) {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	// Close stops the timers while holding the lock so we check this
+	// with the lock held.
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return
+	}
+
+	if t, ok := s.pollTimers[p.Name]; ok {
+		t.Stop()
+		delete(s.pollTimers, p.Name)
+	}
+
+	poll := p.DataSourcePoll
+	if poll == nil || !poll.Enabled {
+		return
+	}
+
+	dur, err := pollInterval(poll)
+	if err != nil {
+		log.Warn("invalid poll interval, not polling", "project", p.Name, "err", err)
+		return
+	}
+
+	if s.pollTimers == nil {
+		s.pollTimers = map[string]*time.Timer{}
+	}
+
+	name := p.Name
+	s.pollTimers[name] = time.AfterFunc(dur, func() {
+		// The timer may have fired just as the service was closed.
+		if atomic.LoadInt32(&s.closed) != 0 {
+			return
+		}
+
+		if err := s.pollProject(log, name); err != nil {
+			log.Warn("error polling project data source", "project", name, "err", err)
+		}
+
+		// Get the project again in case the settings changed during the
+		// poll. If it was deleted then we stop polling.
+		p, err := s.state.ProjectGet(&pb.Ref_Project{Project: name})
+		if err != nil {
+			return
+		}
+
+		s.armProjectPoll(log, p)
+	})
+}
+
+// pollProject checks the data source of the project for a new commit and
+// queues an up job for each application if there is one. The first poll
+// only records the current commit.
+func (s *service) pollProject(log hclog.Logger, name string) error {
+	ref := &pb.Ref_Project{Project: name}
+	p, err := s.state.ProjectGet(ref)
+	if err != nil {
+		return err
+	}
+	if p.DataSourcePoll == nil || !p.DataSourcePoll.Enabled {
+		return nil
+	}
+	if _, ok := p.DataSource.GetSource().(*pb.Job_DataSource_Git); !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	latest := s.pollLatestCommit
+	if latest == nil {
+		latest = (&datasource.GitSource{}).LatestCommit
+	}
+	commit, err := latest(ctx, p.DataSource)
+	if err != nil {
+		return err
+	}
+
+	st, err := s.state.ProjectPollStateGet(ref)
+	if err != nil {
+		return err
+	}
+
+	previous := st.Commit
+	st.Commit = commit
+	st.PollTime = ptypes.TimestampNow()
+	if err := s.state.ProjectPollStatePut(st); err != nil {
+		return err
+	}
+
+	if previous == "" || previous == commit {
+		return nil
+	}
+
+	log.Info("new commit found, queueing up jobs", "project", name, "commit", commit)
+	_, err = s.queueUpJobs(ctx, p, commit, map[string]string{
+		"waypoint/poll-commit": commit,
+	})
+	return err
+}
+
+// queueUpJobs queues an up job for every application of the project using
+// the given commit of the project's git data source. This returns the IDs
+// of the queued jobs.
+func (s *service) queueUpJobs(
+	ctx context.Context,
+	p *pb.Project,
+	commit string,
+	labels map[string]string,
+) ([]string, error) {
+	if _, ok := p.DataSource.GetSource().(*pb.Job_DataSource_Git); !ok {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"project %q does not have a git data source", p.Name)
+	}
+
+	// Jobs use the exact commit so that every app gets the same source
+	// even if more commits land while they're queued.
+	source := proto.Clone(p.DataSource).(*pb.Job_DataSource)
+	git := source.Source.(*pb.Job_DataSource_Git).Git
+	vcs := &pb.Operation_VCS{
+		Commit:    commit,
+		Branch:    git.Ref,
+		RemoteUrl: datasource.GitRemoteRedact(git.Url),
+	}
+	git.Ref = commit
+
+	var result []string
+	for _, app := range p.Applications {
+		resp, err := s.QueueJob(ctx, &pb.QueueJobRequest{
+			Job: &pb.Job{
+				Application: &pb.Ref_Application{
+					Project:     p.Name,
+					Application: app.Name,
+				},
+				Workspace: &pb.Ref_Workspace{Workspace: "default"},
+				TargetRunner: &pb.Ref_Runner{
+					Target: &pb.Ref_Runner_Any{Any: &pb.Ref_RunnerAny{}},
+				},
+				DataSource: source,
+				Labels:     labels,
+				Vcs:        vcs,
+				Operation:  &pb.Job_Up{Up: &pb.Job_UpOp{}},
+
+				// Jobs that operators wait on go before polling jobs
+				Priority: pb.Job_LOW,
+			},
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, resp.JobId)
+	}
+
+	return result, nil
+}
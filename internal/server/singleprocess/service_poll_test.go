@@ -0,0 +1,120 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServicePollProject(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// Use a fake latest commit rather than git
+	s := impl.(*service)
+	commit := "a"
+	s.pollLatestCommit = func(context.Context, *pb.Job_DataSource) (string, error) {
+		return commit, nil
+	}
+
+	// A long interval so that only our manual polls happen
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{
+			Name: "p_test",
+			DataSource: &pb.Job_DataSource{
+				Source: &pb.Job_DataSource_Git{
+					Git: &pb.Job_Git{Url: "https://example.com/repo.git"},
+				},
+			},
+			DataSourcePoll: &pb.Project_Poll{Enabled: true, Interval: "1h"},
+		},
+	})
+	require.NoError(err)
+	_, err = client.UpsertApplication(ctx, &pb.UpsertApplicationRequest{
+		Project: &pb.Ref_Project{Project: "p_test"},
+		Name:    "a_test",
+	})
+	require.NoError(err)
+
+	listJobs := func() []*pb.Job {
+		resp, err := client.ListJobs(ctx, &pb.ListJobsRequest{
+			Project: &pb.Ref_Project{Project: "p_test"},
+		})
+		require.NoError(err)
+		return resp.Jobs
+	}
+
+	// The first poll only records the commit
+	require.NoError(s.pollProject(hclog.L(), "p_test"))
+	require.Empty(listJobs())
+
+	// Polling the same commit does nothing
+	require.NoError(s.pollProject(hclog.L(), "p_test"))
+	require.Empty(listJobs())
+
+	// A new commit queues an up job for that commit
+	commit = "b"
+	require.NoError(s.pollProject(hclog.L(), "p_test"))
+	jobs := listJobs()
+	require.Len(jobs, 1)
+	require.IsType(&pb.Job_Up{}, jobs[0].Operation)
+	require.Equal("a_test", jobs[0].Application.Application)
+	require.Equal("b", jobs[0].DataSource.Source.(*pb.Job_DataSource_Git).Git.Ref)
+}
+
+func TestServiceUpsertProject_pollValidation(t *testing.T) {
+	ctx := context.Background()
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(t, err)
+	client := server.TestServer(t, impl)
+
+	cases := []struct {
+		Name    string
+		Project *pb.Project
+	}{
+		{
+			"no git data source",
+			&pb.Project{
+				Name: "p_test",
+				DataSource: &pb.Job_DataSource{
+					Source: &pb.Job_DataSource_Local{Local: &pb.Job_Local{}},
+				},
+				DataSourcePoll: &pb.Project_Poll{Enabled: true},
+			},
+		},
+		{
+			"short interval",
+			&pb.Project{
+				Name: "p_test",
+				DataSource: &pb.Job_DataSource{
+					Source: &pb.Job_DataSource_Git{Git: &pb.Job_Git{Url: "foo"}},
+				},
+				DataSourcePoll: &pb.Project_Poll{Enabled: true, Interval: "1s"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, err := client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+				Project: tt.Project,
+			})
+			require.Error(err)
+			require.Equal(codes.FailedPrecondition, status.Code(err))
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
 
+	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
@@ -15,10 +17,16 @@ func (s *service) UpsertProject(
 	req *pb.UpsertProjectRequest,
 ) (*pb.UpsertProjectResponse, error) {
 	result := req.Project
+	if err := validateProjectPoll(result); err != nil {
+		return nil, err
+	}
 	if err := s.state.ProjectPut(result); err != nil {
 		return nil, err
 	}
 
+	// Start, change, or stop polling for the project.
+	s.armProjectPoll(hclog.FromContext(ctx), result)
+
 	return &pb.UpsertProjectResponse{Project: result}, nil
 }
 
@@ -35,6 +43,18 @@ func (s *service) GetProject(
 	return &pb.GetProjectResponse{Project: result}, nil
 }
 
+func (s *service) GetProjectConfig(
+	ctx context.Context,
+	req *pb.GetProjectConfigRequest,
+) (*pb.GetProjectConfigResponse, error) {
+	result, err := s.state.ProjectGet(req.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetProjectConfigResponse{WaypointHcl: result.WaypointHcl}, nil
+}
+
 // TODO: test
 func (s *service) ListProjects(
 	ctx context.Context,
@@ -45,6 +65,18 @@ func (s *service) ListProjects(
 		return nil, err
 	}
 
+	// Scoped tokens only see their projects.
+	if scoped := s.scopedToken(ctx); scoped != nil {
+		allowed := result[:0]
+		for _, p := range result {
+			if tokenAllowsScope(scoped, &server.Scope{Project: p.Project}, false) {
+				allowed = append(allowed, p)
+			}
+		}
+
+		result = allowed
+	}
+
 	return &pb.ListProjectsResponse{Projects: result}, nil
 }
 
@@ -0,0 +1,44 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceGetProjectConfig(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	client := TestServer(t)
+	ref := &pb.Ref_Project{Project: "p_test"}
+
+	// Unknown project
+	_, err := client.GetProjectConfig(ctx, &pb.GetProjectConfigRequest{Project: ref})
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// No stored config
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{Name: ref.Project},
+	})
+	require.NoError(err)
+	resp, err := client.GetProjectConfig(ctx, &pb.GetProjectConfigRequest{Project: ref})
+	require.NoError(err)
+	require.Empty(resp.WaypointHcl)
+
+	// Stored config
+	hcl := []byte(`project = "p_test"`)
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{Name: ref.Project, WaypointHcl: hcl},
+	})
+	require.NoError(err)
+	resp, err = client.GetProjectConfig(ctx, &pb.GetProjectConfigRequest{Project: ref})
+	require.NoError(err)
+	require.Equal(hcl, resp.WaypointHcl)
+}
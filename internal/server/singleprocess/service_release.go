@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
 )
 
@@ -42,11 +43,18 @@ func (s *service) ListReleases(
 	ctx context.Context,
 	req *pb.ListReleasesRequest,
 ) (*pb.ListReleasesResponse, error) {
+	labels, err := serverptypes.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid label selector: %s", err)
+	}
+
 	result, err := s.state.ReleaseList(req.Application,
 		state.ListWithStatusFilter(req.Status...),
 		state.ListWithOrder(req.Order),
 		state.ListWithWorkspace(req.Workspace),
 		state.ListWithPhysicalState(req.PhysicalState),
+		state.ListWithLabelSelector(labels),
 	)
 	if err != nil {
 		return nil, err
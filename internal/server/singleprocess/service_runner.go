@@ -3,15 +3,31 @@ package singleprocess
 import (
 	"context"
 	"io"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	"github.com/hashicorp/waypoint/internal/server/logbuffer"
 	"github.com/hashicorp/waypoint/internal/server/singleprocess/state"
+	"github.com/hashicorp/waypoint/internal/telemetry"
+)
+
+var (
+	// runnerTokenDuration is how long the tokens sent to adopted runners
+	// are valid.
+	runnerTokenDuration = 24 * time.Hour
+
+	// runnerTokenRotateInterval is how often adopted runners are sent a
+	// new token. This must be shorter than runnerTokenDuration.
+	runnerTokenRotateInterval = 12 * time.Hour
 )
 
 // TODO: test
@@ -22,6 +38,29 @@ func (s *service) GetRunner(
 	return s.state.RunnerById(req.RunnerId)
 }
 
+func (s *service) ListRunners(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.ListRunnersResponse, error) {
+	result, err := s.state.RunnerList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRunnersResponse{Runners: result}, nil
+}
+
+func (s *service) ForgetRunner(
+	ctx context.Context,
+	req *pb.ForgetRunnerRequest,
+) (*empty.Empty, error) {
+	if err := s.state.RunnerForget(req.RunnerId); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (s *service) RunnerGetDeploymentConfig(
 	ctx context.Context,
 	req *pb.RunnerGetDeploymentConfigRequest,
@@ -76,6 +115,19 @@ func (s *service) RunnerConfig(
 	}
 	record := req.Open.Runner
 
+	// Runners must be adopted by an operator before they're trusted.
+	// Runners that registered with their own runner token were adopted
+	// already or launched by us on-demand. Local runners that the CLI
+	// starts to run its own jobs register with the token of the user
+	// rather than a runner token and only run jobs that target them.
+	record.AdoptionState = pb.Runner_PENDING
+	tokenRunnerId := s.runnerTokenId(srv.Context())
+	if s.autoAdoptRunners ||
+		(tokenRunnerId != "" && tokenRunnerId == record.Id) ||
+		(tokenRunnerId == "" && record.ByIdOnly) {
+		record.AdoptionState = pb.Runner_ADOPTED
+	}
+
 	// Create our record
 	log = log.With("runner_id", record.Id)
 	log.Trace("registering runner")
@@ -83,10 +135,19 @@ func (s *service) RunnerConfig(
 		return err
 	}
 
-	// Defer deleting this.
-	// TODO(mitchellh): this is too aggressive and we want to have some grace
-	// period for reconnecting clients. We should clean this up.
+	// Defer deleting this if the runner closed the stream. If the runner
+	// went away without closing it, we keep the runner so that its jobs
+	// are handled once it stops heartbeating. It is replaced if the runner
+	// reconnects.
+	closed := make(chan struct{})
 	defer func() {
+		select {
+		case <-closed:
+		default:
+			log.Debug("runner disconnected without closing, waiting for heartbeat timeout")
+			return
+		}
+
 		log.Trace("deleting runner")
 		if err := s.state.RunnerDelete(record.Id); err != nil {
 			log.Error("failed to delete runner data. This should not happen.", "err", err)
@@ -94,59 +155,161 @@ func (s *service) RunnerConfig(
 	}()
 
 	// Start a goroutine that listens on the recvmsg so we can detect
-	// when the client exited.
+	// when the client exited and record heartbeats.
 	go func() {
 		defer cancel()
 
 		for {
-			_, err := srv.Recv()
+			req, err := srv.Recv()
 			if err != nil {
-				if err != io.EOF {
+				if err == io.EOF {
+					close(closed)
+				} else {
 					log.Warn("unknown error from recvmsg", "err", err)
 				}
 
 				return
 			}
+
+			if _, ok := req.Event.(*pb.RunnerConfigRequest_Heartbeat_); ok {
+				if err := s.state.RunnerHeartbeat(record.Id); err != nil {
+					log.Warn("error recording runner heartbeat", "err", err)
+				}
+			}
 		}
 	}()
 
 	// Build our config in a loop.
+	var tokenTime time.Time
 	for {
 		ws := memdb.NewWatchSet()
 
-		// Build our config
-		config := &pb.RunnerConfig{}
-
-		// Get our config vars
-		vars, err := s.state.ConfigGetWatch(&pb.ConfigGetRequest{
-			Scope: &pb.ConfigGetRequest_Runner{
-				Runner: &pb.Ref_RunnerId{
-					Id: record.Id,
-				},
-			},
-		}, ws)
+		// Get the runner so we notice when it is adopted or rejected
+		runner, err := s.state.RunnerByIdWatch(record.Id, ws)
 		if err != nil {
 			return err
 		}
-		config.ConfigVars = vars
+		if runner.AdoptionState == pb.Runner_REJECTED {
+			return status.Errorf(codes.PermissionDenied, "runner was rejected")
+		}
+
+		// Build our config. Config can have secrets so it is only sent to
+		// adopted runners.
+		config := &pb.RunnerConfig{}
+		resp := &pb.RunnerConfigResponse{Config: config}
+		if runner.AdoptionState == pb.Runner_ADOPTED {
+			// Get our config vars
+			vars, err := s.state.ConfigGetWatch(&pb.ConfigGetRequest{
+				Scope: &pb.ConfigGetRequest_Runner{
+					Runner: &pb.Ref_RunnerId{
+						Id: record.Id,
+					},
+				},
+			}, ws)
+			if err != nil {
+				return err
+			}
+			config.ConfigVars = vars
+
+			// Send a new runner token if we haven't yet or it is time to
+			// rotate it.
+			if tokenTime.IsZero() || time.Since(tokenTime) >= runnerTokenRotateInterval {
+				resp.RunnerToken, err = s.newLoginToken(
+					runnerTokenDuration, DefaultKeyId, nil, &pb.Token{
+						Runner: &pb.Token_Runner{Id: record.Id},
+					})
+				if err != nil {
+					return err
+				}
+
+				tokenTime = time.Now()
+			}
+		}
 
 		// Send new config
-		if err := srv.Send(&pb.RunnerConfigResponse{
-			Config: config,
-		}); err != nil {
+		if err := srv.Send(resp); err != nil {
 			return err
 		}
 
 		// Nil out the stuff we used so that if we're waiting awhile we can GC
 		config = nil
+		resp = nil
 
-		// Wait for any changes
-		if err := ws.WatchCtx(ctx); err != nil {
+		// Wait for any changes or until the token must be rotated
+		waitCtx, waitCancel := ctx, context.CancelFunc(func() {})
+		if !tokenTime.IsZero() {
+			waitCtx, waitCancel = context.WithDeadline(ctx, tokenTime.Add(runnerTokenRotateInterval))
+		}
+		err = ws.WatchCtx(waitCtx)
+		waitCancel()
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 	}
 }
 
+// runnerTokenId returns the ID of the runner that the token of the request
+// in ctx was issued to. This is empty if it isn't a runner token.
+func (s *service) runnerTokenId(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return ""
+	}
+
+	_, body, err := s.DecodeToken(md["authorization"][0])
+	if err != nil {
+		return ""
+	}
+
+	return body.Runner.GetId()
+}
+
+func (s *service) AdoptRunner(
+	ctx context.Context,
+	req *pb.AdoptRunnerRequest,
+) (*empty.Empty, error) {
+	if err := s.state.RunnerAdopt(req.RunnerId, true); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *service) RejectRunner(
+	ctx context.Context,
+	req *pb.RejectRunnerRequest,
+) (*empty.Empty, error) {
+	if err := s.state.RunnerAdopt(req.RunnerId, false); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// runnerWaitAdopted waits until the runner with the given ID is adopted.
+// This returns an error if the runner is rejected.
+func (s *service) runnerWaitAdopted(ctx context.Context, id string) (*pb.Runner, error) {
+	for {
+		ws := memdb.NewWatchSet()
+		runner, err := s.state.RunnerByIdWatch(id, ws)
+		if err != nil {
+			return nil, err
+		}
+
+		switch runner.AdoptionState {
+		case pb.Runner_ADOPTED:
+			return runner, nil
+
+		case pb.Runner_REJECTED:
+			return nil, status.Errorf(codes.PermissionDenied, "runner was rejected")
+		}
+
+		if err := ws.WatchCtx(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (s *service) RunnerJobStream(
 	server pb.Waypoint_RunnerJobStreamServer,
 ) error {
@@ -166,8 +329,9 @@ func (s *service) RunnerJobStream(
 	}
 	log = log.With("runner_id", reqEvent.Request.RunnerId)
 
-	// Get the runner to validate it is registered
-	runner, err := s.state.RunnerById(reqEvent.Request.RunnerId)
+	// Get the runner to validate it is registered. Only adopted runners
+	// are assigned jobs so we wait for an operator to adopt it.
+	runner, err := s.runnerWaitAdopted(ctx, reqEvent.Request.RunnerId)
 	if err != nil {
 		return err
 	}
@@ -178,6 +342,17 @@ func (s *service) RunnerJobStream(
 		return err
 	}
 
+	// The server's part of the job is traced from assignment until the
+	// runner reports the result, in the trace of the request that queued it.
+	_, span := telemetry.Tracer().Start(
+		telemetry.ExtractMap(ctx, job.TraceContext), "job assigned",
+		trace.WithAttributes(
+			kv.String("job.id", job.Id),
+			kv.String("runner.id", runner.Id),
+		),
+	)
+	defer span.End()
+
 	// Send the job assignment.
 	//
 	// If this has an error, we continue to accumulate the error until
@@ -218,6 +393,7 @@ func (s *service) RunnerJobStream(
 	}
 
 	// Send the ack.
+	span.AddEvent(ctx, "ack", kv.Bool("ack", ack))
 	job, ackerr := s.state.JobAck(job.Id, ack)
 	if ackerr != nil {
 		// If this fails, we just log, there is nothing more we can do.
@@ -350,10 +526,10 @@ func (s *service) RunnerJobStream(
 			// cancel requests are made.
 			if job.CancelTime != nil &&
 				(lastJob == nil || lastJob.CancelTime != job.CancelTime) {
-				// The job is forced if we're in an error state. This must be true
-				// because we would've already exited the loop if we naturally
-				// got a terminal event.
-				force := job.State == pb.Job_ERROR
+				// The job is forced if we're in an error state or the job timed
+				// out. This must be true because we would've already exited the
+				// loop if we naturally got a terminal event.
+				force := job.State == pb.Job_ERROR || job.State == pb.Job_TIMED_OUT
 
 				err := server.Send(&pb.RunnerJobStreamResponse{
 					Event: &pb.RunnerJobStreamResponse_Cancel{
@@ -0,0 +1,235 @@
+package singleprocess
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	"github.com/hashicorp/waypoint/internal/server/runnerlaunch"
+	"github.com/hashicorp/waypoint/internal/serverclient"
+)
+
+var (
+	// onDemandRunnerTokenDuration is how long the token that an on-demand
+	// runner connects to the server with is valid.
+	onDemandRunnerTokenDuration = 24 * time.Hour
+
+	// onDemandRunnerStopTimeout is how long stopping an on-demand runner
+	// may take.
+	onDemandRunnerStopTimeout = 2 * time.Minute
+)
+
+func (s *service) UpsertRunnerProfile(
+	ctx context.Context,
+	req *pb.UpsertRunnerProfileRequest,
+) (*pb.UpsertRunnerProfileResponse, error) {
+	p := req.Profile
+	if p == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "profile must be set")
+	}
+	if p.Name == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "profile name must be set")
+	}
+	if p.Platform == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"profile must set the platform to launch runners on")
+	}
+
+	if err := s.state.RunnerProfilePut(p); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpsertRunnerProfileResponse{Profile: p}, nil
+}
+
+func (s *service) ListRunnerProfiles(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.ListRunnerProfilesResponse, error) {
+	result, err := s.state.RunnerProfileList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRunnerProfilesResponse{Profiles: result}, nil
+}
+
+func (s *service) DeleteRunnerProfile(
+	ctx context.Context,
+	req *pb.DeleteRunnerProfileRequest,
+) (*empty.Empty, error) {
+	if err := s.state.RunnerProfileDelete(req.Name); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// onDemandRunner is a runner that was launched for a single job.
+type onDemandRunner struct {
+	id       string
+	profile  string
+	launcher runnerlaunch.Launcher
+	handle   string
+}
+
+// launchOnDemandRunner launches a runner for the job if no registered
+// runner can run it and there is a runner profile for the job's project.
+// The job is retargeted to the launched runner. This returns nil if no
+// runner was launched.
+func (s *service) launchOnDemandRunner(
+	ctx context.Context,
+	log hclog.Logger,
+	job *pb.Job,
+) (*onDemandRunner, error) {
+	// Jobs that target a runner by ID must run on that runner.
+	if _, ok := job.TargetRunner.Target.(*pb.Ref_Runner_Id); ok {
+		return nil, nil
+	}
+
+	profile, err := s.state.RunnerProfileForProject(job.Application.Project)
+	if err != nil || profile == nil {
+		return nil, err
+	}
+
+	assignable, err := s.state.JobIsAssignable(ctx, job)
+	if err != nil || assignable {
+		return nil, err
+	}
+
+	newLauncher := s.newRunnerLauncher
+	if newLauncher == nil {
+		newLauncher = runnerlaunch.New
+	}
+	launcher, err := newLauncher(profile)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"error configuring runner profile %q: %s", profile.Name, err)
+	}
+
+	id, err := server.Id()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+	}
+
+	env, err := s.onDemandRunnerEnv(profile, id, job)
+	if err != nil {
+		return nil, err
+	}
+
+	log = log.With("runner_id", id, "runner_profile", profile.Name)
+	log.Info("launching on-demand runner")
+	handle, err := launcher.Launch(ctx, &runnerlaunch.Runner{
+		Id:  id,
+		Env: env,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable,
+			"error launching on-demand runner with profile %q: %s", profile.Name, err)
+	}
+
+	job.TargetRunner = &pb.Ref_Runner{
+		Target: &pb.Ref_Runner_Id{
+			Id: &pb.Ref_RunnerId{Id: id},
+		},
+	}
+
+	return &onDemandRunner{
+		id:       id,
+		profile:  profile.Name,
+		launcher: launcher,
+		handle:   handle,
+	}, nil
+}
+
+// onDemandRunnerEnv returns the environment of an on-demand runner. The
+// runner connects to the first advertise address of the server with a
+// runner token for the given ID, so it is adopted when it registers. It
+// runs the job with a token that is limited to the project of the job.
+func (s *service) onDemandRunnerEnv(
+	profile *pb.RunnerProfile,
+	id string,
+	job *pb.Job,
+) (map[string]string, error) {
+	cfg, err := s.state.ServerConfigGet()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || len(cfg.AdvertiseAddrs) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"the server must have an advertise address to launch on-demand runners")
+	}
+	addr := cfg.AdvertiseAddrs[0]
+
+	runnerToken, err := s.newLoginToken(onDemandRunnerTokenDuration, DefaultKeyId, nil, &pb.Token{
+		Runner: &pb.Token_Runner{Id: id},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.newLoginToken(onDemandRunnerTokenDuration, DefaultKeyId, nil, &pb.Token{
+		Projects: []*pb.Ref_Project{{Project: job.Application.Project}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{}
+	for k, v := range profile.EnvVars {
+		env[k] = v
+	}
+	env[serverclient.EnvServerAddr] = addr.Addr
+	env[serverclient.EnvServerToken] = token
+	env[serverclient.EnvRunnerToken] = runnerToken
+	if addr.Tls {
+		env[serverclient.EnvServerTls] = "1"
+	}
+	if addr.TlsSkipVerify {
+		env[serverclient.EnvServerTlsSkipVerify] = "1"
+	}
+
+	return env, nil
+}
+
+// stopOnDemandRunner stops the on-demand runner once the job with the
+// given ID completes.
+func (s *service) stopOnDemandRunner(log hclog.Logger, r *onDemandRunner, jobId string) {
+	log = log.With("runner_id", r.id, "runner_profile", r.profile, "job_id", jobId)
+
+	for {
+		ws := memdb.NewWatchSet()
+		job, err := s.state.JobById(jobId, ws)
+		if err != nil {
+			log.Warn("error watching job of on-demand runner", "err", err)
+			break
+		}
+		if job == nil || job.State == pb.Job_SUCCESS || job.State == pb.Job_ERROR ||
+			job.State == pb.Job_TIMED_OUT {
+			break
+		}
+
+		ws.Watch(nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), onDemandRunnerStopTimeout)
+	defer cancel()
+
+	log.Info("stopping on-demand runner")
+	if err := r.launcher.Stop(ctx, r.handle); err != nil {
+		log.Error("error stopping on-demand runner", "err", err)
+	}
+
+	// The runner may be stopped without deregistering, so we remove it
+	// rather than wait for it to stop heartbeating.
+	if err := s.state.RunnerDelete(r.id); err != nil {
+		log.Warn("error deleting on-demand runner", "err", err)
+	}
+}
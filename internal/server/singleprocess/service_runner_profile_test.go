@@ -0,0 +1,175 @@
+package singleprocess
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+	"github.com/hashicorp/waypoint/internal/server/runnerlaunch"
+	"github.com/hashicorp/waypoint/internal/serverclient"
+)
+
+func TestServiceRunnerProfile(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	client := TestServer(t)
+
+	// A profile needs a platform
+	_, err := client.UpsertRunnerProfile(ctx, &pb.UpsertRunnerProfileRequest{
+		Profile: &pb.RunnerProfile{Name: "default"},
+	})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.UpsertRunnerProfile(ctx, &pb.UpsertRunnerProfileRequest{
+		Profile: &pb.RunnerProfile{
+			Name: "default",
+			Platform: &pb.RunnerProfile_Docker_{
+				Docker: &pb.RunnerProfile_Docker{},
+			},
+		},
+	})
+	require.NoError(err)
+
+	resp, err := client.ListRunnerProfiles(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Len(resp.Profiles, 1)
+	require.Equal("default", resp.Profiles[0].Name)
+
+	_, err = client.DeleteRunnerProfile(ctx, &pb.DeleteRunnerProfileRequest{Name: "default"})
+	require.NoError(err)
+
+	resp, err = client.ListRunnerProfiles(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Empty(resp.Profiles)
+}
+
+func TestServiceQueueJob_onDemandRunner(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	TestApp(t, client, serverptypes.TestJobNew(t, nil).Application)
+
+	launcher := &testLauncher{}
+	s := impl.(*service)
+	s.newRunnerLauncher = func(*pb.RunnerProfile) (runnerlaunch.Launcher, error) {
+		return launcher, nil
+	}
+
+	// Without a profile nothing is launched
+	_, err = client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, nil),
+	})
+	require.NoError(err)
+	require.Empty(launcher.launched())
+
+	_, err = client.UpsertRunnerProfile(ctx, &pb.UpsertRunnerProfileRequest{
+		Profile: &pb.RunnerProfile{
+			Name:    "web",
+			Project: "p_test",
+			EnvVars: map[string]string{"FOO": "bar"},
+			Platform: &pb.RunnerProfile_Docker_{
+				Docker: &pb.RunnerProfile_Docker{},
+			},
+		},
+	})
+	require.NoError(err)
+
+	// Launching requires an advertise address for the runner
+	_, err = client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, nil),
+	})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.SetServerConfig(ctx, &pb.SetServerConfigRequest{
+		Config: serverptypes.TestServerConfig(t, nil),
+	})
+	require.NoError(err)
+
+	// The job is retargeted to the launched runner
+	queueResp, err := client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, nil),
+	})
+	require.NoError(err)
+	launched := launcher.launched()
+	require.Len(launched, 1)
+	require.Equal("bar", launched[0].Env["FOO"])
+	require.Equal("127.0.0.1", launched[0].Env[serverclient.EnvServerAddr])
+	require.NotEmpty(launched[0].Env[serverclient.EnvServerToken])
+	require.NotEmpty(launched[0].Env[serverclient.EnvRunnerToken])
+
+	job, err := client.GetJob(ctx, &pb.GetJobRequest{JobId: queueResp.JobId})
+	require.NoError(err)
+	target, ok := job.TargetRunner.Target.(*pb.Ref_Runner_Id)
+	require.True(ok)
+	require.Equal(launched[0].Id, target.Id.Id)
+
+	// Jobs that target a runner by ID don't launch runners
+	_, err = client.QueueJob(ctx, &pb.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, &pb.Job{
+			TargetRunner: &pb.Ref_Runner{
+				Target: &pb.Ref_Runner_Id{
+					Id: &pb.Ref_RunnerId{Id: "R_A"},
+				},
+			},
+		}),
+	})
+	require.NoError(err)
+	require.Len(launcher.launched(), 1)
+
+	// The runner is stopped once the job completes
+	require.Empty(launcher.stopped())
+	_, err = client.CancelJob(ctx, &pb.CancelJobRequest{JobId: queueResp.JobId})
+	require.NoError(err)
+	require.Eventually(func() bool {
+		stopped := launcher.stopped()
+		return len(stopped) == 1 && stopped[0] == "handle-"+launched[0].Id
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// testLauncher records the runners it launches and stops.
+type testLauncher struct {
+	mu        sync.Mutex
+	runners   []*runnerlaunch.Runner
+	stoppedHs []string
+}
+
+func (l *testLauncher) Launch(ctx context.Context, r *runnerlaunch.Runner) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.runners = append(l.runners, r)
+	return "handle-" + r.Id, nil
+}
+
+func (l *testLauncher) Stop(ctx context.Context, handle string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stoppedHs = append(l.stoppedHs, handle)
+	return nil
+}
+
+func (l *testLauncher) launched() []*runnerlaunch.Runner {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*runnerlaunch.Runner(nil), l.runners...)
+}
+
+func (l *testLauncher) stopped() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.stoppedHs...)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint/internal/server"
@@ -284,3 +287,145 @@ func TestServiceRunnerGetDeploymentConfig(t *testing.T) {
 		require.NotEmpty(resp.ServerAddr)
 	})
 }
+
+func TestServiceListRunners(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	client := TestServer(t)
+
+	// Register our runner
+	id, closer := TestRunner(t, client, &pb.Runner{
+		Labels: map[string]string{"region": "us"},
+	})
+
+	resp, err := client.ListRunners(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Len(resp.Runners, 1)
+	require.Equal(id, resp.Runners[0].Id)
+	require.Equal(pb.Runner_HEALTHY, resp.Runners[0].Health)
+	require.NotNil(resp.Runners[0].LastHeartbeatTime)
+	require.Equal("us", resp.Runners[0].Labels["region"])
+
+	// Healthy runners can't be forgotten
+	_, err = client.ForgetRunner(ctx, &pb.ForgetRunnerRequest{RunnerId: id})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// Closing the config stream deregisters the runner
+	closer()
+	require.Eventually(func() bool {
+		resp, err := client.ListRunners(ctx, &empty.Empty{})
+		require.NoError(err)
+		return len(resp.Runners) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestServiceAdoptRunner(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// Register a runner without adopting it
+	stream, err := client.RunnerConfig(ctx)
+	require.NoError(err)
+	defer stream.CloseSend()
+	require.NoError(stream.Send(&pb.RunnerConfigRequest{
+		Event: &pb.RunnerConfigRequest_Open_{
+			Open: &pb.RunnerConfigRequest_Open{
+				Runner: &pb.Runner{Id: "R_A"},
+			},
+		},
+	}))
+
+	// Pending runners don't get a token
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.Empty(resp.RunnerToken)
+
+	runner, err := client.GetRunner(ctx, &pb.GetRunnerRequest{RunnerId: "R_A"})
+	require.NoError(err)
+	require.Equal(pb.Runner_PENDING, runner.AdoptionState)
+
+	// Adopting sends the runner a token
+	_, err = client.AdoptRunner(ctx, &pb.AdoptRunnerRequest{RunnerId: "R_A"})
+	require.NoError(err)
+	resp, err = stream.Recv()
+	require.NoError(err)
+	require.NotEmpty(resp.RunnerToken)
+
+	runner, err = client.GetRunner(ctx, &pb.GetRunnerRequest{RunnerId: "R_A"})
+	require.NoError(err)
+	require.Equal(pb.Runner_ADOPTED, runner.AdoptionState)
+
+	// Rejecting closes the config stream
+	_, err = client.RejectRunner(ctx, &pb.RejectRunnerRequest{RunnerId: "R_A"})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.Error(err)
+	require.Equal(codes.PermissionDenied, status.Code(err))
+
+	// Rejected runners can't request jobs
+	jobStream, err := client.RunnerJobStream(ctx)
+	require.NoError(err)
+	require.NoError(jobStream.Send(&pb.RunnerJobStreamRequest{
+		Event: &pb.RunnerJobStreamRequest_Request_{
+			Request: &pb.RunnerJobStreamRequest_Request{
+				RunnerId: "R_A",
+			},
+		},
+	}))
+	_, err = jobStream.Recv()
+	require.Error(err)
+	require.Equal(codes.PermissionDenied, status.Code(err))
+
+	// Unknown runners can't be adopted
+	_, err = client.AdoptRunner(ctx, &pb.AdoptRunnerRequest{RunnerId: "R_B"})
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestServiceAdoptRunner_token(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+	client := server.TestServer(t, impl)
+
+	register := func(token, id string) pb.Runner_AdoptionState {
+		ctx := metadata.AppendToOutgoingContext(ctx, "authorization", token)
+		stream, err := client.RunnerConfig(ctx)
+		require.NoError(err)
+		defer stream.CloseSend()
+		require.NoError(stream.Send(&pb.RunnerConfigRequest{
+			Event: &pb.RunnerConfigRequest_Open_{
+				Open: &pb.RunnerConfigRequest_Open{
+					Runner: &pb.Runner{Id: id, ByIdOnly: true},
+				},
+			},
+		}))
+		_, err = stream.Recv()
+		require.NoError(err)
+
+		runner, err := client.GetRunner(ctx, &pb.GetRunnerRequest{RunnerId: id})
+		require.NoError(err)
+		return runner.AdoptionState
+	}
+
+	token, err := s.newLoginToken(0, DefaultKeyId, nil, &pb.Token{
+		Runner: &pb.Token_Runner{Id: "R_A"},
+	})
+	require.NoError(err)
+
+	// The runner token of another runner doesn't adopt a runner, even
+	// one that only runs jobs that target it.
+	require.Equal(pb.Runner_PENDING, register(token, "R_B"))
+
+	// The runner token of the runner adopts it
+	require.Equal(pb.Runner_ADOPTED, register(token, "R_A"))
+}
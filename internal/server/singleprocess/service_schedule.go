@@ -0,0 +1,264 @@
+package singleprocess
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+// TODO: test
+func (s *service) ScheduleOperation(
+	ctx context.Context,
+	req *pb.ScheduleOperationRequest,
+) (*pb.ScheduleOperationResponse, error) {
+	// Validation
+	if req.Job == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "job must be set")
+	}
+	if err := serverptypes.ValidateJob(req.Job); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	at, err := ptypes.Timestamp(req.ScheduleTime)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"invalid schedule time: %s", err)
+	}
+	if !at.After(time.Now()) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"schedule time must be in the future")
+	}
+
+	id, err := server.Id()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+	}
+
+	op := &pb.ScheduledOperation{
+		Id:           id,
+		Job:          req.Job,
+		ScheduleTime: req.ScheduleTime,
+		State:        pb.ScheduledOperation_PENDING,
+	}
+	if err := s.state.ScheduledOperationPut(op); err != nil {
+		return nil, err
+	}
+
+	s.armScheduledOperation(hclog.FromContext(ctx), id, at)
+	return &pb.ScheduleOperationResponse{Id: id}, nil
+}
+
+// TODO: test
+func (s *service) CancelScheduledOperation(
+	ctx context.Context,
+	req *pb.CancelScheduledOperationRequest,
+) (*empty.Empty, error) {
+	if err := s.state.ScheduledOperationCancel(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// armScheduledOperations sets timers for all pending scheduled operations.
+// This is called at startup so that operations scheduled before a restart
+// are still queued.
+func (s *service) armScheduledOperations(log hclog.Logger) error {
+	// Every pending operation is due by the maximum time.
+	ops, err := s.state.ScheduledOperationListDue(time.Unix(1<<62, 0))
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		at, err := ptypes.Timestamp(op.ScheduleTime)
+		if err != nil {
+			return err
+		}
+
+		s.armScheduledOperation(log, op.Id, at)
+	}
+
+	return nil
+}
+
+// armScheduledOperation queues the scheduled operation with the given ID
+// at the given time.
+func (s *service) armScheduledOperation(log hclog.Logger, id string, at time.Time) {
+	dur := time.Until(at)
+	if dur < 0 {
+		dur = 1
+	}
+
+	s.afterFunc(dur, func() {
+		if err := s.queueScheduledOperation(id); err != nil {
+			log.Warn("error queueing scheduled operation", "id", id, "err", err)
+		}
+	})
+}
+
+// queueScheduledOperation queues the job for a scheduled operation if
+// it is still pending.
+func (s *service) queueScheduledOperation(id string) error {
+	op, err := s.state.ScheduledOperationGet(id)
+	if err != nil {
+		return err
+	}
+	if op.State != pb.ScheduledOperation_PENDING {
+		// Cancelled or already queued
+		return nil
+	}
+
+	resp, err := s.QueueJob(context.Background(), &pb.QueueJobRequest{Job: op.Job})
+	if err != nil {
+		return err
+	}
+
+	return s.state.ScheduledOperationQueued(id, resp.JobId)
+}
+
+// TODO: test
+func (s *service) ScheduleRecurringOperation(
+	ctx context.Context,
+	req *pb.ScheduleRecurringOperationRequest,
+) (*pb.ScheduleOperationResponse, error) {
+	v := &pb.RecurringSchedule{
+		Job:  req.Job,
+		Cron: req.Cron,
+	}
+
+	// Validation
+	if err := serverptypes.ValidateRecurringSchedule(v); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	next, err := serverptypes.NextCronTime(v.Cron, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"invalid cron expression: %s", err)
+	}
+	v.NextTime, err = ptypes.TimestampProto(next)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Id, err = server.Id()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+	}
+
+	if err := s.state.RecurringSchedulePut(v); err != nil {
+		return nil, err
+	}
+
+	s.armRecurringSchedule(hclog.FromContext(ctx), v.Id, next)
+	return &pb.ScheduleOperationResponse{Id: v.Id}, nil
+}
+
+// TODO: test
+func (s *service) ListRecurringSchedules(
+	ctx context.Context,
+	req *pb.ListRecurringSchedulesRequest,
+) (*pb.ListRecurringSchedulesResponse, error) {
+	result, err := s.state.RecurringScheduleList(req.Application)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListRecurringSchedulesResponse{Schedules: result}, nil
+}
+
+// TODO: test
+func (s *service) DeleteRecurringSchedule(
+	ctx context.Context,
+	req *pb.DeleteRecurringScheduleRequest,
+) (*empty.Empty, error) {
+	if err := s.state.RecurringScheduleDelete(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// armRecurringSchedules sets timers for all recurring schedules. This is
+// called at startup. A schedule whose next time passed while the server was
+// down queues one job right away rather than one for every missed time.
+func (s *service) armRecurringSchedules(log hclog.Logger) error {
+	schedules, err := s.state.RecurringScheduleList(nil)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range schedules {
+		at, err := ptypes.Timestamp(v.NextTime)
+		if err != nil {
+			return err
+		}
+
+		s.armRecurringSchedule(log, v.Id, at)
+	}
+
+	return nil
+}
+
+// armRecurringSchedule queues a job for the recurring schedule with the
+// given ID at the given time.
+func (s *service) armRecurringSchedule(log hclog.Logger, id string, at time.Time) {
+	dur := time.Until(at)
+	if dur < 0 {
+		dur = 1
+	}
+
+	s.afterFunc(dur, func() {
+		if err := s.queueRecurringSchedule(log, id); err != nil {
+			log.Warn("error queueing recurring schedule", "id", id, "err", err)
+		}
+	})
+}
+
+// queueRecurringSchedule queues a copy of the job for a recurring schedule
+// and arms the timer for the next time. This does nothing if the schedule
+// was deleted.
+func (s *service) queueRecurringSchedule(log hclog.Logger, id string) error {
+	v, err := s.state.RecurringScheduleGet(id)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	next, err := serverptypes.NextCronTime(v.Cron, time.Now())
+	if err != nil {
+		return err
+	}
+
+	job := proto.Clone(v.Job).(*pb.Job)
+	resp, err := s.QueueJob(context.Background(), &pb.QueueJobRequest{Job: job})
+	if err != nil {
+		// We still want future runs to happen, so arm the next time.
+		s.armRecurringSchedule(log, id, next)
+		return err
+	}
+
+	err = s.state.RecurringScheduleQueued(id, resp.JobId, next)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.armRecurringSchedule(log, id, next)
+	return nil
+}
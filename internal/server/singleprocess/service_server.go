@@ -19,7 +19,50 @@ func (s *service) SetServerConfig(
 		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
 	}
 
-	if err := s.state.ServerConfigSet(req.Config); err != nil {
+	if err := s.logSinks.Configure(req.Config.LogSinks); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	if err := s.state.ServerConfigSetBy(req.Config, s.tokenIdentity(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *service) ListServerConfigHistory(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.ListServerConfigHistoryResponse, error) {
+	versions, err := s.state.ServerConfigHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListServerConfigHistoryResponse{Versions: versions}, nil
+}
+
+func (s *service) RollbackServerConfig(
+	ctx context.Context,
+	req *pb.RollbackServerConfigRequest,
+) (*empty.Empty, error) {
+	v, err := s.state.ServerConfigVersionGet(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// The config was valid when it was set but validation may have become
+	// stricter since, so we check it again.
+	if err := serverptypes.ValidateServerConfig(v.Config); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"server config version %d is no longer valid: %s", req.Version, err)
+	}
+
+	if err := s.logSinks.Configure(v.Config.LogSinks); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	if err := s.state.ServerConfigSetBy(v.Config, s.tokenIdentity(ctx)); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,55 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceServerConfig_rollback(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	a := &pb.ServerConfig{
+		AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{{Addr: "a:1234"}},
+	}
+	b := &pb.ServerConfig{
+		AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{{Addr: "b:1234"}},
+	}
+	for _, cfg := range []*pb.ServerConfig{a, b} {
+		_, err := client.SetServerConfig(ctx, &pb.SetServerConfigRequest{Config: cfg})
+		require.NoError(err)
+	}
+
+	// Roll back to the first version
+	_, err = client.RollbackServerConfig(ctx, &pb.RollbackServerConfigRequest{Version: 1})
+	require.NoError(err)
+
+	resp, err := client.GetServerConfig(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.True(proto.Equal(a, resp.Config))
+
+	// The rollback is recorded as a new version
+	history, err := client.ListServerConfigHistory(ctx, &empty.Empty{})
+	require.NoError(err)
+	require.Len(history.Versions, 3)
+	require.Equal(uint64(3), history.Versions[2].Version)
+	require.True(proto.Equal(a, history.Versions[2].Config))
+
+	// Unknown versions
+	_, err = client.RollbackServerConfig(ctx, &pb.RollbackServerConfigRequest{Version: 42})
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
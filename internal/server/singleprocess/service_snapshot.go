@@ -0,0 +1,81 @@
+package singleprocess
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// snapshotChunkSize is the maximum size of a single snapshot chunk. This
+// keeps each message well under the default gRPC message size limit.
+const snapshotChunkSize = 1024 * 1024
+
+func (s *service) CreateSnapshot(
+	req *empty.Empty,
+	srv pb.Waypoint_CreateSnapshotServer,
+) error {
+	return s.state.CreateSnapshot(&snapshotWriter{srv: srv})
+}
+
+func (s *service) RestoreSnapshot(srv pb.Waypoint_RestoreSnapshotServer) error {
+	if err := s.state.StageRestoreSnapshot(&snapshotReader{srv: srv}); err != nil {
+		return err
+	}
+
+	return srv.SendAndClose(&empty.Empty{})
+}
+
+// snapshotWriter is an io.Writer that sends each write as chunks of a
+// CreateSnapshot stream.
+type snapshotWriter struct {
+	srv pb.Waypoint_CreateSnapshotServer
+}
+
+func (w *snapshotWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > snapshotChunkSize {
+			chunk = chunk[:snapshotChunkSize]
+		}
+
+		// The chunk must be copied since the caller may reuse p.
+		if err := w.srv.Send(&pb.CreateSnapshotResponse{
+			Chunk: append([]byte(nil), chunk...),
+		}); err != nil {
+			return n, err
+		}
+
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+
+	return n, nil
+}
+
+// snapshotReader is an io.Reader over the chunks of a RestoreSnapshot
+// stream.
+type snapshotReader struct {
+	srv pb.Waypoint_RestoreSnapshotServer
+	buf []byte
+}
+
+func (r *snapshotReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.srv.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		r.buf = req.Chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+var _ io.Writer = (*snapshotWriter)(nil)
+var _ io.Reader = (*snapshotReader)(nil)
@@ -0,0 +1,71 @@
+package singleprocess
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceSnapshot(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	db := testDB(t)
+	impl, err := New(WithDB(db))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// Create a snapshot
+	snapshotClient, err := client.CreateSnapshot(ctx, &empty.Empty{})
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	for {
+		resp, err := snapshotClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+
+		buf.Write(resp.Chunk)
+	}
+	require.NotZero(buf.Len())
+
+	// Restore it
+	restoreClient, err := client.RestoreSnapshot(ctx)
+	require.NoError(err)
+	require.NoError(restoreClient.Send(&pb.RestoreSnapshotRequest{
+		Chunk: buf.Bytes(),
+	}))
+	_, err = restoreClient.CloseAndRecv()
+	require.NoError(err)
+
+	// The restore is staged for the next start
+	_, err = os.Stat(db.Path() + ".restore")
+	require.NoError(err)
+}
+
+func TestServiceSnapshot_restoreInvalid(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	restoreClient, err := client.RestoreSnapshot(ctx)
+	require.NoError(err)
+	require.NoError(restoreClient.Send(&pb.RestoreSnapshotRequest{
+		Chunk: []byte("not a snapshot"),
+	}))
+	_, err = restoreClient.CloseAndRecv()
+	require.Error(err)
+}
@@ -0,0 +1,107 @@
+package singleprocess
+
+import (
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// sourceBundleMaxSize is the largest source bundle that can be
+	// uploaded.
+	sourceBundleMaxSize = 64 * 1024 * 1024
+
+	// sourceBundleTTL is how long uploaded source bundles are kept. Jobs
+	// that are queued for longer than this can't get their source.
+	sourceBundleTTL = 24 * time.Hour
+
+	// sourceChunkSize is the maximum size of a single chunk sent by
+	// GetSource.
+	sourceChunkSize = 1024 * 1024
+)
+
+func (s *service) UploadSource(srv pb.Waypoint_UploadSourceServer) error {
+	var bundle pb.SourceBundle
+	for {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if bundle.Project == nil {
+			if req.Project == nil {
+				return status.Errorf(codes.InvalidArgument,
+					"project must be set on the first message")
+			}
+
+			bundle.Project = req.Project
+		}
+
+		if len(bundle.Data)+len(req.Chunk) > sourceBundleMaxSize {
+			return status.Errorf(codes.ResourceExhausted,
+				"source bundle is larger than the maximum of %d bytes", sourceBundleMaxSize)
+		}
+		bundle.Data = append(bundle.Data, req.Chunk...)
+	}
+	if bundle.Project == nil {
+		return status.Errorf(codes.InvalidArgument, "no source was uploaded")
+	}
+
+	// The project must exist so that bundles can't be stored for anything.
+	if _, err := s.state.ProjectGet(bundle.Project); err != nil {
+		return err
+	}
+
+	id, err := server.Id()
+	if err != nil {
+		return status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+	}
+	bundle.Id = id
+	bundle.UploadTime = ptypes.TimestampNow()
+
+	if err := s.state.SourceBundlePut(&bundle); err != nil {
+		return err
+	}
+
+	// Delete bundles that are too old to still be needed.
+	if _, err := s.state.SourceBundlePrune(time.Now().Add(-sourceBundleTTL)); err != nil {
+		return err
+	}
+
+	return srv.SendAndClose(&pb.UploadSourceResponse{Id: id})
+}
+
+func (s *service) GetSource(
+	req *pb.GetSourceRequest,
+	srv pb.Waypoint_GetSourceServer,
+) error {
+	bundle, err := s.state.SourceBundleGet(req.Id)
+	if err != nil {
+		return err
+	}
+
+	data := bundle.Data
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > sourceChunkSize {
+			chunk = chunk[:sourceChunkSize]
+		}
+
+		if err := srv.Send(&pb.GetSourceResponse{Chunk: chunk}); err != nil {
+			return err
+		}
+
+		data = data[len(chunk):]
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package singleprocess
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceSource(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{Name: "p_test"},
+	})
+	require.NoError(err)
+
+	// Upload in two chunks
+	uploadClient, err := client.UploadSource(ctx)
+	require.NoError(err)
+	require.NoError(uploadClient.Send(&pb.UploadSourceRequest{
+		Project: &pb.Ref_Project{Project: "p_test"},
+		Chunk:   []byte("hello "),
+	}))
+	require.NoError(uploadClient.Send(&pb.UploadSourceRequest{
+		Chunk: []byte("world"),
+	}))
+	resp, err := uploadClient.CloseAndRecv()
+	require.NoError(err)
+	require.NotEmpty(resp.Id)
+
+	// Get it back
+	getClient, err := client.GetSource(ctx, &pb.GetSourceRequest{Id: resp.Id})
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	for {
+		resp, err := getClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+
+		buf.Write(resp.Chunk)
+	}
+	require.Equal("hello world", buf.String())
+}
+
+func TestServiceSource_unknownProject(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	uploadClient, err := client.UploadSource(ctx)
+	require.NoError(err)
+	require.NoError(uploadClient.Send(&pb.UploadSourceRequest{
+		Project: &pb.Ref_Project{Project: "nope"},
+		Chunk:   []byte("hello"),
+	}))
+	_, err = uploadClient.CloseAndRecv()
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
@@ -1,9 +1,51 @@
 package singleprocess
 
 import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint/internal/server"
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 func testServiceImpl(impl pb.WaypointServer) *service {
 	return impl.(*service)
 }
+
+func TestServiceClose(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Create our server
+	impl := TestImpl(t)
+	client := server.TestServer(t, impl)
+
+	// Schedule an operation shortly in the future
+	job := serverptypes.TestJobNew(t, nil)
+	TestApp(t, client, job.Application)
+	at, err := ptypes.TimestampProto(time.Now().Add(200 * time.Millisecond))
+	require.NoError(err)
+	resp, err := client.ScheduleOperation(ctx, &pb.ScheduleOperationRequest{
+		Job:          job,
+		ScheduleTime: at,
+	})
+	require.NoError(err)
+
+	s := testServiceImpl(impl)
+	s.timerMu.Lock()
+	require.Len(s.timers, 1)
+	s.timerMu.Unlock()
+
+	// Closing stops the timer so the operation is never queued
+	require.NoError(s.Close())
+	time.Sleep(400 * time.Millisecond)
+
+	op, err := s.state.ScheduledOperationGet(resp.Id)
+	require.NoError(err)
+	require.Equal(pb.ScheduledOperation_PENDING, op.State)
+}
@@ -0,0 +1,215 @@
+package singleprocess
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+const (
+	// webhookMaxBody is the largest webhook payload that is read.
+	webhookMaxBody = 1024 * 1024
+
+	// triggerSecretSize is the number of random bytes in a trigger secret.
+	triggerSecretSize = 32
+)
+
+func (s *service) CreateTrigger(
+	ctx context.Context,
+	req *pb.CreateTriggerRequest,
+) (*pb.CreateTriggerResponse, error) {
+	if req.Project == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "project must be set")
+	}
+
+	// Only git data sources can be checked out at the pushed commit.
+	p, err := s.state.ProjectGet(req.Project)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.DataSource.GetSource().(*pb.Job_DataSource_Git); !ok {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"triggers require the project to have a git data source")
+	}
+
+	id, err := server.Id()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "uuid generation failed: %s", err)
+	}
+
+	secret := make([]byte, triggerSecretSize)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return nil, status.Errorf(codes.Internal, "secret generation failed: %s", err)
+	}
+
+	trigger := &pb.Trigger{
+		Id:         id,
+		Project:    req.Project,
+		Secret:     hex.EncodeToString(secret),
+		Branch:     req.Branch,
+		CreateTime: ptypes.TimestampNow(),
+	}
+	if err := s.state.TriggerPut(trigger); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateTriggerResponse{
+		Trigger: trigger,
+		Path:    server.WebhookPathPrefix + id,
+	}, nil
+}
+
+func (s *service) DeleteTrigger(
+	ctx context.Context,
+	req *pb.DeleteTriggerRequest,
+) (*empty.Empty, error) {
+	if err := s.state.TriggerDelete(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// webhookPush is the part of a GitHub or GitLab push event payload that
+// is used. Both use the same field names.
+type webhookPush struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ServeWebhook implements server.WebhookHandler. It verifies the payload
+// with the secret of the trigger in the path and queues up jobs for push
+// events.
+func (s *service) ServeWebhook(w http.ResponseWriter, r *http.Request) {
+	log := hclog.FromContext(r.Context()).Named("webhook")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, server.WebhookPathPrefix)
+	trigger, err := s.state.TriggerGet(id)
+	if status.Code(err) == codes.NotFound {
+		http.Error(w, "unknown trigger", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Warn("error getting trigger", "id", id, "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, webhookMaxBody))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	// Verify the payload and find out if it is a push. Any other events,
+	// such as the ping GitHub sends when a webhook is added, are accepted
+	// but ignored.
+	var push bool
+	switch {
+	case r.Header.Get("X-Hub-Signature-256") != "":
+		if !webhookSignatureValid(trigger.Secret, body, r.Header.Get("X-Hub-Signature-256")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		push = r.Header.Get("X-GitHub-Event") == "push"
+
+	case r.Header.Get("X-Gitlab-Token") != "":
+		if subtle.ConstantTimeCompare(
+			[]byte(r.Header.Get("X-Gitlab-Token")), []byte(trigger.Secret)) != 1 {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		push = r.Header.Get("X-Gitlab-Event") == "Push Hook"
+
+	default:
+		http.Error(w, "payload is not signed", http.StatusUnauthorized)
+		return
+	}
+	if !push {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var payload webhookPush
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Deleted branches have no commit to deploy.
+	if payload.Deleted || strings.Trim(payload.After, "0") == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if trigger.Branch != "" && payload.Ref != "refs/heads/"+trigger.Branch {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	p, err := s.state.ProjectGet(trigger.Project)
+	if err != nil {
+		log.Warn("error getting project", "project", trigger.Project.Project, "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("push received, queueing up jobs",
+		"project", p.Name, "ref", payload.Ref, "commit", payload.After)
+	ids, err := s.queueUpJobs(r.Context(), p, payload.After, map[string]string{
+		"waypoint/trigger":        trigger.Id,
+		"waypoint/trigger-commit": payload.After,
+	})
+	if err != nil {
+		log.Warn("error queueing jobs", "project", p.Name, "err", err)
+		http.Error(w, "error queueing jobs", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string][]string{"job_ids": ids})
+}
+
+// webhookSignatureValid returns true if sig is the "sha256=" prefixed
+// HMAC-SHA256 of body with the secret, as sent by GitHub.
+func webhookSignatureValid(secret string, body []byte, sig string) bool {
+	if !strings.HasPrefix(sig, "sha256=") {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+var _ server.WebhookHandler = (*service)(nil)
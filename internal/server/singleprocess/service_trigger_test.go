@@ -0,0 +1,147 @@
+package singleprocess
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/server"
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServiceTrigger(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	s := impl.(*service)
+
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{
+			Name: "p_test",
+			DataSource: &pb.Job_DataSource{
+				Source: &pb.Job_DataSource_Git{
+					Git: &pb.Job_Git{Url: "https://example.com/repo.git"},
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	_, err = client.UpsertApplication(ctx, &pb.UpsertApplicationRequest{
+		Project: &pb.Ref_Project{Project: "p_test"},
+		Name:    "a_test",
+	})
+	require.NoError(err)
+
+	resp, err := client.CreateTrigger(ctx, &pb.CreateTriggerRequest{
+		Project: &pb.Ref_Project{Project: "p_test"},
+		Branch:  "main",
+	})
+	require.NoError(err)
+	require.NotEmpty(resp.Trigger.Secret)
+	secret := resp.Trigger.Secret
+
+	send := func(ref string, headers map[string]string) int {
+		body := []byte(`{"ref":"` + ref + `","after":"abc123"}`)
+		req := httptest.NewRequest("POST", resp.Path, bytes.NewReader(body))
+		for k, v := range headers {
+			if v == "sign" {
+				mac := hmac.New(sha256.New, []byte(secret))
+				mac.Write(body)
+				v = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			}
+
+			req.Header.Set(k, v)
+		}
+
+		rec := httptest.NewRecorder()
+		s.ServeWebhook(rec, req)
+		return rec.Code
+	}
+
+	listJobs := func() []*pb.Job {
+		resp, err := client.ListJobs(ctx, &pb.ListJobsRequest{
+			Project: &pb.Ref_Project{Project: "p_test"},
+		})
+		require.NoError(err)
+		return resp.Jobs
+	}
+
+	// Unsigned and badly signed payloads are rejected
+	require.Equal(http.StatusUnauthorized, send("refs/heads/main", nil))
+	require.Equal(http.StatusUnauthorized, send("refs/heads/main", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": "sha256=00",
+	}))
+	require.Equal(http.StatusUnauthorized, send("refs/heads/main", map[string]string{
+		"X-Gitlab-Event": "Push Hook",
+		"X-Gitlab-Token": "wrong",
+	}))
+
+	// Pings and other branches are ignored
+	require.Equal(http.StatusNoContent, send("refs/heads/main", map[string]string{
+		"X-GitHub-Event":      "ping",
+		"X-Hub-Signature-256": "sign",
+	}))
+	require.Equal(http.StatusNoContent, send("refs/heads/other", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": "sign",
+	}))
+	require.Empty(listJobs())
+
+	// A GitHub push queues an up job at the commit
+	require.Equal(http.StatusAccepted, send("refs/heads/main", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": "sign",
+	}))
+	jobs := listJobs()
+	require.Len(jobs, 1)
+	require.IsType(&pb.Job_Up{}, jobs[0].Operation)
+	require.Equal("abc123", jobs[0].DataSource.Source.(*pb.Job_DataSource_Git).Git.Ref)
+
+	// So does a GitLab push
+	require.Equal(http.StatusAccepted, send("refs/heads/main", map[string]string{
+		"X-Gitlab-Event": "Push Hook",
+		"X-Gitlab-Token": secret,
+	}))
+	require.Len(listJobs(), 2)
+
+	// Deleted triggers are not found
+	_, err = client.DeleteTrigger(ctx, &pb.DeleteTriggerRequest{Id: resp.Trigger.Id})
+	require.NoError(err)
+	require.Equal(http.StatusNotFound, send("refs/heads/main", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": "sign",
+	}))
+}
+
+func TestServiceCreateTrigger_noGit(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	_, err = client.UpsertProject(ctx, &pb.UpsertProjectRequest{
+		Project: &pb.Project{Name: "p_test"},
+	})
+	require.NoError(err)
+
+	_, err = client.CreateTrigger(ctx, &pb.CreateTriggerRequest{
+		Project: &pb.Ref_Project{Project: "p_test"},
+	})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
@@ -33,3 +33,14 @@ func (s *service) ListWorkspaces(
 
 	return &pb.ListWorkspacesResponse{Workspaces: result}, nil
 }
+
+func (s *service) DeleteWorkspace(
+	ctx context.Context,
+	req *pb.DeleteWorkspaceRequest,
+) (*empty.Empty, error) {
+	if err := s.state.WorkspaceDelete(req.Workspace.Workspace, req.Force); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 // appOperation is an abstraction on any "operation" that may happen to
@@ -237,6 +238,13 @@ func (op *appOperation) List(s *State, opts *listOperationsOptions) ([]interface
 				}
 			}
 
+			if len(opts.Labels) > 0 {
+				labels, _ := op.valueField(value, "Labels").(map[string]string)
+				if !opts.Labels.Match(labels) {
+					continue
+				}
+			}
+
 			result = append(result, value)
 
 			// If we have a limit, check that now
@@ -406,6 +414,59 @@ func (op *appOperation) dbPut(
 	return op.indexPut(s, memTxn, value)
 }
 
+// workspaceRecords returns the index records of all the operations in
+// the given workspace. The workspace name is matched case-insensitively.
+func (op *appOperation) workspaceRecords(
+	memTxn *memdb.Txn,
+	ws string,
+) ([]*operationIndexRecord, error) {
+	iter, err := memTxn.Get(op.memTableName(), opIdIndexName+"_prefix", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*operationIndexRecord
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		record := raw.(*operationIndexRecord)
+		if strings.EqualFold(record.Workspace, ws) {
+			result = append(result, record)
+		}
+	}
+
+	return result, nil
+}
+
+// dbDeleteWorkspace deletes all the operation records in the given
+// workspace along with their index records. It expects to hold a write
+// transaction to both bolt and memdb.
+func (op *appOperation) dbDeleteWorkspace(
+	dbTxn *bolt.Tx,
+	memTxn *memdb.Txn,
+	ws string,
+) error {
+	records, err := op.workspaceRecords(memTxn, ws)
+	if err != nil {
+		return err
+	}
+
+	b := dbTxn.Bucket(op.Bucket)
+	for _, record := range records {
+		if err := b.Delete([]byte(record.Id)); err != nil {
+			return err
+		}
+		if err := memTxn.Delete(op.memTableName(), record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // appSeq gets the pointer to the sequence number for the given application.
 // This can only safely be called while holding the memdb write transaction.
 func (op *appOperation) appSeq(ref *pb.Ref_Application) *uint64 {
@@ -677,6 +738,7 @@ type listOperationsOptions struct {
 	Status        []*pb.StatusFilter
 	Order         *pb.OperationOrder
 	PhysicalState pb.Operation_PhysicalState
+	Labels        serverptypes.LabelSelector
 }
 
 func buildListOperationsOptions(ref *pb.Ref_Application, opts ...ListOperationOption) *listOperationsOptions {
@@ -720,6 +782,14 @@ func ListWithWorkspace(f *pb.Ref_Workspace) ListOperationOption {
 	}
 }
 
+// ListWithLabelSelector only lists operations whose labels match the
+// selector.
+func ListWithLabelSelector(f serverptypes.LabelSelector) ListOperationOption {
+	return func(opts *listOperationsOptions) {
+		opts.Labels = f
+	}
+}
+
 // statusFilterMatch is a helper that compares a pb.Status to a set of
 // StatusFilters. This returns true if the filters match.
 func statusFilterMatch(
@@ -57,3 +57,27 @@ func (s *State) ArtifactLatest(
 
 	return result.(*pb.PushedArtifact), nil
 }
+
+// ArtifactDeployments returns the IDs of the deployments of the application
+// that haven't been destroyed keyed by the ID of the artifact they deployed.
+// If ws is nil, deployments in all workspaces are checked.
+func (s *State) ArtifactDeployments(
+	ref *pb.Ref_Application,
+	ws *pb.Ref_Workspace,
+) (map[string][]string, error) {
+	deployments, err := s.DeploymentList(ref, ListWithWorkspace(ws))
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]string{}
+	for _, d := range deployments {
+		if d.State == pb.Operation_DESTROYED || d.ArtifactId == "" {
+			continue
+		}
+
+		result[d.ArtifactId] = append(result[d.ArtifactId], d.Id)
+	}
+
+	return result, nil
+}
@@ -2,8 +2,62 @@ package state
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 func TestArtifact(t *testing.T) {
 	artifactOp.Test(t)
 }
+
+func TestArtifactDeployments(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	ref := &pb.Ref_Application{Application: "a_test", Project: "p_test"}
+
+	// A live deployment and a destroyed deployment of different artifacts
+	require.NoError(s.DeploymentPut(false, serverptypes.TestValidDeployment(t, &pb.Deployment{
+		Id:         "1",
+		ArtifactId: "live",
+		State:      pb.Operation_CREATED,
+	})))
+	require.NoError(s.DeploymentPut(false, serverptypes.TestValidDeployment(t, &pb.Deployment{
+		Id:         "2",
+		ArtifactId: "gone",
+		State:      pb.Operation_DESTROYED,
+	})))
+
+	result, err := s.ArtifactDeployments(ref, nil)
+	require.NoError(err)
+	require.Equal(map[string][]string{"live": {"1"}}, result)
+
+	// Destroyed deployments aren't returned
+	d, err := s.DeploymentGet(&pb.Ref_Operation{
+		Target: &pb.Ref_Operation_Id{Id: "1"},
+	})
+	require.NoError(err)
+	d.State = pb.Operation_DESTROYED
+	require.NoError(s.DeploymentPut(true, d))
+
+	result, err = s.ArtifactDeployments(ref, nil)
+	require.NoError(err)
+	require.Empty(result)
+
+	// Other workspaces aren't checked if one is given
+	require.NoError(s.DeploymentPut(false, serverptypes.TestValidDeployment(t, &pb.Deployment{
+		Id:         "3",
+		ArtifactId: "staging",
+		State:      pb.Operation_CREATED,
+		Workspace:  &pb.Ref_Workspace{Workspace: "staging"},
+	})))
+
+	result, err = s.ArtifactDeployments(ref, &pb.Ref_Workspace{Workspace: "default"})
+	require.NoError(err)
+	require.Empty(result)
+}
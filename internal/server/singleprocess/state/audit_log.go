@@ -0,0 +1,74 @@
+package state
+
+import (
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var auditLogBucket = []byte("audit-log")
+
+func init() {
+	dbBuckets = append(dbBuckets, auditLogBucket)
+}
+
+// AuditLogAppend records the event at the end of the audit log. The index
+// of the event is set by this call.
+func (s *State) AuditLogAppend(e *pb.AuditEvent) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(auditLogBucket)
+		idx, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		e.Index = idx
+		return dbPut(b, auditLogKey(idx), e)
+	})
+}
+
+// AuditLogList returns the limit most recent audit events, oldest first.
+// If limit is zero all events are returned.
+func (s *State) AuditLogList(limit uint32) ([]*pb.AuditEvent, error) {
+	var result []*pb.AuditEvent
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		c := dbTxn.Bucket(auditLogBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if limit > 0 && len(result) >= int(limit) {
+				break
+			}
+
+			var value pb.AuditEvent
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+
+			result = append(result, &value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// We read newest first so reverse to return oldest first.
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return result, nil
+}
+
+// auditLogKey returns the bucket key for an event. Keys are big endian so
+// that the bucket iterates in the order events were recorded.
+func auditLogKey(idx uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, idx)
+	return key
+}
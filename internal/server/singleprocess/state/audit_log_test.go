@@ -0,0 +1,42 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Empty to start
+	events, err := s.AuditLogList(0)
+	require.NoError(err)
+	require.Empty(events)
+
+	for _, endpoint := range []string{"A", "B", "C"} {
+		e := &pb.AuditEvent{Endpoint: endpoint}
+		require.NoError(s.AuditLogAppend(e))
+		require.NotZero(e.Index)
+	}
+
+	// All
+	events, err = s.AuditLogList(0)
+	require.NoError(err)
+	require.Len(events, 3)
+	require.Equal("A", events[0].Endpoint)
+	require.Equal(uint64(1), events[0].Index)
+	require.Equal("C", events[2].Endpoint)
+
+	// Limited to the most recent, still oldest first
+	events, err = s.AuditLogList(2)
+	require.NoError(err)
+	require.Len(events, 2)
+	require.Equal("B", events[0].Endpoint)
+	require.Equal("C", events[1].Endpoint)
+}
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var authMethodBucket = []byte("auth_methods")
+
+func init() {
+	dbBuckets = append(dbBuckets, authMethodBucket)
+}
+
+// AuthMethodPut creates or updates an auth method.
+func (s *State) AuthMethodPut(v *pb.AuthMethod) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(authMethodBucket), []byte(v.Name), v)
+	})
+}
+
+// AuthMethodGet gets an auth method by name.
+func (s *State) AuthMethodGet(name string) (*pb.AuthMethod, error) {
+	var result pb.AuthMethod
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(authMethodBucket), []byte(name), &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AuthMethodList lists all the auth methods, sorted by name.
+func (s *State) AuthMethodList() ([]*pb.AuthMethod, error) {
+	var result []*pb.AuthMethod
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(authMethodBucket).ForEach(func(k, v []byte) error {
+			var m pb.AuthMethod
+			if err := proto.Unmarshal(v, &m); err != nil {
+				return err
+			}
+
+			result = append(result, &m)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AuthMethodDelete deletes an auth method by name. It isn't an error if
+// the auth method doesn't exist.
+func (s *State) AuthMethodDelete(name string) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(authMethodBucket).Delete([]byte(name))
+	})
+}
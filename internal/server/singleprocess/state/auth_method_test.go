@@ -0,0 +1,52 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestAuthMethod(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Not found
+	_, err := s.AuthMethodGet("b")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	for _, name := range []string{"b", "a"} {
+		require.NoError(s.AuthMethodPut(&pb.AuthMethod{
+			Name: name,
+			Method: &pb.AuthMethod_Oidc{
+				Oidc: &pb.AuthMethod_OIDC{ClientId: name},
+			},
+		}))
+	}
+
+	v, err := s.AuthMethodGet("b")
+	require.NoError(err)
+	require.Equal("b", v.GetOidc().ClientId)
+
+	// List is sorted by name
+	list, err := s.AuthMethodList()
+	require.NoError(err)
+	require.Len(list, 2)
+	require.Equal("a", list[0].Name)
+	require.Equal("b", list[1].Name)
+
+	// Delete
+	require.NoError(s.AuthMethodDelete("b"))
+	_, err = s.AuthMethodGet("b")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// Deleting again is fine
+	require.NoError(s.AuthMethodDelete("b"))
+}
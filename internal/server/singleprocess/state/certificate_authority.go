@@ -0,0 +1,64 @@
+package state
+
+import (
+	"github.com/boltdb/bolt"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var (
+	caBucket = []byte("certificate_authority")
+	caKey    = []byte("entrypoint")
+)
+
+func init() {
+	dbBuckets = append(dbBuckets, caBucket)
+}
+
+// CertificateAuthorityGet gets the entrypoint CA. This returns nil if the
+// CA hasn't been created yet.
+func (s *State) CertificateAuthorityGet() (*pb.CertificateAuthority, error) {
+	var result *pb.CertificateAuthority
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		var err error
+		result, err = s.caGet(dbTxn)
+		return err
+	})
+
+	return result, err
+}
+
+// CertificateAuthorityCreateIfNotExist stores the given entrypoint CA if
+// there isn't one already. The stored CA is returned, which is ca only if
+// it didn't exist.
+func (s *State) CertificateAuthorityCreateIfNotExist(
+	ca *pb.CertificateAuthority,
+) (*pb.CertificateAuthority, error) {
+	var result *pb.CertificateAuthority
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		var err error
+		result, err = s.caGet(dbTxn)
+		if err != nil || result != nil {
+			return err
+		}
+
+		result = ca
+		return dbPut(dbTxn.Bucket(caBucket), caKey, ca)
+	})
+
+	return result, err
+}
+
+func (s *State) caGet(dbTxn *bolt.Tx) (*pb.CertificateAuthority, error) {
+	b := dbTxn.Bucket(caBucket)
+	if b.Get(caKey) == nil {
+		return nil, nil
+	}
+
+	var result pb.CertificateAuthority
+	if err := dbGet(b, caKey, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
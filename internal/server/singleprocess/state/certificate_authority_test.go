@@ -0,0 +1,38 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// None yet
+	ca, err := s.CertificateAuthorityGet()
+	require.NoError(err)
+	require.Nil(ca)
+
+	ca, err = s.CertificateAuthorityCreateIfNotExist(&pb.CertificateAuthority{
+		Cert: []byte("a"),
+	})
+	require.NoError(err)
+	require.Equal([]byte("a"), ca.Cert)
+
+	// The existing CA wins
+	ca, err = s.CertificateAuthorityCreateIfNotExist(&pb.CertificateAuthority{
+		Cert: []byte("b"),
+	})
+	require.NoError(err)
+	require.Equal([]byte("a"), ca.Cert)
+
+	ca, err = s.CertificateAuthorityGet()
+	require.NoError(err)
+	require.Equal([]byte("a"), ca.Cert)
+}
@@ -71,7 +71,7 @@ func (s *State) configSet(
 
 	// Get the global bucket and write the value to it.
 	b := dbTxn.Bucket(configBucket)
-	if value.Value == "" {
+	if configVarEmpty(value) {
 		if err := b.Delete(id); err != nil {
 			return err
 		}
@@ -85,6 +85,12 @@ func (s *State) configSet(
 	return s.configIndexSet(memTxn, id, value)
 }
 
+// configVarEmpty returns true if the variable has no value, which means
+// that setting it deletes it. Dynamic variables have no static value.
+func configVarEmpty(v *pb.ConfigVar) bool {
+	return v.Value == "" && v.Dynamic == nil
+}
+
 func (s *State) configGetMerged(
 	dbTxn *bolt.Tx,
 	memTxn *memdb.Txn,
@@ -306,7 +312,7 @@ func (s *State) configIndexSet(txn *memdb.Txn, id []byte, value *pb.ConfigVar) e
 	}
 
 	// If we have no value, we delete from the memdb index
-	if value.Value == "" {
+	if configVarEmpty(value) {
 		return txn.Delete(configIndexTableName, record)
 	}
 
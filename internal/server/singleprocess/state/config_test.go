@@ -69,6 +69,43 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("dynamic values", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// A dynamic value has no static value but isn't a delete
+		project := &pb.ConfigVar_Project{Project: &pb.Ref_Project{Project: "foo"}}
+		require.NoError(s.ConfigSet(&pb.ConfigVar{
+			Scope: project,
+			Name:  "DB_PASSWORD",
+			Dynamic: &pb.ConfigVar_DynamicVal{
+				From:   "vault",
+				Config: map[string]string{"path": "secret/data/db", "key": "password"},
+			},
+		}))
+
+		req := &pb.ConfigGetRequest{
+			Scope: &pb.ConfigGetRequest_Project{
+				Project: &pb.Ref_Project{Project: "foo"},
+			},
+		}
+		vs, err := s.ConfigGet(req)
+		require.NoError(err)
+		require.Len(vs, 1)
+		require.Equal("vault", vs[0].Dynamic.From)
+
+		// Setting it without any value deletes it
+		require.NoError(s.ConfigSet(&pb.ConfigVar{
+			Scope: project,
+			Name:  "DB_PASSWORD",
+		}))
+		vs, err = s.ConfigGet(req)
+		require.NoError(err)
+		require.Empty(vs)
+	})
+
 	t.Run("merging", func(t *testing.T) {
 		require := require.New(t)
 
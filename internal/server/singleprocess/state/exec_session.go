@@ -0,0 +1,90 @@
+package state
+
+import (
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var execSessionBucket = []byte("exec-session")
+
+func init() {
+	dbBuckets = append(dbBuckets, execSessionBucket)
+}
+
+// ExecSessionAppend records an exec session. The index of the session is
+// set by this call.
+func (s *State) ExecSessionAppend(e *pb.ExecSession) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(execSessionBucket)
+		idx, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		e.Index = idx
+		return dbPut(b, execSessionKey(idx), e)
+	})
+}
+
+// ExecSessionGet gets a recorded exec session by index.
+func (s *State) ExecSessionGet(idx uint64) (*pb.ExecSession, error) {
+	var result pb.ExecSession
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(execSessionBucket), execSessionKey(idx), &result)
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "exec session %d not found", idx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ExecSessionList returns the limit most recent exec sessions, newest
+// first, without their output. If deploymentId is set, only sessions for
+// that deployment are returned. If limit is zero all sessions are returned.
+func (s *State) ExecSessionList(deploymentId string, limit uint32) ([]*pb.ExecSession, error) {
+	var result []*pb.ExecSession
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		c := dbTxn.Bucket(execSessionBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if limit > 0 && len(result) >= int(limit) {
+				break
+			}
+
+			var value pb.ExecSession
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+			if deploymentId != "" && value.DeploymentId != deploymentId {
+				continue
+			}
+
+			value.Output = nil
+			result = append(result, &value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// execSessionKey returns the bucket key for a session. Like the audit log,
+// keys are big endian so the bucket iterates in the order recorded.
+func execSessionKey(idx uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, idx)
+	return key
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestExecSession(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Empty to start
+	sessions, err := s.ExecSessionList("", 0)
+	require.NoError(err)
+	require.Empty(sessions)
+
+	_, err = s.ExecSessionGet(1)
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	for _, id := range []string{"A", "B", "A"} {
+		e := &pb.ExecSession{
+			DeploymentId: id,
+			Output: []*pb.ExecSession_Output{
+				{Data: []byte("hello")},
+			},
+		}
+		require.NoError(s.ExecSessionAppend(e))
+		require.NotZero(e.Index)
+	}
+
+	// All, newest first and without output
+	sessions, err = s.ExecSessionList("", 0)
+	require.NoError(err)
+	require.Len(sessions, 3)
+	require.Equal(uint64(3), sessions[0].Index)
+	require.Empty(sessions[0].Output)
+
+	// By deployment and limited
+	sessions, err = s.ExecSessionList("A", 1)
+	require.NoError(err)
+	require.Len(sessions, 1)
+	require.Equal(uint64(3), sessions[0].Index)
+
+	// Get includes the output
+	e, err := s.ExecSessionGet(2)
+	require.NoError(err)
+	require.Equal("B", e.DeploymentId)
+	require.Len(e.Output, 1)
+}
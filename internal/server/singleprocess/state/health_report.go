@@ -0,0 +1,43 @@
+package state
+
+import (
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var healthReportOp = &appOperation{
+	Struct: (*pb.HealthReport)(nil),
+	Bucket: []byte("health_report"),
+}
+
+func init() {
+	healthReportOp.register()
+}
+
+// HealthReportPut inserts or updates a health report record.
+func (s *State) HealthReportPut(update bool, b *pb.HealthReport) error {
+	return healthReportOp.Put(s, update, b)
+}
+
+// HealthReportGet gets a health report by ref.
+func (s *State) HealthReportGet(ref *pb.Ref_Operation) (*pb.HealthReport, error) {
+	result, err := healthReportOp.Get(s, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*pb.HealthReport), nil
+}
+
+// HealthReportLatest gets the latest health check that was completed
+// successfully. The deployment may still be unhealthy.
+func (s *State) HealthReportLatest(
+	ref *pb.Ref_Application,
+	ws *pb.Ref_Workspace,
+) (*pb.HealthReport, error) {
+	result, err := healthReportOp.Latest(s, ref, ws)
+	if result == nil || err != nil {
+		return nil, err
+	}
+
+	return result.(*pb.HealthReport), nil
+}
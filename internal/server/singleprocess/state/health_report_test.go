@@ -0,0 +1,9 @@
+package state
+
+import (
+	"testing"
+)
+
+func TestHealthReport(t *testing.T) {
+	healthReportOp.Test(t)
+}
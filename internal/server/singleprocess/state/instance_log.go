@@ -0,0 +1,142 @@
+package state
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// instanceLogBucket has a nested bucket for each app in each workspace.
+// Every line is its own key so retention can be enforced per line.
+var instanceLogBucket = []byte("instance-log")
+
+func init() {
+	dbBuckets = append(dbBuckets, instanceLogBucket)
+}
+
+// InstanceLogAppend stores the lines of the batch for the app in the
+// workspace. The oldest lines are deleted so that at most retention lines
+// are kept.
+func (s *State) InstanceLogAppend(
+	ref *pb.Ref_Application,
+	ws *pb.Ref_Workspace,
+	batch *pb.LogBatch,
+	retention uint32,
+) error {
+	if len(batch.Lines) == 0 {
+		return nil
+	}
+
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b, err := dbTxn.Bucket(instanceLogBucket).CreateBucketIfNotExists(
+			instanceLogAppKey(ref, ws))
+		if err != nil {
+			return err
+		}
+
+		var idx uint64
+		for _, line := range batch.Lines {
+			idx, err = b.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			if err := dbPut(b, instanceLogKey(idx), &pb.LogBatch{
+				DeploymentId: batch.DeploymentId,
+				InstanceId:   batch.InstanceId,
+				Lines:        []*pb.LogBatch_Entry{line},
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Keys are sequential so the number of lines is the distance from
+		// the first key to the last. We delete after iterating since
+		// deleting with the cursor skips keys.
+		var expired [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if idx-binary.BigEndian.Uint64(k) < uint64(retention) {
+				break
+			}
+
+			expired = append(expired, k)
+		}
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// InstanceLogList returns the limit most recent stored lines for the app
+// in the workspace, oldest first. Each batch has a single line. If
+// deploymentId is set only lines from that deployment are returned. If
+// limit is zero all stored lines are returned.
+func (s *State) InstanceLogList(
+	ref *pb.Ref_Application,
+	ws *pb.Ref_Workspace,
+	deploymentId string,
+	limit uint32,
+) ([]*pb.LogBatch, error) {
+	var result []*pb.LogBatch
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(instanceLogBucket).Bucket(instanceLogAppKey(ref, ws))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if limit > 0 && len(result) >= int(limit) {
+				break
+			}
+
+			var value pb.LogBatch
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+			if deploymentId != "" && value.DeploymentId != deploymentId {
+				continue
+			}
+
+			result = append(result, &value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// We read newest first so reverse to return oldest first.
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return result, nil
+}
+
+// instanceLogAppKey returns the name of the nested bucket for the app in
+// the workspace.
+func instanceLogAppKey(ref *pb.Ref_Application, ws *pb.Ref_Workspace) []byte {
+	return []byte(strings.ToLower(
+		ref.Project + "/" + ref.Application + "/" + ws.Workspace))
+}
+
+// instanceLogKey returns the key of a line. Keys are big endian so that
+// the bucket iterates in the order the lines were stored.
+func instanceLogKey(idx uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, idx)
+	return key
+}
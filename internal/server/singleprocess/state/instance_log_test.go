@@ -0,0 +1,61 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestInstanceLog(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	ref := &pb.Ref_Application{Project: "p", Application: "a"}
+	ws := &pb.Ref_Workspace{Workspace: "default"}
+
+	// Empty to start
+	lines, err := s.InstanceLogList(ref, ws, "", 0)
+	require.NoError(err)
+	require.Empty(lines)
+
+	batch := func(id string, values ...string) *pb.LogBatch {
+		result := &pb.LogBatch{DeploymentId: id, InstanceId: "i-" + id}
+		for _, v := range values {
+			result.Lines = append(result.Lines, &pb.LogBatch_Entry{Line: v})
+		}
+
+		return result
+	}
+
+	require.NoError(s.InstanceLogAppend(ref, ws, batch("A", "1", "2"), 3))
+	require.NoError(s.InstanceLogAppend(ref, ws, batch("B", "3", "4"), 3))
+
+	// Only the retention is kept, oldest first
+	lines, err = s.InstanceLogList(ref, ws, "", 0)
+	require.NoError(err)
+	require.Len(lines, 3)
+	require.Equal("2", lines[0].Lines[0].Line)
+	require.Equal("A", lines[0].DeploymentId)
+	require.Equal("4", lines[2].Lines[0].Line)
+
+	// Limited to the most recent
+	lines, err = s.InstanceLogList(ref, ws, "", 1)
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal("4", lines[0].Lines[0].Line)
+
+	// By deployment
+	lines, err = s.InstanceLogList(ref, ws, "A", 0)
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal("2", lines[0].Lines[0].Line)
+
+	// Other workspaces are separate
+	lines, err = s.InstanceLogList(ref, &pb.Ref_Workspace{Workspace: "prod"}, "", 0)
+	require.NoError(err)
+	require.Empty(lines)
+}
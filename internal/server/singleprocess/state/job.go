@@ -72,6 +72,10 @@ func jobSchema() *memdb.TableSchema {
 							Field: "State",
 						},
 
+						&memdb.UintFieldIndex{
+							Field: "PriorityOrder",
+						},
+
 						&IndexTime{
 							Field: "QueueTime",
 							Asc:   true,
@@ -95,6 +99,10 @@ func jobSchema() *memdb.TableSchema {
 							Lowercase: true,
 						},
 
+						&memdb.UintFieldIndex{
+							Field: "PriorityOrder",
+						},
+
 						&IndexTime{
 							Field: "QueueTime",
 							Asc:   true,
@@ -120,12 +128,22 @@ type jobIndex struct {
 	// QueueTime is the time that the job was queued.
 	QueueTime time.Time
 
+	// PriorityOrder is the order jobs are assigned in by priority. Jobs
+	// with a lower order are assigned first. See jobPriorityOrder.
+	PriorityOrder uint8
+
 	// TargetAny will be true if this job targets anything
 	TargetAny bool
 
 	// TargetRunnerId is the ID of the runner to target.
 	TargetRunnerId string
 
+	// TargetLabels are the labels a runner must have to run this job.
+	TargetLabels map[string]string
+
+	// AssignedRunnerId is the ID of the runner the job is assigned to.
+	AssignedRunnerId string
+
 	// State is the current state of this job.
 	State pb.Job_State
 
@@ -191,6 +209,9 @@ func (s *State) JobList() ([]*pb.Job, error) {
 			job, err = s.jobById(dbTxn, idx.Id)
 			return err
 		})
+		if err != nil {
+			return nil, err
+		}
 
 		result = append(result, job)
 	}
@@ -252,14 +273,37 @@ RETRY_ASSIGN:
 	txn := s.inmem.Txn(false)
 	defer txn.Abort()
 
+	// If the runner is running as many jobs as it may, we wait for one of
+	// them to end before looking for candidates.
+	ws := memdb.NewWatchSet()
+	if atLimit, err := s.jobRunnerAtLimit(txn, ws, r); err != nil {
+		return nil, err
+	} else if atLimit {
+		txn.Abort()
+		ws.WatchCtx(ctx)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		goto RETRY_ASSIGN
+	}
+
 	// Turn our runner into a runner record so we can more efficiently assign
 	runnerRec := newRunnerRecord(r)
 
+	// If the server config uses the fair policy, this orders the jobs
+	// of the same priority. See job_fair.go.
+	fair, err := s.jobFairness(txn)
+	if err != nil {
+		return nil, err
+	}
+
 	// candidateQuery finds candidate jobs to assign.
-	type candidateFunc func(*memdb.Txn, memdb.WatchSet, *runnerRecord) (*jobIndex, error)
+	type candidateFunc func(*memdb.Txn, memdb.WatchSet, *runnerRecord, *jobFairness) (*jobIndex, error)
 	candidateQuery := []candidateFunc{
 		s.jobCandidateById,
 		s.jobCandidateAny,
+		s.jobCandidateLabels,
 	}
 
 	// If the runner is by id only, then explicitly set it to by id only.
@@ -271,9 +315,8 @@ RETRY_ASSIGN:
 
 	// Build the list of candidates
 	var candidates []*jobIndex
-	ws := memdb.NewWatchSet()
 	for _, f := range candidateQuery {
-		job, err := f(txn, ws, runnerRec)
+		job, err := f(txn, ws, runnerRec, fair)
 		if err != nil {
 			return nil, err
 		}
@@ -309,8 +352,16 @@ RETRY_ASSIGN:
 		goto RETRY_ASSIGN
 	}
 
-	// We sort our candidates by queue time so that we can find the earliest
+	// We sort our candidates by priority and then queue time so that we
+	// can find the earliest job with the highest priority
 	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].PriorityOrder != candidates[j].PriorityOrder {
+			return candidates[i].PriorityOrder < candidates[j].PriorityOrder
+		}
+		if fair != nil {
+			return fair.less(candidates[i], candidates[j])
+		}
+
 		return candidates[i].QueueTime.Before(candidates[j].QueueTime)
 	})
 
@@ -350,10 +401,19 @@ RETRY_ASSIGN:
 			return nil, err
 		}
 
+		// The runner may have been assigned other jobs concurrently.
+		if atLimit, err := s.jobRunnerAtLimit(txn, nil, r); atLimit {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
 		// Update our state and update our on-disk job
 		job.State = pb.Job_WAITING
+		job.AssignedRunnerId = r.Id
 		result, err := s.jobReadAndUpdate(job.Id, func(jobpb *pb.Job) error {
 			jobpb.State = job.State
+			jobpb.AssignedRunner = &pb.Ref_RunnerId{Id: r.Id}
 			jobpb.AssignTime, err = ptypes.TimestampProto(time.Now())
 			if err != nil {
 				// This should never happen since encoding a time now should be safe
@@ -381,6 +441,10 @@ RETRY_ASSIGN:
 			s.JobAck(job.Id, false)
 			return nil, err
 		}
+		if err := s.jobFairAssigned(txn, job); err != nil {
+			s.JobAck(job.Id, false)
+			return nil, err
+		}
 
 		txn.Commit()
 		return job.Job(result), nil
@@ -415,25 +479,43 @@ func (s *State) JobAck(id string, ack bool) (*Job, error) {
 			job.State.String())
 	}
 
+	// The server config can set the timeout of the job
+	cfg, err := s.ServerConfigGet()
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := s.jobReadAndUpdate(job.Id, func(jobpb *pb.Job) error {
 		if ack {
 			// Set to accepted
+			now := time.Now()
 			job.State = pb.Job_RUNNING
 			jobpb.State = job.State
-			jobpb.AckTime, err = ptypes.TimestampProto(time.Now())
+			jobpb.AckTime, err = ptypes.TimestampProto(now)
 			if err != nil {
 				// This should never happen since encoding a time now should be safe
 				panic("time encoding failed: " + err.Error())
 			}
 
+			// The job timeout starts once the job is accepted
+			jobpb.TimeoutTime = nil
+			if timeout := jobTimeout(jobpb, cfg); timeout > 0 {
+				jobpb.TimeoutTime, err = ptypes.TimestampProto(now.Add(timeout))
+				if err != nil {
+					return err
+				}
+			}
+
 			// We also initialize the output buffer here because we can
 			// expect output to begin streaming in.
 			job.OutputBuffer = logbuffer.New()
 		} else {
 			// Set to queued
 			job.State = pb.Job_QUEUED
+			job.AssignedRunnerId = ""
 			jobpb.State = job.State
 			jobpb.AssignTime = nil
+			jobpb.AssignedRunner = nil
 		}
 
 		return nil
@@ -474,6 +556,13 @@ func (s *State) JobAck(id string, ack bool) (*Job, error) {
 	}
 
 	txn.Commit()
+
+	if ack {
+		if err := s.jobTimeoutTimer(result); err != nil {
+			return nil, err
+		}
+	}
+
 	return job.Job(result), nil
 }
 
@@ -573,14 +662,14 @@ func (s *State) jobCancel(txn *memdb.Txn, job *jobIndex, force bool) error {
 
 	// How we handle cancel depends on the state
 	switch job.State {
-	case pb.Job_ERROR, pb.Job_SUCCESS:
+	case pb.Job_ERROR, pb.Job_SUCCESS, pb.Job_TIMED_OUT:
 		s.log.Debug("attempted to cancel completed job", "state", job.State.String(), "job", job.Id)
 		// Jobs that are already completed do nothing for cancellation.
 		// We do not mark that they were requested as cancelled since they
 		// completed fine.
 		return nil
 
-	case pb.Job_QUEUED:
+	case pb.Job_QUEUED, pb.Job_WAITING_APPROVAL:
 		// For queued jobs, we immediately transition them to an error state.
 		job.State = pb.Job_ERROR
 
@@ -682,6 +771,72 @@ func (s *State) jobHeartbeat(txn *memdb.Txn, id string) error {
 	return nil
 }
 
+// jobRunnerLost handles the jobs assigned to a runner that stopped
+// heartbeating. If requeue is true the jobs are queued again so another
+// runner can run them, otherwise they're errored.
+func (s *State) jobRunnerLost(txn *memdb.Txn, runnerId string, requeue bool) error {
+	// Find the jobs first since we modify the index below
+	var jobs []*jobIndex
+	for _, jobState := range []pb.Job_State{pb.Job_WAITING, pb.Job_RUNNING} {
+		iter, err := txn.Get(jobTableName, jobStateIndexName, jobState)
+		if err != nil {
+			return err
+		}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			job := raw.(*jobIndex)
+			if job.AssignedRunnerId == runnerId {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+
+	for _, job := range jobs {
+		s.log.Info("handling job of lost runner",
+			"job", job.Id, "runner_id", runnerId, "requeue", requeue)
+
+		// Stop the ack or heartbeat timer of the job
+		job.End()
+
+		if requeue {
+			job.State = pb.Job_QUEUED
+		} else {
+			job.State = pb.Job_ERROR
+		}
+		job.AssignedRunnerId = ""
+
+		_, err := s.jobReadAndUpdate(job.Id, func(jobpb *pb.Job) error {
+			jobpb.State = job.State
+			if requeue {
+				jobpb.AssignTime = nil
+				jobpb.AckTime = nil
+				jobpb.AssignedRunner = nil
+				jobpb.TimeoutTime = nil
+				return nil
+			}
+
+			jobpb.CompleteTime = ptypes.TimestampNow()
+			jobpb.Error = status.Newf(codes.Unavailable,
+				"runner %s stopped heartbeating", runnerId).Proto()
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := txn.Insert(jobTableName, job); err != nil {
+			return err
+		}
+
+		// Unblock other jobs for the app
+		if err := s.jobAssignedSet(txn, job, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // JobExpire expires a job. This will cancel the job if it is still queued.
 func (s *State) JobExpire(id string) error {
 	txn := s.inmem.Txn(true)
@@ -697,6 +852,26 @@ func (s *State) JobExpire(id string) error {
 	}
 	job := raw.(*jobIndex)
 
+	// The expiry is restarted when a job is approved, so we ignore the
+	// timer from when the job was first queued.
+	var jobpb *pb.Job
+	err = s.db.View(func(dbTxn *bolt.Tx) error {
+		jobpb, err = s.jobById(dbTxn, id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if jobpb.ApproveTime != nil && jobpb.ExpireTime != nil {
+		t, err := ptypes.Timestamp(jobpb.ExpireTime)
+		if err != nil {
+			return err
+		}
+		if time.Now().Before(t) {
+			return nil
+		}
+	}
+
 	// How we handle depends on the state
 	switch job.State {
 	case pb.Job_QUEUED, pb.Job_WAITING:
@@ -749,6 +924,13 @@ func (s *State) JobIsAssignable(ctx context.Context, jobpb *pb.Job) (bool, error
 	case *pb.Ref_Runner_Id:
 		iter, err = memTxn.Get(runnerTableName, runnerIdIndexName, v.Id.Id)
 
+	case *pb.Ref_Runner_Labels:
+		targetCheck = func(r *pb.Runner) (bool, error) {
+			return !r.ByIdOnly && runnerHasLabels(r, v.Labels.Labels), nil
+		}
+
+		iter, err = memTxn.LowerBound(runnerTableName, runnerIdIndexName, "")
+
 	default:
 		return false, fmt.Errorf("unknown runner target value: %#v", jobpb.TargetRunner.Target)
 	}
@@ -764,6 +946,13 @@ func (s *State) JobIsAssignable(ctx context.Context, jobpb *pb.Job) (bool, error
 		}
 		runner := raw.(*runnerRecord)
 
+		// Runners that stopped heartbeating or that an operator hasn't
+		// adopted aren't assigned jobs
+		if runner.Runner.Health == pb.Runner_UNHEALTHY ||
+			runner.Runner.AdoptionState != pb.Runner_ADOPTED {
+			continue
+		}
+
 		// Check our target-specific check
 		if targetCheck != nil {
 			check, err := targetCheck(runner.Runner)
@@ -813,6 +1002,11 @@ func (s *State) jobIndexSet(txn *memdb.Txn, id []byte, jobpb *pb.Job) (*jobIndex
 		Application: jobpb.Application,
 		Workspace:   jobpb.Workspace,
 		OpType:      reflect.TypeOf(jobpb.Operation),
+
+		PriorityOrder: jobPriorityOrder(jobpb.Priority),
+	}
+	if jobpb.AssignedRunner != nil {
+		rec.AssignedRunnerId = jobpb.AssignedRunner.Id
 	}
 
 	// Target
@@ -826,6 +1020,14 @@ func (s *State) jobIndexSet(txn *memdb.Txn, id []byte, jobpb *pb.Job) (*jobIndex
 	case *pb.Ref_Runner_Id:
 		rec.TargetRunnerId = v.Id.Id
 
+	case *pb.Ref_Runner_Labels:
+		// No labels matches any runner.
+		if len(v.Labels.Labels) == 0 {
+			rec.TargetAny = true
+		} else {
+			rec.TargetLabels = v.Labels.Labels
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown runner target value: %#v", jobpb.TargetRunner.Target)
 	}
@@ -864,6 +1066,14 @@ func (s *State) jobIndexSet(txn *memdb.Txn, id []byte, jobpb *pb.Job) (*jobIndex
 		})
 	}
 
+	// If this job is running with a timeout, we need to restart the timer
+	// that times it out.
+	if rec.State == pb.Job_RUNNING {
+		if err := s.jobTimeoutTimer(jobpb); err != nil {
+			return nil, err
+		}
+	}
+
 	// If we have an expiry, we need to set a timer to expire this job.
 	if jobpb.ExpireTime != nil {
 		now := time.Now()
@@ -881,14 +1091,33 @@ func (s *State) jobIndexSet(txn *memdb.Txn, id []byte, jobpb *pb.Job) (*jobIndex
 		time.AfterFunc(dur, func() { s.JobExpire(jobpb.Id) })
 	}
 
+	// If we're waiting for approval with a timeout, we need a timer to
+	// cancel this job if it isn't approved in time.
+	if jobpb.State == pb.Job_WAITING_APPROVAL && jobpb.ApprovalExpireTime != nil {
+		t, err := ptypes.Timestamp(jobpb.ApprovalExpireTime)
+		if err != nil {
+			return nil, err
+		}
+
+		dur := time.Until(t)
+		if dur < 0 {
+			dur = 1
+		}
+
+		time.AfterFunc(dur, func() { s.JobApprovalExpire(jobpb.Id) })
+	}
+
 	// Insert the index
 	return rec, txn.Insert(jobTableName, rec)
 }
 
 func (s *State) jobCreate(dbTxn *bolt.Tx, memTxn *memdb.Txn, jobpb *pb.Job) error {
-	// Setup our initial job state
+	// Setup our initial job state. Jobs that require approval wait for
+	// it before they're queued.
 	var err error
-	jobpb.State = pb.Job_QUEUED
+	if jobpb.State != pb.Job_WAITING_APPROVAL {
+		jobpb.State = pb.Job_QUEUED
+	}
 	jobpb.QueueTime, err = ptypes.TimestampProto(time.Now())
 	if err != nil {
 		return err
@@ -931,20 +1160,41 @@ func (s *State) jobReadAndUpdate(id string, f func(*pb.Job) error) (*pb.Job, err
 	})
 }
 
+// jobPriorityOrder returns the order that jobs with the given priority
+// are assigned in. This is used in the job indexes so that the oldest job
+// with the highest priority is found first.
+func jobPriorityOrder(p pb.Job_Priority) uint8 {
+	switch p {
+	case pb.Job_HIGH:
+		return 0
+	case pb.Job_LOW:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // jobCandidateById returns the most promising candidate job to assign
 // that is targeting a specific runner by ID.
-func (s *State) jobCandidateById(memTxn *memdb.Txn, ws memdb.WatchSet, r *runnerRecord) (*jobIndex, error) {
+func (s *State) jobCandidateById(
+	memTxn *memdb.Txn,
+	ws memdb.WatchSet,
+	r *runnerRecord,
+	fair *jobFairness,
+) (*jobIndex, error) {
 	iter, err := memTxn.LowerBound(
 		jobTableName,
 		jobTargetIdIndexName,
 		pb.Job_QUEUED,
 		r.Id,
+		uint8(0),
 		time.Unix(0, 0),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	var best *jobIndex
 	for {
 		raw := iter.Next()
 		if raw == nil {
@@ -956,6 +1206,12 @@ func (s *State) jobCandidateById(memTxn *memdb.Txn, ws memdb.WatchSet, r *runner
 			continue
 		}
 
+		// With the fair policy we look at all the jobs with the priority
+		// of the first candidate rather than only the oldest one.
+		if best != nil && job.PriorityOrder != best.PriorityOrder {
+			break
+		}
+
 		// If this job is blocked, it is not a candidate.
 		if blocked, err := s.jobIsBlocked(memTxn, job, ws); err != nil {
 			return nil, err
@@ -963,24 +1219,37 @@ func (s *State) jobCandidateById(memTxn *memdb.Txn, ws memdb.WatchSet, r *runner
 			continue
 		}
 
-		return job, nil
+		if fair == nil {
+			return job, nil
+		}
+		if best == nil || fair.less(job, best) {
+			best = job
+		}
 	}
 
-	return nil, nil
+	return best, nil
 }
 
 // jobCandidateAny returns the first candidate job that targets any runner.
-func (s *State) jobCandidateAny(memTxn *memdb.Txn, ws memdb.WatchSet, r *runnerRecord) (*jobIndex, error) {
+// If fair is set, the first is the one that fair orders first.
+func (s *State) jobCandidateAny(
+	memTxn *memdb.Txn,
+	ws memdb.WatchSet,
+	r *runnerRecord,
+	fair *jobFairness,
+) (*jobIndex, error) {
 	iter, err := memTxn.LowerBound(
 		jobTableName,
 		jobQueueTimeIndexName,
 		pb.Job_QUEUED,
+		uint8(0),
 		time.Unix(0, 0),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	var best *jobIndex
 	for {
 		raw := iter.Next()
 		if raw == nil {
@@ -992,6 +1261,12 @@ func (s *State) jobCandidateAny(memTxn *memdb.Txn, ws memdb.WatchSet, r *runnerR
 			continue
 		}
 
+		// With the fair policy we look at all the jobs with the priority
+		// of the first candidate rather than only the oldest one.
+		if best != nil && job.PriorityOrder != best.PriorityOrder {
+			break
+		}
+
 		// If this job is blocked, it is not a candidate.
 		if blocked, err := s.jobIsBlocked(memTxn, job, ws); err != nil {
 			return nil, err
@@ -999,10 +1274,86 @@ func (s *State) jobCandidateAny(memTxn *memdb.Txn, ws memdb.WatchSet, r *runnerR
 			continue
 		}
 
-		return job, nil
+		if fair == nil {
+			return job, nil
+		}
+		if best == nil || fair.less(job, best) {
+			best = job
+		}
+	}
+
+	return best, nil
+}
+
+// jobCandidateLabels returns the oldest queued job that targets runners
+// by labels that the runner r has. If fair is set, this returns the job
+// that fair orders first instead of the oldest.
+func (s *State) jobCandidateLabels(
+	memTxn *memdb.Txn,
+	ws memdb.WatchSet,
+	r *runnerRecord,
+	fair *jobFairness,
+) (*jobIndex, error) {
+	iter, err := memTxn.LowerBound(
+		jobTableName,
+		jobQueueTimeIndexName,
+		pb.Job_QUEUED,
+		uint8(0),
+		time.Unix(0, 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *jobIndex
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		job := raw.(*jobIndex)
+		if job.State != pb.Job_QUEUED || job.TargetLabels == nil {
+			continue
+		}
+		if !runnerHasLabels(r.Runner, job.TargetLabels) {
+			continue
+		}
+
+		// With the fair policy we look at all the jobs with the priority
+		// of the first candidate rather than only the oldest one.
+		if best != nil && job.PriorityOrder != best.PriorityOrder {
+			break
+		}
+
+		// If this job is blocked, it is not a candidate.
+		if blocked, err := s.jobIsBlocked(memTxn, job, ws); err != nil {
+			return nil, err
+		} else if blocked {
+			continue
+		}
+
+		if fair == nil {
+			return job, nil
+		}
+		if best == nil || fair.less(job, best) {
+			best = job
+		}
+	}
+
+	return best, nil
+}
+
+// runnerHasLabels returns true if the runner has every one of the labels
+// with the same value.
+func runnerHasLabels(r *pb.Runner, labels map[string]string) bool {
+	for k, v := range labels {
+		if rv, ok := r.Labels[k]; !ok || rv != v {
+			return false
+		}
 	}
 
-	return nil, nil
+	return true
 }
 
 // Job returns the Job for an index.
@@ -0,0 +1,110 @@
+package state
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// JobApprove approves a job that is waiting for approval, queueing it for
+// assignment. This returns a FailedPrecondition error if the job is not
+// waiting for approval.
+//
+// If the job has an expiry, the expiry is restarted from now so that a
+// job that waited a long time for approval isn't immediately expired.
+func (s *State) JobApprove(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	// Get the job
+	raw, err := txn.First(jobTableName, jobIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "job not found: %s", id)
+	}
+	job := raw.(*jobIndex)
+
+	if job.State != pb.Job_WAITING_APPROVAL {
+		return status.Errorf(codes.FailedPrecondition,
+			"job is not waiting for approval, current state: %s",
+			job.State.String())
+	}
+
+	now := time.Now()
+	var expireIn time.Duration
+	_, err = s.jobReadAndUpdate(job.Id, func(jobpb *pb.Job) error {
+		job.State = pb.Job_QUEUED
+		jobpb.State = job.State
+
+		var err error
+		jobpb.ApproveTime, err = ptypes.TimestampProto(now)
+		if err != nil {
+			return err
+		}
+
+		if jobpb.ExpireTime != nil {
+			expire, err := ptypes.Timestamp(jobpb.ExpireTime)
+			if err != nil {
+				return err
+			}
+
+			expireIn = expire.Sub(job.QueueTime)
+			jobpb.ExpireTime, err = ptypes.TimestampProto(now.Add(expireIn))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Store the inmem data. This will be seen by any runners waiting
+	// for a job to be queued.
+	if err := txn.Insert(jobTableName, job); err != nil {
+		return err
+	}
+	txn.Commit()
+
+	if expireIn > 0 {
+		time.AfterFunc(expireIn, func() { s.JobExpire(id) })
+	}
+
+	return nil
+}
+
+// JobApprovalExpire cancels a job if it is still waiting for approval.
+func (s *State) JobApprovalExpire(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	// Get the job
+	raw, err := txn.First(jobTableName, jobIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "job not found: %s", id)
+	}
+	job := raw.(*jobIndex)
+
+	if job.State != pb.Job_WAITING_APPROVAL {
+		return nil
+	}
+
+	s.log.Info("job approval timeout reached, canceling", "job", id)
+	if err := s.jobCancel(txn, job, false); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
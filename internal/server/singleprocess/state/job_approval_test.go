@@ -0,0 +1,116 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestJobApprove(t *testing.T) {
+	t.Run("approve", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Create a job that requires approval
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:    "A",
+			State: pb.Job_WAITING_APPROVAL,
+		})))
+
+		job, err := s.JobById("A", nil)
+		require.NoError(err)
+		require.Equal(pb.Job_WAITING_APPROVAL, job.State)
+
+		// It should not be assigned
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = s.JobAssignForRunner(ctx, &pb.Runner{Id: "R_A"})
+		require.Error(err)
+		require.Equal(ctx.Err(), err)
+
+		// Approve it
+		require.NoError(s.JobApprove("A"))
+
+		job, err = s.JobById("A", nil)
+		require.NoError(err)
+		require.Equal(pb.Job_QUEUED, job.State)
+		require.NotNil(job.ApproveTime)
+
+		// Now it can be assigned
+		job, err = s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+		require.NoError(err)
+		require.Equal("A", job.Id)
+
+		// Approving again is an error
+		err = s.JobApprove("A")
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("not waiting", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+
+		err := s.JobApprove("A")
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:    "A",
+			State: pb.Job_WAITING_APPROVAL,
+		})))
+		require.NoError(s.JobCancel("A", false))
+
+		job, err := s.JobById("A", nil)
+		require.NoError(err)
+		require.Equal(pb.Job_ERROR, job.State)
+		require.NotNil(job.CancelTime)
+	})
+
+	t.Run("approval timeout", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		expire, err := ptypes.TimestampProto(time.Now().Add(10 * time.Millisecond))
+		require.NoError(err)
+
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:                 "A",
+			State:              pb.Job_WAITING_APPROVAL,
+			ApprovalExpireTime: expire,
+		})))
+
+		require.Eventually(func() bool {
+			job, err := s.JobById("A", nil)
+			require.NoError(err)
+			return job.State == pb.Job_ERROR
+		}, 2*time.Second, 10*time.Millisecond)
+
+		require.Error(s.JobApprove("A"))
+	})
+}
@@ -67,12 +67,17 @@ type jobAssignedIndex struct {
 }
 
 // jobIsBlocked will return true if the given job is currently blocked because
-// a job with the same project/app/workspace is executing.
+// a job with the same project/app/workspace is executing, or because its
+// project has as many jobs assigned as the server config allows.
 //
 // If ws is set then a watch will be added for any changes in assigned jobs.
 // Note that this trigger doesn't mean that the blocking is necessarily gone
 // but something changed to warrant rechecking.
 func (s *State) jobIsBlocked(memTxn *memdb.Txn, idx *jobIndex, ws memdb.WatchSet) (bool, error) {
+	if blocked, err := s.jobProjectAtLimit(memTxn, idx, ws); err != nil || blocked {
+		return blocked, err
+	}
+
 	// If this job represents a parallelizable operation type, then allow it.
 	if _, ok := blockOps[idx.OpType]; !ok {
 		return false, nil
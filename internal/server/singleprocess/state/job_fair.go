@@ -0,0 +1,141 @@
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+// This file has the methods for the "fair" job scheduling policy. By
+// default queued jobs of the same priority are assigned in the order they
+// were queued, so an app that queues many jobs, such as the apps of a big
+// project during "waypoint up", can keep the runners busy while the jobs
+// of other apps wait. With the fair policy the jobs of the app with the
+// fewest assigned jobs go first, and among those the app that was least
+// recently assigned a job, so that apps take turns.
+
+func init() {
+	schemas = append(schemas, jobFairSchema)
+}
+
+const (
+	jobFairTableName   = "jobs-fair"
+	jobFairIdIndexName = "id"
+)
+
+func jobFairSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: jobFairTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			jobFairIdIndexName: {
+				Name:         jobFairIdIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field:     "Project",
+							Lowercase: true,
+						},
+
+						&memdb.StringFieldIndex{
+							Field:     "Application",
+							Lowercase: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// jobFairIndex records when a job of an app was last assigned. This is
+// lost when the server restarts, which only means that apps that haven't
+// been assigned a job since are treated as never assigned one.
+type jobFairIndex struct {
+	Project     string
+	Application string
+	AssignTime  time.Time
+}
+
+// jobFairness orders queued jobs for the fair policy. A nil *jobFairness
+// is returned for the default policy, in which case jobs are assigned in
+// the order they were queued.
+type jobFairness struct {
+	memTxn *memdb.Txn
+
+	// assigned is the number of assigned jobs of each app, keyed by
+	// jobFairKey.
+	assigned map[string]int
+}
+
+// jobFairness returns the fairness ordering for the current server config
+// or nil if the config doesn't use the fair policy.
+func (s *State) jobFairness(memTxn *memdb.Txn) (*jobFairness, error) {
+	cfg, err := s.serverConfigGet(memTxn)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.JobScheduling != serverptypes.JobSchedulingFair {
+		return nil, nil
+	}
+
+	f := &jobFairness{memTxn: memTxn, assigned: map[string]int{}}
+	_, err = s.jobAssignedCount(memTxn, nil, func(job *jobIndex) bool {
+		f.assigned[jobFairKey(job.Application)]++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// less returns true if job a should be assigned before job b. Both jobs
+// must have the same priority.
+func (f *jobFairness) less(a, b *jobIndex) bool {
+	if ka, kb := jobFairKey(a.Application), jobFairKey(b.Application); ka != kb {
+		ca, cb := f.assigned[ka], f.assigned[kb]
+		if ca != cb {
+			return ca < cb
+		}
+
+		ta, tb := f.lastAssigned(a.Application), f.lastAssigned(b.Application)
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+	}
+
+	return a.QueueTime.Before(b.QueueTime)
+}
+
+// lastAssigned returns when a job of the app was last assigned, or the
+// zero time if none was.
+func (f *jobFairness) lastAssigned(ref *pb.Ref_Application) time.Time {
+	raw, err := f.memTxn.First(jobFairTableName, jobFairIdIndexName,
+		ref.Project, ref.Application)
+	if err != nil || raw == nil {
+		return time.Time{}
+	}
+
+	return raw.(*jobFairIndex).AssignTime
+}
+
+// jobFairAssigned records that the job was assigned now.
+func (s *State) jobFairAssigned(memTxn *memdb.Txn, idx *jobIndex) error {
+	return memTxn.Insert(jobFairTableName, &jobFairIndex{
+		Project:     idx.Application.Project,
+		Application: idx.Application.Application,
+		AssignTime:  time.Now(),
+	})
+}
+
+// jobFairKey returns the key of the app in jobFairness.assigned.
+func jobFairKey(ref *pb.Ref_Application) string {
+	return strings.ToLower(ref.Project + "/" + ref.Application)
+}
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestJobAssignForRunner_fair(t *testing.T) {
+	// Three apps queue several long jobs each before a short app queues
+	// one job.
+	create := func(t *testing.T, s *State) {
+		for _, app := range []string{"a", "b", "c"} {
+			for i := 1; i <= 3; i++ {
+				require.NoError(t, s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+					Id: fmt.Sprintf("%s%d", app, i),
+					Application: &pb.Ref_Application{
+						Application: app,
+						Project:     "p_test",
+					},
+				})))
+			}
+		}
+
+		require.NoError(t, s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "short",
+			Application: &pb.Ref_Application{
+				Application: "short",
+				Project:     "p_test",
+			},
+		})))
+	}
+
+	assign := func(t *testing.T, s *State, n int) []string {
+		var ids []string
+		for i := 0; i < n; i++ {
+			job, err := s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+			require.NoError(t, err)
+			ids = append(ids, job.Id)
+		}
+
+		return ids
+	}
+
+	t.Run("fifo starves the short app", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		create(t, s)
+
+		require.Equal([]string{"a1", "a2", "a3", "b1"}, assign(t, s, 4))
+	})
+
+	t.Run("fair takes turns between apps", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		require.NoError(s.ServerConfigSet(serverptypes.TestServerConfig(t, &pb.ServerConfig{
+			JobScheduling: serverptypes.JobSchedulingFair,
+		})))
+		create(t, s)
+
+		require.Equal([]string{"a1", "b1", "c1", "short"}, assign(t, s, 4))
+	})
+
+	t.Run("fair takes turns after jobs end", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		require.NoError(s.ServerConfigSet(serverptypes.TestServerConfig(t, &pb.ServerConfig{
+			JobScheduling: serverptypes.JobSchedulingFair,
+		})))
+		create(t, s)
+
+		// Only one job runs at a time, so every app has no assigned jobs
+		// when the next one is assigned. The app that waited longest
+		// goes next.
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, assign(t, s, 1)...)
+
+			id := ids[len(ids)-1]
+			_, err := s.JobAck(id, true)
+			require.NoError(err)
+			require.NoError(s.JobComplete(id, nil, nil))
+		}
+
+		require.Equal([]string{"a1", "b1", "c1", "short", "a2"}, ids)
+	})
+
+	t.Run("priority comes first", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		require.NoError(s.ServerConfigSet(serverptypes.TestServerConfig(t, &pb.ServerConfig{
+			JobScheduling: serverptypes.JobSchedulingFair,
+		})))
+		create(t, s)
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:       "a_urgent",
+			Priority: pb.Job_HIGH,
+		})))
+
+		require.Equal([]string{"a_urgent", "a1", "b1"}, assign(t, s, 3))
+	})
+}
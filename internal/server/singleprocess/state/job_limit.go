@@ -0,0 +1,102 @@
+package state
+
+import (
+	"github.com/hashicorp/go-memdb"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+// This file has the methods that limit how many jobs run at once. Jobs
+// count against the limits while they're assigned to a runner, that is
+// while they're in the WAITING or RUNNING state.
+
+// jobRunnerAtLimit returns true if the runner is running as many jobs as
+// its max_jobs allows.
+//
+// If ws is set then a watch will be added for any changes in assigned jobs.
+func (s *State) jobRunnerAtLimit(memTxn *memdb.Txn, ws memdb.WatchSet, r *pb.Runner) (bool, error) {
+	if r.MaxJobs == 0 {
+		return false, nil
+	}
+
+	count, err := s.jobAssignedCount(memTxn, ws, func(job *jobIndex) bool {
+		return job.AssignedRunnerId == r.Id
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count >= int(r.MaxJobs), nil
+}
+
+// jobProjectAtLimit returns true if the project of the given job has as
+// many jobs assigned as the server config allows.
+//
+// If ws is set then a watch will be added for any changes in assigned jobs.
+func (s *State) jobProjectAtLimit(memTxn *memdb.Txn, idx *jobIndex, ws memdb.WatchSet) (bool, error) {
+	cfg, err := s.serverConfigGet(memTxn)
+	if err != nil {
+		return false, err
+	}
+
+	project := idx.Application.Project
+	limit, ok := cfg.ProjectJobLimits[project]
+	if !ok {
+		limit = cfg.ProjectJobLimit
+	}
+	if limit == 0 {
+		return false, nil
+	}
+
+	count, err := s.jobAssignedCount(memTxn, ws, func(job *jobIndex) bool {
+		return job.Application.Project == project
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count >= int(limit), nil
+}
+
+// jobAssignedCount returns the number of assigned jobs that f returns
+// true for.
+func (s *State) jobAssignedCount(
+	memTxn *memdb.Txn,
+	ws memdb.WatchSet,
+	f func(*jobIndex) bool,
+) (int, error) {
+	count := 0
+	for _, state := range []pb.Job_State{pb.Job_WAITING, pb.Job_RUNNING} {
+		iter, err := memTxn.Get(jobTableName, jobStateIndexName, state)
+		if err != nil {
+			return 0, err
+		}
+		if ws != nil {
+			ws.Add(iter.WatchCh())
+		}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			// Jobs are updated in place, so the state index can still
+			// have a job that has since moved on to another state.
+			job := raw.(*jobIndex)
+			if job.State != state || !f(job) {
+				continue
+			}
+
+			// Watch the job itself since the state index doesn't change
+			// when the job ends.
+			if ws != nil {
+				watchCh, _, err := memTxn.FirstWatch(jobTableName, jobIdIndexName, job.Id)
+				if err != nil {
+					return 0, err
+				}
+
+				ws.Add(watchCh)
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
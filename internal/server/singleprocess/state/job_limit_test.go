@@ -0,0 +1,145 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestJobAssignForRunner_limits(t *testing.T) {
+	t.Run("runner max jobs", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Create two jobs for different apps so they don't block each other
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "B",
+			Application: &pb.Ref_Application{
+				Application: "b_test",
+				Project:     "p_test",
+			},
+		})))
+
+		r := &pb.Runner{Id: "R_A", MaxJobs: 1}
+		job, err := s.JobAssignForRunner(context.Background(), r)
+		require.NoError(err)
+		require.Equal("A", job.Id)
+
+		// The runner is at its limit
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = s.JobAssignForRunner(ctx, r)
+		require.Error(err)
+		require.Equal(ctx.Err(), err)
+
+		// Other runners can still be assigned jobs
+		job, err = s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_B"})
+		require.NoError(err)
+		require.Equal("B", job.Id)
+	})
+
+	t.Run("runner waits for jobs to end", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "B",
+			Application: &pb.Ref_Application{
+				Application: "b_test",
+				Project:     "p_test",
+			},
+		})))
+
+		r := &pb.Runner{Id: "R_A", MaxJobs: 1}
+		job, err := s.JobAssignForRunner(context.Background(), r)
+		require.NoError(err)
+		require.Equal("A", job.Id)
+		_, err = s.JobAck("A", true)
+		require.NoError(err)
+
+		// Completing the job lets the runner be assigned the next one
+		doneCh := make(chan *Job, 1)
+		go func() {
+			job, err := s.JobAssignForRunner(context.Background(), r)
+			require.NoError(err)
+			doneCh <- job
+		}()
+
+		select {
+		case <-doneCh:
+			t.Fatal("runner should be at its limit")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		require.NoError(s.JobComplete("A", nil, nil))
+		select {
+		case job := <-doneCh:
+			require.Equal("B", job.Id)
+		case <-time.After(2 * time.Second):
+			t.Fatal("runner should be assigned a job")
+		}
+	})
+
+	t.Run("project job limit", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ServerConfigSet(&pb.ServerConfig{
+			ProjectJobLimit:  1,
+			ProjectJobLimits: map[string]uint32{"p_big": 2},
+		}))
+
+		for _, id := range []string{"A", "B"} {
+			require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+				Id: id,
+				Application: &pb.Ref_Application{
+					Application: "a_" + id,
+					Project:     "p_test",
+				},
+			})))
+		}
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "C",
+			Application: &pb.Ref_Application{
+				Application: "a_test",
+				Project:     "p_big",
+			},
+		})))
+
+		job, err := s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+		require.NoError(err)
+		require.Equal("A", job.Id)
+
+		// B is blocked by the project limit so C is assigned
+		job, err = s.JobById("B", nil)
+		require.NoError(err)
+		require.True(job.Blocked)
+
+		job, err = s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_B"})
+		require.NoError(err)
+		require.Equal("C", job.Id)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = s.JobAssignForRunner(ctx, &pb.Runner{Id: "R_C"})
+		require.Error(err)
+		require.Equal(ctx.Err(), err)
+	})
+}
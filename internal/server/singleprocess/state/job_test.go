@@ -297,6 +297,37 @@ func TestJobAssign(t *testing.T) {
 		}
 	})
 
+	t.Run("assignment by priority", func(t *testing.T) {
+		require := require.New(t)
+		ctx := context.Background()
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Create builds slightly apart with different priorities
+		for _, job := range []*pb.Job{
+			{Id: "A", Priority: pb.Job_LOW},
+			{Id: "B"},
+			{Id: "C", Priority: pb.Job_HIGH},
+			{Id: "D"},
+		} {
+			require.NoError(s.JobCreate(serverptypes.TestJobNew(t, job)))
+			time.Sleep(1 * time.Millisecond)
+		}
+
+		// Assign them, we should get the highest priority first and jobs
+		// with the same priority in the order they were queued.
+		for _, id := range []string{"C", "B", "D", "A"} {
+			job, err := s.JobAssignForRunner(ctx, &pb.Runner{Id: "R_A"})
+			require.NoError(err)
+			require.NotNil(job)
+			require.Equal(id, job.Id)
+			_, err = s.JobAck(job.Id, true)
+			require.NoError(err)
+			require.NoError(s.JobComplete(job.Id, nil, nil))
+		}
+	})
+
 	t.Run("assignment by ID", func(t *testing.T) {
 		require := require.New(t)
 
@@ -387,6 +418,56 @@ func TestJobAssign(t *testing.T) {
 		}
 	})
 
+	t.Run("assignment by labels", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		target := &pb.Ref_Runner{
+			Target: &pb.Ref_Runner_Labels{
+				Labels: &pb.Ref_RunnerLabels{
+					Labels: map[string]string{"os": "linux", "env": "prod"},
+				},
+			},
+		}
+
+		// Create a job targeting labels
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:           "A",
+			TargetRunner: target,
+		})))
+
+		// A runner missing a label should block
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		job, err := s.JobAssignForRunner(ctx, &pb.Runner{
+			Id:     "R_A",
+			Labels: map[string]string{"os": "linux"},
+		})
+		require.Error(err)
+		require.Nil(job)
+		require.Equal(ctx.Err(), err)
+
+		// A ByIdOnly runner with the labels should block
+		job, err = s.JobAssignForRunner(ctx, &pb.Runner{
+			Id:       "R_B",
+			ByIdOnly: true,
+			Labels:   map[string]string{"os": "linux", "env": "prod"},
+		})
+		require.Error(err)
+		require.Nil(job)
+
+		// A runner with all the labels, and more, gets the job
+		job, err = s.JobAssignForRunner(context.Background(), &pb.Runner{
+			Id:     "R_C",
+			Labels: map[string]string{"os": "linux", "env": "prod", "arch": "amd64"},
+		})
+		require.NoError(err)
+		require.NotNil(job)
+		require.Equal("A", job.Id)
+	})
+
 	t.Run("any cannot be assigned to ByIdOnly runner", func(t *testing.T) {
 		require := require.New(t)
 
@@ -661,6 +742,24 @@ func TestJobIsAssignable(t *testing.T) {
 		require.False(result)
 	})
 
+	t.Run("any target, runners pending adoption", func(t *testing.T) {
+		require := require.New(t)
+		ctx := context.Background()
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Register a runner. It isn't adopted.
+		require.NoError(s.RunnerCreate(&pb.Runner{Id: "R_A"}))
+
+		// Should not be assignable
+		result, err := s.JobIsAssignable(ctx, serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		}))
+		require.NoError(err)
+		require.False(result)
+	})
+
 	t.Run("ID target, no match", func(t *testing.T) {
 		require := require.New(t)
 		ctx := context.Background()
@@ -711,6 +810,38 @@ func TestJobIsAssignable(t *testing.T) {
 		require.NoError(err)
 		require.True(result)
 	})
+
+	t.Run("labels target", func(t *testing.T) {
+		require := require.New(t)
+		ctx := context.Background()
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Register a runner
+		require.NoError(s.RunnerCreate(serverptypes.TestRunner(t, &pb.Runner{
+			Labels: map[string]string{"os": "linux"},
+		})))
+
+		job := func(labels map[string]string) *pb.Job {
+			return serverptypes.TestJobNew(t, &pb.Job{
+				Id: "A",
+				TargetRunner: &pb.Ref_Runner{
+					Target: &pb.Ref_Runner_Labels{
+						Labels: &pb.Ref_RunnerLabels{Labels: labels},
+					},
+				},
+			})
+		}
+
+		result, err := s.JobIsAssignable(ctx, job(map[string]string{"os": "linux"}))
+		require.NoError(err)
+		require.True(result)
+
+		result, err = s.JobIsAssignable(ctx, job(map[string]string{"os": "windows"}))
+		require.NoError(err)
+		require.False(result)
+	})
 }
 
 func TestJobCancel(t *testing.T) {
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+// jobTimeout returns the maximum duration the job may run for. This is the
+// timeout of the job or otherwise the timeout of the server config for the
+// job operation. This returns zero if the job doesn't time out.
+func jobTimeout(jobpb *pb.Job, cfg *pb.ServerConfig) time.Duration {
+	timeout := jobpb.Timeout
+	if timeout == "" {
+		timeout = cfg.GetJobTimeouts()[serverptypes.JobOperationName(jobpb)]
+	}
+	if timeout == "" {
+		return 0
+	}
+
+	// Both the job and server config timeouts are validated when they're
+	// set so we treat an invalid duration as no timeout.
+	dur, err := time.ParseDuration(timeout)
+	if err != nil || dur <= 0 {
+		return 0
+	}
+
+	return dur
+}
+
+// jobTimeoutTimer starts the timer that times out the job at its timeout
+// time. This does nothing if the job has no timeout time.
+func (s *State) jobTimeoutTimer(jobpb *pb.Job) error {
+	if jobpb.TimeoutTime == nil {
+		return nil
+	}
+
+	t, err := ptypes.Timestamp(jobpb.TimeoutTime)
+	if err != nil {
+		return err
+	}
+
+	dur := time.Until(t)
+	if dur < 0 {
+		dur = 1
+	}
+
+	id := jobpb.Id
+	time.AfterFunc(dur, func() {
+		if err := s.JobTimeout(id); err != nil {
+			s.log.Error("error timing out job", "error", err, "job", id)
+		}
+	})
+
+	return nil
+}
+
+// JobTimeout times out a running job that reached its timeout time. The job
+// is moved to the TIMED_OUT state and the runner is told to cancel it. This
+// does nothing if the job isn't running or hasn't reached its timeout time,
+// such as when it was re-queued and accepted again.
+func (s *State) JobTimeout(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	// Get the job
+	raw, err := txn.First(jobTableName, jobIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "job not found: %s", id)
+	}
+	job := raw.(*jobIndex)
+
+	if job.State != pb.Job_RUNNING {
+		return nil
+	}
+
+	var jobpb *pb.Job
+	err = s.db.View(func(dbTxn *bolt.Tx) error {
+		jobpb, err = s.jobById(dbTxn, id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if jobpb.TimeoutTime == nil {
+		return nil
+	}
+	t, err := ptypes.Timestamp(jobpb.TimeoutTime)
+	if err != nil {
+		return err
+	}
+	if time.Now().Before(t) {
+		return nil
+	}
+
+	s.log.Info("job reached its timeout, canceling", "job", id)
+
+	// Stop the heartbeat timer, the job is over
+	job.End()
+	job.State = pb.Job_TIMED_OUT
+
+	_, err = s.jobReadAndUpdate(job.Id, func(jobpb *pb.Job) error {
+		now := ptypes.TimestampNow()
+		jobpb.State = job.State
+		jobpb.CancelTime = now
+		jobpb.CompleteTime = now
+		jobpb.Error = status.Newf(codes.DeadlineExceeded,
+			"job exceeded its timeout of %s", jobTimeoutString(jobpb)).Proto()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Store the inmem data. The RunnerJobStream of the job sees this and
+	// tells the runner to cancel the job.
+	if err := txn.Insert(jobTableName, job); err != nil {
+		return err
+	}
+
+	// Unblock other jobs for the app
+	if err := s.jobAssignedSet(txn, job, false); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// jobTimeoutString returns the timeout of the job for errors. This is the
+// time from when the job was accepted until its timeout time.
+func jobTimeoutString(jobpb *pb.Job) string {
+	ack, err := ptypes.Timestamp(jobpb.AckTime)
+	if err != nil {
+		return jobpb.Timeout
+	}
+	timeout, err := ptypes.Timestamp(jobpb.TimeoutTime)
+	if err != nil {
+		return jobpb.Timeout
+	}
+
+	return timeout.Sub(ack).Round(time.Second).String()
+}
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestJobTimeout(t *testing.T) {
+	t.Run("job timeout", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id:      "A",
+			Timeout: "50ms",
+		})))
+
+		// Assign and accept it, which starts the timeout
+		job, err := s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+		require.NoError(err)
+		require.Equal("A", job.Id)
+		job, err = s.JobAck("A", true)
+		require.NoError(err)
+		require.NotNil(job.TimeoutTime)
+
+		require.Eventually(func() bool {
+			job, err := s.JobById("A", nil)
+			require.NoError(err)
+			return job.State == pb.Job_TIMED_OUT
+		}, 2*time.Second, 10*time.Millisecond)
+
+		job, err = s.JobById("A", nil)
+		require.NoError(err)
+		require.NotNil(job.CancelTime)
+		require.NotNil(job.CompleteTime)
+		require.Equal(int32(codes.DeadlineExceeded), job.Error.Code)
+
+		// Completing a timed out job is an error
+		require.Error(s.JobComplete("A", nil, nil))
+	})
+
+	t.Run("server config timeout", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ServerConfigSet(&pb.ServerConfig{
+			JobTimeouts: map[string]string{"build": "50ms"},
+		}))
+
+		// Noop jobs don't have a timeout
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+		_, err := s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+		require.NoError(err)
+		job, err := s.JobAck("A", true)
+		require.NoError(err)
+		require.Nil(job.TimeoutTime)
+
+		// Build jobs use the server timeout
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "B",
+			Application: &pb.Ref_Application{
+				Application: "b_test",
+				Project:     "p_test",
+			},
+			Operation: &pb.Job_Build{
+				Build: &pb.Job_BuildOp{},
+			},
+		})))
+		_, err = s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_A"})
+		require.NoError(err)
+		job, err = s.JobAck("B", true)
+		require.NoError(err)
+		require.NotNil(job.TimeoutTime)
+
+		require.Eventually(func() bool {
+			job, err := s.JobById("B", nil)
+			require.NoError(err)
+			return job.State == pb.Job_TIMED_OUT
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// The noop job is still running
+		job, err = s.JobById("A", nil)
+		require.NoError(err)
+		require.Equal(pb.Job_RUNNING, job.State)
+	})
+}
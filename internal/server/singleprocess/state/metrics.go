@@ -0,0 +1,102 @@
+package state
+
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/prometheus/client_golang/prometheus"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var jobQueueDepthDesc = prometheus.NewDesc(
+	"waypoint_job_queue_depth",
+	"Number of jobs that are queued and waiting for a runner.",
+	nil, nil,
+)
+
+// timedDB records how long each bolt transaction takes. Transactions are
+// labeled with the State method that ran them.
+type timedDB struct {
+	*bolt.DB
+	timings *prometheus.HistogramVec
+}
+
+func newTimedDB(db *bolt.DB) *timedDB {
+	return &timedDB{
+		DB: db,
+		timings: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "waypoint_state_operation_duration_seconds",
+			Help:    "Duration of state store transactions, by the operation that ran them.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"op", "type"}),
+	}
+}
+
+func (db *timedDB) View(fn func(*bolt.Tx) error) error {
+	defer db.observe("view", time.Now())
+	return db.DB.View(fn)
+}
+
+func (db *timedDB) Update(fn func(*bolt.Tx) error) error {
+	defer db.observe("update", time.Now())
+	return db.DB.Update(fn)
+}
+
+// observe must be deferred directly by View or Update so that the caller
+// two frames up is the operation.
+func (db *timedDB) observe(typ string, start time.Time) {
+	op := "unknown"
+	if pc, _, _, ok := runtime.Caller(2); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			op = stateOpName(fn.Name())
+		}
+	}
+
+	db.timings.WithLabelValues(op, typ).Observe(time.Since(start).Seconds())
+}
+
+// stateOpName turns a function name such as
+// "github.com/x/state.(*State).JobCreate.func1" into "JobCreate".
+func stateOpName(name string) string {
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	if idx := strings.Index(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = strings.TrimPrefix(name, "(*State).")
+	if idx := strings.Index(name, "."); idx >= 0 {
+		name = name[:idx]
+	}
+
+	return name
+}
+
+// Describe implements prometheus.Collector.
+func (s *State) Describe(ch chan<- *prometheus.Desc) {
+	s.db.timings.Describe(ch)
+	ch <- jobQueueDepthDesc
+}
+
+// Collect implements prometheus.Collector.
+func (s *State) Collect(ch chan<- prometheus.Metric) {
+	s.db.timings.Collect(ch)
+
+	txn := s.inmem.Txn(false)
+	defer txn.Abort()
+
+	var queued int
+	iter, err := txn.Get(jobTableName, jobStateIndexName, pb.Job_QUEUED)
+	if err != nil {
+		s.log.Warn("error counting queued jobs for metrics", "err", err)
+		return
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		queued++
+	}
+
+	ch <- prometheus.MustNewConstMetric(jobQueueDepthDesc, prometheus.GaugeValue, float64(queued))
+}
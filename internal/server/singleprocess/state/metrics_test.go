@@ -0,0 +1,59 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestStateCollect(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{Id: "A"})))
+	require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{Id: "B"})))
+
+	reg := prometheus.NewRegistry()
+	require.NoError(reg.Register(s))
+	families, err := reg.Gather()
+	require.NoError(err)
+
+	values := map[string]float64{}
+	ops := map[string]bool{}
+	for _, f := range families {
+		for _, m := range f.Metric {
+			switch f.GetName() {
+			case "waypoint_job_queue_depth":
+				values[f.GetName()] = m.GetGauge().GetValue()
+
+			case "waypoint_state_operation_duration_seconds":
+				for _, l := range m.Label {
+					if l.GetName() == "op" {
+						ops[l.GetValue()] = true
+					}
+				}
+			}
+		}
+	}
+
+	require.Equal(float64(2), values["waypoint_job_queue_depth"])
+	require.True(ops["JobCreate"])
+}
+
+func TestStateOpName(t *testing.T) {
+	cases := map[string]string{
+		"github.com/hashicorp/waypoint/internal/server/singleprocess/state.(*State).JobCreate":       "JobCreate",
+		"github.com/hashicorp/waypoint/internal/server/singleprocess/state.(*State).JobCreate.func1": "JobCreate",
+		"github.com/hashicorp/waypoint/internal/server/singleprocess/state.New":                      "New",
+	}
+
+	for input, expected := range cases {
+		require.Equal(t, expected, stateOpName(input), input)
+	}
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var projectPollBucket = []byte("project_poll")
+
+func init() {
+	dbBuckets = append(dbBuckets, projectPollBucket)
+}
+
+// ProjectPollStatePut stores the poll state of a project.
+func (s *State) ProjectPollStatePut(v *pb.ProjectPollState) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(projectPollBucket), projectPollId(v.Project), v)
+	})
+}
+
+// ProjectPollStateGet gets the poll state of a project. If the project
+// was never polled, this returns an empty state.
+func (s *State) ProjectPollStateGet(ref *pb.Ref_Project) (*pb.ProjectPollState, error) {
+	var result pb.ProjectPollState
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(projectPollBucket), projectPollId(ref), &result)
+	})
+	if status.Code(err) == codes.NotFound {
+		return &pb.ProjectPollState{Project: ref}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func projectPollId(ref *pb.Ref_Project) []byte {
+	return []byte(strings.ToLower(ref.Project))
+}
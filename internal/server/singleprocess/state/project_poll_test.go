@@ -0,0 +1,33 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestProjectPollState(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	ref := &pb.Ref_Project{Project: "foo"}
+
+	// Never polled
+	v, err := s.ProjectPollStateGet(ref)
+	require.NoError(err)
+	require.Empty(v.Commit)
+
+	require.NoError(s.ProjectPollStatePut(&pb.ProjectPollState{
+		Project: ref,
+		Commit:  "abc",
+	}))
+
+	// Names are case insensitive like projects
+	v, err = s.ProjectPollStateGet(&pb.Ref_Project{Project: "FOO"})
+	require.NoError(err)
+	require.Equal("abc", v.Commit)
+}
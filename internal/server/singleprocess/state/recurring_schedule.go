@@ -0,0 +1,103 @@
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var recurringScheduleBucket = []byte("recurring_schedules")
+
+func init() {
+	dbBuckets = append(dbBuckets, recurringScheduleBucket)
+}
+
+// RecurringSchedulePut creates or updates the given recurring schedule.
+func (s *State) RecurringSchedulePut(v *pb.RecurringSchedule) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(recurringScheduleBucket), []byte(v.Id), v)
+	})
+}
+
+// RecurringScheduleGet gets a recurring schedule by ID.
+func (s *State) RecurringScheduleGet(id string) (*pb.RecurringSchedule, error) {
+	var result pb.RecurringSchedule
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(recurringScheduleBucket), []byte(id), &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RecurringScheduleList returns the recurring schedules for jobs that
+// target the given application. If ref is nil, all schedules are returned.
+func (s *State) RecurringScheduleList(ref *pb.Ref_Application) ([]*pb.RecurringSchedule, error) {
+	var result []*pb.RecurringSchedule
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(recurringScheduleBucket).ForEach(func(k, v []byte) error {
+			var value pb.RecurringSchedule
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+
+			if ref != nil {
+				app := value.Job.GetApplication()
+				if app == nil ||
+					!strings.EqualFold(app.Project, ref.Project) ||
+					!strings.EqualFold(app.Application, ref.Application) {
+					return nil
+				}
+			}
+
+			result = append(result, &value)
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+// RecurringScheduleQueued records that the recurring schedule with the
+// given ID queued a job and sets the next time it should queue one. This
+// returns a NotFound error if the schedule was deleted.
+func (s *State) RecurringScheduleQueued(id, jobId string, next time.Time) error {
+	ts, err := ptypes.TimestampProto(next)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(recurringScheduleBucket)
+
+		var value pb.RecurringSchedule
+		if err := dbGet(b, []byte(id), &value); err != nil {
+			return err
+		}
+
+		value.LastJobId = jobId
+		value.NextTime = ts
+		return dbPut(b, []byte(id), &value)
+	})
+}
+
+// RecurringScheduleDelete deletes the recurring schedule with the given ID.
+func (s *State) RecurringScheduleDelete(id string) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(recurringScheduleBucket)
+		if b.Get([]byte(id)) == nil {
+			return status.Errorf(codes.NotFound, "record not found for ID: %s", id)
+		}
+
+		return b.Delete([]byte(id))
+	})
+}
@@ -0,0 +1,100 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestRecurringSchedule(t *testing.T) {
+	testSchedule := func(t *testing.T, id, app string) *pb.RecurringSchedule {
+		return &pb.RecurringSchedule{
+			Id: id,
+			Job: serverptypes.TestJobNew(t, &pb.Job{
+				Application: &pb.Ref_Application{
+					Project:     "p_test",
+					Application: app,
+				},
+			}),
+			Cron: "@daily",
+		}
+	}
+
+	t.Run("Put, Get and Delete", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RecurringSchedulePut(testSchedule(t, "A", "web")))
+
+		v, err := s.RecurringScheduleGet("A")
+		require.NoError(err)
+		require.Equal("@daily", v.Cron)
+
+		require.NoError(s.RecurringScheduleDelete("A"))
+		_, err = s.RecurringScheduleGet("A")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		err = s.RecurringScheduleDelete("A")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Queued", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RecurringSchedulePut(testSchedule(t, "A", "web")))
+
+		next := time.Now().Add(time.Hour)
+		require.NoError(s.RecurringScheduleQueued("A", "job-1", next))
+
+		v, err := s.RecurringScheduleGet("A")
+		require.NoError(err)
+		require.Equal("job-1", v.LastJobId)
+		nextTime, err := ptypes.Timestamp(v.NextTime)
+		require.NoError(err)
+		require.True(next.Equal(nextTime))
+
+		// Deleted schedules aren't recreated
+		require.NoError(s.RecurringScheduleDelete("A"))
+		err = s.RecurringScheduleQueued("A", "job-2", next)
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("List by application", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RecurringSchedulePut(testSchedule(t, "A", "web")))
+		require.NoError(s.RecurringSchedulePut(testSchedule(t, "B", "api")))
+		require.NoError(s.RecurringSchedulePut(testSchedule(t, "C", "web")))
+
+		result, err := s.RecurringScheduleList(&pb.Ref_Application{
+			Project:     "p_test",
+			Application: "web",
+		})
+		require.NoError(err)
+		require.Len(result, 2)
+		require.Equal("A", result[0].Id)
+		require.Equal("C", result[1].Id)
+
+		result, err = s.RecurringScheduleList(nil)
+		require.NoError(err)
+		require.Len(result, 3)
+	})
+}
@@ -1,6 +1,11 @@
 package state
 
 import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-memdb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -8,6 +13,11 @@ import (
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 )
 
+// runnerHeartbeatTimeout is how long a runner can go without heartbeating
+// before it is considered lost. This is shorter than jobHeartbeatTimeout
+// so that the jobs of a lost runner are handled before they time out.
+var runnerHeartbeatTimeout = 1 * time.Minute
+
 const (
 	runnerTableName   = "runners"
 	runnerIdIndexName = "id"
@@ -40,14 +50,41 @@ type runnerRecord struct {
 
 	// Id of the runner
 	Id string
+
+	// HeartbeatTimer marks the runner as lost when it expires. It is
+	// reset each time the runner heartbeats.
+	HeartbeatTimer *time.Timer
 }
 
 func (s *State) RunnerCreate(r *pb.Runner) error {
 	txn := s.inmem.Txn(true)
 	defer txn.Abort()
 
+	// If the runner is reconnecting, we replace its timer. Rejected
+	// runners stay rejected.
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, r.Id)
+	if err != nil {
+		return err
+	}
+	if raw != nil {
+		old := raw.(*runnerRecord)
+		old.End()
+		if old.Runner.AdoptionState == pb.Runner_REJECTED {
+			r.AdoptionState = pb.Runner_REJECTED
+		}
+	}
+
+	// The runner is healthy until it stops heartbeating
+	r.Health = pb.Runner_HEALTHY
+	r.LastHeartbeatTime = ptypes.TimestampNow()
+	rec := newRunnerRecord(r)
+	rec.HeartbeatTimer = time.AfterFunc(runnerHeartbeatTimeout, func() {
+		s.runnerExpire(r.Id)
+	})
+
 	// Create our runner
-	if err := txn.Insert(runnerTableName, newRunnerRecord(r)); err != nil {
+	if err := txn.Insert(runnerTableName, rec); err != nil {
+		rec.End()
 		return status.Errorf(codes.Aborted, err.Error())
 	}
 
@@ -59,6 +96,15 @@ func (s *State) RunnerCreate(r *pb.Runner) error {
 func (s *State) RunnerDelete(id string) error {
 	txn := s.inmem.Txn(true)
 	defer txn.Abort()
+
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
+	if err != nil {
+		return status.Errorf(codes.Aborted, err.Error())
+	}
+	if raw != nil {
+		raw.(*runnerRecord).End()
+	}
+
 	if _, err := txn.DeleteAll(runnerTableName, runnerIdIndexName, id); err != nil {
 		return status.Errorf(codes.Aborted, err.Error())
 	}
@@ -67,13 +113,136 @@ func (s *State) RunnerDelete(id string) error {
 	return nil
 }
 
+// RunnerForget deletes a runner that stopped heartbeating. The jobs of
+// the runner were already errored or re-queued when it was lost. Healthy
+// runners can't be forgotten since they would keep running jobs.
+func (s *State) RunnerForget(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "runner ID not found")
+	}
+	rec := raw.(*runnerRecord)
+	if rec.Runner.Health != pb.Runner_UNHEALTHY {
+		return status.Errorf(codes.FailedPrecondition,
+			"runner %s is still heartbeating and can't be forgotten", id)
+	}
+
+	rec.End()
+	if err := txn.Delete(runnerTableName, rec); err != nil {
+		return status.Errorf(codes.Aborted, err.Error())
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// RunnerAdopt adopts the runner with the given ID if adopt is true and
+// rejects it otherwise. Only adopted runners are assigned jobs.
+func (s *State) RunnerAdopt(id string, adopt bool) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "runner ID not found")
+	}
+	old := raw.(*runnerRecord)
+
+	r := proto.Clone(old.Runner).(*pb.Runner)
+	r.AdoptionState = pb.Runner_REJECTED
+	if adopt {
+		r.AdoptionState = pb.Runner_ADOPTED
+	}
+	rec := newRunnerRecord(r)
+	rec.HeartbeatTimer = old.HeartbeatTimer
+
+	if err := txn.Insert(runnerTableName, rec); err != nil {
+		return status.Errorf(codes.Aborted, err.Error())
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// RunnerHeartbeat notes that the runner with the given ID is alive. This
+// marks the runner as healthy again if it was lost.
+func (s *State) RunnerHeartbeat(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		return status.Errorf(codes.NotFound, "runner ID not found")
+	}
+	old := raw.(*runnerRecord)
+
+	// Records are read without locks so we update a copy.
+	r := proto.Clone(old.Runner).(*pb.Runner)
+	r.Health = pb.Runner_HEALTHY
+	r.LastHeartbeatTime = ptypes.TimestampNow()
+	rec := newRunnerRecord(r)
+	rec.HeartbeatTimer = old.HeartbeatTimer
+	if rec.HeartbeatTimer != nil {
+		rec.HeartbeatTimer.Reset(runnerHeartbeatTimeout)
+	}
+
+	if err := txn.Insert(runnerTableName, rec); err != nil {
+		return status.Errorf(codes.Aborted, err.Error())
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// RunnerList returns all registered runners sorted by ID.
+func (s *State) RunnerList() ([]*pb.Runner, error) {
+	txn := s.inmem.Txn(false)
+	defer txn.Abort()
+
+	iter, err := txn.LowerBound(runnerTableName, runnerIdIndexName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pb.Runner
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		result = append(result, raw.(*runnerRecord).Runner)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
 func (s *State) RunnerById(id string) (*pb.Runner, error) {
+	return s.RunnerByIdWatch(id, nil)
+}
+
+// RunnerByIdWatch is RunnerById but also adds a watch for changes to the
+// runner to ws if it is non-nil.
+func (s *State) RunnerByIdWatch(id string, ws memdb.WatchSet) (*pb.Runner, error) {
 	txn := s.inmem.Txn(false)
-	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
-	txn.Abort()
+	defer txn.Abort()
+
+	watchCh, raw, err := txn.FirstWatch(runnerTableName, runnerIdIndexName, id)
 	if err != nil {
 		return nil, err
 	}
+	ws.Add(watchCh)
 	if raw == nil {
 		return nil, status.Errorf(codes.NotFound, "runner ID not found")
 	}
@@ -81,6 +250,53 @@ func (s *State) RunnerById(id string) (*pb.Runner, error) {
 	return raw.(*runnerRecord).Runner, nil
 }
 
+// runnerExpire is called when the runner with the given ID stops
+// heartbeating. The runner is marked as unhealthy and the jobs assigned
+// to it are re-queued or errored depending on the server config.
+func (s *State) runnerExpire(id string) {
+	log := s.log.With("runner_id", id)
+
+	cfg, err := s.ServerConfigGet()
+	if err != nil {
+		log.Error("error reading server config for lost runner", "err", err)
+		return
+	}
+	requeue := cfg != nil && cfg.RequeueLostJobs
+
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(runnerTableName, runnerIdIndexName, id)
+	if err != nil || raw == nil {
+		return
+	}
+	old := raw.(*runnerRecord)
+
+	// If the runner heartbeat while we were waiting for the lock, then
+	// it isn't lost.
+	if t, err := ptypes.Timestamp(old.Runner.LastHeartbeatTime); err == nil &&
+		time.Since(t) < runnerHeartbeatTimeout {
+		return
+	}
+
+	log.Warn("runner stopped heartbeating", "requeue_jobs", requeue)
+	r := proto.Clone(old.Runner).(*pb.Runner)
+	r.Health = pb.Runner_UNHEALTHY
+	rec := newRunnerRecord(r)
+	rec.HeartbeatTimer = old.HeartbeatTimer
+	if err := txn.Insert(runnerTableName, rec); err != nil {
+		log.Error("error marking runner as unhealthy", "err", err)
+		return
+	}
+
+	if err := s.jobRunnerLost(txn, id, requeue); err != nil {
+		log.Error("error handling jobs of lost runner", "err", err)
+		return
+	}
+
+	txn.Commit()
+}
+
 // runnerEmpty returns true if there are no runners registered.
 func (s *State) runnerEmpty(memTxn *memdb.Txn) (bool, error) {
 	iter, err := memTxn.LowerBound(runnerTableName, runnerIdIndexName, "")
@@ -100,3 +316,10 @@ func newRunnerRecord(r *pb.Runner) *runnerRecord {
 
 	return rec
 }
+
+// End stops the heartbeat timer of the runner.
+func (rec *runnerRecord) End() {
+	if rec.HeartbeatTimer != nil {
+		rec.HeartbeatTimer.Stop()
+	}
+}
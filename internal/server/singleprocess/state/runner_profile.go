@@ -0,0 +1,101 @@
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var runnerProfileBucket = []byte("runner_profiles")
+
+func init() {
+	dbBuckets = append(dbBuckets, runnerProfileBucket)
+}
+
+// RunnerProfilePut creates or updates the given runner profile.
+func (s *State) RunnerProfilePut(v *pb.RunnerProfile) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(runnerProfileBucket), []byte(v.Name), v)
+	})
+}
+
+// RunnerProfileGet gets a runner profile by name.
+func (s *State) RunnerProfileGet(name string) (*pb.RunnerProfile, error) {
+	var result pb.RunnerProfile
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(runnerProfileBucket), []byte(name), &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RunnerProfileList returns all runner profiles sorted by name.
+func (s *State) RunnerProfileList() ([]*pb.RunnerProfile, error) {
+	var result []*pb.RunnerProfile
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(runnerProfileBucket).ForEach(func(k, v []byte) error {
+			var value pb.RunnerProfile
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+
+			result = append(result, &value)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// RunnerProfileForProject returns the runner profile used to launch
+// on-demand runners for jobs of the given project. This is the profile
+// for the project if there is one and otherwise a profile without a
+// project. If there are several candidates the first by name is used.
+// This returns nil if there is no profile.
+func (s *State) RunnerProfileForProject(project string) (*pb.RunnerProfile, error) {
+	profiles, err := s.RunnerProfileList()
+	if err != nil {
+		return nil, err
+	}
+
+	var result *pb.RunnerProfile
+	for _, p := range profiles {
+		if p.Project != "" && strings.EqualFold(p.Project, project) {
+			return p, nil
+		}
+
+		if p.Project == "" && result == nil {
+			result = p
+		}
+	}
+
+	return result, nil
+}
+
+// RunnerProfileDelete deletes the runner profile with the given name.
+func (s *State) RunnerProfileDelete(name string) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(runnerProfileBucket)
+		if b.Get([]byte(name)) == nil {
+			return status.Errorf(codes.NotFound, "runner profile not found: %s", name)
+		}
+
+		return b.Delete([]byte(name))
+	})
+}
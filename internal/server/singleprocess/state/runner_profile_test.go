@@ -0,0 +1,84 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestRunnerProfile(t *testing.T) {
+	t.Run("Put, Get and Delete", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RunnerProfilePut(&pb.RunnerProfile{
+			Name:  "A",
+			Image: "waypoint:test",
+		}))
+
+		v, err := s.RunnerProfileGet("A")
+		require.NoError(err)
+		require.Equal("waypoint:test", v.Image)
+
+		require.NoError(s.RunnerProfileDelete("A"))
+		_, err = s.RunnerProfileGet("A")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		err = s.RunnerProfileDelete("A")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RunnerProfilePut(&pb.RunnerProfile{Name: "B"}))
+		require.NoError(s.RunnerProfilePut(&pb.RunnerProfile{Name: "A"}))
+
+		list, err := s.RunnerProfileList()
+		require.NoError(err)
+		require.Len(list, 2)
+		require.Equal("A", list[0].Name)
+		require.Equal("B", list[1].Name)
+	})
+
+	t.Run("For project", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// No profiles
+		v, err := s.RunnerProfileForProject("web")
+		require.NoError(err)
+		require.Nil(v)
+
+		// Default profile
+		require.NoError(s.RunnerProfilePut(&pb.RunnerProfile{Name: "default"}))
+		v, err = s.RunnerProfileForProject("web")
+		require.NoError(err)
+		require.NotNil(v)
+		require.Equal("default", v.Name)
+
+		// Project profile takes priority
+		require.NoError(s.RunnerProfilePut(&pb.RunnerProfile{Name: "web", Project: "Web"}))
+		v, err = s.RunnerProfileForProject("web")
+		require.NoError(err)
+		require.NotNil(v)
+		require.Equal("web", v.Name)
+
+		v, err = s.RunnerProfileForProject("api")
+		require.NoError(err)
+		require.Equal("default", v.Name)
+	})
+}
@@ -1,13 +1,16 @@
 package state
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
 )
 
 func TestRunner_crud(t *testing.T) {
@@ -50,3 +53,184 @@ func TestRunnerById_notFound(t *testing.T) {
 	require.Nil(found)
 	require.Equal(codes.NotFound, status.Code(err))
 }
+
+func TestRunnerHeartbeat(t *testing.T) {
+	t.Run("stays healthy while heartbeating", func(t *testing.T) {
+		require := require.New(t)
+
+		// Set a short timeout
+		old := runnerHeartbeatTimeout
+		defer func() { runnerHeartbeatTimeout = old }()
+		runnerHeartbeatTimeout = 250 * time.Millisecond
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.RunnerCreate(&pb.Runner{Id: "A"}))
+		for i := 0; i < 10; i++ {
+			time.Sleep(50 * time.Millisecond)
+			require.NoError(s.RunnerHeartbeat("A"))
+		}
+
+		found, err := s.RunnerById("A")
+		require.NoError(err)
+		require.Equal(pb.Runner_HEALTHY, found.Health)
+		require.NotNil(found.LastHeartbeatTime)
+
+		// Heartbeating an unknown runner is an error
+		err = s.RunnerHeartbeat("B")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("errors jobs of a lost runner", func(t *testing.T) {
+		require := require.New(t)
+
+		old := runnerHeartbeatTimeout
+		defer func() { runnerHeartbeatTimeout = old }()
+		runnerHeartbeatTimeout = 50 * time.Millisecond
+
+		s := TestState(t)
+		defer s.Close()
+
+		r := &pb.Runner{Id: "R_A"}
+		require.NoError(s.RunnerCreate(r))
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+		job, err := s.JobAssignForRunner(context.Background(), r)
+		require.NoError(err)
+		require.Equal("R_A", job.AssignedRunner.Id)
+		_, err = s.JobAck(job.Id, true)
+		require.NoError(err)
+
+		require.Eventually(func() bool {
+			job, err := s.JobById("A", nil)
+			require.NoError(err)
+			return job.State == pb.Job_ERROR
+		}, 1*time.Second, 10*time.Millisecond)
+
+		job, err = s.JobById("A", nil)
+		require.NoError(err)
+		require.Equal(codes.Unavailable, codes.Code(job.Error.Code))
+
+		// The runner is unhealthy and can't be assigned jobs
+		found, err := s.RunnerById("R_A")
+		require.NoError(err)
+		require.Equal(pb.Runner_UNHEALTHY, found.Health)
+
+		assignable, err := s.JobIsAssignable(context.Background(), serverptypes.TestJobNew(t, nil))
+		require.NoError(err)
+		require.False(assignable)
+
+		// Heartbeating again makes it healthy
+		require.NoError(s.RunnerHeartbeat("R_A"))
+		found, err = s.RunnerById("R_A")
+		require.NoError(err)
+		require.Equal(pb.Runner_HEALTHY, found.Health)
+	})
+
+	t.Run("requeues jobs of a lost runner", func(t *testing.T) {
+		require := require.New(t)
+
+		old := runnerHeartbeatTimeout
+		defer func() { runnerHeartbeatTimeout = old }()
+		runnerHeartbeatTimeout = 50 * time.Millisecond
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ServerConfigSet(&pb.ServerConfig{RequeueLostJobs: true}))
+
+		r := &pb.Runner{Id: "R_A"}
+		require.NoError(s.RunnerCreate(r))
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &pb.Job{
+			Id: "A",
+		})))
+		job, err := s.JobAssignForRunner(context.Background(), r)
+		require.NoError(err)
+		_, err = s.JobAck(job.Id, true)
+		require.NoError(err)
+
+		require.Eventually(func() bool {
+			job, err := s.JobById("A", nil)
+			require.NoError(err)
+			return job.State == pb.Job_QUEUED
+		}, 1*time.Second, 10*time.Millisecond)
+
+		job, err = s.JobById("A", nil)
+		require.NoError(err)
+		require.Nil(job.AssignedRunner)
+		require.Nil(job.AckTime)
+
+		// Another runner picks it up
+		job, err = s.JobAssignForRunner(context.Background(), &pb.Runner{Id: "R_B"})
+		require.NoError(err)
+		require.Equal("A", job.Id)
+		require.Equal("R_B", job.AssignedRunner.Id)
+	})
+}
+
+func TestRunnerForget(t *testing.T) {
+	require := require.New(t)
+
+	old := runnerHeartbeatTimeout
+	defer func() { runnerHeartbeatTimeout = old }()
+	runnerHeartbeatTimeout = 50 * time.Millisecond
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Unknown runners can't be forgotten
+	err := s.RunnerForget("A")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// Healthy runners can't be forgotten
+	require.NoError(s.RunnerCreate(&pb.Runner{Id: "A"}))
+	err = s.RunnerForget("A")
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// Once it stops heartbeating it can be
+	require.Eventually(func() bool {
+		r, err := s.RunnerById("A")
+		require.NoError(err)
+		return r.Health == pb.Runner_UNHEALTHY
+	}, 1*time.Second, 10*time.Millisecond)
+	require.NoError(s.RunnerForget("A"))
+
+	_, err = s.RunnerById("A")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestRunnerAdopt(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Unknown runners can't be adopted
+	err := s.RunnerAdopt("A", true)
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// New runners are pending
+	require.NoError(s.RunnerCreate(&pb.Runner{Id: "A"}))
+	found, err := s.RunnerById("A")
+	require.NoError(err)
+	require.Equal(pb.Runner_PENDING, found.AdoptionState)
+
+	require.NoError(s.RunnerAdopt("A", true))
+	found, err = s.RunnerById("A")
+	require.NoError(err)
+	require.Equal(pb.Runner_ADOPTED, found.AdoptionState)
+
+	// Rejected runners stay rejected when they register again
+	require.NoError(s.RunnerAdopt("A", false))
+	require.NoError(s.RunnerCreate(&pb.Runner{Id: "A", AdoptionState: pb.Runner_ADOPTED}))
+	found, err = s.RunnerById("A")
+	require.NoError(err)
+	require.Equal(pb.Runner_REJECTED, found.AdoptionState)
+}
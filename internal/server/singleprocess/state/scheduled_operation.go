@@ -0,0 +1,244 @@
+package state
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-memdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var scheduledOpBucket = []byte("scheduled_operations")
+
+func init() {
+	dbBuckets = append(dbBuckets, scheduledOpBucket)
+	dbIndexers = append(dbIndexers, (*State).scheduledOpIndexInit)
+	schemas = append(schemas, scheduledOpIndexSchema)
+}
+
+// ScheduledOperationPut creates or updates the given scheduled operation.
+func (s *State) ScheduledOperationPut(op *pb.ScheduledOperation) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		return s.scheduledOpPut(dbTxn, memTxn, op)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+// ScheduledOperationGet gets a scheduled operation by ID.
+func (s *State) ScheduledOperationGet(id string) (*pb.ScheduledOperation, error) {
+	var result *pb.ScheduledOperation
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		var err error
+		result, err = s.scheduledOpGet(dbTxn, id)
+		return err
+	})
+
+	return result, err
+}
+
+// ScheduledOperationListDue returns the pending scheduled operations with
+// a schedule time at or before now, ordered by schedule time.
+func (s *State) ScheduledOperationListDue(now time.Time) ([]*pb.ScheduledOperation, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	iter, err := memTxn.LowerBound(
+		scheduledOpIndexTableName,
+		scheduledOpIndexTimeIndexName,
+		time.Unix(0, 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		idx := raw.(*scheduledOpIndexRecord)
+		if idx.ScheduleTime.After(now) {
+			break
+		}
+		if idx.State != pb.ScheduledOperation_PENDING {
+			continue
+		}
+
+		ids = append(ids, idx.Id)
+	}
+
+	var result []*pb.ScheduledOperation
+	err = s.db.View(func(dbTxn *bolt.Tx) error {
+		for _, id := range ids {
+			op, err := s.scheduledOpGet(dbTxn, id)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, op)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ScheduledOperationCancel cancels a pending scheduled operation. This
+// returns an error if the operation was already queued. Cancelling an
+// already cancelled operation does nothing.
+func (s *State) ScheduledOperationCancel(id string) error {
+	return s.scheduledOpUpdate(id, func(op *pb.ScheduledOperation) error {
+		switch op.State {
+		case pb.ScheduledOperation_CANCELLED:
+			return nil
+
+		case pb.ScheduledOperation_QUEUED:
+			return status.Errorf(codes.FailedPrecondition,
+				"scheduled operation %s was already queued as job %s", id, op.JobId)
+		}
+
+		op.State = pb.ScheduledOperation_CANCELLED
+		return nil
+	})
+}
+
+// ScheduledOperationQueued marks a pending scheduled operation as queued
+// with the given job ID.
+func (s *State) ScheduledOperationQueued(id, jobId string) error {
+	return s.scheduledOpUpdate(id, func(op *pb.ScheduledOperation) error {
+		if op.State != pb.ScheduledOperation_PENDING {
+			return status.Errorf(codes.FailedPrecondition,
+				"scheduled operation %s is not pending", id)
+		}
+
+		op.State = pb.ScheduledOperation_QUEUED
+		op.JobId = jobId
+		return nil
+	})
+}
+
+func (s *State) scheduledOpUpdate(id string, f func(*pb.ScheduledOperation) error) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		op, err := s.scheduledOpGet(dbTxn, id)
+		if err != nil {
+			return err
+		}
+
+		if err := f(op); err != nil {
+			return err
+		}
+
+		return s.scheduledOpPut(dbTxn, memTxn, op)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+func (s *State) scheduledOpPut(
+	dbTxn *bolt.Tx,
+	memTxn *memdb.Txn,
+	op *pb.ScheduledOperation,
+) error {
+	if err := dbPut(dbTxn.Bucket(scheduledOpBucket), []byte(op.Id), op); err != nil {
+		return err
+	}
+
+	return s.scheduledOpIndexSet(memTxn, op)
+}
+
+func (s *State) scheduledOpGet(dbTxn *bolt.Tx, id string) (*pb.ScheduledOperation, error) {
+	var result pb.ScheduledOperation
+	if err := dbGet(dbTxn.Bucket(scheduledOpBucket), []byte(id), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// scheduledOpIndexSet writes an index record for a single scheduled operation.
+func (s *State) scheduledOpIndexSet(txn *memdb.Txn, op *pb.ScheduledOperation) error {
+	t, err := ptypes.Timestamp(op.ScheduleTime)
+	if err != nil {
+		return err
+	}
+
+	return txn.Insert(scheduledOpIndexTableName, &scheduledOpIndexRecord{
+		Id:           op.Id,
+		State:        op.State,
+		ScheduleTime: t,
+	})
+}
+
+// scheduledOpIndexInit initializes the scheduled operation index from
+// persisted data.
+func (s *State) scheduledOpIndexInit(dbTxn *bolt.Tx, memTxn *memdb.Txn) error {
+	bucket := dbTxn.Bucket(scheduledOpBucket)
+	return bucket.ForEach(func(k, v []byte) error {
+		var value pb.ScheduledOperation
+		if err := proto.Unmarshal(v, &value); err != nil {
+			return err
+		}
+
+		return s.scheduledOpIndexSet(memTxn, &value)
+	})
+}
+
+func scheduledOpIndexSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: scheduledOpIndexTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			scheduledOpIndexIdIndexName: {
+				Name:         scheduledOpIndexIdIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field:     "Id",
+					Lowercase: true,
+				},
+			},
+
+			scheduledOpIndexTimeIndexName: {
+				Name:         scheduledOpIndexTimeIndexName,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &IndexTime{
+					Field: "ScheduleTime",
+					Asc:   true,
+				},
+			},
+		},
+	}
+}
+
+const (
+	scheduledOpIndexTableName     = "scheduled-operation-index"
+	scheduledOpIndexIdIndexName   = "id"
+	scheduledOpIndexTimeIndexName = "schedule-time"
+)
+
+type scheduledOpIndexRecord struct {
+	Id           string
+	State        pb.ScheduledOperation_State
+	ScheduleTime time.Time
+}
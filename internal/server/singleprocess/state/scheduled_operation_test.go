@@ -0,0 +1,119 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestScheduledOperation(t *testing.T) {
+	now := time.Now()
+
+	testOp := func(t *testing.T, id string, at time.Time) *pb.ScheduledOperation {
+		ts, err := ptypes.TimestampProto(at)
+		require.NoError(t, err)
+
+		return &pb.ScheduledOperation{
+			Id:           id,
+			Job:          serverptypes.TestJobNew(t, nil),
+			ScheduleTime: ts,
+		}
+	}
+
+	t.Run("Put and Get", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ScheduledOperationPut(testOp(t, "A", now)))
+
+		op, err := s.ScheduledOperationGet("A")
+		require.NoError(err)
+		require.Equal("A", op.Id)
+		require.Equal(pb.ScheduledOperation_PENDING, op.State)
+
+		_, err = s.ScheduledOperationGet("B")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("List due", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ScheduledOperationPut(testOp(t, "later", now.Add(time.Hour))))
+		require.NoError(s.ScheduledOperationPut(testOp(t, "second", now.Add(-time.Minute))))
+		require.NoError(s.ScheduledOperationPut(testOp(t, "first", now.Add(-time.Hour))))
+
+		due, err := s.ScheduledOperationListDue(now)
+		require.NoError(err)
+		require.Len(due, 2)
+		require.Equal("first", due[0].Id)
+		require.Equal("second", due[1].Id)
+
+		// Queued operations are no longer due
+		require.NoError(s.ScheduledOperationQueued("first", "job"))
+		due, err = s.ScheduledOperationListDue(now)
+		require.NoError(err)
+		require.Len(due, 1)
+		require.Equal("second", due[0].Id)
+
+		op, err := s.ScheduledOperationGet("first")
+		require.NoError(err)
+		require.Equal(pb.ScheduledOperation_QUEUED, op.State)
+		require.Equal("job", op.JobId)
+
+		// Everything is due eventually
+		due, err = s.ScheduledOperationListDue(now.Add(2 * time.Hour))
+		require.NoError(err)
+		require.Len(due, 2)
+
+		// And survives a restart
+		s2 := TestStateReinit(t, s)
+		defer s2.Close()
+		due, err = s2.ScheduledOperationListDue(now)
+		require.NoError(err)
+		require.Len(due, 1)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.ScheduledOperationPut(testOp(t, "A", now)))
+		require.NoError(s.ScheduledOperationPut(testOp(t, "B", now)))
+		require.NoError(s.ScheduledOperationCancel("A"))
+
+		// Cancelled operations aren't due
+		due, err := s.ScheduledOperationListDue(now)
+		require.NoError(err)
+		require.Len(due, 1)
+		require.Equal("B", due[0].Id)
+
+		// Cancel is idempotent
+		require.NoError(s.ScheduledOperationCancel("A"))
+
+		// Can't cancel after being queued
+		require.NoError(s.ScheduledOperationQueued("B", "job"))
+		err = s.ScheduledOperationCancel("B")
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+
+		// Can't cancel what doesn't exist
+		err = s.ScheduledOperationCancel("C")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+}
@@ -21,11 +21,22 @@ func init() {
 
 // ServerConfigSet writes the server configuration.
 func (s *State) ServerConfigSet(c *pb.ServerConfig) error {
+	return s.ServerConfigSetBy(c, "")
+}
+
+// ServerConfigSetBy writes the server configuration and records it as a
+// new version in the history with setBy as the identity that set it. See
+// ServerConfigHistory.
+func (s *State) ServerConfigSetBy(c *pb.ServerConfig, setBy string) error {
 	memTxn := s.inmem.Txn(true)
 	defer memTxn.Abort()
 
 	err := s.db.Update(func(dbTxn *bolt.Tx) error {
-		return s.serverConfigSet(dbTxn, memTxn, c)
+		if err := s.serverConfigSet(dbTxn, memTxn, c); err != nil {
+			return err
+		}
+
+		return s.serverConfigHistoryAppend(dbTxn, c, setBy)
 	})
 	if err == nil {
 		memTxn.Commit()
@@ -39,6 +50,10 @@ func (s *State) ServerConfigGet() (*pb.ServerConfig, error) {
 	memTxn := s.inmem.Txn(false)
 	defer memTxn.Abort()
 
+	return s.serverConfigGet(memTxn)
+}
+
+func (s *State) serverConfigGet(memTxn *memdb.Txn) (*pb.ServerConfig, error) {
 	v, err := memTxn.First(
 		serverConfigIndexTableName,
 		serverConfigIndexIdIndexName,
@@ -0,0 +1,110 @@
+package state
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var serverConfigHistoryBucket = []byte("server-config-history")
+
+func init() {
+	dbBuckets = append(dbBuckets, serverConfigHistoryBucket)
+}
+
+// ServerConfigHistory returns every version of the server config that
+// has been set, oldest first.
+func (s *State) ServerConfigHistory() ([]*pb.ServerConfigVersion, error) {
+	var result []*pb.ServerConfigVersion
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(serverConfigHistoryBucket).ForEach(func(k, v []byte) error {
+			var value pb.ServerConfigVersion
+			if err := proto.Unmarshal(v, &value); err != nil {
+				return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+			}
+
+			result = append(result, &value)
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+// ServerConfigVersionGet returns a single version of the server config.
+func (s *State) ServerConfigVersionGet(version uint64) (*pb.ServerConfigVersion, error) {
+	var result pb.ServerConfigVersion
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(serverConfigHistoryBucket)
+		raw := b.Get(serverConfigVersionKey(version))
+		if raw == nil {
+			return status.Errorf(codes.NotFound,
+				"server config version %d not found", version)
+		}
+
+		if err := proto.Unmarshal(raw, &result); err != nil {
+			return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// serverConfigHistoryAppend records value as the next version of the
+// server config. Nothing is recorded if value is nil or equal to the
+// latest version, so restarting the server with the same configuration
+// doesn't grow the history.
+func (s *State) serverConfigHistoryAppend(dbTxn *bolt.Tx, value *pb.ServerConfig, setBy string) error {
+	if value == nil {
+		return nil
+	}
+
+	b := dbTxn.Bucket(serverConfigHistoryBucket)
+	if _, raw := b.Cursor().Last(); raw != nil {
+		var latest pb.ServerConfigVersion
+		if err := proto.Unmarshal(raw, &latest); err != nil {
+			return status.Errorf(codes.Internal, "failed to decode data: %s", err)
+		}
+
+		if proto.Equal(latest.Config, value) {
+			return nil
+		}
+	}
+
+	version, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	ts, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return err
+	}
+
+	return dbPut(b, serverConfigVersionKey(version), &pb.ServerConfigVersion{
+		Version: version,
+		Config:  value,
+		Time:    ts,
+		SetBy:   setBy,
+	})
+}
+
+// serverConfigVersionKey returns the bucket key for a version. Keys are
+// big endian so that the bucket iterates in version order.
+func serverConfigVersionKey(version uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, version)
+	return key
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestServerConfigHistory(t *testing.T) {
+	t.Run("records versions", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Empty to start
+		history, err := s.ServerConfigHistory()
+		require.NoError(err)
+		require.Empty(history)
+
+		a := &pb.ServerConfig{
+			AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{{Addr: "a:1234"}},
+		}
+		b := &pb.ServerConfig{
+			AdvertiseAddrs: []*pb.ServerConfig_AdvertiseAddr{{Addr: "b:1234"}},
+		}
+		require.NoError(s.ServerConfigSet(a))
+		require.NoError(s.ServerConfigSetBy(b, "alice"))
+
+		// Setting the same config doesn't record a version
+		require.NoError(s.ServerConfigSetBy(b, "alice"))
+
+		// Unsetting doesn't record a version
+		require.NoError(s.ServerConfigSet(nil))
+
+		history, err = s.ServerConfigHistory()
+		require.NoError(err)
+		require.Len(history, 2)
+		require.Equal(uint64(1), history[0].Version)
+		require.True(proto.Equal(a, history[0].Config))
+		require.Empty(history[0].SetBy)
+		require.NotNil(history[0].Time)
+		require.Equal(uint64(2), history[1].Version)
+		require.True(proto.Equal(b, history[1].Config))
+		require.Equal("alice", history[1].SetBy)
+
+		v, err := s.ServerConfigVersionGet(1)
+		require.NoError(err)
+		require.True(proto.Equal(a, v.Config))
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.ServerConfigVersionGet(42)
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+}
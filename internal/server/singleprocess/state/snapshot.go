@@ -0,0 +1,126 @@
+package state
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restoreSuffix is appended to the database path for a staged restore.
+// A staged restore replaces the database the next time the server starts.
+const restoreSuffix = ".restore"
+
+// CreateSnapshot writes a snapshot of the entire persisted state to w.
+// The snapshot is a gzip compressed copy of the database taken in a single
+// read transaction so it is consistent. Ephemeral in-memory data such as
+// connected runners is not part of the snapshot.
+func (s *State) CreateSnapshot(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if err := s.db.View(func(dbTxn *bolt.Tx) error {
+		_, err := dbTxn.WriteTo(gz)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// StageRestoreSnapshot reads a snapshot created by CreateSnapshot from r
+// and stages it to replace the current database. The running server keeps
+// using the current data; the snapshot is restored by FinalizeRestore the
+// next time the server starts.
+func (s *State) StageRestoreSnapshot(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "snapshot is not valid: %s", err)
+	}
+
+	// Write to a temporary file in the same directory first so that a
+	// failed restore never leaves a partial file to be finalized.
+	path := s.db.Path()
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+"-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, gz)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "error reading snapshot: %s", err)
+	}
+
+	if err := validateSnapshotDB(f.Name()); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path+restoreSuffix)
+}
+
+// FinalizeRestore replaces the database at path with a snapshot staged by
+// StageRestoreSnapshot, if there is one. The replaced database is kept
+// with a timestamp suffix. This must be called before the database is
+// opened.
+func FinalizeRestore(log hclog.Logger, path string) error {
+	staged := path + restoreSuffix
+	if _, err := os.Stat(staged); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		backup := path + ".backup-" + strconv.FormatInt(time.Now().Unix(), 10)
+		log.Info("backing up database before restoring snapshot", "path", backup)
+		if err := os.Rename(path, backup); err != nil {
+			return err
+		}
+	}
+
+	log.Info("restoring database from snapshot", "path", path)
+	return os.Rename(staged, path)
+}
+
+// validateSnapshotDB checks that the file at path is a database that this
+// server can read.
+func validateSnapshotDB(path string) error {
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		Timeout:  time.Second,
+		ReadOnly: true,
+	})
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "snapshot is not a valid database: %s", err)
+	}
+	defer db.Close()
+
+	return db.View(func(dbTxn *bolt.Tx) error {
+		sys := dbTxn.Bucket(sysBucket)
+		if sys == nil {
+			return status.Errorf(codes.InvalidArgument,
+				"snapshot is not a Waypoint server database")
+		}
+
+		vsn := sys.Get(sysVersionKey)
+		if string(vsn) != strconv.FormatInt(dbVersion, 10) {
+			return status.Errorf(codes.FailedPrecondition,
+				"snapshot data version %q does not match the server data version %d",
+				vsn, dbVersion)
+		}
+
+		return nil
+	})
+}
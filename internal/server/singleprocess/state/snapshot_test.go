@@ -0,0 +1,63 @@
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Write some data
+	require.NoError(s.ProjectPut(serverptypes.TestProject(t, &pb.Project{
+		Name: "A",
+	})))
+
+	// Snapshot
+	var buf bytes.Buffer
+	require.NoError(s.CreateSnapshot(&buf))
+
+	// Change the data after the snapshot
+	require.NoError(s.ProjectPut(serverptypes.TestProject(t, &pb.Project{
+		Name: "B",
+	})))
+
+	// Stage the restore and finalize it as if restarting
+	require.NoError(s.StageRestoreSnapshot(&buf))
+	path := s.db.Path()
+	require.NoError(s.db.Close())
+	require.NoError(FinalizeRestore(hclog.L(), path))
+
+	db, err := bolt.Open(path, 0600, nil)
+	require.NoError(err)
+	defer db.Close()
+	s, err = New(hclog.L(), db)
+	require.NoError(err)
+
+	_, err = s.ProjectGet(&pb.Ref_Project{Project: "A"})
+	require.NoError(err)
+	_, err = s.ProjectGet(&pb.Ref_Project{Project: "B"})
+	require.Error(err)
+
+	// Nothing left to finalize
+	require.NoError(FinalizeRestore(hclog.L(), path))
+}
+
+func TestStageRestoreSnapshot_invalid(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	require.Error(s.StageRestoreSnapshot(bytes.NewBufferString("not a snapshot")))
+}
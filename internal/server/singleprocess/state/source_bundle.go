@@ -0,0 +1,77 @@
+package state
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var sourceBundleBucket = []byte("source_bundles")
+
+func init() {
+	dbBuckets = append(dbBuckets, sourceBundleBucket)
+}
+
+// SourceBundlePut stores an uploaded source bundle. The bundle must have
+// an ID set.
+func (s *State) SourceBundlePut(b *pb.SourceBundle) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(sourceBundleBucket), []byte(b.Id), b)
+	})
+}
+
+// SourceBundleGet gets the source bundle with the given ID.
+func (s *State) SourceBundleGet(id string) (*pb.SourceBundle, error) {
+	var result pb.SourceBundle
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(sourceBundleBucket), []byte(id), &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SourceBundlePrune deletes the source bundles uploaded before the given
+// time. This returns the number of bundles deleted.
+func (s *State) SourceBundlePrune(before time.Time) (int, error) {
+	count := 0
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(sourceBundleBucket)
+
+		var ids [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			var bundle pb.SourceBundle
+			if err := proto.Unmarshal(v, &bundle); err != nil {
+				return err
+			}
+
+			uploaded, err := ptypes.Timestamp(bundle.UploadTime)
+			if err != nil || uploaded.Before(before) {
+				ids = append(ids, append([]byte(nil), k...))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Keys can't be deleted during ForEach, so they're deleted after.
+		for _, id := range ids {
+			if err := b.Delete(id); err != nil {
+				return err
+			}
+		}
+
+		count = len(ids)
+		return nil
+	})
+
+	return count, err
+}
@@ -0,0 +1,60 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestSourceBundle(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Not found
+	_, err := s.SourceBundleGet("a")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	now := time.Now()
+	old, err := ptypes.TimestampProto(now.Add(-2 * time.Hour))
+	require.NoError(err)
+	recent, err := ptypes.TimestampProto(now)
+	require.NoError(err)
+
+	require.NoError(s.SourceBundlePut(&pb.SourceBundle{
+		Id:         "a",
+		Project:    &pb.Ref_Project{Project: "p"},
+		Data:       []byte("hello"),
+		UploadTime: old,
+	}))
+	require.NoError(s.SourceBundlePut(&pb.SourceBundle{
+		Id:         "b",
+		Project:    &pb.Ref_Project{Project: "p"},
+		UploadTime: recent,
+	}))
+
+	b, err := s.SourceBundleGet("a")
+	require.NoError(err)
+	require.Equal([]byte("hello"), b.Data)
+	require.Equal("p", b.Project.Project)
+
+	// Prune deletes only the old bundle
+	count, err := s.SourceBundlePrune(now.Add(-time.Hour))
+	require.NoError(err)
+	require.Equal(1, count)
+
+	_, err = s.SourceBundleGet("a")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	_, err = s.SourceBundleGet("b")
+	require.NoError(err)
+}
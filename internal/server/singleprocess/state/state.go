@@ -38,8 +38,8 @@ type State struct {
 	// and supports a transactional model for safe concurrent access.
 	// inmem is used alongside db to store in-memory indexing information
 	// for more efficient lookups into db. This index is built online at
-	// boot.
-	db *bolt.DB
+	// boot. Transactions are timed for metrics.
+	db *timedDB
 
 	// hmacKeyNotEmpty is flipped to 1 when an hmac entry is set. This is
 	// used to determine if we're in a bootstrap state and can create a
@@ -67,7 +67,7 @@ func New(log hclog.Logger, db *bolt.DB) (*State, error) {
 		return nil, err
 	}
 
-	s := &State{inmem: inmem, db: db, log: log}
+	s := &State{inmem: inmem, db: newTimedDB(db), log: log}
 
 	// Initialize our set that'll track what memdb indexers we call.
 	// When we're done we always clear this out since it is never used
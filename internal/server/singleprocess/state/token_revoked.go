@@ -0,0 +1,32 @@
+package state
+
+import (
+	"github.com/boltdb/bolt"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var tokenRevokedBucket = []byte("token_revoked")
+
+func init() {
+	dbBuckets = append(dbBuckets, tokenRevokedBucket)
+}
+
+// TokenRevoke records that the token with the given body is revoked. A
+// revoked token is rejected even if it hasn't expired.
+func (s *State) TokenRevoke(body *pb.Token) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(tokenRevokedBucket), body.TokenId, body)
+	})
+}
+
+// TokenRevoked returns true if the token with the given ID was revoked.
+func (s *State) TokenRevoked(id []byte) (bool, error) {
+	var result bool
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		result = dbTxn.Bucket(tokenRevokedBucket).Get(id) != nil
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,33 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestTokenRevoke(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Not revoked
+	revoked, err := s.TokenRevoked([]byte("a"))
+	require.NoError(err)
+	require.False(revoked)
+
+	// Revoke
+	require.NoError(s.TokenRevoke(&pb.Token{TokenId: []byte("a")}))
+
+	revoked, err = s.TokenRevoked([]byte("a"))
+	require.NoError(err)
+	require.True(revoked)
+
+	// Other tokens are unaffected
+	revoked, err = s.TokenRevoked([]byte("b"))
+	require.NoError(err)
+	require.False(revoked)
+}
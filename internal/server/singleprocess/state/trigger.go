@@ -0,0 +1,41 @@
+package state
+
+import (
+	"github.com/boltdb/bolt"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+var triggerBucket = []byte("triggers")
+
+func init() {
+	dbBuckets = append(dbBuckets, triggerBucket)
+}
+
+// TriggerPut creates or updates a trigger. The trigger must have an ID.
+func (s *State) TriggerPut(v *pb.Trigger) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbPut(dbTxn.Bucket(triggerBucket), []byte(v.Id), v)
+	})
+}
+
+// TriggerGet gets a trigger by ID.
+func (s *State) TriggerGet(id string) (*pb.Trigger, error) {
+	var result pb.Trigger
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		return dbGet(dbTxn.Bucket(triggerBucket), []byte(id), &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// TriggerDelete deletes a trigger by ID. It isn't an error if the trigger
+// doesn't exist.
+func (s *State) TriggerDelete(id string) error {
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		return dbTxn.Bucket(triggerBucket).Delete([]byte(id))
+	})
+}
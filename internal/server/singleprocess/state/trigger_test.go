@@ -0,0 +1,42 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hashicorp/waypoint/internal/server/gen"
+)
+
+func TestTrigger(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	// Not found
+	_, err := s.TriggerGet("a")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	require.NoError(s.TriggerPut(&pb.Trigger{
+		Id:      "a",
+		Project: &pb.Ref_Project{Project: "p"},
+		Secret:  "s",
+	}))
+
+	v, err := s.TriggerGet("a")
+	require.NoError(err)
+	require.Equal("s", v.Secret)
+
+	// Delete
+	require.NoError(s.TriggerDelete("a"))
+	_, err = s.TriggerGet("a")
+	require.Error(err)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// Deleting again is fine
+	require.NoError(s.TriggerDelete("a"))
+}
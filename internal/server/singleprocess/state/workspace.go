@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-memdb"
 	"google.golang.org/grpc/codes"
@@ -135,6 +136,94 @@ func (s *State) WorkspaceGet(n string) (*pb.Workspace, error) {
 		"not found for name: %q", n)
 }
 
+// WorkspaceDelete deletes the workspace with the given name along with
+// all the builds, artifacts, deployments, releases, and health reports
+// recorded in it. If the workspace has any deployments that haven't been
+// destroyed this returns a FailedPrecondition error unless force is true.
+// If the workspace doesn't exist this returns a NotFound error.
+func (s *State) WorkspaceDelete(n string, force bool) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		return s.workspaceDelete(dbTxn, memTxn, n, force)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+func (s *State) workspaceDelete(
+	dbTxn *bolt.Tx,
+	memTxn *memdb.Txn,
+	n string,
+	force bool,
+) error {
+	iter, err := memTxn.Get(
+		workspaceIndexTableName,
+		workspaceIndexIdIndexName+"_prefix",
+		n,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The prefix lookup also matches any workspace whose name starts with
+	// n, so we only keep the exact matches.
+	var records []*workspaceIndexRecord
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		rec := raw.(*workspaceIndexRecord)
+		if strings.EqualFold(rec.Name, n) {
+			records = append(records, rec)
+		}
+	}
+	if len(records) == 0 {
+		return status.Errorf(codes.NotFound,
+			"not found for name: %q", n)
+	}
+
+	if !force {
+		deployments, err := deploymentOp.workspaceRecords(memTxn, n)
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range deployments {
+			var d pb.Deployment
+			if err := deploymentOp.dbGet(dbTxn, []byte(rec.Id), &d); err != nil {
+				return err
+			}
+
+			if d.State == pb.Operation_CREATED {
+				return status.Errorf(codes.FailedPrecondition,
+					"workspace %q has active deployment %q for app %q, destroy it "+
+						"first or force the delete", n, d.Id, rec.App)
+			}
+		}
+	}
+
+	for _, op := range []*appOperation{buildOp, artifactOp, deploymentOp, releaseOp, healthReportOp} {
+		if err := op.dbDeleteWorkspace(dbTxn, memTxn, n); err != nil {
+			return err
+		}
+	}
+
+	for _, rec := range records {
+		if err := memTxn.Delete(workspaceIndexTableName, rec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // workspaceTouch creates an initial record for the given workspace or
 // returns one if it already exists. It also updates the LastActiveAt time
 // for this resource.
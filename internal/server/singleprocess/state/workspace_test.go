@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/hashicorp/waypoint/internal/server/gen"
 	serverptypes "github.com/hashicorp/waypoint/internal/server/ptypes"
@@ -70,4 +72,91 @@ func TestWorkspace(t *testing.T) {
 			require.Len(result, 2)
 		}
 	})
+	t.Run("Delete", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.BuildPut(false, serverptypes.TestValidBuild(t, &pb.Build{
+			Id: "1",
+		})))
+		require.NoError(s.BuildPut(false, serverptypes.TestValidBuild(t, &pb.Build{
+			Id: "2",
+			Workspace: &pb.Ref_Workspace{
+				Workspace: "staging",
+			},
+		})))
+		require.NoError(s.DeploymentPut(false, serverptypes.TestValidDeployment(t, &pb.Deployment{
+			Id:    "1",
+			State: pb.Operation_DESTROYED,
+			Workspace: &pb.Ref_Workspace{
+				Workspace: "staging",
+			},
+		})))
+
+		// Delete
+		require.NoError(s.WorkspaceDelete("staging", false))
+
+		// Only the default workspace is left
+		result, err := s.WorkspaceList()
+		require.NoError(err)
+		require.Len(result, 1)
+		require.Equal("default", result[0].Name)
+
+		// The operations in it are gone
+		_, err = s.BuildGet(&pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: "2"},
+		})
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+		_, err = s.DeploymentGet(&pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: "1"},
+		})
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		// The other workspace is untouched
+		_, err = s.BuildGet(&pb.Ref_Operation{
+			Target: &pb.Ref_Operation_Id{Id: "1"},
+		})
+		require.NoError(err)
+	})
+
+	t.Run("Delete non-existent", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		err := s.WorkspaceDelete("nope", false)
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Delete with active deployments", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.DeploymentPut(false, serverptypes.TestValidDeployment(t, &pb.Deployment{
+			Id:    "1",
+			State: pb.Operation_CREATED,
+		})))
+
+		err := s.WorkspaceDelete("default", false)
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+
+		// Still exists
+		_, err = s.WorkspaceGet("default")
+		require.NoError(err)
+
+		// Force
+		require.NoError(s.WorkspaceDelete("default", true))
+		_, err = s.WorkspaceGet("default")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
 }
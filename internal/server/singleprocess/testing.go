@@ -3,6 +3,7 @@ package singleprocess
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -33,12 +34,19 @@ func TestServer(t testing.T, opts ...Option) pb.WaypointClient {
 
 // TestImpl returns the waypoint server implementation. This can be used
 // with server.TestServer. It is easier to just use TestServer directly.
+//
+// Runners are adopted as they register so that tests can run jobs
+// without adopting them.
 func TestImpl(t testing.T, opts ...Option) pb.WaypointServer {
 	impl, err := New(append(
-		[]Option{WithDB(testDB(t))},
+		[]Option{
+			WithDB(testDB(t)),
+			WithConfig(&configpkg.ServerConfig{AutoAdoptRunners: true}),
+		},
 		opts...,
 	)...)
 	require.NoError(t, err)
+	t.Cleanup(func() { impl.(io.Closer).Close() })
 	return impl
 }
 
@@ -175,7 +183,11 @@ func TestRunner(t testing.T, client pb.WaypointClient, r *pb.Runner) (string, fu
 	_, err = stream.Recv()
 	require.NoError(err)
 
-	return id, func() { stream.CloseSend() }
+	// Adopt it so that it is assigned jobs
+	_, err = client.AdoptRunner(ctx, &pb.AdoptRunnerRequest{RunnerId: r.Id})
+	require.NoError(err)
+
+	return r.Id, func() { stream.CloseSend() }
 }
 
 // TestApp creates the app in the DB.
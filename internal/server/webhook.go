@@ -0,0 +1,17 @@
+package server
+
+import (
+	"net/http"
+)
+
+// WebhookPathPrefix is the HTTP path prefix that is routed to the
+// WebhookHandler.
+const WebhookPathPrefix = "/webhook/"
+
+// WebhookHandler is implemented by a service that accepts webhooks from
+// Git hosts. If the service given to the server implements this then HTTP
+// requests with a path under WebhookPathPrefix are passed to it. These
+// requests don't carry a Waypoint token so the handler must verify them.
+type WebhookHandler interface {
+	ServeWebhook(w http.ResponseWriter, r *http.Request)
+}
@@ -5,6 +5,9 @@ import (
 	"crypto/tls"
 	"fmt"
 	"os"
+	"path"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -13,6 +16,7 @@ import (
 	"github.com/hashicorp/waypoint/internal/clicontext"
 	"github.com/hashicorp/waypoint/internal/config"
 	"github.com/hashicorp/waypoint/internal/protocolversion"
+	"github.com/hashicorp/waypoint/internal/telemetry"
 )
 
 // ConnectOption is used to configure how Waypoint server connection
@@ -51,6 +55,8 @@ func Connect(ctx context.Context, opts ...ConnectOption) (*grpc.ClientConn, erro
 		grpc.WithBlock(),
 		grpc.WithUnaryInterceptor(protocolversion.UnaryClientInterceptor(protocolversion.Current())),
 		grpc.WithStreamInterceptor(protocolversion.StreamClientInterceptor(protocolversion.Current())),
+		grpc.WithChainUnaryInterceptor(telemetry.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(telemetry.StreamClientInterceptor()),
 	}
 
 	if !cfg.Tls {
@@ -71,7 +77,14 @@ func Connect(ctx context.Context, opts ...ConnectOption) (*grpc.ClientConn, erro
 			return nil, fmt.Errorf("No token available at the WAYPOINT_SERVER_TOKEN environment variable")
 		}
 
-		grpcOpts = append(grpcOpts, grpc.WithPerRPCCredentials(StaticToken(token)))
+		if cfg.RunnerToken != nil {
+			grpcOpts = append(grpcOpts,
+				grpc.WithChainUnaryInterceptor(runnerTokenUnaryInterceptor(token, cfg.RunnerToken)),
+				grpc.WithChainStreamInterceptor(runnerTokenStreamInterceptor(token, cfg.RunnerToken)),
+			)
+		} else {
+			grpcOpts = append(grpcOpts, grpc.WithPerRPCCredentials(StaticToken(token)))
+		}
 	}
 
 	// Connect to this server
@@ -109,6 +122,7 @@ type connectConfig struct {
 	Token         string
 	Optional      bool // See Optional func
 	Timeout       time.Duration
+	RunnerToken   *DynamicToken
 }
 
 // FromEnv sources the connection information from the environment
@@ -201,6 +215,17 @@ func Optional() ConnectOption {
 	}
 }
 
+// WithRunnerToken sends the token of t with requests to the runner
+// endpoints once it is set, rather than the sourced token. The server only
+// accepts runner tokens for these endpoints so the sourced token is still
+// required for everything else. t can be replaced while connected.
+func WithRunnerToken(t *DynamicToken) ConnectOption {
+	return func(c *connectConfig) error {
+		c.RunnerToken = t
+		return nil
+	}
+}
+
 // Timeout specifies a connection timeout. This defaults to 5 seconds.
 func Timeout(t time.Duration) ConnectOption {
 	return func(c *connectConfig) error {
@@ -223,6 +248,10 @@ const (
 	// EnvServerToken is the token for authenticated with the server.
 	EnvServerToken = "WAYPOINT_SERVER_TOKEN"
 
+	// EnvRunnerToken is the runner token of a runner. This is used by
+	// runners that the server launches so that they're already adopted.
+	EnvRunnerToken = "WAYPOINT_RUNNER_TOKEN"
+
 	// EnvContext specifies a named context to load.
 	EnvContext = "WAYPOINT_CONTEXT"
 )
@@ -242,3 +271,65 @@ func (t StaticToken) GetRequestMetadata(ctx context.Context, uri ...string) (map
 func (t StaticToken) RequireTransportSecurity() bool {
 	return false
 }
+
+// DynamicToken is a token that can be replaced while connected, such as
+// when the server rotates it.
+type DynamicToken struct {
+	mu    sync.RWMutex
+	token string
+}
+
+// Get returns the current token.
+func (t *DynamicToken) Get() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.token
+}
+
+// Set replaces the token. Requests after this use the new token.
+func (t *DynamicToken) Set(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = token
+}
+
+// runnerTokenUnaryInterceptor returns a gRPC interceptor that sends the
+// runner token with calls to the runner endpoints if it is set and token
+// with all other calls.
+func runnerTokenUnaryInterceptor(token string, runnerToken *DynamicToken) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		opts = append(opts, grpc.PerRPCCredentials(methodToken(method, token, runnerToken)))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// runnerTokenStreamInterceptor is runnerTokenUnaryInterceptor for streams.
+func runnerTokenStreamInterceptor(token string, runnerToken *DynamicToken) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		opts = append(opts, grpc.PerRPCCredentials(methodToken(method, token, runnerToken)))
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// methodToken returns the token to send with a call to method.
+func methodToken(method, token string, runnerToken *DynamicToken) StaticToken {
+	if strings.HasPrefix(path.Base(method), "Runner") {
+		if v := runnerToken.Get(); v != "" {
+			return StaticToken(v)
+		}
+	}
+
+	return StaticToken(token)
+}
@@ -0,0 +1,157 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier reads and writes trace context in gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// extractIncoming returns ctx with the trace context sent by the client.
+func extractIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return propagation.ExtractHTTP(ctx, global.Propagators(), metadataCarrier(md))
+}
+
+// injectOutgoing returns ctx with the trace context added to the metadata
+// that is sent to the server.
+func injectOutgoing(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	propagation.InjectHTTP(withRemoteSpan(ctx), global.Propagators(), metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that creates a span
+// for every call, continuing the trace of the client.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := Tracer().Start(extractIncoming(ctx), info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		SetError(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that creates a span
+// for every stream, continuing the trace of the client.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, span := Tracer().Start(extractIncoming(ss.Context()), info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		SetError(span, err)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor returns a gRPC interceptor that creates a span
+// for every call and sends the trace context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		ctx, span := Tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		err := invoker(injectOutgoing(ctx), method, req, reply, cc, opts...)
+		SetError(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a gRPC interceptor that creates a span
+// for every stream and sends the trace context to the server. The span
+// ends when the stream does.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := Tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+
+		cs, err := streamer(injectOutgoing(ctx), desc, cc, method, opts...)
+		if err != nil {
+			SetError(span, err)
+			span.End()
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: cs, span: span}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+
+	span trace.Span
+	once sync.Once
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if err != io.EOF {
+				SetError(s.span, err)
+			}
+
+			s.span.End()
+		})
+	}
+
+	return err
+}
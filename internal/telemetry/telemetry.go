@@ -0,0 +1,179 @@
+// Package telemetry sets up OpenTelemetry tracing for the CLI, server and
+// runner. Trace context is propagated over gRPC and through queued jobs so
+// that one command produces a single trace across all three.
+package telemetry
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel/api/correlation"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint/internal/version"
+)
+
+const tracerName = "github.com/hashicorp/waypoint"
+
+// Trace context is always propagated, even if this process doesn't export
+// spans, so that the trace isn't broken between processes that do.
+func init() {
+	global.SetPropagators(propagation.New(
+		propagation.WithInjectors(trace.TraceContext{}, correlation.CorrelationContext{}),
+		propagation.WithExtractors(trace.TraceContext{}, correlation.CorrelationContext{}),
+	))
+}
+
+// Init sets up tracing for the named service, such as "waypoint-server".
+//
+// Spans are exported with OTLP over gRPC if OTEL_EXPORTER_OTLP_ENDPOINT is
+// set. This is either the address of the collector or a URL, in which case
+// an "http" scheme disables TLS. OTEL_EXPORTER_OTLP_INSECURE=true also
+// disables TLS. Otherwise spans aren't recorded, but incoming trace
+// context is still propagated.
+//
+// The returned function flushes any pending spans and must be called
+// before exiting.
+func Init(ctx context.Context, service string) (func(context.Context) error, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	insecure, _ := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_INSECURE"))
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		endpoint = u.Host
+		insecure = insecure || u.Scheme == "http"
+	}
+
+	opts := []otlp.ExporterOption{otlp.WithAddress(endpoint)}
+	if insecure {
+		opts = append(opts, otlp.WithInsecure())
+	}
+	exp, err := otlp.NewExporter(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	bsp, err := sdktrace.NewBatchSpanProcessor(exp)
+	if err != nil {
+		exp.Stop()
+		return nil, err
+	}
+
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithResource(resource.New(
+			kv.String("service.name", service),
+			kv.String("service.version", version.GetVersion().VersionNumber()),
+		)),
+	)
+	if err != nil {
+		bsp.Shutdown()
+		exp.Stop()
+		return nil, err
+	}
+	tp.RegisterSpanProcessor(bsp)
+	global.SetTraceProvider(tp)
+
+	return func(ctx context.Context) error {
+		// Shutdown doesn't take a context so we stop waiting for it if
+		// ctx is done.
+		doneCh := make(chan error, 1)
+		go func() {
+			bsp.Shutdown()
+			doneCh <- exp.Stop()
+		}()
+
+		select {
+		case err := <-doneCh:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, nil
+}
+
+// Tracer returns the tracer that Waypoint spans are created with.
+func Tracer() trace.Tracer {
+	return global.Tracer(tracerName)
+}
+
+// SetError marks the span as failed if err is non-nil. The status of the
+// span is the gRPC code of err, which is Unknown for other errors.
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(context.Background(), err,
+		trace.WithErrorStatus(status.Code(err)))
+}
+
+// mapCarrier reads and writes trace context in a map.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c mapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// InjectMap returns the trace context of ctx as a map so that it can be
+// stored, such as in a queued job. This returns nil if ctx has no trace.
+func InjectMap(ctx context.Context) map[string]string {
+	carrier := mapCarrier{}
+	propagation.InjectHTTP(withRemoteSpan(ctx), global.Propagators(), carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}
+
+// ExtractMap returns ctx with the trace context stored by InjectMap.
+func ExtractMap(ctx context.Context, m map[string]string) context.Context {
+	if len(m) == 0 {
+		return ctx
+	}
+
+	return propagation.ExtractHTTP(ctx, global.Propagators(), mapCarrier(m))
+}
+
+// withRemoteSpan returns ctx with a span for the remote span context of ctx
+// if ctx has no valid span. The no-op tracer used when we don't export
+// spans starts spans without a span context, so this is what keeps the
+// trace of the client going to the processes that do.
+func withRemoteSpan(ctx context.Context) context.Context {
+	if trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return ctx
+	}
+
+	sc := trace.RemoteSpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ctx
+	}
+
+	return trace.ContextWithSpan(ctx, remoteSpan{sc: sc})
+}
+
+// remoteSpan is a no-op span with the span context of a remote span.
+type remoteSpan struct {
+	trace.NoopSpan
+
+	sc trace.SpanContext
+}
+
+func (s remoteSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+	"google.golang.org/grpc/metadata"
+)
+
+func testSpanContext(t *testing.T) context.Context {
+	sc := trace.SpanContext{
+		TraceID:    trace.ID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	}
+	require.True(t, sc.IsValid())
+
+	ctx, _ := testtrace.NewTracer().Start(
+		trace.ContextWithRemoteSpanContext(context.Background(), sc), "test")
+	return ctx
+}
+
+func TestInjectMap(t *testing.T) {
+	require := require.New(t)
+
+	ctx := testSpanContext(t)
+	m := InjectMap(ctx)
+	require.NotEmpty(m["traceparent"])
+
+	sc := trace.RemoteSpanContextFromContext(ExtractMap(context.Background(), m))
+	require.Equal(trace.SpanFromContext(ctx).SpanContext().TraceID, sc.TraceID)
+
+	// No trace gives no map
+	require.Nil(InjectMap(context.Background()))
+}
+
+func TestMetadataPropagation(t *testing.T) {
+	require := require.New(t)
+
+	ctx := testSpanContext(t)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "token")
+	out := injectOutgoing(ctx)
+
+	md, ok := metadata.FromOutgoingContext(out)
+	require.True(ok)
+	require.Equal([]string{"token"}, md.Get("authorization"))
+	require.Len(md.Get("traceparent"), 1)
+
+	in := extractIncoming(metadata.NewIncomingContext(context.Background(), md))
+	require.Equal(
+		trace.SpanFromContext(ctx).SpanContext().TraceID,
+		trace.RemoteSpanContextFromContext(in).TraceID,
+	)
+}
@@ -33,11 +33,13 @@ export default [
       'build',
       'deploy',
       'hook',
+      'pipeline',
       'plugin',
       'registry',
       'release',
       'url',
       'use',
+      'variable',
     ],
   },
   {